@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/pem"
 	"errors"
 	"flag"
@@ -16,6 +17,7 @@ import (
 	"github.com/spiffe/go-spiffe/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/spiffe"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/x509util"
 )
 
 func NewFetchX509Command() cli.Command {
@@ -27,8 +29,9 @@ func newFetchX509Command(env *common_cli.Env, clientMaker workloadClientMaker) c
 }
 
 type fetchX509Command struct {
-	silent    bool
-	writePath string
+	silent          bool
+	writePath       string
+	pathPrefixesOID string
 }
 
 func (*fetchX509Command) name() string {
@@ -40,6 +43,11 @@ func (*fetchX509Command) synopsis() string {
 }
 
 func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client *workloadClient) error {
+	pathPrefixesOID, err := parsePathPrefixesOIDFlag(c.pathPrefixesOID)
+	if err != nil {
+		return err
+	}
+
 	start := time.Now()
 	resp, err := c.fetchX509SVID(ctx, client)
 	respTime := time.Since(start)
@@ -47,7 +55,7 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 		return err
 	}
 
-	svids, err := parseAndValidateX509SVIDResponse(resp)
+	svids, err := parseAndValidateX509SVIDResponse(resp, pathPrefixesOID)
 	if err != nil {
 		return err
 	}
@@ -68,6 +76,7 @@ func (c *fetchX509Command) run(ctx context.Context, env *common_cli.Env, client
 func (c *fetchX509Command) appendFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
 	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
+	fs.StringVar(&c.pathPrefixesOID, "pathPrefixesOID", "", "OID of the path prefixes extension of downstream CAs, as configured on the SPIRE servers. If set, SVIDs outside of the path prefixes are reported as invalid (optional)")
 }
 
 func (c *fetchX509Command) fetchX509SVID(ctx context.Context, client *workloadClient) (*workload.X509SVIDResponse, error) {
@@ -167,12 +176,19 @@ type X509SVID struct {
 	FederatedBundles map[string][]*x509.Certificate
 }
 
-func parseAndValidateX509SVIDResponse(resp *workload.X509SVIDResponse) ([]*X509SVID, error) {
+func parsePathPrefixesOIDFlag(s string) (asn1.ObjectIdentifier, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return x509util.ParsePathPrefixesOID(s)
+}
+
+func parseAndValidateX509SVIDResponse(resp *workload.X509SVIDResponse, pathPrefixesOID asn1.ObjectIdentifier) ([]*X509SVID, error) {
 	svids, err := parseX509SVIDResponse(resp)
 	if err != nil {
 		return nil, err
 	}
-	if err := validateX509SVIDs(svids); err != nil {
+	if err := validateX509SVIDs(svids, pathPrefixesOID); err != nil {
 		return nil, err
 	}
 	return svids, nil
@@ -249,16 +265,16 @@ func parseX509SVID(svid *workload.X509SVID, allFederatedBundles map[string][]*x5
 	}, nil
 }
 
-func validateX509SVIDs(svids []*X509SVID) error {
+func validateX509SVIDs(svids []*X509SVID, pathPrefixesOID asn1.ObjectIdentifier) error {
 	for _, svid := range svids {
-		if err := validateX509SVID(svid); err != nil {
+		if err := validateX509SVID(svid, pathPrefixesOID); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func validateX509SVID(svid *X509SVID) error {
+func validateX509SVID(svid *X509SVID, pathPrefixesOID asn1.ObjectIdentifier) error {
 	id, err := spiffe.ParseID(svid.SPIFFEID, spiffe.AllowAny())
 	if err != nil {
 		return fmt.Errorf("malformed SPIFFE ID %q: %v", svid.SPIFFEID, err)
@@ -269,11 +285,14 @@ func validateX509SVID(svid *X509SVID) error {
 	for _, cert := range svid.Bundle {
 		roots.AddCert(cert)
 	}
-	_, err = spiffe.VerifyPeerCertificate(svid.Certificates, map[string]*x509.CertPool{
+	chains, err := spiffe.VerifyPeerCertificate(svid.Certificates, map[string]*x509.CertPool{
 		trustDomainID: roots,
 	}, spiffe.ExpectPeerInDomain(id.Host))
 	if err != nil {
 		return fmt.Errorf("%q SVID failed verification against bundle: %v", svid.SPIFFEID, err)
 	}
+	if err := x509util.VerifyChainsPathPrefixes(pathPrefixesOID, chains); err != nil {
+		return fmt.Errorf("%q SVID failed verification against bundle: %v", svid.SPIFFEID, err)
+	}
 	return nil
 }
@@ -14,7 +14,8 @@ import (
 )
 
 type WatchConfig struct {
-	socketPath string
+	socketPath      string
+	pathPrefixesOID string
 }
 
 type WatchCLI struct {
@@ -39,6 +40,12 @@ func (w *WatchCLI) Run(args []string) int {
 		return 1
 	}
 
+	pathPrefixesOID, err := parsePathPrefixesOIDFlag(w.config.pathPrefixesOID)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
 	w.stopChan = make(chan struct{})
 	client, errChan := w.startClient()
 
@@ -53,7 +60,7 @@ func (w *WatchCLI) Run(args []string) int {
 			fmt.Fprintln(os.Stderr, err)
 			return 1
 		case u := <-client.UpdateChan():
-			svids, err := parseAndValidateX509SVIDResponse(u)
+			svids, err := parseAndValidateX509SVIDResponse(u, pathPrefixesOID)
 			if err == nil {
 				printX509SVIDResponse(svids, time.Since(updateTime))
 			} else {
@@ -68,6 +75,7 @@ func (w *WatchCLI) parseConfig(args []string) error {
 	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
 	c := &WatchConfig{}
 	fs.StringVar(&c.socketPath, "socketPath", "/tmp/agent.sock", "Path to the Workload API socket")
+	fs.StringVar(&c.pathPrefixesOID, "pathPrefixesOID", "", "OID of the path prefixes extension of downstream CAs, as configured on the SPIRE servers. If set, SVIDs outside of the path prefixes are reported as invalid (optional)")
 
 	w.config = c
 	return fs.Parse(args)
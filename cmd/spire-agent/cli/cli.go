@@ -5,6 +5,7 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/api"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/debug"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/validate"
@@ -35,6 +36,21 @@ func (cc *CLI) Run(args []string) int {
 		"api watch": func() (cli.Command, error) {
 			return &api.WatchCLI{}, nil
 		},
+		"debug attest": func() (cli.Command, error) {
+			return debug.NewAttestCommand(), nil
+		},
+		"debug entries": func() (cli.Command, error) {
+			return debug.NewEntriesCommand(), nil
+		},
+		"debug info": func() (cli.Command, error) {
+			return debug.NewInfoCommand(), nil
+		},
+		"debug rotate": func() (cli.Command, error) {
+			return debug.NewRotateCommand(), nil
+		},
+		"debug sync": func() (cli.Command, error) {
+			return debug.NewSyncCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return &run.Command{LogOptions: cc.LogOptions}, nil
 		},
@@ -0,0 +1,75 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func NewAttestCommand() cli.Command {
+	return newAttestCommand(common_cli.DefaultEnv, newAdminClient)
+}
+
+func newAttestCommand(env *common_cli.Env, clientMaker adminClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(attestCommand))
+}
+
+type attestCommand struct {
+	pid int
+}
+
+func (*attestCommand) name() string {
+	return "debug attest"
+}
+
+func (*attestCommand) synopsis() string {
+	return "Attests a process and shows its selectors and the cached entries it matches"
+}
+
+func (c *attestCommand) appendFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.pid, "pid", 0, "Process ID of the workload to attest")
+}
+
+func (c *attestCommand) run(ctx context.Context, env *common_cli.Env, client admin.AdminClient) error {
+	if c.pid <= 0 {
+		return errors.New("a positive pid is required")
+	}
+
+	resp, err := client.DebugAttest(ctx, &admin.DebugAttestRequest{
+		Pid: int32(c.pid),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to attest pid %d: %v", c.pid, err)
+	}
+
+	if err := env.Printf("Found %d %s for pid %d\n", len(resp.Selectors), pluralize(len(resp.Selectors), "selector", "selectors"), c.pid); err != nil {
+		return err
+	}
+	for _, s := range resp.Selectors {
+		if err := env.Printf("Selector      : %s:%s\n", s.Type, s.Value); err != nil {
+			return err
+		}
+	}
+	if err := env.Println(); err != nil {
+		return err
+	}
+
+	if len(resp.MatchingEntries) == 0 {
+		return env.Println("No cached entry matches the selectors; the workload will not be issued an SVID")
+	}
+
+	if err := env.Printf("Found %d matching %s\n\n", len(resp.MatchingEntries), pluralize(len(resp.MatchingEntries), "entry", "entries")); err != nil {
+		return err
+	}
+	for _, entry := range resp.MatchingEntries {
+		if err := printCachedEntry(env, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
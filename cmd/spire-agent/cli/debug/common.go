@@ -0,0 +1,97 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"net"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+	"google.golang.org/grpc"
+)
+
+type adminClientMaker func(socketPath string) (admin.AdminClient, error)
+
+// newAdminClient is the default client maker
+func newAdminClient(socketPath string) (admin.AdminClient, error) {
+	conn, err := grpc.Dial(socketPath, grpc.WithInsecure(), grpc.WithDialer(dialer)) //nolint: staticcheck
+	if err != nil {
+		return nil, err
+	}
+	return admin.NewAdminClient(conn), nil
+}
+
+func dialer(addr string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("unix", addr, timeout)
+}
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *cli.Env, admin.AdminClient) error
+}
+
+type adapter struct {
+	env         *cli.Env
+	clientMaker adminClientMaker
+	cmd         command
+
+	socketPath string
+	timeout    cli.DurationFlag
+	flags      *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *cli.Env, clientMaker adminClientMaker, cmd command) *adapter {
+	a := &adapter{
+		clientMaker: clientMaker,
+		cmd:         cmd,
+		env:         env,
+		timeout:     cli.DurationFlag(30 * time.Second),
+	}
+
+	fs := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.StringVar(&a.socketPath, "adminSocketPath", common.DefaultAdminSocketPath, "Path to the agent admin API socket")
+	fs.Var(&a.timeout, "timeout", "Time to wait for a response")
+	a.cmd.appendFlags(fs)
+	a.flags = fs
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	if err := a.flags.Parse(args); err != nil {
+		return 1
+	}
+
+	client, err := a.clientMaker(a.env.JoinPath(a.socketPath))
+	if err != nil {
+		_ = a.env.ErrPrintf("error: unable to connect to the agent admin API: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.timeout))
+	defer cancel()
+
+	if err := a.cmd.run(ctx, a.env, client); err != nil {
+		_ = a.env.ErrPrintf("error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	_ = a.flags.Parse([]string{"-h"})
+	return ""
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
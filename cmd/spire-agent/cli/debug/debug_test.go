@@ -0,0 +1,258 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	expiresAt = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	fooEntry = &admin.CachedEntry{
+		Entry: &common.RegistrationEntry{
+			EntryId:   "FOO",
+			SpiffeId:  "spiffe://example.org/foo",
+			ParentId:  "spiffe://example.org/spire/agent/test",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		SvidChain:     []byte{1},
+		SvidExpiresAt: expiresAt,
+	}
+	barEntry = &admin.CachedEntry{
+		Entry: &common.RegistrationEntry{
+			EntryId:       "BAR",
+			SpiffeId:      "spiffe://example.org/bar",
+			ParentId:      "spiffe://example.org/spire/agent/test",
+			Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+			FederatesWith: []string{"spiffe://otherdomain.test"},
+		},
+	}
+)
+
+func TestEntries(t *testing.T) {
+	api := &fakeAdminAPI{
+		entries: []*admin.CachedEntry{barEntry, fooEntry},
+	}
+
+	stdout, stderr, code := runCommand(t, api, newEntriesCommand)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `Found 2 cached entries
+
+Entry ID      : BAR
+SPIFFE ID     : spiffe://example.org/bar
+Parent ID     : spiffe://example.org/spire/agent/test
+Selector      : unix:uid:1001
+FederatesWith : spiffe://otherdomain.test
+SVID          : not signed yet
+
+Entry ID      : FOO
+SPIFFE ID     : spiffe://example.org/foo
+Parent ID     : spiffe://example.org/spire/agent/test
+Selector      : unix:uid:1000
+SVID expires  : 2030-01-01T00:00:00Z
+
+`, stdout)
+}
+
+func TestInfo(t *testing.T) {
+	api := &fakeAdminAPI{
+		info: &admin.GetAgentInfoResponse{
+			SpiffeId:      "spiffe://example.org/spire/agent/test",
+			SvidExpiresAt: expiresAt,
+			Bundle: &common.Bundle{
+				TrustDomainId: "spiffe://example.org",
+				RootCas:       []*common.Certificate{{DerBytes: []byte{1}}},
+			},
+			LastSyncSuccess: expiresAt - 7200,
+			LastSyncError:   "ohno",
+			LastSyncErrorAt: expiresAt - 3600,
+		},
+	}
+
+	stdout, stderr, code := runCommand(t, api, newInfoCommand)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `SPIFFE ID         : spiffe://example.org/spire/agent/test
+SVID expires      : 2030-01-01T00:00:00Z
+Trust domain      : spiffe://example.org
+Root CAs          : 1
+JWT signing keys  : 0
+Last sync         : 2029-12-31T22:00:00Z
+Last sync error   : ohno (2029-12-31T23:00:00Z)
+`, stdout)
+}
+
+func TestAttest(t *testing.T) {
+	api := &fakeAdminAPI{
+		attest: map[int32]*admin.DebugAttestResponse{
+			1000: {
+				Selectors:       fooEntry.Entry.Selectors,
+				MatchingEntries: []*admin.CachedEntry{fooEntry},
+			},
+		},
+	}
+
+	stdout, stderr, code := runCommand(t, api, newAttestCommand, "-pid", "1000")
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `Found 1 selector for pid 1000
+Selector      : unix:uid:1000
+
+Found 1 matching entry
+
+Entry ID      : FOO
+SPIFFE ID     : spiffe://example.org/foo
+Parent ID     : spiffe://example.org/spire/agent/test
+Selector      : unix:uid:1000
+SVID expires  : 2030-01-01T00:00:00Z
+
+`, stdout)
+}
+
+func TestAttestWithoutMatches(t *testing.T) {
+	api := &fakeAdminAPI{}
+
+	stdout, stderr, code := runCommand(t, api, newAttestCommand, "-pid", "2000")
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, `Found 0 selectors for pid 2000
+
+No cached entry matches the selectors; the workload will not be issued an SVID
+`, stdout)
+}
+
+func TestAttestRequiresPID(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newAttestCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, newAdminClient)
+
+	// The pid is validated before the admin API is contacted
+	assert.Equal(t, 1, cmd.Run([]string{"-adminSocketPath", "/does/not/exist.sock"}))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "error: a positive pid is required\n", stderr.String())
+}
+
+func TestSync(t *testing.T) {
+	api := &fakeAdminAPI{
+		entries: []*admin.CachedEntry{fooEntry},
+	}
+
+	stdout, stderr, code := runCommand(t, api, newSyncCommand)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "Synced with server, 1 entry cached\n", stdout)
+}
+
+func TestSyncFails(t *testing.T) {
+	api := &fakeAdminAPI{
+		err: status.Error(codes.Unavailable, "server is down"),
+	}
+
+	stdout, stderr, code := runCommand(t, api, newSyncCommand)
+	assert.Equal(t, 1, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "error: unable to sync: rpc error: code = Unavailable desc = server is down\n", stderr)
+}
+
+func TestRotate(t *testing.T) {
+	stdout, stderr, code := runCommand(t, &fakeAdminAPI{}, newRotateCommand)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "Rotated agent SVID, expires 2030-01-01T00:00:00Z\n", stdout)
+}
+
+func runCommand(t *testing.T, api admin.AdminServer, newCommand func(*common_cli.Env, adminClientMaker) cli.Command, args ...string) (string, string, int) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "admin.sock")
+	closeServer := spiretest.StartGRPCSocketServer(t, socketPath, func(s *grpc.Server) {
+		admin.RegisterAdminServer(s, api)
+	})
+	defer closeServer()
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	}, newAdminClient)
+
+	code := cmd.Run(append([]string{"-adminSocketPath", socketPath}, args...))
+	return stdout.String(), stderr.String(), code
+}
+
+type fakeAdminAPI struct {
+	entries []*admin.CachedEntry
+	info    *admin.GetAgentInfoResponse
+	attest  map[int32]*admin.DebugAttestResponse
+	err     error
+}
+
+func (a *fakeAdminAPI) ListCachedEntries(context.Context, *admin.ListCachedEntriesRequest) (*admin.ListCachedEntriesResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &admin.ListCachedEntriesResponse{Entries: a.entries}, nil
+}
+
+func (a *fakeAdminAPI) GetAgentInfo(context.Context, *admin.GetAgentInfoRequest) (*admin.GetAgentInfoResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if a.info == nil {
+		return nil, errors.New("no info")
+	}
+	return a.info, nil
+}
+
+func (a *fakeAdminAPI) DebugAttest(ctx context.Context, req *admin.DebugAttestRequest) (*admin.DebugAttestResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if resp, ok := a.attest[req.Pid]; ok {
+		return resp, nil
+	}
+	return &admin.DebugAttestResponse{}, nil
+}
+
+func (a *fakeAdminAPI) Sync(context.Context, *admin.SyncRequest) (*admin.SyncResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &admin.SyncResponse{Entries: int32(len(a.entries))}, nil
+}
+
+func (a *fakeAdminAPI) RotateSVID(context.Context, *admin.RotateSVIDRequest) (*admin.RotateSVIDResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &admin.RotateSVIDResponse{
+		SvidChain:     []byte{1},
+		SvidExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func NewEntriesCommand() cli.Command {
+	return newEntriesCommand(common_cli.DefaultEnv, newAdminClient)
+}
+
+func newEntriesCommand(env *common_cli.Env, clientMaker adminClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(entriesCommand))
+}
+
+type entriesCommand struct{}
+
+func (*entriesCommand) name() string {
+	return "debug entries"
+}
+
+func (*entriesCommand) synopsis() string {
+	return "Lists the registration entries and SVIDs cached by the agent"
+}
+
+func (*entriesCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (*entriesCommand) run(ctx context.Context, env *common_cli.Env, client admin.AdminClient) error {
+	resp, err := client.ListCachedEntries(ctx, &admin.ListCachedEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list cached entries: %v", err)
+	}
+
+	if err := env.Printf("Found %d cached %s\n\n", len(resp.Entries), pluralize(len(resp.Entries), "entry", "entries")); err != nil {
+		return err
+	}
+	for _, entry := range resp.Entries {
+		if err := printCachedEntry(env, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
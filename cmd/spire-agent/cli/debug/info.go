@@ -0,0 +1,71 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func NewInfoCommand() cli.Command {
+	return newInfoCommand(common_cli.DefaultEnv, newAdminClient)
+}
+
+func newInfoCommand(env *common_cli.Env, clientMaker adminClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(infoCommand))
+}
+
+type infoCommand struct{}
+
+func (*infoCommand) name() string {
+	return "debug info"
+}
+
+func (*infoCommand) synopsis() string {
+	return "Shows the agent SVID, trust bundle and server sync status"
+}
+
+func (*infoCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (*infoCommand) run(ctx context.Context, env *common_cli.Env, client admin.AdminClient) error {
+	resp, err := client.GetAgentInfo(ctx, &admin.GetAgentInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to get agent info: %v", err)
+	}
+
+	if err := env.Printf("SPIFFE ID         : %s\n", resp.SpiffeId); err != nil {
+		return err
+	}
+	if err := env.Printf("SVID expires      : %s\n", formatTime(resp.SvidExpiresAt)); err != nil {
+		return err
+	}
+	if resp.Bundle != nil {
+		if err := env.Printf("Trust domain      : %s\n", resp.Bundle.TrustDomainId); err != nil {
+			return err
+		}
+		if err := env.Printf("Root CAs          : %d\n", len(resp.Bundle.RootCas)); err != nil {
+			return err
+		}
+		if err := env.Printf("JWT signing keys  : %d\n", len(resp.Bundle.JwtSigningKeys)); err != nil {
+			return err
+		}
+	}
+
+	lastSync := "never"
+	if resp.LastSyncSuccess != 0 {
+		lastSync = formatTime(resp.LastSyncSuccess)
+	}
+	if err := env.Printf("Last sync         : %s\n", lastSync); err != nil {
+		return err
+	}
+	if resp.LastSyncError != "" && resp.LastSyncErrorAt > resp.LastSyncSuccess {
+		if err := env.Printf("Last sync error   : %s (%s)\n", resp.LastSyncError, formatTime(resp.LastSyncErrorAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package debug
+
+import (
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func printCachedEntry(env *cli.Env, e *admin.CachedEntry) error {
+	entry := e.Entry
+	if err := env.Printf("Entry ID      : %s\n", entry.EntryId); err != nil {
+		return err
+	}
+	if err := env.Printf("SPIFFE ID     : %s\n", entry.SpiffeId); err != nil {
+		return err
+	}
+	if err := env.Printf("Parent ID     : %s\n", entry.ParentId); err != nil {
+		return err
+	}
+	for _, s := range entry.Selectors {
+		if err := env.Printf("Selector      : %s:%s\n", s.Type, s.Value); err != nil {
+			return err
+		}
+	}
+	for _, id := range entry.FederatesWith {
+		if err := env.Printf("FederatesWith : %s\n", id); err != nil {
+			return err
+		}
+	}
+	if e.SvidExpiresAt == 0 {
+		if err := env.Println("SVID          : not signed yet"); err != nil {
+			return err
+		}
+	} else if err := env.Printf("SVID expires  : %s\n", formatTime(e.SvidExpiresAt)); err != nil {
+		return err
+	}
+	return env.Println()
+}
+
+func formatTime(seconds int64) string {
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func NewRotateCommand() cli.Command {
+	return newRotateCommand(common_cli.DefaultEnv, newAdminClient)
+}
+
+func newRotateCommand(env *common_cli.Env, clientMaker adminClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(rotateCommand))
+}
+
+type rotateCommand struct{}
+
+func (*rotateCommand) name() string {
+	return "debug rotate"
+}
+
+func (*rotateCommand) synopsis() string {
+	return "Rotates the agent SVID immediately"
+}
+
+func (*rotateCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (*rotateCommand) run(ctx context.Context, env *common_cli.Env, client admin.AdminClient) error {
+	resp, err := client.RotateSVID(ctx, &admin.RotateSVIDRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to rotate agent SVID: %v", err)
+	}
+
+	return env.Printf("Rotated agent SVID, expires %s\n", formatTime(resp.SvidExpiresAt))
+}
@@ -0,0 +1,41 @@
+package debug
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+)
+
+func NewSyncCommand() cli.Command {
+	return newSyncCommand(common_cli.DefaultEnv, newAdminClient)
+}
+
+func newSyncCommand(env *common_cli.Env, clientMaker adminClientMaker) cli.Command {
+	return adaptCommand(env, clientMaker, new(syncCommand))
+}
+
+type syncCommand struct{}
+
+func (*syncCommand) name() string {
+	return "debug sync"
+}
+
+func (*syncCommand) synopsis() string {
+	return "Syncs registration entries and SVIDs with the server immediately"
+}
+
+func (*syncCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (*syncCommand) run(ctx context.Context, env *common_cli.Env, client admin.AdminClient) error {
+	resp, err := client.Sync(ctx, &admin.SyncRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to sync: %v", err)
+	}
+
+	return env.Printf("Synced with server, %d %s cached\n", resp.Entries, pluralize(int(resp.Entries), "entry", "entries"))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 )
 
 const (
@@ -46,6 +47,7 @@ type Config struct {
 }
 
 type agentConfig struct {
+	AdminSocketPath   string `hcl:"admin_socket_path"`
 	DataDir           string `hcl:"data_dir"`
 	EnableSDS         bool   `hcl:"enable_sds"`
 	InsecureBootstrap bool   `hcl:"insecure_bootstrap"`
@@ -53,6 +55,8 @@ type agentConfig struct {
 	LogFile           string `hcl:"log_file"`
 	LogFormat         string `hcl:"log_format"`
 	LogLevel          string `hcl:"log_level"`
+	PathPrefixesOID   string `hcl:"path_prefixes_oid"`
+	ReattestInterval  string `hcl:"reattest_interval"`
 	ServerAddress     string `hcl:"server_address"`
 	ServerPort        int    `hcl:"server_port"`
 	SocketPath        string `hcl:"socket_path"`
@@ -73,7 +77,12 @@ type agentConfig struct {
 }
 
 type experimentalConfig struct {
-	SyncInterval string `hcl:"sync_interval"`
+	SyncInterval        string `hcl:"sync_interval"`
+	DelegatedJWTSigning bool   `hcl:"delegated_jwt_signing"`
+	JWTIssuer           string `hcl:"jwt_issuer"`
+	JWTIncludeJTI       bool   `hcl:"jwt_include_jti"`
+
+	JWTSVIDReplayProtection bool `hcl:"jwt_svid_replay_protection"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -88,19 +97,7 @@ func (*Command) Help() string {
 }
 
 func (cmd *Command) Run(args []string) int {
-	cliInput, err := parseFlags(args)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return 1
-	}
-
-	fileInput, err := ParseFile(cliInput.ConfigPath, cliInput.ExpandEnv)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		return 1
-	}
-
-	input, err := mergeInput(fileInput, cliInput)
+	input, err := loadInput(args)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
@@ -122,6 +119,13 @@ func (cmd *Command) Run(args []string) int {
 		}
 	}
 
+	if c.AdminBindAddress != nil {
+		if err := prepareAdminUDSDir(filepath.Dir(c.AdminBindAddress.String()), c.Log); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+
 	// Set umask before starting up the agent
 	cli.SetUmask(c.Log)
 
@@ -130,6 +134,12 @@ func (cmd *Command) Run(args []string) int {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	util.SignalListener(ctx, cancel)
+	util.HangupListener(ctx, func() {
+		c.Log.Info("Received SIGHUP; reloading plugin configuration")
+		if err := reloadPlugins(ctx, a, args); err != nil {
+			c.Log.WithError(err).Error("Failed to reload plugin configuration")
+		}
+	})
 
 	err = a.Run(ctx)
 	if err != nil {
@@ -145,6 +155,61 @@ func (*Command) Synopsis() string {
 	return "Runs the agent"
 }
 
+// loadInput parses the CLI flags and the config file, and merges them
+func loadInput(args []string) (*Config, error) {
+	cliInput, err := parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInput, err := ParseFile(cliInput.ConfigPath, cliInput.ExpandEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeInput(fileInput, cliInput)
+}
+
+// reloadPlugins loads the configuration again and reconfigures the agent
+// plugins whose configuration changed.
+func reloadPlugins(ctx context.Context, a *agent.Agent, args []string) error {
+	input, err := loadInput(args)
+	if err != nil {
+		return err
+	}
+
+	if err := validateConfig(input); err != nil {
+		return err
+	}
+
+	td, err := idutil.ParseSpiffeID("spiffe://"+input.Agent.TrustDomain, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return fmt.Errorf("could not parse trust_domain %q: %v", input.Agent.TrustDomain, err)
+	}
+
+	return a.ReloadPlugins(ctx, *td, *input.Plugins)
+}
+
+// prepareAdminUDSDir creates the admin uds dir, only accessible by the agent
+// user, if it does not exist. An existing dir must not be accessible by other
+// users, since the socket is reachable through it before its permissions are
+// restricted.
+func prepareAdminUDSDir(dir string, log logrus.FieldLogger) error {
+	info, err := os.Stat(dir)
+	switch {
+	case os.IsNotExist(err):
+		log.WithField("dir", dir).Infof("Creating spire agent admin UDS directory")
+		return os.MkdirAll(dir, 0700)
+	case err != nil:
+		return err
+	case !info.IsDir():
+		return fmt.Errorf("admin UDS directory %q is not a directory", dir)
+	case info.Mode().Perm()&0077 != 0:
+		return fmt.Errorf("admin UDS directory %q must only be accessible by the agent user (mode 0700) but has mode %#o", dir, info.Mode().Perm())
+	}
+	return nil
+}
+
 func ParseFile(path string, expandEnv bool) (*Config, error) {
 	c := &Config{}
 
@@ -244,6 +309,27 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		}
 	}
 
+	if c.Agent.ReattestInterval != "" {
+		var err error
+		ac.ReattestInterval, err = time.ParseDuration(c.Agent.ReattestInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse re-attestation interval: %v", err)
+		}
+	}
+
+	ac.DelegatedJWTSigning = c.Agent.Experimental.DelegatedJWTSigning
+	ac.JWTIssuer = c.Agent.Experimental.JWTIssuer
+	ac.JWTIncludeJTI = c.Agent.Experimental.JWTIncludeJTI
+	ac.JWTSVIDReplayProtection = c.Agent.Experimental.JWTSVIDReplayProtection
+
+	if c.Agent.PathPrefixesOID != "" {
+		var err error
+		ac.PathPrefixesOID, err = x509util.ParsePathPrefixesOID(c.Agent.PathPrefixesOID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	serverHostPort := net.JoinHostPort(c.Agent.ServerAddress, strconv.Itoa(c.Agent.ServerPort))
 	ac.ServerAddress = fmt.Sprintf("dns:///%s", serverHostPort)
 
@@ -268,6 +354,13 @@ func NewAgentConfig(c *Config, logOptions []log.Option) (*agent.Config, error) {
 		Net:  "unix",
 	}
 
+	if c.Agent.AdminSocketPath != "" {
+		ac.AdminBindAddress = &net.UnixAddr{
+			Name: c.Agent.AdminSocketPath,
+			Net:  "unix",
+		}
+	}
+
 	ac.JoinToken = c.Agent.JoinToken
 	ac.DataDir = c.Agent.DataDir
 	ac.EnableSDS = c.Agent.EnableSDS
@@ -327,6 +420,22 @@ func validateConfig(c *Config) error {
 		return errors.New("plugins section must be configured")
 	}
 
+	if c.Agent.AdminSocketPath != "" {
+		// The workload API socket directory is commonly shared with
+		// workloads, so the admin socket must live somewhere else
+		adminDir, err := filepath.Abs(filepath.Dir(c.Agent.AdminSocketPath))
+		if err != nil {
+			return fmt.Errorf("could not resolve admin_socket_path: %v", err)
+		}
+		socketDir, err := filepath.Abs(filepath.Dir(c.Agent.SocketPath))
+		if err != nil {
+			return fmt.Errorf("could not resolve socket_path: %v", err)
+		}
+		if adminDir == socketDir {
+			return errors.New("admin_socket_path must not be in the same directory as socket_path")
+		}
+	}
+
 	return nil
 }
 
@@ -372,6 +481,10 @@ func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
 		l.Warnf("Detected unknown InMem config options: %q; this will be fatal in a future release.", p.UnusedKeys)
 	}
 
+	if p := c.Telemetry.Tracing; p != nil && len(p.UnusedKeys) != 0 {
+		l.Warnf("Detected unknown Tracing config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+	}
+
 	if len(c.HealthChecks.UnusedKeys) != 0 {
 		l.Warnf("Detected unknown health check config options: %q; this will be fatal in a future release.", c.HealthChecks.UnusedKeys)
 	}
@@ -2,11 +2,13 @@ package run
 
 import (
 	"bytes"
+	"encoding/asn1"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
 	"github.com/sirupsen/logrus"
@@ -561,6 +563,35 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Equal(t, "unix", c.BindAddress.Net)
 			},
 		},
+		{
+			msg: "admin_socket_path should be correctly configured",
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/run/spire/sockets/agent.sock"
+				c.Agent.AdminSocketPath = "/run/spire/admin/admin.sock"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, "/run/spire/admin/admin.sock", c.AdminBindAddress.Name)
+				require.Equal(t, "unix", c.AdminBindAddress.Net)
+			},
+		},
+		{
+			msg:   "admin_socket_path should be unset by default",
+			input: func(c *Config) {},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c.AdminBindAddress)
+			},
+		},
+		{
+			msg: "admin_socket_path in the same directory as socket_path returns an error",
+			input: func(c *Config) {
+				c.Agent.SocketPath = "/run/spire/sockets/agent.sock"
+				c.Agent.AdminSocketPath = "/run/spire/sockets/admin.sock"
+			},
+			expectError: true,
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "insecure_bootsrap should be correctly set to false",
 			input: func(c *Config) {
@@ -673,6 +704,66 @@ func TestNewAgentConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "reattest_interval parses a duration",
+			input: func(c *Config) {
+				c.Agent.ReattestInterval = "1h"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, time.Hour, c.ReattestInterval)
+			},
+		},
+		{
+			msg:         "invalid reattest_interval returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.ReattestInterval = "moo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "delegated_jwt_signing and jwt_issuer are configured correctly",
+			input: func(c *Config) {
+				c.Agent.Experimental.DelegatedJWTSigning = true
+				c.Agent.Experimental.JWTIssuer = "https://example.org"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.DelegatedJWTSigning)
+				require.Equal(t, "https://example.org", c.JWTIssuer)
+			},
+		},
+		{
+			msg: "jwt_include_jti and jwt_svid_replay_protection are configured correctly",
+			input: func(c *Config) {
+				c.Agent.Experimental.JWTIncludeJTI = true
+				c.Agent.Experimental.JWTSVIDReplayProtection = true
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.True(t, c.JWTIncludeJTI)
+				require.True(t, c.JWTSVIDReplayProtection)
+			},
+		},
+		{
+			msg: "path_prefixes_oid is configured correctly",
+			input: func(c *Config) {
+				c.Agent.PathPrefixesOID = "1.3.6.1.4.1.99999.1"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Equal(t, asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, c.PathPrefixesOID)
+			},
+		},
+		{
+			msg:         "invalid path_prefixes_oid returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Agent.PathPrefixesOID = "1.3.foo"
+			},
+			test: func(t *testing.T, c *agent.Config) {
+				require.Nil(t, c)
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -761,6 +852,11 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_InMem_block.conf", testFileDir),
 			expectedLogMsg: "Detected unknown InMem config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
 		},
+		{
+			msg:            "in nested Tracing block",
+			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_Tracing_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown Tracing config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
 		{
 			msg:            "in nested health_checks block",
 			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_health_checks_block.conf", testFileDir),
@@ -837,3 +933,33 @@ func TestExpandEnv(t *testing.T) {
 		assert.Equal(t, testCase.expectedValue, c.Agent.TrustDomain)
 	}
 }
+
+func TestPrepareAdminUDSDir(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "spire-agent-admin")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	log, _ := test.NewNullLogger()
+
+	// created, accessible only by the agent user, when missing
+	dir := path.Join(tmpDir, "created", "admin")
+	require.NoError(t, prepareAdminUDSDir(dir, log))
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	require.True(t, info.IsDir())
+	require.Zero(t, info.Mode().Perm()&0077)
+
+	// an existing dir accessible only by the agent user is accepted
+	require.NoError(t, prepareAdminUDSDir(dir, log))
+
+	// an existing dir accessible by other users is rejected
+	require.NoError(t, os.Chmod(dir, 0755))
+	require.EqualError(t, prepareAdminUDSDir(dir, log),
+		fmt.Sprintf("admin UDS directory %q must only be accessible by the agent user (mode 0700) but has mode 0755", dir))
+
+	// a file is rejected
+	file := path.Join(tmpDir, "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0600))
+	require.EqualError(t, prepareAdminUDSDir(file, log),
+		fmt.Sprintf("admin UDS directory %q is not a directory", file))
+}
@@ -0,0 +1,175 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+
+	"golang.org/x/net/context"
+)
+
+//BanConfig holds configuration for BanCLI
+type BanConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeID of the agent being banned
+	SpiffeID string
+	// Selector of the agents being banned, formatted as type:value
+	Selector string
+	// Reason the agents are banned
+	Reason string
+	// TTL of the ban, in seconds. Zero means the ban never expires.
+	TTL int
+
+	selector *common.Selector
+}
+
+// Validate will perform a basic validation on config fields
+func (c *BanConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	c.selector, err = validateBanTarget(&c.SpiffeID, c.Selector)
+	if err != nil {
+		return err
+	}
+
+	if c.TTL < 0 {
+		return errors.New("a positive TTL is required")
+	}
+
+	return nil
+}
+
+//BanCLI command for banning agents
+type BanCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (BanCLI) Synopsis() string {
+	return "Evicts agents and prevents them from attesting again"
+}
+
+func (c BanCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will ban the agents matching the given SPIFFE ID or selector
+func (c BanCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	banResponse, err := c.registrationClient.BanAgent(ctx, &registration.BanAgentRequest{
+		SpiffeId: config.SpiffeID,
+		Selector: config.selector,
+		Reason:   config.Reason,
+		Ttl:      int32(config.TTL),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error banning agent: %v \n", err)
+		return 1
+	}
+
+	fmt.Println("Agent banned successfully")
+	fmt.Println()
+	printAgentBan(banResponse.Ban)
+
+	msg := fmt.Sprintf("Evicted %d ", len(banResponse.EvictedNodes))
+	msg = util.Pluralizer(msg, "agent", "agents", len(banResponse.EvictedNodes))
+	fmt.Println(msg)
+	for _, node := range banResponse.EvictedNodes {
+		fmt.Printf("  %s\n", node.SpiffeId)
+	}
+	return 0
+}
+
+func (BanCLI) parseConfig(args []string) (*BanConfig, error) {
+	f := flag.NewFlagSet("agent ban", flag.ContinueOnError)
+	c := &BanConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to ban (agent identity)")
+	f.StringVar(&c.Selector, "selector", "", "An attestation selector of the agents to ban, formatted as type:value")
+	f.StringVar(&c.Reason, "reason", "", "The reason the agents are banned")
+	f.IntVar(&c.TTL, "ttl", 0, "The TTL of the ban in seconds. The ban never expires if unset")
+
+	return c, f.Parse(args)
+}
+
+// validateBanTarget makes sure exactly one of the SPIFFE ID or the selector
+// is set, normalizing the SPIFFE ID and parsing the selector
+func validateBanTarget(spiffeID *string, selector string) (*common.Selector, error) {
+	switch {
+	case *spiffeID == "" && selector == "":
+		return nil, errors.New("a SPIFFE ID or a selector is required")
+	case *spiffeID != "" && selector != "":
+		return nil, errors.New("a SPIFFE ID and a selector cannot be used together")
+	case selector != "":
+		return parseSelector(selector)
+	}
+
+	// make sure SPIFFE ID is well formed
+	id, err := idutil.NormalizeSpiffeID(*spiffeID, idutil.AllowAnyTrustDomainAgent())
+	if err != nil {
+		return nil, err
+	}
+	*spiffeID = id
+	return nil, nil
+}
+
+// parseSelector parses a selector formatted as type:value
+func parseSelector(selector string) (*common.Selector, error) {
+	parts := strings.SplitN(selector, ":", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("selector %q must be formatted as type:value", selector)
+	}
+	return &common.Selector{
+		Type:  parts[0],
+		Value: parts[1],
+	}, nil
+}
+
+func printAgentBan(ban *common.AgentBan) {
+	if ban.Selector != nil {
+		fmt.Printf("Selector          : %s:%s\n", ban.Selector.Type, ban.Selector.Value)
+	} else {
+		fmt.Printf("Spiffe ID         : %s\n", ban.SpiffeId)
+	}
+	if ban.Reason != "" {
+		fmt.Printf("Reason            : %s\n", ban.Reason)
+	}
+	fmt.Printf("Created at        : %s\n", time.Unix(ban.CreatedAt, 0))
+	if ban.ExpiresAt != 0 {
+		fmt.Printf("Expiration time   : %s\n", time.Unix(ban.ExpiresAt, 0))
+	} else {
+		fmt.Printf("Expiration time   : never\n")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type BanTestSuite struct {
+	suite.Suite
+	cli        *BanCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *BanTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &BanCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *BanTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestBanTestSuite(t *testing.T) {
+	suite.Run(t, new(BanTestSuite))
+}
+
+func (s *BanTestSuite) TestRunWithSpiffeID() {
+	spiffeIDToBan := "spiffe://example.org/spire/agent/aws_iid/123456789012/us-east-1/i-0123456789abcdef0"
+	args := []string{"-spiffeID", spiffeIDToBan, "-reason", "compromised", "-ttl", "3600"}
+
+	req := &registration.BanAgentRequest{
+		SpiffeId: spiffeIDToBan,
+		Reason:   "compromised",
+		Ttl:      3600,
+	}
+	resp := &registration.BanAgentResponse{
+		Ban:          &common.AgentBan{SpiffeId: spiffeIDToBan, Reason: "compromised"},
+		EvictedNodes: []*common.AttestedNode{{SpiffeId: spiffeIDToBan}},
+	}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunWithSelector() {
+	args := []string{"-selector", "x509pop:subject:cn:node1"}
+
+	req := &registration.BanAgentRequest{
+		Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"},
+	}
+	resp := &registration.BanAgentResponse{
+		Ban: &common.AgentBan{Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"}},
+	}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	spiffeIDToBan := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToBan}
+
+	req := &registration.BanAgentRequest{
+		SpiffeId: spiffeIDToBan,
+	}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunValidatesArguments() {
+	for _, args := range [][]string{
+		{},
+		{"-spiffeID", "not//an//spiffe/id"},
+		{"-spiffeID", "spiffe://example.org/workload"},
+		{"-selector", "novalue"},
+		{"-spiffeID", "spiffe://example.org/spire/agent/join_token/token_a", "-selector", "x509pop:subject:cn:node1"},
+		{"-spiffeID", "spiffe://example.org/spire/agent/join_token/token_a", "-ttl", "-1"},
+	} {
+		s.Require().Equal(1, s.cli.Run(args), "args: %q", args)
+	}
+}
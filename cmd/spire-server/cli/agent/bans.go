@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+//BansConfig holds configuration for BansCLI
+type BansConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+}
+
+// Validate will perform a basic validation on config fields
+func (c *BansConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+	return nil
+}
+
+//BansCLI command for listing agent bans
+type BansCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (BansCLI) Synopsis() string {
+	return "Lists agent bans"
+}
+
+func (c BansCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will list the agent bans
+func (c BansCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	listResponse, err := c.registrationClient.ListAgentBans(ctx, &registration.ListAgentBansRequest{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing agent bans: %v \n", err)
+		return 1
+	}
+
+	msg := fmt.Sprintf("Found %d agent ", len(listResponse.Bans))
+	msg = util.Pluralizer(msg, "ban", "bans", len(listResponse.Bans))
+	fmt.Print(msg + ":\n\n")
+	for _, ban := range listResponse.Bans {
+		printAgentBan(ban)
+	}
+	return 0
+}
+
+func (BansCLI) parseConfig(args []string) (*BansConfig, error) {
+	f := flag.NewFlagSet("agent bans", flag.ContinueOnError)
+	c := &BansConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,33 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBans(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockClient := mock_registration.NewMockRegistrationClient(mockCtrl)
+	cli := &BansCLI{
+		registrationClient: mockClient,
+	}
+
+	resp := &registration.ListAgentBansResponse{
+		Bans: []*common.AgentBan{
+			{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a", ExpiresAt: 1},
+			{Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"}, Reason: "compromised"},
+		},
+	}
+	mockClient.EXPECT().ListAgentBans(gomock.Any(), &registration.ListAgentBansRequest{}).Return(resp, nil)
+	require.Equal(t, 0, cli.Run([]string{}))
+
+	mockClient.EXPECT().ListAgentBans(gomock.Any(), &registration.ListAgentBansRequest{}).Return(nil, errors.New("some error"))
+	require.Equal(t, 1, cli.Run([]string{}))
+}
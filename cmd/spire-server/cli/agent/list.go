@@ -7,17 +7,57 @@ import (
 	"os"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
 	"golang.org/x/net/context"
 )
 
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
 //ListConfig holds configuration for ListCLI
 type ListConfig struct {
 	// Socket path of registration API
 	RegistrationUDSPath string
+
+	// List the agents reported by downstream servers instead
+	Downstream bool
+
+	// Only list agents that attested with the given attestation type
+	AttestationType string
+
+	// Only list agents that have all of the given selectors, formatted as
+	// type:value
+	Selectors common_cli.StringsFlag
+
+	// Only list agents whose SVID expires before the given time (RFC3339)
+	ExpiresBefore string
+
+	// Only list agents whose SVID expires after the given time (RFC3339)
+	ExpiresAfter string
+
+	// Only list agents that have not been seen for the given duration
+	NotSeenFor common_cli.DurationFlag
+
+	// Number of agents to list. All of the agents are listed if unset.
+	PageSize int
+
+	// Token of the page to list, as printed by a previous paginated listing
+	PageToken string
+
+	// Output format, either text or json
+	Format string
+
+	selectors     []*common.Selector
+	expiresBefore time.Time
+	expiresAfter  time.Time
 }
 
 // Validate will perform a basic validation on config fields
@@ -25,6 +65,51 @@ func (c *ListConfig) Validate() (err error) {
 	if c.RegistrationUDSPath == "" {
 		return errors.New("a socket path for registration api is required")
 	}
+
+	if err := validateFormat(c.Format); err != nil {
+		return err
+	}
+
+	if c.Downstream {
+		if c.AttestationType != "" || len(c.Selectors) > 0 || c.ExpiresBefore != "" || c.ExpiresAfter != "" || c.NotSeenFor != 0 || c.PageSize != 0 || c.PageToken != "" {
+			return errors.New("filters and pagination cannot be used when listing downstream agents")
+		}
+		return nil
+	}
+
+	c.selectors = nil
+	for _, selector := range c.Selectors {
+		s, err := parseSelector(selector)
+		if err != nil {
+			return err
+		}
+		c.selectors = append(c.selectors, s)
+	}
+
+	if c.ExpiresBefore != "" {
+		c.expiresBefore, err = time.Parse(time.RFC3339, c.ExpiresBefore)
+		if err != nil {
+			return fmt.Errorf("invalid expiresBefore time: %v", err)
+		}
+	}
+	if c.ExpiresAfter != "" {
+		c.expiresAfter, err = time.Parse(time.RFC3339, c.ExpiresAfter)
+		if err != nil {
+			return fmt.Errorf("invalid expiresAfter time: %v", err)
+		}
+	}
+
+	if c.NotSeenFor < 0 {
+		return errors.New("notSeenFor cannot be negative")
+	}
+
+	if c.PageSize < 0 {
+		return errors.New("pageSize cannot be negative")
+	}
+	if c.PageToken != "" && c.PageSize == 0 {
+		return errors.New("pageToken requires pageSize")
+	}
+
 	return nil
 }
 
@@ -32,6 +117,8 @@ func (c *ListConfig) Validate() (err error) {
 type ListCLI struct {
 	registrationClient registration.RegistrationClient
 	nodeList           []*common.AttestedNode
+	pagination         *registration.Pagination
+	downstreamList     []*registration.DownstreamAgents
 }
 
 func (ListCLI) Synopsis() string {
@@ -66,12 +153,30 @@ func (c *ListCLI) Run(args []string) int {
 		}
 	}
 
-	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{})
+	if config.Downstream {
+		listResponse, err := c.registrationClient.ListDownstreamAgents(ctx, &registration.ListDownstreamAgentsRequest{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing downstream agents: %v \n", err)
+			return 1
+		}
+		c.downstreamList = listResponse.DownstreamAgents
+		if config.Format == formatJSON {
+			return printJSON(listResponse)
+		}
+		c.printDownstreamAgents()
+		return 0
+	}
+
+	listResponse, err := c.registrationClient.ListAgents(ctx, c.listRequest(config))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing attested agents: %v \n", err)
 		return 1
 	}
 	c.nodeList = listResponse.Nodes
+	c.pagination = listResponse.Pagination
+	if config.Format == formatJSON {
+		return printJSON(listResponse)
+	}
 	c.printAttestedNodes()
 	return 0
 }
@@ -81,24 +186,111 @@ func (ListCLI) parseConfig(args []string) (*ListConfig, error) {
 	c := &ListConfig{}
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.BoolVar(&c.Downstream, "downstream", false, "List the agents reported by downstream SPIRE servers")
+	f.StringVar(&c.AttestationType, "attestationType", "", "Only list agents that attested with the given attestation type")
+	f.Var(&c.Selectors, "selector", "Only list agents that have the given selector, formatted as type:value. Can be used more than once")
+	f.StringVar(&c.ExpiresBefore, "expiresBefore", "", "Only list agents whose SVID expires before the given time (RFC3339)")
+	f.StringVar(&c.ExpiresAfter, "expiresAfter", "", "Only list agents whose SVID expires after the given time (RFC3339)")
+	f.Var(&c.NotSeenFor, "notSeenFor", "Only list agents that have not been seen for the given duration (e.g. 24h)")
+	f.IntVar(&c.PageSize, "pageSize", 0, "Number of agents to list. All of the agents are listed if unset")
+	f.StringVar(&c.PageToken, "pageToken", "", "Token of the page to list, as printed by a previous listing")
+	f.StringVar(&c.Format, "format", formatText, "Output format: 'text' or 'json'")
 
 	return c, f.Parse(args)
 }
 
+func (ListCLI) listRequest(config *ListConfig) *registration.ListAgentsRequest {
+	req := &registration.ListAgentsRequest{
+		ByAttestationType: config.AttestationType,
+		BySelectors:       config.selectors,
+	}
+	if !config.expiresBefore.IsZero() {
+		req.ByExpiresBefore = config.expiresBefore.Unix()
+	}
+	if !config.expiresAfter.IsZero() {
+		req.ByExpiresAfter = config.expiresAfter.Unix()
+	}
+	if config.NotSeenFor != 0 {
+		req.ByNotSeenSince = time.Now().Add(-time.Duration(config.NotSeenFor)).Unix()
+	}
+	if config.PageSize != 0 {
+		req.Pagination = &registration.Pagination{
+			Token:    config.PageToken,
+			PageSize: int32(config.PageSize),
+		}
+	}
+	return req
+}
+
 func (c ListCLI) printAttestedNodes() {
 	msg := fmt.Sprintf("Found %d attested ", len(c.nodeList))
 	msg = util.Pluralizer(msg, "agent", "agents", len(c.nodeList))
 	fmt.Printf(msg + ":\n\n")
 
-	if len(c.nodeList) == 0 {
-		return
-	}
-
 	for _, node := range c.nodeList {
-		fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
-		fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
-		fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
-		fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+		printAttestedNode(node)
+		for _, s := range node.Selectors {
+			fmt.Printf("Selectors         : %s:%s\n", s.Type, s.Value)
+		}
 		fmt.Println()
 	}
+
+	// Only print the token when there may be more agents to list
+	if c.pagination != nil && c.pagination.Token != "" && len(c.nodeList) == int(c.pagination.PageSize) {
+		fmt.Printf("Next page token   : %s\n", c.pagination.Token)
+	}
+}
+
+func (c ListCLI) printDownstreamAgents() {
+	msg := fmt.Sprintf("Found %d downstream ", len(c.downstreamList))
+	msg = util.Pluralizer(msg, "server", "servers", len(c.downstreamList))
+	fmt.Printf(msg + ":\n\n")
+
+	for _, downstream := range c.downstreamList {
+		msg := fmt.Sprintf("Downstream server %s reported %d ", downstream.SpiffeId, len(downstream.Agents))
+		msg = util.Pluralizer(msg, "agent", "agents", len(downstream.Agents))
+		fmt.Printf("%s at %s:\n\n", msg, time.Unix(downstream.ReportedAt, 0))
+
+		for _, node := range downstream.Agents {
+			printAttestedNode(node)
+			fmt.Println()
+		}
+	}
+}
+
+// printAttestedNode prints the details of an attested node, excluding its
+// selectors
+func printAttestedNode(node *common.AttestedNode) {
+	fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
+	fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
+	fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
+	fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+	if node.AgentVersion != "" {
+		fmt.Printf("Agent version     : %s\n", node.AgentVersion)
+	}
+	if node.LastSeenAt != 0 {
+		fmt.Printf("Last seen         : %s\n", time.Unix(node.LastSeenAt, 0))
+	}
+	if node.RemoteAddress != "" {
+		fmt.Printf("Remote address    : %s\n", node.RemoteAddress)
+	}
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case formatText, formatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unsupported format %q: expected 'text' or 'json'", format)
+	}
+}
+
+func printJSON(msg proto.Message) int {
+	marshaler := &jsonpb.Marshaler{Indent: "  "}
+	if err := marshaler.Marshal(os.Stdout, msg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error printing JSON: %v \n", err)
+		return 1
+	}
+	fmt.Println()
+	return 0
 }
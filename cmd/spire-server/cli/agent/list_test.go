@@ -1,14 +1,17 @@
 package agent
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 )
 
 type ListTestSuite struct {
@@ -60,3 +63,111 @@ func (s *ListTestSuite) TestRunExitsWithNonZeroCodeOnFailure() {
 	s.Require().Equal(1, s.cli.Run([]string{}))
 	s.Assert().Nil(s.cli.nodeList)
 }
+
+func (s *ListTestSuite) TestRunWithFilters() {
+	expiresBefore := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAfter := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *registration.ListAgentsRequest, opts ...grpc.CallOption) (*registration.ListAgentsResponse, error) {
+			s.Equal("join_token", req.ByAttestationType)
+			s.Equal([]*common.Selector{
+				{Type: "a", Value: "1"},
+				{Type: "b", Value: "2:3"},
+			}, req.BySelectors)
+			s.Equal(expiresBefore.Unix(), req.ByExpiresBefore)
+			s.Equal(expiresAfter.Unix(), req.ByExpiresAfter)
+			s.InDelta(time.Now().Add(-24*time.Hour).Unix(), req.ByNotSeenSince, 5)
+			s.Nil(req.Pagination)
+			return resp, nil
+		})
+
+	s.Require().Equal(0, s.cli.Run([]string{
+		"-attestationType", "join_token",
+		"-selector", "a:1",
+		"-selector", "b:2:3",
+		"-expiresBefore", expiresBefore.Format(time.RFC3339),
+		"-expiresAfter", expiresAfter.Format(time.RFC3339),
+		"-notSeenFor", "24h",
+	}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunWithPagination() {
+	req := &registration.ListAgentsRequest{
+		Pagination: &registration.Pagination{
+			Token:    "1",
+			PageSize: 1,
+		},
+	}
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_b"},
+		},
+		Pagination: &registration.Pagination{
+			Token:    "2",
+			PageSize: 1,
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-pageSize", "1", "-pageToken", "1"}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+	s.Assert().Equal(resp.Pagination, s.cli.pagination)
+}
+
+func (s *ListTestSuite) TestRunWithJSONFormat() {
+	req := &registration.ListAgentsRequest{}
+	resp := &registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+		},
+	}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-format", "json"}))
+	s.Assert().Equal(resp.Nodes, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunValidatesFlags() {
+	for _, args := range [][]string{
+		{"-selector", "a"},
+		{"-expiresBefore", "tomorrow"},
+		{"-expiresAfter", "yesterday"},
+		{"-notSeenFor", "-1h"},
+		{"-pageSize", "-1"},
+		{"-pageToken", "1"},
+		{"-format", "yaml"},
+		{"-downstream", "-attestationType", "join_token"},
+	} {
+		s.Require().Equal(1, s.cli.Run(args), "args: %v", args)
+	}
+}
+
+func (s *ListTestSuite) TestRunDownstream() {
+	req := &registration.ListDownstreamAgentsRequest{}
+	resp := &registration.ListDownstreamAgentsResponse{
+		DownstreamAgents: []*registration.DownstreamAgents{
+			{
+				SpiffeId:   "spiffe://example.org/downstream",
+				ReportedAt: 1,
+				Agents: []*common.AttestedNode{
+					{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+				},
+			},
+		},
+	}
+	s.mockClient.EXPECT().ListDownstreamAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-downstream"}))
+	s.Assert().Equal(resp.DownstreamAgents, s.cli.downstreamList)
+	s.Assert().Nil(s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunDownstreamExitsWithNonZeroCodeOnFailure() {
+	req := &registration.ListDownstreamAgentsRequest{}
+	s.mockClient.EXPECT().ListDownstreamAgents(gomock.Any(), req).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run([]string{"-downstream"}))
+	s.Assert().Nil(s.cli.downstreamList)
+}
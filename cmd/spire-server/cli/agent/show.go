@@ -5,8 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
@@ -21,6 +21,8 @@ type ShowConfig struct {
 	RegistrationUDSPath string
 	// SpiffeID of the agent being showed
 	SpiffeID string
+	// Output format, either text or json
+	Format string
 }
 
 // Validate will perform a basic validation on config fields
@@ -33,6 +35,10 @@ func (c *ShowConfig) Validate() (err error) {
 		return errors.New("a SPIFFE ID is required")
 	}
 
+	if err := validateFormat(c.Format); err != nil {
+		return err
+	}
+
 	// make sure SPIFFE ID is well formed
 	c.SpiffeID, err = idutil.NormalizeSpiffeID(c.SpiffeID, idutil.AllowAnyTrustDomainAgent())
 	if err != nil {
@@ -105,6 +111,12 @@ func (c *ShowCLI) Run(args []string) int {
 		return 1
 	}
 
+	if config.Format == formatJSON {
+		node := proto.Clone(c.node).(*common.AttestedNode)
+		node.Selectors = c.selectors
+		return printJSON(node)
+	}
+
 	c.printAttestedNode()
 	return 0
 }
@@ -115,6 +127,7 @@ func (ShowCLI) parseConfig(args []string) (*ShowConfig, error) {
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show (agent identity)")
+	f.StringVar(&c.Format, "format", formatText, "Output format: 'text' or 'json'")
 
 	return c, f.Parse(args)
 }
@@ -144,10 +157,7 @@ func (c ShowCLI) fetchSelectors(ctx context.Context, spiffeID string) ([]*common
 
 func (c ShowCLI) printAttestedNode() {
 	fmt.Printf("Found an attested agent given its SPIFFE ID\n\n")
-	fmt.Printf("Spiffe ID         : %s\n", c.node.SpiffeId)
-	fmt.Printf("Attestation type  : %s\n", c.node.AttestationDataType)
-	fmt.Printf("Expiration time   : %s\n", time.Unix(c.node.CertNotAfter, 0))
-	fmt.Printf("Serial number     : %s\n", c.node.CertSerialNumber)
+	printAttestedNode(c.node)
 
 	if c.selectors != nil {
 		for _, s := range c.selectors {
@@ -66,6 +66,37 @@ func (s *ShowTestSuite) TestRun() {
 	s.Assert().Equal(selectors, s.cli.selectors)
 }
 
+func (s *ShowTestSuite) TestRunWithJSONFormat() {
+	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
+	selectors := []*common.Selector{
+		{Type: "k8s_sat", Value: "cluster:demo-cluster"},
+	}
+
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), &registration.ListAgentsRequest{}).Return(&registration.ListAgentsResponse{
+		Nodes: []*common.AttestedNode{
+			{SpiffeId: spiffeID},
+		},
+	}, nil)
+	s.mockClient.EXPECT().GetNodeSelectors(gomock.Any(), &registration.GetNodeSelectorsRequest{
+		SpiffeId: spiffeID,
+	}).Return(&registration.GetNodeSelectorsResponse{
+		Selectors: &registration.NodeSelectors{
+			Selectors: selectors,
+		},
+	}, nil)
+
+	args := []string{"-spiffeID", spiffeID, "-format", "json"}
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(spiffeID, s.cli.node.SpiffeId)
+	s.Assert().Equal(selectors, s.cli.selectors)
+}
+
+func (s *ShowTestSuite) TestRunValidatesFormat() {
+	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
+	args := []string{"-spiffeID", spiffeID, "-format", "yaml"}
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
 func (s *ShowTestSuite) TestRunWithNoSelectorsInDatastore() {
 	spiffeID := "spiffe://example.org/spire/agent/k8s_sat/demo-cluster/c54f273c-f9c2-4d08-9d6f-08879e418aef"
 
@@ -0,0 +1,95 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+
+	"golang.org/x/net/context"
+)
+
+//UnbanConfig holds configuration for UnbanCLI
+type UnbanConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeID of the banned agent
+	SpiffeID string
+	// Selector of the banned agents, formatted as type:value
+	Selector string
+
+	selector *common.Selector
+}
+
+// Validate will perform a basic validation on config fields
+func (c *UnbanConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	c.selector, err = validateBanTarget(&c.SpiffeID, c.Selector)
+	return err
+}
+
+//UnbanCLI command for removing agent bans
+type UnbanCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (UnbanCLI) Synopsis() string {
+	return "Allows banned agents to attest again"
+}
+
+func (c UnbanCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will remove the ban for the given SPIFFE ID or selector
+func (c UnbanCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	if _, err := c.registrationClient.UnbanAgent(ctx, &registration.UnbanAgentRequest{
+		SpiffeId: config.SpiffeID,
+		Selector: config.selector,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error unbanning agent: %v \n", err)
+		return 1
+	}
+
+	fmt.Println("Agent unbanned successfully")
+	return 0
+}
+
+func (UnbanCLI) parseConfig(args []string) (*UnbanConfig, error) {
+	f := flag.NewFlagSet("agent unban", flag.ContinueOnError)
+	c := &UnbanConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the banned agent (agent identity)")
+	f.StringVar(&c.Selector, "selector", "", "The attestation selector of the banned agents, formatted as type:value")
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnbanTestSuite struct {
+	suite.Suite
+	cli        *UnbanCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *UnbanTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &UnbanCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *UnbanTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestUnbanTestSuite(t *testing.T) {
+	suite.Run(t, new(UnbanTestSuite))
+}
+
+func (s *UnbanTestSuite) TestRun() {
+	args := []string{"-selector", "x509pop:subject:cn:node1"}
+
+	req := &registration.UnbanAgentRequest{
+		Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"},
+	}
+	resp := &registration.UnbanAgentResponse{
+		Ban: &common.AgentBan{Selector: req.Selector},
+	}
+
+	s.mockClient.EXPECT().UnbanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *UnbanTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	spiffeID := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeID}
+
+	req := &registration.UnbanAgentRequest{
+		SpiffeId: spiffeID,
+	}
+
+	s.mockClient.EXPECT().UnbanAgent(gomock.Any(), req).Return(nil, errors.New("some error"))
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *UnbanTestSuite) TestRunValidatesArguments() {
+	s.Require().Equal(1, s.cli.Run([]string{}))
+	s.Require().Equal(1, s.cli.Run([]string{"-spiffeID", "not//an//spiffe/id"}))
+}
@@ -145,6 +145,8 @@ func (s *BundleSuite) TestSetHelp() {
     	Path to the bundle data
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -resetSequence
+    	Store the bundle with its own sequence number, e.g. after the sequence number of the trust domain was reset
 `, s.stderr.String())
 }
 
@@ -182,6 +184,32 @@ func (s *BundleSuite) TestSetUpdatesBundle() {
 	s.assertBundleSet()
 }
 
+func (s *BundleSuite) TestSetIncrementsSequenceNumber() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("BOGUSCERTS")},
+		},
+		SequenceNumber: 5,
+	})
+	s.stdin.WriteString(cert1PEM)
+	s.assertBundleSet()
+	s.Require().Equal(uint64(6), s.fetchSequenceNumber("spiffe://otherdomain.test"))
+}
+
+func (s *BundleSuite) TestSetResetsSequenceNumber() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.test",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("BOGUSCERTS")},
+		},
+		SequenceNumber: 5,
+	})
+	s.stdin.WriteString(cert1PEM)
+	s.assertBundleSet("-resetSequence")
+	s.Require().Equal(uint64(0), s.fetchSequenceNumber("spiffe://otherdomain.test"))
+}
+
 func (s *BundleSuite) TestSetCannotLoadBundleFromFile() {
 	rc := s.setCmd.Run([]string{"-id", "spiffe://otherdomain.test", "-path", "/not/a/real/path/to/a/bundle"})
 	s.Require().Equal(1, rc)
@@ -364,6 +392,15 @@ func (s *BundleSuite) assertBundleSet(extraArgs ...string) {
 	s.Require().Equal(s.cert1.Raw, resp.Bundle.RootCas[0].DerBytes)
 }
 
+func (s *BundleSuite) fetchSequenceNumber(trustDomainID string) uint64 {
+	resp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.Bundle)
+	return resp.Bundle.SequenceNumber
+}
+
 func (s *BundleSuite) createBundle(bundle *common.Bundle) {
 	_, err := s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
 		Bundle: bundle,
@@ -162,6 +162,8 @@ func (s *ExperimentalBundleSuite) TestSetHelp() {
     	Path to the bundle data
   -registrationUDSPath string
     	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -resetSequence
+    	Store the bundle with its own sequence number, e.g. after the sequence number of the trust domain was reset
 `, s.stderr.String())
 }
 
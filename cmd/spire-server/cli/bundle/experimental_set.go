@@ -25,6 +25,10 @@ type experimentalSetCommand struct {
 
 	// Path to the bundle on disk (optional). If empty, reads from stdin.
 	path string
+
+	// Whether to store the bundle with its own sequence number, instead of
+	// one greater than that of the existing bundle.
+	resetSequence bool
 }
 
 func (c *experimentalSetCommand) name() string {
@@ -38,6 +42,7 @@ func (c *experimentalSetCommand) synopsis() string {
 func (c *experimentalSetCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.path, "path", "", "Path to the bundle data")
+	fs.BoolVar(&c.resetSequence, "resetSequence", false, "Store the bundle with its own sequence number, e.g. after the sequence number of the trust domain was reset")
 }
 
 func (c *experimentalSetCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -55,7 +60,8 @@ func (c *experimentalSetCommand) run(ctx context.Context, env *env, clients *cli
 	}
 
 	federatedBundle := &registration.FederatedBundle{
-		Bundle: bundle,
+		Bundle:              bundle,
+		ResetSequenceNumber: c.resetSequence,
 	}
 
 	// pull the existing bundle to know if this should be a create or a update.
@@ -28,6 +28,10 @@ type setCommand struct {
 
 	// Path to the bundle on disk (optional). If empty, reads from stdin.
 	path string
+
+	// Whether to store the bundle with its own sequence number, instead of
+	// one greater than that of the existing bundle.
+	resetSequence bool
 }
 
 func (c *setCommand) name() string {
@@ -41,6 +45,7 @@ func (c *setCommand) synopsis() string {
 func (c *setCommand) appendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the trust domain")
 	fs.StringVar(&c.path, "path", "", "Path to the bundle data")
+	fs.BoolVar(&c.resetSequence, "resetSequence", false, "Store the bundle with its own sequence number, e.g. after the sequence number of the trust domain was reset")
 }
 
 func (c *setCommand) run(ctx context.Context, env *env, clients *clients) error {
@@ -63,7 +68,8 @@ func (c *setCommand) run(ctx context.Context, env *env, clients *clients) error
 	}
 
 	bundle := &registration.FederatedBundle{
-		Bundle: bundleutil.BundleProtoFromRootCAs(id, rootCAs),
+		Bundle:              bundleutil.BundleProtoFromRootCAs(id, rootCAs),
+		ResetSequenceNumber: c.resetSequence,
 	}
 
 	// pull the existing bundle to know if this should be a create or a update.
@@ -7,6 +7,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
+	"github.com/spiffe/spire/cmd/spire-server/cli/federation"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
@@ -25,6 +26,12 @@ func (cc *CLI) Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"agent ban": func() (cli.Command, error) {
+			return &agent.BanCLI{}, nil
+		},
+		"agent bans": func() (cli.Command, error) {
+			return &agent.BansCLI{}, nil
+		},
 		"agent evict": func() (cli.Command, error) {
 			return &agent.EvictCLI{}, nil
 		},
@@ -34,6 +41,9 @@ func (cc *CLI) Run(args []string) int {
 		"agent show": func() (cli.Command, error) {
 			return &agent.ShowCLI{}, nil
 		},
+		"agent unban": func() (cli.Command, error) {
+			return &agent.UnbanCLI{}, nil
+		},
 		"bundle show": func() (cli.Command, error) {
 			return bundle.NewShowCommand(), nil
 		},
@@ -67,6 +77,21 @@ func (cc *CLI) Run(args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return &entry.ShowCLI{}, nil
 		},
+		"entry explain": func() (cli.Command, error) {
+			return &entry.ExplainCLI{}, nil
+		},
+		"federation create": func() (cli.Command, error) {
+			return federation.NewCreateCommand(), nil
+		},
+		"federation list": func() (cli.Command, error) {
+			return federation.NewListCommand(), nil
+		},
+		"federation update": func() (cli.Command, error) {
+			return federation.NewUpdateCommand(), nil
+		},
+		"federation delete": func() (cli.Command, error) {
+			return federation.NewDeleteCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return run.NewRunCommand(cc.LogOptions), nil
 		},
@@ -82,6 +107,12 @@ func (cc *CLI) Run(args []string) int {
 		"jwt mint": func() (cli.Command, error) {
 			return jwt.NewMintCommand(), nil
 		},
+		"jwt revoke": func() (cli.Command, error) {
+			return jwt.NewRevokeCommand(), nil
+		},
+		"jwt unrevoke": func() (cli.Command, error) {
+			return jwt.NewUnrevokeCommand(), nil
+		},
 		"validate": func() (cli.Command, error) {
 			return validate.NewValidateCommand(), nil
 		},
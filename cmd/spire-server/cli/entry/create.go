@@ -51,6 +51,20 @@ type CreateConfig struct {
 
 	// IPAddresses entries for SVIDs based on this entry
 	IPAddresses StringsFlag
+
+	// DownstreamPathPrefixes constrain the SPIFFE IDs a downstream SPIRE
+	// server can sign for
+	DownstreamPathPrefixes StringsFlag
+
+	// DownstreamDNSDomains constrain the DNS names a downstream SPIRE server
+	// can sign for
+	DownstreamDNSDomains StringsFlag
+
+	// JWTClaims are name=value static claims added to JWT-SVIDs
+	JWTClaims StringsFlag
+
+	// JWTClaimTemplates are name=template templated claims added to JWT-SVIDs
+	JWTClaimTemplates StringsFlag
 }
 
 // Validate performs basic validation, even on fields that we
@@ -85,6 +99,10 @@ func (rc *CreateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if !rc.Downstream && (len(rc.DownstreamPathPrefixes) > 0 || len(rc.DownstreamDNSDomains) > 0) {
+		return errors.New("downstream constraints require the downstream flag")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -169,6 +187,19 @@ func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntr
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
 		IPAddresses: config.IPAddresses,
+
+		DownstreamPathPrefixes: config.DownstreamPathPrefixes,
+		DownstreamDnsDomains:   config.DownstreamDNSDomains,
+	}
+
+	var err error
+	e.JwtClaims, err = parseClaims(config.JWTClaims)
+	if err != nil {
+		return nil, err
+	}
+	e.JwtClaimTemplates, err = parseClaims(config.JWTClaimTemplates)
+	if err != nil {
+		return nil, err
 	}
 
 	// If the node flag is set, then set the Parent ID to the server's expected SPIFFE ID
@@ -249,6 +280,10 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 	f.Var(&c.IPAddresses, "ip", "An IP Address that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
+	f.Var(&c.DownstreamPathPrefixes, "downstreamPathPrefix", "A SPIFFE ID path prefix the downstream SPIRE server is allowed to sign for. Can be used more than once")
+	f.Var(&c.DownstreamDNSDomains, "downstreamDNSDomain", "A DNS domain the downstream SPIRE server is allowed to sign for. Can be used more than once")
+	f.Var(&c.JWTClaims, "jwtClaim", "A name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
+	f.Var(&c.JWTClaimTemplates, "jwtClaimTemplate", "A name=template claim, rendered against the entry and node selectors, that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
 
 	return c, f.Parse(args)
 }
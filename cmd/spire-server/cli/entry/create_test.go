@@ -114,6 +114,75 @@ func TestCreateNodeParseConfig(t *testing.T) {
 	assert.Equal(t, expectedEntries, entries)
 }
 
+func TestCreateDownstreamParseConfig(t *testing.T) {
+	createdConfig, err := CreateCLI{}.newConfig([]string{
+		"-parentID", "spiffe://example.org/foo",
+		"-spiffeID", "spiffe://example.org/bar",
+		"-selector", "unix:uid:1000",
+		"-downstream",
+		"-downstreamPathPrefix", "/ns/foo",
+		"-downstreamPathPrefix", "/ns/bar",
+		"-downstreamDNSDomain", "foo.example.org",
+	})
+	require.NoError(t, err)
+	require.NoError(t, createdConfig.Validate())
+
+	entries, err := CreateCLI{}.parseConfig(createdConfig)
+	require.NoError(t, err)
+
+	expectedEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/foo",
+		SpiffeId: "spiffe://example.org/bar",
+		Ttl:      3600,
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+		},
+		Downstream:             true,
+		DownstreamPathPrefixes: []string{"/ns/foo", "/ns/bar"},
+		DownstreamDnsDomains:   []string{"foo.example.org"},
+	}
+
+	expectedEntries := []*common.RegistrationEntry{expectedEntry}
+	assert.Equal(t, expectedEntries, entries)
+
+	createdConfig.Downstream = false
+	require.EqualError(t, createdConfig.Validate(), "downstream constraints require the downstream flag")
+}
+
+func TestCreateJWTClaimsParseConfig(t *testing.T) {
+	createdConfig, err := CreateCLI{}.newConfig([]string{
+		"-parentID", "spiffe://example.org/foo",
+		"-spiffeID", "spiffe://example.org/bar",
+		"-selector", "unix:uid:1000",
+		"-jwtClaim", "tenant=acme",
+		"-jwtClaim", "expr=a=b",
+		"-jwtClaimTemplate", `uid={{ selector "unix:uid" }}`,
+	})
+	require.NoError(t, err)
+	require.NoError(t, createdConfig.Validate())
+
+	entries, err := CreateCLI{}.parseConfig(createdConfig)
+	require.NoError(t, err)
+
+	expectedEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/foo",
+		SpiffeId: "spiffe://example.org/bar",
+		Ttl:      3600,
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+		},
+		JwtClaims:         map[string]string{"tenant": "acme", "expr": "a=b"},
+		JwtClaimTemplates: map[string]string{"uid": `{{ selector "unix:uid" }}`},
+	}
+
+	expectedEntries := []*common.RegistrationEntry{expectedEntry}
+	assert.Equal(t, expectedEntries, entries)
+
+	createdConfig.JWTClaims = StringsFlag{"tenant"}
+	_, err = CreateCLI{}.parseConfig(createdConfig)
+	require.EqualError(t, err, `claim "tenant" must be formatted as name=value`)
+}
+
 func TestRegisterParseFile(t *testing.T) {
 	p := path.Join(util.ProjectRoot(), "test/fixture/registration/good.json")
 	entries, err := CreateCLI{}.parseFile(p)
@@ -0,0 +1,155 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+// ExplainConfig is a configuration struct for the
+// `spire-server entry explain` CLI command
+type ExplainConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+
+	// SPIFFE ID of the agent the workload runs on
+	AgentID string
+
+	// Workload selectors. Type and value are delimited by a colon (:)
+	// ex. "unix:uid:1000" or "k8s:ns:default"
+	Selectors StringsFlag
+}
+
+// Validate ensures that the values in ExplainConfig are valid
+func (ec *ExplainConfig) Validate() error {
+	if ec.AgentID == "" {
+		return errors.New("an agent SPIFFE ID is required")
+	}
+
+	if len(ec.Selectors) == 0 {
+		return errors.New("at least one selector is required")
+	}
+
+	return nil
+}
+
+// ExplainCLI is a struct which represents an invocation of the
+// `spire-server entry explain` CLI command
+type ExplainCLI struct {
+	Client registration.RegistrationClient
+	Config *ExplainConfig
+
+	Response *registration.ExplainEntriesResponse
+}
+
+// Synopsis prints a description of the ExplainCLI command
+func (ExplainCLI) Synopsis() string {
+	return "Explains which registration entries a workload would be issued"
+}
+
+// Help prints a help message for the ExplainCLI command
+func (e ExplainCLI) Help() string {
+	err := e.loadConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run executes all logic associated with a single invocation of the
+// `spire-server entry explain` CLI command
+func (e *ExplainCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	err := e.loadConfig(args)
+	if err != nil {
+		fmt.Printf("Error parsing config options: %s\n", err)
+		return 1
+	}
+
+	if err = e.Config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	req := &registration.ExplainEntriesRequest{
+		AgentId: e.Config.AgentID,
+	}
+	for _, s := range e.Config.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		req.Selectors = append(req.Selectors, selector)
+	}
+
+	if e.Client == nil {
+		e.Client, err = util.NewRegistrationClient(e.Config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Printf("Error creating new registration client: %v\n", err)
+			return 1
+		}
+	}
+
+	e.Response, err = e.Client.ExplainEntries(ctx, req)
+	if err != nil {
+		fmt.Printf("Error explaining entries: %s\n", err)
+		return 1
+	}
+
+	e.printExplanation()
+	return 0
+}
+
+func (e *ExplainCLI) loadConfig(args []string) error {
+	f := flag.NewFlagSet("entry explain", flag.ContinueOnError)
+	c := &ExplainConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.AgentID, "agentID", "", "The SPIFFE ID of the agent the workload runs on")
+	f.Var(&c.Selectors, "selector", "A colon-delimited type:value workload selector. Can be used more than once")
+
+	err := f.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	e.Config = c
+	return nil
+}
+
+func (e *ExplainCLI) printExplanation() {
+	fmt.Printf("Found %d agent node %s\n", len(e.Response.NodeSelectors), selectorsNoun(len(e.Response.NodeSelectors)))
+	for _, s := range e.Response.NodeSelectors {
+		fmt.Printf("Node selector : %s:%s\n", s.Type, s.Value)
+	}
+	fmt.Println()
+
+	fmt.Printf("Found %d authorized %s\n\n", len(e.Response.Authorized), entriesNoun(len(e.Response.Authorized)))
+	for _, entry := range e.Response.Authorized {
+		printEntry(entry)
+	}
+
+	fmt.Printf("Found %d excluded %s\n\n", len(e.Response.Excluded), entriesNoun(len(e.Response.Excluded)))
+	for _, excluded := range e.Response.Excluded {
+		fmt.Printf("Excluded      : %s\n", excluded.Reason)
+		printEntry(excluded.Entry)
+	}
+}
+
+func entriesNoun(count int) string {
+	if count == 1 {
+		return "entry"
+	}
+	return "entries"
+}
+
+func selectorsNoun(count int) string {
+	if count == 1 {
+		return "selector"
+	}
+	return "selectors"
+}
@@ -0,0 +1,117 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestExplainTestSuite(t *testing.T) {
+	suite.Run(t, new(ExplainTestSuite))
+}
+
+type ExplainTestSuite struct {
+	suite.Suite
+
+	mockCtrl   *gomock.Controller
+	cli        *ExplainCLI
+	mockClient *mock_registration.MockRegistrationClient
+}
+
+func (s *ExplainTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &ExplainCLI{
+		Client: s.mockClient,
+	}
+}
+
+func (s *ExplainTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ExplainTestSuite) TestRun() {
+	args := []string{
+		"-agentID",
+		"spiffe://example.org/spire/agent/test/node1",
+		"-selector",
+		"unix:uid:1000",
+		"-selector",
+		"unix:gid:1000",
+	}
+
+	req := &registration.ExplainEntriesRequest{
+		AgentId: "spiffe://example.org/spire/agent/test/node1",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+			{Type: "unix", Value: "gid:1000"},
+		},
+	}
+	resp := &registration.ExplainEntriesResponse{
+		NodeSelectors: []*common.Selector{
+			{Type: "test", Value: "cluster:a"},
+		},
+		Authorized: []*common.RegistrationEntry{
+			{
+				EntryId:   "00000000-0000-0000-0000-000000000000",
+				ParentId:  "spiffe://example.org/spire/agent/test/node1",
+				SpiffeId:  "spiffe://example.org/authorized",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+		Excluded: []*registration.ExcludedEntry{
+			{
+				Entry: &common.RegistrationEntry{
+					EntryId:   "00000000-0000-0000-0000-000000000001",
+					ParentId:  "spiffe://example.org/spire/agent/test/node2",
+					SpiffeId:  "spiffe://example.org/wrong-parent",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+				Reason: `parent ID "spiffe://example.org/spire/agent/test/node2" is neither the agent nor an entry authorized for the agent`,
+			},
+		},
+	}
+	s.mockClient.EXPECT().ExplainEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(resp, s.cli.Response)
+}
+
+func (s *ExplainTestSuite) TestRunRequiresAgentID() {
+	s.Require().Equal(1, s.cli.Run([]string{"-selector", "unix:uid:1000"}))
+	s.Assert().Nil(s.cli.Response)
+}
+
+func (s *ExplainTestSuite) TestRunRequiresSelectors() {
+	s.Require().Equal(1, s.cli.Run([]string{"-agentID", "spiffe://example.org/spire/agent/test/node1"}))
+	s.Assert().Nil(s.cli.Response)
+}
+
+func (s *ExplainTestSuite) TestRunWithInvalidSelector() {
+	args := []string{
+		"-agentID",
+		"spiffe://example.org/spire/agent/test/node1",
+		"-selector",
+		"unix",
+	}
+	s.Require().Equal(1, s.cli.Run(args))
+	s.Assert().Nil(s.cli.Response)
+}
+
+func (s *ExplainTestSuite) TestRunFails() {
+	args := []string{
+		"-agentID",
+		"spiffe://example.org/spire/agent/test/node1",
+		"-selector",
+		"unix:uid:1000",
+	}
+	s.mockClient.EXPECT().ExplainEntries(gomock.Any(), gomock.Any()).Return(nil, errors.New("agent is not attested"))
+
+	s.Require().Equal(1, s.cli.Run(args))
+	s.Assert().Nil(s.cli.Response)
+}
@@ -2,6 +2,7 @@ package entry
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spiffe/spire/proto/spire/common"
@@ -56,6 +57,24 @@ func parseSelector(str string) (*common.Selector, error) {
 	return s, nil
 }
 
+// parseClaims parses CLI strings from name=value into a claim map. Everything
+// to the right of the first "=" is considered the claim value.
+func parseClaims(strs []string) (map[string]string, error) {
+	if len(strs) == 0 {
+		return nil, nil
+	}
+
+	claims := make(map[string]string, len(strs))
+	for _, str := range strs {
+		parts := strings.SplitN(str, "=", 2)
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, fmt.Errorf("claim \"%s\" must be formatted as name=value", str)
+		}
+		claims[parts[0]] = parts[1]
+	}
+	return claims, nil
+}
+
 func printEntry(e *common.RegistrationEntry) {
 	fmt.Printf("Entry ID      : %s\n", e.EntryId)
 	fmt.Printf("SPIFFE ID     : %s\n", e.SpiffeId)
@@ -64,6 +83,12 @@ func printEntry(e *common.RegistrationEntry) {
 	if e.Downstream {
 		fmt.Printf("Downstream    : %t\n", e.Downstream)
 	}
+	for _, pathPrefix := range e.DownstreamPathPrefixes {
+		fmt.Printf("Path prefix   : %s\n", pathPrefix)
+	}
+	for _, dnsDomain := range e.DownstreamDnsDomains {
+		fmt.Printf("DNS domain    : %s\n", dnsDomain)
+	}
 
 	if e.Ttl == 0 {
 		fmt.Printf("TTL           : default\n")
@@ -83,6 +108,12 @@ func printEntry(e *common.RegistrationEntry) {
 	for _, ipAddress := range e.IPAddresses {
 		fmt.Printf("IP address    : %s\n", ipAddress)
 	}
+	for _, name := range sortedClaimNames(e.JwtClaims) {
+		fmt.Printf("JWT claim     : %s=%s\n", name, e.JwtClaims[name])
+	}
+	for _, name := range sortedClaimNames(e.JwtClaimTemplates) {
+		fmt.Printf("JWT claim tmpl: %s=%s\n", name, e.JwtClaimTemplates[name])
+	}
 
 	// admin is rare, so only show admin if true to keep
 	// from muddying the output.
@@ -93,6 +124,15 @@ func printEntry(e *common.RegistrationEntry) {
 	fmt.Println()
 }
 
+func sortedClaimNames(claims map[string]string) []string {
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // StringsFlag defines a custom type for string lists. Doing
 // this allows us to support repeatable string flags.
 type StringsFlag []string
@@ -0,0 +1,152 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+type clients struct {
+	r registration.RegistrationClient
+}
+
+type clientsMaker func(registrationUDSPath string) (*clients, error)
+
+// newClients is the default client maker
+func newClients(registrationUDSPath string) (*clients, error) {
+	registrationClient, err := util.NewRegistrationClient(registrationUDSPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients{
+		r: registrationClient,
+	}, nil
+}
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *common_cli.Env, *clients) error
+}
+
+type adapter struct {
+	env          *common_cli.Env
+	clientsMaker clientsMaker
+	cmd          command
+
+	registrationUDSPath string
+	flags               *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *common_cli.Env, clientsMaker clientsMaker, cmd command) *adapter {
+	a := &adapter{
+		clientsMaker: clientsMaker,
+		cmd:          cmd,
+		env:          env,
+	}
+
+	f := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	f.SetOutput(env.Stderr)
+	f.StringVar(&a.registrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	a.cmd.appendFlags(f)
+	a.flags = f
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.flags.Parse(args); err != nil {
+		_ = a.env.ErrPrintln(err)
+		return 1
+	}
+
+	clients, err := a.clientsMaker(a.registrationUDSPath)
+	if err != nil {
+		_ = a.env.ErrPrintln(err)
+		return 1
+	}
+
+	if err := a.cmd.run(ctx, a.env, clients); err != nil {
+		_ = a.env.ErrPrintln(err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	return a.flags.Parse([]string{"-h"}).Error()
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
+
+// relationshipFlags holds the flags describing a federation relationship,
+// shared by the create and update commands
+type relationshipFlags struct {
+	id                    string
+	bundleEndpointURL     string
+	bundleEndpointProfile string
+	endpointSpiffeID      string
+}
+
+func (f *relationshipFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.id, "id", "", "SPIFFE ID of the federated trust domain")
+	fs.StringVar(&f.bundleEndpointURL, "bundleEndpointURL", "", "URL of the bundle endpoint of the federated trust domain")
+	fs.StringVar(&f.bundleEndpointProfile, "bundleEndpointProfile", client.BundleEndpointProfileHTTPSSPIFFE,
+		fmt.Sprintf("Bundle endpoint profile: one of %s or %s", client.BundleEndpointProfileHTTPSSPIFFE, client.BundleEndpointProfileHTTPSWeb))
+	fs.StringVar(&f.endpointSpiffeID, "endpointSpiffeID", "", "Expected SPIFFE ID of the bundle endpoint server when using the https_spiffe profile. Defaults to the SPIRE server ID of the federated trust domain.")
+}
+
+func (f *relationshipFlags) relationship() (*common.FederationRelationship, error) {
+	if f.id == "" {
+		return nil, errors.New("id is required")
+	}
+	if f.bundleEndpointURL == "" {
+		return nil, errors.New("bundleEndpointURL is required")
+	}
+
+	relationship := &common.FederationRelationship{
+		TrustDomainId:         f.id,
+		BundleEndpointUrl:     f.bundleEndpointURL,
+		BundleEndpointProfile: f.bundleEndpointProfile,
+		EndpointSpiffeId:      f.endpointSpiffeID,
+	}
+	if _, _, err := client.TrustDomainConfigFromRelationship(relationship); err != nil {
+		return nil, err
+	}
+	return relationship, nil
+}
+
+func printRelationship(env *common_cli.Env, relationship *common.FederationRelationship) error {
+	if err := env.Printf("Trust domain ID         : %s\n", relationship.TrustDomainId); err != nil {
+		return err
+	}
+	if err := env.Printf("Bundle endpoint URL     : %s\n", relationship.BundleEndpointUrl); err != nil {
+		return err
+	}
+	if err := env.Printf("Bundle endpoint profile : %s\n", relationship.BundleEndpointProfile); err != nil {
+		return err
+	}
+	if relationship.EndpointSpiffeId != "" {
+		if err := env.Printf("Endpoint SPIFFE ID      : %s\n", relationship.EndpointSpiffeId); err != nil {
+			return err
+		}
+	}
+	return env.Println()
+}
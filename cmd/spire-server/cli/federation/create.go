@@ -0,0 +1,48 @@
+package federation
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// NewCreateCommand creates a new "create" subcommand for "federation" command.
+func NewCreateCommand() cli.Command {
+	return newCreateCommand(common_cli.DefaultEnv, newClients)
+}
+
+func newCreateCommand(env *common_cli.Env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(createCommand))
+}
+
+type createCommand struct {
+	relationshipFlags
+}
+
+func (c *createCommand) name() string {
+	return "federation create"
+}
+
+func (c *createCommand) synopsis() string {
+	return "Creates a federation relationship with a foreign trust domain"
+}
+
+func (c *createCommand) appendFlags(fs *flag.FlagSet) {
+	c.relationshipFlags.appendFlags(fs)
+}
+
+func (c *createCommand) run(ctx context.Context, env *common_cli.Env, clients *clients) error {
+	relationship, err := c.relationship()
+	if err != nil {
+		return err
+	}
+
+	relationship, err = clients.r.CreateFederationRelationship(ctx, relationship)
+	if err != nil {
+		return err
+	}
+
+	return printRelationship(env, relationship)
+}
@@ -0,0 +1,92 @@
+package federation
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+const (
+	deleteBundleRestrict   = "restrict"
+	deleteBundleDissociate = "dissociate"
+	deleteBundleDelete     = "delete"
+)
+
+// NewDeleteCommand creates a new "delete" subcommand for "federation" command.
+func NewDeleteCommand() cli.Command {
+	return newDeleteCommand(common_cli.DefaultEnv, newClients)
+}
+
+func newDeleteCommand(env *common_cli.Env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(deleteCommand))
+}
+
+type deleteCommand struct {
+	// SPIFFE ID of the federated trust domain
+	id string
+
+	// Whether to delete the federated bundle as well
+	deleteBundle bool
+
+	// Deletion mode of the federated bundle
+	bundleMode string
+}
+
+func (c *deleteCommand) name() string {
+	return "federation delete"
+}
+
+func (c *deleteCommand) synopsis() string {
+	return "Deletes a federation relationship. The federated bundle is kept unless -deleteBundle is set."
+}
+
+func (c *deleteCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.id, "id", "", "SPIFFE ID of the federated trust domain")
+	fs.BoolVar(&c.deleteBundle, "deleteBundle", false, "Delete the federated bundle along with the relationship")
+	fs.StringVar(&c.bundleMode, "bundleMode", deleteBundleRestrict, fmt.Sprintf("Deletion mode of the federated bundle: one of %s, %s, or %s", deleteBundleRestrict, deleteBundleDelete, deleteBundleDissociate))
+}
+
+func (c *deleteCommand) run(ctx context.Context, env *common_cli.Env, clients *clients) error {
+	if c.id == "" {
+		return errors.New("id is required")
+	}
+
+	id, err := idutil.NormalizeSpiffeID(c.id, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return err
+	}
+
+	bundleMode, err := deleteBundleModeFromFlag(c.bundleMode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := clients.r.DeleteFederationRelationship(ctx, &registration.DeleteFederationRelationshipRequest{
+		TrustDomainId: id,
+		DeleteBundle:  c.deleteBundle,
+		BundleMode:    bundleMode,
+	}); err != nil {
+		return err
+	}
+
+	return env.Println("federation relationship deleted.")
+}
+
+func deleteBundleModeFromFlag(mode string) (registration.DeleteFederatedBundleRequest_Mode, error) {
+	switch mode {
+	case "", deleteBundleRestrict:
+		return registration.DeleteFederatedBundleRequest_RESTRICT, nil
+	case deleteBundleDissociate:
+		return registration.DeleteFederatedBundleRequest_DISSOCIATE, nil
+	case deleteBundleDelete:
+		return registration.DeleteFederatedBundleRequest_DELETE, nil
+	default:
+		return registration.DeleteFederatedBundleRequest_RESTRICT, fmt.Errorf("unsupported bundle mode %q", mode)
+	}
+}
@@ -0,0 +1,264 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeregistrationclient"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFederationCommands(t *testing.T) {
+	suite.Run(t, new(FederationSuite))
+}
+
+type FederationSuite struct {
+	suite.Suite
+
+	ds                 *fakedatastore.DataStore
+	registrationClient *fakeregistrationclient.Client
+	stdout             *bytes.Buffer
+	stderr             *bytes.Buffer
+
+	newCreateCommand func() cli.Command
+	createCmd        cli.Command
+	listCmd          cli.Command
+	updateCmd        cli.Command
+	deleteCmd        cli.Command
+}
+
+func (s *FederationSuite) SetupTest() {
+	s.stdout = new(bytes.Buffer)
+	s.stderr = new(bytes.Buffer)
+
+	s.ds = fakedatastore.New()
+	s.registrationClient = fakeregistrationclient.New(s.T(), "spiffe://example.test", s.ds, nil)
+
+	testEnv := &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: s.stdout,
+		Stderr: s.stderr,
+	}
+	clientMaker := func(string) (*clients, error) {
+		return &clients{
+			r: s.registrationClient,
+		}, nil
+	}
+
+	s.newCreateCommand = func() cli.Command {
+		return newCreateCommand(testEnv, clientMaker)
+	}
+	s.createCmd = s.newCreateCommand()
+	s.listCmd = newListCommand(testEnv, clientMaker)
+	s.updateCmd = newUpdateCommand(testEnv, clientMaker)
+	s.deleteCmd = newDeleteCommand(testEnv, clientMaker)
+}
+
+func (s *FederationSuite) TearDownTest() {
+	// gotta close the registration client or we will leak a goroutine
+	s.registrationClient.Close()
+}
+
+func (s *FederationSuite) TestCreateHelp() {
+	s.createCmd.Help()
+	s.Require().Equal(`Usage of federation create:
+  -bundleEndpointProfile string
+    	Bundle endpoint profile: one of https_spiffe or https_web (default "https_spiffe")
+  -bundleEndpointURL string
+    	URL of the bundle endpoint of the federated trust domain
+  -endpointSpiffeID string
+    	Expected SPIFFE ID of the bundle endpoint server when using the https_spiffe profile. Defaults to the SPIRE server ID of the federated trust domain.
+  -id string
+    	SPIFFE ID of the federated trust domain
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+`, s.stderr.String())
+}
+
+func (s *FederationSuite) TestCreate() {
+	testCases := []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{"-bundleEndpointURL", "https://domain.test"},
+			err:  "id is required\n",
+		},
+		{
+			args: []string{"-id", "spiffe://domain.test"},
+			err:  "bundleEndpointURL is required\n",
+		},
+		{
+			args: []string{"-id", "spiffe://domain.test", "-bundleEndpointURL", "https://domain.test", "-bundleEndpointProfile", "https_foo"},
+			err:  "bundle endpoint profile \"https_foo\" is not supported; expected \"https_spiffe\" or \"https_web\"\n",
+		},
+		{
+			args: []string{"-id", "spiffe://domain.test", "-bundleEndpointURL", "https://domain.test", "-bundleEndpointProfile", "https_web", "-endpointSpiffeID", "spiffe://domain.test/bundle-endpoint"},
+			err:  "endpoint SPIFFE ID cannot be set with the \"https_web\" bundle endpoint profile\n",
+		},
+	}
+	for _, testCase := range testCases {
+		s.stderr.Reset()
+		s.Require().Equal(1, s.newCreateCommand().Run(testCase.args))
+		s.Require().Equal(testCase.err, s.stderr.String())
+	}
+
+	s.stderr.Reset()
+	s.Require().Equal(0, s.createCmd.Run([]string{"-id", "spiffe://domain.test", "-bundleEndpointURL", "https://domain.test:8443", "-endpointSpiffeID", "spiffe://domain.test/bundle-endpoint"}))
+	s.Require().Equal(`Trust domain ID         : spiffe://domain.test
+Bundle endpoint URL     : https://domain.test:8443
+Bundle endpoint profile : https_spiffe
+Endpoint SPIFFE ID      : spiffe://domain.test/bundle-endpoint
+
+`, s.stdout.String())
+
+	s.assertRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test:8443",
+		BundleEndpointProfile: "https_spiffe",
+		EndpointSpiffeId:      "spiffe://domain.test/bundle-endpoint",
+	})
+}
+
+func (s *FederationSuite) TestList() {
+	s.Require().Equal(0, s.listCmd.Run([]string{}))
+	s.Require().Equal("No federation relationships found\n", s.stdout.String())
+
+	s.createRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain1.test",
+		BundleEndpointUrl:     "https://domain1.test",
+		BundleEndpointProfile: "https_web",
+	})
+	s.createRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain2.test",
+		BundleEndpointUrl:     "https://domain2.test:8443",
+		BundleEndpointProfile: "https_spiffe",
+	})
+
+	s.stdout.Reset()
+	s.Require().Equal(0, s.listCmd.Run([]string{}))
+	s.Require().Equal(`Found 2 federation relationship(s)
+
+Trust domain ID         : spiffe://domain1.test
+Bundle endpoint URL     : https://domain1.test
+Bundle endpoint profile : https_web
+
+Trust domain ID         : spiffe://domain2.test
+Bundle endpoint URL     : https://domain2.test:8443
+Bundle endpoint profile : https_spiffe
+
+`, s.stdout.String())
+}
+
+func (s *FederationSuite) TestUpdate() {
+	s.Require().Equal(1, s.updateCmd.Run([]string{"-id", "spiffe://domain.test", "-bundleEndpointURL", "https://domain.test"}))
+	s.Require().Equal("rpc error: code = NotFound desc = federation relationship not found\n", s.stderr.String())
+
+	s.createRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test",
+		BundleEndpointProfile: "https_spiffe",
+	})
+
+	s.Require().Equal(0, s.updateCmd.Run([]string{"-id", "spiffe://domain.test", "-bundleEndpointURL", "https://web.test/bundle", "-bundleEndpointProfile", "https_web"}))
+	s.assertRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://web.test/bundle",
+		BundleEndpointProfile: "https_web",
+	})
+}
+
+func (s *FederationSuite) TestDelete() {
+	s.Require().Equal(1, s.deleteCmd.Run([]string{}))
+	s.Require().Equal("id is required\n", s.stderr.String())
+
+	s.stderr.Reset()
+	s.Require().Equal(1, s.deleteCmd.Run([]string{"-id", "spiffe://domain.test"}))
+	s.Require().Equal("rpc error: code = NotFound desc = federation relationship not found\n", s.stderr.String())
+
+	s.createRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test",
+		BundleEndpointProfile: "https_spiffe",
+	})
+
+	s.Require().Equal(0, s.deleteCmd.Run([]string{"-id", "spiffe://domain.test"}))
+	s.Require().Equal("federation relationship deleted.\n", s.stdout.String())
+
+	resp, err := s.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(resp.Relationship)
+}
+
+func (s *FederationSuite) TestDeleteWithBundle() {
+	s.createRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test",
+		BundleEndpointProfile: "https_spiffe",
+	})
+	_, err := s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{TrustDomainId: "spiffe://domain.test"},
+	})
+	s.Require().NoError(err)
+	entry, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId:      "spiffe://example.test/node",
+			SpiffeId:      "spiffe://example.test/workload",
+			Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			FederatesWith: []string{"spiffe://domain.test"},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.Require().Equal(1, s.deleteCmd.Run([]string{"-id", "spiffe://domain.test", "-deleteBundle", "-bundleMode", "unknown"}))
+	s.Require().Equal("unsupported bundle mode \"unknown\"\n", s.stderr.String())
+
+	// the bundle cannot be deleted while entries federate with it, in which
+	// case the relationship is kept as well
+	s.stderr.Reset()
+	s.Require().Equal(1, s.deleteCmd.Run([]string{"-id", "spiffe://domain.test", "-deleteBundle", "-bundleMode", "restrict"}))
+	s.Require().Contains(s.stderr.String(), "cannot delete bundle; federated with 1 registration entries")
+	s.assertRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test",
+		BundleEndpointProfile: "https_spiffe",
+	})
+
+	s.Require().Equal(0, s.deleteCmd.Run([]string{"-id", "spiffe://domain.test", "-deleteBundle", "-bundleMode", "dissociate"}))
+	s.Require().Equal("federation relationship deleted.\n", s.stdout.String())
+
+	bundleResp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(bundleResp.Bundle)
+
+	entryResp, err := s.ds.FetchRegistrationEntry(context.Background(), &datastore.FetchRegistrationEntryRequest{
+		EntryId: entry.Entry.EntryId,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(entryResp.Entry.FederatesWith)
+}
+
+func (s *FederationSuite) createRelationship(relationship *common.FederationRelationship) {
+	_, err := s.ds.CreateFederationRelationship(context.Background(), &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *FederationSuite) assertRelationship(expected *common.FederationRelationship) {
+	resp, err := s.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: expected.TrustDomainId,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(expected, resp.Relationship)
+}
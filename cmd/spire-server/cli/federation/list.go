@@ -0,0 +1,53 @@
+package federation
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewListCommand creates a new "list" subcommand for "federation" command.
+func NewListCommand() cli.Command {
+	return newListCommand(common_cli.DefaultEnv, newClients)
+}
+
+func newListCommand(env *common_cli.Env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(listCommand))
+}
+
+type listCommand struct{}
+
+func (c *listCommand) name() string {
+	return "federation list"
+}
+
+func (c *listCommand) synopsis() string {
+	return "Lists federation relationships managed through the Registration API"
+}
+
+func (c *listCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *listCommand) run(ctx context.Context, env *common_cli.Env, clients *clients) error {
+	resp, err := clients.r.ListFederationRelationships(ctx, &registration.ListFederationRelationshipsRequest{})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Relationships) == 0 {
+		return env.Println("No federation relationships found")
+	}
+
+	if err := env.Printf("Found %d federation relationship(s)\n\n", len(resp.Relationships)); err != nil {
+		return err
+	}
+	for _, relationship := range resp.Relationships {
+		if err := printRelationship(env, relationship); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package federation
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+)
+
+// NewUpdateCommand creates a new "update" subcommand for "federation" command.
+func NewUpdateCommand() cli.Command {
+	return newUpdateCommand(common_cli.DefaultEnv, newClients)
+}
+
+func newUpdateCommand(env *common_cli.Env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(updateCommand))
+}
+
+type updateCommand struct {
+	relationshipFlags
+}
+
+func (c *updateCommand) name() string {
+	return "federation update"
+}
+
+func (c *updateCommand) synopsis() string {
+	return "Updates a federation relationship with a foreign trust domain"
+}
+
+func (c *updateCommand) appendFlags(fs *flag.FlagSet) {
+	c.relationshipFlags.appendFlags(fs)
+}
+
+func (c *updateCommand) run(ctx context.Context, env *common_cli.Env, clients *clients) error {
+	relationship, err := c.relationship()
+	if err != nil {
+		return err
+	}
+
+	relationship, err = clients.r.UpdateFederationRelationship(ctx, relationship)
+	if err != nil {
+		return err
+	}
+
+	return printRelationship(env, relationship)
+}
@@ -0,0 +1,94 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+func NewRevokeCommand() cli.Command {
+	return newRevokeCommand(common_cli.DefaultEnv)
+}
+
+func newRevokeCommand(env *common_cli.Env) *revokeCommand {
+	return &revokeCommand{
+		env: env,
+	}
+}
+
+type revokeCommand struct {
+	env *common_cli.Env
+
+	socketPath string
+	keyIDs     common_cli.StringsFlag
+	spiffeIDs  common_cli.StringsFlag
+}
+
+func (c *revokeCommand) Help() string {
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *revokeCommand) Synopsis() string {
+	return "Revokes JWT-SVIDs by signing key ID or SPIFFE ID"
+}
+
+func (c *revokeCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		c.env.ErrPrintf("error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *revokeCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("jwt revoke", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.socketPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	fs.Var(&c.keyIDs, "kid", "Key ID of a JWT signing key whose JWT-SVIDs are revoked. Can be used more than once.")
+	fs.Var(&c.spiffeIDs, "spiffeID", "SPIFFE ID whose JWT-SVIDs are revoked. Can be used more than once.")
+	return fs.Parse(args)
+}
+
+func (c *revokeCommand) run() error {
+	if len(c.keyIDs) == 0 && len(c.spiffeIDs) == 0 {
+		return errors.New("at least one kid or spiffeID must be specified")
+	}
+
+	client, err := util.NewRegistrationClient(c.env.JoinPath(c.socketPath))
+	if err != nil {
+		return errors.New("cannot create registration client")
+	}
+
+	resp, err := client.RevokeJWTSVIDs(context.Background(), &registration.RevokeJWTSVIDsRequest{
+		KeyIds:    c.keyIDs,
+		SpiffeIds: c.spiffeIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to revoke JWT-SVIDs: %v", err)
+	}
+	if resp.Bundle == nil {
+		return errors.New("server response missing bundle")
+	}
+
+	for _, kid := range resp.Bundle.RevokedJwtKeyIds {
+		if err := c.env.Printf("Revoked key ID    : %s\n", kid); err != nil {
+			return err
+		}
+	}
+	for _, subject := range resp.Bundle.RevokedJwtSubjects {
+		if err := c.env.Printf("Revoked SPIFFE ID : %s\n", subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
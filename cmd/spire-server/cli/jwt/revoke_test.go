@@ -0,0 +1,193 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	expectedRevokeUsage = `Usage of jwt revoke:
+  -kid value
+    	Key ID of a JWT signing key whose JWT-SVIDs are revoked. Can be used more than once.
+  -registrationUDSPath string
+    	Registration API UDS path (default "/tmp/spire-registration.sock")
+  -spiffeID value
+    	SPIFFE ID whose JWT-SVIDs are revoked. Can be used more than once.
+`
+)
+
+func TestRevokeSynopsis(t *testing.T) {
+	cmd := NewRevokeCommand()
+	assert.Equal(t, "Revokes JWT-SVIDs by signing key ID or SPIFFE ID", cmd.Synopsis())
+}
+
+func TestRevokeHelp(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newRevokeCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	assert.Empty(t, cmd.Help())
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, expectedRevokeUsage, stderr.String())
+}
+
+func TestRevokeRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	api := new(fakeRevokeRegistrationAPI)
+
+	serverDone := spiretest.StartRegistrationAPIOnSocket(t, filepath.Join(dir, util.DefaultSocketPath), api)
+	defer serverDone()
+
+	testCases := []struct {
+		name string
+
+		// flags
+		keyIDs    []string
+		spiffeIDs []string
+		extraArgs []string
+
+		// results
+		code   int
+		stdout string
+		stderr string
+
+		noRequestExpected bool
+		resp              *registration.Bundle
+	}{
+		{
+			name:              "nothing to revoke",
+			code:              1,
+			stderr:            "error: at least one kid or spiffeID must be specified\n",
+			noRequestExpected: true,
+		},
+		{
+			name:              "invalid flag",
+			code:              1,
+			stderr:            "flag provided but not defined: -bad\n" + expectedRevokeUsage,
+			extraArgs:         []string{"-bad", "flag"},
+			noRequestExpected: true,
+		},
+		{
+			name:   "RPC fails",
+			keyIDs: []string{"KID"},
+			code:   1,
+			stderr: "error: unable to revoke JWT-SVIDs: rpc error: code = Unknown desc = response not configured in test\n",
+		},
+		{
+			name:   "response missing bundle",
+			keyIDs: []string{"KID"},
+			code:   1,
+			stderr: "error: server response missing bundle\n",
+			resp:   &registration.Bundle{},
+		},
+		{
+			name:      "success",
+			keyIDs:    []string{"KID1", "KID2"},
+			spiffeIDs: []string{"spiffe://domain.test/workload"},
+			resp: &registration.Bundle{
+				Bundle: &common.Bundle{
+					TrustDomainId:      "spiffe://domain.test",
+					RevokedJwtKeyIds:   []string{"KID0", "KID1", "KID2"},
+					RevokedJwtSubjects: []string{"spiffe://domain.test/workload"},
+				},
+			},
+			stdout: `Revoked key ID    : KID0
+Revoked key ID    : KID1
+Revoked key ID    : KID2
+Revoked SPIFFE ID : spiffe://domain.test/workload
+`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			api.SetResponse(testCase.resp)
+
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			cmd := newRevokeCommand(&common_cli.Env{
+				Stdin:   new(bytes.Buffer),
+				Stdout:  stdout,
+				Stderr:  stderr,
+				BaseDir: dir,
+			})
+
+			args := []string{}
+			for _, kid := range testCase.keyIDs {
+				args = append(args, "-kid", kid)
+			}
+			for _, spiffeID := range testCase.spiffeIDs {
+				args = append(args, "-spiffeID", spiffeID)
+			}
+			args = append(args, testCase.extraArgs...)
+
+			code := cmd.Run(args)
+
+			assert.Equal(t, testCase.code, code, "exit code does not match")
+			assert.Equal(t, testCase.stdout, stdout.String(), "stdout does not match")
+			assert.Equal(t, testCase.stderr, stderr.String(), "stderr does not match")
+
+			req := api.LastRequest()
+			if testCase.noRequestExpected {
+				assert.Nil(t, req)
+				return
+			}
+			if assert.NotNil(t, req) {
+				assert.Equal(t, testCase.keyIDs, req.KeyIds)
+				assert.Equal(t, testCase.spiffeIDs, req.SpiffeIds)
+			}
+		})
+	}
+}
+
+type fakeRevokeRegistrationAPI struct {
+	registration.RegistrationServer
+
+	mu   sync.Mutex
+	req  *registration.RevokeJWTSVIDsRequest
+	resp *registration.Bundle
+}
+
+func (r *fakeRevokeRegistrationAPI) LastRequest() *registration.RevokeJWTSVIDsRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.req
+}
+
+func (r *fakeRevokeRegistrationAPI) SetResponse(resp *registration.Bundle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resp = resp
+}
+
+func (r *fakeRevokeRegistrationAPI) RevokeJWTSVIDs(ctx context.Context, req *registration.RevokeJWTSVIDsRequest) (*registration.Bundle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.req = req
+	if r.resp == nil {
+		return nil, errors.New("response not configured in test")
+	}
+	return r.resp, nil
+}
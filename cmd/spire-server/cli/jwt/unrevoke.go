@@ -0,0 +1,94 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+func NewUnrevokeCommand() cli.Command {
+	return newUnrevokeCommand(common_cli.DefaultEnv)
+}
+
+func newUnrevokeCommand(env *common_cli.Env) *unrevokeCommand {
+	return &unrevokeCommand{
+		env: env,
+	}
+}
+
+type unrevokeCommand struct {
+	env *common_cli.Env
+
+	socketPath string
+	keyIDs     common_cli.StringsFlag
+	spiffeIDs  common_cli.StringsFlag
+}
+
+func (c *unrevokeCommand) Help() string {
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *unrevokeCommand) Synopsis() string {
+	return "Removes JWT-SVID revocations by signing key ID or SPIFFE ID"
+}
+
+func (c *unrevokeCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(); err != nil {
+		c.env.ErrPrintf("error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *unrevokeCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("jwt unrevoke", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.socketPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	fs.Var(&c.keyIDs, "kid", "Key ID of a JWT signing key to remove from the revocations. Can be used more than once.")
+	fs.Var(&c.spiffeIDs, "spiffeID", "SPIFFE ID to remove from the revocations. Can be used more than once.")
+	return fs.Parse(args)
+}
+
+func (c *unrevokeCommand) run() error {
+	if len(c.keyIDs) == 0 && len(c.spiffeIDs) == 0 {
+		return errors.New("at least one kid or spiffeID must be specified")
+	}
+
+	client, err := util.NewRegistrationClient(c.env.JoinPath(c.socketPath))
+	if err != nil {
+		return errors.New("cannot create registration client")
+	}
+
+	resp, err := client.UnrevokeJWTSVIDs(context.Background(), &registration.UnrevokeJWTSVIDsRequest{
+		KeyIds:    c.keyIDs,
+		SpiffeIds: c.spiffeIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to unrevoke JWT-SVIDs: %v", err)
+	}
+	if resp.Bundle == nil {
+		return errors.New("server response missing bundle")
+	}
+
+	for _, kid := range resp.Bundle.RevokedJwtKeyIds {
+		if err := c.env.Printf("Revoked key ID    : %s\n", kid); err != nil {
+			return err
+		}
+	}
+	for _, subject := range resp.Bundle.RevokedJwtSubjects {
+		if err := c.env.Printf("Revoked SPIFFE ID : %s\n", subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
@@ -69,15 +70,22 @@ type serverConfig struct {
 	DataDir             string             `hcl:"data_dir"`
 	Experimental        experimentalConfig `hcl:"experimental"`
 	JWTIssuer           string             `hcl:"jwt_issuer"`
+	JWTIncludeJTI       bool               `hcl:"jwt_include_jti"`
 	LogFile             string             `hcl:"log_file"`
 	LogLevel            string             `hcl:"log_level"`
 	LogFormat           string             `hcl:"log_format"`
+	PathPrefixesOID     string             `hcl:"path_prefixes_oid"`
 	RegistrationUDSPath string             `hcl:"registration_uds_path"`
 	DeprecatedSVIDTTL   string             `hcl:"svid_ttl"`
 	DefaultSVIDTTL      string             `hcl:"default_svid_ttl"`
 	TrustDomain         string             `hcl:"trust_domain"`
 	UpstreamBundle      *bool              `hcl:"upstream_bundle"`
 
+	PruneAttestedNodesExpiredFor string `hcl:"prune_attested_nodes_expired_for"`
+	PruneAttestedNodesDryRun     bool   `hcl:"prune_attested_nodes_dry_run"`
+
+	InventoryReportInterval string `hcl:"inventory_report_interval"`
+
 	ConfigPath string
 	ExpandEnv  bool
 
@@ -99,6 +107,9 @@ type experimentalConfig struct {
 	BundleEndpointACME    *bundleEndpointACMEConfig      `hcl:"bundle_endpoint_acme"`
 	FederatesWith         map[string]federatesWithConfig `hcl:"federates_with"`
 
+	DelegatedJWTKeysEnabled bool   `hcl:"delegated_jwt_keys_enabled"`
+	DelegatedJWTKeyTTL      string `hcl:"delegated_jwt_key_ttl"`
+
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
 
@@ -121,6 +132,7 @@ type federatesWithConfig struct {
 	BundleEndpointAddress  string   `hcl:"bundle_endpoint_address"`
 	BundleEndpointPort     int      `hcl:"bundle_endpoint_port"`
 	BundleEndpointSpiffeID string   `hcl:"bundle_endpoint_spiffe_id"`
+	BundleEndpointProfile  string   `hcl:"bundle_endpoint_profile"`
 	UseWebPKI              bool     `hcl:"use_web_pki"`
 	UnusedKeys             []string `hcl:",unusedKeys"`
 }
@@ -155,6 +167,16 @@ func Help(name string, writer io.Writer) string {
 }
 
 func LoadConfig(name string, args []string, logOptions []log.Option, output io.Writer) (*server.Config, error) {
+	input, err := loadInput(name, args, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServerConfig(input, logOptions)
+}
+
+// loadInput parses the CLI flags and the config file, and merges them
+func loadInput(name string, args []string, output io.Writer) (*Config, error) {
 	// First parse the CLI flags so we can get the config
 	// file path, if set
 	cliInput, err := parseFlags(name, args, output)
@@ -169,12 +191,7 @@ func LoadConfig(name string, args []string, logOptions []log.Option, output io.W
 		return nil, err
 	}
 
-	input, err := mergeInput(fileInput, cliInput)
-	if err != nil {
-		return nil, err
-	}
-
-	return NewServerConfig(input, logOptions)
+	return mergeInput(fileInput, cliInput)
 }
 
 // Run the SPIFFE Server
@@ -193,6 +210,12 @@ func (cmd *Command) Run(args []string) int {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	util.SignalListener(ctx, cancel)
+	util.HangupListener(ctx, func() {
+		c.Log.Info("Received SIGHUP; reloading plugin configuration")
+		if err := reloadPlugins(ctx, s, args); err != nil {
+			c.Log.WithError(err).Error("Failed to reload plugin configuration")
+		}
+	})
 
 	err = s.Run(ctx)
 	if err != nil {
@@ -204,6 +227,26 @@ func (cmd *Command) Run(args []string) int {
 	return 0
 }
 
+// reloadPlugins loads the configuration again and reconfigures the server
+// plugins whose configuration changed.
+func reloadPlugins(ctx context.Context, s *server.Server, args []string) error {
+	input, err := loadInput(commandName, args, ioutil.Discard)
+	if err != nil {
+		return err
+	}
+
+	if err := validateConfig(input); err != nil {
+		return err
+	}
+
+	td, err := idutil.ParseSpiffeID("spiffe://"+input.Server.TrustDomain, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return fmt.Errorf("could not parse trust_domain %q: %v", input.Server.TrustDomain, err)
+	}
+
+	return s.ReloadPlugins(ctx, *td, *input.Plugins)
+}
+
 //Synopsis of the command
 func (*Command) Synopsis() string {
 	return "Runs the server"
@@ -360,6 +403,9 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 		if config.BundleEndpointPort != 0 {
 			port = config.BundleEndpointPort
 		}
+		if config.BundleEndpointProfile == bundleClient.BundleEndpointProfileHTTPSWeb {
+			config.UseWebPKI = true
+		}
 		if config.UseWebPKI && config.BundleEndpointSpiffeID != "" {
 			sc.Log.Warn("The `bundle_endpoint_spiffe_id` configurable is ignored when authenticating with Web PKI")
 			config.BundleEndpointSpiffeID = ""
@@ -372,6 +418,15 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 	sc.Experimental.FederatesWith = federatesWith
 
+	sc.Experimental.DelegatedJWTKeysEnabled = c.Server.Experimental.DelegatedJWTKeysEnabled
+	if c.Server.Experimental.DelegatedJWTKeyTTL != "" {
+		ttl, err := time.ParseDuration(c.Server.Experimental.DelegatedJWTKeyTTL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse delegated JWT key ttl %q: %v", c.Server.Experimental.DelegatedJWTKeyTTL, err)
+		}
+		sc.Experimental.DelegatedJWTKeyTTL = ttl
+	}
+
 	sc.ProfilingEnabled = c.Server.ProfilingEnabled
 	sc.ProfilingPort = c.Server.ProfilingPort
 	sc.ProfilingFreq = c.Server.ProfilingFreq
@@ -405,6 +460,37 @@ func NewServerConfig(c *Config, logOptions []log.Option) (*server.Config, error)
 	}
 
 	sc.JWTIssuer = c.Server.JWTIssuer
+	sc.JWTIncludeJTI = c.Server.JWTIncludeJTI
+
+	if c.Server.PathPrefixesOID != "" {
+		sc.PathPrefixesOID, err = x509util.ParsePathPrefixesOID(c.Server.PathPrefixesOID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Server.PruneAttestedNodesExpiredFor != "" {
+		expiredFor, err := time.ParseDuration(c.Server.PruneAttestedNodesExpiredFor)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse prune attested nodes expired for %q: %v", c.Server.PruneAttestedNodesExpiredFor, err)
+		}
+		if expiredFor < 0 {
+			return nil, fmt.Errorf("prune attested nodes expired for %q cannot be negative", c.Server.PruneAttestedNodesExpiredFor)
+		}
+		sc.PruneAttestedNodesExpiredFor = expiredFor
+	}
+	sc.PruneAttestedNodesDryRun = c.Server.PruneAttestedNodesDryRun
+
+	if c.Server.InventoryReportInterval != "" {
+		interval, err := time.ParseDuration(c.Server.InventoryReportInterval)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse inventory report interval %q: %v", c.Server.InventoryReportInterval, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("inventory report interval %q must be positive", c.Server.InventoryReportInterval)
+		}
+		sc.InventoryReportInterval = interval
+	}
 
 	if subject := c.Server.CASubject; subject != nil {
 		sc.CASubject = pkix.Name{
@@ -479,6 +565,16 @@ func validateConfig(c *Config) error {
 		if tdConfig.BundleEndpointAddress == "" {
 			return fmt.Errorf("%s bundle_endpoint_address must be configured", td)
 		}
+		switch tdConfig.BundleEndpointProfile {
+		case "", bundleClient.BundleEndpointProfileHTTPSWeb:
+		case bundleClient.BundleEndpointProfileHTTPSSPIFFE:
+			if tdConfig.UseWebPKI {
+				return fmt.Errorf("%s use_web_pki cannot be set with the %q bundle_endpoint_profile", td, bundleClient.BundleEndpointProfileHTTPSSPIFFE)
+			}
+		default:
+			return fmt.Errorf("%s bundle_endpoint_profile %q is not supported; expected %q or %q", td, tdConfig.BundleEndpointProfile,
+				bundleClient.BundleEndpointProfileHTTPSSPIFFE, bundleClient.BundleEndpointProfileHTTPSWeb)
+		}
 	}
 
 	// TODO: Remove this check at 0.11.0 (after warnOnUnknownConfig bails out instead of only display a warning)
@@ -554,6 +650,10 @@ func warnOnUnknownConfig(c *Config, l logrus.FieldLogger) {
 		l.Warnf("Detected unknown InMem config options: %q; this will be fatal in a future release.", p.UnusedKeys)
 	}
 
+	if p := c.Telemetry.Tracing; p != nil && len(p.UnusedKeys) != 0 {
+		l.Warnf("Detected unknown Tracing config options: %q; this will be fatal in a future release.", p.UnusedKeys)
+	}
+
 	if len(c.HealthChecks.UnusedKeys) != 0 {
 		l.Warnf("Detected unknown health check config options: %q; this will be fatal in a future release.", c.HealthChecks.UnusedKeys)
 	}
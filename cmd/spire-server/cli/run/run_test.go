@@ -3,6 +3,7 @@ package run
 import (
 	"bytes"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -605,6 +606,94 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, "ISSUER", c.JWTIssuer)
 			},
 		},
+		{
+			msg: "jwt_include_jti is correctly configured",
+			input: func(c *Config) {
+				c.Server.JWTIncludeJTI = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.JWTIncludeJTI)
+			},
+		},
+		{
+			msg: "path_prefixes_oid is correctly configured",
+			input: func(c *Config) {
+				c.Server.PathPrefixesOID = "1.3.6.1.4.1.99999.1"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, c.PathPrefixesOID)
+			},
+		},
+		{
+			msg:         "invalid path_prefixes_oid should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PathPrefixesOID = "1.3.foo"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "prune_attested_nodes_expired_for and prune_attested_nodes_dry_run are correctly configured",
+			input: func(c *Config) {
+				c.Server.PruneAttestedNodesExpiredFor = "720h"
+				c.Server.PruneAttestedNodesDryRun = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 720*time.Hour, c.PruneAttestedNodesExpiredFor)
+				require.True(t, c.PruneAttestedNodesDryRun)
+			},
+		},
+		{
+			msg:         "invalid prune_attested_nodes_expired_for should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PruneAttestedNodesExpiredFor = "forever"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "negative prune_attested_nodes_expired_for should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.PruneAttestedNodesExpiredFor = "-1h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "inventory_report_interval is correctly configured",
+			input: func(c *Config) {
+				c.Server.InventoryReportInterval = "10m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10*time.Minute, c.InventoryReportInterval)
+			},
+		},
+		{
+			msg:         "invalid inventory_report_interval should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.InventoryReportInterval = "often"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "non-positive inventory_report_interval should return an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.InventoryReportInterval = "0s"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "logger gets set correctly",
 			input: func(c *Config) {
@@ -679,6 +768,27 @@ func TestNewServerConfig(t *testing.T) {
 				require.True(t, c.Experimental.AllowAgentlessNodeAttestors)
 			},
 		},
+		{
+			msg: "delegated JWT keys are configured correctly",
+			input: func(c *Config) {
+				c.Server.Experimental.DelegatedJWTKeysEnabled = true
+				c.Server.Experimental.DelegatedJWTKeyTTL = "30m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.Experimental.DelegatedJWTKeysEnabled)
+				require.Equal(t, 30*time.Minute, c.Experimental.DelegatedJWTKeyTTL)
+			},
+		},
+		{
+			msg:         "invalid delegated_jwt_key_ttl returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.DelegatedJWTKeyTTL = "moo"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle endpoint is parsed and configured correctly",
 			input: func(c *Config) {
@@ -708,6 +818,15 @@ func TestNewServerConfig(t *testing.T) {
 						BundleEndpointPort:     1337,
 						UseWebPKI:              true,
 					},
+					"spiffe://domain3.test": {
+						BundleEndpointAddress:  "192.168.1.1",
+						BundleEndpointSpiffeID: "spiffe://domain3.test/bundle/endpoint",
+						BundleEndpointProfile:  "https_spiffe",
+					},
+					"spiffe://domain4.test": {
+						BundleEndpointAddress: "192.168.1.1",
+						BundleEndpointProfile: "https_web",
+					},
 				}
 			},
 			test: func(t *testing.T, c *server.Config) {
@@ -721,6 +840,14 @@ func TestNewServerConfig(t *testing.T) {
 						EndpointAddress: "192.168.1.1:1337",
 						UseWebPKI:       true,
 					},
+					"spiffe://domain3.test": {
+						EndpointAddress:  "192.168.1.1:443",
+						EndpointSpiffeID: "spiffe://domain3.test/bundle/endpoint",
+					},
+					"spiffe://domain4.test": {
+						EndpointAddress: "192.168.1.1:443",
+						UseWebPKI:       true,
+					},
 				}, c.Experimental.FederatesWith)
 			},
 		},
@@ -954,6 +1081,31 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: "spiffe://domain.test bundle_endpoint_address must be configured",
 		},
+		{
+			name: "bundle_endpoint_profile must be supported",
+			applyConf: func(c *Config) {
+				c.Server.Experimental.FederatesWith = map[string]federatesWithConfig{
+					"spiffe://domain.test": {
+						BundleEndpointAddress: "192.168.1.1",
+						BundleEndpointProfile: "ftp",
+					},
+				}
+			},
+			expectedErr: `spiffe://domain.test bundle_endpoint_profile "ftp" is not supported; expected "https_spiffe" or "https_web"`,
+		},
+		{
+			name: "use_web_pki cannot be set with the https_spiffe profile",
+			applyConf: func(c *Config) {
+				c.Server.Experimental.FederatesWith = map[string]federatesWithConfig{
+					"spiffe://domain.test": {
+						BundleEndpointAddress: "192.168.1.1",
+						BundleEndpointProfile: "https_spiffe",
+						UseWebPKI:             true,
+					},
+				}
+			},
+			expectedErr: `spiffe://domain.test use_web_pki cannot be set with the "https_spiffe" bundle_endpoint_profile`,
+		},
 		{
 			name:        "deprecated configurable `svid_ttl` must not be set",
 			applyConf:   func(c *Config) { c.Server.DeprecatedSVIDTTL = "1h" },
@@ -1050,6 +1202,11 @@ func TestWarnOnUnknownConfig(t *testing.T) {
 			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_InMem_block.conf", testFileDir),
 			expectedLogMsg: "Detected unknown InMem config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
 		},
+		{
+			msg:            "in nested Tracing block",
+			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_Tracing_block.conf", testFileDir),
+			expectedLogMsg: "Detected unknown Tracing config options: [\"unknown_option1\" \"unknown_option2\"]; this will be fatal in a future release.",
+		},
 		{
 			msg:            "in nested health_checks block",
 			testFilePath:   fmt.Sprintf("%v/server_and_agent_bad_nested_health_checks_block.conf", testFileDir),
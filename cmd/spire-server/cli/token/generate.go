@@ -1,13 +1,15 @@
 package token
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"net/url"
-	"path"
+	"strings"
 
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/jointokenutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 
@@ -25,6 +27,30 @@ type GenerateConfig struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Optional agent ID template, either a SPIFFE ID or a path relative to
+	// /spire/agent, rendered with the token and use number
+	AgentID string
+
+	// Optional selectors assigned to agents attesting with the token.
+	// Type and value are delimited by a colon (:)
+	Selectors StringsFlag
+
+	// Number of times the token can be used. Zero means once.
+	MaxUses int
+}
+
+// Validate ensures that the values in GenerateConfig are valid
+func (gc *GenerateConfig) Validate() error {
+	if gc.MaxUses < 0 {
+		return errors.New("max uses cannot be negative")
+	}
+
+	if gc.SpiffeID != "" && gc.MaxUses > 1 {
+		return errors.New("a SPIFFE ID cannot be assigned to a multi-use token")
+	}
+
+	return nil
 }
 
 func (GenerateCLI) Synopsis() string {
@@ -45,18 +71,23 @@ func (g GenerateCLI) Run(args []string) int {
 		return 1
 	}
 
+	if err := config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
 	c, err := util.NewRegistrationClient(config.RegistrationUDSPath)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	token, err := g.createToken(ctx, c, config.TTL)
+	token, err := g.createToken(ctx, c, config)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
-	fmt.Printf("Token: %s\n", token)
+	fmt.Printf("Token: %s\n", token.Token)
 
 	if config.SpiffeID == "" {
 		fmt.Printf("Warning: Missing SPIFFE ID.\n")
@@ -73,36 +104,47 @@ func (g GenerateCLI) Run(args []string) int {
 }
 
 // createToken calls the registration API and creates a new token
-// with the given TTL. It returns the raw token and an error, if any
-func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl int) (string, error) {
-	req := &registration.JoinToken{Ttl: int32(ttl)}
-	resp, err := c.CreateJoinToken(ctx, req)
-	if err != nil {
-		return "", err
+// with the given TTL, agent ID template, selectors and quota. It returns
+// the created token and an error, if any
+func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, config GenerateConfig) (*registration.JoinToken, error) {
+	req := &registration.JoinToken{
+		Ttl:     int32(config.TTL),
+		AgentId: config.AgentID,
+		MaxUses: int32(config.MaxUses),
+	}
+	for _, s := range config.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		req.Selectors = append(req.Selectors, selector)
 	}
 
-	return resp.Token, nil
+	return c.CreateJoinToken(ctx, req)
 }
 
 // createVanityRecord inserts a registration entry with parent ID set to the SPIFFE ID
 // belonging to a token. The purpose is to allow folks to easily create vanity names
 // backed by token IDs.
-func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.RegistrationClient, token, spiffeID string) error {
+func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.RegistrationClient, token *registration.JoinToken, spiffeID string) error {
 	id, err := idutil.ParseSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload())
 	if err != nil {
 		return err
 	}
 
-	parentID := &url.URL{
-		Scheme: id.Scheme,
-		Host:   id.Host,
-		Path:   path.Join("spire", "agent", "join_token", token),
+	parentID, err := jointokenutil.MakeAgentID(id.Host, &datastore.JoinToken{
+		Token:           token.Token,
+		AgentIdTemplate: token.AgentId,
+		MaxUses:         token.MaxUses,
+	}, 1)
+	if err != nil {
+		return err
 	}
 	req := &common.RegistrationEntry{
-		ParentId: parentID.String(),
+		ParentId: parentID,
 		SpiffeId: id.String(),
 		Selectors: []*common.Selector{
-			{Type: "spiffe_id", Value: parentID.String()},
+			{Type: "spiffe_id", Value: parentID},
 		},
 	}
 
@@ -121,6 +163,9 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
 	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
 	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	flags.StringVar(&c.AgentID, "agentID", "", "Agent SPIFFE ID, or path relative to /spire/agent, assigned to agents attesting with the token. May reference {{ .Token }} and {{ .Use }} (optional)")
+	flags.Var(&c.Selectors, "selector", "A colon-delimited type:value selector assigned to agents attesting with the token. Can be used more than once")
+	flags.IntVar(&c.MaxUses, "maxUses", 0, "Number of agents that can attest with the token. Zero means once")
 
 	err := flags.Parse(args)
 	if err != nil {
@@ -129,3 +174,28 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 
 	return c, nil
 }
+
+func parseSelector(str string) (*common.Selector, error) {
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector \"%s\" must be formatted as type:value", str)
+	}
+
+	return &common.Selector{
+		Type:  parts[0],
+		Value: parts[1],
+	}, nil
+}
+
+// StringsFlag defines a custom type for string lists. Doing
+// this allows us to support repeatable string flags.
+type StringsFlag []string
+
+func (s *StringsFlag) String() string {
+	return fmt.Sprint(*s)
+}
+
+func (s *StringsFlag) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
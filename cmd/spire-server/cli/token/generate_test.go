@@ -25,9 +25,52 @@ func TestCreateToken(t *testing.T) {
 	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
 
 	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
-	token, err := GenerateCLI{}.createToken(ctx, c, 60)
+	token, err := GenerateCLI{}.createToken(ctx, c, GenerateConfig{TTL: 60})
 	require.NoError(t, err)
-	assert.Equal(t, "foobar", token)
+	assert.Equal(t, "foobar", token.Token)
+}
+
+func TestCreateScopedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	req := &registration.JoinToken{
+		Ttl:     60,
+		AgentId: "imaging/{{ .Use }}",
+		Selectors: []*common.Selector{
+			{Type: "rack", Value: "r1:a"},
+		},
+		MaxUses: 10,
+	}
+	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
+
+	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
+	token, err := GenerateCLI{}.createToken(ctx, c, GenerateConfig{
+		TTL:       60,
+		AgentID:   "imaging/{{ .Use }}",
+		Selectors: StringsFlag{"rack:r1:a"},
+		MaxUses:   10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "foobar", token.Token)
+
+	// Test a malformed selector
+	_, err = GenerateCLI{}.createToken(ctx, c, GenerateConfig{
+		TTL:       60,
+		Selectors: StringsFlag{"rack"},
+	})
+	assert.EqualError(t, err, `selector "rack" must be formatted as type:value`)
+}
+
+func TestGenerateConfigValidate(t *testing.T) {
+	assert.NoError(t, (&GenerateConfig{MaxUses: 5}).Validate())
+	assert.NoError(t, (&GenerateConfig{SpiffeID: "spiffe://example.org/vanity"}).Validate())
+	assert.EqualError(t, (&GenerateConfig{MaxUses: -1}).Validate(), "max uses cannot be negative")
+	assert.EqualError(t, (&GenerateConfig{
+		SpiffeID: "spiffe://example.org/vanity",
+		MaxUses:  2,
+	}).Validate(), "a SPIFFE ID cannot be assigned to a multi-use token")
 }
 
 func TestCreateVanityRecord(t *testing.T) {
@@ -35,7 +78,7 @@ func TestCreateVanityRecord(t *testing.T) {
 	defer ctrl.Finish()
 
 	c := mock_registration.NewMockRegistrationClient(ctrl)
-	token := "foobar"
+	token := &registration.JoinToken{Token: "foobar"}
 	spiffeID := "spiffe://example.org/VanityID"
 	tokenID := "spiffe://example.org/spire/agent/join_token/foobar" //nolint: gosec // false positive
 
@@ -57,3 +100,25 @@ func TestCreateVanityRecord(t *testing.T) {
 	err = GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.Error(t, err)
 }
+
+func TestCreateVanityRecordWithAgentID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	token := &registration.JoinToken{Token: "foobar", AgentId: "imaging/{{ .Token }}"}
+	spiffeID := "spiffe://example.org/VanityID"
+	agentID := "spiffe://example.org/spire/agent/imaging/foobar"
+
+	req := &common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: spiffeID,
+		Selectors: []*common.Selector{
+			{Type: "spiffe_id", Value: agentID},
+		},
+	}
+
+	c.EXPECT().CreateEntry(gomock.Any(), req)
+	err := GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
+	assert.NoError(t, err)
+}
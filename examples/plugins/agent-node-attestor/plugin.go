@@ -6,8 +6,9 @@ import (
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
-	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/plugin/sdk"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
@@ -84,12 +85,13 @@ func (p *Plugin) BrokerHostServices(broker catalog.HostServiceBroker) error {
 // node attestor plugin on the server.
 //
 // FetchAttestationData should do the following:
-//   1) Gather attestation data
-//   2) Send the attestation data through the stream
+//  1. Gather attestation data
+//  2. Send the attestation data through the stream
+//
 // If implementing a challenge response flow, the plugin should then:
-//   3) Receive a challenge from the server
-//   4) Send a challenge response back to the server
-//   5) Repeat 3 and 4 as much as necessary to complete the challenge/response.
+//  3. Receive a challenge from the server
+//  4. Send a challenge response back to the server
+//  5. Repeat 3 and 4 as much as necessary to complete the challenge/response.
 func (p *Plugin) FetchAttestationData(stream nodeattestor.NodeAttestor_FetchAttestationDataServer) (err error) {
 	config, err := p.getConfig()
 	if err != nil {
@@ -167,5 +169,5 @@ func (p *Plugin) loadAttestationData(ctx context.Context, config *Config) ([]byt
 // BuiltIn() function in this package to load the plugin and this function can
 // be removed.
 func main() {
-	catalog.PluginMain(BuiltIn())
+	sdk.ServeAgentNodeAttestor(pluginName, New())
 }
@@ -7,10 +7,11 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/plugin/sdk"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	nodeattestorbase "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/base"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
-	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/zeebo/errs"
 )
 
@@ -95,12 +96,13 @@ func (p *Plugin) BrokerHostServices(broker catalog.HostServiceBroker) error {
 // node attestor plugin on the agent.
 //
 // Attest should do the following:
-//   1) Receive attestation data
-//   2) Attest the received data
+//  1. Receive attestation data
+//  2. Attest the received data
+//
 // If implementing a challenge response flow, the plugin should then:
-//   3) Receive a challenge from the server
-//   4) Send a challenge response back to the server
-//   5) Repeat 3 and 4 as much as necessary to complete the challenge/response.
+//  3. Receive a challenge from the server
+//  4. Send a challenge response back to the server
+//  5. Repeat 3 and 4 as much as necessary to complete the challenge/response.
 func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) (err error) {
 	config, err := p.getConfig()
 	if err != nil {
@@ -187,5 +189,5 @@ func (p *Plugin) attest(ctx context.Context, config *Config, stream nodeattestor
 // BuiltIn() function in this package to load the plugin and this function can
 // be removed.
 func main() {
-	catalog.PluginMain(BuiltIn())
+	sdk.ServeServerNodeAttestor(pluginName, New())
 }
@@ -2,9 +2,11 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
+	"net/url"
 	"os"
 	"path"
 	"runtime"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/node"
+	workload_attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/endpoints"
 	"github.com/spiffe/spire/pkg/agent/manager"
@@ -21,6 +24,7 @@ import (
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/profiling"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/spiffe/spire/pkg/common/util"
 	_ "golang.org/x/net/trace" // registers handlers on the DefaultServeMux
 	"google.golang.org/grpc"
@@ -28,6 +32,10 @@ import (
 
 type Agent struct {
 	c *Config
+
+	mtx sync.Mutex
+	// cat is the plugin catalog, set while the agent is running
+	cat *catalog.Repository
 }
 
 // Run the agent
@@ -61,8 +69,22 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	telemetry.EmitVersion(metrics)
 
+	// The tracer is created before the catalog is loaded so that spans are
+	// recorded for plugin calls and the configuration is passed on to
+	// external plugins.
+	tracer, err := tracing.New(&tracing.Config{
+		FileConfig:  a.c.Telemetry.Tracing,
+		Logger:      a.c.Log.WithField(telemetry.SubsystemName, telemetry.Tracing),
+		ServiceName: telemetry.SpireAgent,
+	})
+	if err != nil {
+		return err
+	}
+
 	cat, err := catalog.Load(ctx, catalog.Config{
-		Log: a.c.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		Log:     a.c.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		Metrics: metrics,
+		Tracer:  tracer,
 		GlobalConfig: catalog.GlobalConfig{
 			TrustDomain: a.c.TrustDomain.Host,
 		},
@@ -76,6 +98,9 @@ func (a *Agent) Run(ctx context.Context) error {
 	}
 	defer cat.Close()
 
+	a.setCatalog(cat)
+	defer a.setCatalog(nil)
+
 	healthChecks := health.NewChecker(a.c.HealthChecks, a.c.Log)
 
 	as, err := a.attest(ctx, cat, metrics)
@@ -83,21 +108,26 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	manager, err := a.newManager(ctx, cat, metrics, as)
+	manager, err := a.newManager(ctx, cat, metrics, tracer, as)
 	if err != nil {
 		return err
 	}
 
-	endpoints := a.newEndpoints(cat, metrics, manager)
+	attestorStatus := workload_attestor.NewStatusTracker(nil)
+	endpoints := a.newEndpoints(cat, metrics, tracer, manager, attestorStatus)
 
-	if err := healthChecks.AddCheck("agent", a, time.Minute); err != nil {
+	if err := healthChecks.AddLivenessCheck("agent", a, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
+	if err := a.addSubsystemHealthChecks(healthChecks, manager, attestorStatus, cat); err != nil {
+		return err
+	}
 
 	err = util.RunTasks(ctx,
 		manager.Run,
 		endpoints.ListenAndServe,
 		metrics.ListenAndServe,
+		tracer.Run,
 		healthChecks.ListenAndServe,
 	)
 	if err == context.Canceled {
@@ -174,11 +204,12 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemet
 		SVIDCachePath:     a.agentSVIDPath(),
 		Log:               a.c.Log.WithField(telemetry.SubsystemName, telemetry.Attestor),
 		ServerAddress:     a.c.ServerAddress,
+		PathPrefixesOID:   a.c.PathPrefixesOID,
 	}
 	return attestor.New(&config).Attest(ctx)
 }
 
-func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, as *attestor.AttestationResult) (manager.Manager, error) {
+func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, tracer *tracing.Tracer, as *attestor.AttestationResult) (manager.Manager, error) {
 	config := &manager.Config{
 		SVID:            as.SVID,
 		SVIDKey:         as.Key,
@@ -188,9 +219,18 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		ServerAddr:      a.c.ServerAddress,
 		Log:             a.c.Log.WithField(telemetry.SubsystemName, telemetry.Manager),
 		Metrics:         metrics,
+		Tracer:          tracer,
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		SyncInterval:    a.c.SyncInterval,
+
+		ReattestInterval: a.c.ReattestInterval,
+
+		DelegatedJWTSigning: a.c.DelegatedJWTSigning,
+		JWTIssuer:           a.c.JWTIssuer,
+		JWTIncludeJTI:       a.c.JWTIncludeJTI,
+
+		PathPrefixesOID: a.c.PathPrefixesOID,
 	}
 
 	mgr, err := manager.New(config)
@@ -205,14 +245,19 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 	return mgr, nil
 }
 
-func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, mgr manager.Manager) endpoints.Server {
+func (a *Agent) newEndpoints(cat catalog.Catalog, metrics telemetry.Metrics, tracer *tracing.Tracer, mgr manager.Manager, attestorStatus *workload_attestor.StatusTracker) endpoints.Server {
 	config := &endpoints.Config{
 		BindAddr:  a.c.BindAddress,
 		Catalog:   cat,
 		Manager:   mgr,
 		Log:       a.c.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:   metrics,
+		Tracer:    tracer,
 		EnableSDS: a.c.EnableSDS,
+
+		AdminBindAddr:           a.c.AdminBindAddress,
+		JWTSVIDReplayProtection: a.c.JWTSVIDReplayProtection,
+		AttestorStatus:          attestorStatus,
 	}
 
 	return endpoints.New(config)
@@ -226,6 +271,37 @@ func (a *Agent) agentSVIDPath() string {
 	return path.Join(a.c.DataDir, "agent_svid.der")
 }
 
+// ReloadPlugins configures again the plugins whose configuration changed. The
+// trust domain and the set of loaded plugins cannot be changed without
+// restarting the agent.
+func (a *Agent) ReloadPlugins(ctx context.Context, trustDomain url.URL, pluginConfigs catalog.HCLPluginConfigMap) error {
+	if trustDomain.String() != a.c.TrustDomain.String() {
+		return fmt.Errorf("trust domain cannot be changed from %q to %q without a restart", a.c.TrustDomain.Host, trustDomain.Host)
+	}
+
+	a.mtx.Lock()
+	cat := a.cat
+	a.mtx.Unlock()
+	if cat == nil {
+		return errors.New("agent is not running")
+	}
+
+	reconfigured, err := cat.Reconfigure(ctx, catalog.GlobalConfig{
+		TrustDomain: trustDomain.Host,
+	}, pluginConfigs)
+	if err != nil {
+		return err
+	}
+	a.c.Log.WithField(telemetry.PluginNames, reconfigured).Info("Plugin configuration reloaded")
+	return nil
+}
+
+func (a *Agent) setCatalog(cat *catalog.Repository) {
+	a.mtx.Lock()
+	a.cat = cat
+	a.mtx.Unlock()
+}
+
 // Status is used as a top-level health check for the Agent.
 func (a *Agent) Status() (interface{}, error) {
 	return nil, nil
@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/asn1"
 	"errors"
 	"fmt"
 	"io"
@@ -23,6 +24,7 @@ import (
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/version"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
@@ -56,6 +58,10 @@ type Config struct {
 	SVIDCachePath     string
 	Log               logrus.FieldLogger
 	ServerAddress     string
+
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes enforced on the server certificate.
+	PathPrefixesOID asn1.ObjectIdentifier
 }
 
 type attestor struct {
@@ -297,6 +303,7 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 			AttestationData: data.AttestationData,
 			Csr:             csr,
 			Response:        data.Response,
+			AgentVersion:    version.Version(),
 		}
 
 		if err := attestStream.Send(attestReq); err != nil {
@@ -346,9 +353,10 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 func (a *attestor) serverConn(ctx context.Context, bundle *bundleutil.Bundle) (*grpc.ClientConn, error) {
 	if bundle != nil {
 		return client.DialServer(ctx, client.DialServerConfig{
-			Address:     a.c.ServerAddress,
-			TrustDomain: a.c.TrustDomain.Host,
-			GetBundle:   bundle.RootCAs,
+			Address:         a.c.ServerAddress,
+			TrustDomain:     a.c.TrustDomain.Host,
+			GetBundle:       bundle.RootCAs,
+			PathPrefixesOID: a.c.PathPrefixesOID,
 		})
 	}
 
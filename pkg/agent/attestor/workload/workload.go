@@ -3,10 +3,15 @@ package attestor
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -32,6 +37,9 @@ type Config struct {
 	Catalog catalog.Catalog
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
+
+	// Status, if set, records the outcome of each plugin call
+	Status *StatusTracker
 }
 
 // Attest invokes all workload attestor plugins against the provided PID. If an error
@@ -82,9 +90,90 @@ func (wla *attestor) invokeAttestor(ctx context.Context, a catalog.WorkloadAttes
 	defer counter.Done(&err)
 
 	resp, err := a.Attest(ctx, req)
+	if wla.c.Status != nil {
+		wla.c.Status.record(a.Name(), err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("workload attestor %q failed: %v", a.Name(), err)
 	}
 
 	return resp.Selectors, nil
 }
+
+// PluginStatus is the outcome of the most recent calls to a workload attestor
+// plugin
+type PluginStatus struct {
+	Name        string    `json:"name"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitempty"`
+}
+
+// StatusTracker records the outcome of calls to the workload attestor plugins
+// across attestations.
+type StatusTracker struct {
+	clk clock.Clock
+
+	mu       sync.RWMutex
+	statuses map[string]*PluginStatus
+}
+
+func NewStatusTracker(clk clock.Clock) *StatusTracker {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &StatusTracker{
+		clk:      clk,
+		statuses: make(map[string]*PluginStatus),
+	}
+}
+
+func (t *StatusTracker) record(name string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status, ok := t.statuses[name]
+	if !ok {
+		status = &PluginStatus{Name: name}
+		t.statuses[name] = status
+	}
+	if err != nil {
+		status.LastError = err.Error()
+		status.LastErrorAt = t.clk.Now()
+	} else {
+		status.LastSuccess = t.clk.Now()
+	}
+}
+
+// Statuses returns the status of each plugin that has been called, sorted by
+// name.
+func (t *StatusTracker) Statuses() []PluginStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	statuses := make([]PluginStatus, 0, len(t.statuses))
+	for _, status := range t.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// CheckWorkloadAttestors is a health check that is degraded when the most
+// recent call to any workload attestor plugin failed.
+func (t *StatusTracker) CheckWorkloadAttestors() (interface{}, error) {
+	statuses := t.Statuses()
+
+	var failing []string
+	for _, status := range statuses {
+		if status.LastError != "" && status.LastErrorAt.After(status.LastSuccess) {
+			failing = append(failing, status.Name)
+		}
+	}
+	if len(failing) > 0 {
+		return statuses, health.Degraded(fmt.Errorf("workload attestors failing: %q", failing))
+	}
+	return statuses, nil
+}
@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_workload "github.com/spiffe/spire/pkg/common/telemetry/agent/workloadapi"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/fakes/fakeworkloadattestor"
@@ -137,3 +139,42 @@ func (s *WorkloadAttestorTestSuite) TestAttestWorkloadMetrics() {
 
 	s.Require().Equal(expected.AllMetrics(), metrics.AllMetrics())
 }
+
+func (s *WorkloadAttestorTestSuite) TestAttestWorkloadStatus() {
+	clk := clock.NewMock(s.T())
+	status := NewStatusTracker(clk)
+	s.attestor.c.Status = status
+
+	// both attestors succeed
+	s.attestor1.SetSelectors(1, nil)
+	s.attestor2.SetSelectors(1, nil)
+	s.attestor.Attest(ctx, 1)
+	details, err := status.CheckWorkloadAttestors()
+	s.Require().NoError(err)
+	s.Equal([]PluginStatus{
+		{Name: "fake1", LastSuccess: clk.Now()},
+		{Name: "fake2", LastSuccess: clk.Now()},
+	}, details)
+
+	// attestor1 fails
+	clk.Add(time.Minute)
+	s.attestor2.SetSelectors(2, nil)
+	s.attestor.Attest(ctx, 2)
+	details, err = status.CheckWorkloadAttestors()
+	s.Require().Error(err)
+	s.True(health.IsDegraded(err))
+	s.Equal(`workload attestors failing: ["fake1"]`, err.Error())
+	statuses := details.([]PluginStatus)
+	s.Require().Len(statuses, 2)
+	s.Equal(clk.Now(), statuses[0].LastErrorAt)
+	s.NotEmpty(statuses[0].LastError)
+	s.Equal(clk.Now(), statuses[1].LastSuccess)
+
+	// attestor1 recovers
+	clk.Add(time.Minute)
+	s.attestor1.SetSelectors(3, nil)
+	s.attestor2.SetSelectors(3, nil)
+	s.attestor.Attest(ctx, 3)
+	_, err = status.CheckWorkloadAttestors()
+	s.Require().NoError(err)
+}
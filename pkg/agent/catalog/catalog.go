@@ -21,6 +21,8 @@ import (
 	wa_k8s "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/k8s"
 	wa_unix "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/unix"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 )
 
 type Catalog interface {
@@ -99,6 +101,8 @@ func (p *Plugins) GetWorkloadAttestors() []WorkloadAttestor {
 
 type Config struct {
 	Log          logrus.FieldLogger
+	Metrics      telemetry.Metrics
+	Tracer       *tracing.Tracer
 	GlobalConfig GlobalConfig
 	PluginConfig HCLPluginConfigMap
 	HostServices []catalog.HostServiceServer
@@ -107,6 +111,26 @@ type Config struct {
 type Repository struct {
 	Catalog
 	catalog.Closer
+
+	loaded catalog.Catalog
+}
+
+// Reconfigure configures again the plugins whose data changed in the given
+// plugin configuration. Changes that require a restart are rejected. It
+// returns the names of the reconfigured plugins.
+func (r *Repository) Reconfigure(ctx context.Context, globalConfig GlobalConfig, pluginConfig HCLPluginConfigMap) ([]string, error) {
+	pluginConfigs, err := catalog.PluginConfigFromHCL(pluginConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.loaded.Reconfigure(ctx, globalConfig, pluginConfigs)
+}
+
+// CheckExternalPlugins is a health check over the external plugins in the
+// catalog.
+func (r *Repository) CheckExternalPlugins() (interface{}, error) {
+	return r.loaded.CheckExternalPlugins()
 }
 
 func Load(ctx context.Context, config Config) (*Repository, error) {
@@ -116,8 +140,10 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}
 
 	p := new(Plugins)
-	closer, err := catalog.Fill(ctx, catalog.Config{
+	loaded, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
+		Metrics:       config.Metrics,
+		Tracer:        config.Tracer,
 		GlobalConfig:  config.GlobalConfig,
 		PluginConfig:  pluginConfig,
 		KnownPlugins:  KnownPlugins(),
@@ -131,6 +157,7 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 
 	return &Repository{
 		Catalog: p,
-		Closer:  closer,
+		Closer:  loaded,
+		loaded:  loaded,
 	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/asn1"
 	"errors"
 	"io"
 	"net/url"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
@@ -36,10 +38,25 @@ type Client interface {
 	FetchUpdates(ctx context.Context, req *node.FetchX509SVIDRequest, forRotation bool) (*Update, error)
 	FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error)
 
+	// FetchDelegatedJWTKey requests the server to publish the given public
+	// key (PKIX, ASN.1 DER) as a JWT signing key delegated to this agent.
+	FetchDelegatedJWTKey(ctx context.Context, publicKey []byte) (*common.PublicKey, error)
+
+	// Reattest re-attests the agent over the authenticated channel and
+	// returns the refreshed node selectors. The attestation data and the
+	// responses to the server challenges are obtained through the given
+	// function, which is called with a nil challenge first.
+	Reattest(ctx context.Context, attest ReattestFunc) ([]*common.Selector, error)
+
 	// Release releases any resources that were held by this Client, if any.
 	Release()
 }
 
+// ReattestFunc returns the request to send to the server when re-attesting
+// the agent, either with the attestation data or with the response to the
+// given challenge.
+type ReattestFunc func(challenge []byte) (*node.ReattestRequest, error)
+
 // Config holds a client configuration
 type Config struct {
 	Addr        string
@@ -51,6 +68,10 @@ type Config struct {
 
 	// RotMtx is used to prevent the creation of new connections during SVID rotations
 	RotMtx *sync.RWMutex
+
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes enforced on the server certificate.
+	PathPrefixesOID asn1.ObjectIdentifier
 }
 
 type client struct {
@@ -98,7 +119,9 @@ func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDReques
 		return nil, ErrUnableToGetStream
 	}
 
-	// Send the request to the server using the stream.
+	// Send the request to the server using the stream. The agent version is
+	// reported so the server can keep track of it.
+	req.AgentVersion = version.Version()
 	if err := stream.Send(req); err != nil {
 		c.release(nodeConn)
 		return nil, errs.Wrap(err)
@@ -199,6 +222,97 @@ func (c *client) FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, err
 	}, nil
 }
 
+func (c *client) FetchDelegatedJWTKey(ctx context.Context, publicKey []byte) (*common.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	nodeClient, nodeConn, err := c.newNodeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer nodeConn.Release()
+
+	response, err := nodeClient.FetchDelegatedJWTKey(ctx, &node.FetchDelegatedJWTKeyRequest{
+		PublicKey: publicKey,
+	})
+	// We weren't able to make the request...close the client and return the error.
+	if err != nil {
+		c.release(nodeConn)
+		c.c.Log.WithError(err).Errorf("Failure fetching delegated JWT key. %v", ErrUnableToGetStream)
+		return nil, ErrUnableToGetStream
+	}
+
+	jwtKey := response.GetJwtKey()
+	if jwtKey == nil {
+		return nil, errors.New("delegated JWT key response missing key")
+	}
+	if jwtKey.Kid == "" {
+		return nil, errors.New("delegated JWT key missing key ID")
+	}
+	if jwtKey.NotAfter == 0 {
+		return nil, errors.New("delegated JWT key missing expiration")
+	}
+
+	return jwtKey, nil
+}
+
+func (c *client) Reattest(ctx context.Context, attest ReattestFunc) ([]*common.Selector, error) {
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	c.c.RotMtx.RLock()
+	defer c.c.RotMtx.RUnlock()
+
+	nodeClient, nodeConn, err := c.newNodeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer nodeConn.Release()
+
+	stream, err := nodeClient.Reattest(ctx)
+	// We weren't able to get a stream...close the client and return the error.
+	if err != nil {
+		c.release(nodeConn)
+		c.c.Log.WithError(err).Errorf("Failure re-attesting. %v", ErrUnableToGetStream)
+		return nil, ErrUnableToGetStream
+	}
+
+	resp := new(node.ReattestResponse)
+	for {
+		req, err := attest(resp.Challenge)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := stream.Send(req); err != nil {
+			c.release(nodeConn)
+			return nil, errs.Wrap(err)
+		}
+
+		resp, err = stream.Recv()
+		if err != nil {
+			return nil, errs.New("re-attesting to SPIRE server: %v", err)
+		}
+
+		if resp.Challenge == nil {
+			break
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		c.release(nodeConn)
+		return nil, errs.Wrap(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		c.c.Log.WithError(err).Warn("received unexpected result on trailing recv")
+	}
+
+	return resp.Selectors, nil
+}
+
 // Release the underlying connection.
 func (c *client) Release() {
 	c.release(nil)
@@ -231,8 +345,9 @@ func (c *client) newNodeClient(ctx context.Context) (node.NodeClient, *nodeConn,
 
 func (c *client) dial(ctx context.Context) (*grpc.ClientConn, error) {
 	return DialServer(ctx, DialServerConfig{
-		Address:     c.c.Addr,
-		TrustDomain: c.c.TrustDomain.Host,
+		Address:         c.c.Addr,
+		TrustDomain:     c.c.TrustDomain.Host,
+		PathPrefixesOID: c.c.PathPrefixesOID,
 		GetBundle: func() []*x509.Certificate {
 			_, _, bundle := c.c.KeysAndBundle()
 			return bundle
@@ -246,6 +246,71 @@ func TestFetchUpdatesReleaseConnectionIfItFailsToReceiveResponse(t *testing.T) {
 	assertNodeConnIsNil(t, client)
 }
 
+func TestReattest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeRc := mock_node.NewMockNode_ReattestClient(ctrl)
+	client := createClient(nodeClient)
+
+	data := &common.AttestationData{Type: "test", Data: []byte("data")}
+	selectors := []*common.Selector{{Type: "test", Value: "value"}}
+
+	nodeClient.EXPECT().Reattest(gomock.Any()).Return(nodeRc, nil)
+	nodeRc.EXPECT().Send(&node.ReattestRequest{AttestationData: data})
+	nodeRc.EXPECT().Recv().Return(&node.ReattestResponse{Challenge: []byte("challenge")}, nil)
+	nodeRc.EXPECT().Send(&node.ReattestRequest{Response: []byte("response")})
+	nodeRc.EXPECT().Recv().Return(&node.ReattestResponse{Selectors: selectors}, nil)
+	nodeRc.EXPECT().CloseSend()
+	nodeRc.EXPECT().Recv().Return(nil, io.EOF)
+
+	var challenges [][]byte
+	actual, err := client.Reattest(context.Background(), func(challenge []byte) (*node.ReattestRequest, error) {
+		challenges = append(challenges, challenge)
+		if challenge == nil {
+			return &node.ReattestRequest{AttestationData: data}, nil
+		}
+		return &node.ReattestRequest{Response: []byte("response")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, selectors, actual)
+	assert.Equal(t, [][]byte{nil, []byte("challenge")}, challenges)
+	assertNodeConnIsNotNil(t, client)
+}
+
+func TestReattestFailsToReceiveResponse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeRc := mock_node.NewMockNode_ReattestClient(ctrl)
+	client := createClient(nodeClient)
+
+	nodeClient.EXPECT().Reattest(gomock.Any()).Return(nodeRc, nil)
+	nodeRc.EXPECT().Send(gomock.Any())
+	nodeRc.EXPECT().Recv().Return(nil, errors.New("an error"))
+
+	selectors, err := client.Reattest(context.Background(), func([]byte) (*node.ReattestRequest, error) {
+		return &node.ReattestRequest{}, nil
+	})
+	assert.Nil(t, selectors)
+	assert.EqualError(t, err, "re-attesting to SPIRE server: an error")
+}
+
+func TestReattestReleaseConnectionIfItFailsToReattest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeClient.EXPECT().Reattest(gomock.Any()).Return(nil, errors.New("an error"))
+	client := createClient(nodeClient)
+
+	selectors, err := client.Reattest(context.Background(), func([]byte) (*node.ReattestRequest, error) {
+		return &node.ReattestRequest{}, nil
+	})
+	assert.Nil(t, selectors)
+	assert.Equal(t, ErrUnableToGetStream, err)
+	assertNodeConnIsNil(t, client)
+}
+
 func TestNewNodeClientFailsDial(t *testing.T) {
 	client := newClient(&Config{
 		KeysAndBundle: keysAndBundle,
@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/asn1"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/spiffe/go-spiffe/spiffe"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer/roundrobin"
 	"google.golang.org/grpc/credentials"
@@ -34,6 +36,11 @@ type DialServerConfig struct {
 	// certificate to present to the server during the TLS handshake.
 	GetAgentCertificate func() *tls.Certificate
 
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes of downstream CAs. If set, the server
+	// certificate must be permitted by the path prefixes of its chain.
+	PathPrefixesOID asn1.ObjectIdentifier
+
 	// dialContext is an optional constructor for the grpc client connection.
 	dialContext func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
 }
@@ -64,8 +71,11 @@ func DialServer(ctx context.Context, config DialServerConfig) (*grpc.ClientConn,
 				serverChain = append(serverChain, cert)
 			}
 
-			_, err := spiffe.VerifyPeerCertificate(serverChain, trustDomainRoots, spiffe.ExpectPeer(idutil.ServerID(config.TrustDomain)))
-			return err
+			chains, err := spiffe.VerifyPeerCertificate(serverChain, trustDomainRoots, spiffe.ExpectPeer(idutil.ServerID(config.TrustDomain)))
+			if err != nil {
+				return err
+			}
+			return x509util.VerifyChainsPathPrefixes(config.PathPrefixesOID, chains)
 		},
 	}
 
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"crypto/x509"
+	"encoding/asn1"
 	"net"
 	"net/url"
 	"time"
@@ -16,6 +17,9 @@ type Config struct {
 	// Address to bind the workload api to
 	BindAddress *net.UnixAddr
 
+	// Address to bind the admin api to. The admin api is disabled if unset.
+	AdminBindAddress *net.UnixAddr
+
 	// Directory to store runtime data
 	DataDir string
 
@@ -39,6 +43,33 @@ type Config struct {
 	// SyncInterval controls how often the agent sync synchronizer waits
 	SyncInterval time.Duration
 
+	// ReattestInterval controls how often the agent re-attests to refresh
+	// its node selectors. Zero disables re-attestation.
+	ReattestInterval time.Duration
+
+	// DelegatedJWTSigning enables minting JWT-SVIDs on the agent using a JWT
+	// key delegated by the server.
+	DelegatedJWTSigning bool
+
+	// JWTIssuer is used as the issuer claim in JWT-SVIDs minted by the agent.
+	JWTIssuer string
+
+	// JWTIncludeJTI adds a random token ID (jti) claim to JWT-SVIDs minted by
+	// the agent.
+	JWTIncludeJTI bool
+
+	// JWTSVIDReplayProtection, if true, makes the Workload API reject JWT-SVIDs
+	// without a token ID (jti) or whose token ID was already validated for the
+	// same audience. Token IDs are only remembered in memory by this agent, so
+	// a token can still be used once through every other agent, and again
+	// after this agent restarts.
+	JWTSVIDReplayProtection bool
+
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes of downstream CAs. If unset, the path prefixes
+	// are not enforced on the server certificate.
+	PathPrefixesOID asn1.ObjectIdentifier
+
 	// Trust domain and associated CA bundle
 	TrustDomain url.URL
 	TrustBundle []*x509.Certificate
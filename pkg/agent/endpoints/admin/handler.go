@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler implements the Agent Admin API interface
+type Handler struct {
+	Manager  manager.Manager
+	Attestor attestor.Attestor
+	Log      logrus.FieldLogger
+}
+
+// ListCachedEntries returns the registration entries in the cache along with
+// their SVIDs
+func (h *Handler) ListCachedEntries(ctx context.Context, req *admin.ListCachedEntriesRequest) (*admin.ListCachedEntriesResponse, error) {
+	identities := h.Manager.AllIdentities()
+
+	entries := make([]*admin.CachedEntry, 0, len(identities))
+	for _, identity := range identities {
+		entries = append(entries, cachedEntryFromIdentity(identity))
+	}
+	return &admin.ListCachedEntriesResponse{
+		Entries: entries,
+	}, nil
+}
+
+// GetAgentInfo returns the agent SVID, the trust domain bundle and the status
+// of the synchronization with the server
+func (h *Handler) GetAgentInfo(ctx context.Context, req *admin.GetAgentInfoRequest) (*admin.GetAgentInfoResponse, error) {
+	state := h.Manager.GetCurrentCredentials()
+	if len(state.SVID) == 0 {
+		return nil, status.Error(codes.Internal, "agent has no SVID")
+	}
+
+	resp := &admin.GetAgentInfoResponse{
+		SvidChain:     x509util.DERFromCertificates(state.SVID),
+		SvidExpiresAt: state.SVID[0].NotAfter.Unix(),
+	}
+	if uris := state.SVID[0].URIs; len(uris) > 0 {
+		resp.SpiffeId = uris[0].String()
+	}
+	if bundle := h.Manager.GetBundle(); bundle != nil {
+		resp.Bundle = bundle.Proto()
+	}
+
+	sync := h.Manager.SyncStatus()
+	resp.LastSyncError = sync.LastError
+	if !sync.LastSuccess.IsZero() {
+		resp.LastSyncSuccess = sync.LastSuccess.Unix()
+	}
+	if !sync.LastErrorAt.IsZero() {
+		resp.LastSyncErrorAt = sync.LastErrorAt.Unix()
+	}
+	return resp, nil
+}
+
+// DebugAttest attests the process with the given PID and returns its
+// selectors and the cached entries they match
+func (h *Handler) DebugAttest(ctx context.Context, req *admin.DebugAttestRequest) (*admin.DebugAttestResponse, error) {
+	if req.Pid <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "a positive PID is required")
+	}
+
+	log := h.Log.WithFields(logrus.Fields{
+		telemetry.Method: telemetry.DebugAttest,
+		telemetry.PID:    req.Pid,
+	})
+	log.Info("Attesting workload on behalf of admin")
+
+	selectors := h.Attestor.Attest(ctx, req.Pid)
+
+	var matching []*admin.CachedEntry
+	for _, identity := range h.Manager.MatchingIdentities(selectors) {
+		matching = append(matching, cachedEntryFromIdentity(identity))
+	}
+
+	return &admin.DebugAttestResponse{
+		Selectors:       selectors,
+		MatchingEntries: matching,
+	}, nil
+}
+
+// Sync synchronizes registration entries and SVIDs with the server
+func (h *Handler) Sync(ctx context.Context, req *admin.SyncRequest) (*admin.SyncResponse, error) {
+	log := h.Log.WithField(telemetry.Method, telemetry.Sync)
+	log.Info("Synchronizing with server on behalf of admin")
+
+	if err := h.Manager.Synchronize(ctx); err != nil {
+		log.WithError(err).Error("Failed to synchronize with server")
+		return nil, status.Errorf(codes.Unavailable, "failed to synchronize with server: %v", err)
+	}
+
+	return &admin.SyncResponse{
+		Entries: int32(len(h.Manager.AllIdentities())),
+	}, nil
+}
+
+// RotateSVID rotates the agent SVID
+func (h *Handler) RotateSVID(ctx context.Context, req *admin.RotateSVIDRequest) (*admin.RotateSVIDResponse, error) {
+	log := h.Log.WithField(telemetry.Method, telemetry.RotateSVID)
+	log.Info("Rotating agent SVID on behalf of admin")
+
+	if err := h.Manager.RotateSVID(ctx); err != nil {
+		log.WithError(err).Error("Failed to rotate agent SVID")
+		return nil, status.Errorf(codes.Unavailable, "failed to rotate agent SVID: %v", err)
+	}
+
+	state := h.Manager.GetCurrentCredentials()
+	resp := &admin.RotateSVIDResponse{
+		SvidChain: x509util.DERFromCertificates(state.SVID),
+	}
+	if len(state.SVID) > 0 {
+		resp.SvidExpiresAt = state.SVID[0].NotAfter.Unix()
+	}
+	return resp, nil
+}
+
+func cachedEntryFromIdentity(identity cache.Identity) *admin.CachedEntry {
+	entry := &admin.CachedEntry{
+		Entry: identity.Entry,
+	}
+	if len(identity.SVID) > 0 {
+		entry.SvidChain = x509util.DERFromCertificates(identity.SVID)
+		entry.SvidExpiresAt = identity.SVID[0].NotAfter.Unix()
+	}
+	return entry
+}
@@ -0,0 +1,211 @@
+package admin
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+	"github.com/spiffe/spire/proto/spire/common"
+	mock_manager "github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	expiresAt = time.Unix(1600000000, 0)
+
+	fooEntry = &common.RegistrationEntry{
+		EntryId:   "FOO",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	barEntry = &common.RegistrationEntry{
+		EntryId:   "BAR",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	}
+	fooSVID = []*x509.Certificate{{Raw: []byte{1}, NotAfter: expiresAt}}
+)
+
+func TestListCachedEntries(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().AllIdentities().Return([]cache.Identity{
+		{Entry: barEntry},
+		{Entry: fooEntry, SVID: fooSVID},
+	})
+
+	resp, err := h.ListCachedEntries(context.Background(), &admin.ListCachedEntriesRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &admin.ListCachedEntriesResponse{
+		Entries: []*admin.CachedEntry{
+			{Entry: barEntry},
+			{Entry: fooEntry, SvidChain: []byte{1}, SvidExpiresAt: expiresAt.Unix()},
+		},
+	}, resp)
+}
+
+func TestGetAgentInfo(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	agentID, err := url.Parse("spiffe://example.org/spire/agent/test")
+	require.NoError(t, err)
+	bundle := bundleutil.BundleFromRootCA("spiffe://example.org", &x509.Certificate{Raw: []byte{2}})
+	lastSuccess := expiresAt.Add(-time.Hour)
+	lastErrorAt := expiresAt.Add(-2 * time.Hour)
+
+	mgr.EXPECT().GetCurrentCredentials().Return(svid.State{
+		SVID: []*x509.Certificate{{Raw: []byte{3}, NotAfter: expiresAt, URIs: []*url.URL{agentID}}},
+	})
+	mgr.EXPECT().GetBundle().Return(bundle)
+	mgr.EXPECT().SyncStatus().Return(manager.SyncStatus{
+		LastSuccess: lastSuccess,
+		LastError:   "ohno",
+		LastErrorAt: lastErrorAt,
+	})
+
+	resp, err := h.GetAgentInfo(context.Background(), &admin.GetAgentInfoRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &admin.GetAgentInfoResponse{
+		SpiffeId:        "spiffe://example.org/spire/agent/test",
+		SvidChain:       []byte{3},
+		SvidExpiresAt:   expiresAt.Unix(),
+		Bundle:          bundle.Proto(),
+		LastSyncSuccess: lastSuccess.Unix(),
+		LastSyncError:   "ohno",
+		LastSyncErrorAt: lastErrorAt.Unix(),
+	}, resp)
+}
+
+func TestGetAgentInfoWithoutSVID(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().GetCurrentCredentials().Return(svid.State{})
+
+	_, err := h.GetAgentInfo(context.Background(), &admin.GetAgentInfoRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "agent has no SVID")
+}
+
+func TestDebugAttest(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().MatchingIdentities(fooEntry.Selectors).Return([]cache.Identity{
+		{Entry: fooEntry, SVID: fooSVID},
+	})
+
+	resp, err := h.DebugAttest(context.Background(), &admin.DebugAttestRequest{Pid: 1000})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &admin.DebugAttestResponse{
+		Selectors: fooEntry.Selectors,
+		MatchingEntries: []*admin.CachedEntry{
+			{Entry: fooEntry, SvidChain: []byte{1}, SvidExpiresAt: expiresAt.Unix()},
+		},
+	}, resp)
+}
+
+func TestDebugAttestWithoutMatches(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	// The PID is unknown to the attestor so no selectors are returned
+	mgr.EXPECT().MatchingIdentities(gomock.Nil()).Return(nil)
+
+	resp, err := h.DebugAttest(context.Background(), &admin.DebugAttestRequest{Pid: 2000})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Selectors)
+	assert.Empty(t, resp.MatchingEntries)
+}
+
+func TestDebugAttestInvalidPID(t *testing.T) {
+	h, _, done := setupHandler(t)
+	defer done()
+
+	_, err := h.DebugAttest(context.Background(), &admin.DebugAttestRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "a positive PID is required")
+}
+
+func TestSync(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().Synchronize(gomock.Any()).Return(nil)
+	mgr.EXPECT().AllIdentities().Return([]cache.Identity{{Entry: fooEntry}, {Entry: barEntry}})
+
+	resp, err := h.Sync(context.Background(), &admin.SyncRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Entries)
+}
+
+func TestSyncFails(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().Synchronize(gomock.Any()).Return(errors.New("ohno"))
+
+	_, err := h.Sync(context.Background(), &admin.SyncRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Unavailable, "failed to synchronize with server: ohno")
+}
+
+func TestRotateSVID(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().RotateSVID(gomock.Any()).Return(nil)
+	mgr.EXPECT().GetCurrentCredentials().Return(svid.State{
+		SVID: []*x509.Certificate{{Raw: []byte{4}, NotAfter: expiresAt}},
+	})
+
+	resp, err := h.RotateSVID(context.Background(), &admin.RotateSVIDRequest{})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &admin.RotateSVIDResponse{
+		SvidChain:     []byte{4},
+		SvidExpiresAt: expiresAt.Unix(),
+	}, resp)
+}
+
+func TestRotateSVIDFails(t *testing.T) {
+	h, mgr, done := setupHandler(t)
+	defer done()
+
+	mgr.EXPECT().RotateSVID(gomock.Any()).Return(errors.New("ohno"))
+
+	_, err := h.RotateSVID(context.Background(), &admin.RotateSVIDRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Unavailable, "failed to rotate agent SVID: ohno")
+}
+
+func setupHandler(t *testing.T) (*Handler, *mock_manager.MockManager, func()) {
+	mockCtrl := gomock.NewController(t)
+	log, _ := test.NewNullLogger()
+	mgr := mock_manager.NewMockManager(mockCtrl)
+
+	h := &Handler{
+		Manager: mgr,
+		Attestor: fakeAttestor{
+			1000: fooEntry.Selectors,
+		},
+		Log: log,
+	}
+	return h, mgr, mockCtrl.Finish
+}
+
+type fakeAttestor map[int32][]*common.Selector
+
+func (a fakeAttestor) Attest(ctx context.Context, pid int32) []*common.Selector {
+	return a[pid]
+}
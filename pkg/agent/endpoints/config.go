@@ -4,10 +4,12 @@ import (
 	"net"
 
 	"github.com/sirupsen/logrus"
+	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 
 	"google.golang.org/grpc"
 )
@@ -15,6 +17,9 @@ import (
 type Config struct {
 	BindAddr *net.UnixAddr
 
+	// AdminBindAddr, if set, is the address the admin API is served on
+	AdminBindAddr *net.UnixAddr
+
 	GRPCHook func(*grpc.Server) error
 
 	Catalog catalog.Catalog
@@ -23,8 +28,20 @@ type Config struct {
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
+	// Tracer records spans for the calls served by the endpoints. Optional.
+	Tracer *tracing.Tracer
+
 	// If true, an SDS server will be served over the UDS socket
 	EnableSDS bool
+
+	// If true, JWT-SVIDs validated through the Workload API must carry a
+	// token ID (jti) that has not been seen before for the same audience by
+	// this agent since it started
+	JWTSVIDReplayProtection bool
+
+	// AttestorStatus, if set, records the outcome of workload attestor
+	// plugin calls for health reporting
+	AttestorStatus *attestor.StatusTracker
 }
 
 func New(c *Config) *Endpoints {
@@ -10,16 +10,20 @@ import (
 	sds_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
 	"github.com/sirupsen/logrus"
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/endpoints/admin"
 	"github.com/spiffe/spire/pkg/agent/endpoints/sds"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	workload_pb "github.com/spiffe/go-spiffe/proto/spiffe/workload"
+	admin_pb "github.com/spiffe/spire/proto/spire/api/agent/admin"
 )
 
 type Server interface {
@@ -32,9 +36,25 @@ type Endpoints struct {
 }
 
 func (e *Endpoints) ListenAndServe(ctx context.Context) error {
+	tasks := []func(context.Context) error{
+		e.serveWorkloadAPI,
+	}
+	if e.c.AdminBindAddr != nil {
+		tasks = append(tasks, e.serveAdminAPI)
+	}
+	err := util.RunTasks(ctx, tasks...)
+	if err == context.Canceled {
+		err = nil
+	}
+	return err
+}
+
+func (e *Endpoints) serveWorkloadAPI(ctx context.Context) error {
 	server := grpc.NewServer(
 		grpc.Creds(peertracker.NewCredentials()),
 		grpc.UnknownServiceHandler(UnknownServiceHandler(e.c.Log)),
+		grpc.UnaryInterceptor(e.c.Tracer.UnaryServerInterceptor),
+		grpc.StreamInterceptor(e.c.Tracer.StreamServerInterceptor),
 	)
 
 	e.registerWorkloadAPI(server)
@@ -70,12 +90,46 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	}
 }
 
+func (e *Endpoints) serveAdminAPI(ctx context.Context) error {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(e.c.Tracer.UnaryServerInterceptor),
+		grpc.StreamInterceptor(e.c.Tracer.StreamServerInterceptor),
+	)
+
+	e.registerAdminAPI(server)
+
+	l, err := e.createAdminUDSListener()
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	e.c.Log.WithField(telemetry.Address, e.c.AdminBindAddr.String()).Info("Starting admin API")
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err = <-errChan:
+		return err
+	case <-ctx.Done():
+		e.c.Log.Info("Stopping admin API")
+		server.Stop()
+		<-errChan
+		return nil
+	}
+}
+
 func (e *Endpoints) registerWorkloadAPI(server *grpc.Server) {
 	w := &workload.Handler{
 		Manager: e.c.Manager,
 		Catalog: e.c.Catalog,
 		Log:     e.c.Log.WithField(telemetry.SubsystemName, telemetry.WorkloadAPI),
 		Metrics: e.c.Metrics,
+
+		AttestorStatus: e.c.AttestorStatus,
+	}
+	if e.c.JWTSVIDReplayProtection {
+		w.ReplayCache = jwtsvid.NewReplayCache(nil, jwtsvid.DefaultReplayCacheSize)
 	}
 
 	workload_pb.RegisterSpiffeWorkloadAPIServer(server, w)
@@ -86,6 +140,7 @@ func (e *Endpoints) registerSecretDiscoveryService(server *grpc.Server) {
 		Catalog: e.c.Catalog,
 		Log:     e.c.Log,
 		Metrics: e.c.Metrics,
+		Status:  e.c.AttestorStatus,
 	})
 
 	h := sds.NewHandler(sds.HandlerConfig{
@@ -97,6 +152,21 @@ func (e *Endpoints) registerSecretDiscoveryService(server *grpc.Server) {
 	sds_v2.RegisterSecretDiscoveryServiceServer(server, h)
 }
 
+func (e *Endpoints) registerAdminAPI(server *grpc.Server) {
+	log := e.c.Log.WithField(telemetry.SubsystemName, telemetry.AdminAPI)
+	h := &admin.Handler{
+		Manager: e.c.Manager,
+		Attestor: attestor.New(&attestor.Config{
+			Catalog: e.c.Catalog,
+			Log:     log,
+			Metrics: e.c.Metrics,
+			Status:  e.c.AttestorStatus,
+		}),
+		Log: log,
+	}
+	admin_pb.RegisterAdminServer(server, h)
+}
+
 func (e *Endpoints) createUDSListener() (net.Listener, error) {
 	// Remove uds if already exists
 	os.Remove(e.c.BindAddr.String())
@@ -112,6 +182,25 @@ func (e *Endpoints) createUDSListener() (net.Listener, error) {
 	return l, nil
 }
 
+// createAdminUDSListener creates the listener for the admin API. Unlike the
+// Workload API socket, the admin socket is only accessible by the user the
+// agent runs as.
+func (e *Endpoints) createAdminUDSListener() (net.Listener, error) {
+	// Remove uds if already exists
+	os.Remove(e.c.AdminBindAddr.String())
+
+	l, err := net.ListenUnix(e.c.AdminBindAddr.Network(), e.c.AdminBindAddr)
+	if err != nil {
+		return nil, fmt.Errorf("create admin UDS listener: %s", err)
+	}
+
+	if err := os.Chmod(e.c.AdminBindAddr.String(), 0600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("unable to change admin UDS permissions: %v", err)
+	}
+	return l, nil
+}
+
 func UnknownServiceHandler(log logrus.FieldLogger) grpc.StreamHandler {
 	const sdsMethodPrefix = "/envoy.service.discovery.v2.SecretDiscoveryService/"
 	logged := false
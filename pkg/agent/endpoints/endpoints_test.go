@@ -2,13 +2,25 @@ package endpoints
 
 import (
 	"context"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	api_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2"
 	sds_v2 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v2"
+	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/go-spiffe/proto/spiffe/workload"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/api/agent/admin"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	mock_manager "github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -65,3 +77,57 @@ func TestUnknownServiceHandler(t *testing.T) {
 	assert.Equal("unknown method /SpiffeWorkloadAPI/FetchJWTSVID", s.Message())
 	assert.Len(hook.AllEntries(), 0, "the Workload API RPC shouldn't be logged")
 }
+
+func TestAdminAPI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	entry := &common.RegistrationEntry{
+		EntryId:  "ENTRY",
+		SpiffeId: "spiffe://example.org/workload",
+	}
+	manager := mock_manager.NewMockManager(mockCtrl)
+	manager.EXPECT().AllIdentities().Return([]cache.Identity{{Entry: entry}})
+
+	log, _ := test.NewNullLogger()
+	adminAddr := &net.UnixAddr{Net: "unix", Name: filepath.Join(dir, "admin", "admin.sock")}
+	require.NoError(t, os.Mkdir(filepath.Dir(adminAddr.Name), 0700))
+	e := New(&Config{
+		BindAddr:      &net.UnixAddr{Net: "unix", Name: filepath.Join(dir, "agent.sock")},
+		AdminBindAddr: adminAddr,
+		Catalog:       fakeagentcatalog.New(),
+		Manager:       manager,
+		Log:           log,
+		Metrics:       telemetry.Blackhole{},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	}()
+
+	conn, err := grpc.Dial("unix://"+adminAddr.Name, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancelCall := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelCall()
+	resp, err := admin.NewAdminClient(conn).ListCachedEntries(ctx, &admin.ListCachedEntriesRequest{}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	spiretest.AssertProtoEqual(t, entry, resp.Entries[0].Entry)
+
+	// The admin socket is only accessible by the agent user
+	info, err := os.Stat(adminAddr.Name)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -40,6 +41,15 @@ type Handler struct {
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
+	// ReplayCache, if set, is used to reject validation of JWT-SVIDs that
+	// lack a token ID (jti) or whose token ID has already been seen for the
+	// same audience. It is in memory and only covers this agent.
+	ReplayCache *jwtsvid.ReplayCache
+
+	// AttestorStatus, if set, records the outcome of workload attestor
+	// plugin calls
+	AttestorStatus *attestor.StatusTracker
+
 	// tracks the number of outstanding connections
 	connections int32
 }
@@ -176,6 +186,17 @@ func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWT
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if h.ReplayCache != nil {
+		if err := h.checkReplay(spiffeID, req.Audience, claims); err != nil {
+			telemetry_workload.IncrValidJWTSVIDErrCounter(metrics)
+			log.WithFields(logrus.Fields{
+				telemetry.Error:    err.Error(),
+				telemetry.SPIFFEID: spiffeID,
+			}).Warn("Rejected JWT")
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
 	telemetry_workload.IncrValidJWTSVIDCounter(metrics, spiffeID, req.Audience)
 	log.WithField(telemetry.SPIFFEID, spiffeID).Debug("Successfully validated JWT")
 
@@ -323,7 +344,7 @@ func (h *Handler) sendJWTBundlesResponse(update *cache.WorkloadUpdate, stream wo
 func (h *Handler) composeJWTBundlesResponse(update *cache.WorkloadUpdate) (*workload.JWTBundlesResponse, error) {
 	bundles := make(map[string][]byte)
 	if update.Bundle != nil {
-		jwksBytes, err := bundleutil.Marshal(update.Bundle, bundleutil.NoX509SVIDKeys())
+		jwksBytes, err := bundleutil.Marshal(update.Bundle, bundleutil.NoX509SVIDKeys(), bundleutil.NoDelegatedJWTSVIDKeys())
 		if err != nil {
 			return nil, err
 		}
@@ -331,7 +352,7 @@ func (h *Handler) composeJWTBundlesResponse(update *cache.WorkloadUpdate) (*work
 	}
 
 	for _, federatedBundle := range update.FederatedBundles {
-		jwksBytes, err := bundleutil.Marshal(federatedBundle, bundleutil.NoX509SVIDKeys())
+		jwksBytes, err := bundleutil.Marshal(federatedBundle, bundleutil.NoX509SVIDKeys(), bundleutil.NoDelegatedJWTSVIDKeys())
 		if err != nil {
 			return nil, err
 		}
@@ -370,6 +391,7 @@ func (h *Handler) startCall(ctx context.Context) (int32, []*common.Selector, tel
 		Catalog: h.Catalog,
 		Log:     h.Log,
 		Metrics: h.Metrics,
+		Status:  h.AttestorStatus,
 	}
 
 	selectors := attestor.New(&config).Attest(ctx, watcher.PID())
@@ -419,12 +441,64 @@ func marshalBundle(certs []*x509.Certificate) []byte {
 	return bundle
 }
 
+// checkReplay records the token ID of a JWT-SVID validated for the given
+// audience, failing if the token has no ID or the ID was already seen for the
+// same audience before the token expired. A token issued for several
+// audiences can be used once by each of them.
+func (h *Handler) checkReplay(spiffeID, audience string, claims map[string]interface{}) error {
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return errors.New("token missing jti claim")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("token missing exp claim")
+	}
+
+	// Token IDs are only unique per issuing trust domain
+	trustDomainID, err := idutil.ParseSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload())
+	if err != nil {
+		return err
+	}
+	trustDomainID.Path = ""
+
+	// Quote the audience and token ID so that neither can spill into the other
+	key := fmt.Sprintf("%s#%q#%q", trustDomainID, audience, jti)
+	if err := h.ReplayCache.Observe(key, time.Unix(int64(exp), 0)); err != nil {
+		return fmt.Errorf("token with jti %q rejected: %v", jti, err)
+	}
+	return nil
+}
+
 func keyStoreFromBundles(bundles []*bundleutil.Bundle) jwtsvid.KeyStore {
 	trustDomainKeys := make(map[string]map[string]crypto.PublicKey)
+	trustDomainPathPrefixes := make(map[string]map[string][]string)
+	trustDomainSPIFFEIDs := make(map[string]map[string][]string)
+	trustDomainRevocations := make(map[string]jwtsvid.Revocations)
 	for _, bundle := range bundles {
 		trustDomainKeys[bundle.TrustDomainID()] = bundle.JWTSigningKeys()
+		trustDomainRevocations[bundle.TrustDomainID()] = jwtsvid.Revocations{
+			KeyIDs:   bundle.RevokedJWTKeyIDs(),
+			Subjects: bundle.RevokedJWTSubjects(),
+		}
+
+		// Keys published by downstream servers may be restricted to a subset
+		// of the trust domain and keys delegated to agents to the exact
+		// SPIFFE IDs they were issued for.
+		pathPrefixes := make(map[string][]string)
+		spiffeIDs := make(map[string][]string)
+		for _, jwtSigningKey := range bundle.Proto().JwtSigningKeys {
+			if len(jwtSigningKey.PathPrefixes) > 0 {
+				pathPrefixes[jwtSigningKey.Kid] = jwtSigningKey.PathPrefixes
+			}
+			if len(jwtSigningKey.SpiffeIds) > 0 {
+				spiffeIDs[jwtSigningKey.Kid] = jwtSigningKey.SpiffeIds
+			}
+		}
+		trustDomainPathPrefixes[bundle.TrustDomainID()] = pathPrefixes
+		trustDomainSPIFFEIDs[bundle.TrustDomainID()] = spiffeIDs
 	}
-	return jwtsvid.NewKeyStore(trustDomainKeys)
+	return jwtsvid.NewKeyStoreWithRevocations(trustDomainKeys, trustDomainPathPrefixes, trustDomainSPIFFEIDs, trustDomainRevocations)
 }
 
 func structFromValues(values map[string]interface{}) (*structpb.Struct, error) {
@@ -460,6 +460,13 @@ func (s *HandlerTestSuite) TestComposeJWTBundlesResponse() {
 				Kid:       "kid",
 				PkixBytes: pkixBytes,
 			},
+			{
+				// delegated keys are not served since workloads would accept
+				// them for any SPIFFE ID
+				Kid:       "agent.0123456789abcdef.kid",
+				PkixBytes: pkixBytes,
+				SpiffeIds: []string{"spiffe://has-keys.test/workload"},
+			},
 		},
 	})
 	s.Require().NoError(err)
@@ -511,6 +518,33 @@ func (s *HandlerTestSuite) TestValidateJWTSVID() {
 	})
 	s.Require().NoError(err)
 
+	// build up a bundle where the JWT signing public key was published by a
+	// downstream server constrained to another path
+	constrainedBundle, err := bundleutil.BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		JwtSigningKeys: []*common.PublicKey{
+			{
+				Kid:          "kid",
+				PkixBytes:    pkixBytes,
+				PathPrefixes: []string{"/downstream"},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	// build up a bundle where the JWT signing key has been revoked
+	revokedBundle, err := bundleutil.BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		JwtSigningKeys: []*common.PublicKey{
+			{
+				Kid:       "kid",
+				PkixBytes: pkixBytes,
+			},
+		},
+		RevokedJwtKeyIds: []string{"kid"},
+	})
+	s.Require().NoError(err)
+
 	// Sign a token with an issuer
 	jwtSigner := jwtsvid.NewSigner(jwtsvid.SignerConfig{
 		Issuer: "issuer",
@@ -630,6 +664,32 @@ func (s *HandlerTestSuite) TestValidateJWTSVID() {
 			},
 			issuer: "issuer",
 		},
+		{
+			name: "rejected by key path prefixes",
+			ctx:  makeContext(1),
+			req: &workload.ValidateJWTSVIDRequest{
+				Audience: "audience",
+				Svid:     svid,
+			},
+			workloadUpdate: &cache.WorkloadUpdate{
+				Bundle: constrainedBundle,
+			},
+			code: codes.InvalidArgument,
+			msg:  `public key "kid" is not permitted to sign for "spiffe://example.org/blog"`,
+		},
+		{
+			name: "rejected by revoked key",
+			ctx:  makeContext(1),
+			req: &workload.ValidateJWTSVIDRequest{
+				Audience: "audience",
+				Svid:     svid,
+			},
+			workloadUpdate: &cache.WorkloadUpdate{
+				Bundle: revokedBundle,
+			},
+			code: codes.InvalidArgument,
+			msg:  `public key "kid" has been revoked`,
+		},
 		{
 			name: "validate token without an issuer",
 			ctx:  makeContext(1),
@@ -723,6 +783,87 @@ func (s *HandlerTestSuite) TestValidateJWTSVID() {
 	}
 }
 
+func (s *HandlerTestSuite) TestValidateJWTSVIDReplayProtection() {
+	selectors := []*common.Selector{{Type: "foo", Value: "bar"}}
+	s.attestor.SetSelectors(1, selectors)
+	attestorStatusLabel := telemetry.Label{Name: telemetry.Status, Value: codes.OK.String()}
+	s.h.ReplayCache = jwtsvid.NewReplayCache(nil, 0)
+
+	pkixBytes, err := x509.MarshalPKIXPublicKey(jwtSigningKey.Public())
+	s.Require().NoError(err)
+	bundle, err := bundleutil.BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		JwtSigningKeys: []*common.PublicKey{
+			{
+				Kid:       "kid",
+				PkixBytes: pkixBytes,
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	signToken := func(includeJTI bool) string {
+		jwtSigner := jwtsvid.NewSigner(jwtsvid.SignerConfig{
+			IncludeJTI: includeJTI,
+		})
+		svid, err := jwtSigner.SignToken(
+			"spiffe://example.org/blog",
+			[]string{"audience-a", "audience-b"},
+			time.Now().Add(time.Minute),
+			jwtSigningKey,
+			"kid",
+		)
+		s.Require().NoError(err)
+		return svid
+	}
+	svid := signToken(true)
+	svidNoJTI := signToken(false)
+
+	validate := func(svid, audience string) (*workload.ValidateJWTSVIDResponse, error) {
+		s.manager.EXPECT().FetchWorkloadUpdate(selectors).Return(&cache.WorkloadUpdate{Bundle: bundle})
+		setupMetricsCommonExpectations(s.metrics, len(selectors), attestorStatusLabel)
+		return s.h.ValidateJWTSVID(makeContext(1), &workload.ValidateJWTSVIDRequest{
+			Audience: audience,
+			Svid:     svid,
+		})
+	}
+	expectValid := func(audience string) {
+		s.metrics.EXPECT().IncrCounterWithLabels([]string{telemetry.WorkloadAPI, telemetry.ValidateJWTSVID}, float32(1), []telemetry.Label{
+			{Name: telemetry.Subject, Value: "spiffe://example.org/blog"},
+			{Name: telemetry.Audience, Value: audience},
+		})
+	}
+
+	// first use succeeds
+	expectValid("audience-a")
+	resp, err := validate(svid, "audience-a")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Claims.Fields["jti"])
+
+	// replay is rejected
+	s.metrics.EXPECT().IncrCounter([]string{telemetry.WorkloadAPI, telemetry.ValidateJWTSVID}, float32(1))
+	resp, err = validate(svid, "audience-a")
+	spiretest.RequireGRPCStatusContains(s.T(), err, codes.InvalidArgument, "has already been used")
+	s.Require().Nil(resp)
+
+	// using the token for audience A does not burn it for audience B
+	expectValid("audience-b")
+	resp, err = validate(svid, "audience-b")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Claims.Fields["jti"])
+
+	s.metrics.EXPECT().IncrCounter([]string{telemetry.WorkloadAPI, telemetry.ValidateJWTSVID}, float32(1))
+	resp, err = validate(svid, "audience-b")
+	spiretest.RequireGRPCStatusContains(s.T(), err, codes.InvalidArgument, "has already been used")
+	s.Require().Nil(resp)
+
+	// tokens without a jti are rejected
+	s.metrics.EXPECT().IncrCounter([]string{telemetry.WorkloadAPI, telemetry.ValidateJWTSVID}, float32(1))
+	resp, err = validate(svidNoJTI, "audience-a")
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "token missing jti claim")
+	s.Require().Nil(resp)
+}
+
 func (s *HandlerTestSuite) TestStructFromValues() {
 	expected := &structpb.Struct{
 		Fields: map[string]*structpb.Value{
@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	workload_attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/rotationutil"
+)
+
+const (
+	// subsystemCheckInterval is how often the subsystem health checks run
+	subsystemCheckInterval = 30 * time.Second
+
+	// svidRotationGrace is how long the agent SVID may be past its rotation
+	// threshold before it is reported as degraded, giving the rotator time
+	// to act.
+	svidRotationGrace = 2 * svid.DefaultRotatorInterval
+)
+
+// ServerSyncStatus is the detail of the server sync health check
+type ServerSyncStatus struct {
+	LastSuccess             time.Time `json:"last_success,omitempty"`
+	SecondsSinceLastSuccess int64     `json:"seconds_since_last_success,omitempty"`
+	LastError               string    `json:"last_error,omitempty"`
+	LastErrorAt             time.Time `json:"last_error_at,omitempty"`
+}
+
+// SVIDStatus is the detail of the agent SVID health check
+type SVIDStatus struct {
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTLSeconds int64     `json:"ttl_seconds"`
+}
+
+// addSubsystemHealthChecks registers health checks for the agent subsystems
+// in addition to the top-level agent check.
+func (a *Agent) addSubsystemHealthChecks(healthChecks *health.Checker, mgr manager.Manager, attestorStatus *workload_attestor.StatusTracker, cat *catalog.Repository) error {
+	clk := clock.New()
+	checks := map[string]health.CheckFunc{
+		"server_sync":        serverSyncCheck(mgr, clk),
+		"svid":               svidCheck(mgr, clk),
+		"workload_attestors": attestorStatus.CheckWorkloadAttestors,
+		"plugins":            cat.CheckExternalPlugins,
+	}
+
+	for name, check := range checks {
+		if err := healthChecks.AddCheck(name, check, subsystemCheckInterval); err != nil {
+			return fmt.Errorf("failed adding %s healthcheck: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// serverSyncCheck reports the time since the last successful sync with the
+// server. The check is degraded while the server is unreachable since
+// workloads keep being served from the cache.
+func serverSyncCheck(mgr manager.Manager, clk clock.Clock) health.CheckFunc {
+	return func() (interface{}, error) {
+		sync := mgr.SyncStatus()
+		status := ServerSyncStatus{
+			LastSuccess: sync.LastSuccess,
+			LastError:   sync.LastError,
+			LastErrorAt: sync.LastErrorAt,
+		}
+		if !sync.LastSuccess.IsZero() {
+			status.SecondsSinceLastSuccess = int64(clk.Now().Sub(sync.LastSuccess) / time.Second)
+		}
+		if sync.LastError != "" && sync.LastErrorAt.After(sync.LastSuccess) {
+			return status, health.Degraded(fmt.Errorf("unable to sync with server: %s", sync.LastError))
+		}
+		return status, nil
+	}
+}
+
+// svidCheck reports the expiry margin of the agent SVID. The check is
+// unhealthy once the SVID has expired, since the agent can no longer talk to
+// the server, and degraded when the SVID is overdue for rotation.
+func svidCheck(mgr manager.Manager, clk clock.Clock) health.CheckFunc {
+	return func() (interface{}, error) {
+		state := mgr.GetCurrentCredentials()
+		if len(state.SVID) == 0 {
+			return nil, errors.New("agent has no SVID")
+		}
+		cert := state.SVID[0]
+
+		now := clk.Now()
+		status := SVIDStatus{
+			ExpiresAt:  cert.NotAfter,
+			TTLSeconds: int64(cert.NotAfter.Sub(now) / time.Second),
+		}
+		switch {
+		case !now.Before(cert.NotAfter):
+			return status, fmt.Errorf("agent SVID expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+		case rotationutil.ShouldRotateX509(now.Add(-svidRotationGrace), cert):
+			return status, health.Degraded(errors.New("agent SVID is overdue for rotation"))
+		}
+		return status, nil
+	}
+}
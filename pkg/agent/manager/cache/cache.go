@@ -141,8 +141,8 @@ func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics t
 	}
 }
 
-// Identities is only used by manager tests
-// TODO: We should remove this and find a better way
+// Identities returns the cached identities that have an SVID, sorted by entry
+// ID.
 func (c *Cache) Identities() []Identity {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -383,6 +383,42 @@ func (c *Cache) UpdateSVIDs(update *UpdateSVIDs) {
 	c.notifyBySelectors(notifySet)
 }
 
+// AllIdentities returns an identity for every cached registration entry,
+// sorted by entry ID. Entries that do not have an SVID yet are returned
+// without SVID and private key.
+func (c *Cache) AllIdentities() []Identity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := make([]Identity, 0, len(c.records))
+	for _, record := range c.records {
+		if record.svid == nil {
+			out = append(out, Identity{Entry: record.entry})
+			continue
+		}
+		out = append(out, makeIdentity(record))
+	}
+	sortIdentities(out)
+	return out
+}
+
+// HasJWTClaims returns true if any cached registration entry for the given
+// SPIFFE ID has custom JWT-SVID claims or claim templates.
+func (c *Cache) HasJWTClaims(spiffeID string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, record := range c.records {
+		if record.entry.SpiffeId != spiffeID {
+			continue
+		}
+		if len(record.entry.JwtClaims) > 0 || len(record.entry.JwtClaimTemplates) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetStaleEntries obtains a list of stale entries
 func (c *Cache) GetStaleEntries() []*StaleEntry {
 	c.mu.Lock()
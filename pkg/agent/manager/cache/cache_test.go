@@ -428,6 +428,43 @@ func TestSubcriberOnlyGetsEntriesWithSVID(t *testing.T) {
 	})
 }
 
+func TestAllIdentities(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+	}, nil)
+	cache.UpdateSVIDs(&UpdateSVIDs{
+		X509SVIDs: makeX509SVIDs(foo),
+	})
+
+	// Only FOO has an SVID but both entries are returned, sorted by ID
+	assert.Equal(t, []Identity{{Entry: bar}, {Entry: foo}}, cache.AllIdentities())
+	assert.Equal(t, []Identity{{Entry: foo}}, cache.Identities())
+}
+
+func TestHasJWTClaims(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	bar.JwtClaims = map[string]string{"foo": "bar"}
+	baz := makeRegistrationEntry("BAZ", "C")
+	baz.JwtClaimTemplates = map[string]string{"ns": `{{ selector "k8s:ns" }}`}
+	cache.UpdateEntries(&UpdateEntries{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar, baz),
+	}, nil)
+
+	assert.False(t, cache.HasJWTClaims(foo.SpiffeId))
+	assert.True(t, cache.HasJWTClaims(bar.SpiffeId))
+	assert.True(t, cache.HasJWTClaims(baz.SpiffeId))
+	assert.False(t, cache.HasJWTClaims("spiffe://domain.test/unknown"))
+}
+
 func TestSubscribersDoNotBlockNotifications(t *testing.T) {
 	cache := newTestCache()
 
@@ -3,6 +3,7 @@ package manager
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/asn1"
 	"fmt"
 	"net/url"
 	"sync"
@@ -13,7 +14,9 @@ import (
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 )
 
 // Config holds a cache manager configuration
@@ -26,12 +29,32 @@ type Config struct {
 	TrustDomain      url.URL
 	Log              logrus.FieldLogger
 	Metrics          telemetry.Metrics
+	Tracer           *tracing.Tracer
 	ServerAddr       string
 	SVIDCachePath    string
 	BundleCachePath  string
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
 
+	// ReattestInterval is how often the agent re-attests so the server
+	// refreshes its node selectors. Zero disables re-attestation.
+	ReattestInterval time.Duration
+
+	// DelegatedJWTSigning enables minting JWT-SVIDs locally using a JWT key
+	// delegated by the server.
+	DelegatedJWTSigning bool
+
+	// JWTIssuer is used as the issuer claim in JWT-SVIDs minted locally.
+	JWTIssuer string
+
+	// JWTIncludeJTI adds a random token ID (jti) claim to JWT-SVIDs minted
+	// locally.
+	JWTIncludeJTI bool
+
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes enforced on the server certificate.
+	PathPrefixesOID asn1.ObjectIdentifier
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
@@ -73,6 +96,8 @@ func newManager(c *Config) (*manager, error) {
 		TrustDomain:  c.TrustDomain,
 		Interval:     c.RotationInterval,
 		Clk:          c.Clk,
+
+		PathPrefixesOID: c.PathPrefixesOID,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -86,6 +111,11 @@ func newManager(c *Config) (*manager, error) {
 		bundleCachePath: c.BundleCachePath,
 		client:          client,
 		clk:             c.Clk,
+		jwtSigner: jwtsvid.NewSigner(jwtsvid.SignerConfig{
+			Clock:      c.Clk,
+			Issuer:     c.JWTIssuer,
+			IncludeJTI: c.JWTIncludeJTI,
+		}),
 	}
 
 	return m, nil
@@ -0,0 +1,164 @@
+package manager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// delegatedJWTSVIDTTL is the TTL of the JWT-SVIDs minted by the agent using
+// a delegated JWT key. It matches the default TTL used by the server.
+const delegatedJWTSVIDTTL = 5 * time.Minute
+
+// delegatedJWTKey is a JWT signing key delegated to the agent by the server.
+type delegatedJWTKey struct {
+	key      *ecdsa.PrivateKey
+	kid      string
+	issuedAt time.Time
+	notAfter time.Time
+
+	// spiffeIDs are the SPIFFE IDs the key can sign for. The server scopes
+	// delegated keys to the entries the agent is authorized for when the key
+	// is issued.
+	spiffeIDs []string
+}
+
+// canSign returns true if validators will accept JWT-SVIDs signed with the
+// key for the given SPIFFE ID.
+func (k *delegatedJWTKey) canSign(spiffeID string) bool {
+	for _, id := range k.spiffeIDs {
+		if id == spiffeID {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRotate returns true if the key has less than half of its lifetime
+// left.
+func (k *delegatedJWTKey) shouldRotate(now time.Time) bool {
+	return !now.Before(k.issuedAt.Add(k.notAfter.Sub(k.issuedAt) / 2))
+}
+
+func (k *delegatedJWTKey) expired(now time.Time) bool {
+	return !now.Before(k.notAfter)
+}
+
+// syncDelegatedJWTKey keeps the delegated JWT key up to date. A key obtained
+// from the server is not used for signing until it shows up in the trust
+// domain bundle, so workloads are never handed tokens that validators are
+// unable to verify. Keys that disappear from the bundle (e.g. revoked when the
+// agent was evicted) or have expired are dropped.
+func (m *manager) syncDelegatedJWTKey(ctx context.Context) {
+	now := m.clk.Now()
+
+	published := make(map[string]bool)
+	if bundle := m.cache.Bundle(); bundle != nil {
+		for kid := range bundle.JWTSigningKeys() {
+			published[kid] = true
+		}
+	}
+
+	m.jwtKeyMtx.Lock()
+	if m.jwtKey != nil && (m.jwtKey.expired(now) || !published[m.jwtKey.kid]) {
+		m.c.Log.WithField(telemetry.Kid, m.jwtKey.kid).Info("Delegated JWT key is no longer valid")
+		m.jwtKey = nil
+	}
+	if m.pendingJWTKey != nil {
+		switch {
+		case m.pendingJWTKey.expired(now):
+			m.pendingJWTKey = nil
+		case published[m.pendingJWTKey.kid]:
+			m.c.Log.WithField(telemetry.Kid, m.pendingJWTKey.kid).Info("Delegated JWT key activated")
+			m.jwtKey = m.pendingJWTKey
+			m.pendingJWTKey = nil
+		}
+	}
+	needsKey := m.pendingJWTKey == nil && (m.jwtKey == nil || m.jwtKey.shouldRotate(now))
+	m.jwtKeyMtx.Unlock()
+
+	if !needsKey {
+		return
+	}
+
+	pendingJWTKey, err := m.fetchDelegatedJWTKey(ctx)
+	if err != nil {
+		m.c.Log.WithError(err).Warn("Unable to obtain delegated JWT key")
+		return
+	}
+
+	m.jwtKeyMtx.Lock()
+	m.pendingJWTKey = pendingJWTKey
+	m.jwtKeyMtx.Unlock()
+}
+
+func (m *manager) fetchDelegatedJWTKey(ctx context.Context) (*delegatedJWTKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKey, err := m.client.FetchDelegatedJWTKey(ctx, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delegatedJWTKey{
+		key:       key,
+		kid:       jwtKey.Kid,
+		issuedAt:  m.clk.Now(),
+		notAfter:  time.Unix(jwtKey.NotAfter, 0),
+		spiffeIDs: jwtKey.SpiffeIds,
+	}, nil
+}
+
+// signJWTSVID mints a JWT-SVID using the active delegated JWT key. It returns
+// false if there is no active key, the key is not permitted to sign for the
+// SPIFFE ID (e.g. the entry was created after the key was issued), the entry
+// has custom claims (which are rendered by the server) or the token could not
+// be signed, in which case the caller is expected to fall back to the server.
+func (m *manager) signJWTSVID(spiffeID string, audience []string, now time.Time) (*client.JWTSVID, bool) {
+	if m.cache.HasJWTClaims(spiffeID) {
+		return nil, false
+	}
+
+	m.jwtKeyMtx.RLock()
+	jwtKey := m.jwtKey
+	m.jwtKeyMtx.RUnlock()
+
+	if jwtKey == nil || jwtKey.expired(now) || !jwtKey.canSign(spiffeID) {
+		return nil, false
+	}
+
+	expiresAt := now.Add(delegatedJWTSVIDTTL)
+	if expiresAt.After(jwtKey.notAfter) {
+		expiresAt = jwtKey.notAfter
+	}
+
+	token, err := m.jwtSigner.SignToken(spiffeID, audience, expiresAt, jwtKey.key, jwtKey.kid)
+	if err != nil {
+		m.c.Log.WithError(err).WithFields(logrus.Fields{
+			telemetry.SPIFFEID: spiffeID,
+			telemetry.Kid:      jwtKey.kid,
+		}).Warn("Unable to sign JWT-SVID with delegated JWT key")
+		return nil, false
+	}
+
+	return &client.JWTSVID{
+		Token:     token,
+		IssuedAt:  now,
+		ExpiresAt: expiresAt,
+	}, true
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/andres-erbsen/clock"
 	observer "github.com/imkira/go-observer"
@@ -16,11 +17,13 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/rotationutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 // Cache Manager errors
@@ -68,6 +71,37 @@ type Manager interface {
 	// FetchJWTSVID returns a JWT SVID for the specified SPIFFEID and audience. If there
 	// is no JWT cached, the manager will get one signed upstream.
 	FetchJWTSVID(ctx context.Context, spiffeID string, audience []string) (*client.JWTSVID, error)
+
+	// SyncStatus returns the status of the synchronization with the server
+	SyncStatus() SyncStatus
+
+	// Synchronize synchronizes registration entries and SVIDs with the server
+	// immediately instead of waiting for the next synchronization interval.
+	Synchronize(ctx context.Context) error
+
+	// RotateSVID rotates the agent SVID immediately, regardless of its
+	// expiration.
+	RotateSVID(ctx context.Context) error
+
+	// AllIdentities returns all of the cached identities, including the
+	// ones whose SVID has not been signed yet.
+	AllIdentities() []cache.Identity
+
+	// GetBundle returns the bundle of the agent trust domain
+	GetBundle() *cache.Bundle
+}
+
+// SyncStatus is the status of the synchronization of registration entries
+// and SVIDs with the server
+type SyncStatus struct {
+	// LastSuccess is the time of the last successful synchronization.
+	LastSuccess time.Time
+
+	// LastError is the error returned by the last failed synchronization.
+	LastError string
+
+	// LastErrorAt is the time of the last failed synchronization.
+	LastErrorAt time.Time
 }
 
 type manager struct {
@@ -91,6 +125,21 @@ type manager struct {
 	client client.Client
 
 	clk clock.Clock
+
+	// JWT signing keys delegated by the server, protected by jwtKeyMtx. The
+	// pending key becomes the active one once it is published in the bundle.
+	jwtKeyMtx     sync.RWMutex
+	jwtKey        *delegatedJWTKey
+	pendingJWTKey *delegatedJWTKey
+	jwtSigner     *jwtsvid.Signer
+
+	// Synchronization status, protected by syncStatusMtx
+	syncStatusMtx sync.RWMutex
+	syncStatus    SyncStatus
+
+	// syncMtx serializes the periodic synchronization with the ones
+	// requested through Synchronize
+	syncMtx sync.Mutex
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
@@ -110,11 +159,17 @@ func (m *manager) Initialize(ctx context.Context) error {
 func (m *manager) Run(ctx context.Context) error {
 	defer m.client.Release()
 
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		m.runSynchronizer,
 		m.runSVIDObserver,
 		m.runBundleObserver,
-		m.svid.Run)
+		m.svid.Run,
+	}
+	if m.c.ReattestInterval > 0 {
+		tasks = append(tasks, m.runReattestor)
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 	if err != nil && err != context.Canceled {
 		m.c.Log.WithError(err).Error("cache manager crashed")
 		return err
@@ -124,6 +179,39 @@ func (m *manager) Run(ctx context.Context) error {
 	return nil
 }
 
+func (m *manager) SyncStatus() SyncStatus {
+	m.syncStatusMtx.RLock()
+	defer m.syncStatusMtx.RUnlock()
+	return m.syncStatus
+}
+
+func (m *manager) Synchronize(ctx context.Context) error {
+	return m.synchronize(ctx)
+}
+
+func (m *manager) RotateSVID(ctx context.Context) error {
+	return m.svid.Rotate(ctx)
+}
+
+func (m *manager) AllIdentities() []cache.Identity {
+	return m.cache.AllIdentities()
+}
+
+func (m *manager) GetBundle() *cache.Bundle {
+	return m.cache.Bundle()
+}
+
+func (m *manager) recordSync(err error) {
+	m.syncStatusMtx.Lock()
+	defer m.syncStatusMtx.Unlock()
+	if err != nil {
+		m.syncStatus.LastError = err.Error()
+		m.syncStatus.LastErrorAt = m.clk.Now()
+	} else {
+		m.syncStatus.LastSuccess = m.clk.Now()
+	}
+}
+
 func (m *manager) SubscribeToCacheChanges(selectors cache.Selectors) cache.Subscriber {
 	return m.cache.SubscribeToWorkloadUpdates(selectors)
 }
@@ -165,6 +253,14 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 		return cachedSVID, nil
 	}
 
+	if newSVID, ok := m.signJWTSVID(spiffeID, audience, now); ok {
+		// JWT-SVIDs with a token ID are meant to be used once
+		if !m.c.JWTIncludeJTI {
+			m.cache.SetJWTSVID(spiffeID, audience, newSVID)
+		}
+		return newSVID, nil
+	}
+
 	newSVID, err := m.client.FetchJWTSVID(ctx, &node.JSR{
 		SpiffeId: spiffeID,
 		Audience: audience,
@@ -180,10 +276,27 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 		return cachedSVID, nil
 	}
 
-	m.cache.SetJWTSVID(spiffeID, audience, newSVID)
+	// JWT-SVIDs with a token ID are meant to be used once
+	if !hasTokenID(newSVID.Token) {
+		m.cache.SetJWTSVID(spiffeID, audience, newSVID)
+	}
 	return newSVID, nil
 }
 
+// hasTokenID returns true if the JWT-SVID carries a token ID (jti) claim.
+// The token comes from the server, so its signature is not verified.
+func hasTokenID(token string) bool {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return false
+	}
+	claims := new(jwt.Claims)
+	if err := parsed.UnsafeClaimsWithoutVerification(claims); err != nil {
+		return false
+	}
+	return claims.ID != ""
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
 	for {
 		select {
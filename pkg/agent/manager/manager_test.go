@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -23,8 +25,11 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/disk"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	agentnodeattestor "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/spire/api/node"
@@ -33,8 +38,11 @@ import (
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/fakes/fakeagentnodeattestor"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -978,6 +986,368 @@ func TestFetchJWTSVID(t *testing.T) {
 	require.Nil(t, svid)
 }
 
+func TestFetchJWTSVIDWithDelegatedJWTKey(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		fetchX509SVID: func(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+			resp := newFetchX509SVIDResponse(nil, nil, h.bundle)
+			resp.SvidUpdate.RegistrationEntries = []*common.RegistrationEntry{
+				{
+					EntryId:   "claims",
+					SpiffeId:  "spiffe://example.org/workload/claims",
+					ParentId:  "spiffe://example.org/spire/agent/join_token/abcd",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+					JwtClaims: map[string]string{"foo": "bar"},
+				},
+			}
+			return stream.Send(resp)
+		},
+		fetchDelegatedJWTKey: func(h *mockNodeAPIHandler, req *node.FetchDelegatedJWTKeyRequest) (*node.FetchDelegatedJWTKeyResponse, error) {
+			publicKey, err := x509.ParsePKIXPublicKey(req.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bundle.AppendJWTSigningKey("delegated", publicKey); err != nil {
+				return nil, err
+			}
+			return &node.FetchDelegatedJWTKeyResponse{
+				JwtKey: &common.PublicKey{
+					PkixBytes: req.PublicKey,
+					Kid:       "delegated",
+					NotAfter:  h.clk.Now().Add(time.Hour).Unix(),
+					SpiffeIds: []string{"spiffe://example.org/workload", "spiffe://example.org/workload/claims"},
+				},
+			}, nil
+		},
+		svidTTL: 200,
+	}, mockClk)
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	c := &Config{
+		ServerAddr:          l.Addr().String(),
+		SVID:                baseSVID,
+		SVIDKey:             baseSVIDKey,
+		Log:                 testLogger,
+		TrustDomain:         trustDomainID,
+		SVIDCachePath:       path.Join(dir, "svid.der"),
+		BundleCachePath:     path.Join(dir, "bundle.der"),
+		Bundle:              apiHandler.bundle,
+		Metrics:             &telemetry.Blackhole{},
+		Clk:                 mockClk,
+		DelegatedJWTSigning: true,
+		JWTIssuer:           "https://example.org",
+	}
+
+	m := makeManager(t, c)
+
+	spiffeID := "spiffe://example.org/workload"
+
+	// the delegated key is pending until it shows up in the bundle, so the
+	// JWT-SVID is requested to the server (which fails)
+	require.NoError(t, m.synchronize(context.Background()))
+	svid, err := m.FetchJWTSVID(context.Background(), spiffeID, []string{"foo"})
+	require.Error(t, err)
+	require.Nil(t, svid)
+
+	// once the key is in the bundle the JWT-SVID is minted locally
+	require.NoError(t, m.synchronize(context.Background()))
+	svid, err = m.FetchJWTSVID(context.Background(), spiffeID, []string{"foo"})
+	require.NoError(t, err)
+	require.Equal(t, mockClk.Now().Add(delegatedJWTSVIDTTL).Unix(), svid.ExpiresAt.Unix())
+
+	token, err := jwt.ParseSigned(svid.Token)
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	require.Equal(t, "delegated", token.Headers[0].KeyID)
+	claims := jwt.Claims{}
+	require.NoError(t, token.Claims(apiHandler.bundle.JWTSigningKeys()["delegated"], &claims))
+	require.Equal(t, spiffeID, claims.Subject)
+	require.Equal(t, "https://example.org", claims.Issuer)
+	require.Equal(t, jwt.Audience{"foo"}, claims.Audience)
+
+	// SPIFFE IDs the key was not issued for, including those nested under
+	// the ones it was issued for, are requested to the server (which fails)
+	svid, err = m.FetchJWTSVID(context.Background(), "spiffe://example.org/other", []string{"foo"})
+	require.Error(t, err)
+	require.Nil(t, svid)
+	svid, err = m.FetchJWTSVID(context.Background(), "spiffe://example.org/workload/nested", []string{"foo"})
+	require.Error(t, err)
+	require.Nil(t, svid)
+
+	// entries with custom claims are requested to the server (which fails)
+	// since the agent does not render them
+	svid, err = m.FetchJWTSVID(context.Background(), "spiffe://example.org/workload/claims", []string{"foo"})
+	require.Error(t, err)
+	require.Nil(t, svid)
+
+	// the key is dropped once it is removed from the bundle (i.e. revoked)
+	// and a new one is requested
+	apiHandler.bundle = bundleutil.BundleFromRootCA("spiffe://"+trustDomain, apiHandler.ca())
+	require.NoError(t, m.synchronize(context.Background()))
+	svid, err = m.FetchJWTSVID(context.Background(), spiffeID, []string{"bar"})
+	require.Error(t, err)
+	require.Nil(t, svid)
+}
+
+func TestFetchJWTSVIDWithTokenID(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	mockClk := clock.NewMock(t)
+	serverSigner := jwtsvid.NewSigner(jwtsvid.SignerConfig{
+		Clock:      mockClk,
+		IncludeJTI: true,
+	})
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		fetchX509SVID: func(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+			return stream.Send(newFetchX509SVIDResponse(nil, nil, h.bundle))
+		},
+		fetchJWTSVID: func(h *mockNodeAPIHandler, req *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+			expiresAt := h.clk.Now().Add(time.Minute)
+			token, err := serverSigner.SignToken(req.Jsr.SpiffeId, req.Jsr.Audience, expiresAt, serverKey, "server")
+			if err != nil {
+				return nil, err
+			}
+			return &node.FetchJWTSVIDResponse{
+				Svid: &node.JWTSVID{
+					Token:     token,
+					IssuedAt:  h.clk.Now().Unix(),
+					ExpiresAt: expiresAt.Unix(),
+				},
+			}, nil
+		},
+		fetchDelegatedJWTKey: func(h *mockNodeAPIHandler, req *node.FetchDelegatedJWTKeyRequest) (*node.FetchDelegatedJWTKeyResponse, error) {
+			publicKey, err := x509.ParsePKIXPublicKey(req.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.bundle.AppendJWTSigningKey("delegated", publicKey); err != nil {
+				return nil, err
+			}
+			return &node.FetchDelegatedJWTKeyResponse{
+				JwtKey: &common.PublicKey{
+					PkixBytes: req.PublicKey,
+					Kid:       "delegated",
+					NotAfter:  h.clk.Now().Add(time.Hour).Unix(),
+					SpiffeIds: []string{"spiffe://example.org/workload"},
+				},
+			}, nil
+		},
+		svidTTL: 200,
+	}, mockClk)
+	require.NoError(t, apiHandler.bundle.AppendJWTSigningKey("server", serverKey.Public()))
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	c := &Config{
+		ServerAddr:          l.Addr().String(),
+		SVID:                baseSVID,
+		SVIDKey:             baseSVIDKey,
+		Log:                 testLogger,
+		TrustDomain:         trustDomainID,
+		SVIDCachePath:       path.Join(dir, "svid.der"),
+		BundleCachePath:     path.Join(dir, "bundle.der"),
+		Bundle:              apiHandler.bundle,
+		Metrics:             &telemetry.Blackhole{},
+		Clk:                 mockClk,
+		DelegatedJWTSigning: true,
+		JWTIncludeJTI:       true,
+	}
+
+	m := makeManager(t, c)
+
+	// activate the delegated key
+	require.NoError(t, m.synchronize(context.Background()))
+	require.NoError(t, m.synchronize(context.Background()))
+
+	replayCache := jwtsvid.NewReplayCache(mockClk, 0)
+	fetchTwice := func(spiffeID, kid string) {
+		var tokenIDs []string
+		for i := 0; i < 2; i++ {
+			svid, err := m.FetchJWTSVID(context.Background(), spiffeID, []string{"foo"})
+			require.NoError(t, err)
+
+			token, err := jwt.ParseSigned(svid.Token)
+			require.NoError(t, err)
+			require.Len(t, token.Headers, 1)
+			require.Equal(t, kid, token.Headers[0].KeyID)
+			claims := jwt.Claims{}
+			require.NoError(t, token.Claims(apiHandler.bundle.JWTSigningKeys()[kid], &claims))
+			require.Equal(t, spiffeID, claims.Subject)
+			require.NotEmpty(t, claims.ID)
+			require.NoError(t, replayCache.Observe(claims.ID, claims.Expiry.Time()))
+			tokenIDs = append(tokenIDs, claims.ID)
+		}
+		require.NotEqual(t, tokenIDs[0], tokenIDs[1], "JWT-SVIDs with a token ID should not be cached")
+	}
+
+	// JWT-SVIDs minted with the delegated key
+	fetchTwice("spiffe://example.org/workload", "delegated")
+
+	// JWT-SVIDs minted by the server
+	fetchTwice("spiffe://example.org/other", "server")
+}
+
+func TestReattest(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	var requests []*node.ReattestRequest
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		reattest: func(h *mockNodeAPIHandler, stream node.Node_ReattestServer) error {
+			req, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			requests = append(requests, req)
+			if err := stream.Send(&node.ReattestResponse{Challenge: []byte("one")}); err != nil {
+				return err
+			}
+			req, err = stream.Recv()
+			if err != nil {
+				return err
+			}
+			requests = append(requests, req)
+			return stream.Send(&node.ReattestResponse{
+				Selectors: []*common.Selector{{Type: "test", Value: "refreshed"}},
+			})
+		},
+		svidTTL: 200,
+	}, mockClk)
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/test/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	var na agentnodeattestor.NodeAttestor
+	naDone := spiretest.LoadPlugin(t, catalog.MakePlugin("test",
+		agentnodeattestor.PluginServer(fakeagentnodeattestor.New(fakeagentnodeattestor.Config{
+			Responses: []string{"one"},
+		})),
+	), &na)
+	defer naDone()
+
+	cat := fakeagentcatalog.New()
+	cat.SetNodeAttestor(fakeagentcatalog.NodeAttestor("test", na))
+
+	c := &Config{
+		Catalog:         cat,
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             mockClk,
+	}
+
+	m := makeManager(t, c)
+	require.NoError(t, m.reattest(context.Background()))
+
+	require.Len(t, requests, 2)
+	require.Equal(t, "test", requests[0].AttestationData.Type)
+	require.Equal(t, []byte("TEST"), requests[0].AttestationData.Data)
+	require.Empty(t, requests[0].Response)
+	require.Equal(t, []byte("one"), requests[1].Response)
+}
+
+func TestReattestFailure(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:           t,
+		trustDomain: trustDomain,
+		listener:    l,
+		svidTTL:     200,
+	}, mockClk)
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/test/abcd", 1*time.Hour)
+
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	var na agentnodeattestor.NodeAttestor
+	naDone := spiretest.LoadPlugin(t, catalog.MakePlugin("test",
+		agentnodeattestor.PluginServer(fakeagentnodeattestor.New(fakeagentnodeattestor.Config{})),
+	), &na)
+	defer naDone()
+
+	cat := fakeagentcatalog.New()
+	cat.SetNodeAttestor(fakeagentcatalog.NodeAttestor("test", na))
+
+	c := &Config{
+		Catalog:         cat,
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             mockClk,
+	}
+
+	m := makeManager(t, c)
+	err = m.reattest(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "re-attesting to SPIRE server")
+}
+
 func fetchX509SVIDForTestHappyPathWithoutSyncNorRotation(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
 	switch h.getCountRequest() {
 	case 1:
@@ -1157,6 +1527,10 @@ type mockNodeAPIHandlerConfig struct {
 	fetchX509SVID func(*mockNodeAPIHandler, *node.FetchX509SVIDRequest, node.Node_FetchX509SVIDServer) error
 	fetchJWTSVID  func(*mockNodeAPIHandler, *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error)
 
+	fetchDelegatedJWTKey func(*mockNodeAPIHandler, *node.FetchDelegatedJWTKeyRequest) (*node.FetchDelegatedJWTKeyResponse, error)
+
+	reattest func(*mockNodeAPIHandler, node.Node_ReattestServer) error
+
 	svidTTL int
 }
 
@@ -1232,6 +1606,14 @@ func (h *mockNodeAPIHandler) Attest(stream node.Node_AttestServer) error {
 	return nil
 }
 
+func (h *mockNodeAPIHandler) Reattest(stream node.Node_ReattestServer) error {
+	h.countRequest()
+	if h.c.reattest != nil {
+		return h.c.reattest(h, stream)
+	}
+	return errors.New("oh noes")
+}
+
 func (h *mockNodeAPIHandler) FetchX509SVID(stream node.Node_FetchX509SVIDServer) error {
 	h.countRequest()
 
@@ -1253,6 +1635,14 @@ func (h *mockNodeAPIHandler) FetchJWTSVID(ctx context.Context, req *node.FetchJW
 	return nil, errors.New("oh noes")
 }
 
+func (h *mockNodeAPIHandler) FetchDelegatedJWTKey(ctx context.Context, req *node.FetchDelegatedJWTKeyRequest) (*node.FetchDelegatedJWTKeyResponse, error) {
+	h.countRequest()
+	if h.c.fetchDelegatedJWTKey != nil {
+		return h.c.fetchDelegatedJWTKey(h, req)
+	}
+	return nil, errors.New("oh noes")
+}
+
 func (h *mockNodeAPIHandler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVIDRequest) (*node.FetchX509CASVIDResponse, error) {
 	return nil, errors.New("oh noes")
 }
@@ -1261,6 +1651,14 @@ func (h *mockNodeAPIHandler) PushJWTKeyUpstream(ctx context.Context, req *node.P
 	return nil, errors.New("oh noes")
 }
 
+func (h *mockNodeAPIHandler) ReportAgentsUpstream(ctx context.Context, req *node.ReportAgentsUpstreamRequest) (*node.ReportAgentsUpstreamResponse, error) {
+	return nil, errors.New("oh noes")
+}
+
+func (h *mockNodeAPIHandler) FetchFederatedBundles(ctx context.Context, req *node.FetchFederatedBundlesRequest) (*node.FetchFederatedBundlesResponse, error) {
+	return nil, errors.New("oh noes")
+}
+
 func (h *mockNodeAPIHandler) FetchBundle(ctx context.Context, req *node.FetchBundleRequest) (*node.FetchBundleResponse, error) {
 	return nil, errors.New("oh noes")
 }
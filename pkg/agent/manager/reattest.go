@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
+	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
+	"github.com/spiffe/spire/proto/spire/api/node"
+)
+
+// joinTokenAttestorName is the name of the node attestor used by agents
+// attested with a join token, which cannot re-attest.
+const joinTokenAttestorName = "join_token"
+
+// runReattestor periodically re-attests the agent so the server refreshes
+// its node selectors.
+func (m *manager) runReattestor(ctx context.Context) error {
+	if name := m.c.Catalog.GetNodeAttestor().Name(); name == joinTokenAttestorName {
+		m.c.Log.Warn("Re-attestation is not supported for agents attested with a join token")
+		return nil
+	}
+
+	for {
+		select {
+		case <-m.clk.After(m.c.ReattestInterval):
+		case <-ctx.Done():
+			return nil
+		}
+		if err := m.reattest(ctx); err != nil {
+			// Just log the error and wait for next re-attestation
+			m.c.Log.WithError(err).Error("Node re-attestation failed")
+		}
+	}
+}
+
+// reattest fetches fresh attestation data from the node attestor and
+// re-attests the agent over the authenticated channel.
+func (m *manager) reattest(ctx context.Context) (err error) {
+	attestor := m.c.Catalog.GetNodeAttestor()
+
+	counter := telemetry_agent.StartNodeAttestorReattestCall(m.c.Metrics)
+	defer func() {
+		telemetry_common.AddAttestorType(counter, attestor.Name())
+		counter.Done(&err)
+	}()
+
+	// make sure the attestation data stream is cancelled if something goes
+	// awry
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fetchStream, err := attestor.FetchAttestationData(ctx)
+	if err != nil {
+		return fmt.Errorf("opening stream for fetching attestation: %v", err)
+	}
+
+	selectors, err := m.client.Reattest(ctx, func(challenge []byte) (*node.ReattestRequest, error) {
+		if challenge != nil {
+			if err := fetchStream.Send(&nodeattestor.FetchAttestationDataRequest{
+				Challenge: challenge,
+			}); err != nil {
+				return nil, fmt.Errorf("requesting attestation data: %v", err)
+			}
+		}
+
+		fetchResp, err := fetchStream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("receiving attestation data: %v", err)
+		}
+
+		return &node.ReattestRequest{
+			AttestationData: fetchResp.AttestationData,
+			Response:        fetchResp.Response,
+		}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := fetchStream.CloseSend(); err != nil {
+		return fmt.Errorf("failed to close send on fetch stream: %v", err)
+	}
+	if _, err := fetchStream.Recv(); err != io.EOF {
+		m.c.Log.WithError(err).Warn("received unexpected result on trailing recv")
+	}
+
+	m.c.Log.WithField(telemetry.Selectors, selectors).Info("Node re-attestation completed")
+	return nil
+}
@@ -27,6 +27,17 @@ type csrRequest struct {
 
 // synchronize hits the node api, checks for entries we haven't fetched yet, and fetches them.
 func (m *manager) synchronize(ctx context.Context) (err error) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	ctx, span := m.c.Tracer.StartSpan(ctx, "agent.manager.synchronize")
+	defer func() {
+		m.recordSync(err)
+		m.reportCacheStats()
+		span.RecordError(err)
+		span.End()
+	}()
+
 	update, err := m.fetchEntries(ctx)
 	if err != nil {
 		return err
@@ -104,9 +115,39 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		// the values in `update` now belong to the cache. DO NOT MODIFY.
 		m.cache.UpdateSVIDs(update)
 	}
+
+	if m.c.DelegatedJWTSigning {
+		m.syncDelegatedJWTKey(ctx)
+	}
 	return nil
 }
 
+// reportCacheStats sets gauges for the number of X509-SVIDs in the cache and
+// the smallest remaining TTL among them. It is reported after every sync
+// attempt so that the remaining TTL keeps going down while the server is
+// unreachable.
+func (m *manager) reportCacheStats() {
+	now := m.c.Clk.Now()
+
+	var count int
+	var minTTL time.Duration
+	for _, identity := range m.cache.Identities() {
+		if len(identity.SVID) == 0 {
+			continue
+		}
+		ttl := identity.SVID[0].NotAfter.Sub(now)
+		if count == 0 || ttl < minTTL {
+			minTTL = ttl
+		}
+		count++
+	}
+
+	telemetry_agent.SetCacheManagerX509SVIDsGauge(m.c.Metrics, float32(count))
+	if count > 0 {
+		telemetry_agent.SetCacheManagerX509SVIDMinTTLGauge(m.c.Metrics, float32(minTTL/time.Second))
+	}
+}
+
 func (m *manager) fetchSVIDs(ctx context.Context, csrs []csrRequest) (_ *cache.UpdateSVIDs, err error) {
 	// Put all the CSRs in an array to make just one call with all the CSRs.
 	counter := telemetry_agent.StartManagerFetchSVIDsUpdatesCall(m.c.Metrics)
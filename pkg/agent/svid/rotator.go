@@ -22,6 +22,9 @@ import (
 type Rotator interface {
 	Run(ctx context.Context) error
 
+	// Rotate rotates the SVID immediately, regardless of its expiration.
+	Rotate(ctx context.Context) error
+
 	State() State
 	Subscribe() observer.Stream
 	GetRotationMtx() *sync.RWMutex
@@ -107,12 +110,21 @@ func (r *rotator) SetRotationFinishedHook(f func()) {
 	r.rotationFinishedHook = f
 }
 
-// rotateSVID asks SPIRE's server for a new agent's SVID.
-func (r *rotator) rotateSVID(ctx context.Context) (err error) {
+func (r *rotator) Rotate(ctx context.Context) error {
+	return r.rotate(ctx)
+}
+
+// rotateSVID asks SPIRE's server for a new agent's SVID if the current one
+// is about to expire.
+func (r *rotator) rotateSVID(ctx context.Context) error {
 	if !rotationutil.ShouldRotateX509(r.clk.Now(), r.state.Value().(State).SVID[0]) {
 		return nil
 	}
+	return r.rotate(ctx)
+}
 
+// rotate asks SPIRE's server for a new agent's SVID.
+func (r *rotator) rotate(ctx context.Context) (err error) {
 	counter := telemetry_agent.StartRotateAgentSVIDCall(r.c.Metrics)
 	defer counter.Done(&err)
 
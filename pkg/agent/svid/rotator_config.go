@@ -3,6 +3,7 @@ package svid
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/asn1"
 	"net/url"
 	"sync"
 	"time"
@@ -38,6 +39,10 @@ type RotatorConfig struct {
 
 	// Clk is the clock that the rotator will use to create a ticker
 	Clk clock.Clock
+
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes enforced on the server certificate.
+	PathPrefixesOID asn1.ObjectIdentifier
 }
 
 func NewRotator(c *RotatorConfig) (Rotator, client.Client) {
@@ -66,6 +71,8 @@ func newRotator(c *RotatorConfig) (*rotator, client.Client) {
 		Log:         c.Log,
 		Addr:        c.ServerAddr,
 		RotMtx:      rotMtx,
+
+		PathPrefixesOID: c.PathPrefixesOID,
 		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
 			s := state.Value().(State)
 
@@ -183,6 +183,38 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().True(goodCert.Equal(state.SVID[0]))
 }
 
+func (s *RotatorTestSuite) TestRotateNotExpiring() {
+	// Cert that's valid for 1hr
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	newCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	state := State{
+		SVID: []*x509.Certificate{goodCert},
+	}
+	s.r.state = observer.NewProperty(state)
+
+	stream := s.r.Subscribe()
+
+	// The SVID is not rotated when checking for expiration...
+	err = s.r.rotateSVID(context.Background())
+	s.Require().NoError(err)
+	s.Require().False(stream.HasNext())
+
+	// ...but it is when the rotation is forced
+	s.expectSVIDRotation(newCert)
+	err = s.r.Rotate(context.Background())
+	s.Require().NoError(err)
+	s.Require().True(stream.HasNext())
+
+	state = stream.Next().(State)
+	s.Require().Len(state.SVID, 1)
+	s.Assert().True(newCert.Equal(state.SVID[0]))
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -100,6 +101,28 @@ func (b *Bundle) SetRefreshHint(d time.Duration) {
 	b.b.RefreshHint = int64((d + (time.Second - 1)) / time.Second)
 }
 
+// SequenceNumber returns the bundle sequence number.
+func (b *Bundle) SequenceNumber() uint64 {
+	return b.b.SequenceNumber
+}
+
+// SetSequenceNumber sets the bundle sequence number.
+func (b *Bundle) SetSequenceNumber(n uint64) {
+	b.b.SequenceNumber = n
+}
+
+// RevokedJWTKeyIDs returns the key IDs of JWT signing keys whose JWT-SVIDs
+// must no longer be accepted.
+func (b *Bundle) RevokedJWTKeyIDs() []string {
+	return b.b.RevokedJwtKeyIds
+}
+
+// RevokedJWTSubjects returns the SPIFFE IDs whose JWT-SVIDs must no longer be
+// accepted.
+func (b *Bundle) RevokedJWTSubjects() []string {
+	return b.b.RevokedJwtSubjects
+}
+
 func (b *Bundle) AppendRootCA(rootCA *x509.Certificate) {
 	b.b.RootCas = append(b.b.RootCas, &common.Certificate{
 		DerBytes: rootCA.Raw,
@@ -184,6 +207,9 @@ func JWTSigningKeysFromBundleProto(b *common.Bundle) (map[string]crypto.PublicKe
 	return out, nil
 }
 
+// MergeBundles appends the root CAs, JWT signing keys and JWT revocations in b
+// that are not already in a. The sequence number of the merged bundle is incremented if
+// anything was appended.
 func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	c := cloneBundle(a)
 
@@ -209,11 +235,20 @@ func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 			changed = true
 		}
 	}
+	var revokedChanged bool
+	c.RevokedJwtKeyIds, revokedChanged = mergeStrings(c.RevokedJwtKeyIds, b.RevokedJwtKeyIds)
+	changed = changed || revokedChanged
+	c.RevokedJwtSubjects, revokedChanged = mergeStrings(c.RevokedJwtSubjects, b.RevokedJwtSubjects)
+	changed = changed || revokedChanged
+	if changed {
+		c.SequenceNumber++
+	}
 	return c, changed
 }
 
 // PruneBundle removes the bundle RootCAs and JWT keys that expired before a given time
-// It returns an error if prunning results in a bundle with no CAs or keys
+// It returns an error if prunning results in a bundle with no CAs or keys. The
+// sequence number of the pruned bundle is incremented if anything was removed.
 func PruneBundle(bundle *common.Bundle, expiration time.Time, log hclog.Logger) (*common.Bundle, bool, error) {
 	if bundle == nil {
 		return nil, false, errors.New("current bundle is nil")
@@ -256,6 +291,20 @@ pruneRootCA:
 		newBundle.JwtSigningKeys = append(newBundle.JwtSigningKeys, jwtSigningKey)
 	}
 
+	// Revoked key IDs are only meaningful while the key is in the bundle
+	remainingKeys := make(map[string]bool)
+	for _, jwtSigningKey := range newBundle.JwtSigningKeys {
+		remainingKeys[jwtSigningKey.Kid] = true
+	}
+	for _, kid := range bundle.RevokedJwtKeyIds {
+		if !remainingKeys[kid] {
+			changed = true
+			continue
+		}
+		newBundle.RevokedJwtKeyIds = append(newBundle.RevokedJwtKeyIds, kid)
+	}
+	newBundle.RevokedJwtSubjects = bundle.RevokedJwtSubjects
+
 	if len(newBundle.RootCas) == 0 {
 		log.Warn("Pruning halted; all known CA certificates have expired")
 		return nil, false, errors.New("would prune all certificates")
@@ -266,9 +315,77 @@ pruneRootCA:
 		return nil, false, errors.New("would prune all JWT signing keys")
 	}
 
+	newBundle.SequenceNumber = bundle.SequenceNumber
+	if changed {
+		newBundle.SequenceNumber++
+	}
+
 	return newBundle, changed, nil
 }
 
+// RemoveJWTSigningKeys removes the JWT signing keys with a key ID that starts
+// with the given prefix from the bundle, along with any revocation of those
+// key IDs. It returns the number of keys removed. The sequence number of the
+// resulting bundle is incremented if anything was removed.
+func RemoveJWTSigningKeys(bundle *common.Bundle, kidPrefix string) (*common.Bundle, int) {
+	newBundle := cloneBundle(bundle)
+	newBundle.JwtSigningKeys = nil
+	newBundle.RevokedJwtKeyIds = nil
+
+	removedKeys := make(map[string]bool)
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if strings.HasPrefix(jwtSigningKey.Kid, kidPrefix) {
+			removedKeys[jwtSigningKey.Kid] = true
+			continue
+		}
+		newBundle.JwtSigningKeys = append(newBundle.JwtSigningKeys, jwtSigningKey)
+	}
+	for _, kid := range bundle.RevokedJwtKeyIds {
+		if !removedKeys[kid] {
+			newBundle.RevokedJwtKeyIds = append(newBundle.RevokedJwtKeyIds, kid)
+		}
+	}
+
+	removed := len(bundle.JwtSigningKeys) - len(newBundle.JwtSigningKeys)
+	if removed > 0 {
+		newBundle.SequenceNumber++
+	}
+	return newBundle, removed
+}
+
+// SequenceBundleUpdate returns the bundle to store when the current bundle is
+// replaced by the updated one. The sequence number never goes backwards, and
+// is incremented past that of the current bundle when the contents change but
+// the updated bundle does not carry a newer sequence number.
+func SequenceBundleUpdate(current, updated *common.Bundle) *common.Bundle {
+	if updated.SequenceNumber > current.SequenceNumber {
+		return updated
+	}
+	next := cloneBundle(updated)
+	next.SequenceNumber = current.SequenceNumber
+	if !proto.Equal(current, next) {
+		next.SequenceNumber++
+	}
+	return next
+}
+
+// mergeStrings appends the values in b that are not already in a.
+func mergeStrings(a, b []string) ([]string, bool) {
+	seen := make(map[string]bool)
+	for _, value := range a {
+		seen[value] = true
+	}
+	var changed bool
+	for _, value := range b {
+		if !seen[value] {
+			a = append(a, value)
+			seen[value] = true
+			changed = true
+		}
+	}
+	return a, changed
+}
+
 func cloneBundle(b *common.Bundle) *common.Bundle {
 	return proto.Clone(b).(*common.Bundle)
 }
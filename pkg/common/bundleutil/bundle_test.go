@@ -96,10 +96,13 @@ func (s *BundleUtilSuite) TestPruneBundleSucceeds() {
 		[]*common.PublicKey{s.jwtKeyNotExpired, s.jwtKeyExpired},
 	)
 
+	bundle.SequenceNumber = 5
+
 	expectedBundle := s.createBundle(
 		[]*x509.Certificate{s.certNotExpired},
 		[]*common.PublicKey{s.jwtKeyNotExpired},
 	)
+	expectedBundle.SequenceNumber = 6
 
 	newBundle, changed, err := PruneBundle(bundle, s.currentTime, hclog.NewNullLogger())
 	s.NoError(err)
@@ -107,6 +110,90 @@ func (s *BundleUtilSuite) TestPruneBundleSucceeds() {
 	s.True(changed)
 }
 
+func (s *BundleUtilSuite) TestSequenceBundleUpdate() {
+	current := BundleProtoFromRootCA("spiffe://foo", s.certNotExpired)
+	current.SequenceNumber = 5
+
+	// unchanged contents keep the current sequence number
+	updated := BundleProtoFromRootCA("spiffe://foo", s.certNotExpired)
+	s.Equal(uint64(5), SequenceBundleUpdate(current, updated).SequenceNumber)
+
+	// changed contents increment it
+	updated.RevokedJwtSubjects = []string{"spiffe://foo/workload"}
+	next := SequenceBundleUpdate(current, updated)
+	s.Equal(uint64(6), next.SequenceNumber)
+	s.Equal(uint64(0), updated.SequenceNumber, "updated bundle should not be modified")
+
+	// a newer sequence number is kept as is
+	updated.SequenceNumber = 10
+	s.Equal(uint64(10), SequenceBundleUpdate(current, updated).SequenceNumber)
+}
+
+func (s *BundleUtilSuite) TestMergeBundlesIncrementsSequenceNumber() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{s.jwtKeyNotExpired},
+	)
+	bundle.SequenceNumber = 5
+
+	// Nothing new to merge
+	merged, changed := MergeBundles(bundle, bundle)
+	s.False(changed)
+	s.Equal(uint64(5), merged.SequenceNumber)
+
+	merged, changed = MergeBundles(bundle, s.createBundle(
+		[]*x509.Certificate{s.certExpired},
+		[]*common.PublicKey{s.jwtKeyExpired},
+	))
+	s.True(changed)
+	s.Len(merged.RootCas, 2)
+	s.Len(merged.JwtSigningKeys, 2)
+	s.Equal(uint64(6), merged.SequenceNumber)
+}
+
+func (s *BundleUtilSuite) TestMergeBundlesMergesJWTRevocations() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{s.jwtKeyNotExpired},
+	)
+	bundle.RevokedJwtKeyIds = []string{"KID1"}
+	bundle.SequenceNumber = 5
+
+	// Already revoked
+	merged, changed := MergeBundles(bundle, &common.Bundle{
+		RevokedJwtKeyIds: []string{"KID1"},
+	})
+	s.False(changed)
+	s.Equal(uint64(5), merged.SequenceNumber)
+
+	merged, changed = MergeBundles(bundle, &common.Bundle{
+		RevokedJwtKeyIds:   []string{"KID1", "KID2"},
+		RevokedJwtSubjects: []string{"spiffe://foo/bar"},
+	})
+	s.True(changed)
+	s.Equal([]string{"KID1", "KID2"}, merged.RevokedJwtKeyIds)
+	s.Equal([]string{"spiffe://foo/bar"}, merged.RevokedJwtSubjects)
+	s.Equal(uint64(6), merged.SequenceNumber)
+}
+
+func (s *BundleUtilSuite) TestPruneBundleDropsRevocationsOfPrunedKeys() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired},
+		[]*common.PublicKey{
+			{Kid: "EXPIRED", NotAfter: s.jwtKeyExpired.NotAfter},
+			{Kid: "VALID", NotAfter: s.jwtKeyNotExpired.NotAfter},
+		},
+	)
+	bundle.RevokedJwtKeyIds = []string{"EXPIRED", "VALID"}
+	bundle.RevokedJwtSubjects = []string{"spiffe://foo/bar"}
+
+	newBundle, changed, err := PruneBundle(bundle, s.currentTime, hclog.NewNullLogger())
+	s.NoError(err)
+	s.True(changed)
+	s.Equal([]string{"VALID"}, newBundle.RevokedJwtKeyIds)
+	s.Equal([]string{"spiffe://foo/bar"}, newBundle.RevokedJwtSubjects)
+}
+
 func (s *BundleUtilSuite) createBundle(certs []*x509.Certificate, jwtKeys []*common.PublicKey) *common.Bundle {
 	bundle := BundleProtoFromRootCAs("spiffe://foo", certs)
 	bundle.JwtSigningKeys = jwtKeys
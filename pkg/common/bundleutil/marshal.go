@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"gopkg.in/square/go-jose.v2"
 )
 
@@ -12,6 +13,8 @@ type marshalConfig struct {
 	refreshHint    time.Duration
 	noX509SVIDKeys bool
 	noJWTSVIDKeys  bool
+
+	noDelegatedJWTSVIDKeys bool
 }
 
 type MarshalOption interface {
@@ -48,6 +51,16 @@ func NoJWTSVIDKeys() MarshalOption {
 	})
 }
 
+// NoDelegatedJWTSVIDKeys skips marshalling the JWT SVID keys delegated to
+// agents. The SPIFFE IDs those keys are restricted to cannot be expressed in
+// the bundle document, so consumers would accept them for any SPIFFE ID.
+func NoDelegatedJWTSVIDKeys() MarshalOption {
+	return marshalOption(func(c *marshalConfig) error {
+		c.noDelegatedJWTSVIDKeys = true
+		return nil
+	})
+}
+
 func Marshal(bundle *Bundle, opts ...MarshalOption) ([]byte, error) {
 	c := &marshalConfig{
 		refreshHint: bundle.RefreshHint(),
@@ -59,6 +72,7 @@ func Marshal(bundle *Bundle, opts ...MarshalOption) ([]byte, error) {
 	}
 
 	doc := bundleDoc{
+		Sequence:    bundle.SequenceNumber(),
 		RefreshHint: int(c.refreshHint / time.Second),
 	}
 
@@ -74,6 +88,9 @@ func Marshal(bundle *Bundle, opts ...MarshalOption) ([]byte, error) {
 
 	if !c.noJWTSVIDKeys {
 		for keyID, jwtSigningKey := range bundle.JWTSigningKeys() {
+			if c.noDelegatedJWTSVIDKeys && jwtsvid.IsDelegatedKeyID(keyID) {
+				continue
+			}
 			doc.Keys = append(doc.Keys, jose.JSONWebKey{
 				Key:   jwtSigningKey,
 				KeyID: keyID,
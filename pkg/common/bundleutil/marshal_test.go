@@ -12,10 +12,12 @@ func TestMarshal(t *testing.T) {
 	rootCA := createCACertificate(t)
 
 	testCases := []struct {
-		name  string
-		empty bool
-		opts  []MarshalOption
-		out   string
+		name      string
+		empty     bool
+		delegated bool
+		sequence  uint64
+		opts      []MarshalOption
+		out       string
 	}{
 		{
 			name:  "empty bundle",
@@ -30,6 +32,12 @@ func TestMarshal(t *testing.T) {
 			},
 			out: `{"keys":null, "spiffe_refresh_hint": 10}`,
 		},
+		{
+			name:     "with sequence number",
+			empty:    true,
+			sequence: 42,
+			out:      `{"keys":null, "spiffe_refresh_hint": 60, "spiffe_sequence": 42}`,
+		},
 		{
 			name: "without X509 SVID keys",
 			opts: []MarshalOption{
@@ -71,6 +79,27 @@ func TestMarshal(t *testing.T) {
 				"spiffe_refresh_hint": 60
 			}`, x5c(rootCA)),
 		},
+		{
+			name:      "without delegated JWT SVID keys",
+			delegated: true,
+			opts: []MarshalOption{
+				NoX509SVIDKeys(),
+				NoDelegatedJWTSVIDKeys(),
+			},
+			out: `{
+				"keys": [
+					{
+						"use": "jwt-svid",
+						"kid": "FOO",
+						"kty": "EC",
+						"crv": "P-256",
+						"x": "kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+						"y": "qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA"
+					}
+				],
+				"spiffe_refresh_hint": 60
+			}`,
+		},
 		{
 			name: "with X509 and JWT SVID keys",
 			out: fmt.Sprintf(`{
@@ -104,10 +133,14 @@ func TestMarshal(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			bundle := New("spiffe://domain.test")
 			bundle.SetRefreshHint(time.Minute)
+			bundle.SetSequenceNumber(testCase.sequence)
 			if !testCase.empty {
 				bundle.AppendRootCA(rootCA)
 				require.NoError(t, bundle.AppendJWTSigningKey("FOO", testKey.Public()))
 			}
+			if testCase.delegated {
+				require.NoError(t, bundle.AppendJWTSigningKey("agent.0123456789abcdef.BAR", testKey.Public()))
+			}
 			bundleBytes, err := Marshal(bundle, testCase.opts...)
 			require.NoError(t, err)
 			require.JSONEq(t, testCase.out, string(bundleBytes))
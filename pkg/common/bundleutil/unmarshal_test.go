@@ -21,6 +21,15 @@ func TestUnmarshal(t *testing.T) {
 			doc:    "{}",
 			bundle: New("spiffe://domain.test"),
 		},
+		{
+			name: "with sequence number",
+			doc:  `{"keys": null, "spiffe_sequence": 3}`,
+			bundle: func() *Bundle {
+				b := New("spiffe://domain.test")
+				b.SetSequenceNumber(3)
+				return b
+			}(),
+		},
 		{
 			name: "entry missing use",
 			doc: `{
@@ -5,16 +5,19 @@ import (
 	"io"
 	"sync"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
 )
 
 type BuiltInPlugin struct {
 	Log          logrus.FieldLogger
+	Tracer       *tracing.Tracer
 	Plugin       Plugin
 	HostServices []HostServiceServer
 }
@@ -51,7 +54,7 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 	closers.AddCloser(hostNet)
 
 	// create a host server to serve host services.
-	hostServer := NewHostServer(builtin.Plugin.Name, nil, builtin.HostServices)
+	hostServer := NewHostServer(builtin.Tracer, builtin.Plugin.Name, nil, builtin.HostServices)
 	closers.AddFunc(hostServer.Stop)
 
 	wg.Add(1)
@@ -74,7 +77,7 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 	closers.AddCloser(builtinNet)
 
 	// create a gRPC server to serve the plugin and services over
-	builtinServer := newBuiltInServer()
+	builtinServer := newBuiltInServer(builtin.Tracer)
 	closers.AddFunc(builtinServer.Stop)
 
 	logger := (&log.HCLogAdapter{
@@ -121,10 +124,16 @@ func LoadBuiltInPlugin(ctx context.Context, builtin BuiltInPlugin) (plugin *Load
 	return plugin, nil
 }
 
-func newBuiltInServer() *grpc.Server {
+func newBuiltInServer(tracer *tracing.Tracer) *grpc.Server {
 	return grpc.NewServer(
-		grpc.StreamInterceptor(grpc_recovery.StreamServerInterceptor()),
-		grpc.UnaryInterceptor(grpc_recovery.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			tracer.StreamServerInterceptor,
+			grpc_recovery.StreamServerInterceptor(),
+		)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			tracer.UnaryServerInterceptor,
+			grpc_recovery.UnaryServerInterceptor(),
+		)),
 	)
 }
 
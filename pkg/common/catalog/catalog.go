@@ -2,9 +2,17 @@ package catalog
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
 )
@@ -14,6 +22,23 @@ type GlobalConfig = spi.ConfigureRequest_GlobalConfig
 type Config struct {
 	Log logrus.FieldLogger
 
+	// Metrics is used to emit catalog metrics, like external plugin
+	// restarts. Optional.
+	Metrics telemetry.Metrics
+
+	// Tracer records spans for calls served by plugins and host services,
+	// and its configuration is passed on to external plugin processes.
+	// Optional.
+	Tracer *tracing.Tracer
+
+	// ExternalPluginProbeInterval is how often external plugins are probed
+	// for health. Defaults to DefaultExternalPluginProbeInterval.
+	ExternalPluginProbeInterval time.Duration
+
+	// Clock is used to supervise external plugins and check their health.
+	// Defaults to the system clock.
+	Clock clock.Clock
+
 	// GlobalConfig is passed to plugins during configuration.
 	GlobalConfig GlobalConfig
 
@@ -106,6 +131,26 @@ type Catalog interface {
 	//
 	Fill(x interface{}) error
 
+	// Reconfigure configures again, in place, the plugins whose data changed
+	// in the given plugin configuration. Changes that cannot be applied
+	// without reloading the catalog, like adding, removing or disabling
+	// plugins, changing their command or checksum, or changing the global
+	// configuration, are rejected before any plugin is configured. It
+	// returns the names of the reconfigured plugins. If a plugin rejects its
+	// new configuration, the plugins already reconfigured are rolled back and
+	// an error is returned along with the names of the plugins that could not
+	// be rolled back, if any.
+	Reconfigure(ctx context.Context, globalConfig GlobalConfig, pluginConfigs []PluginConfig) ([]string, error)
+
+	// ExternalPluginStatuses returns the supervision status of the external
+	// plugins in the catalog.
+	ExternalPluginStatuses() []ExternalPluginStatus
+
+	// CheckExternalPlugins is a health check over the external plugins in
+	// the catalog. The check is unhealthy while a plugin is not running and
+	// degraded if a plugin was restarted recently.
+	CheckExternalPlugins() (interface{}, error)
+
 	// Close() closes the catalog, shutting down servers and killing external
 	// plugin processes.
 	Close()
@@ -115,7 +160,7 @@ type Closer interface {
 	Close()
 }
 
-func Fill(ctx context.Context, config Config, x interface{}) (Closer, error) {
+func Fill(ctx context.Context, config Config, x interface{}) (Catalog, error) {
 	c, err := Load(ctx, config)
 	if err != nil {
 		return nil, err
@@ -131,6 +176,9 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 	if config.Log == nil {
 		config.Log = newDiscardingLogger()
 	}
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
 
 	knownPluginsMap, err := makePluginsMap(config.KnownPlugins)
 	if err != nil {
@@ -143,7 +191,11 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 	}
 
 	// close the plugins if there is an error.
-	cat := new(catalog)
+	cat := &catalog{
+		log:          config.Log,
+		clock:        config.Clock,
+		globalConfig: config.GlobalConfig,
+	}
 	defer func() {
 		if err != nil {
 			cat.Close()
@@ -171,6 +223,7 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 			}
 			plugin, err = LoadBuiltInPlugin(ctx, BuiltInPlugin{
 				Log:          config.Log,
+				Tracer:       config.Tracer,
 				Plugin:       builtin,
 				HostServices: config.HostServices,
 			})
@@ -182,12 +235,17 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 
 			plugin, err = LoadExternalPlugin(ctx, ExternalPlugin{
 				Log:           config.Log,
+				Metrics:       config.Metrics,
+				Tracer:        config.Tracer,
 				Name:          c.Name,
+				Type:          c.Type,
 				Path:          c.Path,
 				Checksum:      c.Checksum,
 				Plugin:        extPlugin,
 				KnownServices: config.KnownServices,
 				HostServices:  config.HostServices,
+				ProbeInterval: config.ExternalPluginProbeInterval,
+				Clock:         config.Clock,
 			})
 		}
 		if err != nil {
@@ -205,13 +263,21 @@ func Load(ctx context.Context, config Config) (_ Catalog, err error) {
 
 		pluginLog.WithField(telemetry.PluginServices, plugin.serviceNames).Info("Plugin loaded.")
 		cat.plugins = append(cat.plugins, plugin)
+		cat.configs = append(cat.configs, c)
 	}
 
 	return cat, nil
 }
 
 type catalog struct {
+	log          logrus.FieldLogger
+	clock        clock.Clock
+	globalConfig GlobalConfig
+
+	mtx     sync.Mutex
 	plugins []*LoadedPlugin
+	// configs holds the configuration of each plugin, in the same order
+	configs []PluginConfig
 }
 
 func (c *catalog) Fill(x interface{}) (err error) {
@@ -219,7 +285,131 @@ func (c *catalog) Fill(x interface{}) (err error) {
 	return f.fill(x)
 }
 
+func (c *catalog) Reconfigure(ctx context.Context, globalConfig GlobalConfig, pluginConfigs []PluginConfig) ([]string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if !proto.Equal(&c.globalConfig, &globalConfig) {
+		return nil, errs.New("global configuration cannot be changed without a restart")
+	}
+
+	newConfigs := make(map[pluginKey]PluginConfig)
+	for _, config := range pluginConfigs {
+		if !config.Disabled {
+			newConfigs[pluginKey{Name: config.Name, Type: config.Type}] = config
+		}
+	}
+
+	// validate every change before configuring any plugin
+	changed := make(map[int]PluginConfig)
+	for i, oldConfig := range c.configs {
+		key := pluginKey{Name: oldConfig.Name, Type: oldConfig.Type}
+		newConfig, ok := newConfigs[key]
+		if !ok {
+			return nil, errs.New("%s plugin %q cannot be removed or disabled without a restart", key.Type, key.Name)
+		}
+		delete(newConfigs, key)
+
+		if newConfig.Path != oldConfig.Path || newConfig.Checksum != oldConfig.Checksum {
+			return nil, errs.New("%s plugin %q command or checksum cannot be changed without a restart", key.Type, key.Name)
+		}
+		if newConfig.Data != oldConfig.Data {
+			changed[i] = newConfig
+		}
+	}
+	if len(newConfigs) > 0 {
+		var added []string
+		for key := range newConfigs {
+			added = append(added, fmt.Sprintf("%s plugin %q", key.Type, key.Name))
+		}
+		sort.Strings(added)
+		return nil, errs.New("%s cannot be added or enabled without a restart", strings.Join(added, ", "))
+	}
+
+	// Plugins cannot validate a configuration without applying it, so if a
+	// plugin rejects its new configuration, the plugins already reconfigured
+	// are configured again with their previous configuration.
+	var applied []int
+	for i, plugin := range c.plugins {
+		newConfig, ok := changed[i]
+		if !ok {
+			continue
+		}
+
+		pluginLog := c.pluginLog(newConfig)
+		if err := configurePlugin(ctx, plugin, &c.globalConfig, newConfig); err != nil {
+			pluginLog.WithError(err).Error("Failed to reconfigure plugin.")
+			reconfigured := c.rollback(ctx, applied, changed)
+			return reconfigured, errs.New("unable to reconfigure plugin %q: %v", newConfig.Name, err)
+		}
+		pluginLog.Info("Plugin reconfigured.")
+		applied = append(applied, i)
+	}
+
+	var reconfigured []string
+	for _, i := range applied {
+		c.configs[i] = changed[i]
+		reconfigured = append(reconfigured, changed[i].Name)
+	}
+	return reconfigured, nil
+}
+
+// rollback configures the given plugins again with their current
+// configuration after a failed reconfiguration. Plugins that fail to roll
+// back keep their new configuration, leaving a partial reload. It returns the
+// names of those plugins.
+func (c *catalog) rollback(ctx context.Context, applied []int, changed map[int]PluginConfig) []string {
+	var partial []string
+	for _, i := range applied {
+		oldConfig := c.configs[i]
+		pluginLog := c.pluginLog(oldConfig)
+		if err := configurePlugin(ctx, c.plugins[i], &c.globalConfig, oldConfig); err != nil {
+			pluginLog.WithError(err).Error("Failed to roll back plugin configuration.")
+			c.configs[i] = changed[i]
+			partial = append(partial, oldConfig.Name)
+			continue
+		}
+		pluginLog.Info("Plugin configuration rolled back.")
+	}
+	if len(partial) > 0 {
+		c.log.WithField(telemetry.PluginNames, partial).Error("Partial plugin configuration reload; plugins kept their new configuration")
+	}
+	return partial
+}
+
+func (c *catalog) pluginLog(config PluginConfig) logrus.FieldLogger {
+	return c.log.WithFields(logrus.Fields{
+		telemetry.PluginName:    config.Name,
+		telemetry.PluginType:    config.Type,
+		telemetry.PluginBuiltIn: config.Path == "",
+	})
+}
+
+func configurePlugin(ctx context.Context, plugin *LoadedPlugin, globalConfig *GlobalConfig, config PluginConfig) error {
+	return plugin.Configure(ctx, &spi.ConfigureRequest{
+		GlobalConfig:  globalConfig,
+		Configuration: config.Data,
+	})
+}
+
+func (c *catalog) ExternalPluginStatuses() []ExternalPluginStatus {
+	var statuses []ExternalPluginStatus
+	for _, p := range c.plugins {
+		if status, ok := p.ExternalStatus(); ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func (c *catalog) CheckExternalPlugins() (interface{}, error) {
+	return checkExternalPlugins(c.ExternalPluginStatuses(), c.clock.Now())
+}
+
 func (c *catalog) Close() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	for _, p := range c.plugins {
 		p.Close()
 	}
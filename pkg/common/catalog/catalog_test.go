@@ -11,12 +11,17 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/catalog/test"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -155,6 +160,382 @@ func (s *CatalogSuite) TestOldPlugin() {
 	s.Require().Equal("plugin(OLD)", resp.Out)
 }
 
+func (s *CatalogSuite) TestExternalPluginRestart() {
+	require := s.Require()
+	metrics := fakemetrics.New()
+
+	plugin, err := catalog.LoadExternalPlugin(context.Background(), catalog.ExternalPlugin{
+		Log:           s.log,
+		Metrics:       metrics,
+		Name:          "testext",
+		Path:          s.path,
+		Checksum:      s.checksum,
+		Plugin:        test.PluginPluginClient,
+		KnownServices: s.knownServices,
+		HostServices:  s.hostServices,
+		ProbeInterval: 50 * time.Millisecond,
+	})
+	require.NoError(err)
+	defer plugin.Close()
+
+	require.NoError(plugin.Configure(context.Background(), &spi.ConfigureRequest{
+		GlobalConfig:  &catalog.GlobalConfig{TrustDomain: "domain.test"},
+		Configuration: "GOOD",
+	}))
+
+	var pluginClient test.Plugin
+	require.NoError(plugin.Fill(&pluginClient))
+	var serviceClient test.Service
+	require.NoError(plugin.Fill(&serviceClient))
+
+	callPlugin := func() (string, error) {
+		resp, err := pluginClient.CallPlugin(context.Background(), &test.Request{
+			In: "hello-to-plugin",
+		})
+		if err != nil {
+			return "", err
+		}
+		return resp.Out, nil
+	}
+
+	out, err := callPlugin()
+	require.NoError(err)
+	require.Equal("plugin(hostservice[plugin=testext](hello-to-plugin))", out)
+
+	// crash the plugin
+	_, err = pluginClient.CallPlugin(context.Background(), &test.Request{
+		In: test.ExitRequest,
+	})
+	require.Error(err)
+
+	require.Eventually(func() bool {
+		status, ok := plugin.ExternalStatus()
+		return ok && status.Running && status.Restarts == 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the clients filled before the crash keep working and the restarted
+	// plugin was configured again with the same configuration
+	out, err = callPlugin()
+	require.NoError(err)
+	require.Equal("plugin(hostservice[plugin=testext](hello-to-plugin))", out)
+	resp, err := serviceClient.CallService(context.Background(), &test.Request{
+		In: "hello-to-service",
+	})
+	require.NoError(err)
+	require.Equal("service(hostservice[plugin=testext](hello-to-service))", resp.Out)
+
+	configureCalls := 0
+	for _, entry := range s.logHook.AllEntries() {
+		if entry.Message == "Configure called." {
+			require.Equal("GOOD", entry.Data["config"])
+			require.Equal("domain.test", entry.Data["trustdomain"])
+			configureCalls++
+		}
+	}
+	require.Equal(2, configureCalls)
+
+	s.assertHasLogEntry(testLogEntry{
+		Level:   logrus.InfoLevel,
+		Message: "External plugin restarted",
+		Data: logrus.Fields{
+			telemetry.PluginName: "testext",
+			telemetry.PluginType: test.PluginType,
+		},
+	})
+
+	require.Contains(metrics.AllMetrics(), fakemetrics.MetricItem{
+		Type: fakemetrics.IncrCounterWithLabelsType,
+		Key:  []string{telemetry.Catalog, telemetry.PluginExternal, telemetry.Restart},
+		Val:  1,
+		Labels: []telemetry.Label{
+			{Name: telemetry.PluginName, Value: "testext"},
+			{Name: telemetry.PluginType, Value: test.PluginType},
+		},
+	})
+}
+
+func (s *CatalogSuite) TestExternalPluginRestartBackoff() {
+	require := s.Require()
+
+	// copy the plugin so it can be removed to make restarts fail
+	path := filepath.Join(s.dir, "supervisedbin")
+	copyPlugin := func() {
+		data, err := ioutil.ReadFile(s.path)
+		require.NoError(err)
+		require.NoError(ioutil.WriteFile(path, data, 0700))
+	}
+	copyPlugin()
+
+	clk := clock.NewMock(s.T())
+	pluginConfig := s.extPluginConfig()
+	pluginConfig[0].Path = path
+	cat, err := catalog.Load(context.Background(), catalog.Config{
+		Log: s.log,
+		GlobalConfig: catalog.GlobalConfig{
+			TrustDomain: "domain.test",
+		},
+		ExternalPluginProbeInterval: time.Second,
+		Clock:                       clk,
+		PluginConfig:                pluginConfig,
+		KnownPlugins:                s.knownPlugins,
+		KnownServices:               s.knownServices,
+		HostServices:                s.hostServices,
+	})
+	require.NoError(err)
+	defer cat.Close()
+	clk.WaitForTicker(time.Minute, "failed to wait for the probe ticker")
+
+	status := func() catalog.ExternalPluginStatus {
+		statuses := cat.ExternalPluginStatuses()
+		require.Len(statuses, 1)
+		return statuses[0]
+	}
+
+	var plugins struct {
+		Plugin test.Plugin
+	}
+	require.NoError(cat.Fill(&plugins))
+
+	// crash the plugin and remove it so the restart fails
+	_, err = plugins.Plugin.CallPlugin(context.Background(), &test.Request{
+		In: test.ExitRequest,
+	})
+	require.Error(err)
+	require.NoError(os.Remove(path))
+
+	// probe until the supervisor gives up on the plugin and waits to retry
+	// the restart
+	var backoff time.Duration
+	for backoff == 0 {
+		clk.Add(time.Second)
+		select {
+		case backoff = <-clk.AfterCh():
+		case <-time.After(2 * time.Second):
+		}
+	}
+	require.True(backoff >= 500*time.Millisecond && backoff <= 1500*time.Millisecond, "unexpected restart backoff %s", backoff)
+	require.False(status().Running)
+	require.Zero(status().Restarts)
+	_, err = cat.CheckExternalPlugins()
+	require.EqualError(err, "external plugins not running: testext")
+
+	// the plugin is not restarted before the backoff elapses
+	copyPlugin()
+	clk.Add(backoff - time.Nanosecond)
+	require.False(status().Running)
+
+	clk.Add(time.Nanosecond)
+	require.Eventually(func() bool {
+		return status().Running
+	}, 10*time.Second, 10*time.Millisecond)
+	restartedAt := clk.Now()
+	require.Equal(1, status().Restarts)
+	require.Equal(restartedAt, status().LastRestart)
+
+	// the plugin is reported as degraded while it was restarted recently
+	_, err = cat.CheckExternalPlugins()
+	require.EqualError(err, "external plugins restarted recently: testext")
+	require.True(health.IsDegraded(err))
+
+	clk.Set(restartedAt.Add(10*time.Minute - time.Nanosecond))
+	_, err = cat.CheckExternalPlugins()
+	require.True(health.IsDegraded(err))
+
+	clk.Set(restartedAt.Add(10 * time.Minute))
+	_, err = cat.CheckExternalPlugins()
+	require.NoError(err)
+}
+
+func (s *CatalogSuite) TestExternalPluginStatuses() {
+	s.pluginConfig = append(s.extPluginConfig(), s.builtinConfig()...)
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	// only external plugins are supervised
+	statuses := cat.ExternalPluginStatuses()
+	s.Require().Equal([]catalog.ExternalPluginStatus{
+		{
+			Name:    "testext",
+			Type:    test.PluginType,
+			Running: true,
+		},
+	}, statuses)
+
+	details, err := cat.CheckExternalPlugins()
+	s.Require().NoError(err)
+	s.Require().Equal(statuses, details)
+}
+
+func (s *CatalogSuite) TestReconfigure() {
+	require := s.Require()
+
+	s.pluginConfig = s.extPluginConfig()
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	globalConfig := catalog.GlobalConfig{TrustDomain: "domain.test"}
+
+	// nothing changed
+	reconfigured, err := cat.Reconfigure(context.Background(), globalConfig, s.extPluginConfig())
+	require.NoError(err)
+	require.Empty(reconfigured)
+
+	// plugin data changed
+	pluginConfig := s.extPluginConfig()
+	pluginConfig[0].Data = "NEWCONFIG"
+	reconfigured, err = cat.Reconfigure(context.Background(), globalConfig, pluginConfig)
+	require.NoError(err)
+	require.Equal([]string{"testext"}, reconfigured)
+	s.assertHasLogEntries([]testLogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Configure called.",
+			Data: logrus.Fields{
+				"@module":               "pluginimpl",
+				"config":                "NEWCONFIG",
+				telemetry.SubsystemName: "external_plugin.testext.pluginbin",
+				"trustdomain":           "domain.test",
+			},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Plugin reconfigured.",
+			Data: logrus.Fields{
+				telemetry.PluginBuiltIn: false,
+				telemetry.PluginName:    "testext",
+				telemetry.PluginType:    "Plugin",
+			},
+		},
+	})
+
+	// the new configuration is now the current one
+	reconfigured, err = cat.Reconfigure(context.Background(), globalConfig, pluginConfig)
+	require.NoError(err)
+	require.Empty(reconfigured)
+
+	// a plugin rejecting its new configuration keeps the current one
+	pluginConfig[0].Data = "BAD"
+	reconfigured, err = cat.Reconfigure(context.Background(), globalConfig, pluginConfig)
+	require.EqualError(err, `unable to reconfigure plugin "testext": rpc error: code = InvalidArgument desc = BAD configuration`)
+	require.Empty(reconfigured)
+}
+
+func (s *CatalogSuite) TestReconfigureRollsBack() {
+	require := s.Require()
+
+	s.pluginConfig = append(s.extPluginConfig(), s.builtinConfig()...)
+	s.builtins = []catalog.Plugin{testBuiltIn()}
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	globalConfig := catalog.GlobalConfig{TrustDomain: "domain.test"}
+
+	// the builtin rejects its new configuration after the external plugin
+	// was reconfigured, so the external plugin is rolled back
+	pluginConfig := append(s.extPluginConfig(), s.builtinConfig()...)
+	pluginConfig[0].Data = "NEWCONFIG"
+	pluginConfig[1].Data = "BAD"
+	reconfigured, err := cat.Reconfigure(context.Background(), globalConfig, pluginConfig)
+	require.EqualError(err, `unable to reconfigure plugin "testbuiltin": rpc error: code = InvalidArgument desc = BAD configuration`)
+	require.Empty(reconfigured)
+	s.assertHasLogEntries([]testLogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Plugin reconfigured.",
+			Data: logrus.Fields{
+				telemetry.PluginBuiltIn: false,
+				telemetry.PluginName:    "testext",
+				telemetry.PluginType:    "Plugin",
+			},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Plugin configuration rolled back.",
+			Data: logrus.Fields{
+				telemetry.PluginBuiltIn: false,
+				telemetry.PluginName:    "testext",
+				telemetry.PluginType:    "Plugin",
+			},
+		},
+	})
+
+	// the previous configuration is still the current one
+	reconfigured, err = cat.Reconfigure(context.Background(), globalConfig, append(s.extPluginConfig(), s.builtinConfig()...))
+	require.NoError(err)
+	require.Empty(reconfigured)
+}
+
+func (s *CatalogSuite) TestReconfigureRequiresRestart() {
+	s.pluginConfig = s.extPluginConfig()
+	cat := s.loadCatalog()
+	defer cat.Close()
+
+	globalConfig := catalog.GlobalConfig{TrustDomain: "domain.test"}
+
+	for _, tt := range []struct {
+		name         string
+		globalConfig catalog.GlobalConfig
+		pluginConfig func() []catalog.PluginConfig
+		err          string
+	}{
+		{
+			name:         "global config changed",
+			globalConfig: catalog.GlobalConfig{TrustDomain: "otherdomain.test"},
+			pluginConfig: s.extPluginConfig,
+			err:          "global configuration cannot be changed without a restart",
+		},
+		{
+			name:         "plugin removed",
+			globalConfig: globalConfig,
+			pluginConfig: func() []catalog.PluginConfig { return nil },
+			err:          `Plugin plugin "testext" cannot be removed or disabled without a restart`,
+		},
+		{
+			name:         "plugin disabled",
+			globalConfig: globalConfig,
+			pluginConfig: func() []catalog.PluginConfig {
+				pluginConfig := s.extPluginConfig()
+				pluginConfig[0].Disabled = true
+				return pluginConfig
+			},
+			err: `Plugin plugin "testext" cannot be removed or disabled without a restart`,
+		},
+		{
+			name:         "plugin added",
+			globalConfig: globalConfig,
+			pluginConfig: func() []catalog.PluginConfig {
+				return append(s.extPluginConfig(), s.builtinConfig()...)
+			},
+			err: `Plugin plugin "testbuiltin" cannot be added or enabled without a restart`,
+		},
+		{
+			name:         "plugin checksum changed",
+			globalConfig: globalConfig,
+			pluginConfig: func() []catalog.PluginConfig {
+				pluginConfig := s.extPluginConfig()
+				pluginConfig[0].Checksum = "00"
+				pluginConfig[0].Data = "NEWCONFIG"
+				return pluginConfig
+			},
+			err: `Plugin plugin "testext" command or checksum cannot be changed without a restart`,
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			reconfigured, err := cat.Reconfigure(context.Background(), tt.globalConfig, tt.pluginConfig())
+			s.Require().EqualError(err, tt.err)
+			s.Require().Empty(reconfigured)
+		})
+	}
+
+	// rejected changes are not applied
+	for _, entry := range s.logHook.AllEntries() {
+		s.Require().NotEqual("Plugin reconfigured.", entry.Message)
+	}
+}
+
 func (s *CatalogSuite) TestNoKnownPlugin() {
 	s.knownPlugins = nil
 	s.pluginConfig = s.extPluginConfig()
@@ -67,6 +67,11 @@ func makeHostServiceTypes(hostServices []HostServiceServer) ([]string, error) {
 	return types, nil
 }
 
+type pluginKey struct {
+	Name string
+	Type string
+}
+
 type builtinKey struct {
 	Name string
 	Type string
@@ -6,15 +6,20 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/andres-erbsen/clock"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/hashicorp/go-hclog"
 	goplugin "github.com/hashicorp/go-plugin"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
 )
@@ -30,6 +35,28 @@ func PluginMain(plugin Plugin) {
 		Output:     os.Stderr,
 		JSONFormat: true,
 	})
+
+	// Record spans for calls from the host if it passed on a tracing
+	// configuration. Spans are parented to the host spans via the
+	// traceparent in the gRPC metadata.
+	tracer, err := tracing.NewFromEnv(newPluginLogger().WithField(telemetry.SubsystemName, telemetry.Tracing))
+	if err != nil {
+		logger.Error("Failed to configure tracing", "error", err)
+	} else if tracer.Enabled() {
+		ctx, cancel := context.WithCancel(context.Background())
+		tracerDone := make(chan struct{})
+		go func() {
+			defer close(tracerDone)
+			_ = tracer.Run(ctx)
+		}()
+		// Serve returns once the host stops the plugin. Flush the spans that
+		// have not been exported yet before the process exits.
+		defer func() {
+			cancel()
+			<-tracerDone
+		}()
+	}
+
 	goplugin.Serve(&goplugin.ServeConfig{
 		HandshakeConfig: goplugin.HandshakeConfig{
 			ProtocolVersion:  1,
@@ -43,10 +70,39 @@ func PluginMain(plugin Plugin) {
 			},
 		},
 		Logger:     logger,
-		GRPCServer: goplugin.DefaultGRPCServer,
+		GRPCServer: newPluginServer(tracer),
 	})
 }
 
+// newPluginLogger returns a logrus logger that emits JSON in the format the
+// host parses from plugin stderr.
+func newPluginLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.Out = os.Stderr
+	logger.Formatter = &logrus.JSONFormatter{
+		TimestampFormat: "2006-01-02T15:04:05.000000Z07:00",
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyMsg:   "@message",
+			logrus.FieldKeyLevel: "@level",
+			logrus.FieldKeyTime:  "@timestamp",
+		},
+	}
+	return logger
+}
+
+func newPluginServer(tracer *tracing.Tracer) func([]grpc.ServerOption) *grpc.Server {
+	return func(opts []grpc.ServerOption) *grpc.Server {
+		return grpc.NewServer(append(opts,
+			grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+				tracer.StreamServerInterceptor,
+			)),
+			grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+				tracer.UnaryServerInterceptor,
+			)),
+		)...)
+	}
+}
+
 type hcServerPlugin struct {
 	goplugin.NetRPCUnsupportedPlugin
 	logger hclog.Logger
@@ -80,23 +136,101 @@ func (d grpcBrokerDialer) DialHost() (*grpc.ClientConn, error) {
 
 type ExternalPlugin struct {
 	Log           logrus.FieldLogger
+	Metrics       telemetry.Metrics
+	Tracer        *tracing.Tracer
 	Name          string
+	Type          string
 	Path          string
 	Checksum      string
 	Data          string
 	Plugin        PluginClient
 	KnownServices []ServiceClient
 	HostServices  []HostServiceServer
+
+	// ProbeInterval is how often the plugin is probed for health. If the
+	// plugin process exits or stops responding, it is restarted. Defaults
+	// to DefaultExternalPluginProbeInterval.
+	ProbeInterval time.Duration
+
+	// Clock is used to schedule health probes and restarts. Defaults to the
+	// system clock.
+	Clock clock.Clock
 }
 
+// LoadExternalPlugin starts an external plugin process. The process is
+// supervised and restarted (and configured again with the last
+// configuration) if it exits or stops responding to health probes. Clients
+// filled from the returned plugin keep working across restarts.
 func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *LoadedPlugin, err error) {
 	// Resolve to an absolute path. We don't to use path environment lookups.
 	ext.Path, err = filepath.Abs(ext.Path)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
+	if ext.Log == nil {
+		ext.Log = newDiscardingLogger()
+	}
+	if ext.Metrics == nil {
+		ext.Metrics = telemetry.Blackhole{}
+	}
+	if ext.Type == "" {
+		ext.Type = ext.Plugin.PluginType()
+	}
+	if ext.ProbeInterval <= 0 {
+		ext.ProbeInterval = DefaultExternalPluginProbeInterval
+	}
+	if ext.Clock == nil {
+		ext.Clock = clock.New()
+	}
+
+	if ext.Checksum == "" {
+		ext.Log.Warn("Plugin checksum not configured")
+	}
 
+	process, err := startExternalProcess(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	supervisor := newExternalPluginSupervisor(ext, process)
+	defer func() {
+		if err != nil {
+			supervisor.Close()
+		}
+	}()
+
+	// The clients handed out by the plugin are created over a connection
+	// that dials whichever process is currently running, so they survive
+	// restarts of the plugin.
+	conn, err := grpc.Dial(ext.Name, grpc.WithInsecure(), grpc.WithContextDialer(supervisor.dial))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	plugin = newLoadedPlugin(conn, ext.Name, ext.Plugin, process.plugin.services)
+	plugin.supervisor = supervisor
+	supervisor.Start(conn, plugin.lastConfig)
+
+	plugin.closer = func() {
+		supervisor.Close()
+		conn.Close()
+	}
+	return plugin, nil
+}
+
+// externalProcess is a running external plugin process
+type externalProcess struct {
+	client   *goplugin.Client
+	hcPlugin *hcClientPlugin
+	addr     net.Addr
+
+	// plugin has clients over the connection established by go-plugin.
+	plugin *LoadedPlugin
+}
+
+func startExternalProcess(ext ExternalPlugin) (process *externalProcess, err error) {
 	cmd := pluginCmd(ext.Path)
+	cmd.Env = ext.Tracer.PluginEnv(ext.Name)
 
 	var secureConfig *goplugin.SecureConfig
 	if ext.Checksum != "" {
@@ -104,8 +238,6 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		ext.Log.Warn("Plugin checksum not configured")
 	}
 
 	logger := log.HCLogAdapter{
@@ -141,6 +273,11 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		}
 	}()
 
+	addr, err := pluginClient.Start()
+	if err != nil {
+		return nil, err
+	}
+
 	// create the GRPC client and ensure it is closed on error
 	grpcClient, err := pluginClient.Client()
 	if err != nil {
@@ -159,13 +296,23 @@ func LoadExternalPlugin(ctx context.Context, ext ExternalPlugin) (plugin *Loaded
 		return nil, errs.New("expected %T, got %T", plugin, pluginRaw)
 	}
 
-	// Kill also closes the gRPC client
-	plugin.closer = func() {
-		pluginClient.Kill()
-		hcPlugin.WaitUntilBrokerDone()
-	}
+	return &externalProcess{
+		client:   pluginClient,
+		hcPlugin: hcPlugin,
+		addr:     addr,
+		plugin:   plugin,
+	}, nil
+}
 
-	return plugin, nil
+// Exited returns true if the plugin process has exited
+func (p *externalProcess) Exited() bool {
+	return p.client.Exited()
+}
+
+// Kill kills the plugin process. Kill also closes the gRPC client.
+func (p *externalProcess) Kill() {
+	p.client.Kill()
+	p.hcPlugin.WaitUntilBrokerDone()
 }
 
 func buildSecureConfig(checksum string) (*goplugin.SecureConfig, error) {
@@ -201,7 +348,7 @@ func (p *hcClientPlugin) GRPCClient(ctx context.Context, b *goplugin.GRPCBroker,
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
-	server := NewHostServer(p.ext.Name, nil, p.ext.HostServices)
+	server := NewHostServer(p.ext.Tracer, p.ext.Name, nil, p.ext.HostServices)
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
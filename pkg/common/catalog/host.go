@@ -5,16 +5,19 @@ import (
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"google.golang.org/grpc"
 )
 
-func NewHostServer(pluginName string, opts []grpc.ServerOption, hostServices []HostServiceServer) *grpc.Server {
+func NewHostServer(tracer *tracing.Tracer, pluginName string, opts []grpc.ServerOption, hostServices []HostServiceServer) *grpc.Server {
 	s := grpc.NewServer(append(opts,
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			tracer.StreamServerInterceptor,
 			streamPluginInterceptor(pluginName),
 			grpc_recovery.StreamServerInterceptor(),
 		)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			tracer.UnaryServerInterceptor,
 			unaryPluginInterceptor(pluginName),
 			grpc_recovery.UnaryServerInterceptor(),
 		)),
@@ -28,6 +28,16 @@ type catalogPluginConfig struct {
 }
 
 func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPluginConfig) (*LoadedPlugin, error) {
+	services, err := initCatalogPlugin(ctx, c, config)
+	if err != nil {
+		return nil, err
+	}
+	return newLoadedPlugin(c, config.Name, config.Plugin, services), nil
+}
+
+// initCatalogPlugin initializes the plugin served over the connection and
+// returns the known services offered by the plugin.
+func initCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPluginConfig) ([]ServiceClient, error) {
 	hostServiceTypes, err := makeHostServiceTypes(config.HostServices)
 	if err != nil {
 		return nil, err
@@ -48,8 +58,7 @@ func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPlu
 		return nil, errs.Wrap(err)
 	}
 
-	var serviceImpls []interface{}
-	var serviceNames []string
+	var services []ServiceClient
 	if resp != nil {
 		for _, typ := range resp.PluginServices {
 			service, ok := servicesMap[typ]
@@ -57,20 +66,34 @@ func newCatalogPlugin(ctx context.Context, c *grpc.ClientConn, config catalogPlu
 				config.Log.WithField(telemetry.PluginService, typ).Warn("Unknown service type.")
 				continue
 			}
-			serviceImpls = append(serviceImpls, service.NewServiceClient(c))
-			serviceNames = append(serviceNames, typ)
+			services = append(services, service)
 		}
-		sort.Strings(serviceNames)
+		sort.Slice(services, func(i, j int) bool {
+			return services[i].ServiceType() < services[j].ServiceType()
+		})
 	}
+	return services, nil
+}
 
-	pluginImpl := config.Plugin.NewPluginClient(c)
+// newLoadedPlugin returns a plugin with clients to the plugin and services
+// served over the connection.
+func newLoadedPlugin(c *grpc.ClientConn, name string, plugin PluginClient, services []ServiceClient) *LoadedPlugin {
+	pluginImpl := plugin.NewPluginClient(c)
+
+	all := []interface{}{pluginImpl}
+	var serviceNames []string
+	for _, service := range services {
+		all = append(all, service.NewServiceClient(c))
+		serviceNames = append(serviceNames, service.ServiceType())
+	}
 
 	return &LoadedPlugin{
-		name:         config.Name,
+		name:         name,
 		plugin:       pluginImpl,
-		all:          append([]interface{}{pluginImpl}, serviceImpls...),
+		all:          all,
 		serviceNames: serviceNames,
-	}, nil
+		services:     services,
+	}
 }
 
 func initPluginServer(s *grpc.Server, dialer hostDialer, logger hclog.Logger, plugin PluginServer, services []ServiceServer) {
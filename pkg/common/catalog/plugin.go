@@ -13,6 +13,14 @@ type LoadedPlugin struct {
 	plugin       interface{}
 	all          []interface{}
 	serviceNames []string
+	services     []ServiceClient
+
+	// supervisor restarts the plugin process. It is only set for external
+	// plugins.
+	supervisor *externalPluginSupervisor
+
+	configMtx sync.Mutex
+	config    *spi.ConfigureRequest
 
 	closeOnce sync.Once
 	closer    func()
@@ -34,9 +42,30 @@ func (p *LoadedPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	if err != nil {
 		return errs.Wrap(err)
 	}
+
+	// remember the configuration so the plugin can be configured again if
+	// it has to be restarted.
+	p.configMtx.Lock()
+	p.config = req
+	p.configMtx.Unlock()
 	return nil
 }
 
+// ExternalStatus returns the supervision status of the plugin. It returns
+// false if the plugin is not an external plugin.
+func (p *LoadedPlugin) ExternalStatus() (ExternalPluginStatus, bool) {
+	if p.supervisor == nil {
+		return ExternalPluginStatus{}, false
+	}
+	return p.supervisor.Status(), true
+}
+
+func (p *LoadedPlugin) lastConfig() *spi.ConfigureRequest {
+	p.configMtx.Lock()
+	defer p.configMtx.Unlock()
+	return p.config
+}
+
 func (p *LoadedPlugin) Fill(x interface{}) (err error) {
 	cf := newPluginFiller(p)
 	return cf.fill(x)
@@ -0,0 +1,286 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v3"
+	goplugin "github.com/hashicorp/go-plugin"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DefaultExternalPluginProbeInterval is how often external plugins are
+	// probed for health by default
+	DefaultExternalPluginProbeInterval = 5 * time.Second
+
+	// externalPluginProbeFailures is the number of consecutive failed health
+	// probes after which a plugin whose process is still running is restarted
+	externalPluginProbeFailures = 3
+
+	// externalPluginRestartBackoff and externalPluginMaxRestartBackoff bound
+	// the delay between failed attempts to restart a plugin
+	externalPluginRestartBackoff    = time.Second
+	externalPluginMaxRestartBackoff = time.Minute
+
+	// externalPluginRecentRestart is how long after being restarted a plugin
+	// is reported as degraded
+	externalPluginRecentRestart = 10 * time.Minute
+)
+
+// ExternalPluginStatus is the supervision status of an external plugin
+type ExternalPluginStatus struct {
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Running     bool      `json:"running"`
+	Restarts    int       `json:"restarts"`
+	LastRestart time.Time `json:"last_restart,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// externalPluginSupervisor probes an external plugin process and restarts it
+// if the process exits or stops responding.
+type externalPluginSupervisor struct {
+	ext ExternalPlugin
+	log logrus.FieldLogger
+
+	mu      sync.Mutex
+	process *externalProcess
+	status  ExternalPluginStatus
+
+	conn       *grpc.ClientConn
+	lastConfig func() *spi.ConfigureRequest
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+func newExternalPluginSupervisor(ext ExternalPlugin, process *externalProcess) *externalPluginSupervisor {
+	return &externalPluginSupervisor{
+		ext: ext,
+		log: ext.Log.WithFields(logrus.Fields{
+			telemetry.PluginName: ext.Name,
+			telemetry.PluginType: ext.Type,
+		}),
+		process: process,
+		status: ExternalPluginStatus{
+			Name:    ext.Name,
+			Type:    ext.Type,
+			Running: true,
+		},
+	}
+}
+
+// Start starts supervising the plugin. The connection is probed for health
+// and lastConfig returns the configuration used when restarting the plugin.
+func (s *externalPluginSupervisor) Start(conn *grpc.ClientConn, lastConfig func() *spi.ConfigureRequest) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.conn = conn
+	s.lastConfig = lastConfig
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+}
+
+// Close stops supervising the plugin and kills the plugin process.
+func (s *externalPluginSupervisor) Close() {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+
+	s.mu.Lock()
+	process := s.process
+	s.process = nil
+	s.status.Running = false
+	s.mu.Unlock()
+
+	if process != nil {
+		process.Kill()
+	}
+}
+
+// Status returns the supervision status of the plugin
+func (s *externalPluginSupervisor) Status() ExternalPluginStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// dial dials the plugin process that is currently running
+func (s *externalPluginSupervisor) dial(ctx context.Context, _ string) (net.Conn, error) {
+	s.mu.Lock()
+	process := s.process
+	s.mu.Unlock()
+
+	if process == nil {
+		return nil, errs.New("external plugin %q is not running", s.ext.Name)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, process.addr.Network(), process.addr.String())
+}
+
+func (s *externalPluginSupervisor) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := s.ext.Clock.Ticker(s.ext.ProbeInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		s.mu.Lock()
+		process := s.process
+		s.mu.Unlock()
+
+		if process.Exited() {
+			s.restart(ctx, errs.New("plugin process exited"))
+			failures = 0
+			continue
+		}
+
+		if err := s.probe(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			s.log.WithError(err).Warn("External plugin health probe failed")
+			if failures >= externalPluginProbeFailures {
+				s.restart(ctx, fmt.Errorf("plugin failed %d consecutive health probes: %v", failures, err))
+				failures = 0
+			}
+			continue
+		}
+		failures = 0
+	}
+}
+
+func (s *externalPluginSupervisor) probe(ctx context.Context, opts ...grpc.CallOption) error {
+	ctx, cancel := context.WithTimeout(ctx, s.ext.ProbeInterval)
+	defer cancel()
+
+	resp, err := grpc_health_v1.NewHealthClient(s.conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: goplugin.GRPCServiceName,
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return errs.New("plugin status is %s", resp.Status)
+	}
+	return nil
+}
+
+// restart kills the plugin process and starts a new one, retrying with
+// backoff until it succeeds or the supervisor is closed.
+func (s *externalPluginSupervisor) restart(ctx context.Context, reason error) {
+	s.log.WithError(reason).Warn("Restarting external plugin")
+
+	s.mu.Lock()
+	process := s.process
+	s.process = nil
+	s.status.Running = false
+	s.status.LastError = reason.Error()
+	s.mu.Unlock()
+
+	if process != nil {
+		process.Kill()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = externalPluginRestartBackoff
+	bo.MaxInterval = externalPluginMaxRestartBackoff
+	bo.MaxElapsedTime = 0
+	bo.Clock = s.ext.Clock
+	bo.Reset()
+
+	for {
+		process, err := s.startProcess(ctx)
+		if err == nil {
+			s.mu.Lock()
+			s.process = process
+			s.status.Running = true
+			s.status.Restarts++
+			s.status.LastRestart = s.ext.Clock.Now()
+			s.mu.Unlock()
+
+			telemetry_common.IncrExternalPluginRestartCounter(s.ext.Metrics, s.ext.Name, s.ext.Type)
+
+			// Reconnect right away instead of waiting out the connection
+			// backoff accumulated while the plugin was down.
+			s.conn.ResetConnectBackoff()
+			if err := s.probe(ctx, grpc.WaitForReady(true)); err != nil && ctx.Err() == nil {
+				s.log.WithError(err).Warn("External plugin health probe failed")
+			}
+
+			s.log.Info("External plugin restarted")
+			return
+		}
+
+		s.log.WithError(err).Error("Failed to restart external plugin")
+		s.mu.Lock()
+		s.status.LastError = err.Error()
+		s.mu.Unlock()
+
+		select {
+		case <-s.ext.Clock.After(bo.NextBackOff()):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// startProcess starts a new plugin process and configures it with the last
+// configuration of the plugin
+func (s *externalPluginSupervisor) startProcess(ctx context.Context) (*externalProcess, error) {
+	process, err := startExternalProcess(s.ext)
+	if err != nil {
+		return nil, err
+	}
+
+	if config := s.lastConfig(); config != nil {
+		if err := process.plugin.Configure(ctx, config); err != nil {
+			process.Kill()
+			return nil, errs.New("unable to configure plugin: %v", err)
+		}
+	}
+	return process, nil
+}
+
+// checkExternalPlugins reports the status of the external plugins. The check
+// is unhealthy while a plugin is not running and degraded if a plugin was
+// restarted recently.
+func checkExternalPlugins(statuses []ExternalPluginStatus, now time.Time) (interface{}, error) {
+	var notRunning, restarted []string
+	for _, status := range statuses {
+		switch {
+		case !status.Running:
+			notRunning = append(notRunning, status.Name)
+		case status.Restarts > 0 && now.Sub(status.LastRestart) < externalPluginRecentRestart:
+			restarted = append(restarted, status.Name)
+		}
+	}
+
+	switch {
+	case len(notRunning) > 0:
+		return statuses, fmt.Errorf("external plugins not running: %s", strings.Join(notRunning, ", "))
+	case len(restarted) > 0:
+		return statuses, health.Degraded(fmt.Errorf("external plugins restarted recently: %s", strings.Join(restarted, ", ")))
+	}
+	return statuses, nil
+}
@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExternalPlugins(t *testing.T) {
+	now := time.Now()
+
+	running := ExternalPluginStatus{Name: "running", Running: true}
+	restartedRecently := ExternalPluginStatus{
+		Name:        "restarted-recently",
+		Running:     true,
+		Restarts:    1,
+		LastRestart: now.Add(-time.Minute),
+	}
+	restartedLongAgo := ExternalPluginStatus{
+		Name:        "restarted-long-ago",
+		Running:     true,
+		Restarts:    2,
+		LastRestart: now.Add(-externalPluginRecentRestart),
+	}
+	notRunning := ExternalPluginStatus{Name: "not-running", LastError: "plugin process exited"}
+
+	// no external plugins
+	details, err := checkExternalPlugins(nil, now)
+	require.NoError(t, err)
+	require.Empty(t, details)
+
+	statuses := []ExternalPluginStatus{running, restartedLongAgo}
+	details, err = checkExternalPlugins(statuses, now)
+	require.NoError(t, err)
+	require.Equal(t, statuses, details)
+
+	statuses = []ExternalPluginStatus{running, restartedRecently}
+	details, err = checkExternalPlugins(statuses, now)
+	require.EqualError(t, err, "external plugins restarted recently: restarted-recently")
+	require.True(t, health.IsDegraded(err))
+	require.Equal(t, statuses, details)
+
+	statuses = []ExternalPluginStatus{running, restartedRecently, notRunning}
+	details, err = checkExternalPlugins(statuses, now)
+	require.EqualError(t, err, "external plugins not running: not-running")
+	require.False(t, health.IsDegraded(err))
+	require.Equal(t, statuses, details)
+}
@@ -5,6 +5,7 @@ package test
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"google.golang.org/grpc"
@@ -57,6 +58,18 @@ func (c *hostServiceHostServiceClient) InitHostServiceClient(conn *grpc.ClientCo
 	*c.client = AdaptHostServiceHostServiceClient(NewHostServiceClient(conn))
 }
 
+// RequireHostServiceHostService obtains a client for the HostService host service from the broker. It fails if the host does not provide the host service.
+func RequireHostServiceHostService(broker catalog.HostServiceBroker, client *HostService) error {
+	has, err := broker.GetHostService(HostServiceHostServiceClient(client))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("required %s host service not available", HostServiceType)
+	}
+	return nil
+}
+
 func AdaptHostServiceHostServiceClient(client HostServiceClient) HostService {
 	return hostServiceHostServiceClientAdapter{client: client}
 }
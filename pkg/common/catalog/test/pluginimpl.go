@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -11,6 +12,9 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ExitRequest is the input that makes the test plugin exit
+const ExitRequest = "exit"
+
 func NewPlugin() PluginPlugin {
 	return &testPlugin{}
 }
@@ -52,6 +56,12 @@ func (s *testPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (
 }
 
 func (s *testPlugin) CallPlugin(ctx context.Context, req *Request) (*Response, error) {
+	if req.In == ExitRequest {
+		// Simulates a crash of an external plugin. Must not be used with
+		// builtins since it would terminate the host.
+		os.Exit(1)
+	}
+
 	out := req.In
 	if s.hs != nil {
 		resp, err := s.hs.CallHostService(ctx, &Request{
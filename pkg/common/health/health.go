@@ -2,16 +2,92 @@ package health
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/InVisionApp/go-health"
-	"github.com/InVisionApp/go-health/handlers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
+// Status is the state of a single health check or of the process as a whole
+type Status string
+
+const (
+	// StatusHealthy means the check passed
+	StatusHealthy Status = "healthy"
+
+	// StatusDegraded means the check failed in a way that does not prevent
+	// the process from serving requests (e.g. a federated bundle could not be
+	// refreshed). Degraded checks do not fail probes.
+	StatusDegraded Status = "degraded"
+
+	// StatusUnhealthy means the check failed and the process is unable to
+	// serve requests. Unhealthy checks fail probes.
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// severity orders statuses from best to worst
+func (s Status) severity() int {
+	switch s {
+	case StatusHealthy:
+		return 0
+	case StatusDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Report is the JSON document served on the ready and live paths
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckReport `json:"checks"`
+}
+
+// CheckReport is the result of the most recent run of a health check
+type CheckReport struct {
+	Status             Status      `json:"status"`
+	Error              string      `json:"error,omitempty"`
+	Details            interface{} `json:"details,omitempty"`
+	CheckTime          time.Time   `json:"check_time"`
+	ContiguousFailures int64       `json:"contiguous_failures,omitempty"`
+}
+
+// CheckFunc adapts a function to the health.ICheckable interface
+type CheckFunc func() (interface{}, error)
+
+// Status calls f()
+func (f CheckFunc) Status() (interface{}, error) {
+	return f()
+}
+
+type degradedError struct {
+	err error
+}
+
+func (e degradedError) Error() string {
+	return e.err.Error()
+}
+
+// Degraded wraps an error returned by a health check to report the check as
+// degraded instead of unhealthy.
+func Degraded(err error) error {
+	if err == nil {
+		return nil
+	}
+	return degradedError{err: err}
+}
+
+// IsDegraded returns true if the error was wrapped with Degraded
+func IsDegraded(err error) bool {
+	_, ok := err.(degradedError)
+	return ok
+}
+
 // health.Checker is responsible for running health checks and serving the healthcheck HTTP paths
 type Checker struct {
 	config Config
@@ -21,48 +97,170 @@ type Checker struct {
 	hc    *health.Health
 	mutex sync.Mutex // Mutex protects non-threadsafe hc
 
+	// checks tracks how the most recent result of each check is reported
+	checksMtx sync.RWMutex
+	checks    map[string]*checkInfo
+
 	log logrus.FieldLogger
 }
 
-func live(w http.ResponseWriter, _ *http.Request) {
-	w.Header().Set("Content-Type", "text/plain")
-	w.WriteHeader(http.StatusOK)
+type checkInfo struct {
+	liveness bool
+	degraded bool
 }
 
 func NewChecker(config Config, log logrus.FieldLogger) *Checker {
 	hc := health.New()
+	log = log.WithField(telemetry.SubsystemName, "health")
+
+	c := &Checker{
+		config: config,
+		hc:     hc,
+		checks: make(map[string]*checkInfo),
+		log:    log,
+	}
 
-	var server *http.Server
 	// Start HTTP server if address is configured
 	address := config.getAddress()
 	if address != nil {
 		handler := http.NewServeMux()
 
-		handler.HandleFunc(config.getReadyPath(), handlers.NewJSONHandlerFunc(hc, nil))
-		handler.HandleFunc(config.getLivePath(), live)
+		handler.HandleFunc(config.getReadyPath(), c.serveReady)
+		handler.HandleFunc(config.getLivePath(), c.serveLive)
 
-		server = &http.Server{
+		c.server = &http.Server{
 			Addr:    *address,
 			Handler: handler,
 		}
 	}
 
-	hc.StatusListener = &statusListener{}
-	hc.Logger = &logadapter{FieldLogger: log.WithField(telemetry.SubsystemName, "health")}
+	hc.StatusListener = &statusListener{log: log}
+	hc.Logger = &logadapter{FieldLogger: log}
 
-	return &Checker{config: config, server: server, hc: hc, log: log}
+	return c
 }
 
+// AddCheck adds a readiness check. A failing readiness check fails the ready
+// probe, but not the live probe.
 func (c *Checker) AddCheck(name string, checker health.ICheckable, interval time.Duration) error {
+	return c.addCheck(name, checker, interval, false)
+}
+
+// AddLivenessCheck adds a check that is reported on both the ready and live
+// probes.
+func (c *Checker) AddLivenessCheck(name string, checker health.ICheckable, interval time.Duration) error {
+	return c.addCheck(name, checker, interval, true)
+}
+
+func (c *Checker) addCheck(name string, checker health.ICheckable, interval time.Duration, liveness bool) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	return c.hc.AddCheck(&health.Config{
-		Name:     name,
-		Checker:  checker,
+	info := &checkInfo{liveness: liveness}
+	if err := c.hc.AddCheck(&health.Config{
+		Name: name,
+		Checker: CheckFunc(func() (interface{}, error) {
+			details, err := checker.Status()
+			c.checksMtx.Lock()
+			info.degraded = IsDegraded(err)
+			c.checksMtx.Unlock()
+			return details, err
+		}),
 		Interval: interval,
 		Fatal:    true,
-	})
+	}); err != nil {
+		return err
+	}
+
+	c.checksMtx.Lock()
+	c.checks[name] = info
+	c.checksMtx.Unlock()
+	return nil
+}
+
+// ReadyReport returns the result of all checks
+func (c *Checker) ReadyReport() Report {
+	return c.report(false)
+}
+
+// LiveReport returns the result of the liveness checks
+func (c *Checker) LiveReport() Report {
+	return c.report(true)
+}
+
+func (c *Checker) report(livenessOnly bool) Report {
+	report := Report{
+		Status: StatusHealthy,
+		Checks: make(map[string]CheckReport),
+	}
+
+	// Checks that have not run yet are not reported. All checks run as soon
+	// as the checker is started.
+	states, _, err := c.hc.State()
+	if err != nil {
+		report.Status = StatusUnhealthy
+		return report
+	}
+
+	c.checksMtx.RLock()
+	defer c.checksMtx.RUnlock()
+
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		info, ok := c.checks[name]
+		if !ok || (livenessOnly && !info.liveness) {
+			continue
+		}
+
+		state := states[name]
+		check := CheckReport{
+			Status:             StatusHealthy,
+			Error:              state.Err,
+			Details:            state.Details,
+			CheckTime:          state.CheckTime,
+			ContiguousFailures: state.ContiguousFailures,
+		}
+		if state.Status != "ok" {
+			check.Status = StatusUnhealthy
+			if info.degraded {
+				check.Status = StatusDegraded
+			}
+		}
+		if check.Status.severity() > report.Status.severity() {
+			report.Status = check.Status
+		}
+		report.Checks[name] = check
+	}
+
+	return report
+}
+
+func (c *Checker) serveReady(w http.ResponseWriter, _ *http.Request) {
+	c.writeReport(w, c.ReadyReport())
+}
+
+func (c *Checker) serveLive(w http.ResponseWriter, _ *http.Request) {
+	c.writeReport(w, c.LiveReport())
+}
+
+// writeReport writes the report as JSON. Unhealthy reports are served with a
+// 503 so that they fail Kubernetes probes; degraded reports are not.
+func (c *Checker) writeReport(w http.ResponseWriter, report Report) {
+	statusCode := http.StatusOK
+	if report.Status == StatusUnhealthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		c.log.WithError(err).Warn("Failed to write health check report")
+	}
 }
 
 func (c *Checker) ListenAndServe(ctx context.Context) error {
@@ -1,10 +1,17 @@
 package health
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServerDisabledByDefault(t *testing.T) {
@@ -20,3 +27,110 @@ func TestServerEnabled(t *testing.T) {
 
 	assert.NotNil(t, checker.server)
 }
+
+func TestReports(t *testing.T) {
+	log, _ := logtest.NewNullLogger()
+	checker := NewChecker(Config{}, log)
+
+	require.NoError(t, checker.AddLivenessCheck("process", CheckFunc(func() (interface{}, error) {
+		return nil, nil
+	}), time.Minute))
+	require.NoError(t, checker.AddCheck("bundles", CheckFunc(func() (interface{}, error) {
+		return map[string]int{"stale": 1}, Degraded(errors.New("bundle is stale"))
+	}), time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = checker.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitForChecks(t, checker, 2)
+
+	report := checker.ReadyReport()
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, StatusHealthy, report.Checks["process"].Status)
+	assert.Equal(t, StatusDegraded, report.Checks["bundles"].Status)
+	assert.Equal(t, "bundle is stale", report.Checks["bundles"].Error)
+	assert.Equal(t, map[string]int{"stale": 1}, report.Checks["bundles"].Details)
+
+	// Degraded checks do not fail the ready probe
+	w := httptest.NewRecorder()
+	checker.serveReady(w, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var served Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &served))
+	assert.Equal(t, StatusDegraded, served.Status)
+	assert.Len(t, served.Checks, 2)
+
+	// Only liveness checks are reported on the live probe
+	report = checker.LiveReport()
+	assert.Equal(t, StatusHealthy, report.Status)
+	assert.Len(t, report.Checks, 1)
+	assert.Contains(t, report.Checks, "process")
+}
+
+func TestUnhealthyReport(t *testing.T) {
+	log, _ := logtest.NewNullLogger()
+	checker := NewChecker(Config{}, log)
+
+	require.NoError(t, checker.AddLivenessCheck("process", CheckFunc(func() (interface{}, error) {
+		return nil, nil
+	}), time.Minute))
+	require.NoError(t, checker.AddCheck("datastore", CheckFunc(func() (interface{}, error) {
+		return nil, errors.New("connection refused")
+	}), time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = checker.ListenAndServe(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	waitForChecks(t, checker, 2)
+
+	report := checker.ReadyReport()
+	assert.Equal(t, StatusUnhealthy, report.Status)
+	assert.Equal(t, StatusUnhealthy, report.Checks["datastore"].Status)
+	assert.Equal(t, "connection refused", report.Checks["datastore"].Error)
+
+	w := httptest.NewRecorder()
+	checker.serveReady(w, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	// A failing readiness check does not fail the live probe
+	w = httptest.NewRecorder()
+	checker.serveLive(w, httptest.NewRequest("GET", "/live", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDegraded(t *testing.T) {
+	assert.Nil(t, Degraded(nil))
+	assert.False(t, IsDegraded(nil))
+	assert.False(t, IsDegraded(errors.New("oh no")))
+
+	err := Degraded(errors.New("oh no"))
+	assert.True(t, IsDegraded(err))
+	assert.EqualError(t, err, "oh no")
+}
+
+func waitForChecks(t *testing.T, checker *Checker, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for len(checker.ReadyReport().Checks) < n {
+		if time.Now().After(deadline) {
+			require.FailNow(t, "timed out waiting for health checks to run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -338,3 +338,43 @@ func ServerURI(trustDomain string) *url.URL {
 		Path:   path.Join("spire", "server"),
 	}
 }
+
+// ValidatePathPrefix validates a SPIFFE ID path prefix used to constrain the
+// identities a downstream CA or JWT key can sign for.
+func ValidatePathPrefix(prefix string) error {
+	switch {
+	case prefix == "":
+		return errors.New("path prefix is empty")
+	case !strings.HasPrefix(prefix, "/"):
+		return fmt.Errorf("path prefix %q must start with a slash", prefix)
+	case prefix != "/" && strings.HasSuffix(prefix, "/"):
+		return fmt.Errorf("path prefix %q must not end with a slash", prefix)
+	case path.Clean(prefix) != prefix:
+		return fmt.Errorf("path prefix %q is not clean", prefix)
+	}
+	return nil
+}
+
+// PathHasPrefix returns true if the path is equal to or nested under the path
+// prefix. The comparison is done on path segments, i.e. the prefix "/foo"
+// matches "/foo" and "/foo/bar" but not "/foobar".
+func PathHasPrefix(p, prefix string) bool {
+	if prefix == "/" || p == prefix {
+		return true
+	}
+	return strings.HasPrefix(p, prefix+"/")
+}
+
+// PathHasAnyPrefix returns true if the path matches any of the path prefixes.
+// An empty list of prefixes matches every path.
+func PathHasAnyPrefix(p string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if PathHasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -280,3 +280,38 @@ func TestNormalizeSpiffeID(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePathPrefix(t *testing.T) {
+	tests := []struct {
+		prefix        string
+		expectedError string
+	}{
+		{prefix: "/"},
+		{prefix: "/ns/foo"},
+		{prefix: "", expectedError: "path prefix is empty"},
+		{prefix: "ns/foo", expectedError: `path prefix "ns/foo" must start with a slash`},
+		{prefix: "/ns/foo/", expectedError: `path prefix "/ns/foo/" must not end with a slash`},
+		{prefix: "/ns/../foo", expectedError: `path prefix "/ns/../foo" is not clean`},
+	}
+
+	for _, test := range tests {
+		test := test // alias loop variable as it is used in the closure
+		t.Run(test.prefix, func(t *testing.T) {
+			err := ValidatePathPrefix(test.prefix)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestPathHasAnyPrefix(t *testing.T) {
+	assert.True(t, PathHasAnyPrefix("/ns/foo", nil))
+	assert.True(t, PathHasAnyPrefix("/ns/foo", []string{"/"}))
+	assert.True(t, PathHasAnyPrefix("/ns/foo", []string{"/ns/foo"}))
+	assert.True(t, PathHasAnyPrefix("/ns/foo/bar", []string{"/ns/bar", "/ns/foo"}))
+	assert.False(t, PathHasAnyPrefix("/ns/foobar", []string{"/ns/foo"}))
+	assert.False(t, PathHasAnyPrefix("/ns", []string{"/ns/foo"}))
+}
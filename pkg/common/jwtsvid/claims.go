@@ -0,0 +1,205 @@
+package jwtsvid
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
+)
+
+var (
+	// reservedClaims are the registered claims populated by the signer. They
+	// cannot be set through custom claims.
+	reservedClaims = map[string]bool{
+		"iss": true,
+		"sub": true,
+		"aud": true,
+		"exp": true,
+		"nbf": true,
+		"iat": true,
+		"jti": true,
+	}
+
+	// parsedClaimTemplates caches parsed claim templates by their text so
+	// templates are parsed once, when the entry is created, and not every
+	// time a JWT-SVID is issued.
+	parsedClaimTemplates = newClaimTemplateCache(maxParsedClaimTemplates)
+)
+
+// maxParsedClaimTemplates bounds the number of parsed claim templates that
+// are cached.
+const maxParsedClaimTemplates = 1024
+
+// ClaimTemplateData is the data claim templates are rendered against. Besides
+// the fields, templates can use the "selector" function, which returns the
+// value of the single selector matching "<type>:<prefix>" with the prefix
+// removed (e.g. {{ selector "k8s:ns" }}), the "selectors" function, which
+// returns the sorted values of all such selectors, and "join".
+type ClaimTemplateData struct {
+	// SPIFFEID is the SPIFFE ID the JWT-SVID is issued for.
+	SPIFFEID string
+}
+
+// ValidateClaims validates the static claims and claim templates of a
+// registration entry.
+func ValidateClaims(claims, claimTemplates map[string]string) error {
+	for name := range claims {
+		if err := validateClaimName(name); err != nil {
+			return err
+		}
+	}
+	for name, text := range claimTemplates {
+		if err := validateClaimName(name); err != nil {
+			return err
+		}
+		if _, ok := claims[name]; ok {
+			return errs.New("claim %q cannot be both static and templated", name)
+		}
+		if _, err := parsedClaimTemplates.get(text); err != nil {
+			return errs.New("invalid template for claim %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// RenderClaims returns the custom claims of a JWT-SVID issued for the given
+// SPIFFE ID, rendering the claim templates against the given selectors.
+func RenderClaims(spiffeID string, claims, claimTemplates map[string]string, selectors []*common.Selector) (map[string]interface{}, error) {
+	if len(claims) == 0 && len(claimTemplates) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]interface{}, len(claims)+len(claimTemplates))
+	for name, value := range claims {
+		out[name] = value
+	}
+
+	data := &ClaimTemplateData{
+		SPIFFEID: spiffeID,
+	}
+	for name, text := range claimTemplates {
+		tmpl, err := parsedClaimTemplates.get(text)
+		if err != nil {
+			return nil, errs.New("invalid template for claim %q: %v", name, err)
+		}
+		tmpl, err = tmpl.Clone()
+		if err != nil {
+			return nil, errs.New("unable to render claim %q: %v", name, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Funcs(claimTemplateFuncs(selectors)).Execute(&buf, data); err != nil {
+			return nil, errs.New("unable to render claim %q: %v", name, err)
+		}
+		out[name] = buf.String()
+	}
+	return out, nil
+}
+
+func validateClaimName(name string) error {
+	switch {
+	case name == "":
+		return errs.New("claim name cannot be empty")
+	case reservedClaims[name]:
+		return errs.New("claim %q is reserved", name)
+	}
+	return nil
+}
+
+// claimTemplateCache is a bounded cache of parsed claim templates, keyed by
+// their text. The cache is cleared when it is full.
+type claimTemplateCache struct {
+	mu        sync.Mutex
+	templates map[string]*template.Template
+	size      int
+}
+
+func newClaimTemplateCache(size int) *claimTemplateCache {
+	return &claimTemplateCache{
+		templates: make(map[string]*template.Template),
+		size:      size,
+	}
+}
+
+// get returns the parsed template for the given text, parsing it if it is
+// not cached. The returned template must be cloned before binding the
+// selector functions.
+func (c *claimTemplateCache) get(text string) (*template.Template, error) {
+	c.mu.Lock()
+	tmpl, ok := c.templates[text]
+	c.mu.Unlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := parseClaimTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.templates) >= c.size {
+		c.templates = make(map[string]*template.Template)
+	}
+	c.templates[text] = tmpl
+	return tmpl, nil
+}
+
+func (c *claimTemplateCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.templates)
+}
+
+func parseClaimTemplate(text string) (*template.Template, error) {
+	return template.New("").
+		Option("missingkey=error").
+		Funcs(claimTemplateFuncs(nil)).
+		Parse(text)
+}
+
+func claimTemplateFuncs(selectors []*common.Selector) template.FuncMap {
+	return template.FuncMap{
+		"selector": func(typePrefix string) (string, error) {
+			values := selectorValues(selectors, typePrefix)
+			switch len(values) {
+			case 0:
+				return "", errs.New("no selector matches %q", typePrefix)
+			case 1:
+				return values[0], nil
+			default:
+				return "", errs.New("more than one selector matches %q", typePrefix)
+			}
+		},
+		"selectors": func(typePrefix string) []string {
+			return selectorValues(selectors, typePrefix)
+		},
+		"join": strings.Join,
+	}
+}
+
+// selectorValues returns the sorted values of the selectors matching
+// "<type>:<prefix>", with the prefix removed. If no prefix is given, the whole
+// value of the selectors of the given type is returned.
+func selectorValues(selectors []*common.Selector, typePrefix string) []string {
+	selectorType := typePrefix
+	prefix := ""
+	if i := strings.Index(typePrefix, ":"); i >= 0 {
+		selectorType = typePrefix[:i]
+		prefix = typePrefix[i+1:] + ":"
+	}
+
+	var values []string
+	for _, selector := range selectors {
+		if selector.Type != selectorType || !strings.HasPrefix(selector.Value, prefix) {
+			continue
+		}
+		values = append(values, strings.TrimPrefix(selector.Value, prefix))
+	}
+	sort.Strings(values)
+	return values
+}
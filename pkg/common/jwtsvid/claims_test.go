@@ -0,0 +1,164 @@
+package jwtsvid
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateClaims(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		claims         map[string]string
+		claimTemplates map[string]string
+		err            string
+	}{
+		{
+			name: "no claims",
+		},
+		{
+			name:           "valid claims",
+			claims:         map[string]string{"tenant": "acme"},
+			claimTemplates: map[string]string{"namespace": `{{ selector "k8s:ns" }}`},
+		},
+		{
+			name:   "empty claim name",
+			claims: map[string]string{"": "acme"},
+			err:    "claim name cannot be empty",
+		},
+		{
+			name:   "reserved static claim",
+			claims: map[string]string{"aud": "acme"},
+			err:    `claim "aud" is reserved`,
+		},
+		{
+			name:           "reserved templated claim",
+			claimTemplates: map[string]string{"iss": "{{ .SPIFFEID }}"},
+			err:            `claim "iss" is reserved`,
+		},
+		{
+			name:           "claim both static and templated",
+			claims:         map[string]string{"tenant": "acme"},
+			claimTemplates: map[string]string{"tenant": "{{ .SPIFFEID }}"},
+			err:            `claim "tenant" cannot be both static and templated`,
+		},
+		{
+			name:           "malformed template",
+			claimTemplates: map[string]string{"tenant": "{{ .SPIFFEID "},
+			err:            `invalid template for claim "tenant"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClaims(tt.claims, tt.claimTemplates)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRenderClaims(t *testing.T) {
+	selectors := []*common.Selector{
+		{Type: "k8s", Value: "ns:prod"},
+		{Type: "k8s", Value: "sa:api"},
+		{Type: "unix", Value: "gid:2000"},
+		{Type: "unix", Value: "gid:1000"},
+		{Type: "x509pop", Value: "subject:cn:node1"},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		claims         map[string]string
+		claimTemplates map[string]string
+		out            map[string]interface{}
+		err            string
+	}{
+		{
+			name: "no claims",
+		},
+		{
+			name:   "static claims",
+			claims: map[string]string{"tenant": "acme"},
+			out:    map[string]interface{}{"tenant": "acme"},
+		},
+		{
+			name:   "templated claims",
+			claims: map[string]string{"tenant": "acme"},
+			claimTemplates: map[string]string{
+				"env":    `{{ selector "k8s:ns" }}`,
+				"groups": `{{ join (selectors "unix:gid") "," }}`,
+				"node":   `{{ selector "x509pop:subject:cn" }}`,
+				"id":     `{{ .SPIFFEID }}`,
+			},
+			out: map[string]interface{}{
+				"tenant": "acme",
+				"env":    "prod",
+				"groups": "1000,2000",
+				"node":   "node1",
+				"id":     fakeSpiffeID,
+			},
+		},
+		{
+			name:           "no matching selector",
+			claimTemplates: map[string]string{"env": `{{ selector "k8s:pod-label:env" }}`},
+			err:            `unable to render claim "env"`,
+		},
+		{
+			name:           "ambiguous selector",
+			claimTemplates: map[string]string{"group": `{{ selector "unix:gid" }}`},
+			err:            `more than one selector matches "unix:gid"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := RenderClaims(fakeSpiffeID, tt.claims, tt.claimTemplates, selectors)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.out, out)
+		})
+	}
+}
+
+func TestClaimTemplateCache(t *testing.T) {
+	cache := newClaimTemplateCache(2)
+
+	// templates are parsed once and rendered with the selectors of each call
+	tmpl, err := cache.get(`{{ selector "k8s:ns" }}`)
+	require.NoError(t, err)
+	cached, err := cache.get(`{{ selector "k8s:ns" }}`)
+	require.NoError(t, err)
+	require.True(t, tmpl == cached)
+	require.Equal(t, 1, cache.len())
+
+	for _, ns := range []string{"prod", "dev"} {
+		clone, err := cached.Clone()
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		selectors := []*common.Selector{{Type: "k8s", Value: "ns:" + ns}}
+		require.NoError(t, clone.Funcs(claimTemplateFuncs(selectors)).Execute(&buf, nil))
+		require.Equal(t, ns, buf.String())
+	}
+
+	// invalid templates are not cached
+	_, err = cache.get(`{{ selector `)
+	require.Error(t, err)
+	require.Equal(t, 1, cache.len())
+
+	// the cache is cleared once full
+	_, err = cache.get(`{{ .SPIFFEID }}`)
+	require.NoError(t, err)
+	require.Equal(t, 2, cache.len())
+	_, err = cache.get(`{{ selector "unix:uid" }}`)
+	require.NoError(t, err)
+	require.Equal(t, 1, cache.len())
+}
@@ -2,12 +2,24 @@ package jwtsvid
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/zeebo/errs"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
 
+// DelegatedKeyIDPrefix prefixes the key ID of every JWT signing key the
+// server delegates to an agent.
+const DelegatedKeyIDPrefix = "agent."
+
+// IsDelegatedKeyID returns true if the key ID belongs to a JWT signing key
+// delegated to an agent. The path prefixes such keys are scoped to are only
+// enforced by SPIRE, so they should not be handed to other JWT validators.
+func IsDelegatedKeyID(kid string) bool {
+	return strings.HasPrefix(kid, DelegatedKeyIDPrefix)
+}
+
 func GetTokenExpiry(token string) (time.Time, time.Time, error) {
 	tok, err := jwt.ParseSigned(token)
 	if err != nil {
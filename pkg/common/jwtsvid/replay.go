@@ -0,0 +1,95 @@
+package jwtsvid
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/zeebo/errs"
+)
+
+const (
+	// DefaultReplayCacheSize is the default maximum number of unexpired
+	// token IDs remembered by a replay cache.
+	DefaultReplayCacheSize = 100000
+
+	// replayCachePruneInterval is how often expired token IDs are removed
+	// from the replay cache.
+	replayCachePruneInterval = time.Minute
+)
+
+var (
+	// ErrTokenReplayed is returned when a token ID was already observed for
+	// a token that has not yet expired.
+	ErrTokenReplayed = errs.New("token ID has already been used")
+
+	// ErrReplayCacheFull is returned when the replay cache is holding the
+	// maximum number of unexpired token IDs.
+	ErrReplayCacheFull = errs.New("too many unexpired token IDs to track")
+)
+
+// ReplayCache remembers the token IDs (jti) of JWT-SVIDs until the tokens
+// expire so that one-time-use tokens are rejected when presented again. The
+// number of token IDs remembered is bounded; once the limit is reached new
+// token IDs are rejected until older ones expire, since forgetting unexpired
+// token IDs would allow replaying their tokens.
+type ReplayCache struct {
+	clock   clock.Clock
+	maxSize int
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextPrune time.Time
+}
+
+// NewReplayCache returns an empty replay cache that remembers up to maxSize
+// token IDs. The system clock is used if clk is nil and DefaultReplayCacheSize
+// is used if maxSize is not positive.
+func NewReplayCache(clk clock.Clock, maxSize int) *ReplayCache {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultReplayCacheSize
+	}
+	return &ReplayCache{
+		clock:   clk,
+		maxSize: maxSize,
+		seen:    make(map[string]time.Time),
+	}
+}
+
+// Observe records the token ID until the token expires. It returns
+// ErrTokenReplayed if the token ID was already observed for a token that has
+// not yet expired, or ErrReplayCacheFull if the token ID cannot be recorded.
+func (c *ReplayCache) Observe(jti string, expiresAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if now.After(c.nextPrune) {
+		c.prune(now)
+	}
+
+	if exp, ok := c.seen[jti]; ok && exp.After(now) {
+		return ErrTokenReplayed
+	}
+	if len(c.seen) >= c.maxSize {
+		// prune ahead of schedule before giving up
+		c.prune(now)
+		if len(c.seen) >= c.maxSize {
+			return ErrReplayCacheFull
+		}
+	}
+	c.seen[jti] = expiresAt
+	return nil
+}
+
+func (c *ReplayCache) prune(now time.Time) {
+	for id, exp := range c.seen {
+		if !exp.After(now) {
+			delete(c.seen, id)
+		}
+	}
+	c.nextPrune = now.Add(replayCachePruneInterval)
+}
@@ -0,0 +1,43 @@
+package jwtsvid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCache(t *testing.T) {
+	clk := clock.NewMock(t)
+	cache := NewReplayCache(clk, 0)
+	require.Equal(t, DefaultReplayCacheSize, cache.maxSize)
+
+	expiresAt := clk.Now().Add(time.Minute)
+	require.NoError(t, cache.Observe("jti1", expiresAt))
+	require.Equal(t, ErrTokenReplayed, cache.Observe("jti1", expiresAt))
+	require.NoError(t, cache.Observe("jti2", expiresAt))
+
+	// Once the token has expired the ID is forgotten
+	clk.Add(2 * time.Minute)
+	require.NoError(t, cache.Observe("jti1", clk.Now().Add(time.Minute)))
+	require.Len(t, cache.seen, 1)
+}
+
+func TestReplayCacheFull(t *testing.T) {
+	clk := clock.NewMock(t)
+	cache := NewReplayCache(clk, 2)
+
+	require.NoError(t, cache.Observe("jti1", clk.Now().Add(time.Second)))
+	require.NoError(t, cache.Observe("jti2", clk.Now().Add(time.Minute)))
+
+	// New token IDs are rejected while the cache is full of unexpired ones
+	require.Equal(t, ErrReplayCacheFull, cache.Observe("jti3", clk.Now().Add(time.Minute)))
+	require.Equal(t, ErrTokenReplayed, cache.Observe("jti2", clk.Now().Add(time.Minute)))
+
+	// Expired token IDs are pruned to make room, even before the next
+	// scheduled prune
+	clk.Add(2 * time.Second)
+	require.NoError(t, cache.Observe("jti3", clk.Now().Add(time.Minute)))
+	require.Len(t, cache.seen, 2)
+}
@@ -3,7 +3,9 @@ package jwtsvid
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/rsa"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -20,6 +22,10 @@ type SignerConfig struct {
 
 	// Issuer is used as the value of the issuer (iss) claim, if set.
 	Issuer string
+
+	// IncludeJTI adds a random token ID (jti) claim to each token so that
+	// validators can detect replayed tokens.
+	IncludeJTI bool
 }
 
 type Signer struct {
@@ -36,6 +42,12 @@ func NewSigner(config SignerConfig) *Signer {
 }
 
 func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string) (string, error) {
+	return s.SignTokenWithClaims(spiffeID, audience, expires, signer, kid, nil)
+}
+
+// SignTokenWithClaims signs a token that also carries the given custom claims.
+// Registered claims (e.g. sub, exp) cannot be set.
+func (s *Signer) SignTokenWithClaims(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string, customClaims map[string]interface{}) (string, error) {
 	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
 		return "", err
 	}
@@ -51,6 +63,11 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	if len(kid) == 0 {
 		return "", errors.New("kid is required")
 	}
+	for name := range customClaims {
+		if err := validateClaimName(name); err != nil {
+			return "", err
+		}
+	}
 
 	claims := jwt.Claims{
 		Subject:  spiffeID,
@@ -59,6 +76,13 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		Audience: audience,
 		IssuedAt: jwt.NewNumericDate(s.c.Clock.Now()),
 	}
+	if s.c.IncludeJTI {
+		jti, err := newJTI()
+		if err != nil {
+			return "", err
+		}
+		claims.ID = jti
+	}
 
 	var alg jose.SignatureAlgorithm
 	switch publicKey := signer.Public().(type) {
@@ -96,7 +120,12 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		return "", errs.Wrap(err)
 	}
 
-	signedToken, err := jwt.Signed(jwtSigner).Claims(claims).CompactSerialize()
+	builder := jwt.Signed(jwtSigner).Claims(claims)
+	if len(customClaims) > 0 {
+		builder = builder.Claims(customClaims)
+	}
+
+	signedToken, err := builder.CompactSerialize()
 	if err != nil {
 		return "", errs.Wrap(err)
 	}
@@ -104,6 +133,14 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	return signedToken, nil
 }
 
+func newJTI() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errs.New("unable to generate token ID: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func pruneEmptyValues(values []string) []string {
 	pruned := make([]string, 0, len(values))
 	for _, value := range values {
@@ -212,6 +212,26 @@ func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
 	s.Require().NotEmpty(claims)
 }
 
+func (s *TokenSuite) TestSignAndValidateWithCustomClaims() {
+	token, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]interface{}{
+		"tenant": "acme",
+	})
+	s.Require().NoError(err)
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience)
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+	s.Require().Equal("acme", claims["tenant"])
+	s.Require().Equal(fakeSpiffeID, claims["sub"])
+}
+
+func (s *TokenSuite) TestSignWithReservedCustomClaim() {
+	_, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key", map[string]interface{}{
+		"sub": "spiffe://example.org/other",
+	})
+	s.Require().EqualError(err, `claim "sub" is reserved`)
+}
+
 func (s *TokenSuite) TestSignWithNoExpiration() {
 	_, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Time{}, ec256Key, "ec256Key")
 	s.Require().EqualError(err, "expiration is required")
@@ -333,6 +353,126 @@ func (s *TokenSuite) TestValidateKeyNotFound() {
 	s.Require().Nil(claims)
 }
 
+func (s *TokenSuite) TestValidateKeyPathPrefixes() {
+	keyStore := NewKeyStoreWithPathPrefixes(map[string]map[string]crypto.PublicKey{
+		"spiffe://example.org": {
+			"ec256Key": ec256Key.Public(),
+		},
+	}, map[string]map[string][]string{
+		"spiffe://example.org": {
+			"ec256Key": {"/blog"},
+		},
+	})
+
+	token, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	spiffeID, _, err := ValidateToken(ctx, token, keyStore, fakeAudience[0:1])
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+
+	token, err = s.signer.SignToken("spiffe://example.org/other", fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	spiffeID, claims, err := ValidateToken(ctx, token, keyStore, fakeAudience[0:1])
+	s.Require().EqualError(err, `public key "ec256Key" is not permitted to sign for "spiffe://example.org/other"`)
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+}
+
+func (s *TokenSuite) TestValidateDelegatedKeySPIFFEIDs() {
+	const kid = "agent.0123456789abcdef.KID"
+	keys := map[string]map[string]crypto.PublicKey{
+		"spiffe://example.org": {
+			kid: ec256Key.Public(),
+		},
+	}
+	keyStore := NewKeyStoreWithRevocations(keys, nil, map[string]map[string][]string{
+		"spiffe://example.org": {
+			kid: {"spiffe://example.org/ns/prod"},
+		},
+	}, nil)
+
+	token, err := s.signer.SignToken("spiffe://example.org/ns/prod", fakeAudience, time.Now().Add(time.Hour), ec256Key, kid)
+	s.Require().NoError(err)
+	spiffeID, _, err := ValidateToken(ctx, token, keyStore, fakeAudience)
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/ns/prod", spiffeID)
+
+	// SPIFFE IDs nested under the ones the key was issued for are rejected
+	token, err = s.signer.SignToken("spiffe://example.org/ns/prod/payments", fakeAudience, time.Now().Add(time.Hour), ec256Key, kid)
+	s.Require().NoError(err)
+	spiffeID, claims, err := ValidateToken(ctx, token, keyStore, fakeAudience)
+	s.Require().EqualError(err, `public key "agent.0123456789abcdef.KID" is not permitted to sign for "spiffe://example.org/ns/prod/payments"`)
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+
+	// key stores that do not know the SPIFFE IDs of delegated keys reject them
+	token, err = s.signer.SignToken("spiffe://example.org/ns/prod", fakeAudience, time.Now().Add(time.Hour), ec256Key, kid)
+	s.Require().NoError(err)
+	_, _, err = ValidateToken(ctx, token, NewKeyStore(keys), fakeAudience)
+	s.Require().EqualError(err, `public key "agent.0123456789abcdef.KID" is not permitted to sign for "spiffe://example.org/ns/prod"`)
+}
+
+func (s *TokenSuite) TestSignWithJTI() {
+	signer := NewSigner(SignerConfig{
+		Clock:      clock.NewMock(s.T()),
+		IncludeJTI: true,
+	})
+
+	token1, err := signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	token2, err := signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+
+	_, claims1, err := ValidateToken(ctx, token1, s.bundle, fakeAudience)
+	s.Require().NoError(err)
+	_, claims2, err := ValidateToken(ctx, token2, s.bundle, fakeAudience)
+	s.Require().NoError(err)
+
+	s.Require().NotEmpty(claims1["jti"])
+	s.Require().NotEqual(claims1["jti"], claims2["jti"])
+
+	// Tokens do not carry a jti unless configured
+	token, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	_, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience)
+	s.Require().NoError(err)
+	s.Require().NotContains(claims, "jti")
+}
+
+func (s *TokenSuite) TestValidateRevocations() {
+	keyStore := NewKeyStoreWithRevocations(map[string]map[string]crypto.PublicKey{
+		"spiffe://example.org": {
+			"ec256Key": ec256Key.Public(),
+			"ec384Key": ec384Key.Public(),
+		},
+	}, nil, nil, map[string]Revocations{
+		"spiffe://example.org": {
+			KeyIDs:   []string{"ec384Key"},
+			Subjects: []string{"spiffe://example.org/revoked"},
+		},
+	})
+
+	token, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	spiffeID, _, err := ValidateToken(ctx, token, keyStore, fakeAudience)
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+
+	token, err = s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), ec384Key, "ec384Key")
+	s.Require().NoError(err)
+	spiffeID, claims, err := ValidateToken(ctx, token, keyStore, fakeAudience)
+	s.Require().EqualError(err, `public key "ec384Key" has been revoked`)
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+
+	token, err = s.signer.SignToken("spiffe://example.org/revoked", fakeAudience, time.Now().Add(time.Hour), ec256Key, "ec256Key")
+	s.Require().NoError(err)
+	spiffeID, claims, err = ValidateToken(ctx, token, keyStore, fakeAudience)
+	s.Require().EqualError(err, `JWT-SVIDs for "spiffe://example.org/revoked" have been revoked`)
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+}
+
 func (s *TokenSuite) signToken(alg jose.SignatureAlgorithm, key interface{}, claims jwt.Claims) string {
 	signer, err := jose.NewSigner(
 		jose.SigningKey{
@@ -16,8 +16,41 @@ type KeyStore interface {
 	FindPublicKey(ctx context.Context, trustDomainID, kid string) (crypto.PublicKey, error)
 }
 
+// PathPrefixStore is implemented by key stores that restrict the SPIFFE IDs
+// a key is allowed to sign JWT-SVIDs for.
+type PathPrefixStore interface {
+	// FindPathPrefixes returns the SPIFFE ID path prefixes the key is
+	// allowed to sign for. An empty list means the key is not restricted.
+	FindPathPrefixes(ctx context.Context, trustDomainID, kid string) []string
+}
+
+// SPIFFEIDStore is implemented by key stores that know the exact SPIFFE IDs
+// JWT keys delegated to agents are allowed to sign JWT-SVIDs for.
+type SPIFFEIDStore interface {
+	// FindSPIFFEIDs returns the SPIFFE IDs the delegated key is allowed to
+	// sign for.
+	FindSPIFFEIDs(ctx context.Context, trustDomainID, kid string) []string
+}
+
+// Revocations holds the JWT signing key IDs and subjects of a trust domain
+// whose JWT-SVIDs must no longer be accepted.
+type Revocations struct {
+	KeyIDs   []string
+	Subjects []string
+}
+
+// RevocationStore is implemented by key stores that know about revoked JWT
+// signing keys and subjects.
+type RevocationStore interface {
+	// FindRevocations returns the revocations published for the trust domain.
+	FindRevocations(ctx context.Context, trustDomainID string) Revocations
+}
+
 type keyStore struct {
-	trustDomainKeys map[string]map[string]crypto.PublicKey
+	trustDomainKeys         map[string]map[string]crypto.PublicKey
+	trustDomainPathPrefixes map[string]map[string][]string
+	trustDomainSPIFFEIDs    map[string]map[string][]string
+	trustDomainRevocations  map[string]Revocations
 }
 
 func NewKeyStore(trustDomainKeys map[string]map[string]crypto.PublicKey) KeyStore {
@@ -26,6 +59,27 @@ func NewKeyStore(trustDomainKeys map[string]map[string]crypto.PublicKey) KeyStor
 	}
 }
 
+// NewKeyStoreWithPathPrefixes returns a key store that also restricts keys to
+// the given SPIFFE ID path prefixes, indexed by trust domain and key ID.
+func NewKeyStoreWithPathPrefixes(trustDomainKeys map[string]map[string]crypto.PublicKey, trustDomainPathPrefixes map[string]map[string][]string) KeyStore {
+	return &keyStore{
+		trustDomainKeys:         trustDomainKeys,
+		trustDomainPathPrefixes: trustDomainPathPrefixes,
+	}
+}
+
+// NewKeyStoreWithRevocations returns a key store that restricts keys to the
+// given SPIFFE ID path prefixes, restricts delegated keys to the given SPIFFE
+// IDs and also rejects the given revocations, all indexed by trust domain.
+func NewKeyStoreWithRevocations(trustDomainKeys map[string]map[string]crypto.PublicKey, trustDomainPathPrefixes, trustDomainSPIFFEIDs map[string]map[string][]string, trustDomainRevocations map[string]Revocations) KeyStore {
+	return &keyStore{
+		trustDomainKeys:         trustDomainKeys,
+		trustDomainPathPrefixes: trustDomainPathPrefixes,
+		trustDomainSPIFFEIDs:    trustDomainSPIFFEIDs,
+		trustDomainRevocations:  trustDomainRevocations,
+	}
+}
+
 func (t *keyStore) FindPublicKey(ctx context.Context, trustDomainID, keyID string) (crypto.PublicKey, error) {
 	publicKeys, ok := t.trustDomainKeys[trustDomainID]
 	if !ok {
@@ -38,6 +92,18 @@ func (t *keyStore) FindPublicKey(ctx context.Context, trustDomainID, keyID strin
 	return publicKey, nil
 }
 
+func (t *keyStore) FindPathPrefixes(ctx context.Context, trustDomainID, keyID string) []string {
+	return t.trustDomainPathPrefixes[trustDomainID][keyID]
+}
+
+func (t *keyStore) FindSPIFFEIDs(ctx context.Context, trustDomainID, keyID string) []string {
+	return t.trustDomainSPIFFEIDs[trustDomainID][keyID]
+}
+
+func (t *keyStore) FindRevocations(ctx context.Context, trustDomainID string) Revocations {
+	return t.trustDomainRevocations[trustDomainID]
+}
+
 func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audience []string) (string, map[string]interface{}, error) {
 	tok, err := jwt.ParseSigned(token)
 	if err != nil {
@@ -87,12 +153,44 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 		return "", nil, err
 	}
 
+	// Keys published by downstream servers may be restricted to a subset of
+	// the trust domain.
+	if pathPrefixStore, ok := keyStore.(PathPrefixStore); ok {
+		pathPrefixes := pathPrefixStore.FindPathPrefixes(ctx, trustDomainID.String(), keyID)
+		if !idutil.PathHasAnyPrefix(spiffeID.Path, pathPrefixes) {
+			return "", nil, errs.New("public key %q is not permitted to sign for %q", keyID, spiffeID.String())
+		}
+	}
+
+	// Keys delegated to agents may only sign for the exact SPIFFE IDs they
+	// were issued for. Key stores unaware of those are not trusted with them.
+	if IsDelegatedKeyID(keyID) {
+		var spiffeIDs []string
+		if spiffeIDStore, ok := keyStore.(SPIFFEIDStore); ok {
+			spiffeIDs = spiffeIDStore.FindSPIFFEIDs(ctx, trustDomainID.String(), keyID)
+		}
+		if !containsString(spiffeIDs, spiffeID.String()) {
+			return "", nil, errs.New("public key %q is not permitted to sign for %q", keyID, spiffeID.String())
+		}
+	}
+
 	// Now obtain the generic claims map verified using the obtained key
 	claimsMap := make(map[string]interface{})
 	if err := tok.Claims(key, &claimsMap); err != nil {
 		return "", nil, errs.Wrap(err)
 	}
 
+	// Reject tokens signed by revoked keys or issued to revoked subjects
+	if revocationStore, ok := keyStore.(RevocationStore); ok {
+		revocations := revocationStore.FindRevocations(ctx, trustDomainID.String())
+		if containsString(revocations.KeyIDs, keyID) {
+			return "", nil, errs.New("public key %q has been revoked", keyID)
+		}
+		if containsString(revocations.Subjects, spiffeID.String()) {
+			return "", nil, errs.New("JWT-SVIDs for %q have been revoked", spiffeID.String())
+		}
+	}
+
 	// Now that the signature over the claims has been verified, validate the
 	// standard claims.
 	if err := claims.Validate(jwt.Expected{
@@ -113,3 +211,12 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 
 	return spiffeID.String(), claimsMap, nil
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
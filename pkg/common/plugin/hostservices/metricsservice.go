@@ -5,6 +5,7 @@ package hostservices
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/proto/spire/common/hostservices"
@@ -77,6 +78,18 @@ func (c *metricsServiceHostServiceClient) InitHostServiceClient(conn *grpc.Clien
 	*c.client = AdaptMetricsServiceHostServiceClient(hostservices.NewMetricsServiceClient(conn))
 }
 
+// RequireMetricsServiceHostService obtains a client for the MetricsService host service from the broker. It fails if the host does not provide the host service.
+func RequireMetricsServiceHostService(broker catalog.HostServiceBroker, client *MetricsService) error {
+	has, err := broker.GetHostService(MetricsServiceHostServiceClient(client))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("required %s host service not available", MetricsServiceType)
+	}
+	return nil
+}
+
 func AdaptMetricsServiceHostServiceClient(client MetricsServiceClient) MetricsService {
 	return metricsServiceHostServiceClientAdapter{client: client}
 }
@@ -0,0 +1,78 @@
+// Package sdk provides helpers to write external SPIRE plugins.
+//
+// The main function of a plugin binary serves the plugin with the helper for
+// its plugin type, for example:
+//
+//	func main() {
+//		sdk.ServeServerNodeAttestor("my-plugin", New())
+//	}
+//
+// Plugins obtain clients for host services in BrokerHostServices, using the
+// Require*HostService functions generated for each host service, e.g.
+// hostservices.RequireAgentStoreHostService. Plugins are tested in-process
+// against fake host services with the sdktest package.
+package sdk
+
+import (
+	agent_keymanager "github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	agent_nodeattestor "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	server_keymanager "github.com/spiffe/spire/pkg/server/plugin/keymanager"
+	server_nodeattestor "github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/pkg/server/plugin/notifier"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+)
+
+// Serve serves the plugin, along with the given services, to the SPIRE
+// process that launched the plugin binary. It does not return.
+func Serve(name string, plugin catalog.PluginServer, services ...catalog.ServiceServer) {
+	catalog.PluginMain(catalog.MakePlugin(name, plugin, services...))
+}
+
+// ServeServerDataStore serves a server DataStore plugin
+func ServeServerDataStore(name string, server datastore.DataStoreServer, services ...catalog.ServiceServer) {
+	Serve(name, datastore.PluginServer(server), services...)
+}
+
+// ServeServerKeyManager serves a server KeyManager plugin
+func ServeServerKeyManager(name string, server server_keymanager.KeyManagerServer, services ...catalog.ServiceServer) {
+	Serve(name, server_keymanager.PluginServer(server), services...)
+}
+
+// ServeServerNodeAttestor serves a server NodeAttestor plugin
+func ServeServerNodeAttestor(name string, server server_nodeattestor.NodeAttestorServer, services ...catalog.ServiceServer) {
+	Serve(name, server_nodeattestor.PluginServer(server), services...)
+}
+
+// ServeServerNodeResolver serves a server NodeResolver plugin
+func ServeServerNodeResolver(name string, server noderesolver.NodeResolverServer, services ...catalog.ServiceServer) {
+	Serve(name, noderesolver.PluginServer(server), services...)
+}
+
+// ServeServerNotifier serves a server Notifier plugin
+func ServeServerNotifier(name string, server notifier.NotifierServer, services ...catalog.ServiceServer) {
+	Serve(name, notifier.PluginServer(server), services...)
+}
+
+// ServeServerUpstreamAuthority serves a server UpstreamAuthority plugin
+func ServeServerUpstreamAuthority(name string, server upstreamauthority.UpstreamAuthorityServer, services ...catalog.ServiceServer) {
+	Serve(name, upstreamauthority.PluginServer(server), services...)
+}
+
+// ServeAgentKeyManager serves an agent KeyManager plugin
+func ServeAgentKeyManager(name string, server agent_keymanager.KeyManagerServer, services ...catalog.ServiceServer) {
+	Serve(name, agent_keymanager.PluginServer(server), services...)
+}
+
+// ServeAgentNodeAttestor serves an agent NodeAttestor plugin
+func ServeAgentNodeAttestor(name string, server agent_nodeattestor.NodeAttestorServer, services ...catalog.ServiceServer) {
+	Serve(name, agent_nodeattestor.PluginServer(server), services...)
+}
+
+// ServeAgentWorkloadAttestor serves an agent WorkloadAttestor plugin
+func ServeAgentWorkloadAttestor(name string, server workloadattestor.WorkloadAttestorServer, services ...catalog.ServiceServer) {
+	Serve(name, workloadattestor.PluginServer(server), services...)
+}
@@ -0,0 +1,52 @@
+// Package sdktest loads plugins in-process for testing, serving them fakes of
+// the host services provided by SPIRE.
+package sdktest
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	common_hostservices "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/test/fakes/fakeagentstore"
+	"github.com/spiffe/spire/test/fakes/fakeidentityprovider"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+)
+
+// HostServices are fakes of the host services provided by SPIRE
+type HostServices struct {
+	AgentStore       *fakeagentstore.AgentStore
+	IdentityProvider *fakeidentityprovider.IdentityProvider
+	Metrics          *fakemetrics.FakeMetrics
+}
+
+// NewHostServices returns new fake host services
+func NewHostServices() *HostServices {
+	return &HostServices{
+		AgentStore:       fakeagentstore.New(),
+		IdentityProvider: fakeidentityprovider.New(),
+		Metrics:          fakemetrics.New(),
+	}
+}
+
+// PluginOptions returns the options serving the fake host services to a
+// plugin loaded with spiretest.LoadPlugin.
+func (h *HostServices) PluginOptions() []spiretest.PluginOption {
+	return []spiretest.PluginOption{
+		spiretest.HostService(hostservices.AgentStoreHostServiceServer(h.AgentStore)),
+		spiretest.HostService(hostservices.IdentityProviderHostServiceServer(h.IdentityProvider)),
+		spiretest.HostService(common_hostservices.MetricsServiceHostServiceServer(metricsservice.New(metricsservice.Config{
+			Metrics: h.Metrics,
+		}))),
+	}
+}
+
+// LoadPlugin loads the plugin in-process, serving it the given fake host
+// services, and fills x with clients for the plugin and its services, as
+// spiretest.LoadPlugin does. Clients support streaming RPCs. The returned
+// function unloads the plugin.
+func LoadPlugin(tb testing.TB, plugin catalog.Plugin, hostServices *HostServices, x interface{}, opts ...spiretest.PluginOption) (done func()) {
+	return spiretest.LoadPlugin(tb, plugin, x, append(hostServices.PluginOptions(), opts...)...)
+}
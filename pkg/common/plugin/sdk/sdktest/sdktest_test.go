@@ -0,0 +1,127 @@
+package sdktest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/plugin/sdk/sdktest"
+	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	nodeattestorbase "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/base"
+	"github.com/spiffe/spire/proto/spire/common"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestLoadPlugin(t *testing.T) {
+	hostServices := sdktest.NewHostServices()
+	hostServices.AgentStore.SetAgentInfo(&hostservices.AgentInfo{
+		AgentId: "spiffe://example.org/agent/attested",
+	})
+
+	var attestor nodeattestor.NodeAttestor
+	done := sdktest.LoadPlugin(t, catalog.MakePlugin("test",
+		nodeattestor.PluginServer(new(testAttestor)),
+	), hostServices, &attestor, spiretest.Configuration("example.org", "CONFIG"))
+	defer done()
+
+	resp, err := attest(t, attestor, "new", []byte("CONFIG"))
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/agent/new", resp.AgentId)
+
+	_, err = attest(t, attestor, "new", []byte("BAD"))
+	spiretest.RequireGRPCStatus(t, err, codes.Unknown, "bad challenge response")
+
+	_, err = attest(t, attestor, "attested", []byte("CONFIG"))
+	spiretest.RequireGRPCStatus(t, err, codes.Unknown, "agent already attested")
+}
+
+func attest(t *testing.T, attestor nodeattestor.NodeAttestor, name string, response []byte) (*nodeattestor.AttestResponse, error) {
+	stream, err := attestor.Attest(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	require.NoError(t, stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "test",
+			Data: []byte(name),
+		},
+	}))
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	require.Equal(t, []byte("challenge"), resp.Challenge)
+
+	require.NoError(t, stream.Send(&nodeattestor.AttestRequest{
+		Response: response,
+	}))
+	return stream.Recv()
+}
+
+// testAttestor challenges agents to echo the plugin configuration back and
+// refuses to attest agents twice.
+type testAttestor struct {
+	nodeattestorbase.Base
+
+	mu     sync.Mutex
+	config *spi.ConfigureRequest
+}
+
+func (p *testAttestor) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
+	p.mu.Lock()
+	config := p.config
+	p.mu.Unlock()
+	if config == nil {
+		return errors.New("not configured")
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	agentID := "spiffe://" + config.GlobalConfig.TrustDomain + "/agent/" + string(req.AttestationData.Data)
+
+	attested, err := p.IsAttested(stream.Context(), agentID)
+	if err != nil {
+		return err
+	}
+	if attested {
+		return errors.New("agent already attested")
+	}
+
+	if err := stream.Send(&nodeattestor.AttestResponse{
+		Challenge: []byte("challenge"),
+	}); err != nil {
+		return err
+	}
+	req, err = stream.Recv()
+	if err != nil {
+		return err
+	}
+	if string(req.Response) != config.Configuration {
+		return errors.New("bad challenge response")
+	}
+
+	return stream.Send(&nodeattestor.AttestResponse{
+		AgentId: agentID,
+	})
+}
+
+func (p *testAttestor) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	p.mu.Lock()
+	p.config = req
+	p.mu.Unlock()
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *testAttestor) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
@@ -135,6 +135,15 @@ func (s *ServerHandshake) VerifyAttestationData(data []byte) error {
 	if err := s.s.certChecker.CheckHostKey(addr, &net.IPAddr{}, cert); err != nil {
 		return Errorf("failed to check host key: %v", err)
 	}
+	if s.s.krl != nil {
+		krl, err := s.s.krl.get()
+		if err != nil {
+			return Errorf("failed to load KRL: %v", err)
+		}
+		if err := krl.Check(cert); err != nil {
+			return Errorf("failed to check revocation: %v", err)
+		}
+	}
 	s.hostname, err = decanonicalizeHostname(cert.ValidPrincipals[0], s.s.canonicalDomain)
 	if err != nil {
 		return Errorf("failed to decanonicalize hostname: %v", err)
@@ -194,6 +203,11 @@ func (s *ServerHandshake) VerifyChallengeResponse(res []byte) error {
 	return nil
 }
 
+// Certificate returns the host certificate provided in the attestation data.
+func (s *ServerHandshake) Certificate() *ssh.Certificate {
+	return s.cert
+}
+
 func (s *ServerHandshake) AgentID() (string, error) {
 	return makeAgentID(s.s.trustDomain, s.s.agentPathTemplate, s.cert, s.hostname)
 }
@@ -228,6 +242,7 @@ func makeAgentID(trustDomain string, agentPathTemplate *template.Template, cert
 		PluginName:  PluginName,
 		Fingerprint: urlSafeSSHFingerprintSHA256(cert),
 		Hostname:    hostname,
+		Principals:  cert.ValidPrincipals,
 	}); err != nil {
 		return "", err
 	}
@@ -112,6 +112,24 @@ func TestServerSpiffeID(t *testing.T) {
 	require.Equal(t, "spiffe://foo.local/spire/agent/static/ec2abcdef-uswest1", spiffeid)
 }
 
+func TestServerSpiffeIDFromPrincipals(t *testing.T) {
+	tt := newTest(t, principal("ec2abcdef-uswest1"), principal("web"))
+	agentPathTemplate, err := template.New("agent-path").Parse(`{{ .PluginName }}/{{ index .Principals 1 }}/{{ .Hostname }}`)
+	require.NoError(t, err)
+
+	s := &ServerHandshake{
+		s: &Server{
+			trustDomain:       "foo.local",
+			agentPathTemplate: agentPathTemplate,
+		},
+		cert:     tt.Certificate,
+		hostname: "ec2abcdef-uswest1",
+	}
+	spiffeid, err := s.AgentID()
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://foo.local/spire/agent/sshpop/web/ec2abcdef-uswest1", spiffeid)
+}
+
 func newTestHandshake(t *testing.T) (*ClientHandshake, *ServerHandshake) {
 	tt := newTest(t, principal("ec2abcdef-uswest1.test.internal"))
 	trustDomain := "foo.local"
@@ -223,6 +241,49 @@ func TestVerifyAttestationData(t *testing.T) {
 	}
 }
 
+func TestVerifyAttestationDataRevoked(t *testing.T) {
+	cert := requireCertFromFile(t, "./testdata/dummy_agent_ssh_key-cert.pub")
+	certChecker, err := certCheckerFromPubkeys([]string{testCertAuthority})
+	require.NoError(t, err)
+
+	tests := []struct {
+		desc      string
+		krlPath   string
+		expectErr string
+	}{
+		{
+			desc:    "cert is not revoked",
+			krlPath: "./testdata/revoked_serials.krl",
+		},
+		{
+			desc:      "cert is revoked",
+			krlPath:   "./testdata/revoked_key_id.krl",
+			expectErr: `sshpop: failed to check revocation: certificate key ID "foo-host" is revoked`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			krl, err := loadKRLFile(tt.krlPath)
+			require.NoError(t, err)
+			s := &Server{
+				trustDomain:       "foo.local",
+				agentPathTemplate: DefaultAgentPathTemplate,
+				certChecker:       certChecker,
+				krl:               krl,
+			}
+
+			err = s.NewHandshake().VerifyAttestationData(marshalAttestationData(t, cert.Marshal()))
+			if tt.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectErr)
+		})
+	}
+}
+
 func marshalAttestationData(t *testing.T, cert []byte) []byte {
 	b, err := json.Marshal(attestationData{
 		Certificate: cert,
@@ -0,0 +1,334 @@
+package sshpop
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint: gosec // KRLs identify keys by SHA1 fingerprint
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// KRL format constants. See PROTOCOL.krl in the OpenSSH sources.
+const (
+	krlMagic         = "SSHKRL\n\x00"
+	krlFormatVersion = 1
+
+	krlSectionCertificates      = 1
+	krlSectionExplicitKey       = 2
+	krlSectionFingerprintSHA1   = 3
+	krlSectionSignature         = 4
+	krlSectionFingerprintSHA256 = 5
+
+	krlCertSectionSerialList   = 0x20
+	krlCertSectionSerialRange  = 0x21
+	krlCertSectionSerialBitmap = 0x22
+	krlCertSectionKeyID        = 0x23
+)
+
+// krl is a parsed OpenSSH key revocation list.
+type krl struct {
+	certs         []*krlCertSection
+	revokedKeys   map[string]bool
+	revokedSHA1   map[string]bool
+	revokedSHA256 map[string]bool
+}
+
+// krlCertSection revokes certificates issued by a single CA, or by any CA
+// when caKey is empty.
+type krlCertSection struct {
+	caKey   []byte
+	ranges  []krlSerialRange
+	bitmaps []krlSerialBitmap
+	keyIDs  map[string]bool
+}
+
+type krlSerialRange struct {
+	min, max uint64
+}
+
+type krlSerialBitmap struct {
+	offset uint64
+	bitmap *big.Int
+}
+
+// parseKRL parses a binary OpenSSH KRL. Signature sections are skipped
+// without being verified, matching the behavior of ssh-keygen and sshd.
+func parseKRL(data []byte) (*krl, error) {
+	if !bytes.HasPrefix(data, []byte(krlMagic)) {
+		return nil, errors.New("not a KRL")
+	}
+	r := &krlReader{data: data[len(krlMagic):]}
+	if version := r.uint32(); r.err == nil && version != krlFormatVersion {
+		return nil, fmt.Errorf("unsupported KRL format version %d", version)
+	}
+	r.uint64() // krl_version
+	r.uint64() // generated_date
+	r.uint64() // flags
+	r.string() // reserved
+	r.string() // comment
+	if r.err != nil {
+		return nil, fmt.Errorf("malformed KRL header: %v", r.err)
+	}
+
+	k := &krl{
+		revokedKeys:   make(map[string]bool),
+		revokedSHA1:   make(map[string]bool),
+		revokedSHA256: make(map[string]bool),
+	}
+	for !r.empty() {
+		sectionType := r.byte()
+		section := &krlReader{data: r.string()}
+		if r.err != nil {
+			return nil, fmt.Errorf("malformed KRL section: %v", r.err)
+		}
+		switch sectionType {
+		case krlSectionCertificates:
+			certs, err := parseKRLCertSection(section)
+			if err != nil {
+				return nil, err
+			}
+			k.certs = append(k.certs, certs)
+		case krlSectionExplicitKey:
+			for !section.empty() {
+				blob := section.string()
+				if section.err == nil {
+					if _, err := ssh.ParsePublicKey(blob); err != nil {
+						return nil, fmt.Errorf("malformed KRL explicit key: %v", err)
+					}
+				}
+				k.revokedKeys[string(blob)] = true
+			}
+		case krlSectionFingerprintSHA1:
+			for !section.empty() {
+				k.revokedSHA1[string(section.string())] = true
+			}
+		case krlSectionFingerprintSHA256:
+			for !section.empty() {
+				k.revokedSHA256[string(section.string())] = true
+			}
+		case krlSectionSignature:
+		default:
+			return nil, fmt.Errorf("unsupported KRL section type %d", sectionType)
+		}
+		if section.err != nil {
+			return nil, fmt.Errorf("malformed KRL section %d: %v", sectionType, section.err)
+		}
+	}
+	return k, nil
+}
+
+func parseKRLCertSection(r *krlReader) (*krlCertSection, error) {
+	s := &krlCertSection{
+		caKey:  r.string(),
+		keyIDs: make(map[string]bool),
+	}
+	r.string() // reserved
+	if r.err != nil {
+		return nil, fmt.Errorf("malformed KRL certificate section: %v", r.err)
+	}
+	if len(s.caKey) > 0 {
+		if _, err := ssh.ParsePublicKey(s.caKey); err != nil {
+			return nil, fmt.Errorf("malformed KRL certificate section CA key: %v", err)
+		}
+	}
+
+	for !r.empty() {
+		subsectionType := r.byte()
+		subsection := &krlReader{data: r.string()}
+		if r.err != nil {
+			return nil, fmt.Errorf("malformed KRL certificate section: %v", r.err)
+		}
+		switch subsectionType {
+		case krlCertSectionSerialList:
+			for !subsection.empty() {
+				serial := subsection.uint64()
+				s.ranges = append(s.ranges, krlSerialRange{min: serial, max: serial})
+			}
+		case krlCertSectionSerialRange:
+			serialRange := krlSerialRange{
+				min: subsection.uint64(),
+				max: subsection.uint64(),
+			}
+			if subsection.err == nil && serialRange.min > serialRange.max {
+				return nil, fmt.Errorf("malformed KRL serial range %d-%d", serialRange.min, serialRange.max)
+			}
+			s.ranges = append(s.ranges, serialRange)
+		case krlCertSectionSerialBitmap:
+			s.bitmaps = append(s.bitmaps, krlSerialBitmap{
+				offset: subsection.uint64(),
+				bitmap: new(big.Int).SetBytes(subsection.string()),
+			})
+		case krlCertSectionKeyID:
+			for !subsection.empty() {
+				s.keyIDs[string(subsection.string())] = true
+			}
+		default:
+			return nil, fmt.Errorf("unsupported KRL certificate section type %d", subsectionType)
+		}
+		if subsection.err == nil && !subsection.empty() {
+			subsection.err = errors.New("trailing data")
+		}
+		if subsection.err != nil {
+			return nil, fmt.Errorf("malformed KRL certificate section %d: %v", subsectionType, subsection.err)
+		}
+	}
+	return s, nil
+}
+
+// Check returns an error if the certificate, the key it certifies or the CA
+// key that signed it has been revoked.
+func (k *krl) Check(cert *ssh.Certificate) error {
+	if k.isKeyRevoked(cert.Key) {
+		return errors.New("host key is revoked")
+	}
+	if k.isKeyRevoked(cert.SignatureKey) {
+		return errors.New("certificate authority key is revoked")
+	}
+
+	caKey := cert.SignatureKey.Marshal()
+	for _, s := range k.certs {
+		if len(s.caKey) > 0 && !bytes.Equal(s.caKey, caKey) {
+			continue
+		}
+		if s.keyIDs[cert.KeyId] {
+			return fmt.Errorf("certificate key ID %q is revoked", cert.KeyId)
+		}
+		// a zero serial is what CAs assign when none is specified, so like
+		// OpenSSH, certificates without a serial cannot be revoked by serial
+		if cert.Serial != 0 && s.isSerialRevoked(cert.Serial) {
+			return fmt.Errorf("certificate serial %d is revoked", cert.Serial)
+		}
+	}
+	return nil
+}
+
+func (k *krl) isKeyRevoked(key ssh.PublicKey) bool {
+	blob := key.Marshal()
+	sha1sum := sha1.Sum(blob) //nolint: gosec // KRLs identify keys by SHA1 fingerprint
+	sha256sum := sha256.Sum256(blob)
+	return k.revokedKeys[string(blob)] ||
+		k.revokedSHA1[string(sha1sum[:])] ||
+		k.revokedSHA256[string(sha256sum[:])]
+}
+
+func (s *krlCertSection) isSerialRevoked(serial uint64) bool {
+	for _, r := range s.ranges {
+		if serial >= r.min && serial <= r.max {
+			return true
+		}
+	}
+	for _, b := range s.bitmaps {
+		if serial >= b.offset && serial-b.offset < uint64(b.bitmap.BitLen()) && b.bitmap.Bit(int(serial-b.offset)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// krlFile is a KRL loaded from disk that is reloaded when the file changes.
+type krlFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	krl     *krl
+}
+
+func loadKRLFile(path string) (*krlFile, error) {
+	f := &krlFile{path: path}
+	if _, err := f.get(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// get returns the KRL, reloading it first if the file has been modified
+// since it was last loaded.
+func (f *krlFile) get() (*krl, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if f.krl != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.krl, nil
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	k, err := parseKRL(data)
+	if err != nil {
+		return nil, err
+	}
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.krl = k
+	return k, nil
+}
+
+// krlReader reads values in the SSH wire format. The first error is sticky
+// and subsequent reads return zero values.
+type krlReader struct {
+	data []byte
+	err  error
+}
+
+func (r *krlReader) empty() bool {
+	return r.err != nil || len(r.data) == 0
+}
+
+func (r *krlReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errors.New("unexpected end of data")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *krlReader) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *krlReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *krlReader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *krlReader) string() []byte {
+	n := r.uint32()
+	if r.err == nil && uint64(n) > uint64(len(r.data)) {
+		r.err = errors.New("string length exceeds data")
+		return nil
+	}
+	return r.next(int(n))
+}
@@ -0,0 +1,305 @@
+package sshpop
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// The testdata KRLs were generated with ssh-keygen -k. The host key of
+// testdata/dummy_agent_ssh_key-cert.pub is also the CA key that signed it.
+//
+//   revoked_key_id.krl:   id: foo-host, signed by the dummy CA
+//   revoked_key.krl:      the dummy key
+//   revoked_key_hash.krl: hash: SHA256 fingerprint of the dummy key
+//   revoked_serials.krl:  serials 5, 10-20, 100, 102, 104 and 1000-2000,
+//                         signed by the dummy CA
+
+func TestKRLCheck(t *testing.T) {
+	hostCert := requireCertFromFile(t, "./testdata/dummy_agent_ssh_key-cert.pub")
+	dummyCA := requireSignerFromFile(t, "./testdata/dummy_agent_ssh_key")
+	otherCA := newTest(t).Signer
+
+	tests := []struct {
+		desc      string
+		krlPath   string
+		cert      *ssh.Certificate
+		expectErr string
+	}{
+		{
+			desc:      "revoked key ID",
+			krlPath:   "./testdata/revoked_key_id.krl",
+			cert:      hostCert,
+			expectErr: `certificate key ID "foo-host" is revoked`,
+		},
+		{
+			desc:    "key ID revoked for another CA",
+			krlPath: "./testdata/revoked_key_id.krl",
+			cert:    requireSignCert(t, otherCA, "foo-host", 0),
+		},
+		{
+			desc:      "revoked key",
+			krlPath:   "./testdata/revoked_key.krl",
+			cert:      hostCert,
+			expectErr: "host key is revoked",
+		},
+		{
+			desc:      "revoked CA key",
+			krlPath:   "./testdata/revoked_key.krl",
+			cert:      requireSignCert(t, dummyCA, "bar-host", 7),
+			expectErr: "certificate authority key is revoked",
+		},
+		{
+			desc:      "revoked key hash",
+			krlPath:   "./testdata/revoked_key_hash.krl",
+			cert:      hostCert,
+			expectErr: "host key is revoked",
+		},
+		{
+			desc:    "serial zero is never revoked",
+			krlPath: "./testdata/revoked_serials.krl",
+			cert:    hostCert,
+		},
+		{
+			desc:      "revoked serial in bitmap",
+			krlPath:   "./testdata/revoked_serials.krl",
+			cert:      requireSignCert(t, dummyCA, "bar-host", 102),
+			expectErr: "certificate serial 102 is revoked",
+		},
+		{
+			desc:    "serial not in bitmap",
+			krlPath: "./testdata/revoked_serials.krl",
+			cert:    requireSignCert(t, dummyCA, "bar-host", 103),
+		},
+		{
+			desc:      "revoked serial in range",
+			krlPath:   "./testdata/revoked_serials.krl",
+			cert:      requireSignCert(t, dummyCA, "bar-host", 1500),
+			expectErr: "certificate serial 1500 is revoked",
+		},
+		{
+			desc:    "serial past range",
+			krlPath: "./testdata/revoked_serials.krl",
+			cert:    requireSignCert(t, dummyCA, "bar-host", 2001),
+		},
+		{
+			desc:    "serial revoked for another CA",
+			krlPath: "./testdata/revoked_serials.krl",
+			cert:    requireSignCert(t, otherCA, "bar-host", 5),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			data, err := ioutil.ReadFile(tt.krlPath)
+			require.NoError(t, err)
+			krl, err := parseKRL(data)
+			require.NoError(t, err)
+
+			err = krl.Check(tt.cert)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseKRL(t *testing.T) {
+	tests := []struct {
+		desc      string
+		data      []byte
+		expectErr string
+	}{
+		{
+			desc:      "not a KRL",
+			data:      []byte("ssh-ed25519 AAAA"),
+			expectErr: "not a KRL",
+		},
+		{
+			desc:      "unsupported format version",
+			data:      append([]byte(krlMagic), 0, 0, 0, 2),
+			expectErr: "unsupported KRL format version 2",
+		},
+		{
+			desc:      "truncated header",
+			data:      append([]byte(krlMagic), 0, 0, 0, 1),
+			expectErr: "malformed KRL header: unexpected end of data",
+		},
+		{
+			desc:      "truncated section",
+			data:      append(krlHeader(), krlSectionExplicitKey, 0, 0, 1, 0),
+			expectErr: "malformed KRL section: string length exceeds data",
+		},
+		{
+			desc:      "unsupported section",
+			data:      append(krlHeader(), krlSection(9, nil)...),
+			expectErr: "unsupported KRL section type 9",
+		},
+		{
+			desc:      "malformed explicit key",
+			data:      append(krlHeader(), krlSection(krlSectionExplicitKey, krlString([]byte("bad")))...),
+			expectErr: "malformed KRL explicit key",
+		},
+		{
+			desc: "unsupported certificate section",
+			data: append(krlHeader(), krlSection(krlSectionCertificates,
+				krlAnyCASection(0x30, nil))...),
+			expectErr: "unsupported KRL certificate section type 48",
+		},
+		{
+			desc: "inverted serial range",
+			data: append(krlHeader(), krlSection(krlSectionCertificates,
+				krlAnyCASection(krlCertSectionSerialRange, append(krlUint64(20), krlUint64(10)...)))...),
+			expectErr: "malformed KRL serial range 20-10",
+		},
+		{
+			desc: "truncated serial range",
+			data: append(krlHeader(), krlSection(krlSectionCertificates,
+				krlAnyCASection(krlCertSectionSerialRange, append(krlUint64(10), krlUint64(20)[:4]...)))...),
+			expectErr: "malformed KRL certificate section 33: unexpected end of data",
+		},
+		{
+			desc: "trailing data in serial range",
+			data: append(krlHeader(), krlSection(krlSectionCertificates,
+				krlAnyCASection(krlCertSectionSerialRange, append(append(krlUint64(10), krlUint64(20)...), 0)))...),
+			expectErr: "malformed KRL certificate section 33: trailing data",
+		},
+		{
+			desc: "signature section is ignored",
+			data: append(krlHeader(), krlSection(krlSectionSignature, krlString([]byte("sig")))...),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := parseKRL(tt.data)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestKRLFileReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sshpop-krl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	hostCert := requireCertFromFile(t, "./testdata/dummy_agent_ssh_key-cert.pub")
+	krlPath := filepath.Join(dir, "revoked.krl")
+
+	copyKRL := func(src string, modTime time.Time) {
+		data, err := ioutil.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(krlPath, data, 0600))
+		require.NoError(t, os.Chtimes(krlPath, modTime, modTime))
+	}
+
+	// nothing revokes the certificate initially
+	now := time.Now()
+	copyKRL("./testdata/revoked_serials.krl", now)
+	f, err := loadKRLFile(krlPath)
+	require.NoError(t, err)
+	krl, err := f.get()
+	require.NoError(t, err)
+	require.NoError(t, krl.Check(hostCert))
+
+	// the KRL is reloaded once the file changes
+	copyKRL("./testdata/revoked_key_id.krl", now.Add(time.Second))
+	krl, err = f.get()
+	require.NoError(t, err)
+	require.EqualError(t, krl.Check(hostCert), `certificate key ID "foo-host" is revoked`)
+
+	// the KRL fails to load once the file is removed
+	require.NoError(t, os.Remove(krlPath))
+	_, err = f.get()
+	require.Error(t, err)
+
+	// a corrupt KRL fails to load
+	require.NoError(t, ioutil.WriteFile(krlPath, []byte("corrupt"), 0600))
+	_, err = f.get()
+	require.EqualError(t, err, "not a KRL")
+}
+
+func requireCertFromFile(t *testing.T, path string) *ssh.Certificate {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	pubkey, _, _, _, err := ssh.ParseAuthorizedKey(data)
+	require.NoError(t, err)
+	cert, ok := pubkey.(*ssh.Certificate)
+	require.True(t, ok)
+	return cert
+}
+
+func requireSignerFromFile(t *testing.T, path string) ssh.Signer {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	signer, err := ssh.ParsePrivateKey(data)
+	require.NoError(t, err)
+	return signer
+}
+
+func requireSignCert(t *testing.T, ca ssh.Signer, keyID string, serial uint64) *ssh.Certificate {
+	cert := &ssh.Certificate{
+		Key:             newTest(t).Signer.PublicKey(),
+		CertType:        ssh.HostCert,
+		KeyId:           keyID,
+		Serial:          serial,
+		ValidPrincipals: []string{keyID},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(randReader, ca))
+	return cert
+}
+
+func krlHeader() []byte {
+	var b bytes.Buffer
+	b.WriteString(krlMagic)
+	b.Write([]byte{0, 0, 0, krlFormatVersion})
+	b.Write(krlUint64(1))   // krl_version
+	b.Write(krlUint64(0))   // generated_date
+	b.Write(krlUint64(0))   // flags
+	b.Write(krlString(nil)) // reserved
+	b.Write(krlString(nil)) // comment
+	return b.Bytes()
+}
+
+func krlSection(sectionType byte, data []byte) []byte {
+	return append([]byte{sectionType}, krlString(data)...)
+}
+
+// krlAnyCASection returns the data of a certificate section that applies to
+// any CA and holds a single subsection.
+func krlAnyCASection(subsectionType byte, data []byte) []byte {
+	var b bytes.Buffer
+	b.Write(krlString(nil)) // ca_key
+	b.Write(krlString(nil)) // reserved
+	b.Write(krlSection(subsectionType, data))
+	return b.Bytes()
+}
+
+func krlUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func krlString(s []byte) []byte {
+	b := make([]byte, 4, 4+len(s))
+	binary.BigEndian.PutUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
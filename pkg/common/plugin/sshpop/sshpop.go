@@ -36,6 +36,7 @@ type agentPathTemplateData struct {
 	PluginName  string
 	Fingerprint string
 	Hostname    string
+	Principals  []string
 }
 
 // Client is a factory for generating client handshake objects.
@@ -52,6 +53,7 @@ type Server struct {
 	agentPathTemplate *template.Template
 	trustDomain       string
 	canonicalDomain   string
+	krl               *krlFile
 }
 
 // ClientConfig configures the client.
@@ -71,6 +73,10 @@ type ServerConfig struct {
 	// the certificate's valid principals. See CanonicalDomains in ssh_config(5).
 	CanonicalDomain   string `hcl:"canonical_domain"`
 	AgentPathTemplate string `hcl:"agent_path_template"`
+	// KRLPath is the path to an OpenSSH key revocation list. The file is
+	// reloaded when it changes. See the KEY REVOCATION LISTS section of
+	// ssh-keygen(1).
+	KRLPath string `hcl:"krl_path"`
 }
 
 func NewClient(trustDomain, configString string) (*Client, error) {
@@ -160,11 +166,19 @@ func NewServer(trustDomain, configString string) (*Server, error) {
 		}
 		agentPathTemplate = tmpl
 	}
+	var krl *krlFile
+	if config.KRLPath != "" {
+		krl, err = loadKRLFile(config.KRLPath)
+		if err != nil {
+			return nil, Errorf("failed to load KRL: %v", err)
+		}
+	}
 	return &Server{
 		certChecker:       certChecker,
 		agentPathTemplate: agentPathTemplate,
 		trustDomain:       trustDomain,
 		canonicalDomain:   config.CanonicalDomain,
+		krl:               krl,
 	}, nil
 }
 
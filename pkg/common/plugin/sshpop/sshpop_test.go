@@ -114,6 +114,30 @@ func TestNewServer(t *testing.T) {
 			trustDomain:  "foo.test",
 			expectErr:    `sshpop: failed to create cert checker: failed to parse public key`,
 		},
+		{
+			desc: "bad krl path",
+			configString: fmt.Sprintf(`cert_authorities = [%q]
+									   krl_path = "blahblahblah"`, testCertAuthority),
+			trustDomain: "foo.test",
+			expectErr:   "sshpop: failed to load KRL: stat blahblahblah: no such file or directory",
+		},
+		{
+			desc: "bad krl",
+			configString: fmt.Sprintf(`cert_authorities = [%q]
+									   krl_path = "./testdata/dummy_ssh_cert_authority.pub"`, testCertAuthority),
+			trustDomain: "foo.test",
+			expectErr:   "sshpop: failed to load KRL: not a KRL",
+		},
+		{
+			desc: "success with krl",
+			configString: fmt.Sprintf(`cert_authorities = [%q]
+									   krl_path = "./testdata/revoked_key_id.krl"`, testCertAuthority),
+			trustDomain: "foo.test",
+			requireServer: func(t *testing.T, s *Server) {
+				require.NotNil(t, s.krl)
+				require.Equal(t, "./testdata/revoked_key_id.krl", s.krl.path)
+			},
+		},
 		{
 			desc: "success",
 			configString: fmt.Sprintf(`cert_authorities = [%q]
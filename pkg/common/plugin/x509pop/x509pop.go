@@ -33,6 +33,36 @@ type agentPathTemplateData struct {
 	Fingerprint string
 	PluginName  string
 	TrustDomain string
+
+	// SAN holds the subject alternative names of the certificate
+	SAN SANs
+
+	// SubjectAttributes holds the first value of each attribute of the
+	// certificate subject, keyed by the attribute short name (e.g. "OU") or,
+	// for attributes without one, by the attribute dotted OID.
+	SubjectAttributes map[string]string
+}
+
+// SANs are the subject alternative names of a certificate
+type SANs struct {
+	DNS   []string
+	URI   []string
+	Email []string
+	IP    []string
+}
+
+// subjectAttributeNames are the short names of the subject attributes, as
+// used by pkix.Name.String()
+var subjectAttributeNames = map[string]string{
+	"2.5.4.3":  "CN",
+	"2.5.4.5":  "SERIALNUMBER",
+	"2.5.4.6":  "C",
+	"2.5.4.7":  "L",
+	"2.5.4.8":  "ST",
+	"2.5.4.9":  "STREET",
+	"2.5.4.10": "O",
+	"2.5.4.11": "OU",
+	"2.5.4.17": "POSTALCODE",
 }
 
 type AttestationData struct {
@@ -264,13 +294,48 @@ func Fingerprint(cert *x509.Certificate) string {
 	return hex.EncodeToString(sum[:])
 }
 
+// CertificateSANs returns the subject alternative names of the certificate
+func CertificateSANs(cert *x509.Certificate) SANs {
+	sans := SANs{
+		DNS:   cert.DNSNames,
+		Email: cert.EmailAddresses,
+	}
+	for _, uri := range cert.URIs {
+		sans.URI = append(sans.URI, uri.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		sans.IP = append(sans.IP, ip.String())
+	}
+	return sans
+}
+
+// SubjectAttributes returns the first value of each attribute of the
+// certificate subject, keyed by the attribute short name or dotted OID.
+func SubjectAttributes(cert *x509.Certificate) map[string]string {
+	attributes := make(map[string]string)
+	for _, atv := range cert.Subject.Names {
+		oid := atv.Type.String()
+		name, ok := subjectAttributeNames[oid]
+		if !ok {
+			name = oid
+		}
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = fmt.Sprint(atv.Value)
+		}
+	}
+	return attributes
+}
+
 // MakeSpiffeID creates a SPIFFE ID from X.509 Certificate data.
 func MakeSpiffeID(trustDomain string, agentPathTemplate *template.Template, cert *x509.Certificate) (string, error) {
 	var agentPath bytes.Buffer
 	if err := agentPathTemplate.Execute(&agentPath, agentPathTemplateData{
-		Certificate: cert,
-		PluginName:  PluginName,
-		Fingerprint: Fingerprint(cert),
+		Certificate:       cert,
+		PluginName:        PluginName,
+		Fingerprint:       Fingerprint(cert),
+		TrustDomain:       trustDomain,
+		SAN:               CertificateSANs(cert),
+		SubjectAttributes: SubjectAttributes(cert),
 	}); err != nil {
 		return "", err
 	}
@@ -6,8 +6,11 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"math/big"
+	"net"
+	"net/url"
 	"testing"
 	"text/template"
 
@@ -145,6 +148,21 @@ func TestMakeSPIFFEID(t *testing.T) {
 			template:     template.Must(template.New("test").Parse("foo/{{ .Subject.CommonName }}")),
 			expectSPIFFE: "spiffe://example.org/spire/agent/foo/test-cert",
 		},
+		{
+			desc:         "custom template with SANs",
+			template:     template.Must(template.New("test").Parse("{{ .TrustDomain }}/{{ index .SAN.DNS 0 }}")),
+			expectSPIFFE: "spiffe://example.org/spire/agent/example.org/host.example.org",
+		},
+		{
+			desc:         "custom template with subject attributes",
+			template:     template.Must(template.New("test").Parse(`{{ .SubjectAttributes.OU }}/{{ index .SubjectAttributes "1.2.3.4" }}`)),
+			expectSPIFFE: "spiffe://example.org/spire/agent/devices/custom",
+		},
+		{
+			desc:      "custom template with missing SAN",
+			template:  template.Must(template.New("test").Parse("{{ index .SAN.URI 0 }}")),
+			expectErr: `template: test:1:3: executing "test" at <index .SAN.URI 0>: error calling index`,
+		},
 		{
 			desc:      "custom template with nonexistant fields",
 			template:  template.Must(template.New("test").Parse("{{ .Foo }}")),
@@ -158,7 +176,13 @@ func TestMakeSPIFFEID(t *testing.T) {
 			cert := &x509.Certificate{
 				Subject: pkix.Name{
 					CommonName: "test-cert",
+					Names: []pkix.AttributeTypeAndValue{
+						{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "test-cert"},
+						{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "devices"},
+						{Type: asn1.ObjectIdentifier{1, 2, 3, 4}, Value: "custom"},
+					},
 				},
+				DNSNames: []string{"host.example.org"},
 			}
 			spiffeid, err := MakeSpiffeID("example.org", tt.template, cert)
 			if tt.expectErr != "" {
@@ -171,3 +195,38 @@ func TestMakeSPIFFEID(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateSANs(t *testing.T) {
+	cert := &x509.Certificate{
+		DNSNames:       []string{"host.example.org"},
+		EmailAddresses: []string{"admin@example.org"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{{Scheme: "urn", Opaque: "device:1234"}},
+	}
+	require.Equal(t, SANs{
+		DNS:   []string{"host.example.org"},
+		URI:   []string{"urn:device:1234"},
+		Email: []string{"admin@example.org"},
+		IP:    []string{"10.0.0.1"},
+	}, CertificateSANs(cert))
+
+	require.Equal(t, SANs{}, CertificateSANs(&x509.Certificate{}))
+}
+
+func TestSubjectAttributes(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			Names: []pkix.AttributeTypeAndValue{
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "test-cert"},
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "devices"},
+				{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "other"},
+				{Type: asn1.ObjectIdentifier{1, 2, 3, 4}, Value: "custom"},
+			},
+		},
+	}
+	require.Equal(t, map[string]string{
+		"CN":      "test-cert",
+		"OU":      "devices",
+		"1.2.3.4": "custom",
+	}, SubjectAttributes(cert))
+}
@@ -38,3 +38,19 @@ func AddCacheManagerOutdatedSVIDsSample(m telemetry.Metrics, count float32) {
 }
 
 // End Add Samples
+
+// Gauge (remember previous value set)
+
+// SetCacheManagerX509SVIDsGauge set gauge for the number of X509-SVIDs
+// in the agent cache
+func SetCacheManagerX509SVIDsGauge(m telemetry.Metrics, count float32) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.X509SVID, telemetry.Count}, count)
+}
+
+// SetCacheManagerX509SVIDMinTTLGauge set gauge for the smallest number of
+// seconds until an X509-SVID in the agent cache expires
+func SetCacheManagerX509SVIDMinTTLGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.CacheManager, telemetry.X509SVID, telemetry.MinTTL}, val)
+}
+
+// End Gauge
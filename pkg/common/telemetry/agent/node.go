@@ -12,4 +12,11 @@ func StartNodeAttestorNewSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Node, telemetry.Attestor, telemetry.NewSVID)
 }
 
+// StartNodeAttestorReattestCall return metric
+// for agent node attestor call to re-attest the
+// agent
+func StartNodeAttestorReattestCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Attestor, telemetry.Reattest)
+}
+
 // End Call Counters
@@ -0,0 +1,14 @@
+package common
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// IncrExternalPluginRestartCounter indicate the catalog restarted an
+// external plugin. Takes the plugin name and type.
+func IncrExternalPluginRestartCounter(m telemetry.Metrics, pluginName, pluginType string) {
+	m.IncrCounterWithLabels([]string{telemetry.Catalog, telemetry.PluginExternal, telemetry.Restart}, 1, []telemetry.Label{
+		{Name: telemetry.PluginName, Value: pluginName},
+		{Name: telemetry.PluginType, Value: pluginType},
+	})
+}
@@ -17,6 +17,7 @@ type FileConfig struct {
 	Statsd     []StatsdConfig    `hcl:"Statsd"`
 	M3         []M3Config        `hcl:"M3"`
 	InMem      *InMem            `hcl:"InMem"`
+	Tracing    *TracingConfig    `hcl:"Tracing"`
 
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
@@ -47,3 +48,24 @@ type InMem struct {
 	Enabled    *bool    `hcl:"enabled"`
 	UnusedKeys []string `hcl:",unusedKeys"`
 }
+
+// TracingConfig configures distributed tracing. Spans are exported either to
+// an OTLP/HTTP collector, to a file (one OTLP JSON export request per line),
+// or both.
+type TracingConfig struct {
+	OTLP        *OTLPConfig        `hcl:"OTLP"`
+	File        *TracingFileConfig `hcl:"File"`
+	SampleRatio *float64           `hcl:"sample_ratio"`
+	UnusedKeys  []string           `hcl:",unusedKeys"`
+}
+
+type OTLPConfig struct {
+	Endpoint   string            `hcl:"endpoint"`
+	Headers    map[string]string `hcl:"headers"`
+	UnusedKeys []string          `hcl:",unusedKeys"`
+}
+
+type TracingFileConfig struct {
+	Path       string   `hcl:"path"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
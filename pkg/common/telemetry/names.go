@@ -55,6 +55,22 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// Reattest functionality related to re-attesting an already attested
+	// node; should be used with other tags to add clarity
+	Reattest = "reattest"
+
+	// Remove functionality related to removing some part of an entity, such
+	// as keys from a bundle; should be used with other tags to add clarity
+	Remove = "remove"
+
+	// Report functionality related to reporting some entity to an upstream
+	// destination; should be used with other tags to add clarity
+	Report = "report"
+
+	// Restart functionality related to restarting some entity, such as an
+	// external plugin; should be used with other tags to add clarity
+	Restart = "restart"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -77,6 +93,12 @@ const (
 
 	// Mint functionality related to minting identities
 	Mint = "mint"
+
+	// Revoke functionality related to revoking identities
+	Revoke = "revoke"
+
+	// Use functionality related to using a limited resource, such as a join token
+	Use = "use"
 )
 
 // Attribute metric tags or labels that are typically an attribute of a
@@ -171,9 +193,16 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// LastSeen tags the last time some entity was seen
+	LastSeen = "last_seen"
+
 	// NodeAttestorType declares the type of node attestation.
 	NodeAttestorType = "node_attestor_type"
 
+	// MinTTL tags the smallest time-to-live among a group of entities; should
+	// be used with other tags to add clarity
+	MinTTL = "min_ttl"
+
 	// Nonce tags some nonce for communication
 	Nonce = "nonce"
 
@@ -200,6 +229,9 @@ const (
 	// PluginName tags name of some plugin
 	PluginName = "plugin_name"
 
+	// PluginNames tags names of some plugins
+	PluginNames = "plugin_names"
+
 	// PluginService tags single service provided by a plugin
 	PluginService = "plugin_service"
 
@@ -209,9 +241,15 @@ const (
 	// PluginType tags type of some plugin
 	PluginType = "plugin_type"
 
+	// Prunable flagging something that would be pruned
+	Prunable = "prunable"
+
 	// Pruned flagging something has been pruned
 	Pruned = "pruned"
 
+	// Reason tags the reason given for some operation (i.e. an agent ban)
+	Reason = "reason"
+
 	// RegistrationID tags some registration entry ID
 	RegistrationID = "entry_id"
 
@@ -318,6 +356,10 @@ const (
 // module in their own right, rather than descriptive of other
 // entities or modules
 const (
+	// AgentBan functionality related to an agent ban; should be used with
+	// other tags to add clarity
+	AgentBan = "agent_ban"
+
 	// AgentSVID tag a node (agent) SVID
 	AgentSVID = "agent_svid"
 
@@ -328,6 +370,10 @@ const (
 	// to add clarity
 	Bundle = "bundle"
 
+	// BundleHistory functionality related to the history of bundles received
+	// from a federated bundle endpoint
+	BundleHistory = "bundle_history"
+
 	// BundlesUpdate functionality related to updating bundles
 	BundlesUpdate = "bundles_update"
 
@@ -347,6 +393,14 @@ const (
 	// Datastore functionality related to datastore plugin
 	Datastore = "datastore"
 
+	// DelegatedJWTKey functionality related to a JWT key delegated to an agent; should
+	// be used with other tags to add clarity
+	DelegatedJWTKey = "delegated_jwt_key"
+
+	// DownstreamAgents functionality related to the agents attested by a
+	// downstream server; should be used with other tags to add clarity
+	DownstreamAgents = "downstream_agents"
+
 	// Endpoints functionality related to agent/server endpoints
 	Endpoints = "endpoints"
 
@@ -367,6 +421,10 @@ const (
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// FederationRelationship functionality related to a federation relationship;
+	// should be used with other tags to add clarity
+	FederationRelationship = "federation_relationship"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -389,6 +447,10 @@ const (
 	// Method is the full name of the method invoked
 	Method = "method"
 
+	// NestedManager functionality related to the nested SPIRE manager of a
+	// downstream server
+	NestedManager = "nested_manager"
+
 	// NewSVID functionality related to creation of a new SVID
 	NewSVID = "new_svid"
 
@@ -404,12 +466,19 @@ const (
 	// to add clarity
 	ServerCA = "server_ca"
 
+	// ServerSVID functionality related to the SVID of the server itself; should
+	// be used with other tags to add clarity
+	ServerSVID = "server_svid"
+
 	// SpireAgent typically the entire spire agent service
 	SpireAgent = "spire_agent"
 
 	// SpireServer typically the entire spire server
 	SpireServer = "spire_server"
 
+	// StatsReporter functionality related to the server stats reporter
+	StatsReporter = "stats_reporter"
+
 	// SVID functionality related to a SVID; should be used with other tags
 	// to add clarity
 	SVID = "svid"
@@ -423,6 +492,9 @@ const (
 	// Telemetry tags a telemetry module
 	Telemetry = "telemetry"
 
+	// Tracing tags the distributed tracing module
+	Tracing = "tracing"
+
 	// X509CA functionality related to an x509 CA; should be used with other tags
 	// to add clarity
 	X509CA = "x509_ca"
@@ -440,9 +512,19 @@ const (
 // operation or API
 const (
 
+	// AdminAPI functionality related to the agent admin API; should be used
+	// with other tags to add clarity
+	AdminAPI = "admin_api"
+
+	// BanAgent functionality related to banning an agent
+	BanAgent = "ban_agent"
+
 	// CreateFederatedBundle functionality related to creating a federated bundle
 	CreateFederatedBundle = "create_federated_bundle"
 
+	// CreateFederationRelationship functionality related to creating a federation relationship
+	CreateFederationRelationship = "create_federation_relationship"
+
 	// CreateJoinToken functionality related to creating a join token
 	CreateJoinToken = "create_join_token"
 
@@ -452,18 +534,34 @@ const (
 	// CreateRegistrationEntryIfNotExists functionality related to creating a registration entry
 	CreateRegistrationEntryIfNotExists = "create_registration_entry_if_not_exists"
 
+	// DebugAttest functionality related to attesting a workload for debugging
+	DebugAttest = "debug_attest"
+
 	// DeleteFederatedBundle functionality related to deleting a federated bundle
 	DeleteFederatedBundle = "delete_federated_bundle"
 
+	// DeleteFederationRelationship functionality related to deleting a federation relationship
+	DeleteFederationRelationship = "delete_federation_relationship"
+
 	// DeleteRegistrationEntry functionality related to deleting a registration entry
 	DeleteRegistrationEntry = "delete_registration_entry"
 
 	// EvictAgent funtionality related to evicting an agent
 	EvictAgent = "evict_agent"
 
+	// ExplainEntries functionality related to explaining which entries a workload would be issued
+	ExplainEntries = "explain_entries"
+
 	// FetchBundle functionality related to fetching a CA bundle
 	FetchBundle = "fetch_bundle"
 
+	// FetchDelegatedJWTKey functionality related to fetching a JWT key delegated to an agent
+	FetchDelegatedJWTKey = "fetch_delegated_jwt_key"
+
+	// FetchFederatedBundles functionality related to fetching the federated bundles
+	// of an upstream server
+	FetchFederatedBundles = "fetch_federated_bundles"
+
 	// FetchEntriesUpdates functionality related to fetching entries updates; should be used
 	// with other tags to add clarity
 	FetchEntriesUpdates = "fetch_entries_updates"
@@ -496,18 +594,34 @@ const (
 	// FetchX509SVID functionality related to fetching an X509 SVID
 	FetchX509SVID = "fetch_x509_svid"
 
+	// GetAgentInfo functionality related to getting the agent SVID, bundle and sync status
+	GetAgentInfo = "get_agent_info"
+
 	// GetNodeSelectors functionality related to getting node selectors
 	GetNodeSelectors = "get_node_selectors"
 
+	// ListAgentBans functionality related to listing agent bans
+	ListAgentBans = "list_agent_bans"
+
 	// ListAgents functionality related to listing agents
 	ListAgents = "list_agents"
 
 	// ListAllEntriesWithPages functionality related to listing all registration entries with pagination
 	ListAllEntriesWithPages = "list_all_entries_with_pages"
 
+	// ListCachedEntries functionality related to listing the entries cached by an agent
+	ListCachedEntries = "list_cached_entries"
+
+	// ListDownstreamAgents functionality related to listing the agents reported by
+	// downstream servers
+	ListDownstreamAgents = "list_downstream_agents"
+
 	// ListFederatedBundles functionality related to listing federated bundles
 	ListFederatedBundles = "list_federated_bundles"
 
+	// ListFederationRelationships functionality related to listing federation relationships
+	ListFederationRelationships = "list_federation_relationships"
+
 	// ListRegistrationsByParentID functionality related to listing registrations by parent ID
 	ListRegistrationsByParentID = "list_registrations_by_parent_id"
 
@@ -529,6 +643,12 @@ const (
 	// NodeAPI functionality related to attested/attesting nodes (agents)
 	NodeAPI = "node_api"
 
+	// RevokeJWTSVIDs functionality related to revoking JWT-SVIDs
+	RevokeJWTSVIDs = "revoke_jwt_svids"
+
+	// RotateSVID functionality related to forcing the rotation of an SVID
+	RotateSVID = "rotate_svid"
+
 	// PushJWTKeyUpstream functionality related to pushing a public JWT Key to an upstream server.
 	PushJWTKeyUpstream = "push_jwtkey_upstream"
 
@@ -536,6 +656,9 @@ const (
 	// with other tags to add clarity
 	RegistrationAPI = "registration_api"
 
+	// ReportAgentsUpstream functionality related to reporting attested agents to an upstream server
+	ReportAgentsUpstream = "report_agents_upstream"
+
 	// SDSAPI functionality related to SDS; should be used with other tags
 	// to add clarity
 	SDSAPI = "sds_api"
@@ -546,9 +669,18 @@ const (
 	// SubsystemName declares field for some subsystem name (an API, module...)
 	SubsystemName = "subsystem_name"
 
+	// UnbanAgent functionality related to unbanning an agent
+	UnbanAgent = "unban_agent"
+
+	// UnrevokeJWTSVIDs functionality related to unrevoking JWT-SVIDs
+	UnrevokeJWTSVIDs = "unrevoke_jwt_svids"
+
 	// UpdateFederatedBundle functionality related to updating a federated bundle
 	UpdateFederatedBundle = "update_federated_bundle"
 
+	// UpdateFederationRelationship functionality related to updating a federation relationship
+	UpdateFederationRelationship = "update_federation_relationship"
+
 	// UpdateRegistrationEntry functionality related to updating a registration entry
 	UpdateRegistrationEntry = "update_registration_entry"
 
@@ -0,0 +1,28 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartCreateAgentBanCall return metric
+// for server's datastore, on creating an agent ban.
+func StartCreateAgentBanCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.AgentBan, telemetry.Create)
+}
+
+// StartDeleteAgentBanCall return metric
+// for server's datastore, on deleting an agent ban.
+func StartDeleteAgentBanCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.AgentBan, telemetry.Delete)
+}
+
+// StartListAgentBansCall return metric
+// for server's datastore, on listing agent bans.
+func StartListAgentBansCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.AgentBan, telemetry.List)
+}
+
+// End Call Counters
@@ -13,6 +13,18 @@ func StartAppendBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.Append)
 }
 
+// StartAppendBundleHistoryCall return metric
+// for server's datastore, on recording a received bundle.
+func StartAppendBundleHistoryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.BundleHistory, telemetry.Append)
+}
+
+// StartCountBundleCall return metric
+// for server's datastore, on counting bundles.
+func StartCountBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.Count)
+}
+
 // StartCreateBundleCall return metric
 // for server's datastore, on creating a bundle.
 func StartCreateBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -37,12 +49,24 @@ func StartListBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.List)
 }
 
+// StartListBundleHistoryCall return metric
+// for server's datastore, on listing received bundles.
+func StartListBundleHistoryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.BundleHistory, telemetry.List)
+}
+
 // StartPruneBundleCall return metric
 // for server's datastore, on pruning a bundle.
 func StartPruneBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.Prune)
 }
 
+// StartRemoveBundleJWTSigningKeysCall return metric
+// for server's datastore, on removing JWT signing keys from a bundle.
+func StartRemoveBundleJWTSigningKeysCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Bundle, telemetry.JWTKey, telemetry.Remove)
+}
+
 // StartSetBundleCall return metric
 // for server's datastore, on sets the bundle.
 func StartSetBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -7,6 +7,12 @@ import (
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
 
+// StartCountNodeCall return metric
+// for server's datastore, on counting nodes.
+func StartCountNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Count)
+}
+
 // StartCreateNodeCall return metric
 // for server's datastore, on creating a node.
 func StartCreateNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -31,6 +37,12 @@ func StartListNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.List)
 }
 
+// StartPruneNodeCall return metric
+// for server's datastore, on pruning nodes.
+func StartPruneNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Prune)
+}
+
 // StartGetNodeSelectorsCall return metric
 // for server's datastore, on getting selectors for a node.
 func StartGetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -49,4 +61,10 @@ func StartUpdateNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Update)
 }
 
+// StartUpdateNodeLastSeenCall return metric
+// for server's datastore, on recording when a node was last seen.
+func StartUpdateNodeLastSeenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.LastSeen, telemetry.Update)
+}
+
 // End Call Counters
@@ -0,0 +1,14 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartNestedManagerReportAgentsCall returns metric
+// for server nested manager reporting agents upstream
+func StartNestedManagerReportAgentsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.DownstreamAgents, telemetry.Manager, telemetry.Report)
+}
+
+// End Call Counters
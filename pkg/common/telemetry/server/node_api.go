@@ -32,10 +32,34 @@ func StartNodeAPIPushJWTKeyUpstreamCall(m telemetry.Metrics) *telemetry.CallCoun
 	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.JWTKey, telemetry.Push)
 }
 
+// StartNodeAPIFetchDelegatedJWTKeyCall return metric for
+// the server's Node API, Fetch a JWT key delegated to the agent.
+func StartNodeAPIFetchDelegatedJWTKeyCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.DelegatedJWTKey, telemetry.Fetch)
+}
+
+// StartNodeAPIReportAgentsUpstreamCall return metric for
+// the server's Node API, Report agents of a downstream server.
+func StartNodeAPIReportAgentsUpstreamCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.DownstreamAgents, telemetry.Report)
+}
+
+// StartNodeAPIFetchFederatedBundlesCall return metric for
+// the server's Node API, Fetch the federated bundles.
+func StartNodeAPIFetchFederatedBundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.FederatedBundle, telemetry.Fetch)
+}
+
 // StartNodeAPIFetchBundleCall return metric for
 // the server's Node API, Fetch the current bundle.
 func StartNodeAPIFetchBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.FetchBundle, telemetry.Fetch)
 }
 
+// StartNodeAPIReattestCall return metric for
+// the server's Node API, Re-attestation of an attested node.
+func StartNodeAPIReattestCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.NodeAPI, telemetry.Reattest)
+}
+
 // End Call Counters
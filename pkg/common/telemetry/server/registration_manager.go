@@ -11,4 +11,31 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneAttestedNodeCall returns metric for
+// for server registration manager attested node pruning
+func StartRegistrationManagerPruneAttestedNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Prune)
+}
+
 // End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrRegistrationManagerPrunedAttestedNodeCounter indicate the number
+// of attested nodes pruned by the registration manager
+func IncrRegistrationManagerPrunedAttestedNodeCounter(m telemetry.Metrics, count int) {
+	m.IncrCounter([]string{telemetry.Node, telemetry.Manager, telemetry.Pruned}, float32(count))
+}
+
+// End Counters
+
+// Gauges (remember previous value set)
+
+// SetRegistrationManagerPrunableAttestedNodesGauge sets the number of
+// attested nodes that would have been pruned by the registration manager
+// in dry run mode
+func SetRegistrationManagerPrunableAttestedNodesGauge(m telemetry.Metrics, count int) {
+	m.SetGauge([]string{telemetry.Node, telemetry.Manager, telemetry.Prunable}, float32(count))
+}
+
+// End Gauges
@@ -23,6 +23,12 @@ func StartCreateFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Create)
 }
 
+// StartCreateFederationRelationshipCall return metric
+// for server's registration API, on creating a federation relationship
+func StartCreateFederationRelationshipCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederationRelationship, telemetry.Create)
+}
+
 // StartCreateJoinTokenCall return metric
 // for server's registration API, on creating a join token
 func StartCreateJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -41,6 +47,12 @@ func StartDeleteFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Delete)
 }
 
+// StartDeleteFederationRelationshipCall return metric
+// for server's registration API, on deleting a federation relationship
+func StartDeleteFederationRelationshipCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederationRelationship, telemetry.Delete)
+}
+
 // StartFetchBundleCall return metric
 // for server's registration API, on fetching a bundle
 func StartFetchBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -71,6 +83,12 @@ func StartListFedBundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.List)
 }
 
+// StartListFederationRelationshipsCall return metric
+// for server's registration API, on listing federation relationships
+func StartListFederationRelationshipsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederationRelationship, telemetry.List)
+}
+
 // StartUpdateEntryCall return metric
 // for server's registration API, on updating an entry
 func StartUpdateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -83,6 +101,12 @@ func StartUpdateFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Update)
 }
 
+// StartUpdateFederationRelationshipCall return metric
+// for server's registration API, on updating a federation relationship
+func StartUpdateFederationRelationshipCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederationRelationship, telemetry.Update)
+}
+
 // StartMintX509SVIDCall return metric
 // for server's registration API, on minting an X509SVID
 func StartMintX509SVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -95,6 +119,36 @@ func StartMintJWTSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JWTSVID, telemetry.Mint)
 }
 
+// StartRevokeJWTSVIDsCall return metric
+// for server's registration API, on revoking JWT-SVIDs
+func StartRevokeJWTSVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JWTSVID, telemetry.Revoke)
+}
+
+// StartUnrevokeJWTSVIDsCall return metric
+// for server's registration API, on unrevoking JWT-SVIDs
+func StartUnrevokeJWTSVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JWTSVID, telemetry.Revoke, telemetry.Delete)
+}
+
+// StartBanAgentCall return metric
+// for server's registration API, on banning an agent
+func StartBanAgentCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.AgentBan, telemetry.Create)
+}
+
+// StartUnbanAgentCall return metric
+// for server's registration API, on unbanning an agent
+func StartUnbanAgentCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.AgentBan, telemetry.Delete)
+}
+
+// StartListAgentBansCall return metric
+// for server's registration API, on listing agent bans
+func StartListAgentBansCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.AgentBan, telemetry.List)
+}
+
 // End Call Counters
 
 // Counters (literal increments, not call counters)
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Gauge (remember previous value set)
+
+// SetX509CATTLGauge set gauge for the number of seconds until the
+// active X509 CA of the given trust domain expires
+func SetX509CATTLGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.CA, telemetry.X509CA, telemetry.TTL},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// SetJWTKeyTTLGauge set gauge for the number of seconds until the
+// active JWT key of the given trust domain expires
+func SetJWTKeyTTLGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.CA, telemetry.JWTKey, telemetry.TTL},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// SetServerSVIDTTLGauge set gauge for the number of seconds until the
+// server SVID of the given trust domain expires
+func SetServerSVIDTTLGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	m.SetGaugeWithLabels(
+		[]string{telemetry.ServerSVID, telemetry.TTL},
+		val,
+		[]telemetry.Label{
+			{Name: telemetry.TrustDomainID, Value: trustDomain},
+		})
+}
+
+// SetRegistrationEntriesGauge set gauge for the number of registration
+// entries
+func SetRegistrationEntriesGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.RegistrationEntry, telemetry.Count}, val)
+}
+
+// SetAttestedNodesGauge set gauge for the number of attested nodes
+// (agents)
+func SetAttestedNodesGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.Node, telemetry.Count}, val)
+}
+
+// SetExpiringAttestedNodesGauge set gauge for the number of attested
+// nodes (agents) whose SVID is about to expire
+func SetExpiringAttestedNodesGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.Node, telemetry.ExpiringSVIDs}, val)
+}
+
+// SetFederatedBundlesGauge set gauge for the number of federated bundles
+func SetFederatedBundlesGauge(m telemetry.Metrics, val float32) {
+	m.SetGauge([]string{telemetry.FederatedBundle, telemetry.Count}, val)
+}
+
+// End Gauge
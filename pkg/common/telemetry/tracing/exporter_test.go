@@ -0,0 +1,243 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSpan = SpanData{
+		Name: "/spire.api.node.Node/FetchX509SVID",
+		Kind: SpanKindServer,
+		SpanContext: SpanContext{
+			TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			Sampled: true,
+		},
+		ParentSpanID: SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		StartTime:    time.Unix(1, 0),
+		EndTime:      time.Unix(2, 5),
+		Attributes: []Attribute{
+			Attr("str", "value"),
+			Attr("int", int64(42)),
+			Attr("bool", true),
+			Attr("double", 1.5),
+		},
+		StatusCode:    StatusError,
+		StatusMessage: "oh no",
+	}
+
+	testSpanJSON = `{
+		"resourceSpans": [{
+			"resource": {
+				"attributes": [{"key": "service.name", "value": {"stringValue": "spire_server"}}]
+			},
+			"scopeSpans": [{
+				"scope": {"name": "github.com/spiffe/spire"},
+				"spans": [{
+					"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+					"spanId": "00f067aa0ba902b7",
+					"parentSpanId": "0102030405060708",
+					"name": "/spire.api.node.Node/FetchX509SVID",
+					"kind": 2,
+					"startTimeUnixNano": "1000000000",
+					"endTimeUnixNano": "2000000005",
+					"attributes": [
+						{"key": "str", "value": {"stringValue": "value"}},
+						{"key": "int", "value": {"intValue": "42"}},
+						{"key": "bool", "value": {"boolValue": true}},
+						{"key": "double", "value": {"doubleValue": 1.5}}
+					],
+					"status": {"code": 2, "message": "oh no"}
+				}]
+			}]
+		}]
+	}`
+)
+
+func TestFileExporter(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "traces.jsonl")
+
+	exporter, err := NewFileExporter(path)
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(context.Background(), "spire_server", []SpanData{testSpan}))
+	require.NoError(t, exporter.ExportSpans(context.Background(), "spire_server", []SpanData{testSpan}))
+	require.NoError(t, exporter.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.JSONEq(t, testSpanJSON, line)
+	}
+}
+
+func TestFileExporterOpenFailure(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	_, err := NewFileExporter(filepath.Join(dir, "missing", "traces.jsonl"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to open tracing file")
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var gotPath, gotContentType, gotAuth string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+		gotAuth = r.Header.Get("Authorization")
+		gotBody, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL+"/", map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, exporter.ExportSpans(context.Background(), "spire_server", []SpanData{testSpan}))
+	require.NoError(t, exporter.Close())
+
+	assert.Equal(t, "/v1/traces", gotPath)
+	assert.Equal(t, "application/json", gotContentType)
+	assert.Equal(t, "Bearer token", gotAuth)
+	assert.JSONEq(t, testSpanJSON, string(gotBody))
+}
+
+func TestOTLPExporterFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	exporter := NewOTLPExporter(server.URL+"/v1/traces", nil)
+	err := exporter.ExportSpans(context.Background(), "spire_server", []SpanData{testSpan})
+	require.EqualError(t, err, "OTLP collector returned 503 Service Unavailable")
+}
+
+// otlpJSONFieldNames is the set of field names, taken from the OTLP/HTTP JSON
+// encoding of opentelemetry.proto.collector.trace.v1.ExportTraceServiceRequest,
+// that the exporter is allowed to emit.
+var otlpJSONFieldNames = map[string]bool{
+	"resourceSpans":     true,
+	"resource":          true,
+	"attributes":        true,
+	"key":               true,
+	"value":             true,
+	"stringValue":       true,
+	"boolValue":         true,
+	"intValue":          true,
+	"doubleValue":       true,
+	"scopeSpans":        true,
+	"scope":             true,
+	"name":              true,
+	"spans":             true,
+	"traceId":           true,
+	"spanId":            true,
+	"parentSpanId":      true,
+	"kind":              true,
+	"startTimeUnixNano": true,
+	"endTimeUnixNano":   true,
+	"status":            true,
+	"code":              true,
+	"message":           true,
+}
+
+func TestOTLPExporterGolden(t *testing.T) {
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	rootSpan := SpanData{
+		Name: "agent.manager.synchronize",
+		Kind: SpanKindInternal,
+		SpanContext: SpanContext{
+			TraceID: testSpan.SpanContext.TraceID,
+			SpanID:  testSpan.ParentSpanID,
+			Sampled: true,
+		},
+		StartTime: time.Unix(0, 500),
+		EndTime:   time.Unix(3, 0),
+	}
+
+	exporter := NewOTLPExporter(server.URL, nil)
+	require.NoError(t, exporter.ExportSpans(context.Background(), "spire_server", []SpanData{testSpan, rootSpan}))
+
+	golden, err := ioutil.ReadFile(filepath.Join("testdata", "otlp_traces.json"))
+	require.NoError(t, err)
+	var got bytes.Buffer
+	require.NoError(t, json.Indent(&got, gotBody, "", "  "))
+	got.WriteByte('\n')
+	assert.Equal(t, string(golden), got.String())
+
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal(gotBody, &decoded))
+	assertOTLPJSONFieldNames(t, "", decoded)
+}
+
+func assertOTLPJSONFieldNames(t *testing.T, path string, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			assert.True(t, otlpJSONFieldNames[key], "unexpected OTLP JSON field %q at %q", key, path)
+			assertOTLPJSONFieldNames(t, path+"."+key, value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			assertOTLPJSONFieldNames(t, fmt.Sprintf("%s[%d]", path, i), value)
+		}
+	}
+}
+
+func TestTracerExportsToFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "traces.jsonl")
+
+	tracer, _, finish := setupTracer(t, &telemetry.TracingConfig{
+		File: &telemetry.TracingFileConfig{Path: path},
+	})
+	defer finish()
+
+	ctx, parent := tracer.StartSpan(context.Background(), "parent")
+	_, child := tracer.StartSpan(ctx, "child")
+	child.End()
+	parent.End()
+	finish()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var req otlpRequest
+	require.NoError(t, json.Unmarshal(data, &req))
+	require.Len(t, req.ResourceSpans, 1)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "tracing-test-")
+	require.NoError(t, err)
+	return dir
+}
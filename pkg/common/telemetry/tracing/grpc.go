@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor starts a server span for each unary call, using the
+// traceparent in the incoming metadata, if any, as the parent.
+func (t *Tracer) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !t.Enabled() {
+		return handler(ctx, req)
+	}
+
+	ctx, span := t.startServerSpan(ctx, info.FullMethod)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+	endServerSpan(span, err)
+	return resp, err
+}
+
+// StreamServerInterceptor starts a server span for each streaming call,
+// using the traceparent in the incoming metadata, if any, as the parent.
+func (t *Tracer) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !t.Enabled() {
+		return handler(srv, ss)
+	}
+
+	ctx, span := t.startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	err := handler(srv, serverStream{ServerStream: ss, ctx: ctx})
+	endServerSpan(span, err)
+	return err
+}
+
+func (t *Tracer) startServerSpan(ctx context.Context, fullMethod string) (context.Context, *Span) {
+	if sc, ok := incomingSpanContext(ctx); ok {
+		ctx = ContextWithRemoteParent(ctx, sc)
+	}
+	return t.startSpan(ctx, fullMethod, SpanKindServer, []Attribute{
+		Attr("rpc.system", "grpc"),
+		Attr("rpc.method", fullMethod),
+	})
+}
+
+func endServerSpan(span *Span, err error) {
+	code := status.Code(err)
+	span.SetAttribute("rpc.grpc.status_code", int64(code))
+	span.RecordError(err)
+}
+
+func incomingSpanContext(ctx context.Context) (SpanContext, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return SpanContext{}, false
+	}
+	values := md.Get(TraceparentHeader)
+	if len(values) == 0 {
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceparent(values[0])
+	if err != nil {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss serverStream) Context() context.Context {
+	return ss.ctx
+}
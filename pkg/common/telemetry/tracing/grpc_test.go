@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tracer, exporter, finish := setupTracer(t, nil)
+	defer finish()
+
+	remote := SpanContext{
+		TraceID: newTraceID(),
+		SpanID:  newSpanID(),
+		Sampled: true,
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceparentHeader, remote.Traceparent()))
+
+	var handlerSpan *Span
+	var outgoing metadata.MD
+	info := &grpc.UnaryServerInfo{FullMethod: "/spire.api.node.Node/FetchX509SVID"}
+	resp, err := tracer.UnaryServerInterceptor(ctx, "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerSpan = FromContext(ctx)
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return "resp", status.Error(codes.PermissionDenied, "denied")
+	})
+	assert.Equal(t, "resp", resp)
+	require.Error(t, err)
+
+	// Calls made by the handler carry the server span as the parent
+	require.NotNil(t, handlerSpan)
+	assert.Equal(t, []string{handlerSpan.SpanContext().Traceparent()}, outgoing.Get(TraceparentHeader))
+
+	finish()
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "/spire.api.node.Node/FetchX509SVID", span.Name)
+	assert.Equal(t, SpanKindServer, span.Kind)
+	assert.Equal(t, remote.TraceID, span.SpanContext.TraceID)
+	assert.Equal(t, remote.SpanID, span.ParentSpanID)
+	assert.Equal(t, StatusError, span.StatusCode)
+	assert.Equal(t, "rpc error: code = PermissionDenied desc = denied", span.StatusMessage)
+	assert.Contains(t, span.Attributes, Attr("rpc.grpc.status_code", int64(codes.PermissionDenied)))
+}
+
+func TestUnaryServerInterceptorIgnoresInvalidTraceparent(t *testing.T) {
+	tracer, exporter, finish := setupTracer(t, nil)
+	defer finish()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceparentHeader, "garbage"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	_, err := tracer.UnaryServerInterceptor(ctx, "req", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	finish()
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	assert.False(t, spans[0].ParentSpanID.IsValid())
+	assert.Equal(t, StatusUnset, spans[0].StatusCode)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	tracer, exporter, finish := setupTracer(t, nil)
+	defer finish()
+
+	remote := SpanContext{
+		TraceID: newTraceID(),
+		SpanID:  newSpanID(),
+		Sampled: true,
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceparentHeader, remote.Traceparent()))
+
+	var handlerSpan *Span
+	info := &grpc.StreamServerInfo{FullMethod: "/SpiffeWorkloadAPI/FetchX509SVID"}
+	err := tracer.StreamServerInterceptor(nil, fakeServerStream{ctx: ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		handlerSpan = FromContext(stream.Context())
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, handlerSpan)
+
+	finish()
+	spans := exporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "/SpiffeWorkloadAPI/FetchX509SVID", spans[0].Name)
+	assert.Equal(t, remote.SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, handlerSpan.SpanContext(), spans[0].SpanContext)
+}
+
+func TestServerInterceptorsDisabled(t *testing.T) {
+	var tracer *Tracer
+
+	_, err := tracer.UnaryServerInterceptor(context.Background(), "req", &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		assert.Nil(t, FromContext(ctx))
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	err = tracer.StreamServerInterceptor(nil, fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		assert.Nil(t, FromContext(stream.Context()))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context {
+	return s.ctx
+}
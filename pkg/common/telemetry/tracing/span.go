@@ -0,0 +1,325 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// TraceparentHeader is the W3C Trace Context header (and gRPC metadata
+	// key) used to propagate span context across process boundaries.
+	TraceparentHeader = "traceparent"
+
+	traceparentVersion = "00"
+	flagSampled        = 0x01
+)
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns true if the trace ID is not all zeros
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid returns true if the span ID is not all zeros
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext is the portion of a span that is propagated to children,
+// including those in other processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid returns true if both the trace and span IDs are valid
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats the span context as a W3C traceparent header value
+func (sc SpanContext) Traceparent() string {
+	flags := byte(0)
+	if sc.Sampled {
+		flags |= flagSampled
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceparentVersion, sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent parses a W3C traceparent header value
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, errors.New("malformed traceparent")
+	}
+	if len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, fmt.Errorf("unsupported traceparent version %q", parts[0])
+	}
+	// Version 00 has exactly four fields; later versions may append more.
+	if parts[0] == traceparentVersion && len(parts) != 4 {
+		return SpanContext{}, errors.New("malformed traceparent")
+	}
+
+	var sc SpanContext
+	if err := decodeHex(sc.TraceID[:], parts[1]); err != nil {
+		return SpanContext{}, fmt.Errorf("invalid trace id: %v", err)
+	}
+	if err := decodeHex(sc.SpanID[:], parts[2]); err != nil {
+		return SpanContext{}, fmt.Errorf("invalid span id: %v", err)
+	}
+	var flags [1]byte
+	if err := decodeHex(flags[:], parts[3]); err != nil {
+		return SpanContext{}, fmt.Errorf("invalid trace flags: %v", err)
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errors.New("trace and span ids must not be zero")
+	}
+	sc.Sampled = flags[0]&flagSampled != 0
+	return sc, nil
+}
+
+func decodeHex(dst []byte, s string) error {
+	if len(s) != hex.EncodedLen(len(dst)) {
+		return fmt.Errorf("expected %d hex characters", hex.EncodedLen(len(dst)))
+	}
+	if strings.ToLower(s) != s {
+		return errors.New("hex must be lowercase")
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// SpanKind describes the relationship of a span to its callers
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// StatusCode is the outcome of the operation covered by a span
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusOK    StatusCode = 1
+	StatusError StatusCode = 2
+)
+
+// Attribute is a key/value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr is a convenience constructor for an Attribute
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span tracks a single timed operation. All methods are safe to call on a
+// nil Span, which is what StartSpan returns when tracing is disabled.
+type Span struct {
+	tracer       *Tracer
+	name         string
+	kind         SpanKind
+	sc           SpanContext
+	parentSpanID SpanID
+	start        time.Time
+
+	mu            sync.Mutex
+	end           time.Time
+	attrs         []Attribute
+	statusCode    StatusCode
+	statusMessage string
+	ended         bool
+}
+
+// SpanContext returns the propagated context of the span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttribute sets an attribute on the span, replacing any previous value
+// for the same key.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.attrs {
+		if s.attrs[i].Key == key {
+			s.attrs[i].Value = value
+			return
+		}
+	}
+	s.attrs = append(s.attrs, Attribute{Key: key, Value: value})
+}
+
+// SetStatus sets the span status
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statusCode = code
+	s.statusMessage = message
+}
+
+// RecordError marks the span as failed if err is non-nil
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// End completes the span and hands it to the tracer for export. Calls after
+// the first have no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = s.tracer.clk.Now()
+	data := SpanData{
+		Name:          s.name,
+		Kind:          s.kind,
+		SpanContext:   s.sc,
+		ParentSpanID:  s.parentSpanID,
+		StartTime:     s.start,
+		EndTime:       s.end,
+		Attributes:    append([]Attribute(nil), s.attrs...),
+		StatusCode:    s.statusCode,
+		StatusMessage: s.statusMessage,
+	}
+	s.mu.Unlock()
+
+	if s.sc.Sampled {
+		s.tracer.enqueue(data)
+	}
+}
+
+type spanKey struct{}
+type remoteParentKey struct{}
+
+// FromContext returns the current span in the context, or nil
+func FromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithRemoteParent returns a context carrying a span context received
+// from another process. The next span started from the context becomes its
+// child.
+func ContextWithRemoteParent(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteParentKey{}, sc)
+}
+
+// StartSpan starts an internal span. The returned context carries the span
+// and propagates its context on outgoing gRPC calls. When tracing is
+// disabled the context is returned unchanged along with a nil span.
+func (t *Tracer) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return t.startSpan(ctx, name, SpanKindInternal, attrs)
+}
+
+func (t *Tracer) startSpan(ctx context.Context, name string, kind SpanKind, attrs []Attribute) (context.Context, *Span) {
+	if !t.Enabled() {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer: t,
+		name:   name,
+		kind:   kind,
+		start:  t.clk.Now(),
+		attrs:  append([]Attribute(nil), attrs...),
+	}
+
+	var parent SpanContext
+	if p := FromContext(ctx); p != nil {
+		parent = p.sc
+	} else if p, ok := ctx.Value(remoteParentKey{}).(SpanContext); ok {
+		parent = p
+	}
+
+	span.sc.SpanID = newSpanID()
+	if parent.IsValid() {
+		span.sc.TraceID = parent.TraceID
+		span.sc.Sampled = parent.Sampled
+		span.parentSpanID = parent.SpanID
+	} else {
+		span.sc.TraceID = newTraceID()
+		span.sc.Sampled = t.shouldSample(span.sc.TraceID)
+	}
+
+	ctx = context.WithValue(ctx, spanKey{}, span)
+	ctx = injectOutgoing(ctx, span.sc)
+	return ctx, span
+}
+
+// injectOutgoing sets the traceparent on the outgoing gRPC metadata of the
+// context, preserving any other metadata already present.
+func injectOutgoing(ctx context.Context, sc SpanContext) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md.Set(TraceparentHeader, sc.Traceparent())
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func newTraceID() (id TraceID) {
+	for !id.IsValid() {
+		mustRead(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	for !id.IsValid() {
+		mustRead(id[:])
+	}
+	return id
+}
+
+func mustRead(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("tracing: unable to read random bytes: %v", err))
+	}
+}
@@ -0,0 +1,289 @@
+// Package tracing provides distributed tracing for SPIRE. Spans are
+// propagated between processes using the W3C Trace Context traceparent
+// header carried in gRPC metadata, and exported using the OTLP/HTTP JSON
+// encoding, so any OpenTelemetry collector can receive them.
+//
+// The OpenTelemetry Go SDK is not used because its releases, and in
+// particular its OTLP exporters, require newer versions of Go, gRPC and the
+// protobuf runtime than SPIRE (and the plugins built against it) currently
+// supports. The package only implements what SPIRE needs from the SDK (span
+// creation, traceparent propagation, ratio sampling and batched OTLP/HTTP
+// JSON export) and uses the SDK's environment variables, so it can be swapped
+// for the SDK once the dependencies allow it.
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+const (
+	// Environment variables used to pass the tracing configuration from
+	// SPIRE to external plugin processes. The names follow the
+	// OpenTelemetry SDK conventions.
+	EnvOTLPEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvOTLPHeaders  = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	EnvServiceName  = "OTEL_SERVICE_NAME"
+	EnvSampleRatio  = "OTEL_TRACES_SAMPLER_ARG"
+
+	defaultBatchSize     = 512
+	defaultQueueSize     = 4096
+	defaultFlushInterval = 5 * time.Second
+	exportTimeout        = 10 * time.Second
+
+	// shutdownTimeout bounds the final flush when the tracer is stopped. It
+	// is kept below the time hosts give plugins to exit gracefully before
+	// killing them.
+	shutdownTimeout = time.Second
+)
+
+// Config configures a Tracer
+type Config struct {
+	FileConfig  *telemetry.TracingConfig
+	Logger      logrus.FieldLogger
+	ServiceName string
+
+	// Exporters are used in addition to those configured by FileConfig.
+	Exporters []Exporter
+
+	// Clock is optional and defaults to the real clock.
+	Clock clock.Clock
+}
+
+// Tracer batches ended spans and hands them to the configured exporters. A
+// tracer without exporters is disabled and records nothing. A nil tracer is
+// disabled as well, so components can be handed a nil tracer when tracing is
+// not configured.
+type Tracer struct {
+	log         logrus.FieldLogger
+	clk         clock.Clock
+	serviceName string
+	sampleRatio float64
+	exporters   []Exporter
+	fileConfig  *telemetry.TracingConfig
+	spans       chan SpanData
+}
+
+// New creates a new Tracer
+func New(c *Config) (*Tracer, error) {
+	if c.Logger == nil {
+		return nil, errors.New("logger must be configured")
+	}
+
+	t := &Tracer{
+		log:         c.Logger,
+		clk:         c.Clock,
+		serviceName: c.ServiceName,
+		sampleRatio: 1,
+		fileConfig:  c.FileConfig,
+		spans:       make(chan SpanData, defaultQueueSize),
+	}
+	if t.clk == nil {
+		t.clk = clock.New()
+	}
+
+	if fc := c.FileConfig; fc != nil {
+		if fc.SampleRatio != nil {
+			if *fc.SampleRatio < 0 || *fc.SampleRatio > 1 {
+				return nil, fmt.Errorf("tracing sample_ratio must be between 0 and 1; got %v", *fc.SampleRatio)
+			}
+			t.sampleRatio = *fc.SampleRatio
+		}
+		if fc.OTLP != nil {
+			if fc.OTLP.Endpoint == "" {
+				return nil, errors.New("tracing OTLP endpoint must be configured")
+			}
+			t.exporters = append(t.exporters, NewOTLPExporter(fc.OTLP.Endpoint, fc.OTLP.Headers))
+		}
+		if fc.File != nil {
+			if fc.File.Path == "" {
+				return nil, errors.New("tracing file path must be configured")
+			}
+			exporter, err := NewFileExporter(fc.File.Path)
+			if err != nil {
+				return nil, err
+			}
+			t.exporters = append(t.exporters, exporter)
+		}
+	}
+	t.exporters = append(t.exporters, c.Exporters...)
+
+	return t, nil
+}
+
+// NewFromEnv creates a tracer from the environment passed by SPIRE to an
+// external plugin process. The tracer is disabled if no OTLP endpoint was
+// passed.
+func NewFromEnv(log logrus.FieldLogger) (*Tracer, error) {
+	endpoint := os.Getenv(EnvOTLPEndpoint)
+	if endpoint == "" {
+		return New(&Config{Logger: log})
+	}
+
+	fc := &telemetry.TracingConfig{
+		OTLP: &telemetry.OTLPConfig{
+			Endpoint: endpoint,
+			Headers:  parseHeaders(os.Getenv(EnvOTLPHeaders)),
+		},
+	}
+	if v := os.Getenv(EnvSampleRatio); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: %v", EnvSampleRatio, v, err)
+		}
+		fc.SampleRatio = &ratio
+	}
+
+	return New(&Config{
+		FileConfig:  fc,
+		Logger:      log,
+		ServiceName: os.Getenv(EnvServiceName),
+	})
+}
+
+// Enabled returns true if spans are recorded and exported
+func (t *Tracer) Enabled() bool {
+	return t != nil && len(t.exporters) > 0
+}
+
+// PluginEnv returns the environment variables that configure tracing in an
+// external plugin process named pluginName. Only the OTLP exporter is
+// passed on; the file exporter is not shared between processes.
+func (t *Tracer) PluginEnv(pluginName string) []string {
+	if !t.Enabled() || t.fileConfig == nil || t.fileConfig.OTLP == nil {
+		return nil
+	}
+
+	env := []string{
+		EnvOTLPEndpoint + "=" + t.fileConfig.OTLP.Endpoint,
+		EnvServiceName + "=" + t.serviceName + "_plugin_" + pluginName,
+		EnvSampleRatio + "=" + strconv.FormatFloat(t.sampleRatio, 'g', -1, 64),
+	}
+	if headers := formatHeaders(t.fileConfig.OTLP.Headers); headers != "" {
+		env = append(env, EnvOTLPHeaders+"="+headers)
+	}
+	return env
+}
+
+// Run exports ended spans in batches until the context is canceled, at which
+// point the remaining spans are flushed, within a bounded time, and the
+// exporters are closed.
+func (t *Tracer) Run(ctx context.Context) error {
+	if !t.Enabled() {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := t.clk.Ticker(defaultFlushInterval)
+	defer ticker.Stop()
+
+	var batch []SpanData
+	for {
+		select {
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) >= defaultBatchSize {
+				t.export(exportTimeout, batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				t.export(exportTimeout, batch)
+				batch = nil
+			}
+		case <-ctx.Done():
+			batch = append(batch, t.drain()...)
+			if len(batch) > 0 {
+				t.export(shutdownTimeout, batch)
+			}
+			t.close()
+			return nil
+		}
+	}
+}
+
+func (t *Tracer) drain() (spans []SpanData) {
+	for {
+		select {
+		case span := <-t.spans:
+			spans = append(spans, span)
+		default:
+			return spans
+		}
+	}
+}
+
+func (t *Tracer) export(timeout time.Duration, spans []SpanData) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for _, exporter := range t.exporters {
+		if err := exporter.ExportSpans(ctx, t.serviceName, spans); err != nil {
+			t.log.WithError(err).Warn("Failed to export spans")
+		}
+	}
+}
+
+func (t *Tracer) close() {
+	for _, exporter := range t.exporters {
+		if err := exporter.Close(); err != nil {
+			t.log.WithError(err).Warn("Failed to close span exporter")
+		}
+	}
+}
+
+func (t *Tracer) enqueue(span SpanData) {
+	select {
+	case t.spans <- span:
+	default:
+		t.log.Debug("Span queue is full; dropping span")
+	}
+}
+
+// shouldSample deterministically samples root traces based on the trace ID
+// so that all processes make the same decision for a given ratio.
+func (t *Tracer) shouldSample(id TraceID) bool {
+	switch {
+	case t.sampleRatio >= 1:
+		return true
+	case t.sampleRatio <= 0:
+		return false
+	}
+	bound := uint64(t.sampleRatio * (1 << 63))
+	return binary.BigEndian.Uint64(id[8:])>>1 < bound
+}
+
+func formatHeaders(headers map[string]string) string {
+	var pairs []string
+	for k, v := range headers {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func parseHeaders(s string) map[string]string {
+	if s == "" {
+		return nil
+	}
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return headers
+}
@@ -0,0 +1,324 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	sc := SpanContext{
+		TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+	value := sc.Traceparent()
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", value)
+
+	parsed, err := ParseTraceparent(value)
+	require.NoError(t, err)
+	assert.Equal(t, sc, parsed)
+
+	sc.Sampled = false
+	parsed, err = ParseTraceparent(sc.Traceparent())
+	require.NoError(t, err)
+	assert.Equal(t, sc, parsed)
+}
+
+func TestParseTraceparentFailures(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "empty", value: "", err: "malformed traceparent"},
+		{name: "extra fields", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-00", err: "malformed traceparent"},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", err: `unsupported traceparent version "ff"`},
+		{name: "short trace id", value: "00-4bf92f3577b34da6-00f067aa0ba902b7-01", err: "invalid trace id: expected 32 hex characters"},
+		{name: "uppercase span id", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00F067AA0BA902B7-01", err: "invalid span id: hex must be lowercase"},
+		{name: "zero trace id", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", err: "trace and span ids must not be zero"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTraceparent(tt.value)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestStartSpanDisabled(t *testing.T) {
+	var tracer *Tracer
+
+	ctx := context.Background()
+	spanCtx, span := tracer.StartSpan(ctx, "op")
+	assert.Nil(t, span)
+	assert.Equal(t, ctx, spanCtx)
+
+	// Nil spans are safe to use
+	span.SetAttribute("key", "value")
+	span.RecordError(errors.New("oh no"))
+	span.End()
+}
+
+func TestStartSpan(t *testing.T) {
+	tracer, exporter, finish := setupTracer(t, nil)
+	defer finish()
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("other", "value"))
+	ctx, parent := tracer.StartSpan(ctx, "parent", Attr("a", "b"))
+	require.NotNil(t, parent)
+	_, child := tracer.StartSpan(ctx, "child")
+	require.NotNil(t, child)
+
+	// The span context is propagated on outgoing calls and existing
+	// metadata is preserved.
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, []string{parent.SpanContext().Traceparent()}, md.Get(TraceparentHeader))
+	assert.Equal(t, []string{"value"}, md.Get("other"))
+
+	child.RecordError(errors.New("oh no"))
+	child.End()
+	parent.SetAttribute("a", "c")
+	parent.End()
+	parent.End()
+
+	finish()
+	spans := exporter.Spans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, SpanKindInternal, spans[0].Kind)
+	assert.Equal(t, parent.SpanContext().TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(t, parent.SpanContext().SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, StatusError, spans[0].StatusCode)
+	assert.Equal(t, "oh no", spans[0].StatusMessage)
+
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.False(t, spans[1].ParentSpanID.IsValid())
+	assert.Equal(t, []Attribute{Attr("a", "c")}, spans[1].Attributes)
+}
+
+func TestStartSpanRemoteParent(t *testing.T) {
+	tracer, exporter, finish := setupTracer(t, nil)
+	defer finish()
+
+	remote := SpanContext{
+		TraceID: newTraceID(),
+		SpanID:  newSpanID(),
+	}
+	ctx := ContextWithRemoteParent(context.Background(), remote)
+
+	// The unsampled decision of the remote parent is honored
+	_, span := tracer.StartSpan(ctx, "op")
+	require.NotNil(t, span)
+	assert.Equal(t, remote.TraceID, span.SpanContext().TraceID)
+	assert.False(t, span.SpanContext().Sampled)
+	span.End()
+
+	finish()
+	assert.Empty(t, exporter.Spans())
+}
+
+func TestSampleRatio(t *testing.T) {
+	never := 0.0
+	tracer, exporter, finish := setupTracer(t, &telemetry.TracingConfig{
+		SampleRatio: &never,
+	})
+	defer finish()
+
+	_, span := tracer.StartSpan(context.Background(), "op")
+	require.NotNil(t, span)
+	assert.False(t, span.SpanContext().Sampled)
+	span.End()
+
+	finish()
+	assert.Empty(t, exporter.Spans())
+}
+
+func TestNewFailures(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	tooBig := 1.5
+
+	_, err := New(&Config{})
+	require.EqualError(t, err, "logger must be configured")
+
+	_, err = New(&Config{Logger: log, FileConfig: &telemetry.TracingConfig{SampleRatio: &tooBig}})
+	require.EqualError(t, err, "tracing sample_ratio must be between 0 and 1; got 1.5")
+
+	_, err = New(&Config{Logger: log, FileConfig: &telemetry.TracingConfig{OTLP: &telemetry.OTLPConfig{}}})
+	require.EqualError(t, err, "tracing OTLP endpoint must be configured")
+
+	_, err = New(&Config{Logger: log, FileConfig: &telemetry.TracingConfig{File: &telemetry.TracingFileConfig{}}})
+	require.EqualError(t, err, "tracing file path must be configured")
+}
+
+func TestPluginEnv(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ratio := 0.25
+
+	tracer, err := New(&Config{Logger: log})
+	require.NoError(t, err)
+	assert.False(t, tracer.Enabled())
+	assert.Empty(t, tracer.PluginEnv("plugin"))
+
+	tracer, err = New(&Config{
+		Logger:      log,
+		ServiceName: "spire_server",
+		FileConfig: &telemetry.TracingConfig{
+			OTLP: &telemetry.OTLPConfig{
+				Endpoint: "http://collector:4318",
+				Headers:  map[string]string{"b": "2", "a": "1"},
+			},
+			SampleRatio: &ratio,
+		},
+	})
+	require.NoError(t, err)
+	env := tracer.PluginEnv("disk")
+	assert.Equal(t, []string{
+		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT=http://collector:4318",
+		"OTEL_SERVICE_NAME=spire_server_plugin_disk",
+		"OTEL_TRACES_SAMPLER_ARG=0.25",
+		"OTEL_EXPORTER_OTLP_TRACES_HEADERS=a=1,b=2",
+	}, env)
+
+	// The plugin side reconstructs the same configuration
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		restore := setenv(t, parts[0], parts[1])
+		defer restore()
+	}
+	pluginTracer, err := NewFromEnv(log)
+	require.NoError(t, err)
+	assert.True(t, pluginTracer.Enabled())
+	assert.Equal(t, "spire_server_plugin_disk", pluginTracer.serviceName)
+	assert.Equal(t, 0.25, pluginTracer.sampleRatio)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, pluginTracer.fileConfig.OTLP.Headers)
+}
+
+func setenv(t *testing.T, key, value string) (restore func()) {
+	old, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestRunBoundsShutdownFlush(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	exporter := &blockingExporter{}
+	tracer, err := New(&Config{
+		Logger:    log,
+		Exporters: []Exporter{exporter},
+		Clock:     clock.NewMock(t),
+	})
+	require.NoError(t, err)
+
+	_, span := tracer.StartSpan(context.Background(), "span")
+	span.End()
+
+	// The pending span is flushed when the tracer is stopped, but the
+	// flush gives up after shutdownTimeout if the exporter is stuck.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	require.NoError(t, tracer.Run(ctx))
+	require.True(t, time.Since(start) < exportTimeout, "shutdown flush took too long")
+	require.Equal(t, 1, exporter.Calls())
+}
+
+// setupTracer runs a tracer that exports to an in-memory exporter. The
+// returned function stops the tracer, flushing any pending spans to the
+// exporter. It is safe to call more than once.
+func setupTracer(t *testing.T, fc *telemetry.TracingConfig) (*Tracer, *fakeExporter, func()) {
+	log, _ := test.NewNullLogger()
+	exporter := new(fakeExporter)
+	tracer, err := New(&Config{
+		FileConfig:  fc,
+		Logger:      log,
+		ServiceName: "test",
+		Exporters:   []Exporter{exporter},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, tracer.Run(ctx))
+	}()
+
+	var once sync.Once
+	finish := func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+	return tracer, exporter, finish
+}
+
+type fakeExporter struct {
+	mu     sync.Mutex
+	spans  []SpanData
+	closed bool
+}
+
+func (e *fakeExporter) ExportSpans(ctx context.Context, serviceName string, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *fakeExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	return nil
+}
+
+func (e *fakeExporter) Spans() []SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]SpanData(nil), e.spans...)
+}
+
+// blockingExporter blocks every export until the context is done
+type blockingExporter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (e *blockingExporter) ExportSpans(ctx context.Context, serviceName string, spans []SpanData) error {
+	e.mu.Lock()
+	e.calls++
+	e.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *blockingExporter) Close() error {
+	return nil
+}
+
+func (e *blockingExporter) Calls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
@@ -20,3 +20,22 @@ func SignalListener(ctx context.Context, cancel func()) {
 		}
 	}()
 }
+
+// HangupListener calls reload every time the process receives SIGHUP, until
+// the context is done.
+func HangupListener(ctx context.Context, reload func()) {
+	go func() {
+		signalCh := make(chan os.Signal, 1)
+		signal.Notify(signalCh, syscall.SIGHUP)
+		defer signal.Stop(signalCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signalCh:
+				reload()
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+package x509util
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+// ParsePathPrefixesOID parses the dotted OID (e.g. "1.2.3.4") of the
+// non-critical extension used to restrict the SPIFFE ID paths a CA
+// certificate is allowed to sign for. X.509 name constraints can only
+// constrain the host part of a URI SAN, so SPIFFE ID path constraints are
+// carried in this extension. There is no registered OID for it, so it has to
+// be allocated under an arc the deployment controls and configured on every
+// SPIRE server and agent. It is only enforced by SPIRE when it verifies
+// X509-SVID chains (see VerifyPathPrefixes); other verifiers ignore it, since
+// it is not critical.
+func ParsePathPrefixesOID(s string) (asn1.ObjectIdentifier, error) {
+	var oid asn1.ObjectIdentifier
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid path prefixes OID %q", s)
+		}
+		oid = append(oid, n)
+	}
+	// The first arc is 0, 1 or 2 and, unless it is 2, the second arc is
+	// lower than 40; otherwise the OID cannot be DER encoded.
+	if len(oid) < 2 || oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, fmt.Errorf("invalid path prefixes OID %q", s)
+	}
+	return oid, nil
+}
+
+// NewPathPrefixesExtension returns the extension with the given OID carrying
+// the given SPIFFE ID path prefixes.
+func NewPathPrefixesExtension(oid asn1.ObjectIdentifier, prefixes []string) (pkix.Extension, error) {
+	if len(oid) == 0 {
+		return pkix.Extension{}, errors.New("path prefixes OID is not configured")
+	}
+	value, err := asn1.Marshal(prefixes)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:    oid,
+		Value: value,
+	}, nil
+}
+
+// PathPrefixesFromCertificate returns the SPIFFE ID path prefixes the
+// certificate is constrained to by the extension with the given OID, or nil
+// if it is not constrained. Certificates are never constrained when the OID
+// is not configured.
+func PathPrefixesFromCertificate(oid asn1.ObjectIdentifier, cert *x509.Certificate) ([]string, error) {
+	if len(oid) == 0 {
+		return nil, nil
+	}
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oid) {
+			continue
+		}
+		var prefixes []string
+		rest, err := asn1.Unmarshal(ext.Value, &prefixes)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) > 0 {
+			return nil, errors.New("trailing data after path prefixes extension")
+		}
+		return prefixes, nil
+	}
+	return nil, nil
+}
+
+// VerifyPathPrefixes verifies that the SPIFFE IDs of the leaf certificate of
+// the chain are permitted by the path prefixes of every CA certificate in the
+// chain, as carried by the extension with the given OID. The chain is ordered
+// from the leaf to the root.
+func VerifyPathPrefixes(oid asn1.ObjectIdentifier, chain []*x509.Certificate) error {
+	if len(chain) == 0 {
+		return errors.New("empty certificate chain")
+	}
+	leaf := chain[0]
+	for _, cert := range chain[1:] {
+		prefixes, err := PathPrefixesFromCertificate(oid, cert)
+		if err != nil {
+			return fmt.Errorf("unable to parse path prefixes of %q: %v", cert.Subject, err)
+		}
+		for _, uri := range leaf.URIs {
+			if !idutil.PathHasAnyPrefix(uri.Path, prefixes) {
+				return fmt.Errorf("%q is not permitted by the path prefixes of %q", uri, cert.Subject)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyChainsPathPrefixes verifies the path prefixes of the given verified
+// chains, succeeding if at least one of them is valid.
+func VerifyChainsPathPrefixes(oid asn1.ObjectIdentifier, chains [][]*x509.Certificate) error {
+	var err error
+	for _, chain := range chains {
+		if err = VerifyPathPrefixes(oid, chain); err == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package x509util
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPathPrefixesOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+
+func TestParsePathPrefixesOID(t *testing.T) {
+	oid, err := ParsePathPrefixesOID("1.3.6.1.4.1.99999.1")
+	require.NoError(t, err)
+	assert.Equal(t, testPathPrefixesOID, oid)
+
+	for _, s := range []string{"", "1", "1.", "1.a", "1.-2", "3.1", "1.40"} {
+		_, err := ParsePathPrefixesOID(s)
+		assert.EqualError(t, err, fmt.Sprintf("invalid path prefixes OID %q", s))
+	}
+}
+
+func TestPathPrefixesExtension(t *testing.T) {
+	prefixes, err := PathPrefixesFromCertificate(testPathPrefixesOID, &x509.Certificate{})
+	require.NoError(t, err)
+	assert.Nil(t, prefixes)
+
+	_, err = NewPathPrefixesExtension(nil, []string{"/ns/foo"})
+	assert.EqualError(t, err, "path prefixes OID is not configured")
+
+	ext, err := NewPathPrefixesExtension(testPathPrefixesOID, []string{"/ns/foo", "/ns/bar"})
+	require.NoError(t, err)
+	assert.False(t, ext.Critical)
+
+	prefixes, err = PathPrefixesFromCertificate(testPathPrefixesOID, &x509.Certificate{
+		Extensions: []pkix.Extension{ext},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/ns/foo", "/ns/bar"}, prefixes)
+
+	// the extension is ignored when the OID is not configured
+	prefixes, err = PathPrefixesFromCertificate(nil, &x509.Certificate{
+		Extensions: []pkix.Extension{ext},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, prefixes)
+
+	ext.Value = append(ext.Value, 0)
+	_, err = PathPrefixesFromCertificate(testPathPrefixesOID, &x509.Certificate{
+		Extensions: []pkix.Extension{ext},
+	})
+	assert.EqualError(t, err, "trailing data after path prefixes extension")
+}
+
+func TestVerifyPathPrefixes(t *testing.T) {
+	ext, err := NewPathPrefixesExtension(testPathPrefixesOID, []string{"/ns/foo"})
+	require.NoError(t, err)
+
+	leaf := func(path string) *x509.Certificate {
+		return &x509.Certificate{
+			URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: path}},
+		}
+	}
+	constrained := &x509.Certificate{
+		Subject:    pkix.Name{CommonName: "DOWNSTREAM"},
+		Extensions: []pkix.Extension{ext},
+	}
+	root := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "ROOT"},
+	}
+
+	assert.EqualError(t, VerifyPathPrefixes(testPathPrefixesOID, nil), "empty certificate chain")
+	assert.NoError(t, VerifyPathPrefixes(testPathPrefixesOID, []*x509.Certificate{leaf("/other"), root}))
+	assert.NoError(t, VerifyPathPrefixes(testPathPrefixesOID, []*x509.Certificate{leaf("/ns/foo/bar"), constrained, root}))
+	assert.EqualError(t, VerifyPathPrefixes(testPathPrefixesOID, []*x509.Certificate{leaf("/ns/foobar"), constrained, root}),
+		`"spiffe://example.org/ns/foobar" is not permitted by the path prefixes of "CN=DOWNSTREAM"`)
+
+	assert.NoError(t, VerifyChainsPathPrefixes(testPathPrefixesOID, [][]*x509.Certificate{
+		{leaf("/other"), constrained, root},
+		{leaf("/other"), root},
+	}))
+	assert.Error(t, VerifyChainsPathPrefixes(testPathPrefixesOID, [][]*x509.Certificate{
+		{leaf("/other"), constrained, root},
+	}))
+}
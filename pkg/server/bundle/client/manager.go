@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
-	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 )
 
@@ -17,6 +20,20 @@ const (
 	// bundle. It is important to try more than once within a refresh hint
 	// period so we can be resilient to temporary downtime or failures.
 	attemptsPerRefreshHint = 4
+
+	// relationshipsPollInterval is how often the federation relationships
+	// stored in the datastore are reloaded.
+	relationshipsPollInterval = 10 * time.Second
+)
+
+const (
+	// BundleEndpointProfileHTTPSSPIFFE is the bundle endpoint profile that
+	// authenticates the endpoint using SPIFFE authentication.
+	BundleEndpointProfileHTTPSSPIFFE = "https_spiffe"
+
+	// BundleEndpointProfileHTTPSWeb is the bundle endpoint profile that
+	// authenticates the endpoint using Web PKI.
+	BundleEndpointProfileHTTPSWeb = "https_web"
 )
 
 type TrustDomainConfig struct {
@@ -33,19 +50,63 @@ type TrustDomainConfig struct {
 }
 
 type ManagerConfig struct {
-	Log          logrus.FieldLogger
-	DataStore    datastore.DataStore
-	Clock        clock.Clock
+	Log       logrus.FieldLogger
+	DataStore datastore.DataStore
+	Clock     clock.Clock
+
+	// TrustDomains are the federated trust domains configured statically.
+	// They take precedence over federation relationships with the same trust
+	// domain stored in the datastore.
 	TrustDomains map[string]TrustDomainConfig
 
 	// newBundleUpdater is a test hook to inject updater behavior
 	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
 }
 
+// SyncStatus is the synchronization status of a federated bundle fetched from
+// a bundle endpoint
+type SyncStatus struct {
+	// TrustDomain is the federated trust domain (i.e. domain.test)
+	TrustDomain string
+
+	// EndpointAddress is the bundle endpoint for the trust domain.
+	EndpointAddress string
+
+	// LastSuccess is the time of the last successful synchronization.
+	LastSuccess time.Time
+
+	// LastError is the error returned by the last failed synchronization.
+	LastError string
+
+	// LastErrorAt is the time of the last failed synchronization.
+	LastErrorAt time.Time
+
+	// NextRefresh is the time of the next scheduled synchronization.
+	NextRefresh time.Time
+
+	// SequenceNumber is the sequence number of the current bundle.
+	SequenceNumber uint64
+}
+
 type Manager struct {
-	log      logrus.FieldLogger
-	clock    clock.Clock
-	updaters map[string]BundleUpdater
+	log              logrus.FieldLogger
+	clock            clock.Clock
+	ds               datastore.DataStore
+	trustDomains     map[string]TrustDomainConfig
+	newBundleUpdater func(BundleUpdaterConfig) BundleUpdater
+
+	// updaters and shadowed are only accessed by the Run goroutine
+	updaters map[string]*runningUpdater
+	shadowed map[string]bool
+
+	mu       sync.RWMutex
+	statuses map[string]*SyncStatus
+}
+
+type runningUpdater struct {
+	config TrustDomainConfig
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 func NewManager(config ManagerConfig) *Manager {
@@ -56,34 +117,161 @@ func NewManager(config ManagerConfig) *Manager {
 		config.newBundleUpdater = NewBundleUpdater
 	}
 
-	updaters := make(map[string]BundleUpdater)
-	for trustDomain, trustDomainConfig := range config.TrustDomains {
-		updaters[trustDomain] = config.newBundleUpdater(BundleUpdaterConfig{
-			TrustDomainConfig: trustDomainConfig,
-			TrustDomain:       trustDomain,
-			DataStore:         config.DataStore,
-		})
+	return &Manager{
+		log:              config.Log,
+		clock:            config.Clock,
+		ds:               config.DataStore,
+		trustDomains:     config.TrustDomains,
+		newBundleUpdater: config.newBundleUpdater,
+		updaters:         make(map[string]*runningUpdater),
+		shadowed:         make(map[string]bool),
+		statuses:         make(map[string]*SyncStatus),
 	}
+}
 
-	return &Manager{
-		log:      config.Log,
-		clock:    config.Clock,
-		updaters: updaters,
+// SyncStatus returns the synchronization status of each federated trust
+// domain, sorted by trust domain.
+func (m *Manager) SyncStatus() []SyncStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make([]SyncStatus, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		statuses = append(statuses, *status)
 	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].TrustDomain < statuses[j].TrustDomain
+	})
+	return statuses
 }
 
+// Run polls the bundle endpoint of each federated trust domain. Federation
+// relationships stored in the datastore are reloaded periodically so that
+// updaters are started, restarted or stopped as relationships change.
 func (m *Manager) Run(ctx context.Context) error {
-	var tasks []func(context.Context) error
+	defer m.stopUpdaters()
+
+	for {
+		m.reconcileUpdaters(ctx)
+
+		select {
+		case <-m.clock.After(relationshipsPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// reconcileUpdaters makes sure an updater is running with the current
+// configuration for each statically configured trust domain and each
+// federation relationship in the datastore.
+func (m *Manager) reconcileUpdaters(ctx context.Context) {
+	trustDomains, err := m.loadTrustDomains(ctx)
+	if err != nil {
+		m.log.WithError(err).Error("Failed to load federation relationships")
+		// Keep running the updaters for the relationships already known
+		for trustDomain, updater := range m.updaters {
+			if _, ok := trustDomains[trustDomain]; !ok {
+				trustDomains[trustDomain] = updater.config
+			}
+		}
+	}
+
+	// The bundles of removed relationships are left in the datastore; they
+	// are deleted along with the relationship only when requested.
 	for trustDomain, updater := range m.updaters {
-		// alias the loop variables that are used by the closure
-		trustDomain := trustDomain
-		updater := updater
-		tasks = append(tasks, func(ctx context.Context) error {
-			return m.runUpdater(ctx, trustDomain, updater)
-		})
+		if config, ok := trustDomains[trustDomain]; !ok || config != updater.config {
+			m.log.WithField("trust_domain", trustDomain).Info("Stopping bundle updater")
+			m.stopUpdater(trustDomain)
+		}
+	}
+
+	for trustDomain, config := range trustDomains {
+		if _, ok := m.updaters[trustDomain]; !ok {
+			m.startUpdater(ctx, trustDomain, config)
+		}
 	}
+}
+
+// loadTrustDomains returns the statically configured trust domains along with
+// the federation relationships stored in the datastore. The static
+// trust domains are always returned, even if loading the relationships fails.
+func (m *Manager) loadTrustDomains(ctx context.Context) (map[string]TrustDomainConfig, error) {
+	trustDomains := make(map[string]TrustDomainConfig, len(m.trustDomains))
+	for trustDomain, config := range m.trustDomains {
+		trustDomains[trustDomain] = config
+	}
+
+	resp, err := m.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	if err != nil {
+		return trustDomains, err
+	}
+
+	shadowed := make(map[string]bool)
+	for _, relationship := range resp.Relationships {
+		trustDomain, config, err := TrustDomainConfigFromRelationship(relationship)
+		if err != nil {
+			m.log.WithError(err).WithField("trust_domain_id", relationship.TrustDomainId).Error("Ignoring invalid federation relationship")
+			continue
+		}
+		if _, ok := m.trustDomains[trustDomain]; ok {
+			if !m.shadowed[trustDomain] {
+				m.log.WithField("trust_domain", trustDomain).Warn("Ignoring federation relationship for a trust domain configured in federates_with")
+			}
+			shadowed[trustDomain] = true
+			continue
+		}
+		trustDomains[trustDomain] = config
+	}
+	m.shadowed = shadowed
+
+	return trustDomains, nil
+}
+
+func (m *Manager) startUpdater(ctx context.Context, trustDomain string, config TrustDomainConfig) {
+	updater := m.newBundleUpdater(BundleUpdaterConfig{
+		TrustDomainConfig: config,
+		TrustDomain:       trustDomain,
+		DataStore:         m.ds,
+		Clock:             m.clock,
+	})
+
+	m.mu.Lock()
+	m.statuses[trustDomain] = &SyncStatus{
+		TrustDomain:     trustDomain,
+		EndpointAddress: config.EndpointAddress,
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	running := &runningUpdater{
+		config: config,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	m.updaters[trustDomain] = running
+
+	go func() {
+		defer close(running.done)
+		_ = m.runUpdater(ctx, trustDomain, updater)
+	}()
+}
+
+func (m *Manager) stopUpdater(trustDomain string) {
+	running := m.updaters[trustDomain]
+	running.cancel()
+	<-running.done
+	delete(m.updaters, trustDomain)
+
+	m.mu.Lock()
+	delete(m.statuses, trustDomain)
+	m.mu.Unlock()
+}
 
-	return util.RunTasks(ctx, tasks...)
+func (m *Manager) stopUpdaters() {
+	for trustDomain := range m.updaters {
+		m.stopUpdater(trustDomain)
+	}
 }
 
 func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater BundleUpdater) error {
@@ -96,11 +284,14 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater Bu
 			log.WithError(err).Error("Error updating bundle")
 		}
 
+		var currentBundle *bundleutil.Bundle
 		switch {
 		case endpointBundle != nil:
 			log.Info("Bundle refreshed")
+			currentBundle = endpointBundle
 			nextRefresh = calculateNextUpdate(endpointBundle)
 		case localBundle != nil:
+			currentBundle = localBundle
 			nextRefresh = calculateNextUpdate(localBundle)
 		default:
 			// We have no bundle to use to calculate the refresh hint. Since
@@ -112,6 +303,8 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater Bu
 			nextRefresh = bundleutil.MinimumRefreshHint
 		}
 
+		m.updateSyncStatus(trustDomain, currentBundle, err, nextRefresh)
+
 		log.WithFields(logrus.Fields{
 			"at": m.clock.Now().Add(nextRefresh).UTC().Format(time.RFC3339),
 		}).Debug("Scheduling next bundle refresh")
@@ -126,6 +319,73 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain string, updater Bu
 	}
 }
 
+func (m *Manager) updateSyncStatus(trustDomain string, currentBundle *bundleutil.Bundle, err error, nextRefresh time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status, ok := m.statuses[trustDomain]
+	if !ok {
+		return
+	}
+
+	now := m.clock.Now()
+	if err != nil {
+		status.LastError = err.Error()
+		status.LastErrorAt = now
+	} else {
+		status.LastSuccess = now
+	}
+	if currentBundle != nil {
+		status.SequenceNumber = currentBundle.SequenceNumber()
+	}
+	status.NextRefresh = now.Add(nextRefresh)
+}
+
 func calculateNextUpdate(b *bundleutil.Bundle) time.Duration {
 	return bundleutil.CalculateRefreshHint(b) / attemptsPerRefreshHint
 }
+
+// BundleFreshness is reported by the federated bundle health check for each
+// federated trust domain.
+type BundleFreshness struct {
+	TrustDomain     string    `json:"trust_domain"`
+	LastSuccess     time.Time `json:"last_success,omitempty"`
+	AgeSeconds      int64     `json:"age_seconds,omitempty"`
+	LastError       string    `json:"last_error,omitempty"`
+	NextRefresh     time.Time `json:"next_refresh,omitempty"`
+	SequenceNumber  uint64    `json:"sequence_number,omitempty"`
+	EndpointAddress string    `json:"endpoint_address"`
+}
+
+// CheckFederatedBundles reports how long ago the bundle of each federated
+// trust domain was last refreshed. The check is degraded if the most recent
+// refresh of any bundle failed.
+func (m *Manager) CheckFederatedBundles() (interface{}, error) {
+	now := m.clock.Now()
+
+	var stale []string
+	statuses := m.SyncStatus()
+	details := make([]BundleFreshness, 0, len(statuses))
+	for _, status := range statuses {
+		freshness := BundleFreshness{
+			TrustDomain:     status.TrustDomain,
+			LastSuccess:     status.LastSuccess,
+			NextRefresh:     status.NextRefresh,
+			SequenceNumber:  status.SequenceNumber,
+			EndpointAddress: status.EndpointAddress,
+		}
+		if !status.LastSuccess.IsZero() {
+			freshness.AgeSeconds = int64(now.Sub(status.LastSuccess) / time.Second)
+		}
+		if status.LastError != "" && status.LastErrorAt.After(status.LastSuccess) {
+			freshness.LastError = status.LastError
+			stale = append(stale, status.TrustDomain)
+		}
+		details = append(details, freshness)
+	}
+
+	if len(stale) > 0 {
+		return details, health.Degraded(fmt.Errorf("failed to refresh federated bundles: %q", stale))
+	}
+	return details, nil
+}
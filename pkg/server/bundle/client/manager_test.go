@@ -3,12 +3,16 @@ package client
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/stretchr/testify/require"
@@ -23,12 +27,14 @@ func TestManager(t *testing.T) {
 	localBundle.SetRefreshHint(time.Hour)
 	endpointBundle := bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "endpoint"))
 	endpointBundle.SetRefreshHint(time.Hour * 2)
+	endpointBundle.SetSequenceNumber(3)
 
 	testCases := []struct {
 		name           string
 		localBundle    *bundleutil.Bundle
 		endpointBundle *bundleutil.Bundle
 		nextRefresh    time.Duration
+		sequenceNumber uint64
 	}{
 		{
 			name:        "update failed to obtain local bundle",
@@ -44,6 +50,7 @@ func TestManager(t *testing.T) {
 			localBundle:    localBundle,
 			endpointBundle: endpointBundle,
 			nextRefresh:    calculateNextUpdate(endpointBundle),
+			sequenceNumber: 3,
 		},
 	}
 
@@ -54,12 +61,36 @@ func TestManager(t *testing.T) {
 
 			updater := newFakeBundleUpdater(testCase.localBundle, testCase.endpointBundle)
 
-			done := startManager(t, clock, updater)
+			manager, done := startManager(t, clock, updater)
 			defer done()
 
 			// wait for the initial refresh
 			waitForRefresh(t, clock, testCase.nextRefresh)
 			require.Equal(t, 1, updater.UpdateCount())
+			require.Equal(t, []SyncStatus{
+				{
+					TrustDomain:     "domain.test",
+					EndpointAddress: "ENDPOINT_ADDRESS",
+					LastError:       "UNUSED",
+					LastErrorAt:     clock.Now(),
+					NextRefresh:     clock.Now().Add(testCase.nextRefresh),
+					SequenceNumber:  testCase.sequenceNumber,
+				},
+			}, manager.SyncStatus())
+
+			// the failed refresh is reported by the health check
+			details, err := manager.CheckFederatedBundles()
+			require.EqualError(t, err, `failed to refresh federated bundles: ["domain.test"]`)
+			require.True(t, health.IsDegraded(err))
+			require.Equal(t, []BundleFreshness{
+				{
+					TrustDomain:     "domain.test",
+					LastError:       "UNUSED",
+					NextRefresh:     clock.Now().Add(testCase.nextRefresh),
+					SequenceNumber:  testCase.sequenceNumber,
+					EndpointAddress: "ENDPOINT_ADDRESS",
+				},
+			}, details)
 
 			// advance time and make sure another refresh happens
 			clock.Add(testCase.nextRefresh + time.Millisecond)
@@ -69,7 +100,81 @@ func TestManager(t *testing.T) {
 	}
 }
 
-func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func() {
+func TestManagerReloadsFederationRelationships(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New()
+	clock := clock.NewMock(t)
+
+	createRelationship(t, ds, &common.FederationRelationship{
+		TrustDomainId:         "spiffe://domain.test",
+		BundleEndpointUrl:     "https://domain.test:8443",
+		BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+	})
+	// relationships for statically configured trust domains are ignored
+	createRelationship(t, ds, &common.FederationRelationship{
+		TrustDomainId:         "spiffe://static.test",
+		BundleEndpointUrl:     "https://dynamic.test:8443",
+		BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+	})
+
+	manager := NewManager(ManagerConfig{
+		Log:       log,
+		DataStore: ds,
+		Clock:     clock,
+		TrustDomains: map[string]TrustDomainConfig{
+			"static.test": {EndpointAddress: "static.test:8443"},
+		},
+		newBundleUpdater: func(config BundleUpdaterConfig) BundleUpdater {
+			return newFakeBundleUpdater(nil, nil)
+		},
+	})
+	done := runManager(t, manager)
+	defer done()
+
+	requireEndpointAddresses := func(expected map[string]string) {
+		require.Eventually(t, func() bool {
+			actual := make(map[string]string)
+			for _, status := range manager.SyncStatus() {
+				actual[status.TrustDomain] = status.EndpointAddress
+			}
+			return reflect.DeepEqual(expected, actual)
+		}, time.Minute, time.Millisecond*10)
+	}
+
+	requireEndpointAddresses(map[string]string{
+		"domain.test": "domain.test:8443",
+		"static.test": "static.test:8443",
+	})
+
+	// updated relationships are picked up on the next poll
+	_, err := ds.UpdateFederationRelationship(context.Background(), &datastore.UpdateFederationRelationshipRequest{
+		Relationship: &common.FederationRelationship{
+			TrustDomainId:         "spiffe://domain.test",
+			BundleEndpointUrl:     "https://other.test/bundle",
+			BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+		},
+	})
+	require.NoError(t, err)
+	clock.WaitForAfter(time.Minute, "timed out waiting for relationships poll")
+	clock.Add(relationshipsPollInterval)
+	requireEndpointAddresses(map[string]string{
+		"domain.test": "other.test/bundle",
+		"static.test": "static.test:8443",
+	})
+
+	// deleted relationships stop being polled
+	_, err = ds.DeleteFederationRelationship(context.Background(), &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	require.NoError(t, err)
+	clock.WaitForAfter(time.Minute, "timed out waiting for relationships poll")
+	clock.Add(relationshipsPollInterval)
+	requireEndpointAddresses(map[string]string{
+		"static.test": "static.test:8443",
+	})
+}
+
+func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) (*Manager, func()) {
 	log, _ := test.NewNullLogger()
 	ds := fakedatastore.New()
 
@@ -92,6 +197,10 @@ func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func()
 		},
 	})
 
+	return manager, runManager(t, manager)
+}
+
+func runManager(t *testing.T, manager *Manager) func() {
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
 	go func() {
@@ -114,6 +223,13 @@ func startManager(t *testing.T, clock clock.Clock, updater BundleUpdater) func()
 	}
 }
 
+func createRelationship(t *testing.T, ds datastore.DataStore, relationship *common.FederationRelationship) {
+	_, err := ds.CreateFederationRelationship(context.Background(), &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	require.NoError(t, err)
+}
+
 func waitForRefresh(t *testing.T, clock *clock.Mock, expectedDuration time.Duration) {
 	select {
 	case d := <-clock.TimerCh():
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
+)
+
+// TrustDomainConfigFromRelationship validates the federation relationship and
+// returns the federated trust domain (i.e. domain.test) along with the
+// configuration used to fetch bundles from its bundle endpoint.
+func TrustDomainConfigFromRelationship(relationship *common.FederationRelationship) (string, TrustDomainConfig, error) {
+	if relationship == nil {
+		return "", TrustDomainConfig{}, errs.New("federation relationship is required")
+	}
+
+	trustDomainID, err := idutil.ParseSpiffeID(relationship.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return "", TrustDomainConfig{}, errs.New("invalid trust domain ID: %v", err)
+	}
+	trustDomain := trustDomainID.Host
+
+	endpointAddress, err := endpointAddressFromURL(relationship.BundleEndpointUrl)
+	if err != nil {
+		return "", TrustDomainConfig{}, err
+	}
+
+	config := TrustDomainConfig{
+		EndpointAddress: endpointAddress,
+	}
+	switch relationship.BundleEndpointProfile {
+	case BundleEndpointProfileHTTPSSPIFFE:
+		if relationship.EndpointSpiffeId != "" {
+			if _, err := idutil.ParseSpiffeID(relationship.EndpointSpiffeId, idutil.AllowAnyInTrustDomain(trustDomain)); err != nil {
+				return "", TrustDomainConfig{}, errs.New("invalid endpoint SPIFFE ID: %v", err)
+			}
+		}
+		config.EndpointSpiffeID = relationship.EndpointSpiffeId
+	case BundleEndpointProfileHTTPSWeb:
+		if relationship.EndpointSpiffeId != "" {
+			return "", TrustDomainConfig{}, errs.New("endpoint SPIFFE ID cannot be set with the %q bundle endpoint profile", BundleEndpointProfileHTTPSWeb)
+		}
+		config.UseWebPKI = true
+	default:
+		return "", TrustDomainConfig{}, errs.New("bundle endpoint profile %q is not supported; expected %q or %q",
+			relationship.BundleEndpointProfile, BundleEndpointProfileHTTPSSPIFFE, BundleEndpointProfileHTTPSWeb)
+	}
+
+	return trustDomain, config, nil
+}
+
+// endpointAddressFromURL converts the bundle endpoint URL into the endpoint
+// address used by the client, which always connects over HTTPS.
+func endpointAddressFromURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", errs.New("bundle endpoint URL is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errs.New("invalid bundle endpoint URL: %v", err)
+	}
+	switch {
+	case u.Scheme != "https":
+		return "", errs.New("invalid bundle endpoint URL: scheme must be https")
+	case u.Host == "":
+		return "", errs.New("invalid bundle endpoint URL: host is required")
+	case u.User != nil:
+		return "", errs.New("invalid bundle endpoint URL: user info is not allowed")
+	case u.RawQuery != "" || u.Fragment != "":
+		return "", errs.New("invalid bundle endpoint URL: query and fragment are not allowed")
+	}
+	return strings.TrimPrefix(u.String(), "https://"), nil
+}
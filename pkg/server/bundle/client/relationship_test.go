@@ -0,0 +1,128 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustDomainConfigFromRelationship(t *testing.T) {
+	testCases := []struct {
+		name         string
+		relationship *common.FederationRelationship
+		trustDomain  string
+		config       TrustDomainConfig
+		err          string
+	}{
+		{
+			name: "missing relationship",
+			err:  "federation relationship is required",
+		},
+		{
+			name: "https_spiffe",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://DOMAIN.test",
+				BundleEndpointUrl:     "https://domain.test:8443",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSSPIFFE,
+				EndpointSpiffeId:      "spiffe://domain.test/bundle-endpoint",
+			},
+			trustDomain: "domain.test",
+			config: TrustDomainConfig{
+				EndpointAddress:  "domain.test:8443",
+				EndpointSpiffeID: "spiffe://domain.test/bundle-endpoint",
+			},
+		},
+		{
+			name: "https_web with path",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://example.org/domain.test/bundle",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+			},
+			trustDomain: "domain.test",
+			config: TrustDomainConfig{
+				EndpointAddress: "example.org/domain.test/bundle",
+				UseWebPKI:       true,
+			},
+		},
+		{
+			name: "invalid trust domain ID",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test/workload",
+				BundleEndpointUrl:     "https://domain.test",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+			},
+			err: "invalid trust domain ID: \"spiffe://domain.test/workload\" is not a valid trust domain SPIFFE ID: path is not empty",
+		},
+		{
+			name: "missing bundle endpoint URL",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+			},
+			err: "bundle endpoint URL is required",
+		},
+		{
+			name: "bundle endpoint URL is not https",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "http://domain.test",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+			},
+			err: "invalid bundle endpoint URL: scheme must be https",
+		},
+		{
+			name: "bundle endpoint URL with query",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test/?foo=bar",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+			},
+			err: "invalid bundle endpoint URL: query and fragment are not allowed",
+		},
+		{
+			name: "unsupported profile",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test",
+				BundleEndpointProfile: "https_foo",
+			},
+			err: "bundle endpoint profile \"https_foo\" is not supported; expected \"https_spiffe\" or \"https_web\"",
+		},
+		{
+			name: "endpoint SPIFFE ID with https_web",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSWeb,
+				EndpointSpiffeId:      "spiffe://domain.test/bundle-endpoint",
+			},
+			err: "endpoint SPIFFE ID cannot be set with the \"https_web\" bundle endpoint profile",
+		},
+		{
+			name: "endpoint SPIFFE ID in another trust domain",
+			relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://domain.test",
+				BundleEndpointUrl:     "https://domain.test",
+				BundleEndpointProfile: BundleEndpointProfileHTTPSSPIFFE,
+				EndpointSpiffeId:      "spiffe://other.test/bundle-endpoint",
+			},
+			err: "invalid endpoint SPIFFE ID: \"spiffe://other.test/bundle-endpoint\" does not belong to trust domain \"domain.test\"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			trustDomain, config, err := TrustDomainConfigFromRelationship(testCase.relationship)
+			if testCase.err != "" {
+				require.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.trustDomain, trustDomain)
+			require.Equal(t, testCase.config, config)
+		})
+	}
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/zeebo/errs"
 )
 
+const (
+	// maxBundleHistory is the number of bundles received from the endpoint
+	// that are kept in the datastore for each trust domain.
+	maxBundleHistory = 10
+)
+
 type BundleUpdaterConfig struct {
 	TrustDomainConfig
 
 	TrustDomain string
 	DataStore   datastore.DataStore
+	Clock       clock.Clock
 
 	// newClient is a test hook for injecting client behavior
 	newClient func(ClientConfig) Client
@@ -28,7 +36,10 @@ type BundleUpdater interface {
 	// bundle will always be returned if it was fetched, independent of any
 	// other failures performing the update. The endpoint bundle is ONLY
 	// returned if it can be successfully downloaded, is different from the
-	// local bundle, and is successfully stored.
+	// local bundle, is not older than the local bundle according to the
+	// bundle sequence number, and is successfully stored. A failure to record
+	// the endpoint bundle in the bundle history is returned along with the
+	// endpoint bundle.
 	UpdateBundle(ctx context.Context) (*bundleutil.Bundle, *bundleutil.Bundle, error)
 }
 
@@ -37,6 +48,9 @@ type bundleUpdater struct {
 }
 
 func NewBundleUpdater(config BundleUpdaterConfig) BundleUpdater {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
 	if config.newClient == nil {
 		config.newClient = NewClient
 	}
@@ -62,20 +76,54 @@ func (u *bundleUpdater) UpdateBundle(ctx context.Context) (*bundleutil.Bundle, *
 		return localBundleOrNil, nil, fmt.Errorf("failed to fetch endpoint bundle: %v", err)
 	}
 
-	if localBundleOrNil != nil && endpointBundle.EqualTo(localBundleOrNil) {
-		return localBundleOrNil, nil, nil
+	if localBundleOrNil != nil {
+		if endpointBundle.EqualTo(localBundleOrNil) {
+			return localBundleOrNil, nil, nil
+		}
+		if err := checkSequenceNumber(localBundleOrNil, endpointBundle); err != nil {
+			return localBundleOrNil, nil, err
+		}
 	}
 
+	// The sequence number has already been checked, and must be kept as
+	// served by the endpoint so that it can be compared on the next update.
 	_, err = u.c.DataStore.SetBundle(ctx, &datastore.SetBundleRequest{
-		Bundle: endpointBundle.Proto(),
+		Bundle:                 endpointBundle.Proto(),
+		PreserveSequenceNumber: true,
 	})
 	if err != nil {
 		return localBundleOrNil, nil, fmt.Errorf("failed to store endpoint bundle: %v", err)
 	}
 
+	_, err = u.c.DataStore.AppendBundleHistory(ctx, &datastore.AppendBundleHistoryRequest{
+		Entry: &datastore.BundleHistoryEntry{
+			Bundle:     endpointBundle.Proto(),
+			ReceivedAt: u.c.Clock.Now().Unix(),
+		},
+		MaxEntries: maxBundleHistory,
+	})
+	if err != nil {
+		return localBundleOrNil, endpointBundle, fmt.Errorf("failed to record endpoint bundle history: %v", err)
+	}
+
 	return localBundleOrNil, endpointBundle, nil
 }
 
+// checkSequenceNumber returns an error if the endpoint bundle, which is known
+// to differ from the local bundle, is not newer than the local bundle. Bundles
+// without a sequence number (i.e. zero) are never considered a regression
+// from another unsequenced bundle.
+func checkSequenceNumber(localBundle, endpointBundle *bundleutil.Bundle) error {
+	local, endpoint := localBundle.SequenceNumber(), endpointBundle.SequenceNumber()
+	switch {
+	case endpoint < local:
+		return fmt.Errorf("endpoint bundle sequence number %d is older than local bundle sequence number %d", endpoint, local)
+	case endpoint == local && local != 0:
+		return fmt.Errorf("endpoint bundle changed without incrementing sequence number %d", local)
+	}
+	return nil
+}
+
 func (u *bundleUpdater) newClient(localBundleOrNil *bundleutil.Bundle) (Client, error) {
 	config := ClientConfig{
 		TrustDomain:     u.c.TrustDomain,
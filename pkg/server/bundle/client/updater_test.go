@@ -22,6 +22,11 @@ func TestBundleUpdater(t *testing.T) {
 	bundle2 := bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "bundle2"))
 	bundle2.SetRefreshHint(time.Minute)
 
+	bundle1Seq5 := withSequenceNumber(t, bundle1, 5)
+	bundle2Seq4 := withSequenceNumber(t, bundle2, 4)
+	bundle2Seq5 := withSequenceNumber(t, bundle2, 5)
+	bundle2Seq6 := withSequenceNumber(t, bundle2, 6)
+
 	testCases := []struct {
 		// name of the test
 		name string
@@ -31,6 +36,8 @@ func TestBundleUpdater(t *testing.T) {
 		endpointBundle *bundleutil.Bundle
 		// the bundle in the datastore after Update()
 		storedBundle *bundleutil.Bundle
+		// the bundle history in the datastore after Update()
+		history []*bundleutil.Bundle
 		// the fake endpoint client
 		client fakeClient
 		// the expected error returned from Update()
@@ -54,10 +61,41 @@ func TestBundleUpdater(t *testing.T) {
 			localBundle:    bundle1,
 			endpointBundle: bundle2,
 			storedBundle:   bundle2,
+			history:        []*bundleutil.Bundle{bundle2},
 			client: fakeClient{
 				bundle: bundle2,
 			},
 		},
+		{
+			name:           "bundle sequence number incremented",
+			localBundle:    bundle1Seq5,
+			endpointBundle: bundle2Seq6,
+			storedBundle:   bundle2Seq6,
+			history:        []*bundleutil.Bundle{bundle2Seq6},
+			client: fakeClient{
+				bundle: bundle2Seq6,
+			},
+		},
+		{
+			name:           "bundle sequence number regressed",
+			localBundle:    bundle1Seq5,
+			endpointBundle: nil,
+			storedBundle:   bundle1Seq5,
+			client: fakeClient{
+				bundle: bundle2Seq4,
+			},
+			err: "endpoint bundle sequence number 4 is older than local bundle sequence number 5",
+		},
+		{
+			name:           "bundle changed without sequence number increment",
+			localBundle:    bundle1Seq5,
+			endpointBundle: nil,
+			storedBundle:   bundle1Seq5,
+			client: fakeClient{
+				bundle: bundle2Seq5,
+			},
+			err: "endpoint bundle changed without incrementing sequence number 5",
+		},
 		{
 			name:           "bundle fails to download",
 			localBundle:    bundle1,
@@ -97,9 +135,9 @@ func TestBundleUpdater(t *testing.T) {
 			localBundle, endpointBundle, err := updater.UpdateBundle(context.Background())
 			if testCase.err != "" {
 				spiretest.RequireErrorContains(t, err, testCase.err)
-				return
+			} else {
+				require.NoError(t, err)
 			}
-			require.NoError(t, err)
 			if testCase.localBundle != nil {
 				require.NotNil(t, localBundle)
 				spiretest.RequireProtoEqual(t, testCase.localBundle.Proto(), localBundle.Proto())
@@ -125,10 +163,50 @@ func TestBundleUpdater(t *testing.T) {
 			} else {
 				require.Nil(t, resp.Bundle)
 			}
+
+			historyResp, err := ds.ListBundleHistory(context.Background(), &datastore.ListBundleHistoryRequest{
+				TrustDomainId: "spiffe://domain.test",
+			})
+			require.NoError(t, err)
+			require.Len(t, historyResp.Entries, len(testCase.history))
+			for i, bundle := range testCase.history {
+				spiretest.RequireProtoEqual(t, bundle.Proto(), historyResp.Entries[i].Bundle)
+			}
 		})
 	}
 }
 
+func TestBundleUpdaterLimitsHistory(t *testing.T) {
+	ds := fakedatastore.New()
+	_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "local")).Proto(),
+	})
+	require.NoError(t, err)
+
+	endpointBundle := bundleutil.BundleFromRootCA("spiffe://domain.test", createCACertificate(t, "endpoint"))
+	updater := NewBundleUpdater(BundleUpdaterConfig{
+		DataStore:   ds,
+		TrustDomain: "domain.test",
+		newClient: func(client ClientConfig) Client {
+			return fakeClient{bundle: endpointBundle}
+		},
+	})
+
+	for i := 1; i <= maxBundleHistory+2; i++ {
+		endpointBundle = withSequenceNumber(t, endpointBundle, uint64(i))
+		_, _, err := updater.UpdateBundle(context.Background())
+		require.NoError(t, err)
+	}
+
+	resp, err := ds.ListBundleHistory(context.Background(), &datastore.ListBundleHistoryRequest{
+		TrustDomainId: "spiffe://domain.test",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, maxBundleHistory)
+	require.Equal(t, uint64(3), resp.Entries[0].Bundle.SequenceNumber)
+	require.Equal(t, uint64(maxBundleHistory+2), resp.Entries[maxBundleHistory-1].Bundle.SequenceNumber)
+}
+
 type fakeClient struct {
 	bundle *bundleutil.Bundle
 	err    error
@@ -138,6 +216,14 @@ func (c fakeClient) FetchBundle(context.Context) (*bundleutil.Bundle, error) {
 	return c.bundle, c.err
 }
 
+func withSequenceNumber(t *testing.T, bundle *bundleutil.Bundle, sequenceNumber uint64) *bundleutil.Bundle {
+	bundleProto := bundle.Proto()
+	bundleProto.SequenceNumber = sequenceNumber
+	out, err := bundleutil.BundleFromProto(bundleProto)
+	require.NoError(t, err)
+	return out
+}
+
 func createCACertificate(t *testing.T, cn string) *x509.Certificate {
 	now := time.Now()
 	cert, _ := spiretest.SelfSignCertificate(t, &x509.Certificate{
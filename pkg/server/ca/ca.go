@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"net/url"
 	"sync"
@@ -94,6 +95,14 @@ type X509CASVIDParams struct {
 	// TTL is the desired time-to-live of the SVID. Regardless of the TTL, the
 	// lifetime of the certificate will be capped to that of the signing cert.
 	TTL time.Duration
+
+	// PathPrefixes are the SPIFFE ID path prefixes the CA is allowed to sign
+	// for. If empty, the CA inherits the path prefixes of the signing CA.
+	PathPrefixes []string
+
+	// DNSDomains are the DNS domains the CA is allowed to sign for. If empty,
+	// DNS names are not constrained beyond what the signing CA allows.
+	DNSDomains []string
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -113,6 +122,10 @@ type JWTSVIDParams struct {
 
 	// Audience is used for audience claims
 	Audience []string
+
+	// Claims are custom claims added to the SVID. Registered claims (e.g.
+	// sub, exp) cannot be set.
+	Claims map[string]interface{}
 }
 
 type X509CA struct {
@@ -141,14 +154,20 @@ type JWTKey struct {
 }
 
 type Config struct {
-	Log         logrus.FieldLogger
-	Metrics     telemetry.Metrics
-	TrustDomain url.URL
-	X509SVIDTTL time.Duration
-	JWTSVIDTTL  time.Duration
-	JWTIssuer   string
-	Clock       clock.Clock
-	CASubject   pkix.Name
+	Log           logrus.FieldLogger
+	Metrics       telemetry.Metrics
+	TrustDomain   url.URL
+	X509SVIDTTL   time.Duration
+	JWTSVIDTTL    time.Duration
+	JWTIssuer     string
+	JWTIncludeJTI bool
+	Clock         clock.Clock
+	CASubject     pkix.Name
+
+	// PathPrefixesOID is the OID of the extension carrying the SPIFFE ID
+	// path prefixes of downstream CAs. If unset, downstream CAs cannot be
+	// constrained to path prefixes.
+	PathPrefixesOID asn1.ObjectIdentifier
 }
 
 type CA struct {
@@ -175,8 +194,9 @@ func NewCA(config Config) *CA {
 	return &CA{
 		c: config,
 		jwtSigner: jwtsvid.NewSigner(jwtsvid.SignerConfig{
-			Clock:  config.Clock,
-			Issuer: config.JWTIssuer,
+			Clock:      config.Clock,
+			Issuer:     config.JWTIssuer,
+			IncludeJTI: config.JWTIncludeJTI,
 		}),
 	}
 }
@@ -250,6 +270,16 @@ func (ca *CA) signX509SVID(params X509SVIDParams, x509CA *X509CA) ([]*x509.Certi
 	if err != nil {
 		return nil, err
 	}
+
+	// The X.509 name constraints only cover the trust domain, so enforce the
+	// path prefixes this CA has been constrained to by the upstream server.
+	pathPrefixes, err := x509util.PathPrefixesFromCertificate(ca.c.PathPrefixesOID, x509CA.Certificate)
+	if err != nil {
+		return nil, errs.New("unable to parse path prefixes of the X509 CA: %v", err)
+	}
+	if !idutil.PathHasAnyPrefix(template.URIs[0].Path, pathPrefixes) {
+		return nil, errs.New("X509 CA is not permitted to sign for %q", params.SpiffeID)
+	}
 	// Explicitly set the AKI on the signed certificate, otherwise it won't be
 	// added if the subject and issuer match name match (however unlikely).
 	template.AuthorityKeyId = x509CA.Certificate.SubjectKeyId
@@ -321,6 +351,10 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 	// OU override below, but just to be safe).
 	template.AuthorityKeyId = x509CA.Certificate.SubjectKeyId
 
+	if err := constrainCATemplate(template, x509CA.Certificate, ca.c.PathPrefixesOID, ca.c.TrustDomain.Host, params.PathPrefixes, params.DNSDomains); err != nil {
+		return nil, err
+	}
+
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
 		return nil, errs.New("unable to create X509 CA SVID: %v", err)
@@ -354,7 +388,7 @@ func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, er
 	}
 	_, expiresAt := ca.capLifetime(ttl, jwtKey.NotAfter)
 
-	token, err := ca.jwtSigner.SignToken(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid)
+	token, err := ca.jwtSigner.SignTokenWithClaims(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid, params.Claims)
 	if err != nil {
 		return "", errs.New("unable to sign JWT SVID: %v", err)
 	}
@@ -391,3 +425,46 @@ func createCertificate(template, parent *x509.Certificate, pub, priv interface{}
 
 	return x509.ParseCertificate(certDER)
 }
+
+// constrainCATemplate encodes the path prefix and DNS name constraints of a
+// downstream CA into the template. Path prefixes must be nested under those
+// of the signing CA, since a downstream CA cannot be granted more than its
+// issuer holds. X.509 name constraints cannot express path prefixes, so they
+// are carried in a separate, non-critical extension under the configured OID.
+// Marking it critical would make validators that do not know it reject every
+// SVID under the CA, so the path prefixes are only enforced by SPIRE servers
+// and agents configured with the same OID.
+func constrainCATemplate(template, signingCert *x509.Certificate, pathPrefixesOID asn1.ObjectIdentifier, trustDomain string, pathPrefixes, dnsDomains []string) error {
+	signingPathPrefixes, err := x509util.PathPrefixesFromCertificate(pathPrefixesOID, signingCert)
+	if err != nil {
+		return errs.New("unable to parse path prefixes of the X509 CA: %v", err)
+	}
+	if len(pathPrefixes) == 0 {
+		pathPrefixes = signingPathPrefixes
+	}
+	for _, pathPrefix := range pathPrefixes {
+		if !idutil.PathHasAnyPrefix(pathPrefix, signingPathPrefixes) {
+			return errs.New("path prefix %q is not permitted by the X509 CA", pathPrefix)
+		}
+	}
+
+	if len(pathPrefixes) > 0 {
+		ext, err := x509util.NewPathPrefixesExtension(pathPrefixesOID, pathPrefixes)
+		if err != nil {
+			return errs.New("unable to create path prefixes extension: %v", err)
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, ext)
+	}
+
+	if len(pathPrefixes) > 0 || len(dnsDomains) > 0 {
+		template.PermittedURIDomains = []string{trustDomain}
+		template.PermittedDNSDomains = dnsDomains
+		if len(dnsDomains) == 0 {
+			// An empty constraint matches every DNS name, so the CA cannot
+			// issue any DNS names.
+			template.ExcludedDNSDomains = []string{""}
+		}
+		template.PermittedDNSDomainsCritical = true
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"math/big"
 	"net/url"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 var (
@@ -28,6 +30,8 @@ qQDuoXqa8i3YOPk5fLib4ORzqD9NJFcrKjI+LLtipQe9yu/eY1K0yhBa
 `))
 
 	ctx = context.Background()
+
+	testPathPrefixesOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
 )
 
 func TestCA(t *testing.T) {
@@ -69,6 +73,7 @@ func (s *CATestSuite) SetupTest() {
 		CASubject: pkix.Name{
 			CommonName: "TESTCA",
 		},
+		PathPrefixesOID: testPathPrefixesOID,
 	})
 	s.setX509CA(false)
 	s.setJWTKey()
@@ -250,6 +255,28 @@ func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
 	s.Require().Equal(s.clock.Now().Add(10*time.Minute), expiresAt)
 }
 
+func (s *CATestSuite) TestSignJWTSVIDWithClaims() {
+	params := s.createJWTSVIDParams("example.org", 0)
+	params.Claims = map[string]interface{}{
+		"tenant": "acme",
+	}
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+
+	tok, err := jwt.ParseSigned(token)
+	s.Require().NoError(err)
+	claims := make(map[string]interface{})
+	s.Require().NoError(tok.UnsafeClaimsWithoutVerification(&claims))
+	s.Require().Equal("acme", claims["tenant"])
+
+	// registered claims cannot be overridden
+	params.Claims = map[string]interface{}{
+		"sub": "spiffe://example.org/other",
+	}
+	_, err = s.ca.SignJWTSVID(ctx, params)
+	s.Require().EqualError(err, `unable to sign JWT SVID: claim "sub" is reserved`)
+}
+
 func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	// spiffe id for wrong trust domain
 	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("foo.com", 0))
@@ -301,6 +328,119 @@ func (s *CATestSuite) TestSignX509CASVIDUsesDefaultTTLIfTTLUnspecified() {
 	s.Require().Equal(s.clock.Now().Add(time.Minute), svid[0].NotAfter)
 }
 
+func (s *CATestSuite) TestSignX509CASVIDWithNameConstraints() {
+	params := s.createX509CASVIDParams("example.org")
+	params.PathPrefixes = []string{"/ns/foo"}
+	params.DNSDomains = []string{"foo.example.org"}
+
+	svid, err := s.ca.SignX509CASVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+
+	s.Equal([]string{"example.org"}, svid[0].PermittedURIDomains)
+	s.Equal([]string{"foo.example.org"}, svid[0].PermittedDNSDomains)
+	s.True(svid[0].PermittedDNSDomainsCritical)
+
+	pathPrefixes, err := x509util.PathPrefixesFromCertificate(testPathPrefixesOID, svid[0])
+	s.Require().NoError(err)
+	s.Equal([]string{"/ns/foo"}, pathPrefixes)
+}
+
+func (s *CATestSuite) TestSignX509CASVIDWithOnlyPathPrefixesExcludesDNSNames() {
+	params := s.createX509CASVIDParams("example.org")
+	params.PathPrefixes = []string{"/ns/foo"}
+
+	svid, err := s.ca.SignX509CASVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+
+	s.Empty(svid[0].PermittedDNSDomains)
+	s.Equal([]string{""}, svid[0].ExcludedDNSDomains)
+	s.True(svid[0].PermittedDNSDomainsCritical)
+
+	// SVIDs with DNS names issued by the CA fail verification
+	s.ca.SetX509CA(&X509CA{
+		Signer:      testSigner,
+		Certificate: svid[0],
+	})
+	svidParams := s.createX509SVIDParams()
+	svidParams.SpiffeID = "spiffe://example.org/ns/foo/workload"
+	svidParams.DNSList = []string{"foo.example.org"}
+	leaf, err := s.ca.SignX509SVID(ctx, svidParams)
+	s.Require().NoError(err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(s.caCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(svid[0])
+	_, err = leaf[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   s.clock.Now(),
+	})
+	s.Require().Error(err)
+	s.Contains(err.Error(), `DNS name "foo.example.org" is excluded by constraint ""`)
+}
+
+func (s *CATestSuite) TestSignX509CASVIDWithPathPrefixesRequiresOID() {
+	s.ca.c.PathPrefixesOID = nil
+
+	params := s.createX509CASVIDParams("example.org")
+	params.PathPrefixes = []string{"/ns/foo"}
+	_, err := s.ca.SignX509CASVID(ctx, params)
+	s.Require().EqualError(err, "unable to create path prefixes extension: path prefixes OID is not configured")
+}
+
+func (s *CATestSuite) TestSignX509CASVIDWithoutNameConstraints() {
+	svid, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+
+	s.Empty(svid[0].PermittedURIDomains)
+	s.Empty(svid[0].PermittedDNSDomains)
+
+	pathPrefixes, err := x509util.PathPrefixesFromCertificate(testPathPrefixesOID, svid[0])
+	s.Require().NoError(err)
+	s.Empty(pathPrefixes)
+}
+
+func (s *CATestSuite) TestSignX509CASVIDNestsPathPrefixes() {
+	s.setConstrainedX509CA("/ns/foo")
+
+	// path prefixes are inherited from the signing CA when not provided
+	svid, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+	s.Require().NoError(err)
+	pathPrefixes, err := x509util.PathPrefixesFromCertificate(testPathPrefixesOID, svid[0])
+	s.Require().NoError(err)
+	s.Equal([]string{"/ns/foo"}, pathPrefixes)
+
+	// path prefixes can be narrowed
+	params := s.createX509CASVIDParams("example.org")
+	params.PathPrefixes = []string{"/ns/foo/bar"}
+	svid, err = s.ca.SignX509CASVID(ctx, params)
+	s.Require().NoError(err)
+	pathPrefixes, err = x509util.PathPrefixesFromCertificate(testPathPrefixesOID, svid[0])
+	s.Require().NoError(err)
+	s.Equal([]string{"/ns/foo/bar"}, pathPrefixes)
+
+	// path prefixes cannot be widened
+	params.PathPrefixes = []string{"/ns"}
+	_, err = s.ca.SignX509CASVID(ctx, params)
+	s.Require().EqualError(err, `path prefix "/ns" is not permitted by the X509 CA`)
+}
+
+func (s *CATestSuite) TestSignX509SVIDEnforcesPathPrefixes() {
+	s.setConstrainedX509CA("/workload")
+
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+
+	params := s.createX509SVIDParams()
+	params.SpiffeID = "spiffe://example.org/other"
+	_, err = s.ca.SignX509SVID(ctx, params)
+	s.Require().EqualError(err, `X509 CA is not permitted to sign for "spiffe://example.org/other"`)
+}
+
 func (s *CATestSuite) TestSignCAX509SVIDValidatesTrustDomain() {
 	_, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("foo.com"))
 	s.Require().EqualError(err, `"spiffe://foo.com" does not belong to trust domain "example.org"`)
@@ -318,6 +458,17 @@ func (s *CATestSuite) setX509CA(upstreamBundle bool) {
 	})
 }
 
+func (s *CATestSuite) setConstrainedX509CA(pathPrefixes ...string) {
+	params := s.createX509CASVIDParams("example.org")
+	params.PathPrefixes = pathPrefixes
+	svid, err := s.ca.SignX509CASVID(ctx, params)
+	s.Require().NoError(err)
+	s.ca.SetX509CA(&X509CA{
+		Signer:      testSigner,
+		Certificate: svid[0],
+	})
+}
+
 func (s *CATestSuite) setJWTKey() {
 	s.ca.SetJWTKey(&JWTKey{
 		Signer:   testSigner,
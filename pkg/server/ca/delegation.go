@@ -0,0 +1,50 @@
+package ca
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/zeebo/errs"
+)
+
+// DefaultDelegatedJWTKeyTTL is the lifetime of JWT keys delegated to agents
+// if not overridden by the server config. The lifetime of a delegated key is
+// also capped to that of the agent SVID.
+const DefaultDelegatedJWTKeyTTL = time.Hour
+
+// DelegatedJWTKeyIDPrefix returns the key ID prefix shared by every JWT key
+// delegated to the given agent. The prefix is derived from the agent ID so
+// the keys can be located (and revoked) without any additional state.
+func DelegatedJWTKeyIDPrefix(agentID string) string {
+	sum := sha256.Sum256([]byte(agentID))
+	return jwtsvid.DelegatedKeyIDPrefix + hex.EncodeToString(sum[:8]) + "."
+}
+
+// NewDelegatedJWTKeyID returns a new key ID for a JWT key delegated to the
+// given agent.
+func NewDelegatedJWTKeyID(agentID string) (string, error) {
+	kid, err := newKeyID()
+	if err != nil {
+		return "", err
+	}
+	return DelegatedJWTKeyIDPrefix(agentID) + kid, nil
+}
+
+// RevokeDelegatedJWTKeys removes the JWT keys delegated to the given agent
+// from the trust domain bundle. It returns the number of keys removed. The
+// keys are removed by the datastore in a single transaction so that keys
+// concurrently added to the bundle are not lost.
+func RevokeDelegatedJWTKeys(ctx context.Context, ds datastore.DataStore, trustDomainID, agentID string) (int, error) {
+	resp, err := ds.RemoveBundleJWTSigningKeys(ctx, &datastore.RemoveBundleJWTSigningKeysRequest{
+		TrustDomainId: trustDomainID,
+		KeyIdPrefix:   DelegatedJWTKeyIDPrefix(agentID),
+	})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	return int(resp.Removed), nil
+}
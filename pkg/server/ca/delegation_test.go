@@ -0,0 +1,69 @@
+package ca_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDelegatedJWTKeyID(t *testing.T) {
+	kidA, err := ca.NewDelegatedJWTKeyID("spiffe://example.org/spire/agent/a")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(kidA, ca.DelegatedJWTKeyIDPrefix("spiffe://example.org/spire/agent/a")))
+
+	kidB, err := ca.NewDelegatedJWTKeyID("spiffe://example.org/spire/agent/a")
+	require.NoError(t, err)
+	require.NotEqual(t, kidA, kidB)
+
+	require.NotEqual(t,
+		ca.DelegatedJWTKeyIDPrefix("spiffe://example.org/spire/agent/a"),
+		ca.DelegatedJWTKeyIDPrefix("spiffe://example.org/spire/agent/b"))
+}
+
+func TestRevokeDelegatedJWTKeys(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+
+	// nothing to revoke if there is no bundle
+	revoked, err := ca.RevokeDelegatedJWTKeys(ctx, ds, "spiffe://example.org", "spiffe://example.org/spire/agent/a")
+	require.NoError(t, err)
+	require.Equal(t, 0, revoked)
+
+	kidA, err := ca.NewDelegatedJWTKeyID("spiffe://example.org/spire/agent/a")
+	require.NoError(t, err)
+	kidB, err := ca.NewDelegatedJWTKeyID("spiffe://example.org/spire/agent/b")
+	require.NoError(t, err)
+
+	_, err = ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: "spiffe://example.org",
+			JwtSigningKeys: []*common.PublicKey{
+				{Kid: "server"},
+				{Kid: kidA},
+				{Kid: kidB},
+			},
+			RevokedJwtKeyIds: []string{kidA},
+		},
+	})
+	require.NoError(t, err)
+
+	revoked, err = ca.RevokeDelegatedJWTKeys(ctx, ds, "spiffe://example.org", "spiffe://example.org/spire/agent/a")
+	require.NoError(t, err)
+	require.Equal(t, 1, revoked)
+
+	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Bundle.JwtSigningKeys, 2)
+	require.Equal(t, "server", resp.Bundle.JwtSigningKeys[0].Kid)
+	require.Equal(t, kidB, resp.Bundle.JwtSigningKeys[1].Kid)
+	require.Empty(t, resp.Bundle.RevokedJwtKeyIds)
+	require.Equal(t, uint64(1), resp.Bundle.SequenceNumber)
+}
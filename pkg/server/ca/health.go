@@ -0,0 +1,209 @@
+package ca
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/health"
+)
+
+// healthState tracks what the manager reports through its health checks. It
+// is updated by the manager tasks and read by the health checker, so it is
+// protected by its own mutex rather than relying on the manager goroutines.
+type healthState struct {
+	mu sync.RWMutex
+
+	x509CA *keyHealth
+	jwtKey *keyHealth
+
+	upstreamCalledAt time.Time
+	upstreamErr      error
+
+	notifiers map[string]notifierHealth
+}
+
+type keyHealth struct {
+	slot     string
+	issuedAt time.Time
+	notAfter time.Time
+}
+
+type notifierHealth struct {
+	event string
+	at    time.Time
+	err   error
+}
+
+// KeyStatus is reported by the CA health check for the active X509 CA and
+// JWT key.
+type KeyStatus struct {
+	Slot       string    `json:"slot"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTLSeconds int64     `json:"ttl_seconds"`
+}
+
+// CAStatus is the detail of the CA health check
+type CAStatus struct {
+	X509CA *KeyStatus `json:"x509_ca,omitempty"`
+	JWTKey *KeyStatus `json:"jwt_key,omitempty"`
+}
+
+// UpstreamStatus is the detail of the UpstreamAuthority health check
+type UpstreamStatus struct {
+	Plugin     string    `json:"plugin"`
+	LastCallAt time.Time `json:"last_call_at,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+// NotifierStatus is the detail of the notifier health check for a single
+// notifier
+type NotifierStatus struct {
+	Name      string    `json:"name"`
+	LastEvent string    `json:"last_event"`
+	LastAt    time.Time `json:"last_at"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+func (h *healthState) setX509CA(slot *x509CASlot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.x509CA = &keyHealth{
+		slot:     slot.id,
+		issuedAt: slot.issuedAt,
+		notAfter: slot.x509CA.Certificate.NotAfter,
+	}
+}
+
+func (h *healthState) setJWTKey(slot *jwtKeySlot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.jwtKey = &keyHealth{
+		slot:     slot.id,
+		issuedAt: slot.issuedAt,
+		notAfter: slot.jwtKey.NotAfter,
+	}
+}
+
+func (h *healthState) recordUpstreamCall(now time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.upstreamCalledAt = now
+	h.upstreamErr = err
+}
+
+func (h *healthState) recordNotifier(name, event string, now time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.notifiers == nil {
+		h.notifiers = make(map[string]notifierHealth)
+	}
+	h.notifiers[name] = notifierHealth{
+		event: event,
+		at:    now,
+		err:   err,
+	}
+}
+
+// CheckCA reports the time to expiry of the active X509 CA and JWT key. The
+// check is unhealthy if either is missing or expired, and degraded if either
+// is past the point where the next one should have been activated, which
+// means preparing the next one is failing.
+func (m *Manager) CheckCA() (interface{}, error) {
+	m.health.mu.RLock()
+	defer m.health.mu.RUnlock()
+
+	now := m.c.Clock.Now()
+	status := CAStatus{
+		X509CA: m.health.x509CA.status(now),
+		JWTKey: m.health.jwtKey.status(now),
+	}
+
+	if err := m.health.x509CA.check(now, "X509 CA"); err != nil {
+		return status, err
+	}
+	if err := m.health.jwtKey.check(now, "JWT key"); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+func (k *keyHealth) status(now time.Time) *KeyStatus {
+	if k == nil {
+		return nil
+	}
+	return &KeyStatus{
+		Slot:       k.slot,
+		ExpiresAt:  k.notAfter,
+		TTLSeconds: int64(k.notAfter.Sub(now) / time.Second),
+	}
+}
+
+func (k *keyHealth) check(now time.Time, what string) error {
+	switch {
+	case k == nil:
+		return fmt.Errorf("no active %s", what)
+	case !now.Before(k.notAfter):
+		return fmt.Errorf("active %s expired at %s", what, k.notAfter.UTC().Format(time.RFC3339))
+	case now.After(KeyActivationThreshold(k.issuedAt, k.notAfter)):
+		return health.Degraded(fmt.Errorf("active %s is past its rotation threshold", what))
+	}
+	return nil
+}
+
+// HasUpstreamAuthority returns true if the manager signs its CA through an
+// UpstreamAuthority plugin
+func (m *Manager) HasUpstreamAuthority() bool {
+	return m.upstreamClient != nil
+}
+
+// CheckUpstreamAuthority reports whether the most recent call to the
+// UpstreamAuthority plugin (minting an X509 CA or publishing a JWT key)
+// succeeded. A failure is reported as degraded since the active CA keeps
+// working until it expires, which is covered by the CA check.
+func (m *Manager) CheckUpstreamAuthority() (interface{}, error) {
+	m.health.mu.RLock()
+	defer m.health.mu.RUnlock()
+
+	status := UpstreamStatus{
+		Plugin:     m.upstreamPluginName,
+		LastCallAt: m.health.upstreamCalledAt,
+	}
+	if err := m.health.upstreamErr; err != nil {
+		status.LastError = err.Error()
+		return status, health.Degraded(fmt.Errorf("upstream authority %q is unreachable: %v", m.upstreamPluginName, err))
+	}
+	return status, nil
+}
+
+// CheckNotifiers reports whether each notifier handled the most recent bundle
+// event. Failures are reported as degraded.
+func (m *Manager) CheckNotifiers() (interface{}, error) {
+	m.health.mu.RLock()
+	defer m.health.mu.RUnlock()
+
+	statuses := make([]NotifierStatus, 0, len(m.health.notifiers))
+	var failed []string
+	for name, n := range m.health.notifiers {
+		status := NotifierStatus{
+			Name:      name,
+			LastEvent: n.event,
+			LastAt:    n.at,
+		}
+		if n.err != nil {
+			status.LastError = n.err.Error()
+			failed = append(failed, name)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return statuses, health.Degraded(fmt.Errorf("notifiers failed to handle the last event: %q", failed))
+	}
+	return statuses, nil
+}
@@ -82,6 +82,8 @@ type Manager struct {
 
 	// Used to log a warning only once when the UpstreamAuthority does not support JWT-SVIDs.
 	jwtUnimplementedWarnOnce sync.Once
+
+	health healthState
 }
 
 func NewManager(c ManagerConfig) *Manager {
@@ -239,6 +241,7 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 	var x509CA *X509CA
 	if m.upstreamClient != nil {
 		x509CA, err = UpstreamSignX509CA(ctx, signer, m.c.TrustDomain.Host, m.c.CASubject, m.upstreamClient, m.c.UpstreamBundle, m.c.CATTL)
+		m.health.recordUpstreamCall(m.c.Clock.Now(), err)
 		if err != nil {
 			return err
 		}
@@ -288,6 +291,7 @@ func (m *Manager) activateX509CA() {
 	}).Debug("Successfully rotated X.509 CA")
 
 	m.c.CA.SetX509CA(m.currentX509CA.x509CA)
+	m.health.setX509CA(m.currentX509CA)
 }
 
 func (m *Manager) rotateJWTKey(ctx context.Context) error {
@@ -384,6 +388,13 @@ func (m *Manager) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) (
 		publishCtx, cancel := context.WithTimeout(ctx, publishJWKTimeout)
 		defer cancel()
 		upstreamJWTKeys, err := m.upstreamClient.PublishJWTKey(publishCtx, jwtKey)
+		if status.Code(err) == codes.Unimplemented {
+			// The upstream authority was reached; it just does not
+			// support publishing JWT keys.
+			m.health.recordUpstreamCall(m.c.Clock.Now(), nil)
+		} else {
+			m.health.recordUpstreamCall(m.c.Clock.Now(), err)
+		}
 		switch {
 		case status.Code(err) == codes.Unimplemented:
 			// JWT Key publishing is not supported by the upstream plugin.
@@ -418,6 +429,7 @@ func (m *Manager) activateJWTKey() {
 	}).Info("JWT key activated")
 	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
 	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
+	m.health.setJWTKey(m.currentJWTKey)
 }
 
 func (m *Manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
@@ -800,6 +812,7 @@ func (m *Manager) notify(ctx context.Context, event string, advise bool, pre fun
 	for _, n := range notifiers {
 		go func(n catalog.Notifier) {
 			err := do(ctx, n)
+			m.health.recordNotifier(n.Name(), event, m.c.Clock.Now(), err)
 			f := m.c.Log.WithFields(logrus.Fields{
 				telemetry.Notifier: n.Name(),
 				telemetry.Event:    event,
@@ -19,6 +19,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/catalog"
@@ -536,6 +537,58 @@ func (s *ManagerSuite) TestRunFailsIfNotifierFails() {
 	s.Equal("bundle loaded", entry.Data["event"])
 	s.Equal("ohno", fmt.Sprintf("%v", entry.Data["error"]))
 	s.Equal("Notifier failed to handle event", entry.Message)
+
+	// the failure is reported by the notifiers health check
+	statuses, err := s.m.CheckNotifiers()
+	s.Require().EqualError(err, `notifiers failed to handle the last event: ["fake"]`)
+	s.True(health.IsDegraded(err))
+	s.Equal([]NotifierStatus{
+		{Name: "fake", LastEvent: "bundle loaded", LastAt: s.clock.Now(), LastError: "ohno"},
+	}, statuses)
+}
+
+func (s *ManagerSuite) TestCheckCA() {
+	s.initSelfSignedManager()
+	s.False(s.m.HasUpstreamAuthority())
+
+	x509CA := s.currentX509CA()
+	status, err := s.m.CheckCA()
+	s.Require().NoError(err)
+	caStatus := status.(CAStatus)
+	s.Require().NotNil(caStatus.X509CA)
+	s.Require().NotNil(caStatus.JWTKey)
+	s.Equal("A", caStatus.X509CA.Slot)
+	s.Equal(x509CA.Certificate.NotAfter, caStatus.X509CA.ExpiresAt)
+	s.Equal(int64(testCATTL/time.Second), caStatus.X509CA.TTLSeconds)
+
+	// past the activation threshold without a rotation the CA is degraded
+	s.clock.Add(activateAfter + time.Minute)
+	_, err = s.m.CheckCA()
+	s.Require().EqualError(err, "active X509 CA is past its rotation threshold")
+	s.True(health.IsDegraded(err))
+
+	// once the active CA expires it is unhealthy
+	s.clock.Add(testCATTL)
+	_, err = s.m.CheckCA()
+	s.Require().Error(err)
+	s.Contains(err.Error(), "active X509 CA expired at")
+	s.False(health.IsDegraded(err))
+}
+
+func (s *ManagerSuite) TestCheckUpstreamAuthority() {
+	upstreamAuthority, _, upDone := fakeupstreamauthority.Load(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	defer upDone()
+	s.initUpstreamSignedManager(upstreamAuthority, true)
+	s.True(s.m.HasUpstreamAuthority())
+
+	status, err := s.m.CheckUpstreamAuthority()
+	s.Require().NoError(err)
+	s.Equal(UpstreamStatus{
+		Plugin:     "fakeupstreamauthority",
+		LastCallAt: s.clock.Now(),
+	}, status)
 }
 
 func (s *ManagerSuite) TestPreparationThresholdCap() {
@@ -8,6 +8,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
@@ -165,6 +167,8 @@ func (p *Plugins) GetUpstreamAuthority() (*UpstreamAuthority, bool) {
 
 type Config struct {
 	Log          logrus.FieldLogger
+	Metrics      telemetry.Metrics
+	Tracer       *tracing.Tracer
 	GlobalConfig GlobalConfig
 	PluginConfig HCLPluginConfigMap
 
@@ -176,6 +180,31 @@ type Config struct {
 type Repository struct {
 	Catalog
 	catalog.Closer
+
+	loaded catalog.Catalog
+	log    logrus.FieldLogger
+}
+
+// Reconfigure configures again the plugins whose data changed in the given
+// plugin configuration. Changes that require a restart are rejected. It
+// returns the names of the reconfigured plugins.
+func (r *Repository) Reconfigure(ctx context.Context, globalConfig GlobalConfig, pluginConfig HCLPluginConfigMap) ([]string, error) {
+	if err := reclassifyPortedUpstreamCAs(pluginConfig, r.log); err != nil {
+		return nil, err
+	}
+
+	pluginConfigs, err := catalog.PluginConfigFromHCL(pluginConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.loaded.Reconfigure(ctx, globalConfig, pluginConfigs)
+}
+
+// CheckExternalPlugins is a health check over the external plugins in the
+// catalog.
+func (r *Repository) CheckExternalPlugins() (interface{}, error) {
+	return r.loaded.CheckExternalPlugins()
 }
 
 // reclassifyPortedUpstreamCAs reclassify ported UpstreamCA plugins into UpstreamAuthority
@@ -213,8 +242,10 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 	}
 
 	p := new(Plugins)
-	closer, err := catalog.Fill(ctx, catalog.Config{
+	loaded, err := catalog.Fill(ctx, catalog.Config{
 		Log:           config.Log,
+		Metrics:       config.Metrics,
+		Tracer:        config.Tracer,
 		GlobalConfig:  config.GlobalConfig,
 		PluginConfig:  pluginConfigs,
 		KnownPlugins:  KnownPlugins(),
@@ -244,6 +275,8 @@ func Load(ctx context.Context, config Config) (*Repository, error) {
 
 	return &Repository{
 		Catalog: p,
-		Closer:  closer,
+		Closer:  loaded,
+		loaded:  loaded,
+		log:     config.Log,
 	}, nil
 }
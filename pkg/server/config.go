@@ -2,6 +2,7 @@ package server
 
 import (
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"net"
 	"net/url"
 	"time"
@@ -60,9 +61,33 @@ type Config struct {
 	// If unset, the JWT-SVID will not have an issuer claim.
 	JWTIssuer string
 
+	// JWTIncludeJTI, if true, adds a random token ID (jti) claim to JWT-SVIDs
+	// minted by the server so that validators can detect replayed tokens.
+	JWTIncludeJTI bool
+
+	// PruneAttestedNodesExpiredFor is how long after their SVID expired
+	// attested nodes (and their node selectors) are pruned. If zero,
+	// attested nodes are never pruned.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// PruneAttestedNodesDryRun, if true, only logs the attested nodes that
+	// would be pruned instead of pruning them.
+	PruneAttestedNodesDryRun bool
+
+	// InventoryReportInterval is how often the registration entry, attested
+	// node and bundle gauges are refreshed using datastore count queries.
+	// Defaults to one minute.
+	InventoryReportInterval time.Duration
+
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
 
+	// PathPrefixesOID is the OID of the certificate extension carrying the
+	// SPIFFE ID path prefixes of downstream CAs. If unset, downstream entries
+	// cannot be constrained to path prefixes and the path prefixes of
+	// downstream CAs are not enforced.
+	PathPrefixesOID asn1.ObjectIdentifier
+
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
 
@@ -91,6 +116,14 @@ type ExperimentalConfig struct {
 	// FederatesWith holds the federation configuration for trust domains this
 	// server federates with.
 	FederatesWith map[string]bundle_client.TrustDomainConfig
+
+	// DelegatedJWTKeysEnabled, if true, allows agents to request short-lived
+	// JWT keys, published in the bundle, to sign JWT-SVIDs locally.
+	DelegatedJWTKeysEnabled bool
+
+	// DelegatedJWTKeyTTL is the time-to-live of the JWT keys delegated to
+	// agents.
+	DelegatedJWTKeyTTL time.Duration
 }
 
 func New(config Config) *Server {
@@ -98,6 +98,7 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 	// TODO: bundle sequence number?
 	opts := []bundleutil.MarshalOption{
 		bundleutil.OverrideRefreshHint(refreshHint),
+		bundleutil.NoDelegatedJWTSVIDKeys(),
 	}
 
 	jsonBytes, err := bundleutil.Marshal(b, opts...)
@@ -41,6 +41,13 @@ func TestServer(t *testing.T) {
 	bundle := bundleutil.New("spiffe://domain.test")
 	bundle.AppendRootCA(serverCert)
 
+	// JWT keys delegated to agents are not published to federated trust
+	// domains
+	bundleWithJWTKeys := bundleutil.New("spiffe://domain.test")
+	bundleWithJWTKeys.AppendRootCA(serverCert)
+	require.NoError(t, bundleWithJWTKeys.AppendJWTSigningKey("KID", serverCert.PublicKey))
+	require.NoError(t, bundleWithJWTKeys.AppendJWTSigningKey("agent.0123456789abcdef.KID", serverCert.PublicKey))
+
 	// even though this will be SPIFFE authentication in production, there is
 	// no functional change in the code based on the server certificate
 	// returned from the getter, so for test purposes we'll just use a
@@ -86,6 +93,35 @@ func TestServer(t *testing.T) {
 			bundle:     bundle,
 			serverCert: serverCert,
 		},
+		{
+			name:   "success without delegated JWT keys",
+			method: "GET",
+			path:   "/",
+			status: http.StatusOK,
+			body: fmt.Sprintf(`{
+				"keys": [
+					{
+						"crv":"P-256",
+						"kty":"EC",
+						"use":"x509-svid",
+						"x":"kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+						"y":"qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA",
+						"x5c": [%q]
+					},
+					{
+						"crv":"P-256",
+						"kty":"EC",
+						"use":"jwt-svid",
+						"kid":"KID",
+						"x":"kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+						"y":"qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA"
+					}
+				],
+				"spiffe_refresh_hint": 360
+			}`, base64.StdEncoding.EncodeToString(serverCert.Raw)),
+			bundle:     bundleWithJWTKeys,
+			serverCert: serverCert,
+		},
 		{
 			name:       "invalid method",
 			method:     "POST",
@@ -1,16 +1,21 @@
 package endpoints
 
 import (
+	"encoding/asn1"
 	"net"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/registration"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/svid"
 
 	"google.golang.org/grpc"
@@ -40,6 +45,12 @@ type Config struct {
 	// Allow agentless spiffeIds when doing node attestation
 	AllowAgentlessNodeAttestors bool
 
+	// If true, agents can request delegated JWT keys to sign JWT-SVIDs
+	DelegatedJWTKeysEnabled bool
+
+	// Time-to-live of the JWT keys delegated to agents
+	DelegatedJWTKeyTTL time.Duration
+
 	// Bundle endpoint address
 	BundleEndpointAddress *net.TCPAddr
 
@@ -49,8 +60,19 @@ type Config struct {
 	// CA Manager
 	Manager *ca.Manager
 
+	// Provides the synchronization status of federated bundles
+	BundleSyncStatus registration.BundleSyncStatusSource
+
+	// OID of the certificate extension carrying the SPIFFE ID path prefixes
+	// of downstream CAs, enforced on the SVIDs of agents. If unset, path
+	// prefixes are not enforced.
+	PathPrefixesOID asn1.ObjectIdentifier
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
+
+	// Tracer records spans for the calls served by the endpoints. Optional.
+	Tracer *tracing.Tracer
 }
 
 // New creates new endpoints struct
@@ -61,5 +83,6 @@ func New(c *Config) *Endpoints {
 		unixListener: &peertracker.ListenerFactory{
 			Log: c.Log,
 		},
+		downstreamAgents: nested.NewInventory(nil),
 	}
 }
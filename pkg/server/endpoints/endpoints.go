@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -20,10 +21,13 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/endpoints/registration"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	datastore_pb "github.com/spiffe/spire/pkg/server/plugin/datastore"
 	node_pb "github.com/spiffe/spire/proto/spire/api/node"
@@ -47,6 +51,10 @@ type Endpoints struct {
 	c            *Config
 	mtx          *sync.RWMutex
 	unixListener *peertracker.ListenerFactory
+
+	// Agents reported by downstream servers, shared between the node and
+	// registration APIs.
+	downstreamAgents *nested.Inventory
 }
 
 // ListenAndServe starts all maintenance routines and endpoints, then blocks
@@ -89,8 +97,8 @@ func (e *Endpoints) createTCPServer(ctx context.Context) *grpc.Server {
 	}
 
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		unaryInterceptor(e.c.Tracer),
+		streamInterceptor(e.c.Tracer),
 		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionAge: defaultMaxConnectionAge,
@@ -100,11 +108,27 @@ func (e *Endpoints) createTCPServer(ctx context.Context) *grpc.Server {
 
 func (e *Endpoints) createUDSServer() *grpc.Server {
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		unaryInterceptor(e.c.Tracer),
+		streamInterceptor(e.c.Tracer),
 		grpc.Creds(peertracker.NewCredentials()))
 }
 
+// unaryInterceptor traces each call before authorizing it so that rejected
+// calls show up in traces too.
+func unaryInterceptor(tracer *tracing.Tracer) grpc.ServerOption {
+	return grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+		tracer.UnaryServerInterceptor,
+		auth.UnaryAuthorizeCall,
+	))
+}
+
+func streamInterceptor(tracer *tracing.Tracer) grpc.ServerOption {
+	return grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+		tracer.StreamServerInterceptor,
+		auth.StreamAuthorizeCall,
+	))
+}
+
 func (e *Endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 	if e.c.BundleEndpointAddress == nil {
 		return nil, false
@@ -153,6 +177,9 @@ func (e *Endpoints) registerNodeAPI(tcpServer *grpc.Server) error {
 		Manager:     e.c.Manager,
 
 		AllowAgentlessNodeAttestors: e.c.AllowAgentlessNodeAttestors,
+		DelegatedJWTKeysEnabled:     e.c.DelegatedJWTKeysEnabled,
+		DelegatedJWTKeyTTL:          e.c.DelegatedJWTKeyTTL,
+		DownstreamAgents:            e.downstreamAgents,
 	})
 	if err != nil {
 		return err
@@ -170,6 +197,9 @@ func (e *Endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) {
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
 		ServerCA:    e.c.ServerCA,
+
+		DownstreamAgents: e.downstreamAgents,
+		BundleSyncStatus: e.c.BundleSyncStatus,
 	}
 
 	registration_pb.RegisterRegistrationServer(tcpServer, r)
@@ -258,6 +288,15 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 
 			Certificates: certs,
 			ClientCAs:    roots,
+
+			// The X.509 name constraints only cover the trust domain, so
+			// enforce the path prefixes of downstream CAs separately.
+			VerifyPeerCertificate: func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+				if len(verifiedChains) == 0 {
+					return nil
+				}
+				return x509util.VerifyChainsPathPrefixes(e.c.PathPrefixesOID, verifiedChains)
+			},
 		}
 		return c, nil
 	}
@@ -15,7 +15,7 @@ import (
 func TestFetchBundleCache(t *testing.T) {
 	req := &datastore.FetchBundleRequest{TrustDomainId: "spiffe://domain.test"}
 	bundle1 := &common.Bundle{TrustDomainId: "spiffe://domain.test", RefreshHint: 1}
-	bundle2 := &common.Bundle{TrustDomainId: "spiffe://domain.test", RefreshHint: 2}
+	bundle2 := &common.Bundle{TrustDomainId: "spiffe://domain.test", RefreshHint: 2, SequenceNumber: 1}
 	ds := fakedatastore.New()
 	clock := clock.NewMock(t)
 	cache := newDatastoreCache(ds, clock)
@@ -2,17 +2,20 @@ package node
 
 import (
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/url"
-	"path"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/errorutil"
@@ -23,9 +26,11 @@ import (
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
 	"github.com/spiffe/spire/pkg/server/plugin/noderesolver"
+	"github.com/spiffe/spire/pkg/server/util/jointokenutil"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -39,6 +44,14 @@ import (
 // Number of agentIDs that can be cached
 const fetchSVIDCacheSize = 500_000
 
+// Number of times a join token use is attempted when racing with concurrent
+// attestations using the same token
+const maxJoinTokenUseAttempts = 3
+
+// errJoinTokenUseNotRecorded is returned when the use of a join token cannot
+// be recorded, usually because a concurrent attestation recorded it first
+var errJoinTokenUseNotRecorded = errors.New("join token use could not be recorded")
+
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
 	Metrics     telemetry.Metrics
@@ -50,6 +63,17 @@ type HandlerConfig struct {
 
 	// Allow agentless SPIFFE IDs when doing node attestation
 	AllowAgentlessNodeAttestors bool
+
+	// If true, agents can request a delegated JWT key to sign JWT-SVIDs
+	// locally.
+	DelegatedJWTKeysEnabled bool
+
+	// DelegatedJWTKeyTTL is the time-to-live of the JWT keys delegated to
+	// agents. It is capped to the expiration of the agent SVID.
+	DelegatedJWTKeyTTL time.Duration
+
+	// DownstreamAgents holds the agents reported by downstream servers.
+	DownstreamAgents *nested.Inventory
 }
 
 type Handler struct {
@@ -58,12 +82,19 @@ type Handler struct {
 
 	dsCache                       *datastoreCache
 	fetchRegistrationEntriesCache *regentryutil.FetchRegistrationEntriesCache
+	lastSeen                      *lastSeenTracker
 }
 
 func NewHandler(config HandlerConfig) (*Handler, error) {
 	if config.Clock == nil {
 		config.Clock = clock.New()
 	}
+	if config.DelegatedJWTKeyTTL <= 0 {
+		config.DelegatedJWTKeyTTL = ca.DefaultDelegatedJWTKeyTTL
+	}
+	if config.DownstreamAgents == nil {
+		config.DownstreamAgents = nested.NewInventory(config.Clock)
+	}
 	fetchX509SVIDCache, err := regentryutil.NewFetchX509SVIDCache(fetchSVIDCacheSize)
 	if err != nil {
 		return nil, fmt.Errorf("could not create cache: %v", err)
@@ -74,6 +105,7 @@ func NewHandler(config HandlerConfig) (*Handler, error) {
 		limiter:                       NewLimiter(config.Log),
 		dsCache:                       newDatastoreCache(config.Catalog.GetDataStore(), config.Clock),
 		fetchRegistrationEntriesCache: fetchX509SVIDCache,
+		lastSeen:                      newLastSeenTracker(config.Clock),
 	}, nil
 }
 
@@ -176,13 +208,31 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 			log.WithError(err).Warn("expected EOF on attestation stream")
 		}
 	} else {
-		attestResponse, err = h.attestToken(ctx, request.AttestationData)
-		if err != nil {
-			log.WithError(err).Error("Failed to attest")
-			return errorutil.WrapError(err, "failed to attest")
+		// Concurrent attestations with a multi-use token race to record the
+		// same use. The losers retry with the next use.
+		for attempt := 1; ; attempt++ {
+			var tokenUse *datastore.JoinTokenUse
+			attestResponse, tokenUse, err = h.attestToken(ctx, request.AttestationData)
+			if err != nil {
+				log.WithError(err).Error("Failed to attest")
+				return errorutil.WrapError(err, "failed to attest")
+			}
+			err = h.completeAttestation(ctx, log, stream, request, csr, attestResponse, tokenUse)
+			if errors.Is(err, errJoinTokenUseNotRecorded) && attempt < maxJoinTokenUseAttempts {
+				continue
+			}
+			return err
 		}
 	}
 
+	return h.completeAttestation(ctx, log, stream, request, csr, attestResponse, nil)
+}
+
+// completeAttestation issues the agent SVID and records the attested node. If
+// the agent attested with a join token, the token use is recorded once the
+// agent passed every check and its SVID was signed, so failed attestations do
+// not consume uses.
+func (h *Handler) completeAttestation(ctx context.Context, log logrus.FieldLogger, stream node.Node_AttestServer, request *node.AttestRequest, csr *CSR, attestResponse *nodeattestor.AttestResponse, tokenUse *datastore.JoinTokenUse) error {
 	agentID := attestResponse.AgentId
 	log = log.WithField(telemetry.SPIFFEID, agentID)
 
@@ -191,6 +241,16 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.NotFound, "attestor returned unexpected response")
 	}
 
+	selectors, err := h.resolveNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type)
+	if err != nil {
+		log.WithError(err).Error("Failed to resolve node selectors")
+		return status.Error(codes.Internal, "failed to resolve node selectors")
+	}
+
+	if err := h.checkAgentBan(ctx, log, agentID, selectors); err != nil {
+		return err
+	}
+
 	log.WithField(telemetry.AgentID, agentID).Debugf("Signing CSR for Agent SVID")
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  agentID,
@@ -201,7 +261,17 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.Internal, "failed to sign CSR")
 	}
 
-	if err := h.updateNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type); err != nil {
+	if tokenUse != nil {
+		if _, err := h.c.Catalog.GetDataStore().UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+			Token: string(request.AttestationData.Data),
+			Use:   tokenUse,
+		}); err != nil {
+			log.WithError(err).Error("Failed to record join token use")
+			return fmt.Errorf("%w: %v", errJoinTokenUseNotRecorded, err)
+		}
+	}
+
+	if err := h.updateNodeSelectors(ctx, agentID, selectors); err != nil {
 		log.WithError(err).Error("Failed to update node selectors")
 		return status.Error(codes.Internal, "failed to update node selectors")
 	}
@@ -235,6 +305,11 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		}
 	}
 
+	// Attestation is always recorded, regardless of when the agent was last
+	// seen
+	h.lastSeen.Forget(agentID)
+	h.recordAgentSeen(ctx, log, agentID, request.AgentVersion)
+
 	p, ok := peer.FromContext(ctx)
 	if ok {
 		log.WithField(telemetry.Address, p.Addr.String()).Info("Node attestation request completed")
@@ -248,6 +323,166 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 	return nil
 }
 
+// Reattest re-runs node attestation for the calling agent using fresh
+// attestation data and replaces its node selectors with the ones produced by
+// the node attestor and node resolver. The agent ID must not change.
+func (h *Handler) Reattest(stream node.Node_ReattestServer) (err error) {
+	counter := telemetry_server.StartNodeAPIReattestCall(h.c.Metrics)
+	attestorName := ""
+	defer func() {
+		telemetry_common.AddAttestorType(counter, attestorName)
+		counter.Done(&err)
+	}()
+
+	log := h.c.Log.WithField(telemetry.Method, telemetry.Reattest)
+
+	// make sure node attestor stream will be cancelled if things go awry
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	peerCert, ok := getPeerCertificate(ctx)
+	if !ok {
+		log.Error("Request missing client SVID")
+		return status.Error(codes.InvalidArgument, "client SVID is required for this request")
+	}
+	agentID, err := getSpiffeIDFromCert(peerCert)
+	if err != nil {
+		log.WithError(err).Error("Failed to get agent ID from SVID")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log = log.WithField(telemetry.AgentID, agentID)
+
+	request, err := stream.Recv()
+	if err != nil {
+		log.WithError(err).Error("Failed to receive request from stream")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = h.limiter.Limit(ctx, AttestMsg, 1)
+	if err != nil {
+		log.WithError(err).Error("Rejecting request due to node attestation rate limiting")
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	if request.AttestationData == nil {
+		log.Error("Request missing attestation data")
+		return status.Error(codes.InvalidArgument, "request missing attestation data")
+	}
+	if request.AttestationData.Type == "" {
+		log.Error("Request missing attestation data type")
+		return status.Error(codes.InvalidArgument, "request missing attestation data type")
+	}
+	attestationType := request.AttestationData.Type
+	attestorName = attestationType
+	log = log.WithField(telemetry.Attestor, attestationType)
+
+	fetchResponse, err := h.c.Catalog.GetDataStore().FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to fetch attested node")
+		return status.Error(codes.Internal, "failed to fetch attested node")
+	case fetchResponse.Node == nil:
+		log.Error("Agent is not attested")
+		return status.Error(codes.PermissionDenied, "agent is not attested")
+	case fetchResponse.Node.AttestationDataType != attestationType:
+		log.WithField(telemetry.NodeAttestorType, fetchResponse.Node.AttestationDataType).Error("Attestation data type does not match the one the agent attested with")
+		return status.Errorf(codes.InvalidArgument, "attestation data type %q does not match the %q type the agent attested with", attestationType, fetchResponse.Node.AttestationDataType)
+	case attestationType == "join_token":
+		log.Error("Agents attested with a join token cannot re-attest")
+		return status.Error(codes.FailedPrecondition, "agents attested with a join token cannot re-attest")
+	}
+
+	nodeAttestor, ok := h.c.Catalog.GetNodeAttestorNamed(attestationType)
+	if !ok {
+		log.WithField(telemetry.NodeAttestorType, attestationType).Error("Could not find node attestor type")
+		return status.Error(codes.Unimplemented, fmt.Sprintf("could not find node attestor type %q", attestationType))
+	}
+
+	attestStream, err := nodeAttestor.Attest(ctx)
+	if err != nil {
+		log.WithError(err).Error("Unable to open attest stream")
+		return errorutil.WrapError(err, "unable to open attest stream")
+	}
+
+	// challenge/response loop
+	var attestResponse *nodeattestor.AttestResponse
+	for {
+		if err := attestStream.Send(&nodeattestor.AttestRequest{
+			AttestationData: request.AttestationData,
+			Response:        request.Response,
+			ReattestAgentId: agentID,
+		}); err != nil {
+			log.WithError(err).Error("Failed to re-attest")
+			return errorutil.WrapError(err, "failed to re-attest")
+		}
+		attestResponse, err = attestStream.Recv()
+		if err != nil {
+			log.WithError(err).Error("Failed to re-attest")
+			return errorutil.WrapError(err, "failed to re-attest")
+		}
+		if attestResponse.Challenge == nil {
+			break
+		}
+
+		if err := stream.Send(&node.ReattestResponse{
+			Challenge: attestResponse.Challenge,
+		}); err != nil {
+			log.WithError(err).Error("Failed to send challenge request")
+			return errorutil.WrapError(err, "failed to send challenge request")
+		}
+
+		request, err = stream.Recv()
+		if err != nil {
+			log.WithError(err).Error("Failed to receive challenge response")
+			return errorutil.WrapError(err, "failed to receive challenge response")
+		}
+	}
+	if err := attestStream.CloseSend(); err != nil {
+		log.WithError(err).Error("Failed to close send stream")
+		return status.Error(codes.Internal, err.Error())
+	}
+	if _, err := attestStream.Recv(); err != io.EOF {
+		log.WithError(err).Warn("expected EOF on attestation stream")
+	}
+
+	if attestResponse.AgentId != agentID {
+		log.WithField(telemetry.SPIFFEID, attestResponse.AgentId).Error("Re-attestation produced a different agent ID")
+		return status.Error(codes.PermissionDenied, "re-attestation produced a different agent ID")
+	}
+
+	selectors, err := h.resolveNodeSelectors(ctx, agentID, attestResponse, attestationType)
+	if err != nil {
+		log.WithError(err).Error("Failed to resolve node selectors")
+		return status.Error(codes.Internal, "failed to resolve node selectors")
+	}
+
+	if err := h.checkAgentBan(ctx, log, agentID, selectors); err != nil {
+		return err
+	}
+
+	if err := h.updateNodeSelectors(ctx, agentID, selectors); err != nil {
+		log.WithError(err).Error("Failed to update node selectors")
+		return status.Error(codes.Internal, "failed to update node selectors")
+	}
+
+	// Entries depending on the replaced selectors must be picked up by the
+	// next synchronization of the agent
+	h.fetchRegistrationEntriesCache.Remove(agentID)
+
+	log.Info("Node re-attestation request completed")
+
+	if err := stream.Send(&node.ReattestResponse{
+		Selectors: selectors,
+	}); err != nil {
+		log.WithError(err).Error("Failed to send response over stream")
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
 //FetchX509SVID gets Workload, Agent certs and CA trust bundles.
 //Also used for rotation Base Node SVID or the Registered Node SVID used for this call.
 //List can be empty to allow Node Agent cache refresh).
@@ -288,6 +523,8 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			return status.Error(codes.InvalidArgument, err.Error())
 		}
 
+		h.recordAgentSeen(ctx, log, agentID, request.AgentVersion)
+
 		regEntries, err := regentryutil.FetchRegistrationEntriesWithCache(ctx, h.c.Catalog.GetDataStore(), h.fetchRegistrationEntriesCache, agentID)
 		if err != nil {
 			log.WithError(err).Error("Failed to fetch agent registration entries")
@@ -391,9 +628,11 @@ func (h *Handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 
 	signLog.Debug("Signing downstream CA SVID")
 	svid, err := h.buildCASVID(ctx, ca.X509CASVIDParams{
-		SpiffeID:  csr.SpiffeID,
-		PublicKey: csr.PublicKey,
-		TTL:       time.Duration(entry.Ttl) * time.Second,
+		SpiffeID:     csr.SpiffeID,
+		PublicKey:    csr.PublicKey,
+		TTL:          time.Duration(entry.Ttl) * time.Second,
+		PathPrefixes: entry.DownstreamPathPrefixes,
+		DNSDomains:   entry.DownstreamDnsDomains,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign downstream CA SVID")
@@ -458,23 +697,29 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	found := false
+	var entries []*common.RegistrationEntry
 	for _, candidateEntry := range regEntries {
 		if candidateEntry.SpiffeId == req.Jsr.SpiffeId {
-			found = true
-			break
+			entries = append(entries, candidateEntry)
 		}
 	}
 
-	if !found {
+	if len(entries) == 0 {
 		log.Error("Caller is not authorized")
 		return nil, status.Error(codes.PermissionDenied, "caller is not authorized")
 	}
 
+	claims, err := h.renderJWTClaims(ctx, agentID, entries)
+	if err != nil {
+		log.WithError(err).Error("Failed to render JWT-SVID claims")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	token, err := h.c.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
 		SpiffeID: req.Jsr.SpiffeId,
 		TTL:      time.Duration(req.Jsr.Ttl) * time.Second,
 		Audience: req.Jsr.Audience,
+		Claims:   claims,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign JWT-SVID")
@@ -496,6 +741,41 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 	}, nil
 }
 
+// renderJWTClaims returns the custom claims for a JWT-SVID issued for the
+// given entries, which all share the same SPIFFE ID. Since the agent does not
+// tell which entry the JWT-SVID is for, the claims of the entry with the
+// lowest ID that has claims configured are used. Claim templates are rendered
+// against the selectors of the entry and the selectors of the agent.
+func (h *Handler) renderJWTClaims(ctx context.Context, agentID string, entries []*common.RegistrationEntry) (map[string]interface{}, error) {
+	var entry *common.RegistrationEntry
+	for _, candidateEntry := range entries {
+		if len(candidateEntry.JwtClaims) == 0 && len(candidateEntry.JwtClaimTemplates) == 0 {
+			continue
+		}
+		if entry == nil || candidateEntry.EntryId < entry.EntryId {
+			entry = candidateEntry
+		}
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	selectors := entry.Selectors
+	if len(entry.JwtClaimTemplates) > 0 {
+		resp, err := h.c.Catalog.GetDataStore().GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+			SpiffeId: agentID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to get agent selectors: %v", err)
+		}
+		if resp.Selectors != nil {
+			selectors = append(append([]*common.Selector(nil), selectors...), resp.Selectors.Selectors...)
+		}
+	}
+
+	return jwtsvid.RenderClaims(entry.SpiffeId, entry.JwtClaims, entry.JwtClaimTemplates, selectors)
+}
+
 func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUpstreamRequest) (resp *node.PushJWTKeyUpstreamResponse, err error) {
 	counter := telemetry_server.StartNodeAPIPushJWTKeyUpstreamCall(h.c.Metrics)
 	defer counter.Done(&err)
@@ -507,7 +787,7 @@ func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUp
 		return nil, status.Error(codes.InvalidArgument, "downstream SVID is required for this request")
 	}
 
-	_, ok = getDownstreamEntry(ctx)
+	entry, ok := getDownstreamEntry(ctx)
 	if !ok {
 		log.Error("Downstream entry is required for this request")
 		return nil, status.Error(codes.InvalidArgument, "downstream entry is required for this request")
@@ -519,7 +799,13 @@ func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUp
 		return nil, status.Error(codes.ResourceExhausted, err.Error())
 	}
 
-	jwtSigningKeys, err := h.c.Manager.PublishJWTKey(ctx, req.JwtKey)
+	jwtKey, err := constrainJWTKey(req.JwtKey, entry.DownstreamPathPrefixes)
+	if err != nil {
+		log.WithError(err).Error("Invalid JWT key")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	jwtSigningKeys, err := h.c.Manager.PublishJWTKey(ctx, jwtKey)
 	if err != nil {
 		log.WithError(err).Error("Could not publish new JWT key")
 		return nil, status.Error(codes.Internal, "error publishing new JWT key")
@@ -533,6 +819,184 @@ func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUp
 	}, nil
 }
 
+func (h *Handler) FetchDelegatedJWTKey(ctx context.Context, req *node.FetchDelegatedJWTKeyRequest) (_ *node.FetchDelegatedJWTKeyResponse, err error) {
+	counter := telemetry_server.StartNodeAPIFetchDelegatedJWTKeyCall(h.c.Metrics)
+	defer counter.Done(&err)
+	log := h.c.Log.WithField(telemetry.Method, telemetry.FetchDelegatedJWTKey)
+
+	if !h.c.DelegatedJWTKeysEnabled {
+		log.Error("JWT key delegation is not enabled")
+		return nil, status.Error(codes.FailedPrecondition, "JWT key delegation is not enabled")
+	}
+
+	peerCert, ok := getPeerCertificate(ctx)
+	if !ok {
+		log.Error("Request missing client SVID")
+		return nil, status.Error(codes.InvalidArgument, "client SVID is required for this request")
+	}
+
+	if err := h.limiter.Limit(ctx, PushJWTKey, 1); err != nil {
+		log.WithError(err).Error("Rejecting request due to JWT key delegation rate limiting")
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	agentID, err := getSpiffeIDFromCert(peerCert)
+	if err != nil {
+		log.WithError(err).Error("Failed to get SPIFFE ID from certificate")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log = log.WithField(telemetry.AgentID, agentID)
+
+	if err := validateDelegatedJWTPublicKey(req.PublicKey); err != nil {
+		log.WithError(err).Error("Invalid delegated JWT public key")
+		return nil, status.Errorf(codes.InvalidArgument, "invalid public key: %v", err)
+	}
+
+	spiffeIDs, err := h.delegatedJWTKeySPIFFEIDs(ctx, agentID)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch registration entries")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if len(spiffeIDs) == 0 {
+		log.Error("Agent is not authorized for any workload in the trust domain")
+		return nil, status.Error(codes.FailedPrecondition, "agent is not authorized for any workload in the trust domain")
+	}
+
+	kid, err := ca.NewDelegatedJWTKeyID(agentID)
+	if err != nil {
+		log.WithError(err).Error("Failed to generate delegated JWT key ID")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	notAfter := h.c.Clock.Now().Add(h.c.DelegatedJWTKeyTTL)
+	if notAfter.After(peerCert.NotAfter) {
+		notAfter = peerCert.NotAfter
+	}
+
+	jwtKey := &common.PublicKey{
+		PkixBytes: req.PublicKey,
+		Kid:       kid,
+		NotAfter:  notAfter.Unix(),
+		SpiffeIds: spiffeIDs,
+	}
+
+	if _, err := h.c.Manager.PublishJWTKey(ctx, jwtKey); err != nil {
+		log.WithError(err).Error("Could not publish delegated JWT key")
+		return nil, status.Error(codes.Internal, "error publishing delegated JWT key")
+	}
+
+	// Ensure we invalidate the cached bundle because PublishJWTKey updated it.
+	h.dsCache.DeleteBundleEntry(h.c.TrustDomain.String())
+
+	log.WithFields(logrus.Fields{
+		telemetry.Kid:        kid,
+		telemetry.Expiration: notAfter.UTC().Format(time.RFC3339),
+	}).Debug("Delegated JWT key to agent")
+
+	return &node.FetchDelegatedJWTKeyResponse{
+		JwtKey: jwtKey,
+	}, nil
+}
+
+// delegatedJWTKeySPIFFEIDs returns the SPIFFE IDs a JWT key delegated to the
+// agent is restricted to, i.e. the SPIFFE IDs in the trust domain the agent
+// is authorized for. Validators only accept exact matches, so the key cannot
+// sign for SPIFFE IDs nested under those, which may belong to other agents.
+func (h *Handler) delegatedJWTKeySPIFFEIDs(ctx context.Context, agentID string) ([]string, error) {
+	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.GetDataStore(), agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var spiffeIDs []string
+	for _, entry := range regEntries {
+		id, err := url.Parse(entry.SpiffeId)
+		if err != nil || id.Host != h.c.TrustDomain.Host || id.Path == "" || seen[entry.SpiffeId] {
+			continue
+		}
+		seen[entry.SpiffeId] = true
+		spiffeIDs = append(spiffeIDs, entry.SpiffeId)
+	}
+	sort.Strings(spiffeIDs)
+	return spiffeIDs, nil
+}
+
+func (h *Handler) ReportAgentsUpstream(ctx context.Context, req *node.ReportAgentsUpstreamRequest) (_ *node.ReportAgentsUpstreamResponse, err error) {
+	counter := telemetry_server.StartNodeAPIReportAgentsUpstreamCall(h.c.Metrics)
+	defer counter.Done(&err)
+	log := h.c.Log.WithField(telemetry.Method, telemetry.ReportAgentsUpstream)
+
+	peerCert, ok := getPeerCertificate(ctx)
+	if !ok {
+		log.Error("Downstream SVID is required for this request")
+		return nil, status.Error(codes.InvalidArgument, "downstream SVID is required for this request")
+	}
+
+	downstreamEntry, ok := getDownstreamEntry(ctx)
+	if !ok {
+		log.Error("Downstream entry is required for this request")
+		return nil, status.Error(codes.InvalidArgument, "downstream entry is required for this request")
+	}
+
+	err = h.limiter.Limit(ctx, ReportAgentsMsg, 1)
+	if err != nil {
+		log.WithError(err).Error("Rejecting request due to agent reporting rate limiting")
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	downstreamID, err := getSpiffeIDFromCert(peerCert)
+	if err != nil {
+		log.WithError(err).Error("Failed to get SPIFFE ID from certificate")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log = log.WithField(telemetry.CallerID, downstreamID)
+
+	for _, agent := range req.Agents {
+		if err := validateDownstreamAgentID(agent.SpiffeId, h.c.TrustDomain.Host, downstreamEntry.DownstreamPathPrefixes); err != nil {
+			log.WithError(err).WithField(telemetry.AgentID, agent.SpiffeId).Error("Invalid downstream agent ID")
+			return nil, status.Errorf(codes.InvalidArgument, "invalid agent ID: %v", err)
+		}
+	}
+
+	h.c.DownstreamAgents.ReportPage(downstreamID, req.Agents, req.Continued, req.More)
+	log.WithField(telemetry.Count, len(req.Agents)).Debug("Downstream agents reported")
+
+	return &node.ReportAgentsUpstreamResponse{}, nil
+}
+
+func (h *Handler) FetchFederatedBundles(ctx context.Context, req *node.FetchFederatedBundlesRequest) (_ *node.FetchFederatedBundlesResponse, err error) {
+	counter := telemetry_server.StartNodeAPIFetchFederatedBundlesCall(h.c.Metrics)
+	defer counter.Done(&err)
+	log := h.c.Log.WithField(telemetry.Method, telemetry.FetchFederatedBundles)
+
+	_, ok := getDownstreamEntry(ctx)
+	if !ok {
+		log.Error("Downstream entry is required for this request")
+		return nil, status.Error(codes.InvalidArgument, "downstream entry is required for this request")
+	}
+
+	resp, err := h.c.Catalog.GetDataStore().ListBundles(ctx, &datastore.ListBundlesRequest{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list bundles")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var bundles []*common.Bundle
+	for _, bundle := range resp.Bundles {
+		// The downstream server receives its own trust domain bundle through
+		// FetchBundle and FetchX509CASVID
+		if bundle.TrustDomainId == h.c.TrustDomain.String() {
+			continue
+		}
+		bundles = append(bundles, bundle)
+	}
+
+	return &node.FetchFederatedBundlesResponse{
+		Bundles: bundles,
+	}, nil
+}
+
 func (h *Handler) FetchBundle(ctx context.Context, req *node.FetchBundleRequest) (_ *node.FetchBundleResponse, err error) {
 	counter := telemetry_server.StartNodeAPIFetchBundleCall(h.c.Metrics)
 	defer counter.Done(&err)
@@ -562,7 +1026,9 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 
 	// peer certificate required for SVID fetching
 	case "/spire.api.node.Node/FetchX509SVID",
-		"/spire.api.node.Node/FetchJWTSVID":
+		"/spire.api.node.Node/FetchJWTSVID",
+		"/spire.api.node.Node/FetchDelegatedJWTKey",
+		"/spire.api.node.Node/Reattest":
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			h.c.Log.WithError(err).WithField(telemetry.Method, fullMethod).Error("Agent SVID is required for this request")
@@ -579,7 +1045,9 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 
 		ctx = withPeerCertificate(ctx, peerCert)
 	case "/spire.api.node.Node/FetchX509CASVID",
-		"/spire.api.node.Node/PushJWTKeyUpstream":
+		"/spire.api.node.Node/PushJWTKeyUpstream",
+		"/spire.api.node.Node/ReportAgentsUpstream",
+		"/spire.api.node.Node/FetchFederatedBundles":
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			h.c.Log.WithError(err).WithField(telemetry.Method, fullMethod).Error("Downstream SVID is required for this request")
@@ -742,55 +1210,92 @@ func (h *Handler) attest(attestStream nodeattestor.NodeAttestor_AttestClient, no
 	return attestStream.Recv()
 }
 
-func (h *Handler) attestToken(ctx context.Context, attestationData *common.AttestationData) (*nodeattestor.AttestResponse, error) {
+// attestToken validates the join token and returns the attestation response
+// along with the token use to record if the attestation completes.
+func (h *Handler) attestToken(ctx context.Context, attestationData *common.AttestationData) (*nodeattestor.AttestResponse, *datastore.JoinTokenUse, error) {
 	tokenValue := string(attestationData.Data)
-
-	agentID := (&url.URL{
-		Scheme: "spiffe",
-		Host:   h.c.TrustDomain.Host,
-		Path:   path.Join("spire", "agent", "join_token", tokenValue),
-	}).String()
-
-	attestedBefore, err := h.isAttested(ctx, agentID)
-	switch {
-	case err != nil:
-		h.c.Log.WithError(err).Error("Failed to determine if agent has already attested")
-		return nil, errorutil.WrapError(err, "failed to determine if agent has already attested")
-	case attestedBefore:
-		return nil, errors.New("join token has already been used")
-	}
-
 	ds := h.c.Catalog.GetDataStore()
+
 	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
 		Token: tokenValue,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if resp.JoinToken == nil {
-		return nil, errors.New("no such token")
+		return nil, nil, h.unknownJoinTokenError(ctx, tokenValue)
 	}
 	t := resp.JoinToken
 
 	if t.Token == "" {
-		return nil, errors.New("invalid join token")
+		return nil, nil, errors.New("invalid join token")
 	}
 
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
-	})
+	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
+		if _, err := ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+			Token: tokenValue,
+		}); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("join token expired")
+	}
+
+	maxUses := t.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+	use := int32(len(t.Uses)) + 1
+	if use > maxUses {
+		return nil, nil, errors.New("join token has already been used")
+	}
+
+	agentID, err := jointokenutil.MakeAgentID(h.c.TrustDomain.Host, t, use)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
-		return nil, errors.New("join token expired")
+	attestedBefore, err := h.isAttested(ctx, agentID)
+	switch {
+	case err != nil:
+		h.c.Log.WithError(err).Error("Failed to determine if agent has already attested")
+		return nil, nil, errorutil.WrapError(err, "failed to determine if agent has already attested")
+	case attestedBefore:
+		return nil, nil, errors.New("join token has already been used")
 	}
 
 	// If we're here, the token is valid
-	return &nodeattestor.AttestResponse{
+	attestResponse := &nodeattestor.AttestResponse{
+		AgentId:   agentID,
+		Selectors: t.Selectors,
+	}
+	tokenUse := &datastore.JoinTokenUse{
+		Use:     use,
 		AgentId: agentID,
-	}, nil
+		UsedAt:  h.c.Clock.Now().Unix(),
+	}
+	return attestResponse, tokenUse, nil
+}
+
+// unknownJoinTokenError tells apart tokens that never existed from
+// single-use tokens that were used and pruned since.
+func (h *Handler) unknownJoinTokenError(ctx context.Context, tokenValue string) error {
+	agentID, err := jointokenutil.MakeAgentID(h.c.TrustDomain.Host, &datastore.JoinToken{
+		Token: tokenValue,
+	}, 1)
+	if err != nil {
+		return errors.New("no such token")
+	}
+
+	attestedBefore, err := h.isAttested(ctx, agentID)
+	switch {
+	case err != nil:
+		h.c.Log.WithError(err).Error("Failed to determine if agent has already attested")
+		return errorutil.WrapError(err, "failed to determine if agent has already attested")
+	case attestedBefore:
+		return errors.New("join token has already been used")
+	default:
+		return errors.New("no such token")
+	}
 }
 
 func (h *Handler) updateAttestedNode(ctx context.Context, req *datastore.UpdateAttestedNodeRequest) error {
@@ -802,12 +1307,39 @@ func (h *Handler) updateAttestedNode(ctx context.Context, req *datastore.UpdateA
 	return nil
 }
 
+// recordAgentSeen records when the agent was last seen, along with the version
+// and address it was seen with. Datastore updates are throttled by the last
+// seen tracker. Failures are logged but do not fail the request.
+func (h *Handler) recordAgentSeen(ctx context.Context, log logrus.FieldLogger, agentID, agentVersion string) {
+	var address string
+	if peerAddress, ok := getPeerAddress(ctx); ok {
+		address = peerAddress.String()
+	}
+
+	now, ok := h.lastSeen.Seen(agentID, agentVersion, address)
+	if !ok {
+		return
+	}
+
+	if _, err := h.c.Catalog.GetDataStore().UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:      agentID,
+		LastSeenAt:    now.Unix(),
+		AgentVersion:  agentVersion,
+		RemoteAddress: address,
+	}); err != nil {
+		h.lastSeen.Forget(agentID)
+		log.WithError(err).Warn("Failed to record when the agent was last seen")
+	}
+}
+
 func (h *Handler) createAttestationEntry(ctx context.Context, cert *x509.Certificate, attestationType string) error {
 	ds := h.c.Catalog.GetDataStore()
 	return createAttestationEntry(ctx, ds, cert, attestationType)
 }
 
-func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) error {
+// resolveNodeSelectors returns the selectors produced by the node resolver and
+// the node attestor for the agent
+func (h *Handler) resolveNodeSelectors(ctx context.Context, baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) ([]*common.Selector, error) {
 	var selectors []*common.Selector
 
 	// Select node resolver based on request attestation type
@@ -818,7 +1350,7 @@ func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string,
 			BaseSpiffeIdList: []string{baseSpiffeID},
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if resolved := response.Map[baseSpiffeID]; resolved != nil {
@@ -828,8 +1360,11 @@ func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string,
 		h.c.Log.WithField(telemetry.Attestor, attestationType).Debug("could not find node resolver")
 	}
 
-	selectors = append(selectors, attestResponse.Selectors...)
+	return append(selectors, attestResponse.Selectors...), nil
+}
 
+// updateNodeSelectors replaces the selectors of the node with the given ones
+func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string, selectors []*common.Selector) error {
 	ds := h.c.Catalog.GetDataStore()
 	_, err := ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
 		Selectors: &datastore.NodeSelectors{
@@ -837,10 +1372,32 @@ func (h *Handler) updateNodeSelectors(ctx context.Context, baseSpiffeID string,
 			Selectors: selectors,
 		},
 	})
+	return err
+}
+
+// checkAgentBan fails with a PermissionDenied error if there is an unexpired
+// ban for the agent ID or any of its node selectors
+func (h *Handler) checkAgentBan(ctx context.Context, log logrus.FieldLogger, agentID string, selectors []*common.Selector) error {
+	resp, err := h.c.Catalog.GetDataStore().ListAgentBans(ctx, &datastore.ListAgentBansRequest{
+		BySpiffeId:  agentID,
+		BySelectors: selectors,
+	})
 	if err != nil {
-		return err
+		log.WithError(err).Error("Failed to list agent bans")
+		return status.Error(codes.Internal, "failed to list agent bans")
 	}
 
+	now := h.c.Clock.Now().Unix()
+	for _, ban := range resp.Bans {
+		if ban.ExpiresAt != 0 && ban.ExpiresAt <= now {
+			continue
+		}
+		if ban.Selector != nil {
+			log = log.WithField(telemetry.Selector, ban.Selector.Type+":"+ban.Selector.Value)
+		}
+		log.WithField(telemetry.Reason, ban.Reason).Error("Agent is banned")
+		return status.Error(codes.PermissionDenied, "agent is banned")
+	}
 	return nil
 }
 
@@ -1258,6 +1815,33 @@ func getSpiffeIDFromCert(cert *x509.Certificate) (string, error) {
 	return spiffeID.String(), nil
 }
 
+// validateDelegatedJWTPublicKey makes sure the public key can be used to
+// sign JWT-SVIDs. The requirements match the ones in jwtsvid.Signer.
+func validateDelegatedJWTPublicKey(pkixBytes []byte) error {
+	if len(pkixBytes) == 0 {
+		return errors.New("missing public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(pkixBytes)
+	if err != nil {
+		return err
+	}
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.Size() < 256 {
+			return fmt.Errorf("unsupported RSA key size: %d", publicKey.Size())
+		}
+	case *ecdsa.PublicKey:
+		switch bitSize := publicKey.Params().BitSize; bitSize {
+		case 256, 384:
+		default:
+			return fmt.Errorf("unsupported EC key size: %d", bitSize)
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
 func makeX509SVID(svid []*x509.Certificate) *node.X509SVID {
 	var certChain []byte
 	for _, cert := range svid {
@@ -1291,6 +1875,63 @@ func getDownstreamEntry(ctx context.Context) (*common.RegistrationEntry, bool) {
 	return entry, ok
 }
 
+// validateDownstreamAgentID validates the ID of an agent reported by a
+// downstream server. The downstream server can only have attested agents
+// with IDs permitted by the path prefixes of its downstream entry, so agents
+// outside of them are rejected.
+func validateDownstreamAgentID(agentID, trustDomain string, pathPrefixes []string) error {
+	if err := idutil.ValidateSpiffeID(agentID, idutil.AllowTrustDomainAgent(trustDomain)); err != nil {
+		return err
+	}
+	u, err := url.Parse(agentID)
+	if err != nil {
+		return err
+	}
+	if !idutil.PathHasAnyPrefix(u.Path, pathPrefixes) {
+		return fmt.Errorf("%q is not permitted by the path prefixes of the downstream entry", agentID)
+	}
+	return nil
+}
+
+// constrainJWTKey validates a JWT key pushed by a downstream server and
+// restricts it to the path prefixes of the downstream entry. Path prefixes
+// already on the key (i.e. set by a server further downstream) must be nested
+// under those of the entry.
+func constrainJWTKey(jwtKey *common.PublicKey, pathPrefixes []string) (*common.PublicKey, error) {
+	if jwtKey == nil {
+		return nil, errors.New("missing JWT key")
+	}
+	if jwtKey.Kid == "" {
+		return nil, errors.New("JWT key is missing the key ID") //nolint: golint // leading cap on error is ok
+	}
+	if _, err := x509.ParsePKIXPublicKey(jwtKey.PkixBytes); err != nil {
+		return nil, fmt.Errorf("failed to parse JWT key: %v", err)
+	}
+
+	jwtKey = proto.Clone(jwtKey).(*common.PublicKey)
+	for _, pathPrefix := range jwtKey.PathPrefixes {
+		if err := idutil.ValidatePathPrefix(pathPrefix); err != nil {
+			return nil, err
+		}
+		if !idutil.PathHasAnyPrefix(pathPrefix, pathPrefixes) {
+			return nil, fmt.Errorf("path prefix %q is not permitted for the downstream entry", pathPrefix)
+		}
+	}
+	if len(jwtKey.PathPrefixes) == 0 {
+		jwtKey.PathPrefixes = pathPrefixes
+	}
+	for _, spiffeID := range jwtKey.SpiffeIds {
+		id, err := url.Parse(spiffeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPIFFE ID %q: %v", spiffeID, err)
+		}
+		if !idutil.PathHasAnyPrefix(id.Path, jwtKey.PathPrefixes) {
+			return nil, fmt.Errorf("SPIFFE ID %q is not permitted for the downstream entry", spiffeID)
+		}
+	}
+	return jwtKey, nil
+}
+
 func getPeerAddress(ctx context.Context) (addr net.Addr, ok bool) {
 	p, ok := peer.FromContext(ctx)
 	if ok {
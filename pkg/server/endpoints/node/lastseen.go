@@ -0,0 +1,66 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+)
+
+const (
+	// lastSeenUpdateInterval is how often the last seen time of an agent is
+	// written to the datastore while the agent keeps syncing
+	lastSeenUpdateInterval = time.Minute
+)
+
+type lastSeenEntry struct {
+	at      time.Time
+	version string
+	address string
+}
+
+// lastSeenTracker throttles the last seen updates of agents so that the
+// datastore is not written on every sync
+type lastSeenTracker struct {
+	clock    clock.Clock
+	interval time.Duration
+
+	mu     sync.Mutex
+	agents map[string]lastSeenEntry
+}
+
+func newLastSeenTracker(clock clock.Clock) *lastSeenTracker {
+	return &lastSeenTracker{
+		clock:    clock,
+		interval: lastSeenUpdateInterval,
+		agents:   make(map[string]lastSeenEntry),
+	}
+}
+
+// Seen tracks that the agent was seen and returns whether the datastore is due
+// an update, which is the case when the last update is older than the update
+// interval, or when the agent version or address changed.
+func (t *lastSeenTracker) Seen(agentID, version, address string) (time.Time, bool) {
+	now := t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.agents[agentID]
+	if ok && now.Sub(entry.at) < t.interval && entry.version == version && entry.address == address {
+		return now, false
+	}
+	t.agents[agentID] = lastSeenEntry{
+		at:      now,
+		version: version,
+		address: address,
+	}
+	return now, true
+}
+
+// Forget drops the agent so the next time it is seen the datastore is updated
+func (t *lastSeenTracker) Forget(agentID string) {
+	t.mu.Lock()
+	delete(t.agents, agentID)
+	t.mu.Unlock()
+}
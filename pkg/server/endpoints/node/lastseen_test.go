@@ -0,0 +1,46 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastSeenTracker(t *testing.T) {
+	clock := clock.NewMock(t)
+	tracker := newLastSeenTracker(clock)
+
+	// First time seen
+	now, ok := tracker.Seen("agent", "1.0.0", "127.0.0.1:1")
+	require.True(t, ok)
+	require.Equal(t, clock.Now(), now)
+
+	// Seen again within the interval
+	clock.Add(lastSeenUpdateInterval - time.Second)
+	_, ok = tracker.Seen("agent", "1.0.0", "127.0.0.1:1")
+	require.False(t, ok)
+
+	// Other agents are tracked separately
+	_, ok = tracker.Seen("other", "1.0.0", "127.0.0.1:2")
+	require.True(t, ok)
+
+	// Address changed
+	_, ok = tracker.Seen("agent", "1.0.0", "127.0.0.1:3")
+	require.True(t, ok)
+
+	// Version changed
+	_, ok = tracker.Seen("agent", "1.1.0", "127.0.0.1:3")
+	require.True(t, ok)
+
+	// Seen again after the interval
+	clock.Add(lastSeenUpdateInterval)
+	_, ok = tracker.Seen("agent", "1.1.0", "127.0.0.1:3")
+	require.True(t, ok)
+
+	// Forgotten agents are updated the next time they are seen
+	tracker.Forget("agent")
+	_, ok = tracker.Seen("agent", "1.1.0", "127.0.0.1:3")
+	require.True(t, ok)
+}
@@ -20,6 +20,7 @@ const (
 	CSRMsg
 	JSRMsg
 	PushJWTKey
+	ReportAgentsMsg
 )
 
 type Limiter interface {
@@ -33,22 +34,24 @@ func NewLimiter(l logrus.FieldLogger) Limiter {
 
 func newLimiter(l logrus.FieldLogger) *limiter {
 	return &limiter{
-		attestRate:   rate.Limit(node.AttestLimit),
-		csrRate:      rate.Limit(node.CSRLimit),
-		jsrRate:      rate.Limit(node.JSRLimit),
-		jwtKeyRate:   rate.Limit(node.PushJWTKeyLimit),
-		lastNotified: make(map[string]time.Time),
-		limiters:     make(map[int]map[string]*rate.Limiter),
-		log:          l,
+		attestRate:       rate.Limit(node.AttestLimit),
+		csrRate:          rate.Limit(node.CSRLimit),
+		jsrRate:          rate.Limit(node.JSRLimit),
+		jwtKeyRate:       rate.Limit(node.PushJWTKeyLimit),
+		reportAgentsRate: rate.Limit(node.ReportAgentsLimit),
+		lastNotified:     make(map[string]time.Time),
+		limiters:         make(map[int]map[string]*rate.Limiter),
+		log:              l,
 	}
 }
 
 type limiter struct {
 	// Allowed number of messages per second
-	attestRate rate.Limit
-	csrRate    rate.Limit
-	jsrRate    rate.Limit
-	jwtKeyRate rate.Limit
+	attestRate       rate.Limit
+	csrRate          rate.Limit
+	jsrRate          rate.Limit
+	jwtKeyRate       rate.Limit
+	reportAgentsRate rate.Limit
 
 	lastNotified map[string]time.Time
 	limiters     map[int]map[string]*rate.Limiter
@@ -138,6 +141,8 @@ func (l *limiter) newLimiterFor(msgType int) (*rate.Limiter, error) {
 		return rate.NewLimiter(l.jsrRate, node.JSRLimit), nil
 	case PushJWTKey:
 		return rate.NewLimiter(l.jwtKeyRate, node.PushJWTKeyLimit), nil
+	case ReportAgentsMsg:
+		return rate.NewLimiter(l.reportAgentsRate, node.ReportAgentsLimit), nil
 	}
 
 	return nil, fmt.Errorf("limiter: unknown message type %v", msgType)
@@ -181,6 +186,8 @@ func (l *limiter) notify(callerID string, msgType int) {
 		action = "get JWTs signed"
 	case PushJWTKey:
 		action = "push JWK"
+	case ReportAgentsMsg:
+		action = "report agents"
 	default:
 		action = "do a questionable thing"
 	}
@@ -14,13 +14,18 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_registrationapi "github.com/spiffe/spire/pkg/common/telemetry/server/registrationapi"
+	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/util/jointokenutil"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"golang.org/x/net/context"
@@ -34,6 +39,8 @@ var isDNSLabel = regexp.MustCompile(`^[a-zA-Z0-9]([-]*[a-zA-Z0-9])+$`).MatchStri
 
 const defaultListEntriesPageSize = 50
 
+const defaultListAgentsPageSize = 50
+
 //Handler service is used to register SPIFFE IDs, and the attestation logic that should
 //be performed on a workload before those IDs can be issued.
 type Handler struct {
@@ -42,6 +49,18 @@ type Handler struct {
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
 	ServerCA    ca.ServerCA
+
+	// DownstreamAgents holds the agents reported by downstream servers
+	DownstreamAgents *nested.Inventory
+
+	// BundleSyncStatus provides the synchronization status of federated bundles
+	BundleSyncStatus BundleSyncStatusSource
+}
+
+// BundleSyncStatusSource provides the synchronization status of the bundles
+// fetched from federated bundle endpoints
+type BundleSyncStatusSource interface {
+	SyncStatus() []bundle_client.SyncStatus
 }
 
 //CreateEntry creates an entry in the Registration table,
@@ -458,7 +477,8 @@ func (h *Handler) UpdateFederatedBundle(ctx context.Context, request *registrati
 
 	ds := h.getDataStore()
 	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
-		Bundle: bundle,
+		Bundle:                 bundle,
+		PreserveSequenceNumber: request.ResetSequenceNumber,
 	}); err != nil {
 		log.WithError(err).Error("Failed to update federated bundle")
 		return nil, status.Error(codes.Internal, err.Error())
@@ -523,14 +543,32 @@ func (h *Handler) CreateJoinToken(ctx context.Context, request *registration.Joi
 		request.Token = u.String()
 	}
 
-	ds := h.getDataStore()
-	expiry := time.Now().Unix() + int64(request.Ttl)
+	if request.MaxUses < 0 {
+		log.Error("Max uses cannot be negative")
+		return nil, status.Error(codes.InvalidArgument, "max uses cannot be negative")
+	}
+	for _, selector := range request.Selectors {
+		if selector.Type == "" || selector.Value == "" {
+			log.Error("Request has an empty selector type or value")
+			return nil, status.Error(codes.InvalidArgument, "selector type and value are required")
+		}
+	}
+
+	joinToken := &datastore.JoinToken{
+		Token:           request.Token,
+		Expiry:          time.Now().Unix() + int64(request.Ttl),
+		AgentIdTemplate: request.AgentId,
+		Selectors:       request.Selectors,
+		MaxUses:         request.MaxUses,
+	}
+	if err := jointokenutil.ValidateAgentIDTemplate(h.TrustDomain.Host, joinToken); err != nil {
+		log.WithError(err).Error("Invalid agent ID")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
+	ds := h.getDataStore()
 	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
-		JoinToken: &datastore.JoinToken{
-			Token:  request.Token,
-			Expiry: expiry,
-		},
+		JoinToken: joinToken,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to register token")
@@ -573,9 +611,8 @@ func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.Evi
 		telemetry.SPIFFEID: spiffeID,
 	})
 
-	deletedNode, err := h.deleteAttestedNode(ctx, spiffeID)
+	deletedNode, err := h.evictAgent(ctx, log, spiffeID)
 	if err != nil {
-		log.WithError(err).Warn("Failed to evict agent")
 		return nil, err
 	}
 
@@ -589,13 +626,348 @@ func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.Evi
 func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
 	log := h.Log.WithField(telemetry.Method, telemetry.ListAgents)
 	ds := h.Catalog.GetDataStore()
-	req := &datastore.ListAttestedNodesRequest{}
+
+	for _, selector := range listReq.BySelectors {
+		if selector.Type == "" || selector.Value == "" {
+			log.Error("Invalid selector filter")
+			return nil, status.Error(codes.InvalidArgument, "selector must have a type and a value")
+		}
+	}
+
+	req := &datastore.ListAttestedNodesRequest{
+		BySelectors:    listReq.BySelectors,
+		FetchSelectors: true,
+	}
+	if listReq.ByAttestationType != "" {
+		req.ByAttestationType = &wrappers.StringValue{
+			Value: listReq.ByAttestationType,
+		}
+	}
+	if listReq.ByExpiresBefore != 0 {
+		req.ByExpiresBefore = &wrappers.Int64Value{
+			Value: listReq.ByExpiresBefore,
+		}
+	}
+	if listReq.ByExpiresAfter != 0 {
+		req.ByExpiresAfter = &wrappers.Int64Value{
+			Value: listReq.ByExpiresAfter,
+		}
+	}
+	if listReq.ByNotSeenSince != 0 {
+		req.ByLastSeenBefore = &wrappers.Int64Value{
+			Value: listReq.ByNotSeenSince,
+		}
+	}
+	if listReq.Pagination != nil {
+		var pageSize int32 = defaultListAgentsPageSize
+		if listReq.Pagination.PageSize != 0 {
+			pageSize = listReq.Pagination.PageSize
+		}
+		req.Pagination = &datastore.Pagination{
+			Token:    listReq.Pagination.Token,
+			PageSize: pageSize,
+		}
+	}
+
 	resp, err := ds.ListAttestedNodes(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Failed to list attested nodes")
 		return nil, err
 	}
-	return &registration.ListAgentsResponse{Nodes: resp.Nodes}, nil
+
+	listResp := &registration.ListAgentsResponse{Nodes: resp.Nodes}
+	if req.Pagination != nil && resp.Pagination != nil {
+		listResp.Pagination = &registration.Pagination{
+			Token:    resp.Pagination.Token,
+			PageSize: resp.Pagination.PageSize,
+		}
+	}
+	return listResp, nil
+}
+
+// BanAgent evicts the matching agents and prevents them from attesting again
+// until they are unbanned or the ban expires
+func (h *Handler) BanAgent(ctx context.Context, req *registration.BanAgentRequest) (_ *registration.BanAgentResponse, err error) {
+	counter := telemetry_registrationapi.StartBanAgentCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.BanAgent)
+
+	ban, err := h.prepareAgentBan(req)
+	if err != nil {
+		log.WithError(err).Error("Invalid agent ban")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log = log.WithFields(agentBanFields(ban.SpiffeId, ban.Selector))
+
+	ds := h.getDataStore()
+	existing, err := h.fetchAgentBan(ctx, ban.SpiffeId, ban.Selector)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch agent ban")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if existing != nil {
+		if existing.ExpiresAt == 0 || existing.ExpiresAt > time.Now().Unix() {
+			log.Error("Agent ban already exists")
+			return nil, status.Error(codes.AlreadyExists, "agent ban already exists")
+		}
+		// The existing ban expired and is replaced by the new one
+		if _, err := ds.DeleteAgentBan(ctx, &datastore.DeleteAgentBanRequest{
+			SpiffeId: existing.SpiffeId,
+			Selector: existing.Selector,
+		}); err != nil {
+			log.WithError(err).Error("Failed to delete expired agent ban")
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// The ban is created before evicting so agents cannot re-attest in
+	// between.
+	createResp, err := ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{
+		Ban: ban,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to create agent ban")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	agentIDs, err := h.listBannedAgentIDs(ctx, ban)
+	if err != nil {
+		log.WithError(err).Error("Failed to list banned agents")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &registration.BanAgentResponse{
+		Ban: createResp.Ban,
+	}
+	for _, agentID := range agentIDs {
+		evictedNode, err := h.evictAgent(ctx, log.WithField(telemetry.AgentID, agentID), agentID)
+		if err != nil {
+			return nil, err
+		}
+		resp.EvictedNodes = append(resp.EvictedNodes, evictedNode)
+	}
+
+	log.WithField(telemetry.Count, len(resp.EvictedNodes)).Info("Agent banned")
+	return resp, nil
+}
+
+// UnbanAgent removes an agent ban
+func (h *Handler) UnbanAgent(ctx context.Context, req *registration.UnbanAgentRequest) (_ *registration.UnbanAgentResponse, err error) {
+	counter := telemetry_registrationapi.StartUnbanAgentCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.UnbanAgent)
+
+	if err := validateAgentBanTarget(req.SpiffeId, req.Selector); err != nil {
+		log.WithError(err).Error("Invalid agent ban")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log = log.WithFields(agentBanFields(req.SpiffeId, req.Selector))
+
+	existing, err := h.fetchAgentBan(ctx, req.SpiffeId, req.Selector)
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch agent ban")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if existing == nil {
+		log.Error("Agent ban does not exist")
+		return nil, status.Error(codes.NotFound, "agent ban does not exist")
+	}
+
+	resp, err := h.getDataStore().DeleteAgentBan(ctx, &datastore.DeleteAgentBanRequest{
+		SpiffeId: req.SpiffeId,
+		Selector: req.Selector,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to delete agent ban")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Info("Agent unbanned")
+	return &registration.UnbanAgentResponse{
+		Ban: resp.Ban,
+	}, nil
+}
+
+// ListAgentBans lists the agent bans
+func (h *Handler) ListAgentBans(ctx context.Context, req *registration.ListAgentBansRequest) (_ *registration.ListAgentBansResponse, err error) {
+	counter := telemetry_registrationapi.StartListAgentBansCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.ListAgentBans)
+
+	resp, err := h.getDataStore().ListAgentBans(ctx, &datastore.ListAgentBansRequest{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list agent bans")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &registration.ListAgentBansResponse{
+		Bans: resp.Bans,
+	}, nil
+}
+
+//ListDownstreamAgents returns the list of agents reported by downstream servers
+func (h *Handler) ListDownstreamAgents(ctx context.Context, listReq *registration.ListDownstreamAgentsRequest) (*registration.ListDownstreamAgentsResponse, error) {
+	resp := &registration.ListDownstreamAgentsResponse{}
+	if h.DownstreamAgents == nil {
+		return resp, nil
+	}
+
+	for _, report := range h.DownstreamAgents.List() {
+		resp.DownstreamAgents = append(resp.DownstreamAgents, &registration.DownstreamAgents{
+			SpiffeId:   report.SpiffeID,
+			ReportedAt: report.ReportedAt.Unix(),
+			Agents:     report.Agents,
+		})
+	}
+	return resp, nil
+}
+
+//ListFederatedBundleSyncStatus returns the synchronization status of the bundles fetched from federated bundle endpoints
+func (h *Handler) ListFederatedBundleSyncStatus(ctx context.Context, listReq *registration.ListFederatedBundleSyncStatusRequest) (*registration.ListFederatedBundleSyncStatusResponse, error) {
+	resp := &registration.ListFederatedBundleSyncStatusResponse{}
+	if h.BundleSyncStatus == nil {
+		return resp, nil
+	}
+
+	for _, syncStatus := range h.BundleSyncStatus.SyncStatus() {
+		resp.Statuses = append(resp.Statuses, &registration.FederatedBundleSyncStatus{
+			TrustDomainId:   idutil.TrustDomainID(syncStatus.TrustDomain),
+			EndpointAddress: syncStatus.EndpointAddress,
+			LastSuccess:     unixTimeOrZero(syncStatus.LastSuccess),
+			LastError:       syncStatus.LastError,
+			LastErrorAt:     unixTimeOrZero(syncStatus.LastErrorAt),
+			NextRefresh:     unixTimeOrZero(syncStatus.NextRefresh),
+			SequenceNumber:  syncStatus.SequenceNumber,
+		})
+	}
+	return resp, nil
+}
+
+//CreateFederationRelationship creates a federation relationship. The bundle client manager picks it up without a restart
+func (h *Handler) CreateFederationRelationship(ctx context.Context, request *common.FederationRelationship) (_ *common.FederationRelationship, err error) {
+	counter := telemetry_registrationapi.StartCreateFederationRelationshipCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.CreateFederationRelationship)
+
+	relationship, err := h.prepareFederationRelationship(request)
+	if err != nil {
+		log.WithError(err).Error("Invalid federation relationship")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ds := h.getDataStore()
+	fetchResp, err := ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: relationship.TrustDomainId,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if fetchResp.Relationship != nil {
+		log.Error("Federation relationship already exists")
+		return nil, status.Error(codes.AlreadyExists, "federation relationship already exists")
+	}
+
+	resp, err := ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to create federation relationship")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.Relationship, nil
+}
+
+//ListFederationRelationships lists the federation relationships managed through the API
+func (h *Handler) ListFederationRelationships(ctx context.Context, request *registration.ListFederationRelationshipsRequest) (_ *registration.ListFederationRelationshipsResponse, err error) {
+	counter := telemetry_registrationapi.StartListFederationRelationshipsCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.ListFederationRelationships)
+
+	ds := h.getDataStore()
+	resp, err := ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list federation relationships")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &registration.ListFederationRelationshipsResponse{
+		Relationships: resp.Relationships,
+	}, nil
+}
+
+//UpdateFederationRelationship updates a federation relationship
+func (h *Handler) UpdateFederationRelationship(ctx context.Context, request *common.FederationRelationship) (_ *common.FederationRelationship, err error) {
+	counter := telemetry_registrationapi.StartUpdateFederationRelationshipCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.UpdateFederationRelationship)
+
+	relationship, err := h.prepareFederationRelationship(request)
+	if err != nil {
+		log.WithError(err).Error("Invalid federation relationship")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ds := h.getDataStore()
+	if err := h.requireFederationRelationship(ctx, relationship.TrustDomainId); err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return nil, err
+	}
+
+	resp, err := ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to update federation relationship")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.Relationship, nil
+}
+
+//DeleteFederationRelationship deletes a federation relationship. The federated bundle is kept
+func (h *Handler) DeleteFederationRelationship(ctx context.Context, request *registration.DeleteFederationRelationshipRequest) (_ *common.FederationRelationship, err error) {
+	counter := telemetry_registrationapi.StartDeleteFederationRelationshipCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.DeleteFederationRelationship)
+
+	trustDomainID, err := idutil.NormalizeSpiffeID(request.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		log.WithError(err).Error("Failed to normalize SPIFFE ID")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bundleMode, err := convertDeleteBundleMode(request.BundleMode)
+	if err != nil {
+		log.WithError(err).Error("Unknown delete bundle mode in request")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ds := h.getDataStore()
+	if err := h.requireFederationRelationship(ctx, trustDomainID); err != nil {
+		log.WithError(err).Error("Failed to fetch federation relationship")
+		return nil, err
+	}
+
+	resp, err := ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+		DeleteBundle:  request.DeleteBundle,
+		BundleMode:    bundleMode,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to delete federation relationship")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return resp.Relationship, nil
 }
 
 func (h *Handler) MintX509SVID(ctx context.Context, req *registration.MintX509SVIDRequest) (_ *registration.MintX509SVIDResponse, err error) {
@@ -704,6 +1076,146 @@ func (h *Handler) MintJWTSVID(ctx context.Context, req *registration.MintJWTSVID
 	}, nil
 }
 
+// RevokeJWTSVIDs adds the given JWT signing key IDs and subjects to the
+// revocation lists published in the server bundle
+func (h *Handler) RevokeJWTSVIDs(ctx context.Context, req *registration.RevokeJWTSVIDsRequest) (_ *registration.Bundle, err error) {
+	counter := telemetry_registrationapi.StartRevokeJWTSVIDsCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.RevokeJWTSVIDs)
+
+	subjects, err := h.validateJWTRevocations(log, req.KeyIds, req.SpiffeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := h.getDataStore()
+	fetchResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: h.TrustDomain.String(),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to get bundle from datastore")
+		return nil, status.Errorf(codes.Internal, "get bundle from datastore: %v", err)
+	}
+	if fetchResp.Bundle == nil {
+		log.Error("Bundle not found")
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	appendResp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:      h.TrustDomain.String(),
+			RevokedJwtKeyIds:   req.KeyIds,
+			RevokedJwtSubjects: subjects,
+		},
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to append revocations to bundle")
+		return nil, status.Errorf(codes.Internal, "append revocations to bundle: %v", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		telemetry.Kid:      req.KeyIds,
+		telemetry.SPIFFEID: subjects,
+	}).Info("Revoked JWT-SVIDs")
+
+	return &registration.Bundle{
+		Bundle: appendResp.Bundle,
+	}, nil
+}
+
+// UnrevokeJWTSVIDs removes the given JWT signing key IDs and subjects from
+// the revocation lists published in the server bundle
+func (h *Handler) UnrevokeJWTSVIDs(ctx context.Context, req *registration.UnrevokeJWTSVIDsRequest) (_ *registration.Bundle, err error) {
+	counter := telemetry_registrationapi.StartUnrevokeJWTSVIDsCall(h.Metrics)
+	telemetry_common.AddCallerID(counter, getCallerID(ctx))
+	defer counter.Done(&err)
+	log := h.Log.WithField(telemetry.Method, telemetry.UnrevokeJWTSVIDs)
+
+	subjects, err := h.validateJWTRevocations(log, req.KeyIds, req.SpiffeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := h.getDataStore()
+	fetchResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: h.TrustDomain.String(),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to get bundle from datastore")
+		return nil, status.Errorf(codes.Internal, "get bundle from datastore: %v", err)
+	}
+	if fetchResp.Bundle == nil {
+		log.Error("Bundle not found")
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	bundle := proto.Clone(fetchResp.Bundle).(*common.Bundle)
+	bundle.RevokedJwtKeyIds = removeStrings(bundle.RevokedJwtKeyIds, req.KeyIds)
+	bundle.RevokedJwtSubjects = removeStrings(bundle.RevokedJwtSubjects, subjects)
+
+	updateResp, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to remove revocations from bundle")
+		return nil, status.Errorf(codes.Internal, "remove revocations from bundle: %v", err)
+	}
+
+	log.WithFields(logrus.Fields{
+		telemetry.Kid:      req.KeyIds,
+		telemetry.SPIFFEID: subjects,
+	}).Info("Unrevoked JWT-SVIDs")
+
+	return &registration.Bundle{
+		Bundle: updateResp.Bundle,
+	}, nil
+}
+
+// validateJWTRevocations validates the key IDs and SPIFFE IDs of a JWT-SVID
+// revocation request and returns the normalized SPIFFE IDs
+func (h *Handler) validateJWTRevocations(log logrus.FieldLogger, keyIDs, spiffeIDs []string) ([]string, error) {
+	if len(keyIDs) == 0 && len(spiffeIDs) == 0 {
+		log.Error("Request must specify at least one key ID or SPIFFE ID")
+		return nil, status.Error(codes.InvalidArgument, "request must specify at least one key ID or SPIFFE ID")
+	}
+
+	for _, kid := range keyIDs {
+		if kid == "" {
+			log.Error("Request has an empty key ID")
+			return nil, status.Error(codes.InvalidArgument, "key ID cannot be empty")
+		}
+	}
+
+	var subjects []string
+	for _, spiffeID := range spiffeIDs {
+		subject, err := idutil.NormalizeSpiffeID(spiffeID, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+		if err != nil {
+			log.WithError(err).WithField(telemetry.SPIFFEID, spiffeID).Error("Invalid SPIFFE ID")
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+// removeStrings returns the values that are not in the given set of strings
+func removeStrings(values, remove []string) []string {
+	removeSet := make(map[string]bool, len(remove))
+	for _, value := range remove {
+		removeSet[value] = true
+	}
+
+	var out []string
+	for _, value := range values {
+		if !removeSet[value] {
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
 // GetNodeSelectors returns node (agent) selectors
 func (h *Handler) GetNodeSelectors(ctx context.Context, req *registration.GetNodeSelectorsRequest) (*registration.GetNodeSelectorsResponse, error) {
 	log := h.Log.WithField(telemetry.Method, telemetry.GetNodeSelectors)
@@ -724,6 +1236,53 @@ func (h *Handler) GetNodeSelectors(ctx context.Context, req *registration.GetNod
 	}, nil
 }
 
+// ExplainEntries reports which entries would be issued to a workload with the
+// given selectors running on the given agent, and why nearly matching entries
+// would not be
+func (h *Handler) ExplainEntries(ctx context.Context, req *registration.ExplainEntriesRequest) (*registration.ExplainEntriesResponse, error) {
+	log := h.Log.WithField(telemetry.Method, telemetry.ExplainEntries)
+
+	agentID, err := idutil.NormalizeSpiffeID(req.AgentId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+	if err != nil {
+		log.WithError(err).Error("Invalid agent ID")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(req.Selectors) == 0 {
+		log.Error("Request must specify at least one selector")
+		return nil, status.Error(codes.InvalidArgument, "request must specify at least one selector")
+	}
+
+	ds := h.getDataStore()
+	nodeResp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch attested node")
+		return nil, status.Errorf(codes.Internal, "fetch attested node: %v", err)
+	}
+	if nodeResp.Node == nil {
+		return nil, status.Errorf(codes.NotFound, "agent %q is not attested", agentID)
+	}
+
+	explanation, err := regentryutil.ExplainRegistrationEntries(ctx, ds, agentID, req.Selectors)
+	if err != nil {
+		log.WithError(err).Error("Failed to explain registration entries")
+		return nil, status.Errorf(codes.Internal, "explain registration entries: %v", err)
+	}
+
+	resp := &registration.ExplainEntriesResponse{
+		NodeSelectors: explanation.NodeSelectors,
+		Authorized:    explanation.Authorized,
+	}
+	for _, excluded := range explanation.Excluded {
+		resp.Excluded = append(resp.Excluded, &registration.ExcludedEntry{
+			Entry:  excluded.Entry,
+			Reason: excluded.Reason,
+		})
+	}
+	return resp, nil
+}
+
 func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
 	if agentID == "" {
 		return nil, errors.New("empty agent ID")
@@ -742,6 +1301,134 @@ func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*comm
 	return resp.Node, nil
 }
 
+// evictAgent deletes the attested node of the agent and revokes the JWT keys
+// delegated to it
+func (h *Handler) evictAgent(ctx context.Context, log logrus.FieldLogger, agentID string) (*common.AttestedNode, error) {
+	deletedNode, err := h.deleteAttestedNode(ctx, agentID)
+	if err != nil {
+		log.WithError(err).Warn("Failed to evict agent")
+		return nil, err
+	}
+
+	// Revoke any JWT keys delegated to the agent so JWT-SVIDs minted by the
+	// evicted agent stop validating once the bundle propagates.
+	revoked, err := ca.RevokeDelegatedJWTKeys(ctx, h.Catalog.GetDataStore(), h.TrustDomain.String(), agentID)
+	if err != nil {
+		log.WithError(err).Error("Failed to revoke delegated JWT keys")
+		return nil, status.Errorf(codes.Internal, "failed to revoke delegated JWT keys: %v", err)
+	}
+	if revoked > 0 {
+		log.WithField(telemetry.Count, revoked).Debug("Revoked delegated JWT keys")
+	}
+
+	return deletedNode, nil
+}
+
+func (h *Handler) prepareAgentBan(req *registration.BanAgentRequest) (*common.AgentBan, error) {
+	if err := validateAgentBanTarget(req.SpiffeId, req.Selector); err != nil {
+		return nil, err
+	}
+	if req.Ttl < 0 {
+		return nil, errors.New("ttl cannot be negative")
+	}
+
+	ban := &common.AgentBan{
+		Selector: req.Selector,
+		Reason:   req.Reason,
+	}
+	if req.SpiffeId != "" {
+		agentID, err := idutil.NormalizeSpiffeID(req.SpiffeId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+		if err != nil {
+			return nil, err
+		}
+		ban.SpiffeId = agentID
+	}
+	if req.Ttl > 0 {
+		ban.ExpiresAt = time.Now().Unix() + int64(req.Ttl)
+	}
+	return ban, nil
+}
+
+// fetchAgentBan returns the ban for the given SPIFFE ID or selector, or nil
+// if there is none
+func (h *Handler) fetchAgentBan(ctx context.Context, spiffeID string, selector *common.Selector) (*common.AgentBan, error) {
+	req := &datastore.ListAgentBansRequest{
+		BySpiffeId: spiffeID,
+	}
+	if selector != nil {
+		req.BySelectors = []*common.Selector{selector}
+	}
+	resp, err := h.getDataStore().ListAgentBans(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Bans) == 0 {
+		return nil, nil
+	}
+	return resp.Bans[0], nil
+}
+
+// listBannedAgentIDs returns the IDs of the attested agents matching the ban
+func (h *Handler) listBannedAgentIDs(ctx context.Context, ban *common.AgentBan) ([]string, error) {
+	ds := h.getDataStore()
+	if ban.SpiffeId != "" {
+		resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+			SpiffeId: ban.SpiffeId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.Node == nil {
+			return nil, nil
+		}
+		return []string{resp.Node.SpiffeId}, nil
+	}
+
+	nodesResp, err := ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var agentIDs []string
+	for _, node := range nodesResp.Nodes {
+		selectorsResp, err := ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+			SpiffeId: node.SpiffeId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, selector := range selectorsResp.Selectors.Selectors {
+			if selector.Type == ban.Selector.Type && selector.Value == ban.Selector.Value {
+				agentIDs = append(agentIDs, node.SpiffeId)
+				break
+			}
+		}
+	}
+	return agentIDs, nil
+}
+
+func validateAgentBanTarget(spiffeID string, selector *common.Selector) error {
+	switch {
+	case spiffeID == "" && selector == nil:
+		return errors.New("a SPIFFE ID or a selector is required")
+	case spiffeID != "" && selector != nil:
+		return errors.New("a SPIFFE ID and a selector cannot be banned together")
+	case selector != nil && (selector.Type == "" || selector.Value == ""):
+		return errors.New("selector must have a type and a value")
+	}
+	return nil
+}
+
+func agentBanFields(spiffeID string, selector *common.Selector) logrus.Fields {
+	if selector != nil {
+		return logrus.Fields{
+			telemetry.Selector: selector.Type + ":" + selector.Value,
+		}
+	}
+	return logrus.Fields{
+		telemetry.SPIFFEID: spiffeID,
+	}
+}
+
 func (h *Handler) normalizeSPIFFEIDForMinting(spiffeID string) (string, error) {
 	if spiffeID == "" {
 		return "", status.Error(codes.InvalidArgument, "request missing SPIFFE ID")
@@ -827,6 +1514,24 @@ func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forU
 		}
 	}
 
+	if !entry.Downstream && (len(entry.DownstreamPathPrefixes) > 0 || len(entry.DownstreamDnsDomains) > 0) {
+		return nil, errors.New("downstream constraints are only allowed on downstream entries")
+	}
+	for _, prefix := range entry.DownstreamPathPrefixes {
+		if err := idutil.ValidatePathPrefix(prefix); err != nil {
+			return nil, err
+		}
+	}
+	for _, domain := range entry.DownstreamDnsDomains {
+		if err := validateDNS(domain); err != nil {
+			return nil, fmt.Errorf("downstream dns domain %v failed validation: %v", domain, err)
+		}
+	}
+
+	if err := jwtsvid.ValidateClaims(entry.JwtClaims, entry.JwtClaimTemplates); err != nil {
+		return nil, fmt.Errorf("invalid JWT claims: %v", err)
+	}
+
 	entry.ParentId, err = idutil.NormalizeSpiffeID(entry.ParentId, idutil.AllowAnyInTrustDomain(h.TrustDomain.Host))
 	if err != nil {
 		return nil, err
@@ -854,6 +1559,46 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 	return ctx, nil
 }
 
+// prepareFederationRelationship validates the federation relationship and
+// returns a copy with a normalized trust domain ID
+func (h *Handler) prepareFederationRelationship(relationship *common.FederationRelationship) (*common.FederationRelationship, error) {
+	trustDomain, _, err := bundle_client.TrustDomainConfigFromRelationship(relationship)
+	if err != nil {
+		return nil, err
+	}
+
+	trustDomainID := idutil.TrustDomainID(trustDomain)
+	if trustDomainID == h.TrustDomain.String() {
+		return nil, errors.New("federation relationship trust domain cannot match server trust domain")
+	}
+
+	relationship = proto.Clone(relationship).(*common.FederationRelationship)
+	relationship.TrustDomainId = trustDomainID
+	return relationship, nil
+}
+
+// requireFederationRelationship returns a NotFound status error if there is
+// no federation relationship for the trust domain
+func (h *Handler) requireFederationRelationship(ctx context.Context, trustDomainID string) error {
+	resp, err := h.getDataStore().FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if resp.Relationship == nil {
+		return status.Error(codes.NotFound, "federation relationship not found")
+	}
+	return nil
+}
+
+func unixTimeOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
 func cloneRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	return proto.Clone(entry).(*common.RegistrationEntry)
 }
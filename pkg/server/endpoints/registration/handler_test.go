@@ -19,9 +19,13 @@ import (
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
@@ -71,9 +75,12 @@ type HandlerSuite struct {
 
 	server *grpc.Server
 
-	ds       *fakedatastore.DataStore
-	serverCA *fakeserverca.CA
-	handler  registration.RegistrationClient
+	ds               *fakedatastore.DataStore
+	serverCA         *fakeserverca.CA
+	clock            *clock.Mock
+	downstreamAgents *nested.Inventory
+	bundleSyncStatus *fakeBundleSyncStatus
+	handler          registration.RegistrationClient
 }
 
 func (s *HandlerSuite) SetupTest() {
@@ -81,6 +88,9 @@ func (s *HandlerSuite) SetupTest() {
 
 	s.ds = fakedatastore.New()
 	s.serverCA = fakeserverca.New(s.T(), "example.org", nil)
+	s.clock = clock.NewMock(s.T())
+	s.downstreamAgents = nested.NewInventory(s.clock)
+	s.bundleSyncStatus = &fakeBundleSyncStatus{}
 
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStore(s.ds)
@@ -91,6 +101,9 @@ func (s *HandlerSuite) SetupTest() {
 		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
 		Catalog:     catalog,
 		ServerCA:    s.serverCA,
+
+		DownstreamAgents: s.downstreamAgents,
+		BundleSyncStatus: s.bundleSyncStatus,
 	}
 
 	// we need to test a streaming API. without doing the same codegen we
@@ -270,6 +283,38 @@ func (s *HandlerSuite) TestUpdateFederatedBundle() {
 	}
 }
 
+func (s *HandlerSuite) TestUpdateFederatedBundleSequenceNumber() {
+	s.createBundle(&common.Bundle{
+		TrustDomainId:  "spiffe://otherdomain.org",
+		RootCas:        []*common.Certificate{{DerBytes: []byte("UPDATEME")}},
+		SequenceNumber: 5,
+	})
+
+	// updates increment the sequence number of the stored bundle
+	_, err := s.handler.UpdateFederatedBundle(context.Background(), &registration.FederatedBundle{
+		Bundle: bundleutil.BundleProtoFromRootCADER("spiffe://otherdomain.org", rootCA1DER),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(6), s.fetchBundleSequenceNumber("spiffe://otherdomain.org"))
+
+	// unless it is being reset
+	_, err = s.handler.UpdateFederatedBundle(context.Background(), &registration.FederatedBundle{
+		Bundle:              bundleutil.BundleProtoFromRootCADER("spiffe://otherdomain.org", rootCA2DER),
+		ResetSequenceNumber: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(0), s.fetchBundleSequenceNumber("spiffe://otherdomain.org"))
+}
+
+func (s *HandlerSuite) fetchBundleSequenceNumber(trustDomainID string) uint64 {
+	resp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.Bundle)
+	return resp.Bundle.SequenceNumber
+}
+
 func (s *HandlerSuite) TestDeleteFederatedBundle() {
 	testCases := []struct {
 		TrustDomainID string
@@ -404,6 +449,79 @@ func (s *HandlerSuite) TestCreateEntry() {
 			},
 			Err: status.Error(codes.AlreadyExists, "entry already exists").Error(),
 		},
+		{
+			Name: "DownstreamConstraints",
+			Entry: &common.RegistrationEntry{
+				ParentId:               "spiffe://example.org/parent",
+				SpiffeId:               "spiffe://example.org/downstream",
+				Selectors:              []*common.Selector{{Type: "B", Value: "b"}},
+				Downstream:             true,
+				DownstreamPathPrefixes: []string{"/ns/foo"},
+				DownstreamDnsDomains:   []string{"foo.example.org"},
+			},
+		},
+		{
+			Name: "DownstreamConstraintsWithoutDownstream",
+			Entry: &common.RegistrationEntry{
+				ParentId:               "spiffe://example.org/parent",
+				SpiffeId:               "spiffe://example.org/notdownstream",
+				Selectors:              []*common.Selector{{Type: "B", Value: "b"}},
+				DownstreamPathPrefixes: []string{"/ns/foo"},
+			},
+			Err: "downstream constraints are only allowed on downstream entries",
+		},
+		{
+			Name: "InvalidDownstreamPathPrefix",
+			Entry: &common.RegistrationEntry{
+				ParentId:               "spiffe://example.org/parent",
+				SpiffeId:               "spiffe://example.org/downstream2",
+				Selectors:              []*common.Selector{{Type: "B", Value: "b"}},
+				Downstream:             true,
+				DownstreamPathPrefixes: []string{"/ns/foo/"},
+			},
+			Err: `path prefix "/ns/foo/" must not end with a slash`,
+		},
+		{
+			Name: "InvalidDownstreamDNSDomain",
+			Entry: &common.RegistrationEntry{
+				ParentId:             "spiffe://example.org/parent",
+				SpiffeId:             "spiffe://example.org/downstream3",
+				Selectors:            []*common.Selector{{Type: "B", Value: "b"}},
+				Downstream:           true,
+				DownstreamDnsDomains: []string{"foo..example.org"},
+			},
+			Err: "downstream dns domain foo..example.org failed validation: label is empty",
+		},
+		{
+			Name: "JWTClaims",
+			Entry: &common.RegistrationEntry{
+				ParentId:          "spiffe://example.org/parent",
+				SpiffeId:          "spiffe://example.org/claims",
+				Selectors:         []*common.Selector{{Type: "B", Value: "b"}},
+				JwtClaims:         map[string]string{"tenant": "acme"},
+				JwtClaimTemplates: map[string]string{"namespace": `{{ selector "k8s:ns" }}`},
+			},
+		},
+		{
+			Name: "ReservedJWTClaim",
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/parent",
+				SpiffeId:  "spiffe://example.org/claims2",
+				Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+				JwtClaims: map[string]string{"sub": "spiffe://example.org/other"},
+			},
+			Err: `invalid JWT claims: claim "sub" is reserved`,
+		},
+		{
+			Name: "InvalidJWTClaimTemplate",
+			Entry: &common.RegistrationEntry{
+				ParentId:          "spiffe://example.org/parent",
+				SpiffeId:          "spiffe://example.org/claims3",
+				Selectors:         []*common.Selector{{Type: "B", Value: "b"}},
+				JwtClaimTemplates: map[string]string{"namespace": `{{ selector "k8s:ns" `},
+			},
+			Err: `invalid JWT claims: invalid template for claim "namespace"`,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -977,6 +1095,57 @@ func (s *HandlerSuite) TestCreateJoinToken() {
 	s.Require().Nil(resp)
 }
 
+func (s *HandlerSuite) TestCreateScopedJoinToken() {
+	selectors := []*common.Selector{{Type: "join_token", Value: "rack:1"}}
+
+	// Multi-use token with an agent ID template and selectors
+	resp, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token:     "multi",
+		Ttl:       60,
+		AgentId:   "imaging/rack1-{{ .Use }}",
+		Selectors: selectors,
+		MaxUses:   10,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("multi", resp.Token)
+
+	fetchResp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{Token: "multi"})
+	s.Require().NoError(err)
+	s.Require().NotNil(fetchResp.JoinToken)
+	s.Equal("imaging/rack1-{{ .Use }}", fetchResp.JoinToken.AgentIdTemplate)
+	spiretest.AssertProtoListEqual(s.T(), selectors, fetchResp.JoinToken.Selectors)
+	s.Equal(int32(10), fetchResp.JoinToken.MaxUses)
+
+	// Multi-use token whose agent ID does not change between uses
+	_, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Ttl:     60,
+		AgentId: "spiffe://example.org/spire/agent/imaging",
+		MaxUses: 10,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "agent ID template of a multi-use token must reference {{ .Use }}")
+
+	// Agent ID outside of the agent namespace
+	_, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Ttl:     60,
+		AgentId: "spiffe://example.org/workload",
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, `"spiffe://example.org/workload" is not a valid agent SPIFFE ID: invalid path: expecting "/spire/agent/*"`)
+
+	// Negative max uses
+	_, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Ttl:     60,
+		MaxUses: -1,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "max uses cannot be negative")
+
+	// Incomplete selector
+	_, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Ttl:       60,
+		Selectors: []*common.Selector{{Type: "join_token"}},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "selector type and value are required")
+}
+
 func (s *HandlerSuite) TestFetchBundle() {
 	// No bundle
 	resp, err := s.handler.FetchBundle(context.Background(), &common.Empty{})
@@ -1002,6 +1171,93 @@ func (s *HandlerSuite) TestFetchBundle() {
 	}, resp)
 }
 
+func (s *HandlerSuite) TestRevokeJWTSVIDs() {
+	// No bundle
+	resp, err := s.handler.RevokeJWTSVIDs(context.Background(), &registration.RevokeJWTSVIDsRequest{
+		KeyIds: []string{"KID"},
+	})
+	s.requireErrorContains(err, "bundle not found")
+	s.Require().Nil(resp)
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("EXAMPLE")},
+		},
+		RevokedJwtKeyIds: []string{"KID"},
+	})
+
+	// Nothing to revoke
+	resp, err = s.handler.RevokeJWTSVIDs(context.Background(), &registration.RevokeJWTSVIDsRequest{})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "request must specify at least one key ID or SPIFFE ID")
+	s.Require().Nil(resp)
+
+	// Subject outside of the trust domain
+	resp, err = s.handler.RevokeJWTSVIDs(context.Background(), &registration.RevokeJWTSVIDsRequest{
+		SpiffeIds: []string{"spiffe://domain.test/workload"},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, `"spiffe://domain.test/workload" does not belong to trust domain "example.org"`)
+	s.Require().Nil(resp)
+
+	// Success
+	resp, err = s.handler.RevokeJWTSVIDs(context.Background(), &registration.RevokeJWTSVIDsRequest{
+		KeyIds:    []string{"KID", "KID2"},
+		SpiffeIds: []string{"spiffe://example.org/workload"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"KID", "KID2"}, resp.Bundle.RevokedJwtKeyIds)
+	s.Require().Equal([]string{"spiffe://example.org/workload"}, resp.Bundle.RevokedJwtSubjects)
+	s.Require().Equal(uint64(1), resp.Bundle.SequenceNumber)
+
+	fetchResp, err := s.handler.FetchBundle(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Equal(resp.Bundle, fetchResp.Bundle)
+}
+
+func (s *HandlerSuite) TestUnrevokeJWTSVIDs() {
+	// No bundle
+	resp, err := s.handler.UnrevokeJWTSVIDs(context.Background(), &registration.UnrevokeJWTSVIDsRequest{
+		KeyIds: []string{"KID"},
+	})
+	s.requireErrorContains(err, "bundle not found")
+	s.Require().Nil(resp)
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("EXAMPLE")},
+		},
+		RevokedJwtKeyIds:   []string{"KID", "KID2"},
+		RevokedJwtSubjects: []string{"spiffe://example.org/workload", "spiffe://example.org/other"},
+	})
+
+	// Nothing to unrevoke
+	resp, err = s.handler.UnrevokeJWTSVIDs(context.Background(), &registration.UnrevokeJWTSVIDsRequest{})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "request must specify at least one key ID or SPIFFE ID")
+	s.Require().Nil(resp)
+
+	// Subject outside of the trust domain
+	resp, err = s.handler.UnrevokeJWTSVIDs(context.Background(), &registration.UnrevokeJWTSVIDsRequest{
+		SpiffeIds: []string{"spiffe://domain.test/workload"},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, `"spiffe://domain.test/workload" does not belong to trust domain "example.org"`)
+	s.Require().Nil(resp)
+
+	// Success
+	resp, err = s.handler.UnrevokeJWTSVIDs(context.Background(), &registration.UnrevokeJWTSVIDsRequest{
+		KeyIds:    []string{"KID", "UNKNOWN"},
+		SpiffeIds: []string{"spiffe://example.org/workload"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"KID2"}, resp.Bundle.RevokedJwtKeyIds)
+	s.Require().Equal([]string{"spiffe://example.org/other"}, resp.Bundle.RevokedJwtSubjects)
+	s.Require().Equal(uint64(1), resp.Bundle.SequenceNumber)
+
+	fetchResp, err := s.handler.FetchBundle(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Equal(resp.Bundle, fetchResp.Bundle)
+}
+
 func (s *HandlerSuite) TestEvictAgent() {
 	spiffeIDToRemove := "spiffe://example.org/spire/agent/join_token/token_a"
 	evictRequest := &registration.EvictAgentRequest{SpiffeID: spiffeIDToRemove}
@@ -1012,6 +1268,33 @@ func (s *HandlerSuite) TestEvictAgent() {
 	s.Equal(evictResponse.Node, node, "Evict did not remove spiffeID: %q", spiffeIDToRemove)
 }
 
+func (s *HandlerSuite) TestEvictAgentRevokesDelegatedJWTKeys() {
+	spiffeIDToRemove := "spiffe://example.org/spire/agent/join_token/token_a"
+	delegatedKid, err := ca.NewDelegatedJWTKeyID(spiffeIDToRemove)
+	s.Require().NoError(err)
+	_, err = s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId: "spiffe://example.org",
+			JwtSigningKeys: []*common.PublicKey{
+				{Kid: "server"},
+				{Kid: delegatedKid},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.createAttestedNode(spiffeIDToRemove)
+	_, err = s.handler.EvictAgent(context.Background(), &registration.EvictAgentRequest{SpiffeID: spiffeIDToRemove})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Bundle.JwtSigningKeys, 1)
+	s.Require().Equal("server", resp.Bundle.JwtSigningKeys[0].Kid)
+}
+
 func (s *HandlerSuite) TestEvictAgentWithNonExistentId() {
 	spiffeIDToAdd := "spiffe://example.org/spire/agent/join_token/token_a"
 	spiffeIDToRemove := "spiffe://example.org/spire/agent/join_token/token_b"
@@ -1024,6 +1307,140 @@ func (s *HandlerSuite) TestEvictAgentWithNonExistentId() {
 	s.Error(err, "Evict should have failed")
 }
 
+func (s *HandlerSuite) TestBanAgentWithSpiffeID() {
+	agentID := "spiffe://example.org/spire/agent/aws_iid/123456789012/us-east-1/i-0123456789abcdef0"
+	node := s.createAttestedNode(agentID)
+	s.createAttestedNode("spiffe://example.org/spire/agent/aws_iid/123456789012/us-east-1/i-other")
+
+	resp, err := s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{
+		SpiffeId: agentID,
+		Reason:   "compromised",
+		Ttl:      3600,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(agentID, resp.Ban.SpiffeId)
+	s.Require().Equal("compromised", resp.Ban.Reason)
+	s.Require().NotZero(resp.Ban.ExpiresAt)
+	spiretest.RequireProtoListEqual(s.T(), []*common.AttestedNode{node}, resp.EvictedNodes)
+
+	fetchResp, err := s.ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().Nil(fetchResp.Node)
+
+	// banning again fails while the ban is active
+	_, err = s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{SpiffeId: agentID})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.AlreadyExists, "agent ban already exists")
+}
+
+func (s *HandlerSuite) TestBanAgentWithSelector() {
+	selector := &common.Selector{Type: "x509pop", Value: "subject:cn:node1"}
+	agentID1 := "spiffe://example.org/spire/agent/x509pop/node1-a"
+	agentID2 := "spiffe://example.org/spire/agent/x509pop/node1-b"
+	agentID3 := "spiffe://example.org/spire/agent/x509pop/node2"
+	for agentID, selectors := range map[string][]*common.Selector{
+		agentID1: {selector},
+		agentID2: {{Type: "x509pop", Value: "ca:fingerprint:abc"}, selector},
+		agentID3: {{Type: "x509pop", Value: "subject:cn:node2"}},
+	} {
+		s.createAttestedNode(agentID)
+		_, err := s.ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{SpiffeId: agentID, Selectors: selectors},
+		})
+		s.Require().NoError(err)
+	}
+
+	resp, err := s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{
+		Selector: selector,
+	})
+	s.Require().NoError(err)
+	spiretest.AssertProtoEqual(s.T(), selector, resp.Ban.Selector)
+	s.Require().Zero(resp.Ban.ExpiresAt)
+
+	var evicted []string
+	for _, node := range resp.EvictedNodes {
+		evicted = append(evicted, node.SpiffeId)
+	}
+	s.Require().ElementsMatch([]string{agentID1, agentID2}, evicted)
+
+	listResp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Nodes, 1)
+	s.Require().Equal(agentID3, listResp.Nodes[0].SpiffeId)
+}
+
+func (s *HandlerSuite) TestBanAgentReplacesExpiredBan() {
+	agentID := "spiffe://example.org/spire/agent/join_token/token_a"
+	_, err := s.ds.CreateAgentBan(context.Background(), &datastore.CreateAgentBanRequest{
+		Ban: &common.AgentBan{SpiffeId: agentID, Reason: "old", ExpiresAt: 1},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{
+		SpiffeId: agentID,
+		Reason:   "new",
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.EvictedNodes)
+
+	listResp, err := s.handler.ListAgentBans(context.Background(), &registration.ListAgentBansRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Bans, 1)
+	s.Require().Equal("new", listResp.Bans[0].Reason)
+	s.Require().Zero(listResp.Bans[0].ExpiresAt)
+}
+
+func (s *HandlerSuite) TestBanAgentValidation() {
+	for _, tt := range []struct {
+		req *registration.BanAgentRequest
+		err string
+	}{
+		{
+			req: &registration.BanAgentRequest{},
+			err: "a SPIFFE ID or a selector is required",
+		},
+		{
+			req: &registration.BanAgentRequest{
+				SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a",
+				Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"},
+			},
+			err: "a SPIFFE ID and a selector cannot be banned together",
+		},
+		{
+			req: &registration.BanAgentRequest{Selector: &common.Selector{Type: "x509pop"}},
+			err: "selector must have a type and a value",
+		},
+		{
+			req: &registration.BanAgentRequest{SpiffeId: "spiffe://example.org/workload"},
+			err: `"spiffe://example.org/workload" is not a valid agent SPIFFE ID: invalid path: expecting "/spire/agent/*"`,
+		},
+		{
+			req: &registration.BanAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a", Ttl: -1},
+			err: "ttl cannot be negative",
+		},
+	} {
+		_, err := s.handler.BanAgent(context.Background(), tt.req)
+		spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, tt.err)
+	}
+}
+
+func (s *HandlerSuite) TestUnbanAgent() {
+	selector := &common.Selector{Type: "x509pop", Value: "subject:cn:node1"}
+
+	_, err := s.handler.UnbanAgent(context.Background(), &registration.UnbanAgentRequest{Selector: selector})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, "agent ban does not exist")
+
+	_, err = s.handler.BanAgent(context.Background(), &registration.BanAgentRequest{Selector: selector})
+	s.Require().NoError(err)
+
+	resp, err := s.handler.UnbanAgent(context.Background(), &registration.UnbanAgentRequest{Selector: selector})
+	s.Require().NoError(err)
+	spiretest.AssertProtoEqual(s.T(), selector, resp.Ban.Selector)
+
+	listResp, err := s.handler.ListAgentBans(context.Background(), &registration.ListAgentBansRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(listResp.Bans)
+}
+
 func (s *HandlerSuite) TestListAgents() {
 	// Creating attested nodes list
 	ctx := context.Background()
@@ -1043,6 +1460,123 @@ func (s *HandlerSuite) TestListAgents() {
 	s.Equal(listResponse.Nodes, expectedNodeList)
 }
 
+func (s *HandlerSuite) TestListAgentsWithFilters() {
+	ctx := context.Background()
+	now := time.Now()
+	selector := &common.Selector{Type: "a", Value: "1"}
+
+	node1 := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/test/node1",
+		AttestationDataType: "test",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+		LastSeenAt:          now.Unix(),
+	}
+	node2 := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/test/node2",
+		AttestationDataType: "test",
+		CertNotAfter:        now.Add(-time.Hour).Unix(),
+		LastSeenAt:          now.Add(-48 * time.Hour).Unix(),
+	}
+	node3 := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/join_token/node3",
+		AttestationDataType: "join_token",
+		CertNotAfter:        now.Add(2 * time.Hour).Unix(),
+	}
+	for _, node := range []*common.AttestedNode{node1, node2, node3} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+	}
+	_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  node1.SpiffeId,
+			Selectors: []*common.Selector{selector},
+		},
+	})
+	s.Require().NoError(err)
+
+	// Selectors are returned with the nodes
+	node1.Selectors = []*common.Selector{selector}
+
+	for _, tt := range []struct {
+		name          string
+		req           *registration.ListAgentsRequest
+		expectedNodes []*common.AttestedNode
+	}{
+		{
+			name:          "no filters",
+			req:           &registration.ListAgentsRequest{},
+			expectedNodes: []*common.AttestedNode{node3, node1, node2},
+		},
+		{
+			name:          "by attestation type",
+			req:           &registration.ListAgentsRequest{ByAttestationType: "test"},
+			expectedNodes: []*common.AttestedNode{node1, node2},
+		},
+		{
+			name:          "by selectors",
+			req:           &registration.ListAgentsRequest{BySelectors: []*common.Selector{selector}},
+			expectedNodes: []*common.AttestedNode{node1},
+		},
+		{
+			name: "by expiry window",
+			req: &registration.ListAgentsRequest{
+				ByExpiresAfter:  now.Unix(),
+				ByExpiresBefore: now.Add(90 * time.Minute).Unix(),
+			},
+			expectedNodes: []*common.AttestedNode{node1},
+		},
+		{
+			name:          "by not seen since",
+			req:           &registration.ListAgentsRequest{ByNotSeenSince: now.Add(-24 * time.Hour).Unix()},
+			expectedNodes: []*common.AttestedNode{node3, node2},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.handler.ListAgents(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectedNodes, resp.Nodes)
+			require.Nil(t, resp.Pagination)
+		})
+	}
+
+	_, err = s.handler.ListAgents(ctx, &registration.ListAgentsRequest{
+		BySelectors: []*common.Selector{{Type: "a"}},
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "selector must have a type and a value")
+}
+
+func (s *HandlerSuite) TestListAgentsWithPagination() {
+	ctx := context.Background()
+	node1 := s.createAttestedNode("spiffe://example.org/spire/agent/join_token/token_a")
+	node2 := s.createAttestedNode("spiffe://example.org/spire/agent/join_token/token_b")
+	node3 := s.createAttestedNode("spiffe://example.org/spire/agent/join_token/token_c")
+
+	resp, err := s.handler.ListAgents(ctx, &registration.ListAgentsRequest{
+		Pagination: &registration.Pagination{PageSize: 2},
+	})
+	s.Require().NoError(err)
+	spiretest.RequireProtoListEqual(s.T(), []*common.AttestedNode{node1, node2}, resp.Nodes)
+	s.Require().NotNil(resp.Pagination)
+	s.Equal(int32(2), resp.Pagination.PageSize)
+	s.NotEmpty(resp.Pagination.Token)
+
+	resp, err = s.handler.ListAgents(ctx, &registration.ListAgentsRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	spiretest.RequireProtoListEqual(s.T(), []*common.AttestedNode{node3}, resp.Nodes)
+	s.Require().NotNil(resp.Pagination)
+
+	resp, err = s.handler.ListAgents(ctx, &registration.ListAgentsRequest{
+		Pagination: resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Empty(resp.Nodes)
+	s.Require().NotNil(resp.Pagination)
+	s.Empty(resp.Pagination.Token)
+}
+
 func (s *HandlerSuite) TestListWithNoAgents() {
 	// Creating attested nodes list
 	ctx := context.Background()
@@ -1053,6 +1587,344 @@ func (s *HandlerSuite) TestListWithNoAgents() {
 	s.Len(listResponse.Nodes, 0)
 }
 
+func (s *HandlerSuite) TestListDownstreamAgents() {
+	ctx := context.Background()
+
+	listResponse, err := s.handler.ListDownstreamAgents(ctx, &registration.ListDownstreamAgentsRequest{})
+	s.Require().NoError(err)
+	s.Len(listResponse.DownstreamAgents, 0)
+
+	agentsA := []*common.AttestedNode{
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+	}
+	agentsB := []*common.AttestedNode{
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_b"},
+		{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_c"},
+	}
+	s.downstreamAgents.Report("spiffe://example.org/downstream-b", agentsB)
+	s.downstreamAgents.Report("spiffe://example.org/downstream-a", agentsA)
+
+	listResponse, err = s.handler.ListDownstreamAgents(ctx, &registration.ListDownstreamAgentsRequest{})
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*registration.DownstreamAgents{
+		{
+			SpiffeId:   "spiffe://example.org/downstream-a",
+			ReportedAt: s.clock.Now().Unix(),
+			Agents:     agentsA,
+		},
+		{
+			SpiffeId:   "spiffe://example.org/downstream-b",
+			ReportedAt: s.clock.Now().Unix(),
+			Agents:     agentsB,
+		},
+	}, listResponse.DownstreamAgents)
+}
+
+func (s *HandlerSuite) TestListFederatedBundleSyncStatus() {
+	ctx := context.Background()
+
+	listResponse, err := s.handler.ListFederatedBundleSyncStatus(ctx, &registration.ListFederatedBundleSyncStatusRequest{})
+	s.Require().NoError(err)
+	s.Len(listResponse.Statuses, 0)
+
+	now := s.clock.Now()
+	s.bundleSyncStatus.statuses = []bundle_client.SyncStatus{
+		{
+			TrustDomain:     "domain1.test",
+			EndpointAddress: "domain1.test:8443",
+			LastSuccess:     now,
+			NextRefresh:     now.Add(time.Minute),
+			SequenceNumber:  7,
+		},
+		{
+			TrustDomain:     "domain2.test",
+			EndpointAddress: "domain2.test:8443",
+			LastError:       "ohno",
+			LastErrorAt:     now,
+			NextRefresh:     now.Add(time.Second),
+		},
+	}
+
+	listResponse, err = s.handler.ListFederatedBundleSyncStatus(ctx, &registration.ListFederatedBundleSyncStatusRequest{})
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*registration.FederatedBundleSyncStatus{
+		{
+			TrustDomainId:   "spiffe://domain1.test",
+			EndpointAddress: "domain1.test:8443",
+			LastSuccess:     now.Unix(),
+			NextRefresh:     now.Add(time.Minute).Unix(),
+			SequenceNumber:  7,
+		},
+		{
+			TrustDomainId:   "spiffe://domain2.test",
+			EndpointAddress: "domain2.test:8443",
+			LastError:       "ohno",
+			LastErrorAt:     now.Unix(),
+			NextRefresh:     now.Add(time.Second).Unix(),
+		},
+	}, listResponse.Statuses)
+}
+
+func (s *HandlerSuite) TestCreateFederationRelationship() {
+	testCases := []struct {
+		Name         string
+		Relationship *common.FederationRelationship
+		Expected     *common.FederationRelationship
+		Err          string
+	}{
+		{
+			Name: "server trust domain",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: "https_web",
+			},
+			Err: "federation relationship trust domain cannot match server trust domain",
+		},
+		{
+			Name: "invalid trust domain",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://otherdomain.org/spire/agent",
+				BundleEndpointUrl:     "https://otherdomain.org",
+				BundleEndpointProfile: "https_web",
+			},
+			Err: `"spiffe://otherdomain.org/spire/agent" is not a valid trust domain SPIFFE ID: path is not empty`,
+		},
+		{
+			Name: "invalid profile",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:     "spiffe://otherdomain.org",
+				BundleEndpointUrl: "https://otherdomain.org",
+			},
+			Err: `bundle endpoint profile "" is not supported`,
+		},
+		{
+			Name: "success",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://OTHERDOMAIN.org",
+				BundleEndpointUrl:     "https://otherdomain.org:8443",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSpiffeId:      "spiffe://otherdomain.org/bundle-endpoint",
+			},
+			Expected: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://otherdomain.org",
+				BundleEndpointUrl:     "https://otherdomain.org:8443",
+				BundleEndpointProfile: "https_spiffe",
+				EndpointSpiffeId:      "spiffe://otherdomain.org/bundle-endpoint",
+			},
+		},
+		{
+			Name: "already exists",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://otherdomain.org",
+				BundleEndpointUrl:     "https://otherdomain.org",
+				BundleEndpointProfile: "https_web",
+			},
+			Err: "federation relationship already exists",
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Logf("case=%s", testCase.Name)
+		response, err := s.handler.CreateFederationRelationship(context.Background(), testCase.Relationship)
+
+		if testCase.Err != "" {
+			s.requireErrorContains(err, testCase.Err)
+			continue
+		}
+		s.Require().NoError(err)
+		spiretest.AssertProtoEqual(s.T(), testCase.Expected, response)
+
+		// assert that the relationship was created in the datastore
+		resp, err := s.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+			TrustDomainId: testCase.Expected.TrustDomainId,
+		})
+		s.Require().NoError(err)
+		spiretest.AssertProtoEqual(s.T(), testCase.Expected, resp.Relationship)
+	}
+}
+
+func (s *HandlerSuite) TestListFederationRelationships() {
+	response, err := s.handler.ListFederationRelationships(context.Background(), &registration.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(response.Relationships)
+
+	relationships := []*common.FederationRelationship{
+		{
+			TrustDomainId:         "spiffe://domain1.test",
+			BundleEndpointUrl:     "https://domain1.test",
+			BundleEndpointProfile: "https_web",
+		},
+		{
+			TrustDomainId:         "spiffe://domain2.test",
+			BundleEndpointUrl:     "https://domain2.test:8443",
+			BundleEndpointProfile: "https_spiffe",
+		},
+	}
+	for _, relationship := range relationships {
+		s.createFederationRelationship(relationship)
+	}
+
+	response, err = s.handler.ListFederationRelationships(context.Background(), &registration.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), relationships, response.Relationships)
+}
+
+func (s *HandlerSuite) TestUpdateFederationRelationship() {
+	s.createFederationRelationship(&common.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org",
+		BundleEndpointProfile: "https_web",
+	})
+
+	testCases := []struct {
+		Name         string
+		Relationship *common.FederationRelationship
+		Err          string
+	}{
+		{
+			Name: "server trust domain",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://example.org",
+				BundleEndpointUrl:     "https://example.org",
+				BundleEndpointProfile: "https_web",
+			},
+			Err: "federation relationship trust domain cannot match server trust domain",
+		},
+		{
+			Name: "endpoint SPIFFE ID with web profile",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://otherdomain.org",
+				BundleEndpointUrl:     "https://otherdomain.org",
+				BundleEndpointProfile: "https_web",
+				EndpointSpiffeId:      "spiffe://otherdomain.org/bundle-endpoint",
+			},
+			Err: `endpoint SPIFFE ID cannot be set with the "https_web" bundle endpoint profile`,
+		},
+		{
+			Name: "not found",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://unknowndomain.org",
+				BundleEndpointUrl:     "https://unknowndomain.org",
+				BundleEndpointProfile: "https_web",
+			},
+			Err: "federation relationship not found",
+		},
+		{
+			Name: "success",
+			Relationship: &common.FederationRelationship{
+				TrustDomainId:         "spiffe://otherdomain.org",
+				BundleEndpointUrl:     "https://otherdomain.org:8443",
+				BundleEndpointProfile: "https_spiffe",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Logf("case=%s", testCase.Name)
+		response, err := s.handler.UpdateFederationRelationship(context.Background(), testCase.Relationship)
+
+		if testCase.Err != "" {
+			s.requireErrorContains(err, testCase.Err)
+			continue
+		}
+		s.Require().NoError(err)
+		spiretest.AssertProtoEqual(s.T(), testCase.Relationship, response)
+
+		// assert that the relationship was updated in the datastore
+		resp, err := s.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+			TrustDomainId: testCase.Relationship.TrustDomainId,
+		})
+		s.Require().NoError(err)
+		spiretest.AssertProtoEqual(s.T(), testCase.Relationship, resp.Relationship)
+	}
+}
+
+func (s *HandlerSuite) TestDeleteFederationRelationship() {
+	relationship := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org",
+		BundleEndpointProfile: "https_web",
+	}
+	s.createFederationRelationship(relationship)
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("BLAH")},
+		},
+	})
+
+	testCases := []struct {
+		TrustDomainID string
+		Err           string
+	}{
+		{TrustDomainID: "spiffe://otherdomain.org/spire/agent", Err: `"spiffe://otherdomain.org/spire/agent" is not a valid trust domain SPIFFE ID: path is not empty`},
+		{TrustDomainID: "spiffe://otherdomain.org", Err: ""},
+		{TrustDomainID: "spiffe://otherdomain.org", Err: "federation relationship not found"},
+	}
+
+	for _, testCase := range testCases {
+		response, err := s.handler.DeleteFederationRelationship(context.Background(), &registration.DeleteFederationRelationshipRequest{
+			TrustDomainId: testCase.TrustDomainID,
+		})
+
+		if testCase.Err != "" {
+			s.requireErrorContains(err, testCase.Err)
+			continue
+		}
+		s.Require().NoError(err)
+		spiretest.AssertProtoEqual(s.T(), relationship, response)
+
+		// assert that the relationship was deleted but the bundle was kept
+		resp, err := s.ds.FetchFederationRelationship(context.Background(), &datastore.FetchFederationRelationshipRequest{
+			TrustDomainId: testCase.TrustDomainID,
+		})
+		s.Require().NoError(err)
+		s.Require().Nil(resp.Relationship)
+
+		bundleResp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+			TrustDomainId: testCase.TrustDomainID,
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(bundleResp.Bundle)
+	}
+}
+
+func (s *HandlerSuite) TestDeleteFederationRelationshipWithBundle() {
+	relationship := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://otherdomain.org",
+		BundleEndpointUrl:     "https://otherdomain.org",
+		BundleEndpointProfile: "https_web",
+	}
+	s.createFederationRelationship(relationship)
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: []byte("BLAH")},
+		},
+	})
+
+	_, err := s.handler.DeleteFederationRelationship(context.Background(), &registration.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		DeleteBundle:  true,
+		BundleMode:    registration.DeleteFederatedBundleRequest_Mode(-1),
+	})
+	s.requireErrorContains(err, "unhandled delete mode \"-1\"")
+
+	response, err := s.handler.DeleteFederationRelationship(context.Background(), &registration.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		DeleteBundle:  true,
+	})
+	s.Require().NoError(err)
+	spiretest.AssertProtoEqual(s.T(), relationship, response)
+
+	bundleResp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(bundleResp.Bundle)
+}
+
 func (s *HandlerSuite) TestMintX509SVID() {
 	bundle := &common.Bundle{
 		TrustDomainId: "spiffe://example.org",
@@ -1305,6 +2177,106 @@ func (s *HandlerSuite) TestGetNodeSelectors() {
 	s.Equal(resp.Selectors, expectedNodeSelectors)
 }
 
+func (s *HandlerSuite) TestExplainEntries() {
+	ctx := context.Background()
+	agentID := "spiffe://example.org/spire/agent/test/node1"
+	nodeSelectors := []*common.Selector{{Type: "test", Value: "cluster:a"}}
+	uid := &common.Selector{Type: "unix", Value: "uid:1000"}
+	gid := &common.Selector{Type: "unix", Value: "gid:1000"}
+
+	s.createAttestedNode(agentID)
+	s.setNodeSelectors(agentID, nodeSelectors)
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/server",
+		SpiffeId:  "spiffe://example.org/cluster-a",
+		Selectors: nodeSelectors,
+	})
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: "spiffe://example.org/spire/server",
+		SpiffeId: "spiffe://example.org/cluster-b",
+		Selectors: []*common.Selector{
+			{Type: "test", Value: "cluster:b"},
+		},
+	})
+	authorized := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  agentID,
+		SpiffeId:  "spiffe://example.org/authorized",
+		Selectors: []*common.Selector{uid},
+	})
+	viaAlias := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/cluster-a",
+		SpiffeId:  "spiffe://example.org/via-alias",
+		Selectors: []*common.Selector{uid},
+	})
+	missingSelector := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  agentID,
+		SpiffeId:  "spiffe://example.org/missing-selector",
+		Selectors: []*common.Selector{uid, gid},
+	})
+	wrongParent := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/agent/test/node2",
+		SpiffeId:  "spiffe://example.org/wrong-parent",
+		Selectors: []*common.Selector{uid},
+	})
+	wrongAlias := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/cluster-b",
+		SpiffeId:  "spiffe://example.org/wrong-alias",
+		Selectors: []*common.Selector{uid},
+	})
+	// Unrelated entries are not reported
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: "spiffe://example.org/unrelated",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:2000"},
+		},
+	})
+
+	resp, err := s.handler.ExplainEntries(ctx, &registration.ExplainEntriesRequest{
+		AgentId:   agentID,
+		Selectors: []*common.Selector{uid},
+	})
+	s.Require().NoError(err)
+	s.Equal(nodeSelectors, resp.NodeSelectors)
+
+	expectedAuthorized := []*common.RegistrationEntry{authorized, viaAlias}
+	util.SortRegistrationEntries(expectedAuthorized)
+	s.Equal(expectedAuthorized, resp.Authorized)
+
+	reasons := make(map[string]string)
+	for _, excluded := range resp.Excluded {
+		reasons[excluded.Entry.EntryId] = excluded.Reason
+	}
+	s.Equal(map[string]string{
+		missingSelector.EntryId: "workload is missing selectors: unix:gid:1000",
+		wrongParent.EntryId:     `parent ID "spiffe://example.org/spire/agent/test/node2" is neither the agent nor an entry authorized for the agent`,
+		wrongAlias.EntryId:      `parent ID "spiffe://example.org/cluster-b" is a node alias the agent does not belong to; agent is missing node selectors: test:cluster:b`,
+	}, reasons)
+}
+
+func (s *HandlerSuite) TestExplainEntriesFailures() {
+	ctx := context.Background()
+	selectors := []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+
+	_, err := s.handler.ExplainEntries(ctx, &registration.ExplainEntriesRequest{
+		AgentId:   "spiffe://example.org/workload",
+		Selectors: selectors,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, `"spiffe://example.org/workload" is not a valid agent SPIFFE ID: invalid path: expecting "/spire/agent/*"`)
+
+	_, err = s.handler.ExplainEntries(ctx, &registration.ExplainEntriesRequest{
+		AgentId: "spiffe://example.org/spire/agent/test/node1",
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.InvalidArgument, "request must specify at least one selector")
+
+	_, err = s.handler.ExplainEntries(ctx, &registration.ExplainEntriesRequest{
+		AgentId:   "spiffe://example.org/spire/agent/test/node1",
+		Selectors: selectors,
+	})
+	spiretest.RequireGRPCStatus(s.T(), err, codes.NotFound, `agent "spiffe://example.org/spire/agent/test/node1" is not attested`)
+}
+
 func (s *HandlerSuite) createAttestedNode(spiffeID string) *common.AttestedNode {
 	createResponse, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
 		Node: &common.AttestedNode{
@@ -1503,6 +2475,13 @@ func (s *HandlerSuite) createBundle(bundle *common.Bundle) {
 	s.Require().NoError(err)
 }
 
+func (s *HandlerSuite) createFederationRelationship(relationship *common.FederationRelationship) {
+	_, err := s.ds.CreateFederationRelationship(context.Background(), &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	s.Require().NoError(err)
+}
+
 func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	resp, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
 		Entry: entry,
@@ -1546,3 +2525,11 @@ func requireGRPCStatusCode(t *testing.T, err error, code codes.Code) {
 	s := status.Convert(err)
 	require.Equal(t, code, s.Code(), "GRPC status code should be %v", code)
 }
+
+type fakeBundleSyncStatus struct {
+	statuses []bundle_client.SyncStatus
+}
+
+func (f *fakeBundleSyncStatus) SyncStatus() []bundle_client.SyncStatus {
+	return f.statuses
+}
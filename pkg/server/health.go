@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/pkg/common/health"
+	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+const (
+	// subsystemCheckInterval is how often the subsystem health checks run
+	subsystemCheckInterval = 30 * time.Second
+
+	// dataStoreCheckTimeout bounds the datastore health check query
+	dataStoreCheckTimeout = 5 * time.Second
+
+	// dataStoreLatencyThreshold is the query latency above which the
+	// datastore is reported as degraded
+	dataStoreLatencyThreshold = time.Second
+)
+
+// DataStoreStatus is the detail of the datastore health check
+type DataStoreStatus struct {
+	LatencyMS int64 `json:"latency_ms"`
+}
+
+// addSubsystemHealthChecks registers health checks for the server subsystems
+// in addition to the top-level server check.
+func (s *Server) addSubsystemHealthChecks(healthChecks *health.Checker, cat *catalog.Repository, caManager *ca.Manager, bundleManager *bundle_client.Manager) error {
+	checks := map[string]health.CheckFunc{
+		"datastore":         dataStoreCheck(cat.GetDataStore(), s.config.TrustDomain.String(), clock.New()),
+		"ca":                caManager.CheckCA,
+		"notifiers":         caManager.CheckNotifiers,
+		"federated_bundles": bundleManager.CheckFederatedBundles,
+		"plugins":           cat.CheckExternalPlugins,
+	}
+	if caManager.HasUpstreamAuthority() {
+		checks["upstream_authority"] = caManager.CheckUpstreamAuthority
+	}
+
+	for name, check := range checks {
+		if err := healthChecks.AddCheck(name, check, subsystemCheckInterval); err != nil {
+			return fmt.Errorf("failed adding %s healthcheck: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// dataStoreCheck returns a check that measures the latency of fetching the
+// trust domain bundle. The check is unhealthy if the query fails and degraded
+// if it is slow.
+func dataStoreCheck(ds datastore.DataStore, trustDomainID string, clk clock.Clock) health.CheckFunc {
+	return func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), dataStoreCheckTimeout)
+		defer cancel()
+
+		start := clk.Now()
+		_, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+			TrustDomainId: trustDomainID,
+		})
+		latency := clk.Now().Sub(start)
+
+		status := DataStoreStatus{
+			LatencyMS: int64(latency / time.Millisecond),
+		}
+		switch {
+		case err != nil:
+			return status, fmt.Errorf("datastore query failed: %v", err)
+		case latency > dataStoreLatencyThreshold:
+			return status, health.Degraded(fmt.Errorf("datastore query took %s", latency))
+		}
+		return status, nil
+	}
+}
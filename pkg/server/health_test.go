@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataStoreCheck(t *testing.T) {
+	testCases := []struct {
+		name     string
+		latency  time.Duration
+		err      error
+		expected DataStoreStatus
+		checkErr string
+		degraded bool
+	}{
+		{
+			name:     "healthy",
+			latency:  10 * time.Millisecond,
+			expected: DataStoreStatus{LatencyMS: 10},
+		},
+		{
+			name:     "slow",
+			latency:  2 * time.Second,
+			expected: DataStoreStatus{LatencyMS: 2000},
+			checkErr: "datastore query took 2s",
+			degraded: true,
+		},
+		{
+			name:     "failing",
+			latency:  time.Millisecond,
+			err:      errors.New("ohno"),
+			expected: DataStoreStatus{LatencyMS: 1},
+			checkErr: "datastore query failed: ohno",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			clk := clock.NewMock(t)
+			ds := &slowDataStore{
+				DataStore: fakedatastore.New(),
+				clk:       clk,
+				latency:   testCase.latency,
+				err:       testCase.err,
+			}
+
+			check := dataStoreCheck(ds, "spiffe://example.org", clk)
+			details, err := check()
+			assert.Equal(t, testCase.expected, details)
+			if testCase.checkErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, testCase.checkErr)
+			assert.Equal(t, testCase.degraded, health.IsDegraded(err))
+		})
+	}
+}
+
+type slowDataStore struct {
+	datastore.DataStore
+
+	clk     *clock.Mock
+	latency time.Duration
+	err     error
+}
+
+func (ds *slowDataStore) FetchBundle(ctx context.Context, req *datastore.FetchBundleRequest) (*datastore.FetchBundleResponse, error) {
+	ds.clk.Add(ds.latency)
+	if ds.err != nil {
+		return nil, ds.err
+	}
+	return ds.DataStore.FetchBundle(ctx, req)
+}
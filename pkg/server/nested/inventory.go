@@ -0,0 +1,100 @@
+package nested
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// DownstreamAgents holds the agents last reported by a downstream server.
+type DownstreamAgents struct {
+	// SpiffeID is the SPIFFE ID of the downstream server
+	SpiffeID string
+
+	// ReportedAt is when the downstream server last reported its agents
+	ReportedAt time.Time
+
+	// Agents are the agents attested by the downstream server
+	Agents []*common.AttestedNode
+}
+
+// Inventory keeps track of the agents reported by downstream servers. The
+// reported agents are kept in memory only; they are not persisted to the
+// datastore since they must not be able to authenticate against this server.
+type Inventory struct {
+	clk clock.Clock
+
+	mtx     sync.RWMutex
+	reports map[string]DownstreamAgents
+	// pending holds the agents of the reports split across requests that
+	// have not been completed yet, keyed by downstream server SPIFFE ID
+	pending map[string][]*common.AttestedNode
+}
+
+// NewInventory creates a new, empty, downstream agent inventory.
+func NewInventory(clk clock.Clock) *Inventory {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Inventory{
+		clk:     clk,
+		reports: make(map[string]DownstreamAgents),
+		pending: make(map[string][]*common.AttestedNode),
+	}
+}
+
+// Report replaces the agents reported by the given downstream server.
+func (i *Inventory) Report(downstreamID string, agents []*common.AttestedNode) {
+	i.ReportPage(downstreamID, agents, false, false)
+}
+
+// ReportPage records a page of a report split across requests. If continued
+// is true, the agents are appended to the pending report of the downstream
+// server; otherwise a new report is started. The agents reported by the
+// downstream server are replaced once the last page, with more set to false,
+// is received. Pages that continue a report that was never started, for
+// example because this server restarted halfway through it, are dropped
+// until the downstream server starts a new report.
+func (i *Inventory) ReportPage(downstreamID string, agents []*common.AttestedNode, continued, more bool) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	if continued {
+		pending, ok := i.pending[downstreamID]
+		if !ok {
+			return
+		}
+		agents = append(pending, agents...)
+	}
+
+	if more {
+		i.pending[downstreamID] = agents
+		return
+	}
+	delete(i.pending, downstreamID)
+
+	i.reports[downstreamID] = DownstreamAgents{
+		SpiffeID:   downstreamID,
+		ReportedAt: i.clk.Now(),
+		Agents:     agents,
+	}
+}
+
+// List returns the agents reported by each downstream server, sorted by the
+// downstream server SPIFFE ID.
+func (i *Inventory) List() []DownstreamAgents {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+
+	reports := make([]DownstreamAgents, 0, len(i.reports))
+	for _, report := range i.reports {
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(a, b int) bool {
+		return reports[a].SpiffeID < reports[b].SpiffeID
+	})
+	return reports
+}
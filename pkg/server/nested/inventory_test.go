@@ -0,0 +1,72 @@
+package nested
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory(t *testing.T) {
+	clk := clock.NewMock(t)
+	inventory := NewInventory(clk)
+	require.Empty(t, inventory.List())
+
+	agentsA := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/a"}}
+	agentsB := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/b"}}
+	agentsC := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/c"}}
+
+	reportedAtB := clk.Now()
+	inventory.Report("spiffe://example.org/downstream-b", agentsB)
+	clk.Add(time.Minute)
+	inventory.Report("spiffe://example.org/downstream-a", agentsA)
+
+	reports := inventory.List()
+	require.Len(t, reports, 2)
+	require.Equal(t, "spiffe://example.org/downstream-a", reports[0].SpiffeID)
+	require.Equal(t, clk.Now(), reports[0].ReportedAt)
+	spiretest.RequireProtoListEqual(t, agentsA, reports[0].Agents)
+	require.Equal(t, "spiffe://example.org/downstream-b", reports[1].SpiffeID)
+	require.Equal(t, reportedAtB, reports[1].ReportedAt)
+	spiretest.RequireProtoListEqual(t, agentsB, reports[1].Agents)
+
+	// a new report replaces the previous one
+	clk.Add(time.Minute)
+	inventory.Report("spiffe://example.org/downstream-b", agentsC)
+	reports = inventory.List()
+	require.Len(t, reports, 2)
+	require.Equal(t, clk.Now(), reports[1].ReportedAt)
+	spiretest.RequireProtoListEqual(t, agentsC, reports[1].Agents)
+}
+
+func TestInventoryReportPages(t *testing.T) {
+	clk := clock.NewMock(t)
+	inventory := NewInventory(clk)
+
+	agentsA := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/a"}}
+	agentsB := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/b"}}
+	agentsC := []*common.AttestedNode{{SpiffeId: "spiffe://example.org/spire/agent/c"}}
+
+	inventory.Report("spiffe://example.org/downstream", agentsA)
+
+	// the previous report is kept until the last page is received
+	inventory.ReportPage("spiffe://example.org/downstream", agentsB, false, true)
+	reports := inventory.List()
+	require.Len(t, reports, 1)
+	spiretest.RequireProtoListEqual(t, agentsA, reports[0].Agents)
+
+	inventory.ReportPage("spiffe://example.org/downstream", agentsC, true, false)
+	reports = inventory.List()
+	require.Len(t, reports, 1)
+	spiretest.RequireProtoListEqual(t, append(agentsB, agentsC...), reports[0].Agents)
+
+	// pages continuing a report that was not started are dropped
+	inventory.ReportPage("spiffe://example.org/downstream", agentsA, true, true)
+	inventory.ReportPage("spiffe://example.org/downstream", agentsA, true, false)
+	reports = inventory.List()
+	require.Len(t, reports, 1)
+	spiretest.RequireProtoListEqual(t, append(agentsB, agentsC...), reports[0].Agents)
+}
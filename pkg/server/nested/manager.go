@@ -0,0 +1,298 @@
+package nested
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_reportAgentsCadence = time.Minute
+	_subscribeRetryDelay = 10 * time.Second
+
+	// _reportAgentsPageSize bounds the number of agents sent in each
+	// request, keeping requests well under the gRPC message size limit
+	_reportAgentsPageSize = 1000
+)
+
+// ManagerConfig is the config for the nested manager
+type ManagerConfig struct {
+	UpstreamAuthority upstreamauthority.UpstreamAuthority
+	DataStore         datastore.DataStore
+	TrustDomain       url.URL
+
+	// FederatesWith are the trust domain IDs whose bundles are maintained
+	// by this server through federation. Bundles received from the upstream
+	// server for these trust domains are ignored.
+	FederatesWith []string
+
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+
+	Clock clock.Clock
+}
+
+// Manager propagates state between this (downstream) server and the upstream
+// SPIRE server: it reports the agents attested by this server and stores the
+// bundles the upstream server is federated with.
+type Manager struct {
+	c ManagerConfig
+}
+
+// NewManager creates a new nested manager
+func NewManager(c ManagerConfig) *Manager {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	return &Manager{
+		c: c,
+	}
+}
+
+// Run runs the nested manager. Each task stops when the UpstreamAuthority
+// plugin does not support it.
+func (m *Manager) Run(ctx context.Context) error {
+	return util.RunTasks(ctx,
+		m.reportAgentsEvery,
+		m.syncFederatedBundles,
+	)
+}
+
+func (m *Manager) reportAgentsEvery(ctx context.Context) error {
+	log := m.c.Log.WithField(telemetry.RetryInterval, _reportAgentsCadence)
+
+	ticker := m.c.Clock.Ticker(_reportAgentsCadence)
+	defer ticker.Stop()
+
+	for {
+		err := m.reportAgents(ctx)
+		switch {
+		case status.Code(err) == codes.Unimplemented:
+			log.WithError(err).Debug("UpstreamAuthority plugin does not support reporting agents; not reporting agents upstream")
+			return nil
+		case err != nil && ctx.Err() == nil:
+			// Log an error on failure unless we're shutting down
+			log.WithError(err).Error("Failed to report agents upstream")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reportAgents reports the attested nodes upstream, paging through them and
+// sending one request per page. The upstream server replaces the reported
+// agents once the last page is received.
+func (m *Manager) reportAgents(ctx context.Context) (err error) {
+	counter := telemetry_server.StartNestedManagerReportAgentsCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	page, err := m.listAttestedNodes(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for continued := false; ; continued = true {
+		// Fetch the next page before sending this one, since the last page
+		// has to be flagged as such.
+		var next *datastore.ListAttestedNodesResponse
+		if len(page.Nodes) == _reportAgentsPageSize && page.Pagination != nil && page.Pagination.Token != "" {
+			next, err = m.listAttestedNodes(ctx, page.Pagination.Token)
+			if err != nil {
+				return err
+			}
+		}
+		more := next != nil && len(next.Nodes) > 0
+
+		if _, err := m.c.UpstreamAuthority.ReportAgents(ctx, &upstreamauthority.ReportAgentsRequest{
+			Agents:    page.Nodes,
+			Continued: continued,
+			More:      more,
+		}); err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		page = next
+	}
+}
+
+func (m *Manager) listAttestedNodes(ctx context.Context, token string) (*datastore.ListAttestedNodesResponse, error) {
+	return m.c.DataStore.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		Pagination: &datastore.Pagination{
+			Token:    token,
+			PageSize: _reportAgentsPageSize,
+		},
+	})
+}
+
+func (m *Manager) syncFederatedBundles(ctx context.Context) error {
+	for {
+		err := m.subscribeToFederatedBundles(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case status.Code(err) == codes.Unimplemented:
+			m.c.Log.WithError(err).Debug("UpstreamAuthority plugin does not support federated bundles; not syncing federated bundles")
+			return nil
+		case err != nil:
+			m.c.Log.WithError(err).Error("Failed to subscribe to federated bundles")
+		}
+
+		select {
+		case <-m.c.Clock.After(_subscribeRetryDelay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Manager) subscribeToFederatedBundles(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := m.c.UpstreamAuthority.SubscribeToFederatedBundles(ctx, &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.setFederatedBundles(ctx, resp.FederatedBundles); err != nil {
+			return err
+		}
+	}
+}
+
+// setFederatedBundles stores the bundles the upstream server is federated
+// with, marked in the datastore as stored on its behalf. Each update from the
+// upstream server holds all of its federated bundles, so marked bundles that
+// are missing from the update are deleted, including the ones the upstream
+// server stopped sending while this server was down.
+func (m *Manager) setFederatedBundles(ctx context.Context, bundles []*common.Bundle) error {
+	received := make(map[string]bool)
+	for _, bundle := range bundles {
+		log := m.c.Log.WithField(telemetry.TrustDomainID, bundle.TrustDomainId)
+		locallyManaged, err := m.isLocallyManaged(ctx, bundle.TrustDomainId)
+		if err != nil {
+			return err
+		}
+		if locallyManaged {
+			log.Debug("Ignoring upstream federated bundle for locally managed trust domain")
+			continue
+		}
+
+		if _, err := m.c.DataStore.SetBundle(ctx, &datastore.SetBundleRequest{
+			Bundle:   bundle,
+			Upstream: true,
+		}); err != nil {
+			return err
+		}
+		received[bundle.TrustDomainId] = true
+		log.Debug("Stored upstream federated bundle")
+	}
+
+	resp, err := m.c.DataStore.ListBundles(ctx, &datastore.ListBundlesRequest{
+		ByUpstream: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, bundle := range resp.Bundles {
+		if received[bundle.TrustDomainId] {
+			continue
+		}
+		log := m.c.Log.WithField(telemetry.TrustDomainID, bundle.TrustDomainId)
+
+		// The trust domain may have been federated with locally since the
+		// bundle was stored, in which case the bundle is no longer ours and
+		// only the mark is cleared.
+		locallyManaged, err := m.isLocallyManaged(ctx, bundle.TrustDomainId)
+		if err != nil {
+			return err
+		}
+		if locallyManaged {
+			if _, err := m.c.DataStore.SetBundle(ctx, &datastore.SetBundleRequest{
+				Bundle:                 bundle,
+				PreserveSequenceNumber: true,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := m.deleteBundle(ctx, bundle.TrustDomainId); err != nil {
+			return err
+		}
+		log.Info("Deleted federated bundle no longer sent by the upstream server")
+	}
+	return nil
+}
+
+// deleteBundle deletes a bundle the upstream server is no longer federated
+// with. Registration entries federated with the trust domain are dissociated
+// from it, since they can no longer trust it.
+func (m *Manager) deleteBundle(ctx context.Context, trustDomainID string) error {
+	resp, err := m.c.DataStore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Bundle == nil {
+		// Already deleted
+		return nil
+	}
+
+	_, err = m.c.DataStore.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: trustDomainID,
+		Mode:          datastore.DeleteBundleRequest_DISSOCIATE,
+	})
+	return err
+}
+
+// isLocallyManaged returns true if the bundle for the trust domain is owned
+// by this server, either because it is its own trust domain or because it is
+// kept up to date through federation, as configured in the server
+// configuration or through a federation relationship.
+func (m *Manager) isLocallyManaged(ctx context.Context, trustDomainID string) (bool, error) {
+	if trustDomainID == m.c.TrustDomain.String() {
+		return true, nil
+	}
+	for _, federatedID := range m.c.FederatesWith {
+		if trustDomainID == federatedID {
+			return true, nil
+		}
+	}
+
+	resp, err := m.c.DataStore.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: trustDomainID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Relationship != nil, nil
+}
@@ -0,0 +1,336 @@
+package nested
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/fakes/fakeupstreamauthority"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	trustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+)
+
+func TestManagerReportsAgents(t *testing.T) {
+	ctx := context.Background()
+	test := setupManagerTest(t, fakeupstreamauthority.Config{
+		TrustDomain:              trustDomain.Host,
+		DisallowFederatedBundles: true,
+	})
+	defer test.done()
+
+	nodeA := test.createAttestedNode(ctx, "spiffe://example.org/spire/agent/a")
+	test.run(ctx)
+
+	require.Eventually(t, func() bool {
+		return len(test.ua.ReportedAgents()) == 1
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoListEqual(t, []*common.AttestedNode{nodeA}, test.ua.ReportedAgents())
+
+	// agents are reported again on the next tick
+	nodeB := test.createAttestedNode(ctx, "spiffe://example.org/spire/agent/b")
+	test.clock.WaitForTicker(time.Minute, "waiting for the report ticker")
+	test.clock.Add(_reportAgentsCadence)
+
+	require.Eventually(t, func() bool {
+		return len(test.ua.ReportedAgents()) == 2
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoListEqual(t, []*common.AttestedNode{nodeA, nodeB}, test.ua.ReportedAgents())
+}
+
+func TestManagerReportsAgentsInPages(t *testing.T) {
+	ctx := context.Background()
+	test := setupManagerTest(t, fakeupstreamauthority.Config{
+		TrustDomain:              trustDomain.Host,
+		DisallowFederatedBundles: true,
+	})
+	defer test.done()
+
+	for i := 0; i < _reportAgentsPageSize+1; i++ {
+		test.createAttestedNode(ctx, fmt.Sprintf("spiffe://example.org/spire/agent/%04d", i))
+	}
+	test.run(ctx)
+
+	require.Eventually(t, func() bool {
+		return len(test.ua.ReportedAgents()) == _reportAgentsPageSize+1
+	}, time.Minute, 10*time.Millisecond)
+
+	reports := test.ua.AgentReports()
+	require.Len(t, reports, 2)
+	require.Len(t, reports[0].Agents, _reportAgentsPageSize)
+	require.False(t, reports[0].Continued)
+	require.True(t, reports[0].More)
+	require.Len(t, reports[1].Agents, 1)
+	require.True(t, reports[1].Continued)
+	require.False(t, reports[1].More)
+}
+
+func TestManagerStopsWhenUnsupported(t *testing.T) {
+	test := setupManagerTest(t, fakeupstreamauthority.Config{
+		TrustDomain:              trustDomain.Host,
+		DisallowReportAgents:     true,
+		DisallowFederatedBundles: true,
+	})
+	defer test.done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.NoError(t, test.m.Run(ctx))
+	require.NoError(t, ctx.Err(), "manager should stop before the context is done")
+}
+
+func TestManagerSyncsFederatedBundles(t *testing.T) {
+	ctx := context.Background()
+	test := setupManagerTest(t, fakeupstreamauthority.Config{
+		TrustDomain:          trustDomain.Host,
+		DisallowReportAgents: true,
+	})
+	defer test.done()
+
+	// bundles for the local trust domain and trust domains federated
+	// locally must not be overwritten
+	localBundle := &common.Bundle{
+		TrustDomainId: trustDomain.String(),
+		RootCas:       []*common.Certificate{{DerBytes: []byte("LOCAL")}},
+	}
+	federatedBundle := &common.Bundle{
+		TrustDomainId: "spiffe://federated.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("FEDERATED")}},
+	}
+	relatedBundle := &common.Bundle{
+		TrustDomainId: "spiffe://related.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("RELATED")}},
+	}
+	operatorBundle := &common.Bundle{
+		TrustDomainId: "spiffe://operator.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("OPERATOR")}},
+	}
+	test.setBundle(ctx, localBundle)
+	test.setBundle(ctx, federatedBundle)
+	test.setBundle(ctx, relatedBundle)
+	test.setBundle(ctx, operatorBundle)
+	test.createFederationRelationship(ctx, "spiffe://related.test")
+
+	upstreamBundleA := &common.Bundle{
+		TrustDomainId: "spiffe://a.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("A")}},
+	}
+	test.ua.SetFederatedBundles([]*common.Bundle{
+		{
+			TrustDomainId: trustDomain.String(),
+			RootCas:       []*common.Certificate{{DerBytes: []byte("UPSTREAM")}},
+		},
+		{
+			TrustDomainId: "spiffe://federated.test",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("UPSTREAM")}},
+		},
+		{
+			TrustDomainId: "spiffe://related.test",
+			RootCas:       []*common.Certificate{{DerBytes: []byte("UPSTREAM")}},
+		},
+		upstreamBundleA,
+	})
+
+	test.run(ctx)
+
+	require.Eventually(t, func() bool {
+		return test.fetchBundle(ctx, "spiffe://a.test") != nil
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoEqual(t, upstreamBundleA, test.fetchBundle(ctx, "spiffe://a.test"))
+	spiretest.RequireProtoEqual(t, localBundle, test.fetchBundle(ctx, trustDomain.String()))
+	spiretest.RequireProtoEqual(t, federatedBundle, test.fetchBundle(ctx, "spiffe://federated.test"))
+	spiretest.RequireProtoEqual(t, relatedBundle, test.fetchBundle(ctx, "spiffe://related.test"))
+
+	// updates to the upstream federated bundles are propagated
+	upstreamBundleB := &common.Bundle{
+		TrustDomainId: "spiffe://b.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("B")}},
+	}
+	test.ua.SetFederatedBundles([]*common.Bundle{upstreamBundleA, upstreamBundleB})
+
+	require.Eventually(t, func() bool {
+		return test.fetchBundle(ctx, "spiffe://b.test") != nil
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoEqual(t, upstreamBundleB, test.fetchBundle(ctx, "spiffe://b.test"))
+
+	// bundles the upstream server stops sending are deleted, and the
+	// entries federated with them dissociated, but bundles that were not
+	// received from the upstream server are kept
+	entry := test.createRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/spire/agent/a",
+		SpiffeId:      "spiffe://example.org/workload",
+		Selectors:     []*common.Selector{{Type: "test", Value: "test"}},
+		FederatesWith: []string{"spiffe://a.test", "spiffe://b.test"},
+	})
+	test.ua.SetFederatedBundles([]*common.Bundle{upstreamBundleB})
+
+	require.Eventually(t, func() bool {
+		return test.fetchBundle(ctx, "spiffe://a.test") == nil
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoEqual(t, upstreamBundleB, test.fetchBundle(ctx, "spiffe://b.test"))
+	spiretest.RequireProtoEqual(t, operatorBundle, test.fetchBundle(ctx, "spiffe://operator.test"))
+	spiretest.RequireProtoEqual(t, relatedBundle, test.fetchBundle(ctx, "spiffe://related.test"))
+	spiretest.RequireProtoEqual(t, federatedBundle, test.fetchBundle(ctx, "spiffe://federated.test"))
+	require.Equal(t, []string{"spiffe://b.test"}, test.fetchRegistrationEntry(ctx, entry.EntryId).FederatesWith)
+}
+
+func TestManagerDeletesUpstreamBundlesStoredBeforeRestart(t *testing.T) {
+	ctx := context.Background()
+	test := setupManagerTest(t, fakeupstreamauthority.Config{
+		TrustDomain:          trustDomain.Host,
+		DisallowReportAgents: true,
+	})
+	defer test.done()
+
+	// bundles stored on behalf of the upstream server by a previous run are
+	// deleted if the upstream server no longer sends them
+	test.setUpstreamBundle(ctx, &common.Bundle{
+		TrustDomainId: "spiffe://a.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("A")}},
+	})
+	operatorBundle := &common.Bundle{
+		TrustDomainId: "spiffe://operator.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("OPERATOR")}},
+	}
+	test.setBundle(ctx, operatorBundle)
+
+	upstreamBundleB := &common.Bundle{
+		TrustDomainId: "spiffe://b.test",
+		RootCas:       []*common.Certificate{{DerBytes: []byte("B")}},
+	}
+	test.ua.SetFederatedBundles([]*common.Bundle{upstreamBundleB})
+
+	test.run(ctx)
+
+	require.Eventually(t, func() bool {
+		return test.fetchBundle(ctx, "spiffe://a.test") == nil
+	}, time.Minute, 10*time.Millisecond)
+	spiretest.RequireProtoEqual(t, upstreamBundleB, test.fetchBundle(ctx, "spiffe://b.test"))
+	spiretest.RequireProtoEqual(t, operatorBundle, test.fetchBundle(ctx, "spiffe://operator.test"))
+}
+
+type managerTest struct {
+	t     *testing.T
+	clock *clock.Mock
+	ds    *fakedatastore.DataStore
+	ua    *fakeupstreamauthority.UpstreamAuthority
+	m     *Manager
+
+	closers []func()
+}
+
+func setupManagerTest(t *testing.T, config fakeupstreamauthority.Config) *managerTest {
+	log, _ := test.NewNullLogger()
+	upstreamAuthority, ua, uaDone := fakeupstreamauthority.Load(t, config)
+
+	test := &managerTest{
+		t:       t,
+		clock:   clock.NewMock(t),
+		ds:      fakedatastore.New(),
+		ua:      ua,
+		closers: []func(){uaDone},
+	}
+	test.m = NewManager(ManagerConfig{
+		UpstreamAuthority: upstreamAuthority,
+		DataStore:         test.ds,
+		TrustDomain:       trustDomain,
+		FederatesWith:     []string{"spiffe://federated.test"},
+		Log:               log,
+		Metrics:           fakemetrics.New(),
+		Clock:             test.clock,
+	})
+	return test
+}
+
+func (m *managerTest) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.m.Run(ctx)
+	}()
+	m.closers = append(m.closers, func() {
+		cancel()
+		if err := <-errCh; err != context.Canceled {
+			require.NoError(m.t, err)
+		}
+	})
+}
+
+func (m *managerTest) done() {
+	for i := len(m.closers) - 1; i >= 0; i-- {
+		m.closers[i]()
+	}
+}
+
+func (m *managerTest) createAttestedNode(ctx context.Context, spiffeID string) *common.AttestedNode {
+	resp, err := m.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            spiffeID,
+			AttestationDataType: "test",
+		},
+	})
+	require.NoError(m.t, err)
+	return resp.Node
+}
+
+func (m *managerTest) setBundle(ctx context.Context, bundle *common.Bundle) {
+	_, err := m.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundle,
+	})
+	require.NoError(m.t, err)
+}
+
+func (m *managerTest) setUpstreamBundle(ctx context.Context, bundle *common.Bundle) {
+	_, err := m.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle:   bundle,
+		Upstream: true,
+	})
+	require.NoError(m.t, err)
+}
+
+func (m *managerTest) createFederationRelationship(ctx context.Context, trustDomainID string) {
+	_, err := m.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		Relationship: &common.FederationRelationship{
+			TrustDomainId:         trustDomainID,
+			BundleEndpointUrl:     "https://" + strings.TrimPrefix(trustDomainID, "spiffe://"),
+			BundleEndpointProfile: "https_web",
+		},
+	})
+	require.NoError(m.t, err)
+}
+
+func (m *managerTest) createRegistrationEntry(ctx context.Context, entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := m.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(m.t, err)
+	return resp.Entry
+}
+
+func (m *managerTest) fetchRegistrationEntry(ctx context.Context, entryID string) *common.RegistrationEntry {
+	resp, err := m.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	require.NoError(m.t, err)
+	return resp.Entry
+}
+
+func (m *managerTest) fetchBundle(ctx context.Context, trustDomainID string) *common.Bundle {
+	resp, err := m.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	require.NoError(m.t, err)
+	return resp.Bundle
+}
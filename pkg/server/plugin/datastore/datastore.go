@@ -12,65 +12,101 @@ import (
 	"google.golang.org/grpc"
 )
 
-type AppendBundleRequest = datastore.AppendBundleRequest                           //nolint: golint
-type AppendBundleResponse = datastore.AppendBundleResponse                         //nolint: golint
-type BySelectors = datastore.BySelectors                                           //nolint: golint
-type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior               //nolint: golint
-type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest               //nolint: golint
-type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse             //nolint: golint
-type CreateBundleRequest = datastore.CreateBundleRequest                           //nolint: golint
-type CreateBundleResponse = datastore.CreateBundleResponse                         //nolint: golint
-type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                     //nolint: golint
-type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                   //nolint: golint
-type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest     //nolint: golint
-type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse   //nolint: golint
-type DataStoreClient = datastore.DataStoreClient                                   //nolint: golint
-type DataStoreServer = datastore.DataStoreServer                                   //nolint: golint
-type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest               //nolint: golint
-type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse             //nolint: golint
-type DeleteBundleRequest = datastore.DeleteBundleRequest                           //nolint: golint
-type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                 //nolint: golint
-type DeleteBundleResponse = datastore.DeleteBundleResponse                         //nolint: golint
-type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                     //nolint: golint
-type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                   //nolint: golint
-type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest     //nolint: golint
-type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse   //nolint: golint
-type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                 //nolint: golint
-type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse               //nolint: golint
-type FetchBundleRequest = datastore.FetchBundleRequest                             //nolint: golint
-type FetchBundleResponse = datastore.FetchBundleResponse                           //nolint: golint
-type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                       //nolint: golint
-type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                     //nolint: golint
-type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest       //nolint: golint
-type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse     //nolint: golint
-type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                   //nolint: golint
-type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                 //nolint: golint
-type JoinToken = datastore.JoinToken                                               //nolint: golint
-type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                 //nolint: golint
-type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse               //nolint: golint
-type ListBundlesRequest = datastore.ListBundlesRequest                             //nolint: golint
-type ListBundlesResponse = datastore.ListBundlesResponse                           //nolint: golint
-type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest     //nolint: golint
-type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse   //nolint: golint
-type NodeSelectors = datastore.NodeSelectors                                       //nolint: golint
-type Pagination = datastore.Pagination                                             //nolint: golint
-type PruneBundleRequest = datastore.PruneBundleRequest                             //nolint: golint
-type PruneBundleResponse = datastore.PruneBundleResponse                           //nolint: golint
-type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                     //nolint: golint
-type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                   //nolint: golint
-type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest   //nolint: golint
-type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse //nolint: golint
-type SetBundleRequest = datastore.SetBundleRequest                                 //nolint: golint
-type SetBundleResponse = datastore.SetBundleResponse                               //nolint: golint
-type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                   //nolint: golint
-type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                 //nolint: golint
-type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer         //nolint: golint
-type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest               //nolint: golint
-type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse             //nolint: golint
-type UpdateBundleRequest = datastore.UpdateBundleRequest                           //nolint: golint
-type UpdateBundleResponse = datastore.UpdateBundleResponse                         //nolint: golint
-type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest     //nolint: golint
-type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse   //nolint: golint
+type AppendBundleHistoryRequest = datastore.AppendBundleHistoryRequest                     //nolint: golint
+type AppendBundleHistoryResponse = datastore.AppendBundleHistoryResponse                   //nolint: golint
+type AppendBundleRequest = datastore.AppendBundleRequest                                   //nolint: golint
+type AppendBundleResponse = datastore.AppendBundleResponse                                 //nolint: golint
+type BundleHistoryEntry = datastore.BundleHistoryEntry                                     //nolint: golint
+type BySelectors = datastore.BySelectors                                                   //nolint: golint
+type BySelectors_MatchBehavior = datastore.BySelectors_MatchBehavior                       //nolint: golint
+type CountAttestedNodesRequest = datastore.CountAttestedNodesRequest                       //nolint: golint
+type CountAttestedNodesResponse = datastore.CountAttestedNodesResponse                     //nolint: golint
+type CountBundlesRequest = datastore.CountBundlesRequest                                   //nolint: golint
+type CountBundlesResponse = datastore.CountBundlesResponse                                 //nolint: golint
+type CountRegistrationEntriesRequest = datastore.CountRegistrationEntriesRequest           //nolint: golint
+type CountRegistrationEntriesResponse = datastore.CountRegistrationEntriesResponse         //nolint: golint
+type CreateAgentBanRequest = datastore.CreateAgentBanRequest                               //nolint: golint
+type CreateAgentBanResponse = datastore.CreateAgentBanResponse                             //nolint: golint
+type CreateAttestedNodeRequest = datastore.CreateAttestedNodeRequest                       //nolint: golint
+type CreateAttestedNodeResponse = datastore.CreateAttestedNodeResponse                     //nolint: golint
+type CreateBundleRequest = datastore.CreateBundleRequest                                   //nolint: golint
+type CreateBundleResponse = datastore.CreateBundleResponse                                 //nolint: golint
+type CreateFederationRelationshipRequest = datastore.CreateFederationRelationshipRequest   //nolint: golint
+type CreateFederationRelationshipResponse = datastore.CreateFederationRelationshipResponse //nolint: golint
+type CreateJoinTokenRequest = datastore.CreateJoinTokenRequest                             //nolint: golint
+type CreateJoinTokenResponse = datastore.CreateJoinTokenResponse                           //nolint: golint
+type CreateRegistrationEntryRequest = datastore.CreateRegistrationEntryRequest             //nolint: golint
+type CreateRegistrationEntryResponse = datastore.CreateRegistrationEntryResponse           //nolint: golint
+type DataStoreClient = datastore.DataStoreClient                                           //nolint: golint
+type DataStoreServer = datastore.DataStoreServer                                           //nolint: golint
+type DeleteAgentBanRequest = datastore.DeleteAgentBanRequest                               //nolint: golint
+type DeleteAgentBanResponse = datastore.DeleteAgentBanResponse                             //nolint: golint
+type DeleteAttestedNodeRequest = datastore.DeleteAttestedNodeRequest                       //nolint: golint
+type DeleteAttestedNodeResponse = datastore.DeleteAttestedNodeResponse                     //nolint: golint
+type DeleteBundleRequest = datastore.DeleteBundleRequest                                   //nolint: golint
+type DeleteBundleRequest_Mode = datastore.DeleteBundleRequest_Mode                         //nolint: golint
+type DeleteBundleResponse = datastore.DeleteBundleResponse                                 //nolint: golint
+type DeleteFederationRelationshipRequest = datastore.DeleteFederationRelationshipRequest   //nolint: golint
+type DeleteFederationRelationshipResponse = datastore.DeleteFederationRelationshipResponse //nolint: golint
+type DeleteJoinTokenRequest = datastore.DeleteJoinTokenRequest                             //nolint: golint
+type DeleteJoinTokenResponse = datastore.DeleteJoinTokenResponse                           //nolint: golint
+type DeleteRegistrationEntryRequest = datastore.DeleteRegistrationEntryRequest             //nolint: golint
+type DeleteRegistrationEntryResponse = datastore.DeleteRegistrationEntryResponse           //nolint: golint
+type FetchAttestedNodeRequest = datastore.FetchAttestedNodeRequest                         //nolint: golint
+type FetchAttestedNodeResponse = datastore.FetchAttestedNodeResponse                       //nolint: golint
+type FetchBundleRequest = datastore.FetchBundleRequest                                     //nolint: golint
+type FetchBundleResponse = datastore.FetchBundleResponse                                   //nolint: golint
+type FetchFederationRelationshipRequest = datastore.FetchFederationRelationshipRequest     //nolint: golint
+type FetchFederationRelationshipResponse = datastore.FetchFederationRelationshipResponse   //nolint: golint
+type FetchJoinTokenRequest = datastore.FetchJoinTokenRequest                               //nolint: golint
+type FetchJoinTokenResponse = datastore.FetchJoinTokenResponse                             //nolint: golint
+type FetchRegistrationEntryRequest = datastore.FetchRegistrationEntryRequest               //nolint: golint
+type FetchRegistrationEntryResponse = datastore.FetchRegistrationEntryResponse             //nolint: golint
+type GetNodeSelectorsRequest = datastore.GetNodeSelectorsRequest                           //nolint: golint
+type GetNodeSelectorsResponse = datastore.GetNodeSelectorsResponse                         //nolint: golint
+type JoinToken = datastore.JoinToken                                                       //nolint: golint
+type JoinTokenUse = datastore.JoinTokenUse                                                 //nolint: golint
+type ListAgentBansRequest = datastore.ListAgentBansRequest                                 //nolint: golint
+type ListAgentBansResponse = datastore.ListAgentBansResponse                               //nolint: golint
+type ListAttestedNodesRequest = datastore.ListAttestedNodesRequest                         //nolint: golint
+type ListAttestedNodesResponse = datastore.ListAttestedNodesResponse                       //nolint: golint
+type ListBundleHistoryRequest = datastore.ListBundleHistoryRequest                         //nolint: golint
+type ListBundleHistoryResponse = datastore.ListBundleHistoryResponse                       //nolint: golint
+type ListBundlesRequest = datastore.ListBundlesRequest                                     //nolint: golint
+type ListBundlesResponse = datastore.ListBundlesResponse                                   //nolint: golint
+type ListFederationRelationshipsRequest = datastore.ListFederationRelationshipsRequest     //nolint: golint
+type ListFederationRelationshipsResponse = datastore.ListFederationRelationshipsResponse   //nolint: golint
+type ListRegistrationEntriesRequest = datastore.ListRegistrationEntriesRequest             //nolint: golint
+type ListRegistrationEntriesResponse = datastore.ListRegistrationEntriesResponse           //nolint: golint
+type NodeSelectors = datastore.NodeSelectors                                               //nolint: golint
+type Pagination = datastore.Pagination                                                     //nolint: golint
+type PruneAttestedNodesRequest = datastore.PruneAttestedNodesRequest                       //nolint: golint
+type PruneAttestedNodesResponse = datastore.PruneAttestedNodesResponse                     //nolint: golint
+type PruneBundleRequest = datastore.PruneBundleRequest                                     //nolint: golint
+type PruneBundleResponse = datastore.PruneBundleResponse                                   //nolint: golint
+type PruneJoinTokensRequest = datastore.PruneJoinTokensRequest                             //nolint: golint
+type PruneJoinTokensResponse = datastore.PruneJoinTokensResponse                           //nolint: golint
+type PruneRegistrationEntriesRequest = datastore.PruneRegistrationEntriesRequest           //nolint: golint
+type PruneRegistrationEntriesResponse = datastore.PruneRegistrationEntriesResponse         //nolint: golint
+type RemoveBundleJWTSigningKeysRequest = datastore.RemoveBundleJWTSigningKeysRequest       //nolint: golint
+type RemoveBundleJWTSigningKeysResponse = datastore.RemoveBundleJWTSigningKeysResponse     //nolint: golint
+type SetBundleRequest = datastore.SetBundleRequest                                         //nolint: golint
+type SetBundleResponse = datastore.SetBundleResponse                                       //nolint: golint
+type SetNodeSelectorsRequest = datastore.SetNodeSelectorsRequest                           //nolint: golint
+type SetNodeSelectorsResponse = datastore.SetNodeSelectorsResponse                         //nolint: golint
+type UnimplementedDataStoreServer = datastore.UnimplementedDataStoreServer                 //nolint: golint
+type UpdateAttestedNodeLastSeenRequest = datastore.UpdateAttestedNodeLastSeenRequest       //nolint: golint
+type UpdateAttestedNodeLastSeenResponse = datastore.UpdateAttestedNodeLastSeenResponse     //nolint: golint
+type UpdateAttestedNodeRequest = datastore.UpdateAttestedNodeRequest                       //nolint: golint
+type UpdateAttestedNodeResponse = datastore.UpdateAttestedNodeResponse                     //nolint: golint
+type UpdateBundleRequest = datastore.UpdateBundleRequest                                   //nolint: golint
+type UpdateBundleResponse = datastore.UpdateBundleResponse                                 //nolint: golint
+type UpdateFederationRelationshipRequest = datastore.UpdateFederationRelationshipRequest   //nolint: golint
+type UpdateFederationRelationshipResponse = datastore.UpdateFederationRelationshipResponse //nolint: golint
+type UpdateRegistrationEntryRequest = datastore.UpdateRegistrationEntryRequest             //nolint: golint
+type UpdateRegistrationEntryResponse = datastore.UpdateRegistrationEntryResponse           //nolint: golint
+type UseJoinTokenRequest = datastore.UseJoinTokenRequest                                   //nolint: golint
+type UseJoinTokenResponse = datastore.UseJoinTokenResponse                                 //nolint: golint
 
 const (
 	Type                           = "DataStore"
@@ -84,61 +120,95 @@ const (
 // DataStore is the client interface for the service type DataStore interface.
 type DataStore interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	AppendBundleHistory(context.Context, *AppendBundleHistoryRequest) (*AppendBundleHistoryResponse, error)
+	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
+	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
+	CreateAgentBan(context.Context, *CreateAgentBanRequest) (*CreateAgentBanResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
+	DeleteAgentBan(context.Context, *DeleteAgentBanRequest) (*DeleteAgentBanResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
+	ListAgentBans(context.Context, *ListAgentBansRequest) (*ListAgentBansResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
+	ListBundleHistory(context.Context, *ListBundleHistoryRequest) (*ListBundleHistoryResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	RemoveBundleJWTSigningKeys(context.Context, *RemoveBundleJWTSigningKeysRequest) (*RemoveBundleJWTSigningKeysResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
 type Plugin interface {
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	AppendBundleHistory(context.Context, *AppendBundleHistoryRequest) (*AppendBundleHistoryResponse, error)
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
+	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
+	CreateAgentBan(context.Context, *CreateAgentBanRequest) (*CreateAgentBanResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
+	DeleteAgentBan(context.Context, *DeleteAgentBanRequest) (*DeleteAgentBanResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
+	ListAgentBans(context.Context, *ListAgentBansRequest) (*ListAgentBansResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
+	ListBundleHistory(context.Context, *ListBundleHistoryRequest) (*ListBundleHistoryResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	RemoveBundleJWTSigningKeys(context.Context, *RemoveBundleJWTSigningKeysRequest) (*RemoveBundleJWTSigningKeysResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 }
 
 // PluginServer returns a catalog PluginServer implementation for the DataStore plugin.
@@ -190,10 +260,30 @@ func (a pluginClientAdapter) AppendBundle(ctx context.Context, in *AppendBundleR
 	return a.client.AppendBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) AppendBundleHistory(ctx context.Context, in *AppendBundleHistoryRequest) (*AppendBundleHistoryResponse, error) {
+	return a.client.AppendBundleHistory(ctx, in)
+}
+
 func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return a.client.Configure(ctx, in)
 }
 
+func (a pluginClientAdapter) CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error) {
+	return a.client.CountAttestedNodes(ctx, in)
+}
+
+func (a pluginClientAdapter) CountBundles(ctx context.Context, in *CountBundlesRequest) (*CountBundlesResponse, error) {
+	return a.client.CountBundles(ctx, in)
+}
+
+func (a pluginClientAdapter) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	return a.client.CountRegistrationEntries(ctx, in)
+}
+
+func (a pluginClientAdapter) CreateAgentBan(ctx context.Context, in *CreateAgentBanRequest) (*CreateAgentBanResponse, error) {
+	return a.client.CreateAgentBan(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
 	return a.client.CreateAttestedNode(ctx, in)
 }
@@ -202,6 +292,10 @@ func (a pluginClientAdapter) CreateBundle(ctx context.Context, in *CreateBundleR
 	return a.client.CreateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return a.client.CreateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return a.client.CreateJoinToken(ctx, in)
 }
@@ -210,6 +304,10 @@ func (a pluginClientAdapter) CreateRegistrationEntry(ctx context.Context, in *Cr
 	return a.client.CreateRegistrationEntry(ctx, in)
 }
 
+func (a pluginClientAdapter) DeleteAgentBan(ctx context.Context, in *DeleteAgentBanRequest) (*DeleteAgentBanResponse, error) {
+	return a.client.DeleteAgentBan(ctx, in)
+}
+
 func (a pluginClientAdapter) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	return a.client.DeleteAttestedNode(ctx, in)
 }
@@ -218,6 +316,10 @@ func (a pluginClientAdapter) DeleteBundle(ctx context.Context, in *DeleteBundleR
 	return a.client.DeleteBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return a.client.DeleteFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return a.client.DeleteJoinToken(ctx, in)
 }
@@ -234,6 +336,10 @@ func (a pluginClientAdapter) FetchBundle(ctx context.Context, in *FetchBundleReq
 	return a.client.FetchBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return a.client.FetchFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return a.client.FetchJoinToken(ctx, in)
 }
@@ -250,18 +356,34 @@ func (a pluginClientAdapter) GetPluginInfo(ctx context.Context, in *spi.GetPlugi
 	return a.client.GetPluginInfo(ctx, in)
 }
 
+func (a pluginClientAdapter) ListAgentBans(ctx context.Context, in *ListAgentBansRequest) (*ListAgentBansResponse, error) {
+	return a.client.ListAgentBans(ctx, in)
+}
+
 func (a pluginClientAdapter) ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error) {
 	return a.client.ListAttestedNodes(ctx, in)
 }
 
+func (a pluginClientAdapter) ListBundleHistory(ctx context.Context, in *ListBundleHistoryRequest) (*ListBundleHistoryResponse, error) {
+	return a.client.ListBundleHistory(ctx, in)
+}
+
 func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesRequest) (*ListBundlesResponse, error) {
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return a.client.ListFederationRelationships(ctx, in)
+}
+
 func (a pluginClientAdapter) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
 	return a.client.ListRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error) {
+	return a.client.PruneAttestedNodes(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneBundle(ctx context.Context, in *PruneBundleRequest) (*PruneBundleResponse, error) {
 	return a.client.PruneBundle(ctx, in)
 }
@@ -274,6 +396,10 @@ func (a pluginClientAdapter) PruneRegistrationEntries(ctx context.Context, in *P
 	return a.client.PruneRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) RemoveBundleJWTSigningKeys(ctx context.Context, in *RemoveBundleJWTSigningKeysRequest) (*RemoveBundleJWTSigningKeysResponse, error) {
+	return a.client.RemoveBundleJWTSigningKeys(ctx, in)
+}
+
 func (a pluginClientAdapter) SetBundle(ctx context.Context, in *SetBundleRequest) (*SetBundleResponse, error) {
 	return a.client.SetBundle(ctx, in)
 }
@@ -286,10 +412,22 @@ func (a pluginClientAdapter) UpdateAttestedNode(ctx context.Context, in *UpdateA
 	return a.client.UpdateAttestedNode(ctx, in)
 }
 
+func (a pluginClientAdapter) UpdateAttestedNodeLastSeen(ctx context.Context, in *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error) {
+	return a.client.UpdateAttestedNodeLastSeen(ctx, in)
+}
+
 func (a pluginClientAdapter) UpdateBundle(ctx context.Context, in *UpdateBundleRequest) (*UpdateBundleResponse, error) {
 	return a.client.UpdateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return a.client.UpdateFederationRelationship(ctx, in)
+}
+
 func (a pluginClientAdapter) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return a.client.UpdateRegistrationEntry(ctx, in)
 }
+
+func (a pluginClientAdapter) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return a.client.UseJoinToken(ctx, in)
+}
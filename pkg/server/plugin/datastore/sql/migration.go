@@ -19,7 +19,7 @@ import (
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 14
+	latestSchemaVersion = 23
 )
 
 var (
@@ -179,6 +179,13 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		&Migration{},
 		&DNSName{},
 		&IPAddress{},
+		&DownstreamConstraint{},
+		&BundleHistory{},
+		&FederationRelationship{},
+		&JWTClaim{},
+		&JoinTokenSelector{},
+		&JoinTokenUse{},
+		&AgentBan{},
 	}
 
 	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
@@ -248,6 +255,24 @@ func migrateVersion(tx *gorm.DB, currVersion int, log hclog.Logger) (versionOut
 		err = migrateToV13(tx)
 	case 13:
 		err = migrateToV14(tx)
+	case 14:
+		err = migrateToV15(tx)
+	case 15:
+		err = migrateToV16(tx)
+	case 16:
+		err = migrateToV17(tx)
+	case 17:
+		err = migrateToV18(tx)
+	case 18:
+		err = migrateToV19(tx)
+	case 19:
+		err = migrateToV20(tx)
+	case 20:
+		err = migrateToV21(tx)
+	case 21:
+		err = migrateToV22(tx)
+	case 22:
+		err = migrateToV23(tx)
 	default:
 		err = sqlError.New("no migration support for version %d", currVersion)
 	}
@@ -482,6 +507,79 @@ func migrateToV14(tx *gorm.DB) error {
 	return nil
 }
 
+func migrateToV15(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&DownstreamConstraint{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV16(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&BundleHistory{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV17(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&FederationRelationship{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV18(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&JWTClaim{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV19(tx *gorm.DB) error {
+	// Adds the attested node expiration index so that nodes can be counted
+	// and listed by expiration without scanning the whole table.
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV20(tx *gorm.DB) error {
+	// Adds join token agent ID templates, selectors and multi-use quotas.
+	// Existing tokens have no max uses set and remain single-use.
+	if err := tx.AutoMigrate(&JoinToken{}, &JoinTokenSelector{}, &JoinTokenUse{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV21(tx *gorm.DB) error {
+	// Adds the agent ban table
+	if err := tx.AutoMigrate(&AgentBan{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV22(tx *gorm.DB) error {
+	// Adds the agent version, last seen time and remote address to attested
+	// nodes. Existing nodes have not been seen until they next sync.
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV23(tx *gorm.DB) error {
+	// Adds the mark on the bundles stored on behalf of the upstream server.
+	// Bundles stored before are not marked, so they are never deleted by
+	// the nested manager.
+	if err := tx.AutoMigrate(&Bundle{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 func addFederatedRegistrationEntriesRegisteredEntryIDIndex(tx *gorm.DB) error {
 	// GORM creates the federated_registration_entries implicitly with a primary
 	// key tuple (bundle_id, registered_entry_id). Unfortunately, MySQL5 does
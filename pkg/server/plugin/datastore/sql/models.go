@@ -19,6 +19,10 @@ type Bundle struct {
 	TrustDomain string `gorm:"not null;unique_index"`
 	Data        []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 24MB) - doesn't affect PostgreSQL/SQLite
 
+	// Upstream is true if the bundle is stored on behalf of the upstream
+	// SPIRE server in a nested deployment
+	Upstream bool
+
 	FederatedEntries []RegisteredEntry `gorm:"many2many:federated_registration_entries;"`
 }
 
@@ -29,9 +33,12 @@ type AttestedNode struct {
 	SpiffeID        string `gorm:"unique_index"`
 	DataType        string
 	SerialNumber    string
-	ExpiresAt       time.Time
+	ExpiresAt       time.Time `gorm:"index"`
 	NewSerialNumber string
 	NewExpiresAt    *time.Time
+	AgentVersion    string
+	LastSeenAt      *time.Time `gorm:"index"`
+	RemoteAddress   string
 }
 
 // TableName gets table name of AttestedNode
@@ -84,6 +91,10 @@ type RegisteredEntry struct {
 	// (optional) DNS entries
 	DNSList []DNSName
 	IPAddressList []IPAddress
+	// (optional) name constraints for the CA issued to a downstream entry
+	DownstreamConstraints []DownstreamConstraint
+	// (optional) custom claims of the JWT-SVIDs issued for the entry
+	JWTClaims []JWTClaim
 
 	// RevisionNumber is a counter that is incremented when the entry is
 	// updated.
@@ -96,6 +107,36 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+	// (optional) SPIFFE ID or path template of the agents attesting with
+	// the token
+	AgentIDTemplate string
+	// (optional) node selectors applied to the agents attesting with the
+	// token
+	Selectors []JoinTokenSelector
+	// Number of times the token can be used. Zero means once.
+	MaxUses int32
+	Uses    []JoinTokenUse
+}
+
+// JoinTokenSelector holds a node selector applied to the agents attesting
+// with a join token
+type JoinTokenSelector struct {
+	Model
+
+	JoinTokenID uint   `gorm:"unique_index:idx_join_token_selector"`
+	Type        string `gorm:"unique_index:idx_join_token_selector"`
+	Value       string `gorm:"unique_index:idx_join_token_selector"`
+}
+
+// JoinTokenUse records an agent attesting with a join token
+type JoinTokenUse struct {
+	Model
+
+	JoinTokenID uint `gorm:"unique_index:idx_join_token_use"`
+	// Use number, starting at one
+	Number  int32 `gorm:"unique_index:idx_join_token_use"`
+	AgentID string
+	UsedAt  int64
 }
 
 type Selector struct {
@@ -132,6 +173,73 @@ func (IPAddress) TableName() string {
 	return "ip_addresses"
 }
 
+// DownstreamConstraint holds a name constraint for the CA issued to a
+// downstream registration entry
+type DownstreamConstraint struct {
+	Model
+
+	RegisteredEntryID uint   `gorm:"unique_index:idx_downstream_constraint_entry"`
+	Type              string `gorm:"unique_index:idx_downstream_constraint_entry"`
+	Value             string `gorm:"unique_index:idx_downstream_constraint_entry"`
+}
+
+const (
+	// downstreamPathPrefix is the type of a SPIFFE ID path prefix constraint
+	downstreamPathPrefix = "path_prefix"
+	// downstreamDNSDomain is the type of a DNS domain constraint
+	downstreamDNSDomain = "dns_domain"
+)
+
+// JWTClaim holds a custom claim of the JWT-SVIDs issued for a registration
+// entry
+type JWTClaim struct {
+	Model
+
+	RegisteredEntryID uint   `gorm:"unique_index:idx_jwt_claim_entry"`
+	Name              string `gorm:"unique_index:idx_jwt_claim_entry"`
+	Value             string
+	// Template is true if Value is a template rendered when signing
+	Template bool
+}
+
+// BundleHistory holds a bundle received from a federated bundle endpoint
+type BundleHistory struct {
+	Model
+
+	TrustDomain    string `gorm:"not null;index"`
+	SequenceNumber uint64
+	ReceivedAt     time.Time
+	Data           []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 24MB) - doesn't affect PostgreSQL/SQLite
+}
+
+// TableName gets table name for bundle history entries
+func (BundleHistory) TableName() string {
+	return "bundle_history"
+}
+
+// FederationRelationship holds a relationship with a federated trust domain
+// managed through the registration API
+type FederationRelationship struct {
+	Model
+
+	TrustDomain           string `gorm:"not null;unique_index"`
+	BundleEndpointURL     string
+	BundleEndpointProfile string
+	EndpointSpiffeID      string
+}
+
+// AgentBan holds a ban preventing matching agents from attesting. Either the
+// SPIFFE ID or the selector type and value are set.
+type AgentBan struct {
+	Model
+
+	SpiffeID      string `gorm:"unique_index:idx_agent_ban"`
+	SelectorType  string `gorm:"unique_index:idx_agent_ban"`
+	SelectorValue string `gorm:"unique_index:idx_agent_ban"`
+	Reason        string
+	ExpiresAt     int64
+}
+
 
 // Migration holds database schema version number, and
 // the SPIRE Code version number
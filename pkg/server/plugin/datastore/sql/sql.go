@@ -55,6 +55,10 @@ const (
 	SQLite = "sqlite3"
 )
 
+// estimatedCountThreshold is the number of rows, as estimated by the database
+// engine, above which tables are no longer counted exactly
+const estimatedCountThreshold = 10000
+
 func BuiltIn() catalog.Plugin {
 	return builtin(New())
 }
@@ -150,7 +154,8 @@ func (ds *Plugin) CreateBundle(ctx context.Context, req *datastore.CreateBundleR
 }
 
 // UpdateBundle updates an existing bundle with the given CAs. Overwrites any
-// existing certificates.
+// existing certificates. Unless requested otherwise, the sequence number is
+// incremented past that of the existing bundle when the contents change.
 func (ds *Plugin) UpdateBundle(ctx context.Context, req *datastore.UpdateBundleRequest) (resp *datastore.UpdateBundleResponse, err error) {
 	callCounter := ds_telemetry.StartUpdateBundleCall(ds.prepareMetricsForCall())
 	defer callCounter.Done(&err)
@@ -226,7 +231,21 @@ func (ds *Plugin) ListBundles(ctx context.Context, req *datastore.ListBundlesReq
 	defer callCounter.Done(&err)
 
 	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
-		resp, err = listBundles(tx)
+		resp, err = listBundles(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CountBundles can be used to count all existing bundles.
+func (ds *Plugin) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (resp *datastore.CountBundlesResponse, err error) {
+	callCounter := ds_telemetry.StartCountBundleCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = countBundles(tx)
 		return err
 	}); err != nil {
 		return nil, err
@@ -249,6 +268,162 @@ func (ds *Plugin) PruneBundle(ctx context.Context, req *datastore.PruneBundleReq
 	return resp, nil
 }
 
+// RemoveBundleJWTSigningKeys removes the JWT signing keys with a given key ID
+// prefix from a bundle
+func (ds *Plugin) RemoveBundleJWTSigningKeys(ctx context.Context, req *datastore.RemoveBundleJWTSigningKeysRequest) (resp *datastore.RemoveBundleJWTSigningKeysResponse, err error) {
+	callCounter := ds_telemetry.StartRemoveBundleJWTSigningKeysCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteRepeatableReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = removeBundleJWTSigningKeys(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AppendBundleHistory records a bundle received from a federated bundle endpoint
+func (ds *Plugin) AppendBundleHistory(ctx context.Context, req *datastore.AppendBundleHistoryRequest) (resp *datastore.AppendBundleHistoryResponse, err error) {
+	callCounter := ds_telemetry.StartAppendBundleHistoryCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = appendBundleHistory(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListBundleHistory lists the bundles received from a federated bundle endpoint, oldest first
+func (ds *Plugin) ListBundleHistory(ctx context.Context, req *datastore.ListBundleHistoryRequest) (resp *datastore.ListBundleHistoryResponse, err error) {
+	callCounter := ds_telemetry.StartListBundleHistoryCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listBundleHistory(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateFederationRelationship stores the given federation relationship
+func (ds *Plugin) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (resp *datastore.CreateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartCreateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchFederationRelationship fetches the federation relationship with the given trust domain
+func (ds *Plugin) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (resp *datastore.FetchFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartFetchFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListFederationRelationships lists all federation relationships
+func (ds *Plugin) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (resp *datastore.ListFederationRelationshipsResponse, err error) {
+	callCounter := ds_telemetry.StartListFederationRelationshipsCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listFederationRelationships(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates an existing federation relationship
+func (ds *Plugin) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (resp *datastore.UpdateFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship with the given trust domain
+func (ds *Plugin) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (resp *datastore.DeleteFederationRelationshipResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteFederationRelationshipCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteFederationRelationship(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateAgentBan stores the given agent ban
+func (ds *Plugin) CreateAgentBan(ctx context.Context, req *datastore.CreateAgentBanRequest) (resp *datastore.CreateAgentBanResponse, err error) {
+	callCounter := ds_telemetry.StartCreateAgentBanCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createAgentBan(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListAgentBans lists agent bans, optionally filtered by SPIFFE ID or selectors
+func (ds *Plugin) ListAgentBans(ctx context.Context, req *datastore.ListAgentBansRequest) (resp *datastore.ListAgentBansResponse, err error) {
+	callCounter := ds_telemetry.StartListAgentBansCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listAgentBans(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteAgentBan deletes the agent ban for the given SPIFFE ID or selector
+func (ds *Plugin) DeleteAgentBan(ctx context.Context, req *datastore.DeleteAgentBanRequest) (resp *datastore.DeleteAgentBanResponse, err error) {
+	callCounter := ds_telemetry.StartDeleteAgentBanCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteAgentBan(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // CreateAttestedNode stores the given attested node
 func (ds *Plugin) CreateAttestedNode(ctx context.Context,
 	req *datastore.CreateAttestedNodeRequest) (resp *datastore.CreateAttestedNodeResponse, err error) {
@@ -298,6 +473,22 @@ func (ds *Plugin) ListAttestedNodes(ctx context.Context,
 	return resp, nil
 }
 
+// CountAttestedNodes counts all attested nodes, optionally filtered by
+// expiration
+func (ds *Plugin) CountAttestedNodes(ctx context.Context,
+	req *datastore.CountAttestedNodesRequest) (resp *datastore.CountAttestedNodesResponse, err error) {
+	callCounter := ds_telemetry.StartCountNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = countAttestedNodes(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // UpdateAttestedNode updates the given node's cert serial and expiration.
 func (ds *Plugin) UpdateAttestedNode(ctx context.Context,
 	req *datastore.UpdateAttestedNodeRequest) (resp *datastore.UpdateAttestedNodeResponse, err error) {
@@ -313,6 +504,22 @@ func (ds *Plugin) UpdateAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// UpdateAttestedNodeLastSeen records when the given node was last seen, along
+// with the agent version and the address it was seen from.
+func (ds *Plugin) UpdateAttestedNodeLastSeen(ctx context.Context,
+	req *datastore.UpdateAttestedNodeLastSeenRequest) (resp *datastore.UpdateAttestedNodeLastSeenResponse, err error) {
+	callCounter := ds_telemetry.StartUpdateNodeLastSeenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateAttestedNodeLastSeen(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteAttestedNode deletes the given attested node
 func (ds *Plugin) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (resp *datastore.DeleteAttestedNodeResponse, err error) {
@@ -328,6 +535,24 @@ func (ds *Plugin) DeleteAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// PruneAttestedNodes deletes the attested nodes, along with their node
+// selectors, whose SVID expired before the date in the request. At most
+// req.Limit nodes are deleted if a limit is set, which bounds the size of
+// the transaction.
+func (ds *Plugin) PruneAttestedNodes(ctx context.Context,
+	req *datastore.PruneAttestedNodesRequest) (resp *datastore.PruneAttestedNodesResponse, err error) {
+	callCounter := ds_telemetry.StartPruneNodeCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneAttestedNodes(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
 func (ds *Plugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (resp *datastore.SetNodeSelectorsResponse, err error) {
 	callCounter := ds_telemetry.StartSetNodeSelectorsCall(ds.prepareMetricsForCall())
@@ -399,6 +624,21 @@ func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
 	return listRegistrationEntries(ctx, ds.db, req)
 }
 
+// CountRegistrationEntries counts all registration entries
+func (ds *Plugin) CountRegistrationEntries(ctx context.Context,
+	req *datastore.CountRegistrationEntriesRequest) (resp *datastore.CountRegistrationEntriesResponse, err error) {
+	callCounter := ds_telemetry.StartCountRegistrationCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = countRegistrationEntries(tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // UpdateRegistrationEntry updates an existing registration entry
 func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context,
 	req *datastore.UpdateRegistrationEntryRequest) (resp *datastore.UpdateRegistrationEntryResponse, err error) {
@@ -496,6 +736,24 @@ func (ds *Plugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoin
 	return resp, nil
 }
 
+// UseJoinToken records a use of the given join token
+func (ds *Plugin) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (resp *datastore.UseJoinTokenResponse, err error) {
+	callCounter := ds_telemetry.StartUseJoinTokenCall(ds.prepareMetricsForCall())
+	defer callCounter.Done(&err)
+
+	if req.Use == nil || req.Use.AgentId == "" {
+		return nil, errors.New("use and agent ID are required")
+	}
+
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = useJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *Plugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -728,13 +986,26 @@ func updateBundle(tx *gorm.DB, req *datastore.UpdateBundleRequest) (*datastore.U
 		return nil, sqlError.Wrap(err)
 	}
 
+	bundle := req.Bundle
+	if !req.PreserveSequenceNumber {
+		current, err := modelToBundle(model)
+		if err != nil {
+			return nil, err
+		}
+		bundle = bundleutil.SequenceBundleUpdate(current, bundle)
+		newModel, err = bundleToModel(bundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	model.Data = newModel.Data
 	if err := tx.Save(model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
 	return &datastore.UpdateBundleResponse{
-		Bundle: req.Bundle,
+		Bundle: bundle,
 	}, nil
 }
 
@@ -745,26 +1016,35 @@ func setBundle(tx *gorm.DB, req *datastore.SetBundleRequest) (*datastore.SetBund
 	}
 
 	// fetch existing or create new
+	var bundle *common.Bundle
 	model := &Bundle{}
 	result := tx.Find(model, "trust_domain = ?", newModel.TrustDomain)
-	if result.RecordNotFound() {
+	switch {
+	case result.RecordNotFound():
 		resp, err := createBundle(tx, &datastore.CreateBundleRequest{Bundle: req.Bundle})
 		if err != nil {
 			return nil, err
 		}
-		return &datastore.SetBundleResponse{
-			Bundle: resp.Bundle,
-		}, nil
-	} else if result.Error != nil {
+		bundle = resp.Bundle
+	case result.Error != nil:
 		return nil, sqlError.Wrap(result.Error)
+	default:
+		resp, err := updateBundle(tx, &datastore.UpdateBundleRequest{
+			Bundle:                 req.Bundle,
+			PreserveSequenceNumber: req.PreserveSequenceNumber,
+		})
+		if err != nil {
+			return nil, err
+		}
+		bundle = resp.Bundle
 	}
 
-	resp, err := updateBundle(tx, &datastore.UpdateBundleRequest{Bundle: req.Bundle})
-	if err != nil {
-		return nil, err
+	if err := tx.Model(&Bundle{}).Where("trust_domain = ?", newModel.TrustDomain).Update("upstream", req.Upstream).Error; err != nil {
+		return nil, sqlError.Wrap(err)
 	}
+
 	return &datastore.SetBundleResponse{
-		Bundle: resp.Bundle,
+		Bundle: bundle,
 	}, nil
 }
 
@@ -856,6 +1136,10 @@ func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.D
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := tx.Where("trust_domain = ?", trustDomainID).Delete(&BundleHistory{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
 	bundle, err := modelToBundle(model)
 	if err != nil {
 		return nil, err
@@ -893,7 +1177,11 @@ func fetchBundle(tx *gorm.DB, req *datastore.FetchBundleRequest) (*datastore.Fet
 }
 
 // listBundles can be used to fetch all existing bundles.
-func listBundles(tx *gorm.DB) (*datastore.ListBundlesResponse, error) {
+func listBundles(tx *gorm.DB, req *datastore.ListBundlesRequest) (*datastore.ListBundlesResponse, error) {
+	if req.ByUpstream {
+		tx = tx.Where("upstream = ?", true)
+	}
+
 	var bundles []Bundle
 	if err := tx.Find(&bundles).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -913,6 +1201,17 @@ func listBundles(tx *gorm.DB) (*datastore.ListBundlesResponse, error) {
 	return resp, nil
 }
 
+func countBundles(tx *gorm.DB) (*datastore.CountBundlesResponse, error) {
+	count, err := countRows(tx, &Bundle{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.CountBundlesResponse{
+		Bundles: int32(count),
+	}, nil
+}
+
 func pruneBundle(tx *gorm.DB, req *datastore.PruneBundleRequest, log hclog.Logger) (*datastore.PruneBundleResponse, error) {
 	// Get current bundle
 	current, err := fetchBundle(tx, &datastore.FetchBundleRequest{TrustDomainId: req.TrustDomainId})
@@ -944,6 +1243,273 @@ func pruneBundle(tx *gorm.DB, req *datastore.PruneBundleRequest, log hclog.Logge
 	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
 }
 
+func removeBundleJWTSigningKeys(tx *gorm.DB, req *datastore.RemoveBundleJWTSigningKeysRequest) (*datastore.RemoveBundleJWTSigningKeysResponse, error) {
+	if req.KeyIdPrefix == "" {
+		return nil, sqlError.New("key ID prefix is required")
+	}
+
+	current, err := fetchBundle(tx, &datastore.FetchBundleRequest{TrustDomainId: req.TrustDomainId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch current bundle: %v", err)
+	}
+
+	if current.Bundle == nil {
+		// No bundle to remove keys from
+		return &datastore.RemoveBundleJWTSigningKeysResponse{}, nil
+	}
+
+	newBundle, removed := bundleutil.RemoveJWTSigningKeys(current.Bundle, req.KeyIdPrefix)
+	if removed > 0 {
+		if _, err := updateBundle(tx, &datastore.UpdateBundleRequest{
+			Bundle: newBundle,
+		}); err != nil {
+			return nil, fmt.Errorf("unable to write new bundle: %v", err)
+		}
+	}
+
+	return &datastore.RemoveBundleJWTSigningKeysResponse{Removed: int32(removed)}, nil
+}
+
+func appendBundleHistory(tx *gorm.DB, req *datastore.AppendBundleHistoryRequest) (*datastore.AppendBundleHistoryResponse, error) {
+	if req.Entry == nil {
+		return nil, sqlError.New("missing bundle history entry in request")
+	}
+
+	bundleModel, err := bundleToModel(req.Entry.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	model := &BundleHistory{
+		TrustDomain:    bundleModel.TrustDomain,
+		SequenceNumber: req.Entry.Bundle.SequenceNumber,
+		ReceivedAt:     time.Unix(req.Entry.ReceivedAt, 0),
+		Data:           bundleModel.Data,
+	}
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if req.MaxEntries > 0 {
+		var ids []uint
+		if err := tx.Model(&BundleHistory{}).Where("trust_domain = ?", model.TrustDomain).Order("id DESC").Pluck("id", &ids).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if len(ids) > int(req.MaxEntries) {
+			if err := tx.Where("id IN (?)", ids[req.MaxEntries:]).Delete(&BundleHistory{}).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+		}
+	}
+
+	return &datastore.AppendBundleHistoryResponse{}, nil
+}
+
+func listBundleHistory(tx *gorm.DB, req *datastore.ListBundleHistoryRequest) (*datastore.ListBundleHistoryResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var models []BundleHistory
+	if err := tx.Where("trust_domain = ?", trustDomainID).Order("id ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListBundleHistoryResponse)
+	for _, model := range models {
+		bundle := new(common.Bundle)
+		if err := proto.Unmarshal(model.Data, bundle); err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		resp.Entries = append(resp.Entries, &datastore.BundleHistoryEntry{
+			Bundle:     bundle,
+			ReceivedAt: model.ReceivedAt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+func createFederationRelationship(tx *gorm.DB, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	model, err := federationRelationshipToModel(req.Relationship)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateFederationRelationshipResponse{
+		Relationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func fetchFederationRelationship(tx *gorm.DB, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederationRelationship)
+	err = tx.Find(model, "trust_domain = ?", trustDomainID).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		Relationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func listFederationRelationships(tx *gorm.DB, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	var models []FederationRelationship
+	if err := tx.Order("trust_domain ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListFederationRelationshipsResponse)
+	for i := range models {
+		resp.Relationships = append(resp.Relationships, modelToFederationRelationship(&models[i]))
+	}
+	return resp, nil
+}
+
+func updateFederationRelationship(tx *gorm.DB, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	newModel, err := federationRelationshipToModel(req.Relationship)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(FederationRelationship)
+	if err := tx.Find(model, "trust_domain = ?", newModel.TrustDomain).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model.BundleEndpointURL = newModel.BundleEndpointURL
+	model.BundleEndpointProfile = newModel.BundleEndpointProfile
+	model.EndpointSpiffeID = newModel.EndpointSpiffeID
+	if err := tx.Save(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		Relationship: modelToFederationRelationship(model),
+	}, nil
+}
+
+func deleteFederationRelationship(tx *gorm.DB, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	model := new(FederationRelationship)
+	if err := tx.Find(model, "trust_domain = ?", trustDomainID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.DeleteFederationRelationshipResponse{
+		Relationship: modelToFederationRelationship(model),
+	}
+	if !req.DeleteBundle {
+		return resp, nil
+	}
+
+	result := tx.Find(new(Bundle), "trust_domain = ?", trustDomainID)
+	switch {
+	case result.RecordNotFound():
+		return resp, nil
+	case result.Error != nil:
+		return nil, sqlError.Wrap(result.Error)
+	}
+	bundleResp, err := deleteBundle(tx, &datastore.DeleteBundleRequest{
+		TrustDomainId: trustDomainID,
+		Mode:          req.BundleMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Bundle = bundleResp.Bundle
+	return resp, nil
+}
+
+func createAgentBan(tx *gorm.DB, req *datastore.CreateAgentBanRequest) (*datastore.CreateAgentBanResponse, error) {
+	if req.Ban == nil {
+		return nil, sqlError.New("missing agent ban in request")
+	}
+	model, err := agentBanToModel(req.Ban.SpiffeId, req.Ban.Selector)
+	if err != nil {
+		return nil, err
+	}
+	model.Reason = req.Ban.Reason
+	model.ExpiresAt = req.Ban.ExpiresAt
+
+	if err := tx.Create(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateAgentBanResponse{
+		Ban: modelToAgentBan(model),
+	}, nil
+}
+
+func listAgentBans(tx *gorm.DB, req *datastore.ListAgentBansRequest) (*datastore.ListAgentBansResponse, error) {
+	var conditions []string
+	var args []interface{}
+	if req.BySpiffeId != "" {
+		conditions = append(conditions, "spiffe_id = ?")
+		args = append(args, req.BySpiffeId)
+	}
+	for _, selector := range req.BySelectors {
+		conditions = append(conditions, "(selector_type = ? AND selector_value = ?)")
+		args = append(args, selector.Type, selector.Value)
+	}
+	if len(conditions) > 0 {
+		tx = tx.Where(strings.Join(conditions, " OR "), args...)
+	}
+
+	var models []AgentBan
+	if err := tx.Order("id ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListAgentBansResponse)
+	for i := range models {
+		resp.Bans = append(resp.Bans, modelToAgentBan(&models[i]))
+	}
+	return resp, nil
+}
+
+func deleteAgentBan(tx *gorm.DB, req *datastore.DeleteAgentBanRequest) (*datastore.DeleteAgentBanResponse, error) {
+	target, err := agentBanToModel(req.SpiffeId, req.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(AgentBan)
+	if err := tx.Find(model, "spiffe_id = ? AND selector_type = ? AND selector_value = ?",
+		target.SpiffeID, target.SelectorType, target.SelectorValue).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.DeleteAgentBanResponse{
+		Ban: modelToAgentBan(model),
+	}, nil
+}
+
 func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	model := AttestedNode{
 		SpiffeID:        req.Node.SpiffeId,
@@ -952,6 +1518,9 @@ func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (
 		ExpiresAt:       time.Unix(req.Node.CertNotAfter, 0),
 		NewSerialNumber: req.Node.NewCertSerialNumber,
 		NewExpiresAt:    nullableUnixTimeToDBTime(req.Node.NewCertNotAfter),
+		AgentVersion:    req.Node.AgentVersion,
+		LastSeenAt:      nullableUnixTimeToDBTime(req.Node.LastSeenAt),
+		RemoteAddress:   req.Node.RemoteAddress,
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
@@ -978,41 +1547,126 @@ func fetchAttestedNode(tx *gorm.DB, req *datastore.FetchAttestedNodeRequest) (*d
 }
 
 func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	nodesTx := tx
 	p := req.Pagination
 	var err error
 	if p != nil {
-		tx, err = applyPagination(p, tx)
+		nodesTx, err = applyPagination(p, nodesTx)
 		if err != nil {
 			return nil, err
 		}
-	}
+	} else {
+		// Keep a stable order, since the filters may be served by indexes
+		// other than the primary key
+		nodesTx = nodesTx.Order("id asc")
+	}
+
+	if req.ByExpiresBefore != nil {
+		nodesTx = nodesTx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
+	}
+	if req.ByExpiresAfter != nil {
+		nodesTx = nodesTx.Where("expires_at > ?", time.Unix(req.ByExpiresAfter.Value, 0))
+	}
+	if req.ByAttestationType != nil {
+		nodesTx = nodesTx.Where("data_type = ?", req.ByAttestationType.Value)
+	}
+	if req.ByLastSeenBefore != nil {
+		nodesTx = nodesTx.Where("last_seen_at IS NULL OR last_seen_at < ?", time.Unix(req.ByLastSeenBefore.Value, 0))
+	}
+	for _, selector := range req.BySelectors {
+		nodesTx = nodesTx.Where("spiffe_id IN (SELECT spiffe_id FROM node_resolver_map_entries WHERE type = ? AND value = ?)", selector.Type, selector.Value)
+	}
+
+	var models []AttestedNode
+	if err := nodesTx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil {
+		p.Token = ""
+		if len(models) > 0 {
+			lastEntry := models[len(models)-1]
+			p.Token = fmt.Sprint(lastEntry.ID)
+		}
+	}
+
+	resp := &datastore.ListAttestedNodesResponse{
+		Nodes:      make([]*common.AttestedNode, 0, len(models)),
+		Pagination: p,
+	}
+
+	for _, model := range models {
+		resp.Nodes = append(resp.Nodes, modelToAttestedNode(model))
+	}
+
+	if req.FetchSelectors {
+		if err := fillAttestedNodeSelectors(tx, resp.Nodes); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// fillAttestedNodeSelectors populates the selectors of the given nodes. The
+// selectors are queried in batches to stay below the bound parameter limits
+// of the supported databases.
+func fillAttestedNodeSelectors(tx *gorm.DB, nodes []*common.AttestedNode) error {
+	const batchSize = 500
+
+	byID := make(map[string]*common.AttestedNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.SpiffeId] = node
+	}
+
+	for start := 0; start < len(nodes); start += batchSize {
+		end := start + batchSize
+		if end > len(nodes) {
+			end = len(nodes)
+		}
 
-	if req.ByExpiresBefore != nil {
-		tx = tx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
-	}
+		ids := make([]string, 0, end-start)
+		for _, node := range nodes[start:end] {
+			ids = append(ids, node.SpiffeId)
+		}
 
-	var models []AttestedNode
-	if err := tx.Find(&models).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+		var models []NodeSelector
+		if err := tx.Where("spiffe_id IN (?)", ids).Order("id").Find(&models).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+		for _, model := range models {
+			node := byID[model.SpiffeID]
+			node.Selectors = append(node.Selectors, &common.Selector{
+				Type:  model.Type,
+				Value: model.Value,
+			})
+		}
 	}
+	return nil
+}
 
-	if p != nil {
-		p.Token = ""
-		if len(models) > 0 {
-			lastEntry := models[len(models)-1]
-			p.Token = fmt.Sprint(lastEntry.ID)
+func countAttestedNodes(tx *gorm.DB, req *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
+	if req.ByExpiresBefore == nil {
+		count, err := countRows(tx, &AttestedNode{})
+		if err != nil {
+			return nil, err
 		}
+		return &datastore.CountAttestedNodesResponse{
+			Nodes: int32(count),
+		}, nil
 	}
 
-	resp := &datastore.ListAttestedNodesResponse{
-		Nodes:      make([]*common.AttestedNode, 0, len(models)),
-		Pagination: p,
+	// The expiration filter is served by the expires_at index, so only the
+	// nodes expiring before the given time are visited. Agents rotate their
+	// SVID when half of it remains, so those are the few that failed to
+	// rotate, not the whole table.
+	var count int
+	if err := tx.Model(&AttestedNode{}).Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0)).Count(&count).Error; err != nil {
+		return nil, sqlError.Wrap(err)
 	}
 
-	for _, model := range models {
-		resp.Nodes = append(resp.Nodes, modelToAttestedNode(model))
-	}
-	return resp, nil
+	return &datastore.CountAttestedNodesResponse{
+		Nodes: int32(count),
+	}, nil
 }
 
 func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
@@ -1037,6 +1691,33 @@ func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (
 	}, nil
 }
 
+func updateAttestedNodeLastSeen(tx *gorm.DB, req *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+	if req.LastSeenAt == 0 {
+		return nil, sqlError.New("invalid request: missing last seen time")
+	}
+
+	var model AttestedNode
+	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Empty versions and addresses are not recorded so the last ones reported
+	// are kept
+	updates := AttestedNode{
+		AgentVersion:  req.AgentVersion,
+		LastSeenAt:    nullableUnixTimeToDBTime(req.LastSeenAt),
+		RemoteAddress: req.RemoteAddress,
+	}
+
+	if err := tx.Model(&model).Updates(updates).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.UpdateAttestedNodeLastSeenResponse{
+		Node: modelToAttestedNode(model),
+	}, nil
+}
+
 func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	var model AttestedNode
 	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
@@ -1052,6 +1733,40 @@ func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (
 	}, nil
 }
 
+func pruneAttestedNodes(tx *gorm.DB, req *datastore.PruneAttestedNodesRequest) (*datastore.PruneAttestedNodesResponse, error) {
+	query := tx.Where("expires_at < ?", time.Unix(req.ExpiresBefore, 0)).Order("id asc")
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+
+	var models []AttestedNode
+	if err := query.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.PruneAttestedNodesResponse)
+	for _, model := range models {
+		// Node selectors are deleted by ID for the same reason explained in
+		// setNodeSelectors.
+		var ids []int64
+		if err := tx.Model(&NodeSelector{}).Where("spiffe_id = ?", model.SpiffeID).Pluck("id", &ids).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if len(ids) > 0 {
+			if err := tx.Where("id IN (?)", ids).Delete(&NodeSelector{}).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+		}
+
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		resp.Nodes = append(resp.Nodes, modelToAttestedNode(model))
+	}
+
+	return resp, nil
+}
+
 func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	// Previously the deletion of the previous set of node selectors was
 	// implemented via query like DELETE FROM node_resolver_map_entries WHERE
@@ -1179,6 +1894,20 @@ func createRegistrationEntry(tx *gorm.DB, req *datastore.CreateRegistrationEntry
 		}
 	}
 
+	for _, constraint := range makeDownstreamConstraints(req.Entry) {
+		constraint.RegisteredEntryID = newRegisteredEntry.ID
+		if err := tx.Create(&constraint).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	for _, claim := range makeJWTClaims(req.Entry) {
+		claim.RegisteredEntryID = newRegisteredEntry.ID
+		if err := tx.Create(&claim).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
 	entry, err := modelToEntry(tx, newRegisteredEntry)
 	if err != nil {
 		return nil, err
@@ -1201,6 +1930,7 @@ func fetchRegistrationEntry(ctx context.Context, db *sqlDB, req *datastore.Fetch
 	}
 	defer rows.Close()
 
+	var eid uint64
 	var entry *common.RegistrationEntry
 	for rows.Next() {
 		var r entryRow
@@ -1209,6 +1939,7 @@ func fetchRegistrationEntry(ctx context.Context, db *sqlDB, req *datastore.Fetch
 		}
 
 		if entry == nil {
+			eid = r.EId
 			entry = new(common.RegistrationEntry)
 		}
 		if err := fillEntryFromRow(entry, &r); err != nil {
@@ -1220,6 +1951,16 @@ func fetchRegistrationEntry(ctx context.Context, db *sqlDB, req *datastore.Fetch
 		return nil, sqlError.Wrap(err)
 	}
 
+	if entry != nil {
+		entries := map[uint64]*common.RegistrationEntry{eid: entry}
+		if err := fetchDownstreamConstraints(db, entries); err != nil {
+			return nil, err
+		}
+		if err := fetchJWTClaims(db, entries); err != nil {
+			return nil, err
+		}
+	}
+
 	return &datastore.FetchRegistrationEntryResponse{
 		Entry: entry,
 	}, nil
@@ -1486,6 +2227,55 @@ ORDER BY selector_id, dns_name_id
 	return query, []interface{}{req.EntryId}, nil
 }
 
+func countRegistrationEntries(tx *gorm.DB) (*datastore.CountRegistrationEntriesResponse, error) {
+	count, err := countRows(tx, &RegisteredEntry{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.CountRegistrationEntriesResponse{
+		Entries: int32(count),
+	}, nil
+}
+
+// countRows counts the rows of the table of the given model. Once the table
+// is large, the row estimate kept by the database engine is returned instead
+// of running a COUNT(*), which has to scan the whole table (or one of its
+// indexes). SQLite keeps no such estimate, so rows are always counted there.
+func countRows(tx *gorm.DB, model interface{}) (int, error) {
+	table := tx.NewScope(model).TableName()
+
+	if query, ok := estimateRowsQuery(tx.Dialect().GetName()); ok {
+		var estimate sql.NullInt64
+		if err := tx.Raw(query, table).Row().Scan(&estimate); err != nil && err != sql.ErrNoRows {
+			return 0, sqlError.Wrap(err)
+		}
+		// The estimate is missing or negative until the table is analyzed
+		if estimate.Valid && estimate.Int64 >= estimatedCountThreshold {
+			return int(estimate.Int64), nil
+		}
+	}
+
+	var count int
+	if err := tx.Model(model).Count(&count).Error; err != nil {
+		return 0, sqlError.Wrap(err)
+	}
+	return count, nil
+}
+
+// estimateRowsQuery returns the query for the row estimate of the table
+// named by the query argument, if the database engine keeps one.
+func estimateRowsQuery(databaseType string) (string, bool) {
+	switch databaseType {
+	case PostgreSQL:
+		return "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(?)", true
+	case MySQL:
+		return "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?", true
+	default:
+		return "", false
+	}
+}
+
 func listRegistrationEntries(ctx context.Context, db *sqlDB, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	if req.Pagination != nil && req.Pagination.PageSize == 0 {
 		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
@@ -1567,7 +2357,9 @@ func listRegistrationEntriesOnce(ctx context.Context, db *sqlDB, req *datastore.
 		entries = make([]*common.RegistrationEntry, 0, 64)
 	}
 
-	pushEntry := func(entry *common.RegistrationEntry) {
+	allEntries := make(map[uint64]*common.RegistrationEntry)
+	downstreamEntries := make(map[uint64]*common.RegistrationEntry)
+	pushEntry := func(eid uint64, entry *common.RegistrationEntry) {
 		// Due to previous bugs (i.e. #1191), there can be cruft rows related
 		// to a deleted registration entries that are fetched with the list
 		// query. To avoid hydrating partial entries, append only entries that
@@ -1575,6 +2367,10 @@ func listRegistrationEntriesOnce(ctx context.Context, db *sqlDB, req *datastore.
 		// entry id).
 		if entry != nil && entry.EntryId != "" {
 			entries = append(entries, entry)
+			allEntries[eid] = entry
+			if entry.Downstream {
+				downstreamEntries[eid] = entry
+			}
 		}
 	}
 
@@ -1587,8 +2383,8 @@ func listRegistrationEntriesOnce(ctx context.Context, db *sqlDB, req *datastore.
 		}
 
 		if entry == nil || lastEID != r.EId {
+			pushEntry(lastEID, entry)
 			lastEID = r.EId
-			pushEntry(entry)
 			entry = new(common.RegistrationEntry)
 		}
 
@@ -1596,12 +2392,19 @@ func listRegistrationEntriesOnce(ctx context.Context, db *sqlDB, req *datastore.
 			return nil, err
 		}
 	}
-	pushEntry(entry)
+	pushEntry(lastEID, entry)
 
 	if err := rows.Err(); err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := fetchDownstreamConstraints(db, downstreamEntries); err != nil {
+		return nil, err
+	}
+	if err := fetchJWTClaims(db, allEntries); err != nil {
+		return nil, err
+	}
+
 	resp := &datastore.ListRegistrationEntriesResponse{
 		Entries: entries,
 	}
@@ -2270,6 +3073,121 @@ func fillEntryFromRow(entry *common.RegistrationEntry, r *entryRow) error {
 	return nil
 }
 
+// fetchDownstreamConstraints fills in the downstream constraints of the given
+// entries, keyed by registered entry row id. The constraints are only relevant
+// to downstream entries so they are fetched separately instead of being part
+// of the entry queries.
+func fetchDownstreamConstraints(db *sqlDB, entries map[uint64]*common.RegistrationEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+
+	var constraints []DownstreamConstraint
+	if err := db.Where("registered_entry_id IN (?)", ids).Order("id ASC").Find(&constraints).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	constraintsByEntry := make(map[uint64][]DownstreamConstraint)
+	for _, constraint := range constraints {
+		id := uint64(constraint.RegisteredEntryID)
+		constraintsByEntry[id] = append(constraintsByEntry[id], constraint)
+	}
+	for id, entry := range entries {
+		fillEntryDownstreamConstraints(entry, constraintsByEntry[id])
+	}
+	return nil
+}
+
+func fillEntryDownstreamConstraints(entry *common.RegistrationEntry, constraints []DownstreamConstraint) {
+	for _, constraint := range constraints {
+		switch constraint.Type {
+		case downstreamPathPrefix:
+			entry.DownstreamPathPrefixes = append(entry.DownstreamPathPrefixes, constraint.Value)
+		case downstreamDNSDomain:
+			entry.DownstreamDnsDomains = append(entry.DownstreamDnsDomains, constraint.Value)
+		}
+	}
+}
+
+func makeDownstreamConstraints(entry *common.RegistrationEntry) []DownstreamConstraint {
+	var constraints []DownstreamConstraint
+	for _, pathPrefix := range entry.DownstreamPathPrefixes {
+		constraints = append(constraints, DownstreamConstraint{
+			Type:  downstreamPathPrefix,
+			Value: pathPrefix,
+		})
+	}
+	for _, dnsDomain := range entry.DownstreamDnsDomains {
+		constraints = append(constraints, DownstreamConstraint{
+			Type:  downstreamDNSDomain,
+			Value: dnsDomain,
+		})
+	}
+	return constraints
+}
+
+// fetchJWTClaims fills in the JWT-SVID claims of the given entries, keyed by
+// registered entry row id.
+func fetchJWTClaims(db *sqlDB, entries map[uint64]*common.RegistrationEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+
+	var claims []JWTClaim
+	if err := db.Where("registered_entry_id IN (?)", ids).Find(&claims).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	for _, claim := range claims {
+		if entry, ok := entries[uint64(claim.RegisteredEntryID)]; ok {
+			fillEntryJWTClaim(entry, claim)
+		}
+	}
+	return nil
+}
+
+func fillEntryJWTClaim(entry *common.RegistrationEntry, claim JWTClaim) {
+	if claim.Template {
+		if entry.JwtClaimTemplates == nil {
+			entry.JwtClaimTemplates = make(map[string]string)
+		}
+		entry.JwtClaimTemplates[claim.Name] = claim.Value
+		return
+	}
+	if entry.JwtClaims == nil {
+		entry.JwtClaims = make(map[string]string)
+	}
+	entry.JwtClaims[claim.Name] = claim.Value
+}
+
+func makeJWTClaims(entry *common.RegistrationEntry) []JWTClaim {
+	var claims []JWTClaim
+	for name, value := range entry.JwtClaims {
+		claims = append(claims, JWTClaim{
+			Name:  name,
+			Value: value,
+		})
+	}
+	for name, value := range entry.JwtClaimTemplates {
+		claims = append(claims, JWTClaim{
+			Name:     name,
+			Value:    value,
+			Template: true,
+		})
+	}
+	return claims
+}
+
 // applyPagination  add order limit and token to current query
 func applyPagination(p *datastore.Pagination, entryTx *gorm.DB) (*gorm.DB, error) {
 	if p.PageSize == 0 {
@@ -2338,6 +3256,16 @@ func updateRegistrationEntry(tx *gorm.DB,
 		ipAddressList = append(ipAddressList, ipAddress)
 	}
 
+	// Delete existing downstream constraints - we will write new ones
+	if err := tx.Exec("DELETE FROM downstream_constraints WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Delete existing JWT claims - we will write new ones
+	if err := tx.Exec("DELETE FROM jwt_claims WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
 	entry.SpiffeID = req.Entry.SpiffeId
 	entry.ParentID = req.Entry.ParentId
 	entry.TTL = req.Entry.Ttl
@@ -2347,6 +3275,8 @@ func updateRegistrationEntry(tx *gorm.DB,
 	entry.Expiry = req.Entry.EntryExpiry
 	entry.DNSList = dnsList
 	entry.IPAddressList = ipAddressList
+	entry.DownstreamConstraints = makeDownstreamConstraints(req.Entry)
+	entry.JWTClaims = makeJWTClaims(req.Entry)
 	if err := tx.Save(&entry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
@@ -2401,6 +3331,16 @@ func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 		return sqlError.Wrap(err)
 	}
 
+	// Delete existing downstream constraints
+	if err := tx.Exec("DELETE FROM downstream_constraints WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	// Delete existing JWT claims
+	if err := tx.Exec("DELETE FROM jwt_claims WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
 	return nil
 }
 
@@ -2421,8 +3361,16 @@ func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntri
 
 func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	t := JoinToken{
-		Token:  req.JoinToken.Token,
-		Expiry: req.JoinToken.Expiry,
+		Token:           req.JoinToken.Token,
+		Expiry:          req.JoinToken.Expiry,
+		AgentIDTemplate: req.JoinToken.AgentIdTemplate,
+		MaxUses:         req.JoinToken.MaxUses,
+	}
+	for _, selector := range req.JoinToken.Selectors {
+		t.Selectors = append(t.Selectors, JoinTokenSelector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
 	}
 
 	if err := tx.Create(&t).Error; err != nil {
@@ -2435,8 +3383,7 @@ func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datas
 }
 
 func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
-	var model JoinToken
-	err := tx.Find(&model, "token = ?", req.Token).Error
+	model, err := findJoinToken(tx, req.Token)
 	if err == gorm.ErrRecordNotFound {
 		return &datastore.FetchJoinTokenResponse{}, nil
 	} else if err != nil {
@@ -2449,13 +3396,13 @@ func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datasto
 }
 
 func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
-	var model JoinToken
-	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
+	model, err := findJoinToken(tx, req.Token)
+	if err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	if err := tx.Delete(&model).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+	if err := deleteJoinTokenSupport(tx, model); err != nil {
+		return nil, err
 	}
 
 	return &datastore.DeleteJoinTokenResponse{
@@ -2463,14 +3410,85 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 	}, nil
 }
 
+func useJoinToken(tx *gorm.DB, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	model, err := findJoinToken(tx, req.Token)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	maxUses := model.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+	uses := int32(len(model.Uses))
+	switch {
+	case uses >= maxUses:
+		return nil, sqlError.New("join token has no uses left")
+	case req.Use.Use != uses+1:
+		return nil, sqlError.New("join token use %d is not the next use (%d)", req.Use.Use, uses+1)
+	}
+
+	use := JoinTokenUse{
+		JoinTokenID: model.ID,
+		Number:      req.Use.Use,
+		AgentID:     req.Use.AgentId,
+		UsedAt:      req.Use.UsedAt,
+	}
+	if err := tx.Create(&use).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	model.Uses = append(model.Uses, use)
+
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: modelToJoinToken(model),
+	}, nil
+}
+
 func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
-	if err := tx.Where("expiry < ?", req.ExpiresBefore).Delete(&JoinToken{}).Error; err != nil {
+	var models []JoinToken
+	if err := tx.Where("expiry < ?", req.ExpiresBefore).Find(&models).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	for _, model := range models {
+		if err := deleteJoinTokenSupport(tx, model); err != nil {
+			return nil, err
+		}
+	}
+
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func findJoinToken(tx *gorm.DB, token string) (JoinToken, error) {
+	var model JoinToken
+	if err := tx.Find(&model, "token = ?", token).Error; err != nil {
+		return JoinToken{}, err
+	}
+	if err := tx.Model(&model).Related(&model.Selectors).Error; err != nil {
+		return JoinToken{}, err
+	}
+	if err := tx.Model(&model).Order("number").Related(&model.Uses).Error; err != nil {
+		return JoinToken{}, err
+	}
+	return model, nil
+}
+
+func deleteJoinTokenSupport(tx *gorm.DB, model JoinToken) error {
+	if err := tx.Delete(&model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	if err := tx.Exec("DELETE FROM join_token_selectors WHERE join_token_id = ?", model.ID).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	if err := tx.Exec("DELETE FROM join_token_uses WHERE join_token_id = ?", model.ID).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	return nil
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -2524,6 +3542,68 @@ func bundleToModel(pb *common.Bundle) (*Bundle, error) {
 	}, nil
 }
 
+func federationRelationshipToModel(pb *common.FederationRelationship) (*FederationRelationship, error) {
+	if pb == nil {
+		return nil, sqlError.New("missing federation relationship in request")
+	}
+	id, err := idutil.NormalizeSpiffeID(pb.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &FederationRelationship{
+		TrustDomain:           id,
+		BundleEndpointURL:     pb.BundleEndpointUrl,
+		BundleEndpointProfile: pb.BundleEndpointProfile,
+		EndpointSpiffeID:      pb.EndpointSpiffeId,
+	}, nil
+}
+
+func modelToFederationRelationship(model *FederationRelationship) *common.FederationRelationship {
+	return &common.FederationRelationship{
+		TrustDomainId:         model.TrustDomain,
+		BundleEndpointUrl:     model.BundleEndpointURL,
+		BundleEndpointProfile: model.BundleEndpointProfile,
+		EndpointSpiffeId:      model.EndpointSpiffeID,
+	}
+}
+
+func agentBanToModel(spiffeID string, selector *common.Selector) (*AgentBan, error) {
+	switch {
+	case spiffeID == "" && selector == nil:
+		return nil, sqlError.New("agent ban must have a SPIFFE ID or a selector")
+	case spiffeID != "" && selector != nil:
+		return nil, sqlError.New("agent ban cannot have both a SPIFFE ID and a selector")
+	case selector != nil && (selector.Type == "" || selector.Value == ""):
+		return nil, sqlError.New("agent ban selector must have a type and a value")
+	case selector != nil:
+		return &AgentBan{
+			SelectorType:  selector.Type,
+			SelectorValue: selector.Value,
+		}, nil
+	default:
+		return &AgentBan{
+			SpiffeID: spiffeID,
+		}, nil
+	}
+}
+
+func modelToAgentBan(model *AgentBan) *common.AgentBan {
+	ban := &common.AgentBan{
+		SpiffeId:  model.SpiffeID,
+		Reason:    model.Reason,
+		CreatedAt: model.CreatedAt.Unix(),
+		ExpiresAt: model.ExpiresAt,
+	}
+	if model.SelectorType != "" {
+		ban.Selector = &common.Selector{
+			Type:  model.SelectorType,
+			Value: model.SelectorValue,
+		}
+	}
+	return ban
+}
+
 func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry, error) {
 	var fetchedSelectors []*Selector
 	if err := tx.Model(&model).Related(&fetchedSelectors).Error; err != nil {
@@ -2574,7 +3654,12 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		federatesWith = append(federatesWith, bundle.TrustDomain)
 	}
 
-	return &common.RegistrationEntry{
+	var fetchedConstraints []DownstreamConstraint
+	if err := tx.Model(&model).Order("id ASC").Related(&fetchedConstraints).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	entry := &common.RegistrationEntry{
 		EntryId:       model.EntryID,
 		Selectors:     selectors,
 		SpiffeId:      model.SpiffeID,
@@ -2586,7 +3671,17 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		EntryExpiry:   model.Expiry,
 		DnsNames:      dnsList,
 		IPAddresses:   ipAddressList,
-	}, nil
+	}
+	fillEntryDownstreamConstraints(entry, fetchedConstraints)
+
+	var fetchedClaims []JWTClaim
+	if err := tx.Model(&model).Related(&fetchedClaims).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	for _, claim := range fetchedClaims {
+		fillEntryJWTClaim(entry, claim)
+	}
+	return entry, nil
 }
 
 func newRegistrationEntryID() (string, error) {
@@ -2605,14 +3700,33 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 		CertNotAfter:        model.ExpiresAt.Unix(),
 		NewCertSerialNumber: model.NewSerialNumber,
 		NewCertNotAfter:     nullableDBTimeToUnixTime(model.NewExpiresAt),
+		AgentVersion:        model.AgentVersion,
+		LastSeenAt:          nullableDBTimeToUnixTime(model.LastSeenAt),
+		RemoteAddress:       model.RemoteAddress,
 	}
 }
 
 func modelToJoinToken(model JoinToken) *datastore.JoinToken {
-	return &datastore.JoinToken{
-		Token:  model.Token,
-		Expiry: model.Expiry,
+	joinToken := &datastore.JoinToken{
+		Token:           model.Token,
+		Expiry:          model.Expiry,
+		AgentIdTemplate: model.AgentIDTemplate,
+		MaxUses:         model.MaxUses,
+	}
+	for _, selector := range model.Selectors {
+		joinToken.Selectors = append(joinToken.Selectors, &common.Selector{
+			Type:  selector.Type,
+			Value: selector.Value,
+		})
+	}
+	for _, use := range model.Uses {
+		joinToken.Uses = append(joinToken.Uses, &datastore.JoinTokenUse{
+			Use:     use.Number,
+			AgentId: use.AgentID,
+			UsedAt:  use.UsedAt,
+		})
 	}
+	return joinToken
 }
 
 func makeFederatesWith(tx *gorm.DB, ids []string) ([]*Bundle, error) {
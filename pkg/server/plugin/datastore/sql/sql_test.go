@@ -290,6 +290,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	bundle2 := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, s.cacert)
 	appendedBundle := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId,
 		[]*x509.Certificate{s.cert, s.cacert})
+	appendedBundle.SequenceNumber = 1
 
 	// append
 	expectedCallCounter = ds_telemetry.StartAppendBundleCall(s.expectedMetrics)
@@ -321,20 +322,22 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.Require().NoError(err)
 	s.AssertProtoEqual(bundle3, anresp.Bundle)
 
-	// update
+	// update (the sequence number is incremented past the stored one)
+	updatedBundle := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, s.cacert)
+	updatedBundle.SequenceNumber = 2
 	expectedCallCounter = ds_telemetry.StartUpdateBundleCall(s.expectedMetrics)
 	uresp, err := s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
 		Bundle: bundle2,
 	})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	s.AssertProtoEqual(bundle2, uresp.Bundle)
+	s.AssertProtoEqual(updatedBundle, uresp.Bundle)
 
 	expectedCallCounter = ds_telemetry.StartListBundleCall(s.expectedMetrics)
 	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	assertBundlesEqual(s.T(), []*common.Bundle{bundle2, bundle3}, lresp.Bundles)
+	assertBundlesEqual(s.T(), []*common.Bundle{updatedBundle, bundle3}, lresp.Bundles)
 
 	// delete
 	expectedCallCounter = ds_telemetry.StartDeleteBundleCall(s.expectedMetrics)
@@ -343,7 +346,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	s.AssertProtoEqual(bundle2, dresp.Bundle)
+	s.AssertProtoEqual(updatedBundle, dresp.Bundle)
 
 	expectedCallCounter = ds_telemetry.StartListBundleCall(s.expectedMetrics)
 	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
@@ -388,18 +391,83 @@ func (s *PluginSuite) TestSetBundle() {
 	s.Require().NoError(err)
 	s.RequireProtoEqual(bundle, s.fetchBundle("spiffe://foo"))
 
-	// set the bundle and make sure it is updated
+	// set the bundle and make sure it is updated, incrementing the sequence
+	// number since the contents changed
+	expectedCallCounter = ds_telemetry.StartSetBundleCall(s.expectedMetrics)
+	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundle2,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(1), s.fetchBundle("spiffe://foo").SequenceNumber)
+
+	// setting identical contents leaves the sequence number alone
 	expectedCallCounter = ds_telemetry.StartSetBundleCall(s.expectedMetrics)
 	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
 		Bundle: bundle2,
 	})
 	expectedCallCounter.Done(nil)
 	s.Require().NoError(err)
-	s.RequireProtoEqual(bundle2, s.fetchBundle("spiffe://foo"))
+	s.Require().Equal(uint64(1), s.fetchBundle("spiffe://foo").SequenceNumber)
+
+	// set the bundle preserving its sequence number
+	expectedCallCounter = ds_telemetry.StartSetBundleCall(s.expectedMetrics)
+	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle:                 bundle,
+		PreserveSequenceNumber: true,
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.RequireProtoEqual(bundle, s.fetchBundle("spiffe://foo"))
 
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestSetBundleUpstream() {
+	listUpstream := func() []string {
+		resp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{ByUpstream: true})
+		s.Require().NoError(err)
+		var trustDomainIDs []string
+		for _, bundle := range resp.Bundles {
+			trustDomainIDs = append(trustDomainIDs, bundle.TrustDomainId)
+		}
+		return trustDomainIDs
+	}
+
+	s.createBundle("spiffe://local")
+
+	// bundles set on behalf of the upstream server are marked
+	_, err := s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle:   bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert),
+		Upstream: true,
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle:   bundleutil.BundleProtoFromRootCA("spiffe://bar", s.cert),
+		Upstream: true,
+	})
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"spiffe://foo", "spiffe://bar"}, listUpstream())
+
+	// updating the bundle keeps the mark
+	_, err = s.ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cacert),
+	})
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{"spiffe://foo", "spiffe://bar"}, listUpstream())
+
+	// setting the bundle without the mark clears it
+	_, err = s.ds.SetBundle(ctx, &datastore.SetBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA("spiffe://bar", s.cacert),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"spiffe://foo"}, listUpstream())
+
+	resp, err := s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Bundles, 3)
+}
+
 func (s *PluginSuite) TestBundlePrune() {
 	// Setup
 	// Create new bundle with two cert (one valid and one expired)
@@ -465,6 +533,7 @@ func (s *PluginSuite) TestBundlePrune() {
 	// Fetch and verify pruned bundle is the expected
 	expectedPrunedBundle := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert})
 	expectedPrunedBundle.JwtSigningKeys = []*common.PublicKey{{NotAfter: nonExpiredKeyTime.Unix()}}
+	expectedPrunedBundle.SequenceNumber = 1
 	expectedCallCounter = ds_telemetry.StartFetchBundleCall(s.expectedMetrics)
 	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
 	expectedCallCounter.Done(nil)
@@ -474,6 +543,353 @@ func (s *PluginSuite) TestBundlePrune() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestRemoveBundleJWTSigningKeys() {
+	bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
+	bundle.JwtSigningKeys = []*common.PublicKey{
+		{Kid: "server"},
+		{Kid: "agent.a.1"},
+		{Kid: "agent.a.2"},
+		{Kid: "agent.b.1"},
+	}
+	bundle.RevokedJwtKeyIds = []string{"agent.a.1", "agent.b.1"}
+
+	// missing key ID prefix
+	_, err := s.ds.RemoveBundleJWTSigningKeys(ctx, &datastore.RemoveBundleJWTSigningKeysRequest{
+		TrustDomainId: "spiffe://foo",
+	})
+	s.RequireErrorContains(err, "key ID prefix is required")
+
+	// nothing to remove if there is no bundle
+	resp, err := s.ds.RemoveBundleJWTSigningKeys(ctx, &datastore.RemoveBundleJWTSigningKeysRequest{
+		TrustDomainId: "spiffe://foo",
+		KeyIdPrefix:   "agent.a.",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), resp.Removed)
+
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+
+	resp, err = s.ds.RemoveBundleJWTSigningKeys(ctx, &datastore.RemoveBundleJWTSigningKeysRequest{
+		TrustDomainId: "spiffe://foo",
+		KeyIdPrefix:   "agent.a.",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), resp.Removed)
+
+	expectedBundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
+	expectedBundle.JwtSigningKeys = []*common.PublicKey{
+		{Kid: "server"},
+		{Kid: "agent.b.1"},
+	}
+	expectedBundle.RevokedJwtKeyIds = []string{"agent.b.1"}
+	expectedBundle.SequenceNumber = 1
+	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(expectedBundle, fresp.Bundle)
+
+	// removing again is a no-op
+	resp, err = s.ds.RemoveBundleJWTSigningKeys(ctx, &datastore.RemoveBundleJWTSigningKeysRequest{
+		TrustDomainId: "spiffe://foo",
+		KeyIdPrefix:   "agent.a.",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), resp.Removed)
+}
+
+func (s *PluginSuite) TestBundleHistory() {
+	bundle1 := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
+	bundle1.SequenceNumber = 1
+	bundle2 := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cacert)
+	bundle2.SequenceNumber = 2
+	bundle3 := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert, s.cacert})
+	bundle3.SequenceNumber = 3
+	otherBundle := bundleutil.BundleProtoFromRootCA("spiffe://bar", s.cert)
+
+	appendHistory := func(bundle *common.Bundle, receivedAt int64) {
+		_, err := s.ds.AppendBundleHistory(ctx, &datastore.AppendBundleHistoryRequest{
+			Entry: &datastore.BundleHistoryEntry{
+				Bundle:     bundle,
+				ReceivedAt: receivedAt,
+			},
+			MaxEntries: 2,
+		})
+		s.Require().NoError(err)
+	}
+	listHistory := func(trustDomainID string) []*datastore.BundleHistoryEntry {
+		resp, err := s.ds.ListBundleHistory(ctx, &datastore.ListBundleHistoryRequest{
+			TrustDomainId: trustDomainID,
+		})
+		s.Require().NoError(err)
+		return resp.Entries
+	}
+
+	// no history
+	s.Require().Empty(listHistory("spiffe://foo"))
+
+	// missing entry
+	_, err := s.ds.AppendBundleHistory(ctx, &datastore.AppendBundleHistoryRequest{})
+	s.RequireErrorContains(err, "missing bundle history entry in request")
+
+	appendHistory(bundle1, 1)
+	appendHistory(otherBundle, 2)
+	appendHistory(bundle2, 3)
+	s.RequireProtoListEqual([]*datastore.BundleHistoryEntry{
+		{Bundle: bundle1, ReceivedAt: 1},
+		{Bundle: bundle2, ReceivedAt: 3},
+	}, listHistory("spiffe://foo"))
+
+	// the oldest entry is dropped when the maximum is reached
+	appendHistory(bundle3, 4)
+	s.RequireProtoListEqual([]*datastore.BundleHistoryEntry{
+		{Bundle: bundle2, ReceivedAt: 3},
+		{Bundle: bundle3, ReceivedAt: 4},
+	}, listHistory("spiffe://foo"))
+
+	// other trust domains are unaffected
+	s.RequireProtoListEqual([]*datastore.BundleHistoryEntry{
+		{Bundle: otherBundle, ReceivedAt: 2},
+	}, listHistory("spiffe://bar"))
+
+	// deleting the bundle deletes the history
+	s.createBundle("spiffe://foo")
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://foo",
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(listHistory("spiffe://foo"))
+	s.Require().Len(listHistory("spiffe://bar"), 1)
+}
+
+func (s *PluginSuite) TestCountBundles() {
+	resp, err := s.ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), resp.Bundles)
+
+	s.createBundle("spiffe://foo")
+	s.createBundle("spiffe://bar")
+
+	resp, err = s.ds.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), resp.Bundles)
+}
+
+func (s *PluginSuite) TestFederationRelationshipCRUD() {
+	relationship := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://foo",
+		BundleEndpointUrl:     "https://foo:8443",
+		BundleEndpointProfile: "https_spiffe",
+		EndpointSpiffeId:      "spiffe://foo/bundle-endpoint",
+	}
+
+	// fetch non-existent
+	fresp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{TrustDomainId: "spiffe://foo"})
+	s.Require().NoError(err)
+	s.Require().NotNil(fresp)
+	s.Require().Nil(fresp.Relationship)
+
+	// update non-existent
+	_, err = s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{Relationship: relationship})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// delete non-existent
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{TrustDomainId: "spiffe://foo"})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// missing relationship
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{})
+	s.RequireErrorContains(err, "missing federation relationship in request")
+
+	// create
+	cresp, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, cresp.Relationship)
+
+	// create duplicate
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship,
+	})
+	s.Require().Error(err)
+
+	// fetch (with denormalized id)
+	fresp, err = s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{TrustDomainId: "spiffe://fOO"})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, fresp.Relationship)
+
+	// update
+	relationship2 := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://foo",
+		BundleEndpointUrl:     "https://foo/bundle",
+		BundleEndpointProfile: "https_web",
+	}
+	uresp, err := s.ds.UpdateFederationRelationship(ctx, &datastore.UpdateFederationRelationshipRequest{
+		Relationship: relationship2,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship2, uresp.Relationship)
+
+	// list
+	relationship3 := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://bar",
+		BundleEndpointUrl:     "https://bar",
+		BundleEndpointProfile: "https_web",
+	}
+	_, err = s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+		Relationship: relationship3,
+	})
+	s.Require().NoError(err)
+
+	lresp, err := s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.FederationRelationship{relationship3, relationship2}, lresp.Relationships)
+
+	// delete
+	dresp, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship2, dresp.Relationship)
+
+	lresp, err = s.ds.ListFederationRelationships(ctx, &datastore.ListFederationRelationshipsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.FederationRelationship{relationship3}, lresp.Relationships)
+}
+
+func (s *PluginSuite) TestDeleteFederationRelationshipWithBundle() {
+	relationship := &common.FederationRelationship{
+		TrustDomainId:         "spiffe://foo",
+		BundleEndpointUrl:     "https://foo.test/bundle",
+		BundleEndpointProfile: "https_web",
+	}
+	createRelationship := func() {
+		_, err := s.ds.CreateFederationRelationship(ctx, &datastore.CreateFederationRelationshipRequest{
+			Relationship: relationship,
+		})
+		s.Require().NoError(err)
+	}
+
+	// deleting the bundle is a no-op if there is none
+	createRelationship()
+	dresp, err := s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+		DeleteBundle:  true,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, dresp.Relationship)
+	s.Nil(dresp.Bundle)
+
+	createRelationship()
+	bundle := bundleutil.BundleProtoFromRootCA("spiffe://foo", s.cert)
+	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{Bundle: bundle})
+	s.Require().NoError(err)
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors:     []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/bar",
+		FederatesWith: []string{"spiffe://foo"},
+	})
+
+	// the relationship is kept if the bundle cannot be deleted
+	_, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+		DeleteBundle:  true,
+	})
+	s.RequireErrorContains(err, "cannot delete bundle; federated with 1 registration entries")
+	fresp, err := s.ds.FetchFederationRelationship(ctx, &datastore.FetchFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(relationship, fresp.Relationship)
+
+	dresp, err = s.ds.DeleteFederationRelationship(ctx, &datastore.DeleteFederationRelationshipRequest{
+		TrustDomainId: "spiffe://foo",
+		DeleteBundle:  true,
+		BundleMode:    datastore.DeleteBundleRequest_DISSOCIATE,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(bundle, dresp.Bundle)
+	s.Nil(s.fetchBundle("spiffe://foo"))
+	s.Empty(s.fetchRegistrationEntry(entry.EntryId).FederatesWith)
+}
+
+func (s *PluginSuite) TestAgentBanCRUD() {
+	idBan := &common.AgentBan{
+		SpiffeId:  "spiffe://example.org/spire/agent/aws_iid/123456789012/us-east-1/i-0123456789abcdef0",
+		Reason:    "compromised",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	selectorBan := &common.AgentBan{
+		Selector: &common.Selector{Type: "x509pop", Value: "subject:cn:node1"},
+	}
+
+	// delete non-existent
+	_, err := s.ds.DeleteAgentBan(ctx, &datastore.DeleteAgentBanRequest{SpiffeId: idBan.SpiffeId})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	// invalid bans
+	_, err = s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{})
+	s.RequireErrorContains(err, "missing agent ban in request")
+	_, err = s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{Ban: &common.AgentBan{}})
+	s.RequireErrorContains(err, "agent ban must have a SPIFFE ID or a selector")
+	_, err = s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{Ban: &common.AgentBan{
+		SpiffeId: idBan.SpiffeId,
+		Selector: selectorBan.Selector,
+	}})
+	s.RequireErrorContains(err, "agent ban cannot have both a SPIFFE ID and a selector")
+	_, err = s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{Ban: &common.AgentBan{
+		Selector: &common.Selector{Type: "x509pop"},
+	}})
+	s.RequireErrorContains(err, "agent ban selector must have a type and a value")
+
+	// create
+	for _, ban := range []*common.AgentBan{idBan, selectorBan} {
+		resp, err := s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{Ban: ban})
+		s.Require().NoError(err)
+		s.Require().NotZero(resp.Ban.CreatedAt)
+		resp.Ban.CreatedAt = 0
+		s.AssertProtoEqual(ban, resp.Ban)
+	}
+
+	// create duplicate
+	_, err = s.ds.CreateAgentBan(ctx, &datastore.CreateAgentBanRequest{Ban: selectorBan})
+	s.Require().Error(err)
+
+	listBans := func(req *datastore.ListAgentBansRequest) []*common.AgentBan {
+		resp, err := s.ds.ListAgentBans(ctx, req)
+		s.Require().NoError(err)
+		for _, ban := range resp.Bans {
+			ban.CreatedAt = 0
+		}
+		return resp.Bans
+	}
+
+	// list
+	s.RequireProtoListEqual([]*common.AgentBan{idBan, selectorBan}, listBans(&datastore.ListAgentBansRequest{}))
+	s.RequireProtoListEqual([]*common.AgentBan{idBan}, listBans(&datastore.ListAgentBansRequest{
+		BySpiffeId: idBan.SpiffeId,
+	}))
+	s.RequireProtoListEqual([]*common.AgentBan{selectorBan}, listBans(&datastore.ListAgentBansRequest{
+		BySpiffeId: "spiffe://example.org/spire/agent/x509pop/other",
+		BySelectors: []*common.Selector{
+			{Type: "x509pop", Value: "subject:cn:node2"},
+			{Type: "x509pop", Value: "subject:cn:node1"},
+		},
+	}))
+	s.Require().Empty(listBans(&datastore.ListAgentBansRequest{
+		BySelectors: []*common.Selector{{Type: "x509pop", Value: "subject:cn:node2"}},
+	}))
+
+	// delete
+	dresp, err := s.ds.DeleteAgentBan(ctx, &datastore.DeleteAgentBanRequest{Selector: selectorBan.Selector})
+	s.Require().NoError(err)
+	dresp.Ban.CreatedAt = 0
+	s.AssertProtoEqual(selectorBan, dresp.Ban)
+
+	s.RequireProtoListEqual([]*common.AgentBan{idBan}, listBans(&datastore.ListAgentBansRequest{}))
+}
+
 func (s *PluginSuite) TestCreateAttestedNode() {
 	node := &common.AttestedNode{
 		SpiffeId:            "foo",
@@ -557,6 +973,161 @@ func (s *PluginSuite) TestFetchStaleNodes() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestCountAttestedNodes() {
+	now := time.Now()
+	for i, notAfter := range []time.Time{
+		now.Add(-time.Hour),
+		now.Add(time.Minute),
+		now.Add(time.Hour),
+	} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            fmt.Sprintf("spiffe://example.org/node%d", i),
+				AttestationDataType: "aws-tag",
+				CertSerialNumber:    "badcafe",
+				CertNotAfter:        notAfter.Unix(),
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	expectedCallCounter := ds_telemetry.StartCountNodeCall(s.expectedMetrics)
+	resp, err := s.ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int32(3), resp.Nodes)
+
+	expectedCallCounter = ds_telemetry.StartCountNodeCall(s.expectedMetrics)
+	resp, err = s.ds.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{
+		ByExpiresBefore: &wrappers.Int64Value{
+			Value: now.Add(10 * time.Minute).Unix(),
+		},
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), resp.Nodes)
+}
+
+func (s *PluginSuite) TestListAttestedNodesWithFilters() {
+	now := time.Now()
+	makeNode := func(name, attestationType string, notAfter, lastSeenAt time.Time) *common.AttestedNode {
+		node := &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/spire/agent/" + name,
+			AttestationDataType: attestationType,
+			CertSerialNumber:    "badcafe",
+			CertNotAfter:        notAfter.Unix(),
+		}
+		if !lastSeenAt.IsZero() {
+			node.LastSeenAt = lastSeenAt.Unix()
+		}
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+		return node
+	}
+	setSelectors := func(node *common.AttestedNode, selectors ...*common.Selector) {
+		_, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  node.SpiffeId,
+				Selectors: selectors,
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	selectorA := &common.Selector{Type: "a", Value: "1"}
+	selectorB := &common.Selector{Type: "b", Value: "2"}
+
+	node1 := makeNode("node1", "aws-tag", now.Add(time.Hour), now)
+	node2 := makeNode("node2", "aws-tag", now.Add(-time.Hour), now.Add(-2*time.Hour))
+	node3 := makeNode("node3", "x509pop", now.Add(2*time.Hour), time.Time{})
+	setSelectors(node1, selectorA, selectorB)
+	setSelectors(node2, selectorA)
+
+	for _, tt := range []struct {
+		name          string
+		req           *datastore.ListAttestedNodesRequest
+		expectedNodes []*common.AttestedNode
+	}{
+		{
+			name:          "no filters",
+			req:           &datastore.ListAttestedNodesRequest{},
+			expectedNodes: []*common.AttestedNode{node1, node2, node3},
+		},
+		{
+			name: "by attestation type",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: &wrappers.StringValue{Value: "aws-tag"},
+			},
+			expectedNodes: []*common.AttestedNode{node1, node2},
+		},
+		{
+			name: "by single selector",
+			req: &datastore.ListAttestedNodesRequest{
+				BySelectors: []*common.Selector{selectorA},
+			},
+			expectedNodes: []*common.AttestedNode{node1, node2},
+		},
+		{
+			name: "by all selectors",
+			req: &datastore.ListAttestedNodesRequest{
+				BySelectors: []*common.Selector{selectorA, selectorB},
+			},
+			expectedNodes: []*common.AttestedNode{node1},
+		},
+		{
+			name: "by expiry window",
+			req: &datastore.ListAttestedNodesRequest{
+				ByExpiresAfter:  &wrappers.Int64Value{Value: now.Unix()},
+				ByExpiresBefore: &wrappers.Int64Value{Value: now.Add(90 * time.Minute).Unix()},
+			},
+			expectedNodes: []*common.AttestedNode{node1},
+		},
+		{
+			name: "by last seen before includes nodes never seen",
+			req: &datastore.ListAttestedNodesRequest{
+				ByLastSeenBefore: &wrappers.Int64Value{Value: now.Add(-time.Hour).Unix()},
+			},
+			expectedNodes: []*common.AttestedNode{node2, node3},
+		},
+		{
+			name: "combined filters",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: &wrappers.StringValue{Value: "aws-tag"},
+				ByLastSeenBefore:  &wrappers.Int64Value{Value: now.Add(-time.Hour).Unix()},
+				BySelectors:       []*common.Selector{selectorA},
+			},
+			expectedNodes: []*common.AttestedNode{node2},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expectedNodes, resp.Nodes)
+		})
+	}
+
+	// Selectors are only returned when requested
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+		Pagination: &datastore.Pagination{
+			PageSize: 2,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Nodes, 2)
+	s.RequireProtoListEqual([]*common.Selector{selectorA, selectorB}, resp.Nodes[0].Selectors)
+	s.RequireProtoListEqual([]*common.Selector{selectorA}, resp.Nodes[1].Selectors)
+
+	resp, err = s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		FetchSelectors: true,
+		Pagination:     resp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Nodes, 1)
+	s.Empty(resp.Nodes[0].Selectors)
+}
+
 func (s *PluginSuite) TestFetchAttestedNodesWithPagination() {
 	// Create all necessary nodes
 	aNode1 := &common.AttestedNode{
@@ -799,6 +1370,62 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestUpdateAttestedNodeLastSeen() {
+	node := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	// update non-existing attested node
+	_, err := s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   node.SpiffeId,
+		LastSeenAt: time.Now().Unix(),
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, _notFoundErrMsg)
+
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+
+	// the last seen time is required
+	_, err = s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId: node.SpiffeId,
+	})
+	s.RequireErrorContains(err, "invalid request: missing last seen time")
+
+	lastSeenAt := time.Now().Unix()
+	expectedCallCounter := ds_telemetry.StartUpdateNodeLastSeenCall(s.expectedMetrics)
+	uresp, err := s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:      node.SpiffeId,
+		LastSeenAt:    lastSeenAt,
+		AgentVersion:  "0.10.0",
+		RemoteAddress: "127.0.0.1:12345",
+	})
+	expectedCallCounter.Done(nil)
+	s.Require().NoError(err)
+
+	node.LastSeenAt = lastSeenAt
+	node.AgentVersion = "0.10.0"
+	node.RemoteAddress = "127.0.0.1:12345"
+	s.AssertProtoEqual(node, uresp.Node)
+
+	// The version and address are kept when not reported
+	lastSeenAt++
+	uresp, err = s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   node.SpiffeId,
+		LastSeenAt: lastSeenAt,
+	})
+	s.Require().NoError(err)
+
+	node.LastSeenAt = lastSeenAt
+	s.AssertProtoEqual(node, uresp.Node)
+
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(node, fresp.Node)
+}
+
 func (s *PluginSuite) TestDeleteAttestedNode() {
 	entry := &common.AttestedNode{
 		SpiffeId:            "foo",
@@ -834,6 +1461,90 @@ func (s *PluginSuite) TestDeleteAttestedNode() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestPruneAttestedNodes() {
+	now := time.Now()
+	expired := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/expired",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        now.Add(-time.Hour).Unix(),
+	}
+	unexpired := &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/unexpired",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	selectors := []*common.Selector{{Type: "TYPE", Value: "VALUE"}}
+
+	for _, node := range []*common.AttestedNode{expired, unexpired} {
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+		s.setNodeSelectors(node.SpiffeId, selectors)
+	}
+
+	// nothing expired before an hour ago
+	resp, err := s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Add(-2 * time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Empty(resp.Nodes)
+
+	resp, err = s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Unix(),
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.AttestedNode{expired}, resp.Nodes)
+
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: expired.SpiffeId})
+	s.Require().NoError(err)
+	s.Nil(fresp.Node)
+	s.Empty(s.getNodeSelectors(expired.SpiffeId, false))
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: unexpired.SpiffeId})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(unexpired, fresp.Node)
+	s.RequireProtoListEqual(selectors, s.getNodeSelectors(unexpired.SpiffeId, false))
+}
+
+func (s *PluginSuite) TestPruneAttestedNodesWithLimit() {
+	now := time.Now()
+	var expired []*common.AttestedNode
+	for _, name := range []string{"one", "two", "three"} {
+		node := &common.AttestedNode{
+			SpiffeId:            "spiffe://example.org/" + name,
+			AttestationDataType: "aws-tag",
+			CertSerialNumber:    name,
+			CertNotAfter:        now.Add(-time.Hour).Unix(),
+		}
+		_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+		s.Require().NoError(err)
+		expired = append(expired, node)
+	}
+
+	// the oldest nodes are pruned first, up to the limit
+	resp, err := s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Unix(),
+		Limit:         2,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(expired[:2], resp.Nodes)
+
+	resp, err = s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Unix(),
+		Limit:         2,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(expired[2:], resp.Nodes)
+
+	resp, err = s.ds.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+		ExpiresBefore: now.Unix(),
+		Limit:         2,
+	})
+	s.Require().NoError(err)
+	s.Empty(resp.Nodes)
+}
+
 func (s *PluginSuite) TestNodeSelectors() {
 	foo1 := []*common.Selector{
 		{Type: "FOO1", Value: "1"},
@@ -1106,6 +1817,27 @@ func (s *PluginSuite) TestListRegistrationEntries() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestCountRegistrationEntries() {
+	resp, err := s.ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), resp.Entries)
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	})
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		SpiffeId:  "spiffe://example.org/baz",
+		ParentId:  "spiffe://example.org/bar",
+	})
+
+	resp, err = s.ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), resp.Entries)
+}
+
 func (s *PluginSuite) TestListRegistrationEntriesWithPagination() {
 	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
 		Selectors: []*common.Selector{
@@ -1496,6 +2228,122 @@ func (s *PluginSuite) TestDeleteRegistrationEntry() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestRegistrationEntryJWTClaims() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{
+			{Type: "Type1", Value: "Value1"},
+		},
+		SpiffeId:          "spiffe://example.org/claims",
+		ParentId:          "spiffe://example.org/bar",
+		Ttl:               1,
+		JwtClaims:         map[string]string{"tenant": "acme", "env": "prod"},
+		JwtClaimTemplates: map[string]string{"namespace": `{{ selector "k8s:ns" }}`},
+	})
+	s.Require().Equal(map[string]string{"tenant": "acme", "env": "prod"}, entry.JwtClaims)
+	s.Require().Equal(map[string]string{"namespace": `{{ selector "k8s:ns" }}`}, entry.JwtClaimTemplates)
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{
+			{Type: "Type1", Value: "Value1"},
+		},
+		SpiffeId: "spiffe://example.org/noclaims",
+		ParentId: "spiffe://example.org/bar",
+		Ttl:      1,
+	})
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fetchResp.Entry)
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Entries, 2)
+	for _, listed := range listResp.Entries {
+		if listed.EntryId == entry.EntryId {
+			s.RequireProtoEqual(entry, listed)
+		} else {
+			s.Empty(listed.JwtClaims)
+			s.Empty(listed.JwtClaimTemplates)
+		}
+	}
+
+	// claims are replaced on update
+	entry.JwtClaims = map[string]string{"tenant": "other"}
+	entry.JwtClaimTemplates = nil
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: entry})
+	s.Require().NoError(err)
+
+	fetchResp, err = s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fetchResp.Entry)
+
+	// claims are returned on delete
+	deleteResp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, deleteResp.Entry)
+
+	var count int
+	s.Require().NoError(s.sqlPlugin.db.Model(&JWTClaim{}).Count(&count).Error)
+	s.Require().Zero(count)
+}
+
+func (s *PluginSuite) TestRegistrationEntryDownstreamConstraints() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{
+			{Type: "Type1", Value: "Value1"},
+		},
+		SpiffeId:               "spiffe://example.org/downstream",
+		ParentId:               "spiffe://example.org/bar",
+		Ttl:                    1,
+		Downstream:             true,
+		DownstreamPathPrefixes: []string{"/ns/foo", "/ns/bar"},
+		DownstreamDnsDomains:   []string{"foo.example.org"},
+	})
+	s.Require().Equal([]string{"/ns/foo", "/ns/bar"}, entry.DownstreamPathPrefixes)
+	s.Require().Equal([]string{"foo.example.org"}, entry.DownstreamDnsDomains)
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{
+			{Type: "Type1", Value: "Value1"},
+		},
+		SpiffeId:   "spiffe://example.org/unconstrained",
+		ParentId:   "spiffe://example.org/bar",
+		Ttl:        1,
+		Downstream: true,
+	})
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fetchResp.Entry)
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Entries, 2)
+	for _, listed := range listResp.Entries {
+		if listed.EntryId == entry.EntryId {
+			s.RequireProtoEqual(entry, listed)
+		} else {
+			s.Empty(listed.DownstreamPathPrefixes)
+			s.Empty(listed.DownstreamDnsDomains)
+		}
+	}
+
+	// constraints are replaced on update
+	entry.DownstreamPathPrefixes = []string{"/ns/baz"}
+	entry.DownstreamDnsDomains = nil
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{Entry: entry})
+	s.Require().NoError(err)
+
+	fetchResp, err = s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, fetchResp.Entry)
+
+	// constraints are returned on delete
+	deleteResp, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: entry.EntryId})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(entry, deleteResp.Entry)
+}
+
 func (s *PluginSuite) TestListParentIDEntries() {
 	allEntries := make([]*common.RegistrationEntry, 0)
 	s.getTestDataFromJSONFile(filepath.Join("testdata", "entries.json"), &allEntries)
@@ -1897,6 +2745,72 @@ func (s *PluginSuite) TestPruneJoinTokens() {
 	s.Require().Equal(s.expectedMetrics.AllMetrics(), s.m.AllMetrics())
 }
 
+func (s *PluginSuite) TestUseJoinToken() {
+	joinToken := &datastore.JoinToken{
+		Token:           "foobar",
+		Expiry:          time.Now().Add(time.Hour).Unix(),
+		AgentIdTemplate: "imaging/{{ .Use }}",
+		Selectors:       []*common.Selector{{Type: "join_token", Value: "rack:1"}},
+		MaxUses:         2,
+	}
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: joinToken,
+	})
+	s.Require().NoError(err)
+
+	use := func(n int32) (*datastore.UseJoinTokenResponse, error) {
+		return s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+			Token: joinToken.Token,
+			Use: &datastore.JoinTokenUse{
+				Use:     n,
+				AgentId: fmt.Sprintf("spiffe://example.org/spire/agent/imaging/%d", n),
+				UsedAt:  int64(n),
+			},
+		})
+	}
+
+	// Uses must be recorded in order
+	_, err = use(2)
+	s.RequireErrorContains(err, "join token use 2 is not the next use (1)")
+
+	resp, err := use(1)
+	s.Require().NoError(err)
+	s.Require().Len(resp.JoinToken.Uses, 1)
+
+	// The same use cannot be recorded twice
+	_, err = use(1)
+	s.RequireErrorContains(err, "join token use 1 is not the next use (2)")
+
+	_, err = use(2)
+	s.Require().NoError(err)
+
+	// The quota is exhausted
+	_, err = use(3)
+	s.RequireErrorContains(err, "join token has no uses left")
+
+	fetchResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	joinToken.Uses = []*datastore.JoinTokenUse{
+		{Use: 1, AgentId: "spiffe://example.org/spire/agent/imaging/1", UsedAt: 1},
+		{Use: 2, AgentId: "spiffe://example.org/spire/agent/imaging/2", UsedAt: 2},
+	}
+	s.RequireProtoEqual(joinToken, fetchResp.JoinToken)
+
+	// Deleting the token deletes its selectors and uses
+	_, err = s.ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+
+	var count int
+	s.Require().NoError(s.sqlPlugin.db.Model(&JoinTokenSelector{}).Count(&count).Error)
+	s.Equal(0, count)
+	s.Require().NoError(s.sqlPlugin.db.Model(&JoinTokenUse{}).Count(&count).Error)
+	s.Equal(0, count)
+}
+
 func (s *PluginSuite) TestGetPluginInfo() {
 	resp, err := s.ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.Require().NoError(err)
@@ -2129,6 +3043,88 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().Empty(resp.Node.NewCertNotAfter)
 		case 13:
 			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("registered_entries", "revision_number"))
+		case 14:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("downstream_constraints"))
+
+			// pre-existing downstream entries are left unconstrained
+			resp, err := s.ds.FetchRegistrationEntry(context.Background(), &datastore.FetchRegistrationEntryRequest{
+				EntryId: "f0373f87-a0f3-4c94-aa6a-a2f948bfc15a",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.Entry)
+			s.Require().True(resp.Entry.Downstream)
+			s.Require().Empty(resp.Entry.DownstreamPathPrefixes)
+			s.Require().Empty(resp.Entry.DownstreamDnsDomains)
+		case 15:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("bundle_history"))
+
+			// pre-existing bundles have no history
+			resp, err := s.ds.ListBundleHistory(context.Background(), &datastore.ListBundleHistoryRequest{
+				TrustDomainId: "spiffe://otherdomain.test",
+			})
+			s.Require().NoError(err)
+			s.Require().Empty(resp.Entries)
+		case 16:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("federation_relationships"))
+
+			resp, err := s.ds.ListFederationRelationships(context.Background(), &datastore.ListFederationRelationshipsRequest{})
+			s.Require().NoError(err)
+			s.Require().Empty(resp.Relationships)
+		case 17:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("jwt_claims"))
+
+			// pre-existing entries have no custom claims
+			resp, err := s.ds.FetchRegistrationEntry(context.Background(), &datastore.FetchRegistrationEntryRequest{
+				EntryId: "f0373f87-a0f3-4c94-aa6a-a2f948bfc15a",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.Entry)
+			s.Require().Empty(resp.Entry.JwtClaims)
+			s.Require().Empty(resp.Entry.JwtClaimTemplates)
+		case 18:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasIndex("attested_node_entries", "idx_attested_node_entries_expires_at"))
+		case 19:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("join_token_selectors"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("join_token_uses"))
+
+			// pre-existing tokens remain single-use
+			resp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: "legacy-token",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.JoinToken)
+			s.Require().Zero(resp.JoinToken.MaxUses)
+			s.Require().Empty(resp.JoinToken.AgentIdTemplate)
+
+			_, err = s.ds.UseJoinToken(context.Background(), &datastore.UseJoinTokenRequest{
+				Token: "legacy-token",
+				Use:   &datastore.JoinTokenUse{Use: 1, AgentId: "spiffe://example.org/spire/agent/join_token/legacy-token"},
+			})
+			s.Require().NoError(err)
+			_, err = s.ds.UseJoinToken(context.Background(), &datastore.UseJoinTokenRequest{
+				Token: "legacy-token",
+				Use:   &datastore.JoinTokenUse{Use: 2, AgentId: "spiffe://example.org/spire/agent/join_token/legacy-token"},
+			})
+			s.Require().Error(err)
+		case 20:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasTable("agent_bans"))
+
+			_, err := s.ds.CreateAgentBan(context.Background(), &datastore.CreateAgentBanRequest{
+				Ban: &common.AgentBan{SpiffeId: "spiffe://example.org/spire/agent/join_token/legacy-token"},
+			})
+			s.Require().NoError(err)
+		case 21:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("attested_node_entries", "agent_version"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("attested_node_entries", "last_seen_at"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("attested_node_entries", "remote_address"))
+			s.Require().True(s.sqlPlugin.db.Dialect().HasIndex("attested_node_entries", "idx_attested_node_entries_last_seen_at"))
+		case 22:
+			s.Require().True(s.sqlPlugin.db.Dialect().HasColumn("bundles", "upstream"))
+
+			// bundles stored before the migration are not marked as upstream
+			resp, err := s.ds.ListBundles(context.Background(), &datastore.ListBundlesRequest{ByUpstream: true})
+			s.Require().NoError(err)
+			s.Require().Empty(resp.Bundles)
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -5,6 +5,7 @@ package hostservices
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/proto/spire/server/hostservices"
@@ -65,6 +66,18 @@ func (c *agentStoreHostServiceClient) InitHostServiceClient(conn *grpc.ClientCon
 	*c.client = AdaptAgentStoreHostServiceClient(hostservices.NewAgentStoreClient(conn))
 }
 
+// RequireAgentStoreHostService obtains a client for the AgentStore host service from the broker. It fails if the host does not provide the host service.
+func RequireAgentStoreHostService(broker catalog.HostServiceBroker, client *AgentStore) error {
+	has, err := broker.GetHostService(AgentStoreHostServiceClient(client))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("required %s host service not available", AgentStoreType)
+	}
+	return nil
+}
+
 func AdaptAgentStoreHostServiceClient(client AgentStoreClient) AgentStore {
 	return agentStoreHostServiceClientAdapter{client: client}
 }
@@ -5,6 +5,7 @@ package hostservices
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/proto/spire/server/hostservices"
@@ -65,6 +66,18 @@ func (c *identityProviderHostServiceClient) InitHostServiceClient(conn *grpc.Cli
 	*c.client = AdaptIdentityProviderHostServiceClient(hostservices.NewIdentityProviderClient(conn))
 }
 
+// RequireIdentityProviderHostService obtains a client for the IdentityProvider host service from the broker. It fails if the host does not provide the host service.
+func RequireIdentityProviderHostService(broker catalog.HostServiceBroker, client *IdentityProvider) error {
+	has, err := broker.GetHostService(IdentityProviderHostServiceClient(client))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("required %s host service not available", IdentityProviderType)
+	}
+	return nil
+}
+
 func AdaptIdentityProviderHostServiceClient(client IdentityProviderClient) IdentityProvider {
 	return identityProviderHostServiceClientAdapter{client: client}
 }
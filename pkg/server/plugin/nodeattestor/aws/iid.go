@@ -173,7 +173,7 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 	switch {
 	case err != nil:
 		return err
-	case attested:
+	case attested && agentID.String() != req.ReattestAgentId:
 		return errors.New("IID has already been used to attest an agent")
 	}
 
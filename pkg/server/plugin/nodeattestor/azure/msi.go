@@ -136,7 +136,7 @@ func (p *MSIAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 	switch {
 	case err != nil:
 		return msiError.Wrap(err)
-	case attested:
+	case attested && agentID != req.ReattestAgentId:
 		return msiError.New("MSI token has already been used to attest an agent")
 	}
 
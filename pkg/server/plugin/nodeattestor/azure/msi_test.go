@@ -230,6 +230,21 @@ func (s *MSIAttestorSuite) TestAttestFailsWhenAttestedBefore() {
 		"azure-msi: MSI token has already been used to attest an agent")
 }
 
+func (s *MSIAttestorSuite) TestReattestSucceedsForSameAgent() {
+	s.addKey()
+
+	agentID := "spiffe://example.org/spire/agent/azure_msi/TENANTID/PRINCIPALID"
+	s.agentStore.SetAgentInfo(&hostservices.AgentInfo{
+		AgentId: agentID,
+	})
+
+	req := s.signAttestRequest("KEYID", resourceID, "TENANTID", "PRINCIPALID")
+	req.ReattestAgentId = agentID
+	resp, err := s.doAttest(req)
+	s.Require().NoError(err)
+	s.Require().Equal(agentID, resp.AgentId)
+}
+
 func (s *MSIAttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -2,7 +2,6 @@ package base
 
 import (
 	"context"
-	"errors"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
@@ -14,14 +13,7 @@ type Base struct {
 }
 
 func (p *Base) BrokerHostServices(broker catalog.HostServiceBroker) error {
-	has, err := broker.GetHostService(hostservices.AgentStoreHostServiceClient(&p.store))
-	if err != nil {
-		return err
-	}
-	if !has {
-		return errors.New("required AgentStore host service not available")
-	}
-	return nil
+	return hostservices.RequireAgentStoreHostService(broker, &p.store)
 }
 
 func (p *Base) IsAttested(ctx context.Context, agentID string) (bool, error) {
@@ -90,7 +90,7 @@ func (p *IITAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 		return err
 	}
 
-	identityMetadata, err := validateAttestationAndExtractIdentityMetadata(stream, gcp.PluginName, p.tokenKeyRetriever)
+	req, identityMetadata, err := validateAttestationAndExtractIdentityMetadata(stream, gcp.PluginName, p.tokenKeyRetriever)
 	if err != nil {
 		return err
 	}
@@ -115,7 +115,7 @@ func (p *IITAttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer
 	switch {
 	case err != nil:
 		return pluginErr.Wrap(err)
-	case attested:
+	case attested && id.String() != req.ReattestAgentId:
 		return pluginErr.New("IIT has already been used to attest an agent")
 	}
 
@@ -245,32 +245,32 @@ type keyValue struct {
 	value string
 }
 
-func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttestor_AttestServer, pluginName string, tokenRetriever tokenKeyRetriever) (gcp.ComputeEngine, error) {
+func validateAttestationAndExtractIdentityMetadata(stream nodeattestor.NodeAttestor_AttestServer, pluginName string, tokenRetriever tokenKeyRetriever) (*nodeattestor.AttestRequest, gcp.ComputeEngine, error) {
 	req, err := stream.Recv()
 	if err != nil {
-		return gcp.ComputeEngine{}, err
+		return nil, gcp.ComputeEngine{}, err
 	}
 
 	attestationData := req.GetAttestationData()
 	if attestationData == nil {
-		return gcp.ComputeEngine{}, pluginErr.New("request missing attestation data")
+		return nil, gcp.ComputeEngine{}, pluginErr.New("request missing attestation data")
 	}
 
 	if attestationData.Type != pluginName {
-		return gcp.ComputeEngine{}, pluginErr.New("unexpected attestation data type %q", attestationData.Type)
+		return nil, gcp.ComputeEngine{}, pluginErr.New("unexpected attestation data type %q", attestationData.Type)
 	}
 
 	identityToken := &gcp.IdentityToken{}
 	_, err = jwt.ParseWithClaims(string(req.GetAttestationData().Data), identityToken, tokenRetriever.retrieveKey)
 	if err != nil {
-		return gcp.ComputeEngine{}, pluginErr.New("unable to parse/validate the identity token: %v", err)
+		return nil, gcp.ComputeEngine{}, pluginErr.New("unable to parse/validate the identity token: %v", err)
 	}
 
 	if identityToken.Audience != tokenAudience {
-		return gcp.ComputeEngine{}, pluginErr.New("unexpected identity token audience %q", identityToken.Audience)
+		return nil, gcp.ComputeEngine{}, pluginErr.New("unexpected identity token audience %q", identityToken.Audience)
 	}
 
-	return identityToken.Google.ComputeEngine, nil
+	return req, identityToken.Google.ComputeEngine, nil
 }
 
 func getInstanceTags(instance *compute.Instance) []string {
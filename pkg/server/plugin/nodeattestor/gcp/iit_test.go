@@ -145,6 +145,33 @@ func (s *IITAttestorSuite) TestErrorOnAttestedBefore() {
 
 	_, err := s.attest(&nodeattestor.AttestRequest{AttestationData: data})
 	s.RequireErrorContains(err, "gcp-iit: IIT has already been used to attest an agent")
+
+	// Only the agent itself can re-attest
+	_, err = s.attest(&nodeattestor.AttestRequest{
+		AttestationData: data,
+		ReattestAgentId: "spiffe://example.org/spire/agent/gcp_iit/other",
+	})
+	s.RequireErrorContains(err, "gcp-iit: IIT has already been used to attest an agent")
+}
+
+func (s *IITAttestorSuite) TestReattestSuccess() {
+	token := buildToken()
+
+	data := &common.AttestationData{
+		Type: gcp.PluginName,
+		Data: s.signToken(token),
+	}
+
+	s.agentStore.SetAgentInfo(&hostservices.AgentInfo{
+		AgentId: testAgentID,
+	})
+
+	res, err := s.attest(&nodeattestor.AttestRequest{
+		AttestationData: data,
+		ReattestAgentId: testAgentID,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(testAgentID, res.AgentId)
 }
 
 func (s *IITAttestorSuite) TestErrorOnProjectIdMismatch() {
@@ -416,7 +443,7 @@ func (s *IITAttestorSuite) TestGetPluginInfo() {
 }
 
 func (s *IITAttestorSuite) TestFailToRecvStream() {
-	_, err := validateAttestationAndExtractIdentityMetadata(&recvFailStream{}, gcp.PluginName, testKeyRetriever{})
+	_, _, err := validateAttestationAndExtractIdentityMetadata(&recvFailStream{}, gcp.PluginName, testKeyRetriever{})
 	s.Require().EqualError(err, "failed to recv from stream")
 }
 
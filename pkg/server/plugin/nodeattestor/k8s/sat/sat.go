@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"sync"
 
 	"github.com/gofrs/uuid"
@@ -145,20 +146,32 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) e
 		return satError.New("not configured for cluster %q", attestationData.Cluster)
 	}
 
-	uuid, err := p.hooks.newUUID()
-	if err != nil {
-		return err
-	}
+	var agentID string
+	if req.ReattestAgentId != "" {
+		// The agent ID is made unique by a random UUID instead of being
+		// derived from the token, so a re-attesting agent keeps its ID as
+		// long as it belongs to the same cluster
+		clusterPrefix := k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, "") + "/"
+		if !strings.HasPrefix(req.ReattestAgentId, clusterPrefix) {
+			return satError.New("agent %q cannot re-attest for cluster %q", req.ReattestAgentId, attestationData.Cluster)
+		}
+		agentID = req.ReattestAgentId
+	} else {
+		uuid, err := p.hooks.newUUID()
+		if err != nil {
+			return err
+		}
 
-	// It is incredibly unlikely the agent will have already attested since we
-	// generate a new UUID on each attestation but just in case...
-	agentID := k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, uuid)
-	attested, err := p.IsAttested(stream.Context(), agentID)
-	switch {
-	case err != nil:
-		return satError.Wrap(err)
-	case attested:
-		return satError.New("SAT has already been used to attest an agent with the same UUID")
+		// It is incredibly unlikely the agent will have already attested since we
+		// generate a new UUID on each attestation but just in case...
+		agentID = k8s.AgentID(pluginName, config.trustDomain, attestationData.Cluster, uuid)
+		attested, err := p.IsAttested(stream.Context(), agentID)
+		switch {
+		case err != nil:
+			return satError.Wrap(err)
+		case attested:
+			return satError.New("SAT has already been used to attest an agent with the same UUID")
+		}
 	}
 
 	var namespace, serviceAccountName string
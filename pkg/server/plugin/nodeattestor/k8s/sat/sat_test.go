@@ -297,6 +297,38 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}, resp.Selectors)
 }
 
+func (s *AttestorSuite) TestReattestKeepsAgentID() {
+	agentID := "spiffe://example.org/spire/agent/k8s_sat/FOO/ORIGINAL-UUID"
+	s.agentStore.SetAgentInfo(&hostservices.AgentInfo{
+		AgentId: agentID,
+	})
+
+	req := makeAttestRequest("FOO", s.signToken(s.fooSigner, "NS1", "SA1"))
+	req.ReattestAgentId = agentID
+	resp, err := s.doAttest(req)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Equal(agentID, resp.AgentId)
+	s.Require().Equal([]*common.Selector{
+		{Type: "k8s_sat", Value: "cluster:FOO"},
+		{Type: "k8s_sat", Value: "agent_ns:NS1"},
+		{Type: "k8s_sat", Value: "agent_sa:SA1"},
+	}, resp.Selectors)
+}
+
+func (s *AttestorSuite) TestReattestFailsFromAnotherCluster() {
+	req := makeAttestRequest("FOO", s.signToken(s.fooSigner, "NS1", "SA1"))
+	req.ReattestAgentId = "spiffe://example.org/spire/agent/k8s_sat/BAR/UUID"
+	s.requireAttestError(req,
+		`k8s-sat: agent "spiffe://example.org/spire/agent/k8s_sat/BAR/UUID" cannot re-attest for cluster "FOO"`)
+}
+
+func (s *AttestorSuite) TestReattestFailsWithInvalidToken() {
+	req := makeAttestRequest("FOO", s.signToken(s.bazSigner, "NS1", "SA1"))
+	req.ReattestAgentId = "spiffe://example.org/spire/agent/k8s_sat/FOO/UUID"
+	s.requireAttestError(req, "k8s-sat: unable to verify token")
+}
+
 func (s *AttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -2,12 +2,15 @@ package sshpop
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/plugin/sshpop"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"golang.org/x/crypto/ssh"
 )
 
 type Plugin struct {
@@ -72,7 +75,8 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 	}
 
 	return stream.Send(&nodeattestor.AttestResponse{
-		AgentId: agentID,
+		AgentId:   agentID,
+		Selectors: buildSelectors(handshaker.Certificate()),
 	})
 }
 
@@ -90,3 +94,46 @@ func (p *Plugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*
 func (*Plugin) GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
 	return &plugin.GetPluginInfoResponse{}, nil
 }
+
+func buildSelectors(cert *ssh.Certificate) []*common.Selector {
+	selectors := []*common.Selector{}
+
+	for _, principal := range cert.ValidPrincipals {
+		selectors = append(selectors, &common.Selector{
+			Type: sshpop.PluginName, Value: "principal:" + principal,
+		})
+	}
+
+	if cert.KeyId != "" {
+		selectors = append(selectors, &common.Selector{
+			Type: sshpop.PluginName, Value: "key_id:" + cert.KeyId,
+		})
+	}
+
+	selectors = append(selectors, optionSelectors("critical_option", cert.CriticalOptions)...)
+	selectors = append(selectors, optionSelectors("extension", cert.Extensions)...)
+	return selectors
+}
+
+// optionSelectors returns a selector for each certificate option, sorted by
+// name. Options with a value, like critical options, are suffixed with it;
+// flag options, like most extensions, are not.
+func optionSelectors(kind string, options map[string]string) []*common.Selector {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	selectors := make([]*common.Selector, 0, len(names))
+	for _, name := range names {
+		value := kind + ":" + name
+		if options[name] != "" {
+			value += ":" + options[name]
+		}
+		selectors = append(selectors, &common.Selector{
+			Type: sshpop.PluginName, Value: value,
+		})
+	}
+	return selectors
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/test/fixture"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc/codes"
 )
 
@@ -29,7 +31,7 @@ type Suite struct {
 
 func (s *Suite) SetupTest() {
 	s.p = s.newPlugin()
-	s.configure()
+	s.configure("")
 }
 
 func (s *Suite) newPlugin() nodeattestor.Plugin {
@@ -38,7 +40,7 @@ func (s *Suite) newPlugin() nodeattestor.Plugin {
 	return p
 }
 
-func (s *Suite) configure() {
+func (s *Suite) configure(extraConfig string) {
 	require := s.Require()
 
 	certificatePath := fixture.Join("nodeattestor", "sshpop", "agent_ssh_key-cert.pub")
@@ -47,7 +49,8 @@ func (s *Suite) configure() {
 
 	certAuthority, err := ioutil.ReadFile(certAuthoritiesPath)
 	require.NoError(err)
-	serverConfig := fmt.Sprintf(`cert_authorities = [%q]`, certAuthority)
+	serverConfig := fmt.Sprintf(`cert_authorities = [%q]
+		%s`, certAuthority, extraConfig)
 
 	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: serverConfig,
@@ -106,7 +109,34 @@ func (s *Suite) TestAttestSuccess() {
 	require.NoError(err)
 	require.Equal("spiffe://example.org/spire/agent/sshpop/21Aic_muK032oJMhLfU1_CMNcGmfAnvESeuH5zyFw_g", resp.AgentId)
 	require.Nil(resp.Challenge)
-	require.Len(resp.Selectors, 0)
+	require.Equal([]*common.Selector{
+		{Type: "sshpop", Value: "principal:foo-host"},
+		{Type: "sshpop", Value: "key_id:foo-host"},
+	}, resp.Selectors)
+}
+
+func (s *Suite) TestAttestRevoked() {
+	require := s.Require()
+
+	s.configure(fmt.Sprintf("krl_path = %q", fixture.Join("nodeattestor", "sshpop", "revoked_key_id.krl")))
+
+	client := s.sshclient.NewHandshake()
+	attestationData, err := client.AttestationData()
+	require.NoError(err)
+
+	stream, done := s.attest()
+	defer done()
+
+	require.NoError(stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "sshpop",
+			Data: attestationData,
+		},
+	}))
+
+	resp, err := stream.Recv()
+	s.errorContains(err, `sshpop: failed to check revocation: certificate key ID "foo-host" is revoked`)
+	require.Nil(resp)
 }
 
 func (s *Suite) TestAttestFailure() {
@@ -181,6 +211,38 @@ func (s *Suite) TestGetPluginInfo() {
 	require.Equal(resp, &plugin.GetPluginInfoResponse{})
 }
 
+func TestBuildSelectors(t *testing.T) {
+	cert := &ssh.Certificate{
+		KeyId:           "web-1",
+		ValidPrincipals: []string{"web-1.example.org", "web"},
+		Permissions: ssh.Permissions{
+			CriticalOptions: map[string]string{
+				"source-address": "10.0.0.0/8",
+			},
+			Extensions: map[string]string{
+				"role":        "frontend",
+				"permit-x11":  "",
+				"environment": "",
+			},
+		},
+	}
+
+	require.Equal(t, []*common.Selector{
+		{Type: "sshpop", Value: "principal:web-1.example.org"},
+		{Type: "sshpop", Value: "principal:web"},
+		{Type: "sshpop", Value: "key_id:web-1"},
+		{Type: "sshpop", Value: "critical_option:source-address:10.0.0.0/8"},
+		{Type: "sshpop", Value: "extension:environment"},
+		{Type: "sshpop", Value: "extension:permit-x11"},
+		{Type: "sshpop", Value: "extension:role:frontend"},
+	}, buildSelectors(cert))
+
+	// certificates without a key ID or options only produce principal selectors
+	require.Equal(t, []*common.Selector{
+		{Type: "sshpop", Value: "principal:web"},
+	}, buildSelectors(&ssh.Certificate{ValidPrincipals: []string{"web"}}))
+}
+
 func (s *Suite) attest() (nodeattestor.NodeAttestor_AttestClient, func()) {
 	stream, err := s.p.Attest(context.Background())
 	s.Require().NoError(err)
@@ -0,0 +1,261 @@
+package x509pop
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// revocationFetchTimeout bounds the time spent fetching a CRL or querying
+	// an OCSP responder
+	revocationFetchTimeout = 10 * time.Second
+
+	// maxRevocationResponseSize bounds the size of fetched CRLs and OCSP
+	// responses
+	maxRevocationResponseSize = 16 << 20
+)
+
+// errRevocationUnavailable is returned when the revocation status of a
+// certificate cannot be determined
+var errRevocationUnavailable = errors.New("revocation status unavailable")
+
+// revocationChecker checks the certificates of a verified chain against the
+// CRLs of their issuers and the OCSP responders named in the certificates.
+type revocationChecker struct {
+	log        hclog.Logger
+	httpClient *http.Client
+	now        func() time.Time
+
+	crlCheck  bool
+	ocspCheck bool
+	failOpen  bool
+
+	// localCRLs are the CRLs loaded from disk
+	localCRLs []*crlFile
+
+	mu sync.Mutex
+	// fetchedCRLs are the CRLs fetched from distribution points, by URL,
+	// cached until their next update
+	fetchedCRLs map[string]*pkix.CertificateList
+}
+
+// crlFile is a PEM or DER encoded CRL loaded from disk that is reloaded when
+// the file changes.
+type crlFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	crl     *pkix.CertificateList
+}
+
+func loadCRLFile(path string) (*crlFile, error) {
+	f := &crlFile{path: path}
+	if _, err := f.get(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// get returns the CRL, reloading it first if the file has been modified
+// since it was last loaded.
+func (f *crlFile) get() (*pkix.CertificateList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if f.crl != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.crl, nil
+	}
+
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	crl, err := x509.ParseCRL(data)
+	if err != nil {
+		return nil, err
+	}
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	f.crl = crl
+	return crl, nil
+}
+
+// Check checks the revocation status of each certificate in the chain, which
+// goes from the leaf to the root. The root is not checked.
+func (r *revocationChecker) Check(ctx context.Context, chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+
+		if r.crlCheck {
+			if err := r.checkWithFailOpen(cert, "CRL", r.checkCRL(ctx, cert, issuer)); err != nil {
+				return err
+			}
+		}
+		if r.ocspCheck {
+			if err := r.checkWithFailOpen(cert, "OCSP", r.checkOCSP(ctx, cert, issuer)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *revocationChecker) checkWithFailOpen(cert *x509.Certificate, method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errRevocationUnavailable) || !r.failOpen {
+		return err
+	}
+	r.log.Warn("Unable to check certificate revocation; failing open", "method", method, "serial_number", cert.SerialNumber.String(), "error", err)
+	return nil
+}
+
+func (r *revocationChecker) checkCRL(ctx context.Context, cert, issuer *x509.Certificate) error {
+	var crls []*pkix.CertificateList
+	for _, f := range r.localCRLs {
+		crl, err := f.get()
+		if err != nil {
+			return fmt.Errorf("%w: unable to load CRL %q: %v", errRevocationUnavailable, f.path, err)
+		}
+		// only CRLs signed by the issuer apply to the certificate
+		if issuer.CheckCRLSignature(crl) == nil {
+			crls = append(crls, crl)
+		}
+	}
+	for _, url := range cert.CRLDistributionPoints {
+		crl, err := r.fetchCRL(ctx, url, issuer)
+		if err != nil {
+			return err
+		}
+		crls = append(crls, crl)
+	}
+
+	for _, crl := range crls {
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate %s was revoked", cert.SerialNumber)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *revocationChecker) fetchCRL(ctx context.Context, url string, issuer *x509.Certificate) (*pkix.CertificateList, error) {
+	r.mu.Lock()
+	crl, ok := r.fetchedCRLs[url]
+	r.mu.Unlock()
+	if ok && !crl.HasExpired(r.now()) {
+		return crl, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid CRL distribution point %q: %v", errRevocationUnavailable, url, err)
+	}
+	data, err := r.do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to fetch CRL from %q: %v", errRevocationUnavailable, url, err)
+	}
+	crl, err = x509.ParseCRL(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse CRL from %q: %v", errRevocationUnavailable, url, err)
+	}
+	if err := issuer.CheckCRLSignature(crl); err != nil {
+		return nil, fmt.Errorf("%w: invalid CRL signature from %q: %v", errRevocationUnavailable, url, err)
+	}
+	if crl.HasExpired(r.now()) {
+		return nil, fmt.Errorf("%w: CRL from %q has expired", errRevocationUnavailable, url)
+	}
+
+	r.mu.Lock()
+	r.fetchedCRLs[url] = crl
+	r.mu.Unlock()
+	return crl, nil
+}
+
+func (r *revocationChecker) checkOCSP(ctx context.Context, cert, issuer *x509.Certificate) error {
+	if len(cert.OCSPServer) == 0 {
+		return nil
+	}
+
+	ocspReq, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create OCSP request: %v", err)
+	}
+
+	// try each responder until one answers
+	var lastErr error
+	for _, url := range cert.OCSPServer {
+		resp, err := r.queryOCSP(ctx, url, ocspReq, cert, issuer)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		switch resp.Status {
+		case ocsp.Good:
+			return nil
+		case ocsp.Revoked:
+			return fmt.Errorf("certificate %s was revoked", cert.SerialNumber)
+		default:
+			lastErr = fmt.Errorf("OCSP responder %q does not know the certificate", url)
+		}
+	}
+	return fmt.Errorf("%w: %v", errRevocationUnavailable, lastErr)
+}
+
+func (r *revocationChecker) queryOCSP(ctx context.Context, url string, ocspReq []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(ocspReq))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCSP responder %q: %v", url, err)
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+
+	data, err := r.do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query OCSP responder %q: %v", url, err)
+	}
+	resp, err := ocsp.ParseResponseForCert(data, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OCSP response from %q: %v", url, err)
+	}
+	if !resp.NextUpdate.IsZero() && resp.NextUpdate.Before(r.now()) {
+		return nil, fmt.Errorf("OCSP response from %q has expired", url)
+	}
+	return resp, nil
+}
+
+func (r *revocationChecker) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, revocationFetchTimeout)
+	defer cancel()
+
+	resp, err := r.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+}
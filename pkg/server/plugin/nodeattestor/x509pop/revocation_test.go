@@ -0,0 +1,408 @@
+package x509pop
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/plugin/x509pop"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestAttestRevocation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x509pop-revocation-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	server := newRevocationServer()
+	defer server.Close()
+
+	pki := newTestPKI(t, dir, server.URL)
+	server.ca, server.caKey = pki.root, pki.rootKey
+
+	// a local CRL revoking the leaf, issued by the root
+	localCRLPath := filepath.Join(dir, "local.crl")
+	localCRL, err := pki.root.CreateCRL(rand.Reader, pki.rootKey, []pkix.RevokedCertificate{
+		{SerialNumber: pki.leaf.SerialNumber, RevocationTime: time.Now()},
+	}, time.Now(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(localCRLPath, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: localCRL}), 0600))
+
+	revokedErr := fmt.Sprintf("x509pop: certificate revocation check failed: certificate %s was revoked", pki.leaf.SerialNumber)
+
+	for _, tt := range []struct {
+		name        string
+		config      string
+		revoked     bool
+		unavailable bool
+		err         string
+	}{
+		{
+			name:   "CRL good",
+			config: "crl_check = true",
+		},
+		{
+			name:    "CRL revoked",
+			config:  "crl_check = true",
+			revoked: true,
+			err:     revokedErr,
+		},
+		{
+			name:        "CRL unavailable",
+			config:      "crl_check = true",
+			unavailable: true,
+			err:         "x509pop: certificate revocation check failed: revocation status unavailable: unable to fetch CRL",
+		},
+		{
+			name:        "CRL unavailable failing open",
+			config:      "crl_check = true\nrevocation_fail_open = true",
+			unavailable: true,
+		},
+		{
+			name:   "local CRL revoked",
+			config: fmt.Sprintf("crl_check = true\ncrl_paths = [%q]", localCRLPath),
+			err:    revokedErr,
+		},
+		{
+			name:   "OCSP good",
+			config: "ocsp_check = true",
+		},
+		{
+			name:    "OCSP revoked",
+			config:  "ocsp_check = true",
+			revoked: true,
+			err:     revokedErr,
+		},
+		{
+			name:    "OCSP revoked failing open",
+			config:  "ocsp_check = true\nrevocation_fail_open = true",
+			revoked: true,
+			err:     revokedErr,
+		},
+		{
+			name:        "OCSP unavailable",
+			config:      "ocsp_check = true",
+			unavailable: true,
+			err:         "x509pop: certificate revocation check failed: revocation status unavailable: unable to query OCSP responder",
+		},
+		{
+			name:        "OCSP unavailable failing open",
+			config:      "ocsp_check = true\nrevocation_fail_open = true",
+			unavailable: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server.set(tt.revoked, tt.unavailable)
+
+			p, done := loadTestPlugin(t, pki.rootPath, tt.config)
+			defer done()
+
+			resp, err := attestChain(t, p, pki.chain(), pki.leafKey)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "spiffe://example.org/spire/agent/x509pop/"+x509pop.Fingerprint(pki.leaf), resp.AgentId)
+		})
+	}
+}
+
+func TestAttestLocalCRLReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x509pop-crl-reload-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pki := newTestPKI(t, dir, "")
+	crlPath := filepath.Join(dir, "local.crl")
+	writeCRL := func(revoked []pkix.RevokedCertificate, modTime time.Time) {
+		crl, err := pki.root.CreateCRL(rand.Reader, pki.rootKey, revoked, time.Now(), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(crlPath, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), 0600))
+		require.NoError(t, os.Chtimes(crlPath, modTime, modTime))
+	}
+
+	// nothing is revoked initially
+	now := time.Now()
+	writeCRL(nil, now)
+	p, done := loadTestPlugin(t, pki.rootPath, fmt.Sprintf("crl_check = true\ncrl_paths = [%q]", crlPath))
+	defer done()
+	_, err = attestChain(t, p, pki.chain(), pki.leafKey)
+	require.NoError(t, err)
+
+	// the CRL is reloaded once the file changes
+	writeCRL([]pkix.RevokedCertificate{
+		{SerialNumber: pki.leaf.SerialNumber, RevocationTime: now},
+	}, now.Add(time.Second))
+	_, err = attestChain(t, p, pki.chain(), pki.leafKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("x509pop: certificate revocation check failed: certificate %s was revoked", pki.leaf.SerialNumber))
+
+	// a CRL that can no longer be loaded leaves the status unavailable
+	require.NoError(t, ioutil.WriteFile(crlPath, []byte("corrupt"), 0600))
+	_, err = attestChain(t, p, pki.chain(), pki.leafKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("x509pop: certificate revocation check failed: revocation status unavailable: unable to load CRL %q", crlPath))
+}
+
+func TestAttestSANs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x509pop-san-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pki := newTestPKI(t, dir, "")
+
+	p, done := loadTestPlugin(t, pki.rootPath, `agent_path_template = "/{{ .SubjectAttributes.OU }}/{{ index .SAN.DNS 0 }}"`)
+	defer done()
+
+	resp, err := attestChain(t, p, pki.chain(), pki.leafKey)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/devices/host.example.org", resp.AgentId)
+	require.Equal(t, []*common.Selector{
+		{Type: "x509pop", Value: "subject:cn:leaf"},
+		{Type: "x509pop", Value: "issuer:cn:root"},
+		{Type: "x509pop", Value: "san:dns:host.example.org"},
+		{Type: "x509pop", Value: "san:uri:urn:device:1234"},
+		{Type: "x509pop", Value: "ca:fingerprint:" + x509pop.Fingerprint(pki.root)},
+	}, resp.Selectors)
+
+	// templates fail on missing SANs
+	p, done = loadTestPlugin(t, pki.rootPath, `agent_path_template = "/{{ index .SAN.Email 0 }}"`)
+	defer done()
+
+	_, err = attestChain(t, p, pki.chain(), pki.leafKey)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "x509pop: failed to make spiffe id")
+}
+
+type testPKI struct {
+	root     *x509.Certificate
+	rootKey  crypto.Signer
+	rootPath string
+	leaf     *x509.Certificate
+	leafKey  crypto.Signer
+}
+
+// newTestPKI creates a root CA and a leaf certificate with SANs, pointing to
+// the CRL and OCSP endpoints of the revocation server, if any.
+func newTestPKI(t *testing.T, dir, revocationURL string) *testPKI {
+	rootKey := newTestKey(t)
+	root := createTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}, nil, rootKey.Public(), rootKey)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf", OrganizationalUnit: []string{"devices"}},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		DNSNames:     []string{"host.example.org"},
+		URIs:         []*url.URL{{Scheme: "urn", Opaque: "device:1234"}},
+	}
+	if revocationURL != "" {
+		leafTemplate.CRLDistributionPoints = []string{revocationURL + "/crl"}
+		leafTemplate.OCSPServer = []string{revocationURL + "/ocsp"}
+	}
+	leafKey := newTestKey(t)
+	leaf := createTestCertificate(t, leafTemplate, root, leafKey.Public(), rootKey)
+
+	rootPath := filepath.Join(dir, "root.pem")
+	require.NoError(t, ioutil.WriteFile(rootPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.Raw}), 0600))
+
+	return &testPKI{
+		root:     root,
+		rootKey:  rootKey,
+		rootPath: rootPath,
+		leaf:     leaf,
+		leafKey:  leafKey,
+	}
+}
+
+func (pki *testPKI) chain() [][]byte {
+	return [][]byte{pki.leaf.Raw}
+}
+
+func newTestKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func createTestCertificate(t *testing.T, template, parent *x509.Certificate, publicKey interface{}, signer crypto.Signer) *x509.Certificate {
+	if parent == nil {
+		parent = template
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, publicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func loadTestPlugin(t *testing.T, rootPath, extraConfig string) (nodeattestor.Plugin, func()) {
+	var p nodeattestor.Plugin
+	done := spiretest.LoadPlugin(t, BuiltIn(), &p, spiretest.Configuration("example.org",
+		fmt.Sprintf("ca_bundle_path = %q\n%s", rootPath, extraConfig)))
+	return p, done
+}
+
+// attestChain attests with the given certificate chain, answering the
+// challenge with the given key
+func attestChain(t *testing.T, p nodeattestor.Plugin, chain [][]byte, key crypto.Signer) (*nodeattestor.AttestResponse, error) {
+	stream, err := p.Attest(context.Background())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stream.CloseSend())
+	}()
+
+	data, err := json.Marshal(&x509pop.AttestationData{Certificates: chain})
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "x509pop",
+			Data: data,
+		},
+	}))
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	challenge := new(x509pop.Challenge)
+	require.NoError(t, json.Unmarshal(resp.Challenge, challenge))
+	response, err := x509pop.CalculateResponse(key, challenge)
+	require.NoError(t, err)
+	responseBytes, err := json.Marshal(response)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&nodeattestor.AttestRequest{
+		Response: responseBytes,
+	}))
+
+	return stream.Recv()
+}
+
+// revocationServer is a local stand-in for the CRL distribution point and
+// OCSP responder of a CA
+type revocationServer struct {
+	*httptest.Server
+
+	ca    *x509.Certificate
+	caKey crypto.Signer
+
+	mu          sync.Mutex
+	revoked     bool
+	unavailable bool
+}
+
+func newRevocationServer() *revocationServer {
+	s := new(revocationServer)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/crl", s.serveCRL)
+	mux.HandleFunc("/ocsp", s.serveOCSP)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// set sets whether certificates are revoked and whether the server is
+// unavailable
+func (s *revocationServer) set(revoked, unavailable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked = revoked
+	s.unavailable = unavailable
+}
+
+func (s *revocationServer) state() (revoked, unavailable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revoked, s.unavailable
+}
+
+func (s *revocationServer) serveCRL(w http.ResponseWriter, r *http.Request) {
+	revoked, unavailable := s.state()
+	if unavailable {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	var revokedCerts []pkix.RevokedCertificate
+	if revoked {
+		revokedCerts = append(revokedCerts, pkix.RevokedCertificate{
+			SerialNumber:   big.NewInt(2),
+			RevocationTime: time.Now(),
+		})
+	}
+	crl, err := s.ca.CreateCRL(rand.Reader, s.caKey, revokedCerts, time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(crl)
+}
+
+func (s *revocationServer) serveOCSP(w http.ResponseWriter, r *http.Request) {
+	revoked, unavailable := s.state()
+	if unavailable {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req, err := ocsp.ParseRequest(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	template := ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: req.SerialNumber,
+		ThisUpdate:   time.Now(),
+		NextUpdate:   time.Now().Add(time.Hour),
+	}
+	if revoked {
+		template.Status = ocsp.Revoked
+		template.RevokedAt = time.Now()
+	}
+	resp, err := ocsp.CreateResponse(s.ca, s.ca, template, s.caKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	_, _ = w.Write(resp)
+}
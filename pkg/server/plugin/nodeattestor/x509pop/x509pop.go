@@ -3,11 +3,15 @@ package x509pop
 import (
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"sync"
 	"text/template"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/plugin/x509pop"
@@ -35,20 +39,43 @@ type configuration struct {
 	trustDomain  string
 	trustBundle  *x509.CertPool
 	pathTemplate *template.Template
+	// revocation is nil if revocation checking is disabled
+	revocation *revocationChecker
 }
 
 type Config struct {
 	CABundlePath      string `hcl:"ca_bundle_path"`
 	AgentPathTemplate string `hcl:"agent_path_template"`
+
+	// CRLCheck enables checking the agent certificate chain against the CRLs
+	// in CRLPaths and at the CRL distribution points of the certificates.
+	CRLCheck bool     `hcl:"crl_check"`
+	CRLPaths []string `hcl:"crl_paths"`
+
+	// OCSPCheck enables checking the agent certificate chain with the OCSP
+	// responders named in the certificates.
+	OCSPCheck bool `hcl:"ocsp_check"`
+
+	// RevocationFailOpen lets agents attest when the revocation status of
+	// their certificates cannot be determined, e.g. if a CRL distribution
+	// point or OCSP responder is unreachable.
+	RevocationFailOpen bool `hcl:"revocation_fail_open"`
 }
 
 type Plugin struct {
-	m sync.Mutex
-	c *configuration
+	m   sync.Mutex
+	c   *configuration
+	log hclog.Logger
 }
 
 func New() *Plugin {
-	return &Plugin{}
+	return &Plugin{
+		log: hclog.NewNullLogger(),
+	}
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
 }
 
 func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
@@ -98,6 +125,12 @@ func (p *Plugin) Attest(stream nodeattestor.NodeAttestor_AttestServer) error {
 		return newError("certificate verification failed: %v", err)
 	}
 
+	if c.revocation != nil {
+		if err := c.revocation.Check(stream.Context(), chains[0]); err != nil {
+			return newError("certificate revocation check failed: %v", err)
+		}
+	}
+
 	// now that the leaf certificate is trusted, issue a challenge to the node
 	// to prove possession of the private key.
 	challenge, err := x509pop.GenerateChallenge(leaf)
@@ -174,10 +207,35 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi
 		pathTemplate = tmpl
 	}
 
+	if len(config.CRLPaths) > 0 && !config.CRLCheck {
+		return nil, newError("crl_paths requires crl_check to be enabled")
+	}
+
+	var revocation *revocationChecker
+	if config.CRLCheck || config.OCSPCheck {
+		revocation = &revocationChecker{
+			log:         p.log,
+			httpClient:  &http.Client{},
+			now:         time.Now,
+			crlCheck:    config.CRLCheck,
+			ocspCheck:   config.OCSPCheck,
+			failOpen:    config.RevocationFailOpen,
+			fetchedCRLs: make(map[string]*pkix.CertificateList),
+		}
+		for _, path := range config.CRLPaths {
+			crl, err := loadCRLFile(path)
+			if err != nil {
+				return nil, newError("unable to load CRL %q: %v", path, err)
+			}
+			revocation.localCRLs = append(revocation.localCRLs, crl)
+		}
+	}
+
 	p.setConfiguration(&configuration{
 		trustDomain:  req.GlobalConfig.TrustDomain,
 		trustBundle:  trustBundle,
 		pathTemplate: pathTemplate,
+		revocation:   revocation,
 	})
 
 	return &spi.ConfigureResponse{}, nil
@@ -212,6 +270,29 @@ func buildSelectors(leaf *x509.Certificate, chains [][]*x509.Certificate) []*com
 		})
 	}
 
+	if leaf.Issuer.CommonName != "" {
+		selectors = append(selectors, &common.Selector{
+			Type: "x509pop", Value: "issuer:cn:" + leaf.Issuer.CommonName,
+		})
+	}
+
+	sans := x509pop.CertificateSANs(leaf)
+	for _, san := range []struct {
+		kind   string
+		values []string
+	}{
+		{kind: "dns", values: sans.DNS},
+		{kind: "uri", values: sans.URI},
+		{kind: "email", values: sans.Email},
+		{kind: "ip", values: sans.IP},
+	} {
+		for _, value := range san.values {
+			selectors = append(selectors, &common.Selector{
+				Type: "x509pop", Value: "san:" + san.kind + ":" + value,
+			})
+		}
+	}
+
 	// Used to avoid duplicating selectors.
 	fingerprints := map[string]*x509.Certificate{}
 	for _, chain := range chains {
@@ -260,6 +260,29 @@ func (s *Suite) TestConfigure() {
 	})
 	s.errorContains(err, "x509pop: unable to load trust bundle")
 	require.Nil(resp)
+
+	// CRL paths without CRL checking
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		ca_bundle_path = %q
+		crl_paths = ["blah"]
+		`, s.rootCertPath),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.EqualError(err, "x509pop: crl_paths requires crl_check to be enabled")
+	require.Nil(resp)
+
+	// bad CRL
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		ca_bundle_path = %q
+		crl_check = true
+		crl_paths = ["blah"]
+		`, s.rootCertPath),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.errorContains(err, `x509pop: unable to load CRL "blah"`)
+	require.Nil(resp)
 }
 
 func (s *Suite) TestGetPluginInfo() {
@@ -18,10 +18,13 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/zeebo/errs"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -48,10 +51,32 @@ func builtIn(p *Plugin) catalog.Plugin {
 }
 
 type pluginConfig struct {
+	clusterConfig `hcl:",squash"`
+
+	// Clusters are additional clusters the bundle is pushed to.
+	Clusters []*clusterConfig `hcl:"clusters"`
+
+	// topLevelCluster is true if the bundle is pushed to the cluster
+	// configured by the top-level options, i.e. if any are set or if no
+	// other clusters are configured.
+	topLevelCluster bool
+}
+
+type clusterConfig struct {
 	Namespace          string `hcl:"namespace"`
 	ConfigMap          string `hcl:"config_map"`
 	ConfigMapKey       string `hcl:"config_map_key"`
 	KubeConfigFilePath string `hcl:"kube_config_file_path"`
+
+	// WebhookLabel, if set, selects the validating and mutating webhook
+	// configurations, labeled with "<WebhookLabel>=true", whose caBundle
+	// fields are updated with the bundle.
+	WebhookLabel string `hcl:"webhook_label"`
+
+	// APIServiceLabel, if set, selects the API services, labeled with
+	// "<APIServiceLabel>=true", whose caBundle fields are updated with the
+	// bundle.
+	APIServiceLabel string `hcl:"api_service_label"`
 }
 
 type Plugin struct {
@@ -94,7 +119,7 @@ func (p *Plugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*noti
 
 	if _, ok := req.Event.(*notifier.NotifyRequest_BundleUpdated); ok {
 		// ignore the bundle presented in the request. see updateBundleConfigMap for details on why.
-		if err := p.updateBundleConfigMap(ctx, config); err != nil {
+		if err := p.updateBundles(ctx, config); err != nil {
 			return nil, err
 		}
 	}
@@ -109,7 +134,7 @@ func (p *Plugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdv
 
 	if _, ok := req.Event.(*notifier.NotifyAndAdviseRequest_BundleLoaded); ok {
 		// ignore the bundle presented in the request. see updateBundleConfigMap for details on why.
-		if err := p.updateBundleConfigMap(ctx, config); err != nil {
+		if err := p.updateBundles(ctx, config); err != nil {
 			return nil, err
 		}
 	}
@@ -126,14 +151,13 @@ func (p *Plugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (resp
 		return nil, k8sErr.New("unable to decode configuration: %v", err)
 	}
 
-	if config.Namespace == "" {
-		config.Namespace = defaultNamespace
-	}
-	if config.ConfigMap == "" {
-		config.ConfigMap = defaultConfigMap
-	}
-	if config.ConfigMapKey == "" {
-		config.ConfigMapKey = defaultConfigMapKey
+	config.topLevelCluster = config.clusterConfig != clusterConfig{} || len(config.Clusters) == 0
+	setClusterDefaults(&config.clusterConfig)
+	for i, cluster := range config.Clusters {
+		if cluster == nil || cluster.KubeConfigFilePath == "" {
+			return nil, k8sErr.New("clusters[%d]: kube_config_file_path is required", i)
+		}
+		setClusterDefaults(cluster)
 	}
 
 	p.setConfig(config)
@@ -159,12 +183,32 @@ func (p *Plugin) setConfig(config *pluginConfig) {
 	p.config = config
 }
 
-func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (err error) {
+// updateBundles pushes the bundle to every configured cluster. A failure to
+// update one cluster does not prevent the others from being updated.
+func (p *Plugin) updateBundles(ctx context.Context, c *pluginConfig) error {
+	var errGroup errs.Group
+	for _, cluster := range c.clusters() {
+		if err := p.updateCluster(ctx, cluster); err != nil {
+			p.log.Error("Failed to update bundle", "kube_config_file_path", cluster.KubeConfigFilePath, telemetry.Error, err)
+			errGroup.Add(err)
+		}
+	}
+	return errGroup.Err()
+}
+
+func (p *Plugin) updateCluster(ctx context.Context, c *clusterConfig) error {
 	client, err := p.hooks.newKubeClient(c.KubeConfigFilePath)
 	if err != nil {
 		return err
 	}
 
+	if err := p.updateBundleConfigMap(ctx, client, c); err != nil {
+		return err
+	}
+	return p.updateCABundles(ctx, client, c)
+}
+
+func (p *Plugin) updateBundleConfigMap(ctx context.Context, client kubeClient, c *clusterConfig) (err error) {
 	for {
 		// Get the config map so we can use the version to resolve conflicts racing
 		// on updates from other servers.
@@ -200,7 +244,7 @@ func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (er
 		if err := client.PatchConfigMap(ctx, c.Namespace, c.ConfigMap, patchBytes); err != nil {
 			// If there is a conflict then some other server won the race updating
 			// the ConfigMap. We need to retrieve the latest bundle and try again.
-			if s, ok := err.(k8serrors.APIStatus); ok && s.Status().Code == http.StatusConflict {
+			if isConflict(err) {
 				p.log.Debug("Conflict detected patching configmap; will retry", telemetry.VersionInfo, configMap.ResourceVersion)
 				continue
 			}
@@ -211,6 +255,183 @@ func (p *Plugin) updateBundleConfigMap(ctx context.Context, c *pluginConfig) (er
 	}
 }
 
+// updateCABundles injects the bundle into the caBundle fields of the webhook
+// configurations and API services selected by the configured labels.
+func (p *Plugin) updateCABundles(ctx context.Context, client kubeClient, c *clusterConfig) error {
+	if c.WebhookLabel == "" && c.APIServiceLabel == "" {
+		return nil
+	}
+
+	for {
+		// As with the config map, the objects are listed before loading the
+		// bundle so that conflicting updates from other servers are detected
+		// and the latest bundle wins.
+		targets, err := listCABundleTargets(ctx, client, c)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+
+		resp, err := p.identityProvider.FetchX509Identity(ctx, &hostservices.FetchX509IdentityRequest{})
+		if err != nil {
+			return err
+		}
+		caBundle := []byte(bundleData(resp.Bundle))
+
+		conflict := false
+		for _, target := range targets {
+			patchBytes, err := json.Marshal(target.makePatch(caBundle))
+			if err != nil {
+				return k8sErr.New("unable to marshal patch: %v", err)
+			}
+			if err := target.patch(ctx, patchBytes); err != nil {
+				if isConflict(err) {
+					p.log.Debug("Conflict detected patching caBundle; will retry", "kind", target.kind, "name", target.name, telemetry.VersionInfo, target.resourceVersion)
+					conflict = true
+					continue
+				}
+				return k8sErr.New("unable to update %s %s: %v", target.kind, target.name, err)
+			}
+		}
+		if !conflict {
+			return nil
+		}
+	}
+}
+
+// caBundleTarget is an object with caBundle fields to inject the bundle into.
+type caBundleTarget struct {
+	kind            string
+	name            string
+	resourceVersion string
+	makePatch       func(caBundle []byte) interface{}
+	patch           func(ctx context.Context, patchBytes []byte) error
+}
+
+func listCABundleTargets(ctx context.Context, client kubeClient, c *clusterConfig) ([]caBundleTarget, error) {
+	var targets []caBundleTarget
+	if c.WebhookLabel != "" {
+		selector := labelSelector(c.WebhookLabel)
+
+		validatingWebhookConfigurations, err := client.ListValidatingWebhookConfigurations(ctx, selector)
+		if err != nil {
+			return nil, k8sErr.New("unable to list validating webhook configurations: %v", err)
+		}
+		for _, webhookConfiguration := range validatingWebhookConfigurations {
+			name := webhookConfiguration.Name
+			targets = append(targets, caBundleTarget{
+				kind:            "validating webhook configuration",
+				name:            name,
+				resourceVersion: webhookConfiguration.ResourceVersion,
+				makePatch:       webhookConfigurationPatch(webhookConfiguration.ResourceVersion, webhookConfiguration.Webhooks),
+				patch: func(ctx context.Context, patchBytes []byte) error {
+					return client.PatchValidatingWebhookConfiguration(ctx, name, patchBytes)
+				},
+			})
+		}
+
+		mutatingWebhookConfigurations, err := client.ListMutatingWebhookConfigurations(ctx, selector)
+		if err != nil {
+			return nil, k8sErr.New("unable to list mutating webhook configurations: %v", err)
+		}
+		for _, webhookConfiguration := range mutatingWebhookConfigurations {
+			name := webhookConfiguration.Name
+			targets = append(targets, caBundleTarget{
+				kind:            "mutating webhook configuration",
+				name:            name,
+				resourceVersion: webhookConfiguration.ResourceVersion,
+				makePatch:       webhookConfigurationPatch(webhookConfiguration.ResourceVersion, webhookConfiguration.Webhooks),
+				patch: func(ctx context.Context, patchBytes []byte) error {
+					return client.PatchMutatingWebhookConfiguration(ctx, name, patchBytes)
+				},
+			})
+		}
+	}
+
+	if c.APIServiceLabel != "" {
+		apiServices, err := client.ListAPIServices(ctx, labelSelector(c.APIServiceLabel))
+		if err != nil {
+			return nil, k8sErr.New("unable to list API services: %v", err)
+		}
+		for _, apiService := range apiServices {
+			name := apiService.Name
+			resourceVersion := apiService.ResourceVersion
+			targets = append(targets, caBundleTarget{
+				kind:            "API service",
+				name:            name,
+				resourceVersion: resourceVersion,
+				makePatch: func(caBundle []byte) interface{} {
+					return map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"resourceVersion": resourceVersion,
+						},
+						"spec": map[string]interface{}{
+							"caBundle": caBundle,
+						},
+					}
+				},
+				patch: func(ctx context.Context, patchBytes []byte) error {
+					return client.PatchAPIService(ctx, name, patchBytes)
+				},
+			})
+		}
+	}
+	return targets, nil
+}
+
+// webhookConfigurationPatch returns a function that builds the strategic merge
+// patch setting the caBundle of every webhook in a webhook configuration. The
+// resource version MUST be set to support conflict resolution.
+func webhookConfigurationPatch(resourceVersion string, webhooks []admissionv1beta1.Webhook) func([]byte) interface{} {
+	return func(caBundle []byte) interface{} {
+		webhookPatches := make([]interface{}, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			webhookPatches = append(webhookPatches, map[string]interface{}{
+				"name": webhook.Name,
+				"clientConfig": map[string]interface{}{
+					"caBundle": caBundle,
+				},
+			})
+		}
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"resourceVersion": resourceVersion,
+			},
+			"webhooks": webhookPatches,
+		}
+	}
+}
+
+func (c *pluginConfig) clusters() []*clusterConfig {
+	if !c.topLevelCluster {
+		return c.Clusters
+	}
+	return append([]*clusterConfig{&c.clusterConfig}, c.Clusters...)
+}
+
+func setClusterDefaults(c *clusterConfig) {
+	if c.Namespace == "" {
+		c.Namespace = defaultNamespace
+	}
+	if c.ConfigMap == "" {
+		c.ConfigMap = defaultConfigMap
+	}
+	if c.ConfigMapKey == "" {
+		c.ConfigMapKey = defaultConfigMapKey
+	}
+}
+
+func labelSelector(label string) string {
+	return label + "=true"
+}
+
+func isConflict(err error) bool {
+	s, ok := err.(k8serrors.APIStatus)
+	return ok && s.Status().Code == http.StatusConflict
+}
+
 func newKubeClient(configPath string) (kubeClient, error) {
 	config, err := getKubeConfig(configPath)
 	if err != nil {
@@ -221,7 +442,11 @@ func newKubeClient(configPath string) (kubeClient, error) {
 	if err != nil {
 		return nil, k8sErr.Wrap(err)
 	}
-	return kubeClientset{Clientset: client}, nil
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, k8sErr.Wrap(err)
+	}
+	return kubeClientset{Clientset: client, dynamic: dynamicClient}, nil
 }
 
 func getKubeConfig(configPath string) (*rest.Config, error) {
@@ -234,10 +459,25 @@ func getKubeConfig(configPath string) (*rest.Config, error) {
 type kubeClient interface {
 	GetConfigMap(ctx context.Context, namespace, configMap string) (*corev1.ConfigMap, error)
 	PatchConfigMap(ctx context.Context, namespace string, configMap string, patchBytes []byte) error
+	ListValidatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.ValidatingWebhookConfiguration, error)
+	PatchValidatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error
+	ListMutatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.MutatingWebhookConfiguration, error)
+	PatchMutatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error
+	ListAPIServices(ctx context.Context, labelSelector string) ([]metav1.ObjectMeta, error)
+	PatchAPIService(ctx context.Context, name string, patchBytes []byte) error
+}
+
+// apiServiceResource identifies the APIService resource. The dynamic client
+// is used since the typed client lives in the kube-aggregator.
+var apiServiceResource = schema.GroupVersionResource{
+	Group:    "apiregistration.k8s.io",
+	Version:  "v1",
+	Resource: "apiservices",
 }
 
 type kubeClientset struct {
 	*kubernetes.Clientset
+	dynamic dynamic.Interface
 }
 
 func (c kubeClientset) GetConfigMap(ctx context.Context, namespace, configMap string) (*corev1.ConfigMap, error) {
@@ -249,6 +489,60 @@ func (c kubeClientset) PatchConfigMap(ctx context.Context, namespace, configMap
 	return err
 }
 
+func (c kubeClientset) ListValidatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.ValidatingWebhookConfiguration, error) {
+	list, err := c.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (c kubeClientset) PatchValidatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error {
+	_, err := c.AdmissionregistrationV1beta1().ValidatingWebhookConfigurations().Patch(name, types.StrategicMergePatchType, patchBytes)
+	return err
+}
+
+func (c kubeClientset) ListMutatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.MutatingWebhookConfiguration, error) {
+	list, err := c.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (c kubeClientset) PatchMutatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error {
+	_, err := c.AdmissionregistrationV1beta1().MutatingWebhookConfigurations().Patch(name, types.StrategicMergePatchType, patchBytes)
+	return err
+}
+
+func (c kubeClientset) ListAPIServices(ctx context.Context, labelSelector string) ([]metav1.ObjectMeta, error) {
+	list, err := c.dynamic.Resource(apiServiceResource).List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var apiServices []metav1.ObjectMeta
+	for _, item := range list.Items {
+		apiServices = append(apiServices, metav1.ObjectMeta{
+			Name:            item.GetName(),
+			ResourceVersion: item.GetResourceVersion(),
+		})
+	}
+	return apiServices, nil
+}
+
+func (c kubeClientset) PatchAPIService(ctx context.Context, name string, patchBytes []byte) error {
+	// APIService is not a built-in type so strategic merge patches are not
+	// supported; the patch only sets scalar fields so a merge patch suffices.
+	_, err := c.dynamic.Resource(apiServiceResource).Patch(name, types.MergePatchType, patchBytes, metav1.UpdateOptions{})
+	return err
+}
+
 // bundleData formats the bundle data for inclusion in the config map
 func bundleData(bundle *common.Bundle) string {
 	bundleData := new(bytes.Buffer)
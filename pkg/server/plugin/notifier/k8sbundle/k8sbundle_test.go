@@ -20,9 +20,11 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeidentityprovider"
 	"github.com/spiffe/spire/test/spiretest"
 	"google.golang.org/grpc/codes"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
@@ -406,6 +408,186 @@ kube_config_file_path = "/some/file/path"
 	}, s.k.getConfigMap("NAMESPACE", "CONFIGMAP"))
 }
 
+func (s *Suite) TestBundleUpdatedInjectsCABundles() {
+	s.k.setConfigMap(newConfigMap())
+	s.k.setValidatingWebhookConfiguration(newValidatingWebhookConfiguration("VALIDATING", map[string]string{"spiffe.io/webhook": "true"}, "webhook1", "webhook2"))
+	s.k.setValidatingWebhookConfiguration(newValidatingWebhookConfiguration("UNLABELED", nil, "webhook"))
+	s.k.setMutatingWebhookConfiguration(newMutatingWebhookConfiguration("MUTATING", map[string]string{"spiffe.io/webhook": "true"}, "webhook"))
+	s.k.setAPIService(newAPIService("APISERVICE", map[string]string{"spiffe.io/api-service": "true"}))
+	s.k.setAPIService(newAPIService("OTHERAPISERVICE", map[string]string{"spiffe.io/webhook": "true"}))
+	// the bundle is fetched once for the config map and once for the caBundles.
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+webhook_label = "spiffe.io/webhook"
+api_service_label = "spiffe.io/api-service"
+`)
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&notifier.NotifyResponse{}, resp)
+
+	s.Equal(testBundleData, s.k.getConfigMap("spire", "spire-bundle").Data["bundle.crt"])
+	s.Equal([]string{testBundleData, testBundleData}, s.k.getValidatingWebhookCABundles("VALIDATING"))
+	s.Equal([]string{""}, s.k.getValidatingWebhookCABundles("UNLABELED"))
+	s.Equal([]string{testBundleData}, s.k.getMutatingWebhookCABundles("MUTATING"))
+	s.Equal(testBundleData, s.k.getAPIServiceCABundle("APISERVICE"))
+	s.Equal("", s.k.getAPIServiceCABundle("OTHERAPISERVICE"))
+}
+
+func (s *Suite) TestBundleUpdatedCABundleUpdateConflict() {
+	s.k.setConfigMap(newConfigMap())
+	s.k.setMutatingWebhookConfiguration(newMutatingWebhookConfiguration("MUTATING", map[string]string{"spiffe.io/webhook": "true"}, "webhook"))
+
+	// the first bundle is used for the config map, the second for the
+	// conflicting patch and the third for the successful retry.
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle2)
+
+	s.configure(`webhook_label = "spiffe.io/webhook"`)
+	s.k.setPatchErr(&k8serrors.StatusError{
+		ErrStatus: metav1.Status{
+			Code:    http.StatusConflict,
+			Message: "unexpected version",
+		},
+	})
+	s.k.setPatchErrKind("mutating")
+
+	_, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{testBundle2Data}, s.k.getMutatingWebhookCABundles("MUTATING"))
+}
+
+func (s *Suite) TestBundleUpdatedCABundlePatchFailure() {
+	s.k.setConfigMap(newConfigMap())
+	s.k.setAPIService(newAPIService("APISERVICE", map[string]string{"spiffe.io/api-service": "true"}))
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`api_service_label = "spiffe.io/api-service"`)
+	s.k.setPatchErr(errors.New("some error"))
+	s.k.setPatchErrKind("apiservice")
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: unable to update API service APISERVICE: some error")
+	s.Nil(resp)
+}
+
+func (s *Suite) TestBundleUpdatedMultipleClusters() {
+	other := newFakeKubeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "NAMESPACE",
+			Name:            "CONFIGMAP",
+			ResourceVersion: "1",
+		},
+	})
+	other.setValidatingWebhookConfiguration(newValidatingWebhookConfiguration("VALIDATING", map[string]string{"spiffe.io/webhook": "true"}, "webhook"))
+	broken := newFakeKubeClient()
+	s.withKubeClients(map[string]kubeClient{
+		"":                   s.k,
+		"/other/kubeconfig":  other,
+		"/broken/kubeconfig": broken,
+	})
+
+	s.k.setConfigMap(newConfigMap())
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+namespace = "spire"
+clusters = [
+	{
+		kube_config_file_path = "/broken/kubeconfig"
+	},
+	{
+		namespace = "NAMESPACE"
+		config_map = "CONFIGMAP"
+		webhook_label = "spiffe.io/webhook"
+		kube_config_file_path = "/other/kubeconfig"
+	},
+]
+`)
+
+	// The broken cluster fails the notification but does not prevent the
+	// other clusters from being updated.
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.RequireGRPCStatusContains(err, codes.Unknown, "k8s-bundle: unable to get config map spire/spire-bundle: not found")
+	s.Nil(resp)
+
+	s.Equal(testBundleData, s.k.getConfigMap("spire", "spire-bundle").Data["bundle.crt"])
+	s.Equal(testBundleData, other.getConfigMap("NAMESPACE", "CONFIGMAP").Data["bundle.crt"])
+	s.Equal([]string{testBundleData}, other.getValidatingWebhookCABundles("VALIDATING"))
+}
+
+func (s *Suite) TestBundleUpdatedOnlyAdditionalClusters() {
+	other := newFakeKubeClient(newConfigMap())
+	// no client is registered for the top-level cluster, so the test fails
+	// if the plugin tries to update it
+	s.withKubeClients(map[string]kubeClient{
+		"/other/kubeconfig": other,
+	})
+	s.r.AppendBundle(testBundle)
+
+	s.configure(`
+clusters = [
+	{
+		kube_config_file_path = "/other/kubeconfig"
+	},
+]
+`)
+
+	resp, err := s.p.Notify(context.Background(), &notifier.NotifyRequest{
+		Event: &notifier.NotifyRequest_BundleUpdated{
+			BundleUpdated: &notifier.BundleUpdated{
+				Bundle: testBundle,
+			},
+		},
+	})
+	s.Require().NoError(err)
+	s.Equal(&notifier.NotifyResponse{}, resp)
+	s.Equal(testBundleData, other.getConfigMap("spire", "spire-bundle").Data["bundle.crt"])
+}
+
+func (s *Suite) TestConfigureClusterWithoutKubeConfig() {
+	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+clusters = [
+	{
+		namespace = "NAMESPACE"
+	},
+]
+`,
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "k8s-bundle: clusters[0]: kube_config_file_path is required")
+}
+
 func (s *Suite) TestConfigureWithMalformedConfiguration() {
 	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: "blah",
@@ -438,6 +620,14 @@ func (s *Suite) withKubeClient(client kubeClient, expectedConfigPath string) {
 	}
 }
 
+func (s *Suite) withKubeClients(clients map[string]kubeClient) {
+	s.raw.hooks.newKubeClient = func(configPath string) (kubeClient, error) {
+		client, ok := clients[configPath]
+		s.Require().True(ok, "unexpected config path %q", configPath)
+		return client, nil
+	}
+}
+
 func (s *Suite) configure(configuration string) {
 	_, err := s.p.Configure(context.Background(), &spi.ConfigureRequest{
 		Configuration: configuration,
@@ -446,14 +636,26 @@ func (s *Suite) configure(configuration string) {
 }
 
 type fakeKubeClient struct {
-	mu         sync.RWMutex
-	configMaps map[string]*corev1.ConfigMap
-	patchErr   error
+	mu                              sync.RWMutex
+	configMaps                      map[string]*corev1.ConfigMap
+	validatingWebhookConfigurations map[string]*admissionv1beta1.ValidatingWebhookConfiguration
+	mutatingWebhookConfigurations   map[string]*admissionv1beta1.MutatingWebhookConfiguration
+	apiServices                     map[string]*fakeAPIService
+	patchErr                        error
+	patchErrKind                    string
+}
+
+type fakeAPIService struct {
+	metav1.ObjectMeta
+	CABundle []byte
 }
 
 func newFakeKubeClient(configMaps ...*corev1.ConfigMap) *fakeKubeClient {
 	c := &fakeKubeClient{
-		configMaps: make(map[string]*corev1.ConfigMap),
+		configMaps:                      make(map[string]*corev1.ConfigMap),
+		validatingWebhookConfigurations: make(map[string]*admissionv1beta1.ValidatingWebhookConfiguration),
+		mutatingWebhookConfigurations:   make(map[string]*admissionv1beta1.MutatingWebhookConfiguration),
+		apiServices:                     make(map[string]*fakeAPIService),
 	}
 	for _, configMap := range configMaps {
 		c.setConfigMap(configMap)
@@ -479,10 +681,8 @@ func (c *fakeKubeClient) PatchConfigMap(ctx context.Context, namespace, configMa
 	}
 
 	// if there is a patch error configured, return it and clear the patchErr state.
-	patchErr := c.patchErr
-	c.patchErr = nil
-	if patchErr != nil {
-		return patchErr
+	if err := c.takePatchErr("configmap"); err != nil {
+		return err
 	}
 
 	patchedMap := new(corev1.ConfigMap)
@@ -503,6 +703,136 @@ func (c *fakeKubeClient) PatchConfigMap(ctx context.Context, namespace, configMa
 	return nil
 }
 
+func (c *fakeKubeClient) ListValidatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.ValidatingWebhookConfiguration, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []admissionv1beta1.ValidatingWebhookConfiguration
+	for _, webhookConfiguration := range c.validatingWebhookConfigurations {
+		if matchesLabelSelector(labelSelector, webhookConfiguration.Labels) {
+			out = append(out, *webhookConfiguration.DeepCopy())
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeKubeClient) PatchValidatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.validatingWebhookConfigurations[name]
+	if !ok {
+		return errors.New("not found")
+	}
+	if err := c.takePatchErr("validating"); err != nil {
+		return err
+	}
+
+	patch := new(admissionv1beta1.ValidatingWebhookConfiguration)
+	if err := json.Unmarshal(patchBytes, patch); err != nil {
+		return err
+	}
+	resourceVersion, err := patchResourceVersion(patch.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	entry.ResourceVersion = resourceVersion
+	patchWebhooks(entry.Webhooks, patch.Webhooks)
+	return nil
+}
+
+func (c *fakeKubeClient) ListMutatingWebhookConfigurations(ctx context.Context, labelSelector string) ([]admissionv1beta1.MutatingWebhookConfiguration, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []admissionv1beta1.MutatingWebhookConfiguration
+	for _, webhookConfiguration := range c.mutatingWebhookConfigurations {
+		if matchesLabelSelector(labelSelector, webhookConfiguration.Labels) {
+			out = append(out, *webhookConfiguration.DeepCopy())
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeKubeClient) PatchMutatingWebhookConfiguration(ctx context.Context, name string, patchBytes []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.mutatingWebhookConfigurations[name]
+	if !ok {
+		return errors.New("not found")
+	}
+	if err := c.takePatchErr("mutating"); err != nil {
+		return err
+	}
+
+	patch := new(admissionv1beta1.MutatingWebhookConfiguration)
+	if err := json.Unmarshal(patchBytes, patch); err != nil {
+		return err
+	}
+	resourceVersion, err := patchResourceVersion(patch.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	entry.ResourceVersion = resourceVersion
+	patchWebhooks(entry.Webhooks, patch.Webhooks)
+	return nil
+}
+
+func (c *fakeKubeClient) ListAPIServices(ctx context.Context, labelSelector string) ([]metav1.ObjectMeta, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []metav1.ObjectMeta
+	for _, apiService := range c.apiServices {
+		if matchesLabelSelector(labelSelector, apiService.Labels) {
+			out = append(out, *apiService.ObjectMeta.DeepCopy())
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeKubeClient) PatchAPIService(ctx context.Context, name string, patchBytes []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.apiServices[name]
+	if !ok {
+		return errors.New("not found")
+	}
+	if err := c.takePatchErr("apiservice"); err != nil {
+		return err
+	}
+
+	patch := new(struct {
+		metav1.ObjectMeta `json:"metadata"`
+		Spec              struct {
+			CABundle []byte `json:"caBundle"`
+		} `json:"spec"`
+	})
+	if err := json.Unmarshal(patchBytes, patch); err != nil {
+		return err
+	}
+	resourceVersion, err := patchResourceVersion(patch.ResourceVersion)
+	if err != nil {
+		return err
+	}
+	entry.ResourceVersion = resourceVersion
+	entry.CABundle = patch.Spec.CABundle
+	return nil
+}
+
+// takePatchErr returns the configured patch error, if any, if it applies to
+// the kind being patched. The patch error is cleared once returned.
+func (c *fakeKubeClient) takePatchErr(kind string) error {
+	if c.patchErrKind != "" && c.patchErrKind != kind {
+		return nil
+	}
+	patchErr := c.patchErr
+	c.patchErr = nil
+	return patchErr
+}
+
 func (c *fakeKubeClient) getConfigMap(namespace, configMap string) *corev1.ConfigMap {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -521,6 +851,84 @@ func (c *fakeKubeClient) setPatchErr(err error) {
 	c.patchErr = err
 }
 
+func (c *fakeKubeClient) setPatchErrKind(kind string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.patchErrKind = kind
+}
+
+func (c *fakeKubeClient) setValidatingWebhookConfiguration(webhookConfiguration *admissionv1beta1.ValidatingWebhookConfiguration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validatingWebhookConfigurations[webhookConfiguration.Name] = webhookConfiguration
+}
+
+func (c *fakeKubeClient) getValidatingWebhookCABundles(name string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return webhookCABundles(c.validatingWebhookConfigurations[name].Webhooks)
+}
+
+func (c *fakeKubeClient) setMutatingWebhookConfiguration(webhookConfiguration *admissionv1beta1.MutatingWebhookConfiguration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mutatingWebhookConfigurations[webhookConfiguration.Name] = webhookConfiguration
+}
+
+func (c *fakeKubeClient) getMutatingWebhookCABundles(name string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return webhookCABundles(c.mutatingWebhookConfigurations[name].Webhooks)
+}
+
+func (c *fakeKubeClient) setAPIService(apiService *fakeAPIService) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiServices[apiService.Name] = apiService
+}
+
+func (c *fakeKubeClient) getAPIServiceCABundle(name string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return string(c.apiServices[name].CABundle)
+}
+
+func matchesLabelSelector(labelSelector string, objLabels map[string]string) bool {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		panic(err)
+	}
+	return selector.Matches(labels.Set(objLabels))
+}
+
+func patchResourceVersion(patchResourceVersion string) (string, error) {
+	resourceVersion, err := strconv.Atoi(patchResourceVersion)
+	if err != nil {
+		return "", errors.New("patch does not have resource version")
+	}
+	return fmt.Sprint(resourceVersion + 1), nil
+}
+
+// patchWebhooks applies the webhook patches, merged by name, as a strategic
+// merge patch would.
+func patchWebhooks(webhooks, patches []admissionv1beta1.Webhook) {
+	for _, patch := range patches {
+		for i := range webhooks {
+			if webhooks[i].Name == patch.Name {
+				webhooks[i].ClientConfig.CABundle = patch.ClientConfig.CABundle
+			}
+		}
+	}
+}
+
+func webhookCABundles(webhooks []admissionv1beta1.Webhook) []string {
+	var caBundles []string
+	for _, webhook := range webhooks {
+		caBundles = append(caBundles, string(webhook.ClientConfig.CABundle))
+	}
+	return caBundles
+}
+
 func configMapKey(namespace, configMap string) string {
 	return fmt.Sprintf("%s|%s", namespace, configMap)
 }
@@ -534,3 +942,45 @@ func newConfigMap() *corev1.ConfigMap {
 		},
 	}
 }
+
+func newValidatingWebhookConfiguration(name string, labels map[string]string, webhooks ...string) *admissionv1beta1.ValidatingWebhookConfiguration {
+	return &admissionv1beta1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Labels:          labels,
+			ResourceVersion: "1",
+		},
+		Webhooks: newWebhooks(webhooks),
+	}
+}
+
+func newMutatingWebhookConfiguration(name string, labels map[string]string, webhooks ...string) *admissionv1beta1.MutatingWebhookConfiguration {
+	return &admissionv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Labels:          labels,
+			ResourceVersion: "1",
+		},
+		Webhooks: newWebhooks(webhooks),
+	}
+}
+
+func newWebhooks(names []string) []admissionv1beta1.Webhook {
+	var webhooks []admissionv1beta1.Webhook
+	for _, name := range names {
+		webhooks = append(webhooks, admissionv1beta1.Webhook{
+			Name: name,
+		})
+	}
+	return webhooks
+}
+
+func newAPIService(name string, labels map[string]string) *fakeAPIService {
+	return &fakeAPIService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Labels:          labels,
+			ResourceVersion: "1",
+		},
+	}
+}
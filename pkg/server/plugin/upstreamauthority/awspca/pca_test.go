@@ -452,6 +452,22 @@ func (as *PCAPluginSuite) TestPublishJWTKey() {
 	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-pca: publishing upstream is unsupported")
 }
 
+func (as *PCAPluginSuite) TestReportAgents() {
+	resp, err := as.plugin.ReportAgents(ctx, &upstreamauthority.ReportAgentsRequest{})
+	as.Require().Nil(resp, "no response expected")
+	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-pca: reporting agents upstream is unsupported")
+}
+
+func (as *PCAPluginSuite) TestSubscribeToFederatedBundles() {
+	stream, err := as.plugin.SubscribeToFederatedBundles(ctx, &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	as.Require().NoError(err)
+	as.Require().NotNil(stream)
+
+	resp, err := stream.Recv()
+	as.Require().Nil(resp, "no response expected")
+	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-pca: federated bundles are unsupported")
+}
+
 func (as *PCAPluginSuite) mintX509CA(req *upstreamauthority.MintX509CARequest) (*upstreamauthority.MintX509CAResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
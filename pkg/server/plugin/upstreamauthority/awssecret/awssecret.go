@@ -210,6 +210,16 @@ func (m *Plugin) PublishJWTKey(*upstreamauthority.PublishJWTKeyRequest, upstream
 	return makeError(codes.Unimplemented, "publishing upstream is unsupported")
 }
 
+// ReportAgents is not implemented and returns a codes.Unimplemented status
+func (m *Plugin) ReportAgents(context.Context, *upstreamauthority.ReportAgentsRequest) (*upstreamauthority.ReportAgentsResponse, error) {
+	return nil, makeError(codes.Unimplemented, "reporting agents upstream is unsupported")
+}
+
+// SubscribeToFederatedBundles is not implemented and returns a codes.Unimplemented status
+func (m *Plugin) SubscribeToFederatedBundles(*upstreamauthority.SubscribeToFederatedBundlesRequest, upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesServer) error {
+	return makeError(codes.Unimplemented, "federated bundles are unsupported")
+}
+
 func makeError(code codes.Code, format string, args ...interface{}) error {
 	return status.Errorf(code, "aws-secret: "+format, args...)
 }
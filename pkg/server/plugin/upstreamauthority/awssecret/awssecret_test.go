@@ -202,6 +202,22 @@ func (as *Suite) TestPublishJWTKey() {
 	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-secret: publishing upstream is unsupported")
 }
 
+func (as *Suite) TestReportAgents() {
+	resp, err := as.plugin.ReportAgents(ctx, &upstreamauthority.ReportAgentsRequest{})
+	as.Require().Nil(resp, "no response expected")
+	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-secret: reporting agents upstream is unsupported")
+}
+
+func (as *Suite) TestSubscribeToFederatedBundles() {
+	stream, err := as.plugin.SubscribeToFederatedBundles(ctx, &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	as.Require().Nil(err)
+	as.Require().NotNil(stream)
+
+	resp, err := stream.Recv()
+	as.Require().Nil(resp, "no response expected")
+	as.RequireGRPCStatus(err, codes.Unimplemented, "aws-secret: federated bundles are unsupported")
+}
+
 func (as *Suite) mintX509CA(plugin upstreamauthority.Plugin, req *upstreamauthority.MintX509CARequest) (*upstreamauthority.MintX509CAResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -128,6 +128,14 @@ func (*Plugin) PublishJWTKey(*upstreamauthority.PublishJWTKeyRequest, upstreamau
 	return makeError(codes.Unimplemented, "publishing upstream is unsupported")
 }
 
+func (*Plugin) ReportAgents(context.Context, *upstreamauthority.ReportAgentsRequest) (*upstreamauthority.ReportAgentsResponse, error) {
+	return nil, makeError(codes.Unimplemented, "reporting agents upstream is unsupported")
+}
+
+func (*Plugin) SubscribeToFederatedBundles(*upstreamauthority.SubscribeToFederatedBundlesRequest, upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesServer) error {
+	return makeError(codes.Unimplemented, "federated bundles are unsupported")
+}
+
 func (p *Plugin) reloadCA() (*x509svid.UpstreamCA, *caCerts, error) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
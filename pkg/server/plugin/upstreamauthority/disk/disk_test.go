@@ -328,6 +328,22 @@ func (s *DiskSuite) TestPublishJWTKey() {
 	s.Require().EqualError(err, "rpc error: code = Unimplemented desc = upstreamauthority-disk: publishing upstream is unsupported")
 }
 
+func (s *DiskSuite) TestReportAgents() {
+	resp, err := s.p.ReportAgents(context.Background(), &upstreamauthority.ReportAgentsRequest{})
+	s.Require().Nil(resp)
+	s.Require().EqualError(err, "rpc error: code = Unimplemented desc = upstreamauthority-disk: reporting agents upstream is unsupported")
+}
+
+func (s *DiskSuite) TestSubscribeToFederatedBundles() {
+	stream, err := s.p.SubscribeToFederatedBundles(context.Background(), &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	s.Require().NoError(err)
+	s.Require().NotNil(stream)
+
+	resp, err := stream.Recv()
+	s.Require().Nil(resp)
+	s.Require().EqualError(err, "rpc error: code = Unimplemented desc = upstreamauthority-disk: federated bundles are unsupported")
+}
+
 func certURI(cert *x509.Certificate) string {
 	if len(cert.URIs) == 1 {
 		return cert.URIs[0].String()
@@ -18,6 +18,8 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -32,6 +34,14 @@ type Configuration struct {
 	ServerAddr        string `hcl:"server_address" json:"server_address"`
 	ServerPort        string `hcl:"server_port" json:"server_port"`
 	WorkloadAPISocket string `hcl:"workload_api_socket" json:"workload_api_socket"`
+
+	// ReportAgents enables reporting the agents attested by this server to
+	// the upstream SPIRE server.
+	ReportAgents bool `hcl:"report_agents" json:"report_agents"`
+
+	// FederatedBundles enables fetching the bundles the upstream SPIRE
+	// server is federated with.
+	FederatedBundles bool `hcl:"federated_bundles" json:"federated_bundles"`
 }
 
 func BuiltIn() catalog.Plugin {
@@ -172,6 +182,71 @@ func (m *Plugin) PublishJWTKey(req *upstreamauthority.PublishJWTKeyRequest, stre
 	}
 }
 
+func (m *Plugin) ReportAgents(ctx context.Context, req *upstreamauthority.ReportAgentsRequest) (*upstreamauthority.ReportAgentsResponse, error) {
+	if !m.getConfig().ReportAgents {
+		return nil, status.Error(codes.Unimplemented, "reporting agents upstream is disabled")
+	}
+
+	err := m.subscribeToPolling(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer m.unsubscribeToPolling()
+
+	if err := m.reportAgentsUpstream(ctx, req); err != nil {
+		return nil, err
+	}
+	return &upstreamauthority.ReportAgentsResponse{}, nil
+}
+
+func (m *Plugin) SubscribeToFederatedBundles(req *upstreamauthority.SubscribeToFederatedBundlesRequest, stream upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesServer) error {
+	if !m.getConfig().FederatedBundles {
+		return status.Error(codes.Unimplemented, "federated bundles are disabled")
+	}
+
+	err := m.subscribeToPolling(stream.Context())
+	if err != nil {
+		return err
+	}
+	defer m.unsubscribeToPolling()
+
+	var bundles []*common.Bundle
+	sent := false
+	ticker := clk.Ticker(upstreamPollFreq)
+	defer ticker.Stop()
+	for {
+		newBundles, err := m.fetchFederatedBundles(stream.Context())
+		switch {
+		case err != nil:
+			m.log.Warn("Failed to fetch federated bundles", "error", err)
+		case !sent || !areBundlesEqual(bundles, newBundles):
+			bundles = newBundles
+			sent = true
+			err := stream.Send(&upstreamauthority.SubscribeToFederatedBundlesResponse{
+				FederatedBundles: bundles,
+			})
+			if err != nil {
+				m.log.Error("Cannot send federated bundles", "error", err)
+				return err
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func (m *Plugin) getConfig() *Configuration {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if m.config == nil {
+		return &Configuration{}
+	}
+	return m.config
+}
+
 func (m *Plugin) pollBundleUpdates(ctx context.Context) {
 	ticker := clk.Ticker(upstreamPollFreq)
 	defer ticker.Stop()
@@ -283,6 +358,18 @@ func areRootsEqual(a, b []*common.Certificate) bool {
 	return true
 }
 
+func areBundlesEqual(a, b []*common.Bundle) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, bundle := range a {
+		if !proto.Equal(bundle, b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func arePublicKeysEqual(a, b []*common.PublicKey) bool {
 	if len(a) != len(b) {
 		return false
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 )
@@ -72,6 +73,29 @@ func (m *Plugin) pushAndSetInitialKeys(ctx context.Context, key *common.PublicKe
 	return nil
 }
 
+func (m *Plugin) reportAgentsUpstream(ctx context.Context, req *upstreamauthority.ReportAgentsRequest) error {
+	m.nodeMtx.RLock()
+	defer m.nodeMtx.RUnlock()
+
+	_, err := m.nodeClient.ReportAgentsUpstream(ctx, &node.ReportAgentsUpstreamRequest{
+		Agents:    req.Agents,
+		Continued: req.Continued,
+		More:      req.More,
+	})
+	return err
+}
+
+func (m *Plugin) fetchFederatedBundles(ctx context.Context) ([]*common.Bundle, error) {
+	m.nodeMtx.RLock()
+	defer m.nodeMtx.RUnlock()
+
+	resp, err := m.nodeClient.FetchFederatedBundles(ctx, &node.FetchFederatedBundlesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Bundles, nil
+}
+
 func (m *Plugin) fetchAndSetBundle(ctx context.Context) error {
 	m.nodeMtx.RLock()
 	defer m.nodeMtx.RUnlock()
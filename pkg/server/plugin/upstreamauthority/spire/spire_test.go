@@ -3,12 +3,19 @@ package spireplugin
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sync"
@@ -19,7 +26,6 @@ import (
 	w_pb "github.com/spiffe/go-spiffe/proto/spiffe/workload"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
-	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
@@ -32,6 +38,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -42,10 +49,7 @@ const (
 	"server_port":"_test_data/keys/cert.pem",
 	"server_agent_address":"8090"
 }`
-	trustDomain        = "example.com"
-	keyFilePath        = "_test_data/keys/private_key.pem"
-	certFilePath       = "_test_data/keys/cert.pem"
-	serverCertFilePath = "_test_data/keys/server.pem"
+	trustDomain = "example.com"
 )
 
 var (
@@ -53,18 +57,88 @@ var (
 	mockClock *clock.Mock = clock.NewMock()
 )
 
+// testKeys are the key and certificates used by the test servers. They are
+// generated for each test so that they are always valid.
+type testKeys struct {
+	// key is the private key of both certificates
+	key *ecdsa.PrivateKey
+
+	// caCert is the upstream CA certificate. It is also served as the X509-SVID
+	// of the workload, which is why it has a workload SPIFFE ID.
+	caCert *x509.Certificate
+
+	// serverCert is the certificate of the upstream server, signed by caCert
+	serverCert *x509.Certificate
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	now := time.Now()
+	subject := pkix.Name{
+		Country:      []string{"US"},
+		Organization: []string{"SPIFFE"},
+		CommonName:   "SPIFFE",
+	}
+	caCert := createCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               subject,
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: "localhost", Path: "/workload"}},
+	}, nil, key)
+	serverCert := createCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		URIs:         []*url.URL{{Scheme: "spiffe", Host: "localhost", Path: "/spire/server"}},
+	}, caCert, key)
+
+	return &testKeys{
+		key:        key,
+		caCert:     caCert,
+		serverCert: serverCert,
+	}
+}
+
+func createCertificate(t *testing.T, tmpl, parent *x509.Certificate, key *ecdsa.PrivateKey) *x509.Certificate {
+	if parent == nil {
+		parent = tmpl
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	return cert
+}
+
 type handler struct {
 	server *grpc.Server
 	addr   string
+	keys   *testKeys
 
 	bundleMtx sync.RWMutex
 	bundle    common.Bundle
+
+	agentsMtx   sync.RWMutex
+	agentReport *node_pb.ReportAgentsUpstreamRequest
+
+	federatedBundlesMtx sync.RWMutex
+	federatedBundles    []*common.Bundle
 }
 
 type whandler struct {
 	dir        string
 	socketPath string
 	server     *grpc.Server
+	keys       *testKeys
 }
 
 type testHandler struct {
@@ -73,8 +147,9 @@ type testHandler struct {
 }
 
 func (h *testHandler) startTestServers(t *testing.T) {
-	h.wapiServer = &whandler{}
-	h.napiServer = &handler{}
+	keys := newTestKeys(t)
+	h.wapiServer = &whandler{keys: keys}
+	h.napiServer = &handler{keys: keys}
 	h.napiServer.startNodeAPITestServer(t)
 	h.wapiServer.startWAPITestServer(t)
 }
@@ -101,39 +176,16 @@ func (w *whandler) startWAPITestServer(t *testing.T) {
 }
 
 func (w *whandler) FetchX509SVID(_ *w_pb.X509SVIDRequest, stream w_pb.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
-	keyPEM, err := ioutil.ReadFile(keyFilePath)
-	if err != nil {
-		fmt.Println("error" + err.Error())
-		return nil
-	}
-	keyblock, rest := pem.Decode(keyPEM)
-
-	if keyblock == nil {
-		return errors.New("error : invalid key format")
-	}
-
-	if len(rest) > 0 {
-		return errors.New("error : invalid key format - too many keys")
-	}
-
-	certPEM, err := ioutil.ReadFile(certFilePath)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(w.keys.key)
 	if err != nil {
-		return errors.New("error : unable to read cert file")
-	}
-
-	block, rest := pem.Decode(certPEM)
-	if block == nil {
-		return errors.New("error : invalid cert format")
-	}
-	if len(rest) > 0 {
-		return errors.New("error : invalid key format - too many certs")
+		return err
 	}
 
 	svid := &w_pb.X509SVID{
 		SpiffeId:    "spiffe://localhost/workload",
-		X509Svid:    block.Bytes,
-		X509SvidKey: keyblock.Bytes,
-		Bundle:      block.Bytes,
+		X509Svid:    w.keys.caCert.Raw,
+		X509SvidKey: keyDER,
+		Bundle:      w.keys.caCert.Raw,
 	}
 
 	resp := new(w_pb.X509SVIDResponse)
@@ -162,8 +214,10 @@ func (w *whandler) FetchJWTBundles(req *w_pb.JWTBundlesRequest, stream w_pb.Spif
 func (h *handler) startNodeAPITestServer(t *testing.T) {
 	h.loadInitialBundle(t)
 
-	creds, err := credentials.NewServerTLSFromFile(serverCertFilePath, keyFilePath)
-	require.NoError(t, err)
+	creds := credentials.NewServerTLSFromCert(&tls.Certificate{
+		Certificate: [][]byte{h.keys.serverCert.Raw},
+		PrivateKey:  h.keys.key,
+	})
 
 	opts := grpc.Creds(creds)
 	h.server = grpc.NewServer(opts)
@@ -182,9 +236,7 @@ func (h *handler) loadInitialBundle(t *testing.T) {
 	b, err := bundleutil.Unmarshal("spiffe://localhost", jwksBytes)
 	require.NoError(t, err)
 
-	caCert, err := pemutil.LoadCertificate(certFilePath)
-	require.NoError(t, err)
-	b.AppendRootCA(caCert)
+	b.AppendRootCA(h.keys.caCert)
 
 	h.setBundle(*b.Proto())
 }
@@ -213,24 +265,34 @@ func (h *handler) setBundle(b common.Bundle) {
 	h.bundle = b
 }
 
+func (h *handler) getAgentReport() *node_pb.ReportAgentsUpstreamRequest {
+	h.agentsMtx.RLock()
+	defer h.agentsMtx.RUnlock()
+	return h.agentReport
+}
+
+func (h *handler) getFederatedBundles() []*common.Bundle {
+	h.federatedBundlesMtx.RLock()
+	defer h.federatedBundlesMtx.RUnlock()
+	return h.federatedBundles
+}
+
+func (h *handler) setFederatedBundles(bundles []*common.Bundle) {
+	h.federatedBundlesMtx.Lock()
+	defer h.federatedBundlesMtx.Unlock()
+	h.federatedBundles = bundles
+}
+
 func (h *handler) FetchX509SVID(server node_pb.Node_FetchX509SVIDServer) error {
 	return errors.New("NOT IMPLEMENTED")
 }
 
 func (h *handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVIDRequest) (*node.FetchX509CASVIDResponse, error) {
-	caKey, err := pemutil.LoadPrivateKey(keyFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("unable to load test CA key")
-	}
-
-	caCert, err := pemutil.LoadCertificate(certFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("unable to load test CA certificate")
-	}
+	caCert := h.keys.caCert
 
 	// configure upstream ca
 	ca := x509svid.NewUpstreamCA(
-		x509util.NewMemoryKeypair(caCert, caKey),
+		x509util.NewMemoryKeypair(caCert, h.keys.key),
 		"localhost",
 		x509svid.UpstreamCAOptions{})
 
@@ -257,10 +319,18 @@ func (h *handler) FetchJWTSVID(ctx context.Context, req *node_pb.FetchJWTSVIDReq
 	return nil, errors.New("NOT IMPLEMENTED")
 }
 
+func (h *handler) FetchDelegatedJWTKey(ctx context.Context, req *node_pb.FetchDelegatedJWTKeyRequest) (*node_pb.FetchDelegatedJWTKeyResponse, error) {
+	return nil, errors.New("NOT IMPLEMENTED")
+}
+
 func (h *handler) Attest(stream node_pb.Node_AttestServer) (err error) {
 	return errors.New("NOT IMPLEMENTED")
 }
 
+func (h *handler) Reattest(stream node_pb.Node_ReattestServer) (err error) {
+	return errors.New("NOT IMPLEMENTED")
+}
+
 // PushJWTKeyUpstream fakes the real implementation (node endpoint) for testing purposes
 func (h *handler) PushJWTKeyUpstream(ctx context.Context, req *node_pb.PushJWTKeyUpstreamRequest) (*node_pb.PushJWTKeyUpstreamResponse, error) {
 	h.appendKey(req.JwtKey)
@@ -269,6 +339,21 @@ func (h *handler) PushJWTKeyUpstream(ctx context.Context, req *node_pb.PushJWTKe
 	}, nil
 }
 
+// ReportAgentsUpstream fakes the real implementation (node endpoint) for testing purposes
+func (h *handler) ReportAgentsUpstream(ctx context.Context, req *node_pb.ReportAgentsUpstreamRequest) (*node_pb.ReportAgentsUpstreamResponse, error) {
+	h.agentsMtx.Lock()
+	defer h.agentsMtx.Unlock()
+	h.agentReport = req
+	return &node_pb.ReportAgentsUpstreamResponse{}, nil
+}
+
+// FetchFederatedBundles fakes the real implementation (node endpoint) for testing purposes
+func (h *handler) FetchFederatedBundles(ctx context.Context, req *node_pb.FetchFederatedBundlesRequest) (*node_pb.FetchFederatedBundlesResponse, error) {
+	return &node_pb.FetchFederatedBundlesResponse{
+		Bundles: h.getFederatedBundles(),
+	}, nil
+}
+
 func (h *handler) FetchBundle(ctx context.Context, req *node_pb.FetchBundleRequest) (*node_pb.FetchBundleResponse, error) {
 	b := h.getBundle()
 	return &node_pb.FetchBundleResponse{
@@ -445,7 +530,96 @@ func TestSpirePlugin_PublishJWTKey(t *testing.T) {
 	require.Contains(t, err.Error(), "rpc error: code = Canceled desc = context canceled")
 }
 
+func TestSpirePlugin_ReportAgents(t *testing.T) {
+	// Setup servers
+	server := testHandler{}
+	server.startTestServers(t)
+	defer server.stopTestServers()
+
+	agents := []*common.AttestedNode{
+		{SpiffeId: "spiffe://localhost/spire/agent/a"},
+		{SpiffeId: "spiffe://localhost/spire/agent/b"},
+	}
+
+	// Reporting agents is disabled by default
+	p, done := newWithDefault(t, server.napiServer.addr, server.wapiServer.socketPath)
+	defer done()
+	resp, err := p.ReportAgents(ctx, &upstreamauthority.ReportAgentsRequest{Agents: agents})
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "reporting agents upstream is disabled")
+	require.Nil(t, resp)
+	require.Nil(t, server.napiServer.getAgentReport())
+
+	p, done = newWithConfig(t, server.napiServer.addr, server.wapiServer.socketPath, func(c *Configuration) {
+		c.ReportAgents = true
+	})
+	defer done()
+	resp, err = p.ReportAgents(ctx, &upstreamauthority.ReportAgentsRequest{Agents: agents, Continued: true, More: true})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	spiretest.RequireProtoEqual(t, &node_pb.ReportAgentsUpstreamRequest{
+		Agents:    agents,
+		Continued: true,
+		More:      true,
+	}, server.napiServer.getAgentReport())
+}
+
+func TestSpirePlugin_SubscribeToFederatedBundles(t *testing.T) {
+	// Setup servers
+	server := testHandler{}
+	server.startTestServers(t)
+	defer server.stopTestServers()
+
+	// Federated bundles are disabled by default
+	p, done := newWithDefault(t, server.napiServer.addr, server.wapiServer.socketPath)
+	defer done()
+	stream, err := p.SubscribeToFederatedBundles(ctx, &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "federated bundles are disabled")
+	require.Nil(t, resp)
+
+	p, done = newWithConfig(t, server.napiServer.addr, server.wapiServer.socketPath, func(c *Configuration) {
+		c.FederatedBundles = true
+	})
+	defer done()
+
+	bundleA := &common.Bundle{TrustDomainId: "spiffe://a.test"}
+	bundleB := &common.Bundle{TrustDomainId: "spiffe://b.test"}
+	server.napiServer.setFederatedBundles([]*common.Bundle{bundleA})
+
+	// Send initial request and get stream
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err = p.SubscribeToFederatedBundles(ctx, &upstreamauthority.SubscribeToFederatedBundlesRequest{})
+	require.NoError(t, err)
+
+	// Get first response
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{bundleA}, resp.FederatedBundles)
+
+	// Update federated bundles to trigger another response
+	server.napiServer.setFederatedBundles([]*common.Bundle{bundleA, bundleB})
+	mockClock.Add(upstreamPollFreq)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.Bundle{bundleA, bundleB}, resp.FederatedBundles)
+
+	// Cancel ctx to stop getting updates
+	cancel()
+
+	// Verify stream is closed
+	resp, err = stream.Recv()
+	require.Nil(t, resp)
+	require.Contains(t, err.Error(), "rpc error: code = Canceled desc = context canceled")
+}
+
 func newWithDefault(t *testing.T, addr string, socketPath string) (upstreamauthority.Plugin, func()) {
+	return newWithConfig(t, addr, socketPath, nil)
+}
+
+func newWithConfig(t *testing.T, addr string, socketPath string, configure func(*Configuration)) (upstreamauthority.Plugin, func()) {
 	host, port, _ := net.SplitHostPort(addr)
 
 	config := Configuration{
@@ -453,6 +627,9 @@ func newWithDefault(t *testing.T, addr string, socketPath string) (upstreamautho
 		ServerPort:        port,
 		WorkloadAPISocket: socketPath,
 	}
+	if configure != nil {
+		configure(&config)
+	}
 
 	jsonConfig, err := json.Marshal(config)
 	require.NoError(t, err)
@@ -12,17 +12,23 @@ import (
 	"google.golang.org/grpc"
 )
 
-type MintX509CARequest = upstreamauthority.MintX509CARequest                                         //nolint: golint
-type MintX509CAResponse = upstreamauthority.MintX509CAResponse                                       //nolint: golint
-type PublishJWTKeyRequest = upstreamauthority.PublishJWTKeyRequest                                   //nolint: golint
-type PublishJWTKeyResponse = upstreamauthority.PublishJWTKeyResponse                                 //nolint: golint
-type UnimplementedUpstreamAuthorityServer = upstreamauthority.UnimplementedUpstreamAuthorityServer   //nolint: golint
-type UpstreamAuthorityClient = upstreamauthority.UpstreamAuthorityClient                             //nolint: golint
-type UpstreamAuthorityServer = upstreamauthority.UpstreamAuthorityServer                             //nolint: golint
-type UpstreamAuthority_MintX509CAClient = upstreamauthority.UpstreamAuthority_MintX509CAClient       //nolint: golint
-type UpstreamAuthority_MintX509CAServer = upstreamauthority.UpstreamAuthority_MintX509CAServer       //nolint: golint
-type UpstreamAuthority_PublishJWTKeyClient = upstreamauthority.UpstreamAuthority_PublishJWTKeyClient //nolint: golint
-type UpstreamAuthority_PublishJWTKeyServer = upstreamauthority.UpstreamAuthority_PublishJWTKeyServer //nolint: golint
+type MintX509CARequest = upstreamauthority.MintX509CARequest                                                                     //nolint: golint
+type MintX509CAResponse = upstreamauthority.MintX509CAResponse                                                                   //nolint: golint
+type PublishJWTKeyRequest = upstreamauthority.PublishJWTKeyRequest                                                               //nolint: golint
+type PublishJWTKeyResponse = upstreamauthority.PublishJWTKeyResponse                                                             //nolint: golint
+type ReportAgentsRequest = upstreamauthority.ReportAgentsRequest                                                                 //nolint: golint
+type ReportAgentsResponse = upstreamauthority.ReportAgentsResponse                                                               //nolint: golint
+type SubscribeToFederatedBundlesRequest = upstreamauthority.SubscribeToFederatedBundlesRequest                                   //nolint: golint
+type SubscribeToFederatedBundlesResponse = upstreamauthority.SubscribeToFederatedBundlesResponse                                 //nolint: golint
+type UnimplementedUpstreamAuthorityServer = upstreamauthority.UnimplementedUpstreamAuthorityServer                               //nolint: golint
+type UpstreamAuthorityClient = upstreamauthority.UpstreamAuthorityClient                                                         //nolint: golint
+type UpstreamAuthorityServer = upstreamauthority.UpstreamAuthorityServer                                                         //nolint: golint
+type UpstreamAuthority_MintX509CAClient = upstreamauthority.UpstreamAuthority_MintX509CAClient                                   //nolint: golint
+type UpstreamAuthority_MintX509CAServer = upstreamauthority.UpstreamAuthority_MintX509CAServer                                   //nolint: golint
+type UpstreamAuthority_PublishJWTKeyClient = upstreamauthority.UpstreamAuthority_PublishJWTKeyClient                             //nolint: golint
+type UpstreamAuthority_PublishJWTKeyServer = upstreamauthority.UpstreamAuthority_PublishJWTKeyServer                             //nolint: golint
+type UpstreamAuthority_SubscribeToFederatedBundlesClient = upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesClient //nolint: golint
+type UpstreamAuthority_SubscribeToFederatedBundlesServer = upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesServer //nolint: golint
 
 const (
 	Type = "UpstreamAuthority"
@@ -32,6 +38,8 @@ const (
 type UpstreamAuthority interface {
 	MintX509CA(context.Context, *MintX509CARequest) (UpstreamAuthority_MintX509CAClient, error)
 	PublishJWTKey(context.Context, *PublishJWTKeyRequest) (UpstreamAuthority_PublishJWTKeyClient, error)
+	ReportAgents(context.Context, *ReportAgentsRequest) (*ReportAgentsResponse, error)
+	SubscribeToFederatedBundles(context.Context, *SubscribeToFederatedBundlesRequest) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error)
 }
 
 // Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
@@ -40,6 +48,8 @@ type Plugin interface {
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	MintX509CA(context.Context, *MintX509CARequest) (UpstreamAuthority_MintX509CAClient, error)
 	PublishJWTKey(context.Context, *PublishJWTKeyRequest) (UpstreamAuthority_PublishJWTKeyClient, error)
+	ReportAgents(context.Context, *ReportAgentsRequest) (*ReportAgentsResponse, error)
+	SubscribeToFederatedBundles(context.Context, *SubscribeToFederatedBundlesRequest) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error)
 }
 
 // PluginServer returns a catalog PluginServer implementation for the UpstreamAuthority plugin.
@@ -102,3 +112,11 @@ func (a pluginClientAdapter) MintX509CA(ctx context.Context, in *MintX509CAReque
 func (a pluginClientAdapter) PublishJWTKey(ctx context.Context, in *PublishJWTKeyRequest) (UpstreamAuthority_PublishJWTKeyClient, error) {
 	return a.client.PublishJWTKey(ctx, in)
 }
+
+func (a pluginClientAdapter) ReportAgents(ctx context.Context, in *ReportAgentsRequest) (*ReportAgentsResponse, error) {
+	return a.client.ReportAgents(ctx, in)
+}
+
+func (a pluginClientAdapter) SubscribeToFederatedBundles(ctx context.Context, in *SubscribeToFederatedBundlesRequest) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error) {
+	return a.client.SubscribeToFederatedBundles(ctx, in)
+}
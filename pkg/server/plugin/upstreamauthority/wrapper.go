@@ -19,7 +19,7 @@ type wrapper struct {
 	upstreamCA upstreamca.UpstreamCA
 }
 
-// Wrap produces a conforming UpstreamAuthority by wrapping an UpstreamCA. The PublishJWTKey method is not implemented and returns a codes.Unimplemented status.
+// Wrap produces a conforming UpstreamAuthority by wrapping an UpstreamCA. The PublishJWTKey, ReportAgents and SubscribeToFederatedBundles methods are not implemented and return a codes.Unimplemented status.
 func Wrap(upstreamCA upstreamca.UpstreamCA) UpstreamAuthority {
 	return &wrapper{upstreamCA: upstreamCA}
 }
@@ -74,6 +74,16 @@ func (w *wrapper) PublishJWTKey(ctx context.Context, request *PublishJWTKeyReque
 	return nil, makeError(codes.Unimplemented, "publishing upstream is unsupported")
 }
 
+// ReportAgents is not implemented by the wrapper and returns a codes.Unimplemented status
+func (w *wrapper) ReportAgents(ctx context.Context, request *ReportAgentsRequest) (*ReportAgentsResponse, error) {
+	return nil, makeError(codes.Unimplemented, "reporting agents upstream is unsupported")
+}
+
+// SubscribeToFederatedBundles is not implemented by the wrapper and returns a codes.Unimplemented status
+func (w *wrapper) SubscribeToFederatedBundles(ctx context.Context, request *SubscribeToFederatedBundlesRequest) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error) {
+	return nil, makeError(codes.Unimplemented, "federated bundles are unsupported")
+}
+
 type mintX509CAClientStream struct {
 	ctx  context.Context
 	resp *MintX509CAResponse
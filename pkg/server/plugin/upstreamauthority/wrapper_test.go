@@ -143,6 +143,24 @@ func TestPublishJWTKey(t *testing.T) {
 	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "upstreamauthority-wrapper: publishing upstream is unsupported")
 }
 
+func TestReportAgents(t *testing.T) {
+	wrapper := Wrap(&fakeUpstreamCA{})
+
+	resp, err := wrapper.ReportAgents(ctx, &ReportAgentsRequest{})
+	require.Nil(t, resp, "no response expected")
+
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "upstreamauthority-wrapper: reporting agents upstream is unsupported")
+}
+
+func TestSubscribeToFederatedBundles(t *testing.T) {
+	wrapper := Wrap(&fakeUpstreamCA{})
+
+	stream, err := wrapper.SubscribeToFederatedBundles(ctx, &SubscribeToFederatedBundlesRequest{})
+	require.Nil(t, stream, "no stream expected")
+
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "upstreamauthority-wrapper: federated bundles are unsupported")
+}
+
 // fakeUpstreamCA is a custom UpstreamCA that returns error or response depending on its configurations
 type fakeUpstreamCA struct {
 	t *testing.T
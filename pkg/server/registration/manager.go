@@ -5,20 +5,35 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
 	_pruningCandence = 5 * time.Minute
+
+	// _pruneAttestedNodesBatchSize is the maximum number of attested nodes
+	// pruned (or listed in dry run mode) per datastore call, which bounds
+	// the size of the transactions.
+	_pruneAttestedNodesBatchSize = 500
 )
 
 // ManagerConfig is the config for the registration manager
 type ManagerConfig struct {
 	DataStore datastore.DataStore
 
+	// PruneAttestedNodesExpiredFor is how long after their SVID expired
+	// attested nodes are pruned. If zero, attested nodes are not pruned.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// PruneAttestedNodesDryRun, if true, only logs the attested nodes that
+	// would be pruned.
+	PruneAttestedNodesDryRun bool
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
@@ -61,6 +76,9 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if err := m.pruneAttestedNodes(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning attested nodes")
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -76,3 +94,80 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	})
 	return err
 }
+
+func (m *Manager) pruneAttestedNodes(ctx context.Context) (err error) {
+	if m.c.PruneAttestedNodesExpiredFor <= 0 {
+		return nil
+	}
+
+	counter := telemetry_server.StartRegistrationManagerPruneAttestedNodeCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	expiresBefore := m.c.Clock.Now().Add(-m.c.PruneAttestedNodesExpiredFor).Unix()
+
+	if m.c.PruneAttestedNodesDryRun {
+		return m.reportPrunableAttestedNodes(ctx, expiresBefore)
+	}
+
+	// Prune in batches so a large backlog of expired nodes does not end up
+	// in a single long running transaction.
+	for {
+		resp, err := m.c.DataStore.PruneAttestedNodes(ctx, &datastore.PruneAttestedNodesRequest{
+			ExpiresBefore: expiresBefore,
+			Limit:         _pruneAttestedNodesBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.Nodes {
+			m.attestedNodeLog(node).Info("Pruned attested node")
+		}
+		telemetry_server.IncrRegistrationManagerPrunedAttestedNodeCounter(m.c.Metrics, len(resp.Nodes))
+
+		if len(resp.Nodes) < _pruneAttestedNodesBatchSize {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// reportPrunableAttestedNodes logs the attested nodes that would be pruned
+// and reports their number.
+func (m *Manager) reportPrunableAttestedNodes(ctx context.Context, expiresBefore int64) error {
+	var count int
+	pagination := &datastore.Pagination{
+		PageSize: _pruneAttestedNodesBatchSize,
+	}
+	for {
+		resp, err := m.c.DataStore.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+			ByExpiresBefore: &wrappers.Int64Value{
+				Value: expiresBefore,
+			},
+			Pagination: pagination,
+		})
+		if err != nil {
+			return err
+		}
+		for _, node := range resp.Nodes {
+			m.attestedNodeLog(node).Info("Attested node would be pruned (dry run)")
+		}
+		count += len(resp.Nodes)
+
+		if len(resp.Nodes) == 0 || resp.Pagination == nil || resp.Pagination.Token == "" {
+			break
+		}
+		pagination = resp.Pagination
+	}
+
+	telemetry_server.SetRegistrationManagerPrunableAttestedNodesGauge(m.c.Metrics, count)
+	return nil
+}
+
+func (m *Manager) attestedNodeLog(node *common.AttestedNode) logrus.FieldLogger {
+	return m.c.Log.WithFields(logrus.Fields{
+		telemetry.SPIFFEID:   node.SpiffeId,
+		telemetry.Expiration: time.Unix(node.CertNotAfter, 0),
+	})
+}
@@ -2,11 +2,14 @@ package registration
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
@@ -28,7 +31,8 @@ type ManagerSuite struct {
 	ds      *fakedatastore.DataStore
 	metrics *fakemetrics.FakeMetrics
 
-	m *Manager
+	config ManagerConfig
+	m      *Manager
 }
 
 func (s *ManagerSuite) SetupTest() {
@@ -36,6 +40,7 @@ func (s *ManagerSuite) SetupTest() {
 	s.log, s.logHook = test.NewNullLogger()
 	s.ds = fakedatastore.New()
 	s.metrics = fakemetrics.New()
+	s.config = ManagerConfig{}
 }
 
 func (s *ManagerSuite) TestPruning() {
@@ -132,13 +137,132 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
-func (s *ManagerSuite) setupAndRunManager() func() {
-	s.m = NewManager(ManagerConfig{
-		Clock:     s.clock,
-		DataStore: s.ds,
-		Log:       s.log,
-		Metrics:   s.metrics,
+func (s *ManagerSuite) TestPruningAttestedNodes() {
+	s.config.PruneAttestedNodesExpiredFor = time.Hour
+	done := s.setupAndRunManager()
+	defer done()
+
+	expired := s.createAttestedNode("spiffe://test.test/spire/agent/expired", s.clock.Now().Add(-time.Hour-time.Second))
+	inGracePeriod := s.createAttestedNode("spiffe://test.test/spire/agent/grace", s.clock.Now().Add(-time.Minute))
+	unexpired := s.createAttestedNode("spiffe://test.test/spire/agent/unexpired", s.clock.Now().Add(time.Hour))
+
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]*common.AttestedNode{inGracePeriod, unexpired}, s.listAttestedNodes())
+	selectorsResp, err := s.ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
+		SpiffeId: expired.SpiffeId,
+	})
+	s.Require().NoError(err)
+	s.Empty(selectorsResp.Selectors.Selectors)
+	s.Equal("Pruned attested node", s.logHook.LastEntry().Message)
+	s.Equal(expired.SpiffeId, s.logHook.LastEntry().Data[telemetry.SPIFFEID])
+
+	// the grace period of the second node ends
+	s.clock.Add(time.Hour)
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]*common.AttestedNode{unexpired}, s.listAttestedNodes())
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodesDryRun() {
+	s.config.PruneAttestedNodesExpiredFor = time.Hour
+	s.config.PruneAttestedNodesDryRun = true
+	done := s.setupAndRunManager()
+	defer done()
+
+	expired := s.createAttestedNode("spiffe://test.test/spire/agent/expired", s.clock.Now().Add(-2*time.Hour))
+
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]*common.AttestedNode{expired}, s.listAttestedNodes())
+	s.Equal("Attested node would be pruned (dry run)", s.logHook.LastEntry().Message)
+	s.Equal(expired.SpiffeId, s.logHook.LastEntry().Data[telemetry.SPIFFEID])
+	s.Equal([]float32{1}, s.metricValues(telemetry.Node, telemetry.Manager, telemetry.Prunable))
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodesInBatches() {
+	s.config.PruneAttestedNodesExpiredFor = time.Hour
+	done := s.setupAndRunManager()
+	defer done()
+
+	s.createExpiredAttestedNodes(_pruneAttestedNodesBatchSize + 1)
+	unexpired := s.createAttestedNode("spiffe://test.test/spire/agent/unexpired", s.clock.Now().Add(time.Hour))
+
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]*common.AttestedNode{unexpired}, s.listAttestedNodes())
+	s.Equal([]float32{_pruneAttestedNodesBatchSize, 1}, s.metricValues(telemetry.Node, telemetry.Manager, telemetry.Pruned))
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodesDryRunInBatches() {
+	s.config.PruneAttestedNodesExpiredFor = time.Hour
+	s.config.PruneAttestedNodesDryRun = true
+	done := s.setupAndRunManager()
+	defer done()
+
+	s.createExpiredAttestedNodes(_pruneAttestedNodesBatchSize + 1)
+
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Len(s.listAttestedNodes(), _pruneAttestedNodesBatchSize+1)
+	s.Equal([]float32{_pruneAttestedNodesBatchSize + 1}, s.metricValues(telemetry.Node, telemetry.Manager, telemetry.Prunable))
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodesDisabled() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	expired := s.createAttestedNode("spiffe://test.test/spire/agent/expired", s.clock.Now().Add(-time.Hour))
+
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal([]*common.AttestedNode{expired}, s.listAttestedNodes())
+}
+
+func (s *ManagerSuite) createAttestedNode(spiffeID string, notAfter time.Time) *common.AttestedNode {
+	resp, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:            spiffeID,
+			AttestationDataType: "test",
+			CertSerialNumber:    "1234",
+			CertNotAfter:        notAfter.Unix(),
+		},
+	})
+	s.Require().NoError(err)
+
+	_, err = s.ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+		Selectors: &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+		},
 	})
+	s.Require().NoError(err)
+	return resp.Node
+}
+
+func (s *ManagerSuite) listAttestedNodes() []*common.AttestedNode {
+	resp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	return resp.Nodes
+}
+
+func (s *ManagerSuite) createExpiredAttestedNodes(count int) {
+	for i := 0; i < count; i++ {
+		s.createAttestedNode(fmt.Sprintf("spiffe://test.test/spire/agent/expired%d", i), s.clock.Now().Add(-2*time.Hour))
+	}
+}
+
+// metricValues returns the values of the metrics with the given key
+func (s *ManagerSuite) metricValues(key ...string) []float32 {
+	var values []float32
+	for _, metric := range s.metrics.AllMetrics() {
+		if reflect.DeepEqual(metric.Key, key) {
+			values = append(values, metric.Val)
+		}
+	}
+	return values
+}
+
+func (s *ManagerSuite) setupAndRunManager() func() {
+	s.config.Clock = s.clock
+	s.config.DataStore = s.ds
+	s.config.Log = s.log
+	s.config.Metrics = s.metrics
+	s.m = NewManager(s.config)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 1)
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof" //nolint: gosec // import registers routes on DefaultServeMux
@@ -16,6 +17,7 @@ import (
 	common_services "github.com/spiffe/spire/pkg/common/plugin/hostservices"
 	"github.com/spiffe/spire/pkg/common/profiling"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/telemetry/tracing"
 	"github.com/spiffe/spire/pkg/common/util"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
@@ -23,9 +25,12 @@ import (
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
 	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
+	"github.com/spiffe/spire/pkg/server/nested"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore"
 	"github.com/spiffe/spire/pkg/server/plugin/hostservices"
+	"github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	"github.com/spiffe/spire/pkg/server/registration"
+	"github.com/spiffe/spire/pkg/server/stats"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"google.golang.org/grpc"
 )
@@ -44,6 +49,10 @@ const (
 
 type Server struct {
 	config Config
+
+	mtx sync.Mutex
+	// cat is the plugin catalog, set while the server is running
+	cat *catalog.Repository
 }
 
 // Run the server
@@ -83,6 +92,18 @@ func (s *Server) run(ctx context.Context) (err error) {
 
 	telemetry.EmitVersion(metrics)
 
+	// The tracer is created before the catalog is loaded so that spans are
+	// recorded for plugin calls and the configuration is passed on to
+	// external plugins.
+	tracer, err := tracing.New(&tracing.Config{
+		FileConfig:  s.config.Telemetry.Tracing,
+		Logger:      s.config.Log.WithField(telemetry.SubsystemName, telemetry.Tracing),
+		ServiceName: telemetry.SpireServer,
+	})
+	if err != nil {
+		return err
+	}
+
 	// Create the identity provider host service. It will not be functional
 	// until the call to SetDeps() below. There is some tricky initialization
 	// stuff going on since the identity provider host service requires plugins
@@ -96,12 +117,15 @@ func (s *Server) run(ctx context.Context) (err error) {
 	// until the call to SetDeps() below.
 	agentStore := agentstore.New()
 
-	cat, err := s.loadCatalog(ctx, identityProvider, agentStore, metricsService)
+	cat, err := s.loadCatalog(ctx, identityProvider, agentStore, metrics, tracer, metricsService)
 	if err != nil {
 		return err
 	}
 	defer cat.Close()
 
+	s.setCatalog(cat)
+	defer s.setCatalog(nil)
+
 	healthChecks := health.NewChecker(s.config.HealthChecks, s.config.Log)
 
 	s.config.Log.Info("plugins started")
@@ -125,7 +149,9 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics, caManager)
+	bundleManager := s.newBundleManager(cat)
+
+	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics, tracer, caManager, bundleManager)
 
 	// Set the identity provider dependencies
 	if err := identityProvider.SetDeps(identityprovider.Deps{
@@ -149,23 +175,36 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return fmt.Errorf("failed setting AgentStore deps: %v", err)
 	}
 
-	bundleManager := s.newBundleManager(cat)
-
 	registrationManager := s.newRegistrationManager(cat, metrics)
+	statsReporter := s.newStatsReporter(cat, metrics, serverCA, svidRotator)
 
-	if err := healthChecks.AddCheck("server", s, time.Minute); err != nil {
-		return fmt.Errorf("failed adding healthcheck: %v", err)
-	}
-
-	err = util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		caManager.Run,
 		svidRotator.Run,
 		endpointsServer.ListenAndServe,
 		metrics.ListenAndServe,
+		tracer.Run,
 		bundleManager.Run,
 		registrationManager.Run,
+		statsReporter.Run,
 		healthChecks.ListenAndServe,
-	)
+	}
+
+	// Downstream servers propagate agents and federated bundles through the
+	// UpstreamAuthority plugin.
+	if upstreamAuthority, ok := cat.GetUpstreamAuthority(); ok {
+		nestedManager := s.newNestedManager(cat, upstreamAuthority, metrics)
+		tasks = append(tasks, nestedManager.Run)
+	}
+
+	if err := healthChecks.AddLivenessCheck("server", s, time.Minute); err != nil {
+		return fmt.Errorf("failed adding healthcheck: %v", err)
+	}
+	if err := s.addSubsystemHealthChecks(healthChecks, cat, caManager, bundleManager); err != nil {
+		return err
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
@@ -229,9 +268,11 @@ func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 }
 
 func (s *Server) loadCatalog(ctx context.Context, identityProvider hostservices.IdentityProvider, agentStore hostservices.AgentStore,
-	metricsService common_services.MetricsService) (*catalog.Repository, error) {
+	metrics telemetry.Metrics, tracer *tracing.Tracer, metricsService common_services.MetricsService) (*catalog.Repository, error) {
 	return catalog.Load(ctx, catalog.Config{
-		Log: s.config.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		Log:     s.config.Log.WithField(telemetry.SubsystemName, telemetry.Catalog),
+		Metrics: metrics,
+		Tracer:  tracer,
 		GlobalConfig: catalog.GlobalConfig{
 			TrustDomain: s.config.TrustDomain.Host,
 		},
@@ -244,12 +285,15 @@ func (s *Server) loadCatalog(ctx context.Context, identityProvider hostservices.
 
 func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
 	return ca.NewCA(ca.Config{
-		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
-		Metrics:     metrics,
-		X509SVIDTTL: s.config.SVIDTTL,
-		JWTIssuer:   s.config.JWTIssuer,
-		TrustDomain: s.config.TrustDomain,
-		CASubject:   s.config.CASubject,
+		Log:           s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
+		Metrics:       metrics,
+		X509SVIDTTL:   s.config.SVIDTTL,
+		JWTIssuer:     s.config.JWTIssuer,
+		JWTIncludeJTI: s.config.JWTIncludeJTI,
+		TrustDomain:   s.config.TrustDomain,
+		CASubject:     s.config.CASubject,
+
+		PathPrefixesOID: s.config.PathPrefixesOID,
 	})
 }
 
@@ -275,13 +319,44 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:                    cat.GetDataStore(),
+		PruneAttestedNodesExpiredFor: s.config.PruneAttestedNodesExpiredFor,
+		PruneAttestedNodesDryRun:     s.config.PruneAttestedNodesDryRun,
+		Log:                          s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:                      metrics,
 	})
 	return registrationManager
 }
 
+func (s *Server) newStatsReporter(cat catalog.Catalog, metrics telemetry.Metrics, serverCA *ca.CA, svidRotator svid.Rotator) *stats.Reporter {
+	return stats.NewReporter(stats.ReporterConfig{
+		DataStore:     cat.GetDataStore(),
+		CA:            serverCA,
+		ServerSVID:    svidRotator,
+		TrustDomainID: s.config.TrustDomain.String(),
+		AgentSVIDTTL:  s.config.SVIDTTL,
+		Log:           s.config.Log.WithField(telemetry.SubsystemName, telemetry.StatsReporter),
+		Metrics:       metrics,
+
+		InventoryInterval: s.config.InventoryReportInterval,
+	})
+}
+
+func (s *Server) newNestedManager(cat catalog.Catalog, upstreamAuthority upstreamauthority.UpstreamAuthority, metrics telemetry.Metrics) *nested.Manager {
+	var federatesWith []string
+	for trustDomain := range s.config.Experimental.FederatesWith {
+		federatesWith = append(federatesWith, trustDomain)
+	}
+	return nested.NewManager(nested.ManagerConfig{
+		UpstreamAuthority: upstreamAuthority,
+		DataStore:         cat.GetDataStore(),
+		TrustDomain:       s.config.TrustDomain,
+		FederatesWith:     federatesWith,
+		Log:               s.config.Log.WithField(telemetry.SubsystemName, telemetry.NestedManager),
+		Metrics:           metrics,
+	})
+}
+
 func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metrics telemetry.Metrics) (svid.Rotator, error) {
 	svidRotator := svid.NewRotator(&svid.RotatorConfig{
 		ServerCA:    serverCA,
@@ -295,7 +370,7 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager) endpoints.Server {
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, tracer *tracing.Tracer, caManager *ca.Manager, bundleManager *bundle_client.Manager) endpoints.Server {
 	config := &endpoints.Config{
 		TCPAddr:                     s.config.BindAddress,
 		UDSAddr:                     s.config.BindUDSAddress,
@@ -305,8 +380,13 @@ func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.O
 		ServerCA:                    serverCA,
 		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:                     metrics,
+		Tracer:                      tracer,
 		Manager:                     caManager,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
+		DelegatedJWTKeysEnabled:     s.config.Experimental.DelegatedJWTKeysEnabled,
+		DelegatedJWTKeyTTL:          s.config.Experimental.DelegatedJWTKeyTTL,
+		BundleSyncStatus:            bundleManager,
+		PathPrefixesOID:             s.config.PathPrefixesOID,
 	}
 	if s.config.Experimental.BundleEndpointEnabled {
 		config.BundleEndpointAddress = s.config.Experimental.BundleEndpointAddress
@@ -373,6 +453,37 @@ func (s *Server) validateTrustDomain(ctx context.Context, ds datastore.DataStore
 	return nil
 }
 
+// ReloadPlugins configures again the plugins whose configuration changed. The
+// trust domain and the set of loaded plugins cannot be changed without
+// restarting the server.
+func (s *Server) ReloadPlugins(ctx context.Context, trustDomain url.URL, pluginConfigs catalog.HCLPluginConfigMap) error {
+	if trustDomain.String() != s.config.TrustDomain.String() {
+		return fmt.Errorf("trust domain cannot be changed from %q to %q without a restart", s.config.TrustDomain.Host, trustDomain.Host)
+	}
+
+	s.mtx.Lock()
+	cat := s.cat
+	s.mtx.Unlock()
+	if cat == nil {
+		return errors.New("server is not running")
+	}
+
+	reconfigured, err := cat.Reconfigure(ctx, catalog.GlobalConfig{
+		TrustDomain: trustDomain.Host,
+	}, pluginConfigs)
+	if err != nil {
+		return err
+	}
+	s.config.Log.WithField(telemetry.PluginNames, reconfigured).Info("Plugin configuration reloaded")
+	return nil
+}
+
+func (s *Server) setCatalog(cat *catalog.Repository) {
+	s.mtx.Lock()
+	s.cat = cat
+	s.mtx.Unlock()
+}
+
 // Status is used as a top-level health check for the Server.
 func (s *Server) Status() (interface{}, error) {
 	return nil, nil
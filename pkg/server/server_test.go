@@ -147,3 +147,15 @@ func (suite *ServerTestSuite) TestValidateTrustDomain() {
 	suite.NoError(err)
 	suite.Require().Contains(suite.stdout.String(), invalidSpiffeIDAttestedNode)
 }
+
+func (suite *ServerTestSuite) TestReloadPlugins() {
+	ctx := context.Background()
+
+	// the trust domain cannot be changed
+	err := suite.server.ReloadPlugins(ctx, url.URL{Scheme: "spiffe", Host: "otherdomain.org"}, nil)
+	suite.EqualError(err, `trust domain cannot be changed from "example.org" to "otherdomain.org" without a restart`)
+
+	// plugins cannot be reloaded before the catalog is loaded
+	err = suite.server.ReloadPlugins(ctx, url.URL{Scheme: "spiffe", Host: "example.org"}, nil)
+	suite.EqualError(err, "server is not running")
+}
@@ -0,0 +1,184 @@
+package stats
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/svid"
+)
+
+const (
+	// reportInterval is how often the expiry gauges are refreshed
+	reportInterval = time.Minute
+
+	// DefaultInventoryInterval is how often the inventory gauges are
+	// refreshed by default
+	DefaultInventoryInterval = time.Minute
+)
+
+// CA provides the active X509 CA and JWT key
+type CA interface {
+	X509CA() *ca.X509CA
+	JWTKey() *ca.JWTKey
+}
+
+// ServerSVIDSource provides the current server SVID
+type ServerSVIDSource interface {
+	State() svid.State
+}
+
+// ReporterConfig is the config for the stats reporter
+type ReporterConfig struct {
+	DataStore  datastore.DataStore
+	CA         CA
+	ServerSVID ServerSVIDSource
+
+	// TrustDomainID is the SPIFFE ID of the server trust domain
+	TrustDomainID string
+
+	// AgentSVIDTTL is the TTL of the agent SVIDs signed by the server.
+	// Agents are expected to rotate their SVID when half of it remains; the
+	// ones that have not done so are reported as expiring. Defaults to
+	// ca.DefaultX509SVIDTTL.
+	AgentSVIDTTL time.Duration
+
+	// InventoryInterval is how often the inventory gauges are refreshed.
+	// Defaults to DefaultInventoryInterval.
+	InventoryInterval time.Duration
+
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+}
+
+// Reporter periodically sets gauges describing the state of the server:
+// how long until its signing keys and SVID expire and how many entries,
+// agents and federated bundles it manages. Inventory figures come from
+// datastore counts, which are estimated by the database engine once tables
+// are large, so that reporting does not scan the tables.
+type Reporter struct {
+	c ReporterConfig
+}
+
+// NewReporter creates a new stats reporter
+func NewReporter(c ReporterConfig) *Reporter {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.AgentSVIDTTL <= 0 {
+		c.AgentSVIDTTL = ca.DefaultX509SVIDTTL
+	}
+	if c.InventoryInterval <= 0 {
+		c.InventoryInterval = DefaultInventoryInterval
+	}
+	return &Reporter{
+		c: c,
+	}
+}
+
+// Run reports the gauges until the context is canceled. The expiry gauges
+// are cheap to compute and are refreshed every minute, while the inventory
+// gauges, which require datastore count queries, are refreshed every
+// InventoryInterval.
+func (r *Reporter) Run(ctx context.Context) error {
+	ticker := r.c.Clock.Ticker(reportInterval)
+	defer ticker.Stop()
+	inventoryTicker := r.c.Clock.Ticker(r.c.InventoryInterval)
+	defer inventoryTicker.Stop()
+
+	r.reportExpiry()
+	r.reportInventory(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.reportExpiry()
+		case <-inventoryTicker.C:
+			r.reportInventory(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *Reporter) reportExpiry() {
+	now := r.c.Clock.Now()
+	ttl := func(notAfter time.Time) float32 {
+		return float32(notAfter.Sub(now) / time.Second)
+	}
+
+	if x509CA := r.c.CA.X509CA(); x509CA != nil && x509CA.Certificate != nil {
+		telemetry_server.SetX509CATTLGauge(r.c.Metrics, r.c.TrustDomainID, ttl(x509CA.Certificate.NotAfter))
+	}
+	if jwtKey := r.c.CA.JWTKey(); jwtKey != nil {
+		telemetry_server.SetJWTKeyTTLGauge(r.c.Metrics, r.c.TrustDomainID, ttl(jwtKey.NotAfter))
+	}
+	if state := r.c.ServerSVID.State(); len(state.SVID) > 0 {
+		telemetry_server.SetServerSVIDTTLGauge(r.c.Metrics, r.c.TrustDomainID, ttl(state.SVID[0].NotAfter))
+	}
+}
+
+func (r *Reporter) reportInventory(ctx context.Context) {
+	// Log an error on failure unless we're shutting down
+	if err := r.countInventory(ctx); err != nil && ctx.Err() == nil {
+		r.c.Log.WithError(err).Error("Failed to report inventory metrics")
+	}
+}
+
+func (r *Reporter) countInventory(ctx context.Context) error {
+	entries, err := r.c.DataStore.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	if err != nil {
+		return err
+	}
+	telemetry_server.SetRegistrationEntriesGauge(r.c.Metrics, float32(entries.Entries))
+
+	nodes, err := r.c.DataStore.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{})
+	if err != nil {
+		return err
+	}
+	telemetry_server.SetAttestedNodesGauge(r.c.Metrics, float32(nodes.Nodes))
+
+	// Expiring agents are the ones past their rotation point that have not
+	// expired yet.
+	now := r.c.Clock.Now()
+	expired, err := r.countNodesExpiringBefore(ctx, now)
+	if err != nil {
+		return err
+	}
+	expiring, err := r.countNodesExpiringBefore(ctx, now.Add(r.c.AgentSVIDTTL/2))
+	if err != nil {
+		return err
+	}
+	telemetry_server.SetExpiringAttestedNodesGauge(r.c.Metrics, float32(expiring-expired))
+
+	bundles, err := r.c.DataStore.CountBundles(ctx, &datastore.CountBundlesRequest{})
+	if err != nil {
+		return err
+	}
+	// Every bundle except the one of the server trust domain is federated
+	federated := bundles.Bundles - 1
+	if federated < 0 {
+		federated = 0
+	}
+	telemetry_server.SetFederatedBundlesGauge(r.c.Metrics, float32(federated))
+
+	return nil
+}
+
+func (r *Reporter) countNodesExpiringBefore(ctx context.Context, t time.Time) (int32, error) {
+	resp, err := r.c.DataStore.CountAttestedNodes(ctx, &datastore.CountAttestedNodesRequest{
+		ByExpiresBefore: &wrappers.Int64Value{
+			Value: t.Unix(),
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Nodes, nil
+}
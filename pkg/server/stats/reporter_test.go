@@ -0,0 +1,208 @@
+package stats
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	trustDomainID = "spiffe://example.org"
+)
+
+func TestReport(t *testing.T) {
+	clk := clock.NewMock(t)
+	now := clk.Now()
+	log, _ := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	ds := fakedatastore.New()
+
+	for _, trustDomain := range []string{trustDomainID, "spiffe://otherdomain.test"} {
+		_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+			Bundle: &common.Bundle{TrustDomainId: trustDomain},
+		})
+		require.NoError(t, err)
+	}
+
+	for _, spiffeID := range []string{"spiffe://example.org/foo", "spiffe://example.org/bar"} {
+		_, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/agent",
+				SpiffeId:  spiffeID,
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	for spiffeID, notAfter := range map[string]time.Time{
+		"spiffe://example.org/spire/agent/expired":  now.Add(-time.Minute),
+		"spiffe://example.org/spire/agent/expiring": now.Add(10 * time.Minute),
+		"spiffe://example.org/spire/agent/healthy":  now.Add(45 * time.Minute),
+	} {
+		_, err := ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:     spiffeID,
+				CertNotAfter: notAfter.Unix(),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	r := NewReporter(ReporterConfig{
+		DataStore: ds,
+		CA: fakeCA{
+			x509CA: &ca.X509CA{Certificate: &x509.Certificate{NotAfter: now.Add(24 * time.Hour)}},
+			jwtKey: &ca.JWTKey{NotAfter: now.Add(12 * time.Hour)},
+		},
+		ServerSVID: fakeServerSVID{
+			state: svid.State{SVID: []*x509.Certificate{{NotAfter: now.Add(30 * time.Minute)}}},
+		},
+		TrustDomainID: trustDomainID,
+		AgentSVIDTTL:  time.Hour,
+		Log:           log,
+		Metrics:       metrics,
+		Clock:         clk,
+	})
+	r.reportExpiry()
+	r.reportInventory(context.Background())
+
+	expected := fakemetrics.New()
+	telemetry_server.SetX509CATTLGauge(expected, trustDomainID, 86400)
+	telemetry_server.SetJWTKeyTTLGauge(expected, trustDomainID, 43200)
+	telemetry_server.SetServerSVIDTTLGauge(expected, trustDomainID, 1800)
+	telemetry_server.SetRegistrationEntriesGauge(expected, 2)
+	telemetry_server.SetAttestedNodesGauge(expected, 3)
+	telemetry_server.SetExpiringAttestedNodesGauge(expected, 1)
+	telemetry_server.SetFederatedBundlesGauge(expected, 1)
+	require.Equal(t, expected.AllMetrics(), metrics.AllMetrics())
+}
+
+func TestReportWithoutCA(t *testing.T) {
+	clk := clock.NewMock(t)
+	log, _ := test.NewNullLogger()
+	metrics := fakemetrics.New()
+
+	r := NewReporter(ReporterConfig{
+		DataStore:     fakedatastore.New(),
+		CA:            fakeCA{},
+		ServerSVID:    fakeServerSVID{},
+		TrustDomainID: trustDomainID,
+		Log:           log,
+		Metrics:       metrics,
+		Clock:         clk,
+	})
+	r.reportExpiry()
+	r.reportInventory(context.Background())
+
+	// Only the inventory is reported
+	expected := fakemetrics.New()
+	telemetry_server.SetRegistrationEntriesGauge(expected, 0)
+	telemetry_server.SetAttestedNodesGauge(expected, 0)
+	telemetry_server.SetExpiringAttestedNodesGauge(expected, 0)
+	telemetry_server.SetFederatedBundlesGauge(expected, 0)
+	require.Equal(t, expected.AllMetrics(), metrics.AllMetrics())
+}
+
+func TestRunInventoryInterval(t *testing.T) {
+	clk := clock.NewMock(t)
+	log, _ := test.NewNullLogger()
+	ds := &countingDataStore{
+		DataStore: fakedatastore.New(),
+		counted:   make(chan struct{}, 10),
+	}
+
+	r := NewReporter(ReporterConfig{
+		DataStore:         ds,
+		CA:                fakeCA{},
+		ServerSVID:        fakeServerSVID{},
+		TrustDomainID:     trustDomainID,
+		InventoryInterval: 5 * time.Minute,
+		Log:               log,
+		Metrics:           fakemetrics.New(),
+		Clock:             clk,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	// The inventory is reported on start
+	clk.WaitForTickerMulti(time.Minute, 2, "reporter did not create its tickers")
+	ds.requireCounted(t)
+
+	// The expiry gauges are refreshed every minute without counting the
+	// inventory
+	clk.Add(4 * time.Minute)
+	ds.requireNotCounted(t)
+
+	// The inventory is counted again once the inventory interval elapses
+	clk.Add(time.Minute)
+	ds.requireCounted(t)
+}
+
+// countingDataStore signals every time the registration entries are counted
+type countingDataStore struct {
+	datastore.DataStore
+	counted chan struct{}
+}
+
+func (ds *countingDataStore) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	ds.counted <- struct{}{}
+	return ds.DataStore.CountRegistrationEntries(ctx, req)
+}
+
+func (ds *countingDataStore) requireCounted(t *testing.T) {
+	select {
+	case <-ds.counted:
+	case <-time.After(time.Minute):
+		require.FailNow(t, "inventory was not counted")
+	}
+}
+
+func (ds *countingDataStore) requireNotCounted(t *testing.T) {
+	select {
+	case <-ds.counted:
+		require.FailNow(t, "inventory was counted")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type fakeCA struct {
+	x509CA *ca.X509CA
+	jwtKey *ca.JWTKey
+}
+
+func (c fakeCA) X509CA() *ca.X509CA {
+	return c.x509CA
+}
+
+func (c fakeCA) JWTKey() *ca.JWTKey {
+	return c.jwtKey
+}
+
+type fakeServerSVID struct {
+	state svid.State
+}
+
+func (s fakeServerSVID) State() svid.State {
+	return s.state
+}
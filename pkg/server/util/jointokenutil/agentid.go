@@ -0,0 +1,78 @@
+package jointokenutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+)
+
+const (
+	// DefaultAgentPathTemplate is the agent path template of single-use tokens
+	DefaultAgentPathTemplate = "join_token/{{ .Token }}"
+
+	// DefaultMultiUseAgentPathTemplate is the agent path template of
+	// multi-use tokens
+	DefaultMultiUseAgentPathTemplate = "join_token/{{ .Token }}/{{ .Use }}"
+)
+
+type agentIDTemplateData struct {
+	Token string
+	Use   int32
+}
+
+// MakeAgentID renders the agent ID template of the join token for the given
+// use. Templates starting with spiffe:// produce the full SPIFFE ID, other
+// templates a path relative to /spire/agent.
+func MakeAgentID(trustDomain string, joinToken *datastore.JoinToken, use int32) (string, error) {
+	text := joinToken.AgentIdTemplate
+	if text == "" {
+		text = DefaultAgentPathTemplate
+		if joinToken.MaxUses > 1 {
+			text = DefaultMultiUseAgentPathTemplate
+		}
+	}
+
+	tmpl, err := template.New("agent-id").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid agent ID template: %v", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, agentIDTemplateData{
+		Token: joinToken.Token,
+		Use:   use,
+	}); err != nil {
+		return "", fmt.Errorf("unable to render agent ID template: %v", err)
+	}
+
+	agentID := rendered.String()
+	if !strings.HasPrefix(agentID, "spiffe://") {
+		agentID = idutil.AgentID(trustDomain, agentID)
+	}
+	return idutil.NormalizeSpiffeID(agentID, idutil.AllowTrustDomainAgent(trustDomain))
+}
+
+// ValidateAgentIDTemplate checks that the agent ID template of the join token
+// renders valid agent IDs, and distinct ones for each use of a multi-use
+// token.
+func ValidateAgentIDTemplate(trustDomain string, joinToken *datastore.JoinToken) error {
+	first, err := MakeAgentID(trustDomain, joinToken, 1)
+	if err != nil {
+		return err
+	}
+	if joinToken.MaxUses > 1 {
+		second, err := MakeAgentID(trustDomain, joinToken, 2)
+		if err != nil {
+			return err
+		}
+		if first == second {
+			return errors.New("agent ID template of a multi-use token must reference {{ .Use }}")
+		}
+	}
+	return nil
+}
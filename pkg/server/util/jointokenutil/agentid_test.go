@@ -0,0 +1,101 @@
+package jointokenutil
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeAgentID(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		template  string
+		maxUses   int32
+		use       int32
+		expectID  string
+		expectErr string
+	}{
+		{
+			name:     "single-use default",
+			use:      1,
+			expectID: "spiffe://example.org/spire/agent/join_token/TOKEN",
+		},
+		{
+			name:     "multi-use default",
+			maxUses:  3,
+			use:      2,
+			expectID: "spiffe://example.org/spire/agent/join_token/TOKEN/2",
+		},
+		{
+			name:     "path template",
+			template: "imaging/rack1-{{ .Use }}",
+			maxUses:  3,
+			use:      3,
+			expectID: "spiffe://example.org/spire/agent/imaging/rack1-3",
+		},
+		{
+			name:     "spiffe id template",
+			template: "spiffe://example.org/spire/agent/imaging/{{ .Token }}",
+			use:      1,
+			expectID: "spiffe://example.org/spire/agent/imaging/TOKEN",
+		},
+		{
+			name:      "spiffe id outside agent namespace",
+			template:  "spiffe://example.org/workload",
+			use:       1,
+			expectErr: `"spiffe://example.org/workload" is not a valid agent SPIFFE ID: invalid path: expecting "/spire/agent/*"`,
+		},
+		{
+			name:      "spiffe id in another trust domain",
+			template:  "spiffe://otherdomain.test/spire/agent/foo",
+			use:       1,
+			expectErr: `"spiffe://otherdomain.test/spire/agent/foo" does not belong to trust domain "example.org"`,
+		},
+		{
+			name:      "malformed template",
+			template:  "imaging/{{ .Use",
+			use:       1,
+			expectErr: "invalid agent ID template: template: agent-id:1: unclosed action",
+		},
+		{
+			name:      "unknown field",
+			template:  "imaging/{{ .Host }}",
+			use:       1,
+			expectErr: "unable to render agent ID template: ",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			agentID, err := MakeAgentID("example.org", &datastore.JoinToken{
+				Token:           "TOKEN",
+				AgentIdTemplate: tt.template,
+				MaxUses:         tt.maxUses,
+			}, tt.use)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectID, agentID)
+		})
+	}
+}
+
+func TestValidateAgentIDTemplate(t *testing.T) {
+	assert.NoError(t, ValidateAgentIDTemplate("example.org", &datastore.JoinToken{
+		Token:   "TOKEN",
+		MaxUses: 5,
+	}))
+	assert.NoError(t, ValidateAgentIDTemplate("example.org", &datastore.JoinToken{
+		Token:           "TOKEN",
+		AgentIdTemplate: "imaging/foo",
+	}))
+	assert.EqualError(t, ValidateAgentIDTemplate("example.org", &datastore.JoinToken{
+		Token:           "TOKEN",
+		AgentIdTemplate: "imaging/{{ .Token }}",
+		MaxUses:         5,
+	}), "agent ID template of a multi-use token must reference {{ .Use }}")
+}
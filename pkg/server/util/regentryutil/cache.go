@@ -83,3 +83,10 @@ func (c *FetchRegistrationEntriesCache) AddWithExpire(key string, value []*commo
 		expiresAt: c.TimeNow().Add(expire),
 	})
 }
+
+// Remove evicts the entries cached for the given key, if any.
+func (c *FetchRegistrationEntriesCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Cache.Remove(key)
+}
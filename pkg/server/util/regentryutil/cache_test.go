@@ -56,4 +56,11 @@ func TestFetchSVIDCache(t *testing.T) {
 	ifc, ok := cache.Cache.Get(key)
 	require.Nil(t, ifc)
 	require.False(t, ok)
+
+	// cached value disappears when removed
+	cache.AddWithExpire(key, entries, ttl)
+	cache.Remove(key)
+	val, ok = cache.Get(key)
+	require.Empty(t, val)
+	require.False(t, ok)
 }
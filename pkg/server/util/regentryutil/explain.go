@@ -0,0 +1,168 @@
+package regentryutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// Explanation describes which registration entries a workload would be
+// issued by an agent and why nearly matching entries would not be.
+type Explanation struct {
+	// NodeSelectors are the selectors the agent was attested with
+	NodeSelectors []*common.Selector
+
+	// Authorized are the entries the workload would be issued
+	Authorized []*common.RegistrationEntry
+
+	// Excluded are the near-miss entries, along with the reason they would
+	// not be issued
+	Excluded []ExcludedEntry
+}
+
+// ExcludedEntry is a near-miss entry that would not be issued to a workload.
+type ExcludedEntry struct {
+	Entry  *common.RegistrationEntry
+	Reason string
+}
+
+// ExplainRegistrationEntries performs a dry run of the entry matching done
+// for a workload with the given selectors running on the given agent. An
+// entry is a near miss when it is authorized for the agent and shares at
+// least one selector with the workload, or when the workload holds all of
+// its selectors but it is not authorized for the agent.
+func ExplainRegistrationEntries(ctx context.Context, dataStore datastore.DataStore, agentID string, selectors []*common.Selector) (*Explanation, error) {
+	nodeSelectorsResp, err := dataStore.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var nodeSelectors []*common.Selector
+	if nodeSelectorsResp.Selectors != nil {
+		nodeSelectors = nodeSelectorsResp.Selectors.Selectors
+	}
+
+	agentEntries, err := FetchRegistrationEntries(ctx, dataStore, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		NodeSelectors: nodeSelectors,
+	}
+
+	workloadSet := selector.NewSetFromRaw(selectors)
+	agentEntryIDs := make(map[string]bool, len(agentEntries))
+	for _, entry := range agentEntries {
+		agentEntryIDs[entry.EntryId] = true
+
+		missing := missingSelectors(entry.Selectors, workloadSet)
+		switch {
+		case len(entry.Selectors) == 0 || len(missing) == len(entry.Selectors):
+			// Unrelated to the workload
+		case len(missing) == 0:
+			explanation.Authorized = append(explanation.Authorized, entry)
+		default:
+			explanation.Excluded = append(explanation.Excluded, ExcludedEntry{
+				Entry:  entry,
+				Reason: fmt.Sprintf("workload is missing selectors: %s", formatSelectors(missing)),
+			})
+		}
+	}
+
+	// Entries whose selectors all match the workload but that are not
+	// authorized for the agent point at a wrong parent ID or at a node alias
+	// the agent does not belong to.
+	matchingResp, err := dataStore.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySelectors: &datastore.BySelectors{
+			Selectors: selectors,
+			Match:     datastore.BySelectors_MATCH_SUBSET,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range util.DedupRegistrationEntries(matchingResp.Entries) {
+		if agentEntryIDs[entry.EntryId] || len(entry.Selectors) == 0 {
+			continue
+		}
+		reason, err := explainParent(ctx, dataStore, entry.ParentId, nodeSelectors)
+		if err != nil {
+			return nil, err
+		}
+		explanation.Excluded = append(explanation.Excluded, ExcludedEntry{
+			Entry:  entry,
+			Reason: reason,
+		})
+	}
+
+	util.SortRegistrationEntries(explanation.Authorized)
+	sort.Slice(explanation.Excluded, func(i, j int) bool {
+		return explanation.Excluded[i].Entry.EntryId < explanation.Excluded[j].Entry.EntryId
+	})
+	return explanation, nil
+}
+
+// explainParent describes why the given parent ID does not authorize the
+// agent with the given node selectors.
+func explainParent(ctx context.Context, dataStore datastore.DataStore, parentID string, nodeSelectors []*common.Selector) (string, error) {
+	resp, err := dataStore.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySpiffeId: &wrappers.StringValue{
+			Value: parentID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Entries) == 0 {
+		return fmt.Sprintf("parent ID %q is neither the agent nor an entry authorized for the agent", parentID), nil
+	}
+
+	// None of the parent entries is authorized for the agent, otherwise the
+	// entry would have been as well. Report the node alias that comes
+	// closest to covering the agent.
+	nodeSet := selector.NewSetFromRaw(nodeSelectors)
+	var closest []*common.Selector
+	isAlias := false
+	for _, parent := range resp.Entries {
+		if len(parent.Selectors) == 0 {
+			continue
+		}
+		missing := missingSelectors(parent.Selectors, nodeSet)
+		if !isAlias || len(missing) < len(closest) {
+			closest = missing
+			isAlias = true
+		}
+	}
+	if !isAlias {
+		return fmt.Sprintf("parent ID %q belongs to an entry that is not authorized for the agent", parentID), nil
+	}
+	return fmt.Sprintf("parent ID %q is a node alias the agent does not belong to; agent is missing node selectors: %s", parentID, formatSelectors(closest)), nil
+}
+
+// missingSelectors returns the selectors that are not included in the set.
+func missingSelectors(selectors []*common.Selector, set selector.Set) []*common.Selector {
+	var missing []*common.Selector
+	for _, s := range selectors {
+		if !set.Includes(selector.New(s)) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+func formatSelectors(selectors []*common.Selector) string {
+	var parts []string
+	for _, s := range selectors {
+		parts = append(parts, s.Type+":"+s.Value)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,62 @@
+package regentryutil
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/plugin/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainRegistrationEntries(t *testing.T) {
+	dataStore := fakedatastore.New()
+
+	createRegistrationEntry := func(entry *common.RegistrationEntry) *common.RegistrationEntry {
+		resp, err := dataStore.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			Entry: entry,
+		})
+		require.NoError(t, err)
+		return resp.Entry
+	}
+
+	agentID := "spiffe://example.org/agent"
+	a1 := &common.Selector{Type: "a", Value: "1"}
+	b2 := &common.Selector{Type: "b", Value: "2"}
+
+	//
+	//   agent         other-agent
+	//     |                |
+	//   1(a1)           2(a1,b2)
+	//                      |
+	//                    3(a1)
+	//
+	oneEntry := createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  agentID,
+		SpiffeId:  "spiffe://example.org/1",
+		Selectors: []*common.Selector{a1},
+	})
+	createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/other-agent",
+		SpiffeId:  "spiffe://example.org/2",
+		Selectors: []*common.Selector{a1, b2},
+	})
+	threeEntry := createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/2",
+		SpiffeId:  "spiffe://example.org/3",
+		Selectors: []*common.Selector{a1},
+	})
+
+	// Entry 2 is a node alias whose selectors the agent does not hold, and
+	// entry 3 hangs off of it. Entry 2 itself is not a near miss since the
+	// workload lacks one of its selectors and it is not authorized for the
+	// agent.
+	explanation, err := ExplainRegistrationEntries(ctx, dataStore, agentID, []*common.Selector{a1})
+	require.NoError(t, err)
+	require.Empty(t, explanation.NodeSelectors)
+	require.Equal(t, []*common.RegistrationEntry{oneEntry}, explanation.Authorized)
+
+	require.Equal(t, []ExcludedEntry{
+		{Entry: threeEntry, Reason: `parent ID "spiffe://example.org/2" is a node alias the agent does not belong to; agent is missing node selectors: a:1, b:2`},
+	}, explanation.Excluded)
+}
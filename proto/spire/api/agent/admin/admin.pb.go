@@ -0,0 +1,825 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package admin
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/spiffe/spire/proto/spire/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// A registration entry cached by the agent
+type CachedEntry struct {
+	// The registration entry
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// ASN.1 DER encoded X509-SVID certificate chain signed for the entry.
+	// Empty if no X509-SVID has been signed for the entry yet.
+	SvidChain []byte `protobuf:"bytes,2,opt,name=svid_chain,json=svidChain,proto3" json:"svid_chain,omitempty"`
+	// Expiration of the X509-SVID in seconds since the Unix epoch. Zero if
+	// no X509-SVID has been signed for the entry yet.
+	SvidExpiresAt        int64    `protobuf:"varint,3,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CachedEntry) Reset()         { *m = CachedEntry{} }
+func (m *CachedEntry) String() string { return proto.CompactTextString(m) }
+func (*CachedEntry) ProtoMessage()    {}
+func (*CachedEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *CachedEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CachedEntry.Unmarshal(m, b)
+}
+func (m *CachedEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CachedEntry.Marshal(b, m, deterministic)
+}
+func (m *CachedEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CachedEntry.Merge(m, src)
+}
+func (m *CachedEntry) XXX_Size() int {
+	return xxx_messageInfo_CachedEntry.Size(m)
+}
+func (m *CachedEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_CachedEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CachedEntry proto.InternalMessageInfo
+
+func (m *CachedEntry) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *CachedEntry) GetSvidChain() []byte {
+	if m != nil {
+		return m.SvidChain
+	}
+	return nil
+}
+
+func (m *CachedEntry) GetSvidExpiresAt() int64 {
+	if m != nil {
+		return m.SvidExpiresAt
+	}
+	return 0
+}
+
+type ListCachedEntriesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCachedEntriesRequest) Reset()         { *m = ListCachedEntriesRequest{} }
+func (m *ListCachedEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCachedEntriesRequest) ProtoMessage()    {}
+func (*ListCachedEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *ListCachedEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCachedEntriesRequest.Unmarshal(m, b)
+}
+func (m *ListCachedEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCachedEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCachedEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCachedEntriesRequest.Merge(m, src)
+}
+func (m *ListCachedEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCachedEntriesRequest.Size(m)
+}
+func (m *ListCachedEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCachedEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCachedEntriesRequest proto.InternalMessageInfo
+
+type ListCachedEntriesResponse struct {
+	// The cached entries, sorted by entry ID
+	Entries              []*CachedEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListCachedEntriesResponse) Reset()         { *m = ListCachedEntriesResponse{} }
+func (m *ListCachedEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCachedEntriesResponse) ProtoMessage()    {}
+func (*ListCachedEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *ListCachedEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCachedEntriesResponse.Unmarshal(m, b)
+}
+func (m *ListCachedEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCachedEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCachedEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCachedEntriesResponse.Merge(m, src)
+}
+func (m *ListCachedEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCachedEntriesResponse.Size(m)
+}
+func (m *ListCachedEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCachedEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCachedEntriesResponse proto.InternalMessageInfo
+
+func (m *ListCachedEntriesResponse) GetEntries() []*CachedEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type GetAgentInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAgentInfoRequest) Reset()         { *m = GetAgentInfoRequest{} }
+func (m *GetAgentInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAgentInfoRequest) ProtoMessage()    {}
+func (*GetAgentInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *GetAgentInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentInfoRequest.Unmarshal(m, b)
+}
+func (m *GetAgentInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAgentInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentInfoRequest.Merge(m, src)
+}
+func (m *GetAgentInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAgentInfoRequest.Size(m)
+}
+func (m *GetAgentInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentInfoRequest proto.InternalMessageInfo
+
+type GetAgentInfoResponse struct {
+	// SPIFFE ID of the agent
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// ASN.1 DER encoded agent X509-SVID certificate chain
+	SvidChain []byte `protobuf:"bytes,2,opt,name=svid_chain,json=svidChain,proto3" json:"svid_chain,omitempty"`
+	// Expiration of the agent X509-SVID in seconds since the Unix epoch
+	SvidExpiresAt int64 `protobuf:"varint,3,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	// The bundle of the agent trust domain
+	Bundle *common.Bundle `protobuf:"bytes,4,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Time of the last successful sync with the server in seconds since
+	// the Unix epoch. Zero if the agent has not synced yet.
+	LastSyncSuccess int64 `protobuf:"varint,5,opt,name=last_sync_success,json=lastSyncSuccess,proto3" json:"last_sync_success,omitempty"`
+	// Error returned by the last failed sync with the server
+	LastSyncError string `protobuf:"bytes,6,opt,name=last_sync_error,json=lastSyncError,proto3" json:"last_sync_error,omitempty"`
+	// Time of the last failed sync with the server in seconds since the
+	// Unix epoch. Zero if no sync has failed.
+	LastSyncErrorAt      int64    `protobuf:"varint,7,opt,name=last_sync_error_at,json=lastSyncErrorAt,proto3" json:"last_sync_error_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAgentInfoResponse) Reset()         { *m = GetAgentInfoResponse{} }
+func (m *GetAgentInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAgentInfoResponse) ProtoMessage()    {}
+func (*GetAgentInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *GetAgentInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentInfoResponse.Unmarshal(m, b)
+}
+func (m *GetAgentInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAgentInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentInfoResponse.Merge(m, src)
+}
+func (m *GetAgentInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAgentInfoResponse.Size(m)
+}
+func (m *GetAgentInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentInfoResponse proto.InternalMessageInfo
+
+func (m *GetAgentInfoResponse) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *GetAgentInfoResponse) GetSvidChain() []byte {
+	if m != nil {
+		return m.SvidChain
+	}
+	return nil
+}
+
+func (m *GetAgentInfoResponse) GetSvidExpiresAt() int64 {
+	if m != nil {
+		return m.SvidExpiresAt
+	}
+	return 0
+}
+
+func (m *GetAgentInfoResponse) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *GetAgentInfoResponse) GetLastSyncSuccess() int64 {
+	if m != nil {
+		return m.LastSyncSuccess
+	}
+	return 0
+}
+
+func (m *GetAgentInfoResponse) GetLastSyncError() string {
+	if m != nil {
+		return m.LastSyncError
+	}
+	return ""
+}
+
+func (m *GetAgentInfoResponse) GetLastSyncErrorAt() int64 {
+	if m != nil {
+		return m.LastSyncErrorAt
+	}
+	return 0
+}
+
+type DebugAttestRequest struct {
+	// Process ID of the workload to attest
+	Pid                  int32    `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DebugAttestRequest) Reset()         { *m = DebugAttestRequest{} }
+func (m *DebugAttestRequest) String() string { return proto.CompactTextString(m) }
+func (*DebugAttestRequest) ProtoMessage()    {}
+func (*DebugAttestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *DebugAttestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugAttestRequest.Unmarshal(m, b)
+}
+func (m *DebugAttestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugAttestRequest.Marshal(b, m, deterministic)
+}
+func (m *DebugAttestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugAttestRequest.Merge(m, src)
+}
+func (m *DebugAttestRequest) XXX_Size() int {
+	return xxx_messageInfo_DebugAttestRequest.Size(m)
+}
+func (m *DebugAttestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugAttestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugAttestRequest proto.InternalMessageInfo
+
+func (m *DebugAttestRequest) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+type DebugAttestResponse struct {
+	// Selectors returned by the workload attestor plugins
+	Selectors []*common.Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Cached entries whose selectors are a subset of the workload
+	// selectors, i.e. the identities the workload would be issued
+	MatchingEntries      []*CachedEntry `protobuf:"bytes,2,rep,name=matching_entries,json=matchingEntries,proto3" json:"matching_entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DebugAttestResponse) Reset()         { *m = DebugAttestResponse{} }
+func (m *DebugAttestResponse) String() string { return proto.CompactTextString(m) }
+func (*DebugAttestResponse) ProtoMessage()    {}
+func (*DebugAttestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *DebugAttestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugAttestResponse.Unmarshal(m, b)
+}
+func (m *DebugAttestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugAttestResponse.Marshal(b, m, deterministic)
+}
+func (m *DebugAttestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugAttestResponse.Merge(m, src)
+}
+func (m *DebugAttestResponse) XXX_Size() int {
+	return xxx_messageInfo_DebugAttestResponse.Size(m)
+}
+func (m *DebugAttestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugAttestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugAttestResponse proto.InternalMessageInfo
+
+func (m *DebugAttestResponse) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *DebugAttestResponse) GetMatchingEntries() []*CachedEntry {
+	if m != nil {
+		return m.MatchingEntries
+	}
+	return nil
+}
+
+type SyncRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(m, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+type SyncResponse struct {
+	// Number of entries in the cache after the sync
+	Entries              int32    `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncResponse) Reset()         { *m = SyncResponse{} }
+func (m *SyncResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncResponse) ProtoMessage()    {}
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{8}
+}
+
+func (m *SyncResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncResponse.Unmarshal(m, b)
+}
+func (m *SyncResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncResponse.Marshal(b, m, deterministic)
+}
+func (m *SyncResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncResponse.Merge(m, src)
+}
+func (m *SyncResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncResponse.Size(m)
+}
+func (m *SyncResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncResponse proto.InternalMessageInfo
+
+func (m *SyncResponse) GetEntries() int32 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+type RotateSVIDRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateSVIDRequest) Reset()         { *m = RotateSVIDRequest{} }
+func (m *RotateSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*RotateSVIDRequest) ProtoMessage()    {}
+func (*RotateSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{9}
+}
+
+func (m *RotateSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateSVIDRequest.Unmarshal(m, b)
+}
+func (m *RotateSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *RotateSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateSVIDRequest.Merge(m, src)
+}
+func (m *RotateSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_RotateSVIDRequest.Size(m)
+}
+func (m *RotateSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateSVIDRequest proto.InternalMessageInfo
+
+type RotateSVIDResponse struct {
+	// ASN.1 DER encoded certificate chain of the new agent X509-SVID
+	SvidChain []byte `protobuf:"bytes,1,opt,name=svid_chain,json=svidChain,proto3" json:"svid_chain,omitempty"`
+	// Expiration of the new agent X509-SVID in seconds since the Unix epoch
+	SvidExpiresAt        int64    `protobuf:"varint,2,opt,name=svid_expires_at,json=svidExpiresAt,proto3" json:"svid_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateSVIDResponse) Reset()         { *m = RotateSVIDResponse{} }
+func (m *RotateSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*RotateSVIDResponse) ProtoMessage()    {}
+func (*RotateSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{10}
+}
+
+func (m *RotateSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateSVIDResponse.Unmarshal(m, b)
+}
+func (m *RotateSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateSVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *RotateSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateSVIDResponse.Merge(m, src)
+}
+func (m *RotateSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_RotateSVIDResponse.Size(m)
+}
+func (m *RotateSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateSVIDResponse proto.InternalMessageInfo
+
+func (m *RotateSVIDResponse) GetSvidChain() []byte {
+	if m != nil {
+		return m.SvidChain
+	}
+	return nil
+}
+
+func (m *RotateSVIDResponse) GetSvidExpiresAt() int64 {
+	if m != nil {
+		return m.SvidExpiresAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*CachedEntry)(nil), "spire.api.agent.admin.CachedEntry")
+	proto.RegisterType((*ListCachedEntriesRequest)(nil), "spire.api.agent.admin.ListCachedEntriesRequest")
+	proto.RegisterType((*ListCachedEntriesResponse)(nil), "spire.api.agent.admin.ListCachedEntriesResponse")
+	proto.RegisterType((*GetAgentInfoRequest)(nil), "spire.api.agent.admin.GetAgentInfoRequest")
+	proto.RegisterType((*GetAgentInfoResponse)(nil), "spire.api.agent.admin.GetAgentInfoResponse")
+	proto.RegisterType((*DebugAttestRequest)(nil), "spire.api.agent.admin.DebugAttestRequest")
+	proto.RegisterType((*DebugAttestResponse)(nil), "spire.api.agent.admin.DebugAttestResponse")
+	proto.RegisterType((*SyncRequest)(nil), "spire.api.agent.admin.SyncRequest")
+	proto.RegisterType((*SyncResponse)(nil), "spire.api.agent.admin.SyncResponse")
+	proto.RegisterType((*RotateSVIDRequest)(nil), "spire.api.agent.admin.RotateSVIDRequest")
+	proto.RegisterType((*RotateSVIDResponse)(nil), "spire.api.agent.admin.RotateSVIDResponse")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcf, 0x6a, 0xdb, 0x4e,
+	0x10, 0x46, 0x76, 0x9c, 0xfc, 0x3c, 0x4a, 0x48, 0xb2, 0x49, 0x7e, 0x28, 0x2a, 0xa5, 0x46, 0x85,
+	0xa0, 0x24, 0x45, 0x2a, 0x49, 0x73, 0xeb, 0xc5, 0xf9, 0x43, 0x09, 0xb4, 0x14, 0x64, 0x28, 0xb4,
+	0x3d, 0x88, 0xb5, 0xb4, 0x91, 0x17, 0xec, 0x95, 0xaa, 0x5d, 0x87, 0xfa, 0xda, 0x6b, 0xdf, 0xa0,
+	0x6f, 0xd0, 0xb7, 0x2c, 0xbb, 0x5a, 0xd5, 0x52, 0x6d, 0x15, 0x1d, 0x7a, 0xd2, 0xea, 0x9b, 0x6f,
+	0x66, 0x76, 0x66, 0xbe, 0x59, 0x30, 0x71, 0x3c, 0xa3, 0xcc, 0xcb, 0xf2, 0x54, 0xa4, 0xe8, 0x88,
+	0x67, 0x34, 0x27, 0x1e, 0xce, 0xa8, 0x87, 0x13, 0xc2, 0x84, 0xa7, 0x8c, 0xf6, 0xb1, 0x82, 0xfd,
+	0x28, 0x9d, 0xcd, 0x52, 0xa6, 0x3f, 0x85, 0x87, 0xf3, 0xdd, 0x00, 0xf3, 0x06, 0x47, 0x13, 0x12,
+	0xdf, 0x31, 0x91, 0x2f, 0xd0, 0x15, 0xf4, 0x88, 0x3c, 0x58, 0xc6, 0xc0, 0x70, 0xcd, 0x8b, 0x67,
+	0x5e, 0x11, 0x51, 0xfb, 0x04, 0x24, 0xa1, 0x5c, 0xe4, 0x58, 0xd0, 0x94, 0x29, 0x7e, 0x50, 0xb0,
+	0xd1, 0x53, 0x00, 0xfe, 0x48, 0xe3, 0x30, 0x9a, 0x60, 0xca, 0xac, 0xce, 0xc0, 0x70, 0xb7, 0x83,
+	0xbe, 0x44, 0x6e, 0x24, 0x80, 0x4e, 0x60, 0x57, 0x99, 0xc9, 0x57, 0x19, 0x8d, 0x87, 0x58, 0x58,
+	0xdd, 0x81, 0xe1, 0x76, 0x83, 0x1d, 0x09, 0xdf, 0x15, 0xe8, 0x50, 0x38, 0x36, 0x58, 0x6f, 0x29,
+	0x17, 0xcb, 0x0b, 0x51, 0xc2, 0x03, 0xf2, 0x65, 0x4e, 0xb8, 0x70, 0x3e, 0xc2, 0xf1, 0x1a, 0x1b,
+	0xcf, 0x52, 0xc6, 0x09, 0x7a, 0x0d, 0x5b, 0xa4, 0x80, 0x2c, 0x63, 0xd0, 0x75, 0xcd, 0x0b, 0xc7,
+	0x5b, 0xdb, 0x0a, 0xaf, 0x52, 0x6b, 0x50, 0xba, 0x38, 0x47, 0x70, 0xf0, 0x86, 0x88, 0xa1, 0xa4,
+	0xdd, 0xb3, 0x87, 0xb4, 0xcc, 0xf8, 0xb3, 0x03, 0x87, 0x75, 0x5c, 0x67, 0x7b, 0x02, 0x7d, 0x9e,
+	0xd1, 0x87, 0x07, 0x12, 0xd2, 0x58, 0x35, 0xaa, 0x1f, 0xfc, 0x57, 0x00, 0xf7, 0xf1, 0x3f, 0x6a,
+	0x05, 0x7a, 0x01, 0x9b, 0xe3, 0x39, 0x8b, 0xa7, 0xc4, 0xda, 0x50, 0x93, 0x38, 0xac, 0x4f, 0xe2,
+	0x5a, 0xd9, 0x02, 0xcd, 0x41, 0x67, 0xb0, 0x3f, 0xc5, 0x5c, 0x84, 0x7c, 0xc1, 0xa2, 0x90, 0xcf,
+	0xa3, 0x88, 0x70, 0x6e, 0xf5, 0x54, 0xdc, 0x5d, 0x69, 0x18, 0x2d, 0x58, 0x34, 0x2a, 0x60, 0x79,
+	0x83, 0x25, 0x97, 0xe4, 0x79, 0x9a, 0x5b, 0x9b, 0xaa, 0x86, 0x9d, 0x92, 0x79, 0x27, 0x41, 0x74,
+	0x0e, 0xe8, 0x0f, 0x9e, 0xbc, 0xec, 0x56, 0x3d, 0xa8, 0xa2, 0x0e, 0x85, 0x73, 0x02, 0xe8, 0x96,
+	0x8c, 0xe7, 0xc9, 0x50, 0x08, 0xc2, 0x85, 0xee, 0x20, 0xda, 0x83, 0x6e, 0xa6, 0x5b, 0xd4, 0x0b,
+	0xe4, 0xd1, 0xf9, 0x61, 0xc0, 0x41, 0x8d, 0xa8, 0x5b, 0xfa, 0x0a, 0xfa, 0x9c, 0x4c, 0x49, 0x24,
+	0xd2, 0xbc, 0x1c, 0xe1, 0xff, 0xf5, 0x8a, 0x47, 0xda, 0x1c, 0x2c, 0x89, 0xe8, 0x1d, 0xec, 0xcd,
+	0xb0, 0x88, 0x26, 0x94, 0x25, 0x61, 0x39, 0xff, 0x4e, 0xeb, 0xf9, 0xef, 0x96, 0xbe, 0x5a, 0x4d,
+	0xce, 0x0e, 0x98, 0xb2, 0xa6, 0x72, 0xfe, 0x2e, 0x6c, 0x17, 0xbf, 0xfa, 0x8e, 0x56, 0x55, 0x64,
+	0xb2, 0xa2, 0xdf, 0x02, 0x3a, 0x80, 0xfd, 0x20, 0x15, 0x58, 0x90, 0xd1, 0x87, 0xfb, 0xdb, 0xd2,
+	0xfd, 0x33, 0xa0, 0x2a, 0xa8, 0x83, 0xd4, 0xe5, 0x61, 0xb4, 0x90, 0x47, 0x67, 0x8d, 0x3c, 0x2e,
+	0xbe, 0x6d, 0x40, 0x6f, 0x28, 0x2b, 0x42, 0x8f, 0xb0, 0xbf, 0xb2, 0x17, 0xc8, 0x6f, 0x28, 0xbf,
+	0x69, 0xbb, 0xec, 0x97, 0xed, 0x1d, 0x74, 0x21, 0x09, 0x6c, 0x57, 0x97, 0x03, 0x9d, 0x35, 0x44,
+	0x58, 0xb3, 0x59, 0xf6, 0x79, 0x2b, 0xae, 0x4e, 0x14, 0x83, 0x59, 0x51, 0x0c, 0x3a, 0x6d, 0xf0,
+	0x5d, 0x95, 0x9f, 0x7d, 0xd6, 0x86, 0xaa, 0xb3, 0xbc, 0x87, 0x0d, 0x39, 0x6c, 0xd4, 0x24, 0x9c,
+	0x8a, 0x30, 0xec, 0xe7, 0x7f, 0xe5, 0xe8, 0x80, 0x18, 0x60, 0x39, 0x7e, 0xe4, 0x36, 0xb8, 0xac,
+	0xc8, 0xc6, 0x3e, 0x6d, 0xc1, 0x2c, 0x52, 0x5c, 0x5f, 0x7d, 0xba, 0x4c, 0xa8, 0x98, 0xcc, 0xc7,
+	0x72, 0x47, 0xfc, 0xe2, 0x05, 0xf2, 0x8b, 0xb7, 0x5e, 0xbd, 0xee, 0xfa, 0x8c, 0x33, 0xea, 0xab,
+	0x48, 0xbe, 0x8a, 0x34, 0xde, 0x54, 0xc6, 0xcb, 0x5f, 0x03, 0x00, 0x88, 0xe3, 0x2d, 0x76, 0x3b,
+	0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	// Lists the registration entries in the agent cache along with the
+	// X509-SVIDs signed for them
+	ListCachedEntries(ctx context.Context, in *ListCachedEntriesRequest, opts ...grpc.CallOption) (*ListCachedEntriesResponse, error)
+	// Returns the agent SVID, the trust domain bundle and the server sync
+	// status
+	GetAgentInfo(ctx context.Context, in *GetAgentInfoRequest, opts ...grpc.CallOption) (*GetAgentInfoResponse, error)
+	// Attests the process with the given PID and returns its selectors
+	// along with the cached entries it matches
+	DebugAttest(ctx context.Context, in *DebugAttestRequest, opts ...grpc.CallOption) (*DebugAttestResponse, error)
+	// Syncs registration entries and SVIDs with the server immediately
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	// Rotates the agent SVID immediately, regardless of its expiration
+	RotateSVID(ctx context.Context, in *RotateSVIDRequest, opts ...grpc.CallOption) (*RotateSVIDResponse, error)
+}
+
+type adminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminClient(cc *grpc.ClientConn) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListCachedEntries(ctx context.Context, in *ListCachedEntriesRequest, opts ...grpc.CallOption) (*ListCachedEntriesResponse, error) {
+	out := new(ListCachedEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.agent.admin.Admin/ListCachedEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetAgentInfo(ctx context.Context, in *GetAgentInfoRequest, opts ...grpc.CallOption) (*GetAgentInfoResponse, error) {
+	out := new(GetAgentInfoResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.agent.admin.Admin/GetAgentInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DebugAttest(ctx context.Context, in *DebugAttestRequest, opts ...grpc.CallOption) (*DebugAttestResponse, error) {
+	out := new(DebugAttestResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.agent.admin.Admin/DebugAttest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.agent.admin.Admin/Sync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RotateSVID(ctx context.Context, in *RotateSVIDRequest, opts ...grpc.CallOption) (*RotateSVIDResponse, error) {
+	out := new(RotateSVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.agent.admin.Admin/RotateSVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	// Lists the registration entries in the agent cache along with the
+	// X509-SVIDs signed for them
+	ListCachedEntries(context.Context, *ListCachedEntriesRequest) (*ListCachedEntriesResponse, error)
+	// Returns the agent SVID, the trust domain bundle and the server sync
+	// status
+	GetAgentInfo(context.Context, *GetAgentInfoRequest) (*GetAgentInfoResponse, error)
+	// Attests the process with the given PID and returns its selectors
+	// along with the cached entries it matches
+	DebugAttest(context.Context, *DebugAttestRequest) (*DebugAttestResponse, error)
+	// Syncs registration entries and SVIDs with the server immediately
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	// Rotates the agent SVID immediately, regardless of its expiration
+	RotateSVID(context.Context, *RotateSVIDRequest) (*RotateSVIDResponse, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) ListCachedEntries(ctx context.Context, req *ListCachedEntriesRequest) (*ListCachedEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCachedEntries not implemented")
+}
+func (*UnimplementedAdminServer) GetAgentInfo(ctx context.Context, req *GetAgentInfoRequest) (*GetAgentInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentInfo not implemented")
+}
+func (*UnimplementedAdminServer) DebugAttest(ctx context.Context, req *DebugAttestRequest) (*DebugAttestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugAttest not implemented")
+}
+func (*UnimplementedAdminServer) Sync(ctx context.Context, req *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (*UnimplementedAdminServer) RotateSVID(ctx context.Context, req *RotateSVIDRequest) (*RotateSVIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSVID not implemented")
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_ListCachedEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCachedEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListCachedEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.agent.admin.Admin/ListCachedEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListCachedEntries(ctx, req.(*ListCachedEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetAgentInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetAgentInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.agent.admin.Admin/GetAgentInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetAgentInfo(ctx, req.(*GetAgentInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DebugAttest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugAttestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DebugAttest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.agent.admin.Admin/DebugAttest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DebugAttest(ctx, req.(*DebugAttestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.agent.admin.Admin/Sync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RotateSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RotateSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.agent.admin.Admin/RotateSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RotateSVID(ctx, req.(*RotateSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.agent.admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCachedEntries",
+			Handler:    _Admin_ListCachedEntries_Handler,
+		},
+		{
+			MethodName: "GetAgentInfo",
+			Handler:    _Admin_GetAgentInfo_Handler,
+		},
+		{
+			MethodName: "DebugAttest",
+			Handler:    _Admin_DebugAttest_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _Admin_Sync_Handler,
+		},
+		{
+			MethodName: "RotateSVID",
+			Handler:    _Admin_RotateSVID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
@@ -4,8 +4,9 @@ const (
 	// Max burst values for ratelimiting
 	// Requests containing more than this number of
 	// operations will always be rejected
-	AttestLimit     int = 1
-	CSRLimit        int = 500
-	JSRLimit        int = 500
-	PushJWTKeyLimit int = 500
+	AttestLimit       int = 1
+	CSRLimit          int = 500
+	JSRLimit          int = 500
+	PushJWTKeyLimit   int = 500
+	ReportAgentsLimit int = 1
 )
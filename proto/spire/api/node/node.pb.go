@@ -319,7 +319,9 @@ type AttestRequest struct {
 	// Certificate signing request.
 	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
 	// Attestation challenge response
-	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	Response []byte `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	// Version of the agent
+	AgentVersion         string   `protobuf:"bytes,4,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -371,6 +373,13 @@ func (m *AttestRequest) GetResponse() []byte {
 	return nil
 }
 
+func (m *AttestRequest) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
 // Represents a response that contains  map of signed SVIDs and an array of
 // all current Registration Entries which are relevant to the caller SPIFFE ID
 type AttestResponse struct {
@@ -430,10 +439,12 @@ type FetchX509SVIDRequest struct {
 	// A list of CSRs (deprecated, use `csrs` map instead)
 	DEPRECATEDCsrs [][]byte `protobuf:"bytes,2,rep,name=DEPRECATED_csrs,json=DEPRECATEDCsrs,proto3" json:"DEPRECATED_csrs,omitempty"`
 	// A map of CSRs keyed by entry ID
-	Csrs                 map[string][]byte `protobuf:"bytes,3,rep,name=csrs,proto3" json:"csrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Csrs map[string][]byte `protobuf:"bytes,3,rep,name=csrs,proto3" json:"csrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Version of the agent
+	AgentVersion         string   `protobuf:"bytes,4,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FetchX509SVIDRequest) Reset()         { *m = FetchX509SVIDRequest{} }
@@ -475,6 +486,13 @@ func (m *FetchX509SVIDRequest) GetCsrs() map[string][]byte {
 	return nil
 }
 
+func (m *FetchX509SVIDRequest) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
 // Represents a response that contains  map of signed SVIDs and an array
 // of all current Registration Entries which are relevant to the caller SPIFFE ID.
 type FetchX509SVIDResponse struct {
@@ -763,6 +781,249 @@ func (m *PushJWTKeyUpstreamResponse) GetJwtSigningKeys() []*common.PublicKey {
 	return nil
 }
 
+type FetchDelegatedJWTKeyRequest struct {
+	// PKIX encoded public key of the key pair generated by the agent
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchDelegatedJWTKeyRequest) Reset()         { *m = FetchDelegatedJWTKeyRequest{} }
+func (m *FetchDelegatedJWTKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchDelegatedJWTKeyRequest) ProtoMessage()    {}
+func (*FetchDelegatedJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{15}
+}
+
+func (m *FetchDelegatedJWTKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchDelegatedJWTKeyRequest.Unmarshal(m, b)
+}
+func (m *FetchDelegatedJWTKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchDelegatedJWTKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchDelegatedJWTKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchDelegatedJWTKeyRequest.Merge(m, src)
+}
+func (m *FetchDelegatedJWTKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchDelegatedJWTKeyRequest.Size(m)
+}
+func (m *FetchDelegatedJWTKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchDelegatedJWTKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchDelegatedJWTKeyRequest proto.InternalMessageInfo
+
+func (m *FetchDelegatedJWTKeyRequest) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type FetchDelegatedJWTKeyResponse struct {
+	// The delegated JWT signing key, as published in the trust bundle. The
+	// server assigns the key ID and the expiration.
+	JwtKey               *common.PublicKey `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *FetchDelegatedJWTKeyResponse) Reset()         { *m = FetchDelegatedJWTKeyResponse{} }
+func (m *FetchDelegatedJWTKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchDelegatedJWTKeyResponse) ProtoMessage()    {}
+func (*FetchDelegatedJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{16}
+}
+
+func (m *FetchDelegatedJWTKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchDelegatedJWTKeyResponse.Unmarshal(m, b)
+}
+func (m *FetchDelegatedJWTKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchDelegatedJWTKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchDelegatedJWTKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchDelegatedJWTKeyResponse.Merge(m, src)
+}
+func (m *FetchDelegatedJWTKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchDelegatedJWTKeyResponse.Size(m)
+}
+func (m *FetchDelegatedJWTKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchDelegatedJWTKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchDelegatedJWTKeyResponse proto.InternalMessageInfo
+
+func (m *FetchDelegatedJWTKeyResponse) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type ReportAgentsUpstreamRequest struct {
+	// The agents attested by the downstream SPIRE server
+	Agents []*common.AttestedNode `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Large reports are split across requests. True if the agents are
+	// appended to the report started by the previous request.
+	Continued bool `protobuf:"varint,2,opt,name=continued,proto3" json:"continued,omitempty"`
+	// True if the report continues in the next request. The upstream SPIRE server
+	// replaces the reported agents once the last request is received.
+	More                 bool     `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportAgentsUpstreamRequest) Reset()         { *m = ReportAgentsUpstreamRequest{} }
+func (m *ReportAgentsUpstreamRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentsUpstreamRequest) ProtoMessage()    {}
+func (*ReportAgentsUpstreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{17}
+}
+
+func (m *ReportAgentsUpstreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentsUpstreamRequest.Unmarshal(m, b)
+}
+func (m *ReportAgentsUpstreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentsUpstreamRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportAgentsUpstreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentsUpstreamRequest.Merge(m, src)
+}
+func (m *ReportAgentsUpstreamRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentsUpstreamRequest.Size(m)
+}
+func (m *ReportAgentsUpstreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentsUpstreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportAgentsUpstreamRequest proto.InternalMessageInfo
+
+func (m *ReportAgentsUpstreamRequest) GetAgents() []*common.AttestedNode {
+	if m != nil {
+		return m.Agents
+	}
+	return nil
+}
+
+func (m *ReportAgentsUpstreamRequest) GetContinued() bool {
+	if m != nil {
+		return m.Continued
+	}
+	return false
+}
+
+func (m *ReportAgentsUpstreamRequest) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type ReportAgentsUpstreamResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportAgentsUpstreamResponse) Reset()         { *m = ReportAgentsUpstreamResponse{} }
+func (m *ReportAgentsUpstreamResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentsUpstreamResponse) ProtoMessage()    {}
+func (*ReportAgentsUpstreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{18}
+}
+
+func (m *ReportAgentsUpstreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentsUpstreamResponse.Unmarshal(m, b)
+}
+func (m *ReportAgentsUpstreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentsUpstreamResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportAgentsUpstreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentsUpstreamResponse.Merge(m, src)
+}
+func (m *ReportAgentsUpstreamResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentsUpstreamResponse.Size(m)
+}
+func (m *ReportAgentsUpstreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentsUpstreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportAgentsUpstreamResponse proto.InternalMessageInfo
+
+type FetchFederatedBundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchFederatedBundlesRequest) Reset()         { *m = FetchFederatedBundlesRequest{} }
+func (m *FetchFederatedBundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchFederatedBundlesRequest) ProtoMessage()    {}
+func (*FetchFederatedBundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{19}
+}
+
+func (m *FetchFederatedBundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederatedBundlesRequest.Unmarshal(m, b)
+}
+func (m *FetchFederatedBundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederatedBundlesRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchFederatedBundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederatedBundlesRequest.Merge(m, src)
+}
+func (m *FetchFederatedBundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchFederatedBundlesRequest.Size(m)
+}
+func (m *FetchFederatedBundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederatedBundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederatedBundlesRequest proto.InternalMessageInfo
+
+type FetchFederatedBundlesResponse struct {
+	// Bundles of the trust domains federated with the upstream SPIRE server
+	Bundles              []*common.Bundle `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *FetchFederatedBundlesResponse) Reset()         { *m = FetchFederatedBundlesResponse{} }
+func (m *FetchFederatedBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchFederatedBundlesResponse) ProtoMessage()    {}
+func (*FetchFederatedBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{20}
+}
+
+func (m *FetchFederatedBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederatedBundlesResponse.Unmarshal(m, b)
+}
+func (m *FetchFederatedBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederatedBundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchFederatedBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederatedBundlesResponse.Merge(m, src)
+}
+func (m *FetchFederatedBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchFederatedBundlesResponse.Size(m)
+}
+func (m *FetchFederatedBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederatedBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederatedBundlesResponse proto.InternalMessageInfo
+
+func (m *FetchFederatedBundlesResponse) GetBundles() []*common.Bundle {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
 type FetchBundleRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -773,7 +1034,7 @@ func (m *FetchBundleRequest) Reset()         { *m = FetchBundleRequest{} }
 func (m *FetchBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchBundleRequest) ProtoMessage()    {}
 func (*FetchBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c843d59d2d938e7, []int{15}
+	return fileDescriptor_0c843d59d2d938e7, []int{21}
 }
 
 func (m *FetchBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -805,7 +1066,7 @@ func (m *FetchBundleResponse) Reset()         { *m = FetchBundleResponse{} }
 func (m *FetchBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchBundleResponse) ProtoMessage()    {}
 func (*FetchBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c843d59d2d938e7, []int{16}
+	return fileDescriptor_0c843d59d2d938e7, []int{22}
 }
 
 func (m *FetchBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -833,6 +1094,107 @@ func (m *FetchBundleResponse) GetBundle() *common.Bundle {
 	return nil
 }
 
+// Represents a request to re-attest an already attested node.
+type ReattestRequest struct {
+	// A type which contains fresh attestation data for specific platform.
+	AttestationData *common.AttestationData `protobuf:"bytes,1,opt,name=attestation_data,json=attestationData,proto3" json:"attestation_data,omitempty"`
+	// Attestation challenge response
+	Response             []byte   `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReattestRequest) Reset()         { *m = ReattestRequest{} }
+func (m *ReattestRequest) String() string { return proto.CompactTextString(m) }
+func (*ReattestRequest) ProtoMessage()    {}
+func (*ReattestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{23}
+}
+
+func (m *ReattestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReattestRequest.Unmarshal(m, b)
+}
+func (m *ReattestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReattestRequest.Marshal(b, m, deterministic)
+}
+func (m *ReattestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReattestRequest.Merge(m, src)
+}
+func (m *ReattestRequest) XXX_Size() int {
+	return xxx_messageInfo_ReattestRequest.Size(m)
+}
+func (m *ReattestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReattestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReattestRequest proto.InternalMessageInfo
+
+func (m *ReattestRequest) GetAttestationData() *common.AttestationData {
+	if m != nil {
+		return m.AttestationData
+	}
+	return nil
+}
+
+func (m *ReattestRequest) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type ReattestResponse struct {
+	// This is a challenge issued by the server to the node. If populated, the
+	// node is expected to respond with another ReattestRequest with the
+	// response.
+	Challenge []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// The refreshed node selectors. Only set on the final response.
+	Selectors            []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ReattestResponse) Reset()         { *m = ReattestResponse{} }
+func (m *ReattestResponse) String() string { return proto.CompactTextString(m) }
+func (*ReattestResponse) ProtoMessage()    {}
+func (*ReattestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{24}
+}
+
+func (m *ReattestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReattestResponse.Unmarshal(m, b)
+}
+func (m *ReattestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReattestResponse.Marshal(b, m, deterministic)
+}
+func (m *ReattestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReattestResponse.Merge(m, src)
+}
+func (m *ReattestResponse) XXX_Size() int {
+	return xxx_messageInfo_ReattestResponse.Size(m)
+}
+func (m *ReattestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReattestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReattestResponse proto.InternalMessageInfo
+
+func (m *ReattestResponse) GetChallenge() []byte {
+	if m != nil {
+		return m.Challenge
+	}
+	return nil
+}
+
+func (m *ReattestResponse) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Bundle)(nil), "spire.api.node.Bundle")
 	proto.RegisterType((*X509SVID)(nil), "spire.api.node.X509SVID")
@@ -852,73 +1214,98 @@ func init() {
 	proto.RegisterType((*FetchX509CASVIDResponse)(nil), "spire.api.node.FetchX509CASVIDResponse")
 	proto.RegisterType((*PushJWTKeyUpstreamRequest)(nil), "spire.api.node.PushJWTKeyUpstreamRequest")
 	proto.RegisterType((*PushJWTKeyUpstreamResponse)(nil), "spire.api.node.PushJWTKeyUpstreamResponse")
+	proto.RegisterType((*FetchDelegatedJWTKeyRequest)(nil), "spire.api.node.FetchDelegatedJWTKeyRequest")
+	proto.RegisterType((*FetchDelegatedJWTKeyResponse)(nil), "spire.api.node.FetchDelegatedJWTKeyResponse")
+	proto.RegisterType((*ReportAgentsUpstreamRequest)(nil), "spire.api.node.ReportAgentsUpstreamRequest")
+	proto.RegisterType((*ReportAgentsUpstreamResponse)(nil), "spire.api.node.ReportAgentsUpstreamResponse")
+	proto.RegisterType((*FetchFederatedBundlesRequest)(nil), "spire.api.node.FetchFederatedBundlesRequest")
+	proto.RegisterType((*FetchFederatedBundlesResponse)(nil), "spire.api.node.FetchFederatedBundlesResponse")
 	proto.RegisterType((*FetchBundleRequest)(nil), "spire.api.node.FetchBundleRequest")
 	proto.RegisterType((*FetchBundleResponse)(nil), "spire.api.node.FetchBundleResponse")
+	proto.RegisterType((*ReattestRequest)(nil), "spire.api.node.ReattestRequest")
+	proto.RegisterType((*ReattestResponse)(nil), "spire.api.node.ReattestResponse")
 }
 
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 933 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5d, 0x6f, 0x1a, 0x47,
-	0x14, 0xd5, 0x7a, 0x0d, 0x86, 0x0b, 0xc1, 0xd6, 0x98, 0x36, 0x78, 0x5b, 0xa7, 0xd6, 0x26, 0x69,
-	0x5c, 0xc7, 0x5a, 0x5b, 0x8e, 0xaa, 0x7e, 0xa8, 0x52, 0x84, 0x31, 0x55, 0x62, 0xd4, 0x0a, 0x0d,
-	0x49, 0x9a, 0xb6, 0x0f, 0x74, 0xd8, 0x9d, 0xe0, 0xb1, 0xf1, 0x2e, 0xdd, 0x99, 0x8d, 0xcb, 0x2f,
-	0xe8, 0x7b, 0xff, 0x4d, 0xa5, 0xfe, 0xb8, 0x6a, 0x3e, 0x16, 0x76, 0x31, 0x98, 0x3c, 0xe4, 0x89,
-	0x9d, 0x3b, 0xe7, 0x9e, 0x7b, 0xef, 0xd9, 0x33, 0xc3, 0x02, 0x84, 0x51, 0x40, 0xbd, 0x71, 0x1c,
-	0x89, 0x08, 0xd5, 0xf8, 0x98, 0xc5, 0xd4, 0x23, 0x63, 0xe6, 0xc9, 0xa8, 0xb3, 0xa3, 0xd6, 0x47,
-	0x7e, 0x74, 0x7d, 0x1d, 0x85, 0xe6, 0x47, 0x43, 0xdd, 0x67, 0x50, 0x3c, 0x4d, 0xc2, 0x60, 0x44,
-	0x51, 0x0d, 0xd6, 0x58, 0xd0, 0xb0, 0xf6, 0xac, 0xfd, 0x32, 0x5e, 0x63, 0x01, 0xda, 0x81, 0x92,
-	0x4f, 0xfa, 0x3e, 0x8d, 0x05, 0x6f, 0xac, 0xed, 0x59, 0xfb, 0x55, 0xbc, 0xe1, 0x93, 0x96, 0x5c,
-	0xba, 0x2f, 0xa0, 0xf4, 0xf6, 0xeb, 0xe3, 0xef, 0x7a, 0x6f, 0x5e, 0x9e, 0xa1, 0x5d, 0x00, 0x89,
-	0xe9, 0xfb, 0x17, 0x84, 0x85, 0x0d, 0x5b, 0x01, 0xcb, 0x32, 0xd2, 0x92, 0x01, 0xb9, 0x4d, 0xff,
-	0x92, 0xd5, 0x79, 0x9f, 0x08, 0xc5, 0x63, 0xe3, 0xb2, 0x89, 0x34, 0x85, 0xfb, 0x8f, 0x0d, 0xb5,
-	0x94, 0xea, 0xf5, 0x38, 0x20, 0x82, 0xa2, 0xe7, 0x50, 0xe0, 0xef, 0x59, 0xc0, 0x1b, 0xd6, 0x9e,
-	0xbd, 0x5f, 0x39, 0xf9, 0xca, 0xcb, 0x0f, 0xe3, 0xe5, 0xe1, 0x5e, 0x4f, 0x62, 0xdb, 0xa1, 0x88,
-	0x27, 0x58, 0xe7, 0x21, 0x0c, 0xf5, 0x98, 0x0e, 0x19, 0x17, 0x31, 0x11, 0x2c, 0x0a, 0xfb, 0x34,
-	0x14, 0x31, 0xa3, 0xbc, 0x61, 0x2b, 0xbe, 0x2f, 0x0c, 0x9f, 0x51, 0x01, 0x67, 0x90, 0x9a, 0x65,
-	0x3b, 0x9e, 0x0b, 0x31, 0xca, 0x51, 0x1b, 0x36, 0x06, 0x4a, 0x26, 0xde, 0x28, 0x28, 0x9a, 0xa7,
-	0x2b, 0xda, 0xd2, 0xa2, 0x9a, 0xc6, 0xd2, 0x5c, 0x07, 0x03, 0xcc, 0xfa, 0x45, 0x5b, 0x60, 0x5f,
-	0xd1, 0x89, 0x91, 0x5c, 0x3e, 0x22, 0x0f, 0x0a, 0xef, 0xc9, 0x28, 0xa1, 0x4a, 0xa8, 0xca, 0x49,
-	0x63, 0x59, 0x11, 0xac, 0x61, 0xdf, 0xaf, 0x7d, 0x6b, 0x39, 0x5d, 0xa8, 0x66, 0x8b, 0x2d, 0x60,
-	0x3d, 0xc8, 0xb3, 0xd6, 0xf3, 0x0a, 0xe8, 0xe4, 0x0c, 0xa3, 0xdb, 0x05, 0xfb, 0xbc, 0x87, 0xd1,
-	0x67, 0x50, 0xe6, 0x63, 0xf6, 0xee, 0x1d, 0xed, 0x4f, 0x7d, 0x51, 0xd2, 0x81, 0x97, 0x01, 0x72,
-	0xa0, 0x44, 0x92, 0x80, 0xd1, 0xd0, 0x97, 0xb4, 0xb6, 0xdc, 0x4b, 0xd7, 0xb2, 0x03, 0x21, 0x46,
-	0xca, 0x0b, 0x05, 0x2c, 0x1f, 0xdd, 0xdf, 0x61, 0xe3, 0xfc, 0x97, 0x57, 0xca, 0x2f, 0x75, 0x28,
-	0x88, 0xe8, 0x8a, 0x86, 0x86, 0x51, 0x2f, 0x56, 0xd8, 0x44, 0xb6, 0xc2, 0x38, 0x4f, 0x68, 0x20,
-	0x77, 0x6d, 0xb5, 0x5b, 0xd2, 0x81, 0xa6, 0x70, 0xff, 0xb6, 0xe0, 0x5e, 0x53, 0x08, 0xca, 0x05,
-	0xa6, 0x7f, 0x26, 0x94, 0x0b, 0xf4, 0x02, 0xb6, 0x88, 0x0a, 0x68, 0x03, 0x04, 0x44, 0x10, 0x55,
-	0xae, 0x72, 0xb2, 0x9b, 0x9f, 0xbd, 0x39, 0x43, 0x9d, 0x11, 0x41, 0xf0, 0x26, 0xc9, 0x07, 0xe4,
-	0x28, 0x3e, 0x8f, 0x8d, 0xff, 0xe5, 0xa3, 0x1c, 0x3c, 0xa6, 0x7c, 0x1c, 0x85, 0x9c, 0x1a, 0xb7,
-	0x4f, 0xd7, 0x6e, 0x04, 0xb5, 0xb4, 0x11, 0x1d, 0x41, 0xcf, 0xa1, 0x22, 0x4d, 0xd9, 0x4f, 0x94,
-	0x2b, 0x4c, 0x13, 0x0f, 0xee, 0xf6, 0x0e, 0x06, 0x99, 0x62, 0x4e, 0xc3, 0xe7, 0x50, 0xf6, 0x2f,
-	0xc8, 0x68, 0x44, 0xc3, 0x21, 0x35, 0x6d, 0xcc, 0x02, 0xee, 0x7f, 0x16, 0xd4, 0x7f, 0xa4, 0xc2,
-	0xbf, 0x98, 0x1a, 0xc3, 0x28, 0xf0, 0x04, 0x36, 0xcf, 0xda, 0x5d, 0xdc, 0x6e, 0x35, 0x5f, 0xb5,
-	0xcf, 0xfa, 0x3e, 0x8f, 0xb9, 0x7a, 0x4b, 0x55, 0x5c, 0x9b, 0x85, 0x5b, 0x3c, 0xe6, 0xe8, 0x14,
-	0xd6, 0xd5, 0xae, 0x3e, 0x1c, 0xde, 0x7c, 0x67, 0x8b, 0xc8, 0x3d, 0x99, 0xa8, 0x8d, 0xad, 0x72,
-	0x9d, 0x6f, 0xa0, 0x3c, 0x0d, 0x2d, 0xb0, 0x5f, 0x3d, 0x6b, 0xbf, 0x6a, 0xd6, 0x68, 0x6f, 0xe1,
-	0x93, 0xb9, 0x02, 0x1f, 0x49, 0x36, 0xf7, 0x07, 0xd8, 0x56, 0xcc, 0xc6, 0x75, 0xa9, 0x2c, 0x8f,
-	0xc1, 0xbe, 0xe4, 0xb1, 0xe1, 0xdb, 0x9e, 0xe7, 0x3b, 0xef, 0x61, 0x2c, 0xf7, 0xdd, 0x96, 0x51,
-	0x75, 0x9a, 0x6d, 0xda, 0x7a, 0x0a, 0xeb, 0xb2, 0x86, 0xc9, 0xbf, 0x7f, 0x2b, 0xdf, 0xc0, 0x15,
-	0xc8, 0x3d, 0x80, 0x4f, 0xa7, 0xc3, 0xb5, 0x9a, 0xd9, 0x2e, 0x8c, 0xa9, 0xac, 0xa9, 0xa9, 0xdc,
-	0x04, 0xee, 0xdf, 0xc2, 0x9a, 0x9a, 0x87, 0xb9, 0x9a, 0xcb, 0x6f, 0x04, 0x85, 0x42, 0x87, 0x50,
-	0xd4, 0x77, 0xcd, 0x9d, 0x67, 0xdd, 0x60, 0xdc, 0x9f, 0x60, 0xa7, 0x9b, 0x70, 0x39, 0x66, 0x87,
-	0x4e, 0x5e, 0x8f, 0xb9, 0x88, 0x29, 0xb9, 0x4e, 0xbb, 0x3c, 0x86, 0x8d, 0xcb, 0x1b, 0xd1, 0x4f,
-	0x5f, 0xe6, 0x6c, 0x5e, 0xc3, 0xd5, 0x4d, 0x06, 0x23, 0xe6, 0x77, 0xe8, 0x04, 0x17, 0x2f, 0x6f,
-	0x44, 0x87, 0x4e, 0xdc, 0x3e, 0x38, 0x8b, 0xe8, 0xcc, 0x20, 0x4d, 0xd8, 0x92, 0x7c, 0x9c, 0x0d,
-	0x43, 0x16, 0x0e, 0x25, 0x6f, 0x7a, 0xc5, 0x2f, 0x25, 0xae, 0x5d, 0xde, 0x88, 0x9e, 0xc6, 0x77,
-	0xe8, 0x84, 0xbb, 0x75, 0x40, 0x4a, 0x26, 0x33, 0x86, 0x6e, 0xd4, 0x6d, 0x99, 0x77, 0x9d, 0x46,
-	0xa7, 0xc2, 0xa5, 0x52, 0x58, 0xab, 0xa5, 0x38, 0xf9, 0x77, 0x1d, 0xd6, 0x7f, 0x8e, 0x02, 0x8a,
-	0x3a, 0x50, 0xd4, 0x67, 0x18, 0xed, 0xce, 0x6b, 0x9d, 0xbb, 0x64, 0x9c, 0x07, 0xcb, 0xb6, 0x75,
-	0xfd, 0x7d, 0xeb, 0xd8, 0x42, 0x7f, 0xc0, 0xbd, 0x9c, 0xc1, 0xd1, 0xa3, 0x0f, 0x39, 0x60, 0xce,
-	0xe3, 0x15, 0xa8, 0x4c, 0x85, 0x5f, 0xa1, 0x9a, 0xb5, 0x2a, 0x7a, 0xb8, 0x30, 0x35, 0x7f, 0x0c,
-	0x9c, 0x47, 0x77, 0x83, 0x8c, 0x80, 0x03, 0xd8, 0x9c, 0x33, 0x25, 0xfa, 0x72, 0x69, 0x63, 0x39,
-	0x87, 0x3b, 0x4f, 0x56, 0xe2, 0x4c, 0x8d, 0x2b, 0x40, 0xb7, 0x2d, 0x83, 0x6e, 0xfd, 0xe7, 0x2f,
-	0x75, 0xa9, 0x73, 0xf0, 0x21, 0x50, 0x53, 0xec, 0x0d, 0x54, 0x32, 0x46, 0x41, 0xee, 0xc2, 0x26,
-	0x73, 0xde, 0x72, 0x1e, 0xde, 0x89, 0xd1, 0xbc, 0xa7, 0xde, 0x6f, 0x87, 0x43, 0x26, 0x2e, 0x92,
-	0x81, 0xf4, 0xd6, 0x91, 0xfe, 0x8b, 0x3c, 0xd2, 0x9f, 0x5c, 0xea, 0x23, 0xcb, 0x3c, 0x93, 0x31,
-	0x3b, 0x92, 0x1c, 0x83, 0xa2, 0x8a, 0x3e, 0xfb, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x22, 0xfc, 0x6f,
-	0xa7, 0xb3, 0x09, 0x00, 0x00,
+	// 1201 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x53, 0xdb, 0x46,
+	0x10, 0x1e, 0x61, 0x30, 0xf6, 0xda, 0x31, 0xcc, 0xe1, 0x24, 0x46, 0xbc, 0x84, 0x39, 0x92, 0x86,
+	0x02, 0x35, 0x8c, 0xd3, 0x4e, 0x5f, 0x26, 0x33, 0x19, 0x63, 0xc8, 0x24, 0x30, 0x6d, 0xdd, 0x73,
+	0x42, 0xd3, 0xf6, 0x83, 0x2b, 0xa4, 0xc3, 0x08, 0x8c, 0xe4, 0xe8, 0x4e, 0x50, 0x7f, 0xee, 0x3f,
+	0xe8, 0xef, 0xe8, 0x9f, 0xea, 0xb7, 0xfe, 0x8c, 0xce, 0xbd, 0xc8, 0xb2, 0x6c, 0x19, 0xdc, 0x99,
+	0xf6, 0x13, 0x77, 0x7b, 0xcf, 0xee, 0x3e, 0xbb, 0xda, 0x7b, 0x0e, 0x03, 0x78, 0xbe, 0x43, 0xab,
+	0xbd, 0xc0, 0xe7, 0x3e, 0x2a, 0xb1, 0x9e, 0x1b, 0xd0, 0xaa, 0xd5, 0x73, 0xab, 0xc2, 0x6a, 0x2e,
+	0xcb, 0xfd, 0x9e, 0xed, 0x5f, 0x5f, 0xfb, 0x9e, 0xfe, 0xa3, 0xa0, 0xf8, 0x05, 0x64, 0x0f, 0x42,
+	0xcf, 0xe9, 0x52, 0x54, 0x82, 0x19, 0xd7, 0xa9, 0x18, 0x1b, 0xc6, 0x56, 0x9e, 0xcc, 0xb8, 0x0e,
+	0x5a, 0x86, 0x9c, 0x6d, 0xb5, 0x6d, 0x1a, 0x70, 0x56, 0x99, 0xd9, 0x30, 0xb6, 0x8a, 0x64, 0xde,
+	0xb6, 0x1a, 0x62, 0x8b, 0xdf, 0x40, 0xee, 0xc3, 0x17, 0xfb, 0x5f, 0xb7, 0x4e, 0xdf, 0x1e, 0xa2,
+	0x35, 0x00, 0x81, 0x69, 0xdb, 0x17, 0x96, 0xeb, 0x55, 0x32, 0x12, 0x98, 0x17, 0x96, 0x86, 0x30,
+	0x88, 0x63, 0xfa, 0x9b, 0xc8, 0xce, 0xda, 0x16, 0x97, 0x71, 0x32, 0x24, 0xaf, 0x2d, 0x75, 0x8e,
+	0xff, 0xc8, 0x40, 0x29, 0x0a, 0xf5, 0xbe, 0xe7, 0x58, 0x9c, 0xa2, 0x57, 0x30, 0xc7, 0x6e, 0x5c,
+	0x87, 0x55, 0x8c, 0x8d, 0xcc, 0x56, 0xa1, 0xf6, 0x69, 0x35, 0x59, 0x4c, 0x35, 0x09, 0xaf, 0xb6,
+	0x04, 0xf6, 0xc8, 0xe3, 0x41, 0x9f, 0x28, 0x3f, 0x44, 0xa0, 0x1c, 0xd0, 0x8e, 0xcb, 0x78, 0x60,
+	0x71, 0xd7, 0xf7, 0xda, 0xd4, 0xe3, 0x81, 0x4b, 0x59, 0x25, 0x23, 0xe3, 0x3d, 0xd1, 0xf1, 0x74,
+	0x17, 0xc8, 0x10, 0x52, 0x45, 0x59, 0x0a, 0x46, 0x4c, 0x2e, 0x65, 0xe8, 0x08, 0xe6, 0xcf, 0x64,
+	0x9b, 0x58, 0x65, 0x4e, 0x86, 0xd9, 0xb9, 0x87, 0x96, 0x6a, 0xaa, 0x26, 0x16, 0xf9, 0x9a, 0x04,
+	0x20, 0xe6, 0x8b, 0x16, 0x21, 0x73, 0x45, 0xfb, 0xba, 0xe5, 0x62, 0x89, 0xaa, 0x30, 0x77, 0x63,
+	0x75, 0x43, 0x2a, 0x1b, 0x55, 0xa8, 0x55, 0x26, 0x25, 0x21, 0x0a, 0xf6, 0xcd, 0xcc, 0x57, 0x86,
+	0xd9, 0x84, 0xe2, 0x70, 0xb2, 0x94, 0xa8, 0xdb, 0xc9, 0xa8, 0xe5, 0x64, 0x07, 0x94, 0xf3, 0x50,
+	0x44, 0xdc, 0x84, 0xcc, 0x71, 0x8b, 0xa0, 0x15, 0xc8, 0xb3, 0x9e, 0x7b, 0x7e, 0x4e, 0xdb, 0x83,
+	0xb9, 0xc8, 0x29, 0xc3, 0x5b, 0x07, 0x99, 0x90, 0xb3, 0x42, 0xc7, 0xa5, 0x9e, 0x2d, 0xc2, 0x66,
+	0xc4, 0x59, 0xb4, 0x17, 0x0c, 0x38, 0xef, 0xca, 0x59, 0x98, 0x23, 0x62, 0x89, 0x7f, 0x81, 0xf9,
+	0xe3, 0x1f, 0xdf, 0xc9, 0x79, 0x29, 0xc3, 0x1c, 0xf7, 0xaf, 0xa8, 0xa7, 0x23, 0xaa, 0xcd, 0x3d,
+	0x63, 0x22, 0xa8, 0xb8, 0x8c, 0x85, 0xd4, 0x11, 0xa7, 0x19, 0x79, 0x9a, 0x53, 0x86, 0x3a, 0xc7,
+	0x7f, 0x1a, 0xf0, 0xa0, 0xce, 0x39, 0x65, 0x9c, 0xd0, 0x8f, 0x21, 0x65, 0x1c, 0xbd, 0x81, 0x45,
+	0x4b, 0x1a, 0xd4, 0x00, 0x38, 0x16, 0xb7, 0x64, 0xba, 0x42, 0x6d, 0x2d, 0x59, 0x7b, 0x3d, 0x46,
+	0x1d, 0x5a, 0xdc, 0x22, 0x0b, 0x56, 0xd2, 0x20, 0x4a, 0xb1, 0x59, 0xa0, 0xe7, 0x5f, 0x2c, 0x45,
+	0xe1, 0x01, 0x65, 0x3d, 0xdf, 0x63, 0x54, 0x4f, 0xfb, 0x60, 0x8f, 0x36, 0xe1, 0x81, 0xd5, 0xa1,
+	0x1e, 0x6f, 0xdf, 0xd0, 0x80, 0xb9, 0xbe, 0x57, 0x99, 0x95, 0x35, 0x16, 0xa5, 0xf1, 0x54, 0xd9,
+	0xb0, 0x0f, 0xa5, 0x88, 0xad, 0x76, 0x7b, 0x05, 0x05, 0x31, 0xb9, 0xed, 0x50, 0x8e, 0x8e, 0x66,
+	0xba, 0x7e, 0xf7, 0x80, 0x11, 0x10, 0x2e, 0x6a, 0x8d, 0x56, 0x21, 0x6f, 0x5f, 0x58, 0xdd, 0x2e,
+	0xf5, 0x3a, 0x54, 0x73, 0x8d, 0x0d, 0xf8, 0x2f, 0x03, 0xca, 0xaf, 0x29, 0xb7, 0x2f, 0x06, 0xd3,
+	0xa3, 0xdb, 0xf4, 0x1c, 0x16, 0x0e, 0x8f, 0x9a, 0xe4, 0xa8, 0x51, 0x7f, 0x77, 0x74, 0xd8, 0xb6,
+	0x59, 0xc0, 0xe4, 0xa7, 0x2c, 0x92, 0x52, 0x6c, 0x6e, 0xb0, 0x80, 0xa1, 0x03, 0x98, 0x95, 0xa7,
+	0xea, 0x06, 0x55, 0x47, 0x99, 0xa5, 0x05, 0xaf, 0x0a, 0x47, 0x35, 0xfd, 0xd2, 0x77, 0xaa, 0xde,
+	0x98, 0x5f, 0x42, 0x7e, 0xe0, 0x97, 0x32, 0xc8, 0xe5, 0xe1, 0x41, 0x2e, 0x0e, 0x8f, 0xec, 0x07,
+	0x78, 0x38, 0xc2, 0xe2, 0x3f, 0xea, 0x2d, 0x7e, 0x09, 0x4b, 0x32, 0xb2, 0x9e, 0xdf, 0xa8, 0x77,
+	0xcf, 0x20, 0x73, 0xc9, 0x02, 0x1d, 0x6f, 0x69, 0x34, 0xde, 0x71, 0x8b, 0x10, 0x71, 0x8e, 0x1b,
+	0x50, 0x4e, 0x7a, 0x6b, 0x5a, 0x3b, 0x30, 0x2b, 0x72, 0x68, 0xff, 0xc7, 0x63, 0xfe, 0x1a, 0x2e,
+	0x41, 0x78, 0x1b, 0x1e, 0x0d, 0x8a, 0x6b, 0xd4, 0x87, 0x59, 0xe8, 0xf1, 0x34, 0x06, 0xe3, 0x89,
+	0x43, 0x78, 0x3c, 0x86, 0xd5, 0x39, 0x77, 0x13, 0x39, 0x27, 0x6b, 0x8b, 0x44, 0xa1, 0x5d, 0xc8,
+	0x2a, 0xd5, 0xba, 0x53, 0x35, 0x34, 0x06, 0x7f, 0x0b, 0xcb, 0xcd, 0x90, 0x89, 0x32, 0x4f, 0x68,
+	0xff, 0x7d, 0x8f, 0xf1, 0x80, 0x5a, 0xd7, 0x11, 0xcb, 0x7d, 0x98, 0xbf, 0xbc, 0xe5, 0xed, 0xe8,
+	0x63, 0xc6, 0xf5, 0xea, 0x58, 0xcd, 0xf0, 0xac, 0xeb, 0xda, 0x27, 0xb4, 0x4f, 0xb2, 0x97, 0xb7,
+	0xfc, 0x84, 0xf6, 0x71, 0x1b, 0xcc, 0xb4, 0x70, 0xba, 0x90, 0x3a, 0x2c, 0x8a, 0x78, 0xcc, 0xed,
+	0x78, 0xae, 0xd7, 0x11, 0x71, 0xa3, 0xc7, 0x62, 0x62, 0xe0, 0xd2, 0xe5, 0x2d, 0x6f, 0x29, 0xfc,
+	0x09, 0xed, 0x33, 0xfc, 0x12, 0x56, 0x64, 0x9b, 0x0e, 0x69, 0x97, 0x76, 0x2c, 0x4e, 0x1d, 0x95,
+	0x2a, 0x62, 0xbc, 0x06, 0xd0, 0x93, 0xbe, 0x03, 0xd2, 0x45, 0x92, 0xef, 0x45, 0xd1, 0x70, 0x13,
+	0x56, 0xd3, 0xbd, 0x35, 0xc1, 0x7f, 0x5f, 0xf0, 0xef, 0x06, 0xac, 0x10, 0xda, 0xf3, 0x03, 0x5e,
+	0x17, 0xf7, 0x81, 0x8d, 0xb6, 0xb0, 0x06, 0x59, 0x79, 0x51, 0xa2, 0x42, 0xcd, 0x34, 0x1d, 0xa3,
+	0xce, 0x77, 0xbe, 0x43, 0x89, 0x46, 0x4a, 0x55, 0xf0, 0x3d, 0xee, 0x7a, 0x21, 0x75, 0xe4, 0x47,
+	0xcc, 0x91, 0xd8, 0x80, 0x10, 0xcc, 0x5e, 0xfb, 0x81, 0xd2, 0xb0, 0x1c, 0x91, 0x6b, 0xbc, 0x0e,
+	0xab, 0xe9, 0x24, 0x54, 0x5d, 0x78, 0x5d, 0xd7, 0xfd, 0x9a, 0x3a, 0x34, 0x10, 0x75, 0xeb, 0x87,
+	0x47, 0xb3, 0xc4, 0xdf, 0xc3, 0xda, 0x84, 0x73, 0xdd, 0x98, 0x6a, 0xfc, 0x8c, 0xaa, 0x3a, 0xd2,
+	0xa7, 0x2a, 0x02, 0xe1, 0x32, 0x20, 0x19, 0x50, 0xdb, 0x75, 0x9a, 0x06, 0x2c, 0x25, 0xac, 0x83,
+	0xf9, 0x8e, 0x26, 0xd6, 0x98, 0x62, 0x62, 0x6f, 0x61, 0x81, 0x50, 0xeb, 0x7f, 0x7a, 0x36, 0x86,
+	0x1f, 0x89, 0x99, 0xe4, 0x23, 0x81, 0xcf, 0x61, 0x31, 0x4e, 0xac, 0xa9, 0x27, 0x04, 0xdc, 0x18,
+	0x11, 0x70, 0xf4, 0x39, 0xe4, 0x19, 0xed, 0x52, 0x9b, 0xfb, 0x5a, 0xa1, 0x0b, 0xb5, 0x47, 0x49,
+	0x42, 0x2d, 0x7d, 0x4c, 0x62, 0x60, 0xed, 0xef, 0x79, 0x98, 0x15, 0xf3, 0x80, 0x4e, 0x20, 0xab,
+	0x08, 0xa3, 0xb5, 0xd1, 0x3b, 0x9f, 0x78, 0x36, 0xcd, 0xf5, 0x49, 0xc7, 0x8a, 0xe5, 0x96, 0xb1,
+	0x6f, 0xa0, 0x5f, 0xe1, 0x41, 0x42, 0x68, 0xd1, 0xd3, 0x69, 0x5e, 0x03, 0xf3, 0xd9, 0x3d, 0xa8,
+	0xa1, 0x0c, 0x3f, 0x41, 0x71, 0x58, 0x32, 0xd1, 0x66, 0xaa, 0x6b, 0x52, 0x8e, 0xcd, 0xa7, 0x77,
+	0x83, 0x74, 0x9b, 0xcf, 0x60, 0x61, 0x44, 0x1c, 0xd1, 0x27, 0x13, 0x89, 0x25, 0x94, 0xd6, 0x7c,
+	0x7e, 0x2f, 0x4e, 0xe7, 0xb8, 0x02, 0x34, 0x2e, 0x5d, 0x68, 0xec, 0xbf, 0xd8, 0x89, 0x6a, 0x69,
+	0x6e, 0x4f, 0x03, 0xd5, 0xc9, 0x3e, 0x42, 0x39, 0x4d, 0x88, 0xd0, 0x4e, 0x2a, 0xdb, 0x74, 0xb1,
+	0x33, 0x77, 0xa7, 0x03, 0xc7, 0x29, 0xd3, 0x34, 0x62, 0x3c, 0xe5, 0x1d, 0x72, 0x66, 0xee, 0x4e,
+	0x07, 0xd6, 0x29, 0x39, 0x3c, 0x4c, 0x95, 0x15, 0x94, 0xce, 0x7c, 0x82, 0x3a, 0x99, 0x9f, 0x4d,
+	0x89, 0xd6, 0x59, 0x4f, 0xa1, 0x30, 0xa4, 0x32, 0x08, 0xa7, 0x7a, 0x27, 0x84, 0xc9, 0xdc, 0xbc,
+	0x13, 0xa3, 0xe3, 0xfe, 0x00, 0xb9, 0xe8, 0xfe, 0xa3, 0x27, 0xe3, 0x7d, 0x48, 0x48, 0x92, 0xb9,
+	0x31, 0x19, 0x10, 0x5f, 0x99, 0x83, 0xea, 0xcf, 0xbb, 0x1d, 0x97, 0x5f, 0x84, 0x67, 0x42, 0x0f,
+	0xf6, 0xd4, 0xff, 0xe8, 0x7b, 0xea, 0x37, 0x9f, 0xfc, 0x95, 0xa7, 0xd7, 0x56, 0xcf, 0xdd, 0x13,
+	0x41, 0xce, 0xb2, 0xd2, 0xfa, 0xe2, 0x9f, 0x01, 0x00, 0x7c, 0x17, 0xe3, 0x5b, 0x34, 0x0e, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -947,8 +1334,25 @@ type NodeClient interface {
 	// is the root server, in which case it stores the JWK in its bundle. Returns an
 	// up-to-date list of the JWT signing keys stored in the bundle.
 	PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error)
+	// FetchDelegatedJWTKey publishes an agent generated public key in the
+	// trust bundle so the agent can sign JWT-SVIDs locally for the
+	// registration entries it is authorized for.
+	FetchDelegatedJWTKey(ctx context.Context, in *FetchDelegatedJWTKeyRequest, opts ...grpc.CallOption) (*FetchDelegatedJWTKeyResponse, error)
+	// ReportAgentsUpstream reports the agents attested by a downstream SPIRE
+	// server. The upstream SPIRE server keeps the latest report of each
+	// downstream server as part of its agent inventory.
+	ReportAgentsUpstream(ctx context.Context, in *ReportAgentsUpstreamRequest, opts ...grpc.CallOption) (*ReportAgentsUpstreamResponse, error)
+	// FetchFederatedBundles fetches the bundles of the trust domains federated
+	// with the upstream SPIRE server so they can be pushed down to downstream
+	// SPIRE servers.
+	FetchFederatedBundles(ctx context.Context, in *FetchFederatedBundlesRequest, opts ...grpc.CallOption) (*FetchFederatedBundlesResponse, error)
 	// FetchBundle fetches the bundle of the local trust domain
 	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	// Reattest re-runs node attestation for an attested agent over an
+	// authenticated channel and replaces its node selectors with the ones
+	// resolved from the fresh attestation data. The agent ID and SVID are
+	// left untouched.
+	Reattest(ctx context.Context, opts ...grpc.CallOption) (Node_ReattestClient, error)
 }
 
 type nodeClient struct {
@@ -1048,6 +1452,33 @@ func (c *nodeClient) PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstr
 	return out, nil
 }
 
+func (c *nodeClient) FetchDelegatedJWTKey(ctx context.Context, in *FetchDelegatedJWTKeyRequest, opts ...grpc.CallOption) (*FetchDelegatedJWTKeyResponse, error) {
+	out := new(FetchDelegatedJWTKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.node.Node/FetchDelegatedJWTKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) ReportAgentsUpstream(ctx context.Context, in *ReportAgentsUpstreamRequest, opts ...grpc.CallOption) (*ReportAgentsUpstreamResponse, error) {
+	out := new(ReportAgentsUpstreamResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.node.Node/ReportAgentsUpstream", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) FetchFederatedBundles(ctx context.Context, in *FetchFederatedBundlesRequest, opts ...grpc.CallOption) (*FetchFederatedBundlesResponse, error) {
+	out := new(FetchFederatedBundlesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.node.Node/FetchFederatedBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error) {
 	out := new(FetchBundleResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.node.Node/FetchBundle", in, out, opts...)
@@ -1057,6 +1488,37 @@ func (c *nodeClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, op
 	return out, nil
 }
 
+func (c *nodeClient) Reattest(ctx context.Context, opts ...grpc.CallOption) (Node_ReattestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[2], "/spire.api.node.Node/Reattest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeReattestClient{stream}
+	return x, nil
+}
+
+type Node_ReattestClient interface {
+	Send(*ReattestRequest) error
+	Recv() (*ReattestResponse, error)
+	grpc.ClientStream
+}
+
+type nodeReattestClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeReattestClient) Send(m *ReattestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nodeReattestClient) Recv() (*ReattestResponse, error) {
+	m := new(ReattestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // NodeServer is the server API for Node service.
 type NodeServer interface {
 	// Attest the node, get base node SVID.
@@ -1073,8 +1535,25 @@ type NodeServer interface {
 	// is the root server, in which case it stores the JWK in its bundle. Returns an
 	// up-to-date list of the JWT signing keys stored in the bundle.
 	PushJWTKeyUpstream(context.Context, *PushJWTKeyUpstreamRequest) (*PushJWTKeyUpstreamResponse, error)
+	// FetchDelegatedJWTKey publishes an agent generated public key in the
+	// trust bundle so the agent can sign JWT-SVIDs locally for the
+	// registration entries it is authorized for.
+	FetchDelegatedJWTKey(context.Context, *FetchDelegatedJWTKeyRequest) (*FetchDelegatedJWTKeyResponse, error)
+	// ReportAgentsUpstream reports the agents attested by a downstream SPIRE
+	// server. The upstream SPIRE server keeps the latest report of each
+	// downstream server as part of its agent inventory.
+	ReportAgentsUpstream(context.Context, *ReportAgentsUpstreamRequest) (*ReportAgentsUpstreamResponse, error)
+	// FetchFederatedBundles fetches the bundles of the trust domains federated
+	// with the upstream SPIRE server so they can be pushed down to downstream
+	// SPIRE servers.
+	FetchFederatedBundles(context.Context, *FetchFederatedBundlesRequest) (*FetchFederatedBundlesResponse, error)
 	// FetchBundle fetches the bundle of the local trust domain
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	// Reattest re-runs node attestation for an attested agent over an
+	// authenticated channel and replaces its node selectors with the ones
+	// resolved from the fresh attestation data. The agent ID and SVID are
+	// left untouched.
+	Reattest(Node_ReattestServer) error
 }
 
 // UnimplementedNodeServer can be embedded to have forward compatible implementations.
@@ -1096,9 +1575,21 @@ func (*UnimplementedNodeServer) FetchX509CASVID(ctx context.Context, req *FetchX
 func (*UnimplementedNodeServer) PushJWTKeyUpstream(ctx context.Context, req *PushJWTKeyUpstreamRequest) (*PushJWTKeyUpstreamResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PushJWTKeyUpstream not implemented")
 }
+func (*UnimplementedNodeServer) FetchDelegatedJWTKey(ctx context.Context, req *FetchDelegatedJWTKeyRequest) (*FetchDelegatedJWTKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchDelegatedJWTKey not implemented")
+}
+func (*UnimplementedNodeServer) ReportAgentsUpstream(ctx context.Context, req *ReportAgentsUpstreamRequest) (*ReportAgentsUpstreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportAgentsUpstream not implemented")
+}
+func (*UnimplementedNodeServer) FetchFederatedBundles(ctx context.Context, req *FetchFederatedBundlesRequest) (*FetchFederatedBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchFederatedBundles not implemented")
+}
 func (*UnimplementedNodeServer) FetchBundle(ctx context.Context, req *FetchBundleRequest) (*FetchBundleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FetchBundle not implemented")
 }
+func (*UnimplementedNodeServer) Reattest(srv Node_ReattestServer) error {
+	return status.Errorf(codes.Unimplemented, "method Reattest not implemented")
+}
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
 	s.RegisterService(&_Node_serviceDesc, srv)
@@ -1210,6 +1701,60 @@ func _Node_PushJWTKeyUpstream_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_FetchDelegatedJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchDelegatedJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).FetchDelegatedJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/FetchDelegatedJWTKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).FetchDelegatedJWTKey(ctx, req.(*FetchDelegatedJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_ReportAgentsUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportAgentsUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).ReportAgentsUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/ReportAgentsUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ReportAgentsUpstream(ctx, req.(*ReportAgentsUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_FetchFederatedBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchFederatedBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).FetchFederatedBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/FetchFederatedBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).FetchFederatedBundles(ctx, req.(*FetchFederatedBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Node_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FetchBundleRequest)
 	if err := dec(in); err != nil {
@@ -1228,6 +1773,32 @@ func _Node_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_Reattest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeServer).Reattest(&nodeReattestServer{stream})
+}
+
+type Node_ReattestServer interface {
+	Send(*ReattestResponse) error
+	Recv() (*ReattestRequest, error)
+	grpc.ServerStream
+}
+
+type nodeReattestServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeReattestServer) Send(m *ReattestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *nodeReattestServer) Recv() (*ReattestRequest, error) {
+	m := new(ReattestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.node.Node",
 	HandlerType: (*NodeServer)(nil),
@@ -1244,6 +1815,18 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PushJWTKeyUpstream",
 			Handler:    _Node_PushJWTKeyUpstream_Handler,
 		},
+		{
+			MethodName: "FetchDelegatedJWTKey",
+			Handler:    _Node_FetchDelegatedJWTKey_Handler,
+		},
+		{
+			MethodName: "ReportAgentsUpstream",
+			Handler:    _Node_ReportAgentsUpstream_Handler,
+		},
+		{
+			MethodName: "FetchFederatedBundles",
+			Handler:    _Node_FetchFederatedBundles_Handler,
+		},
 		{
 			MethodName: "FetchBundle",
 			Handler:    _Node_FetchBundle_Handler,
@@ -1262,6 +1845,12 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Reattest",
+			Handler:       _Node_Reattest_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "node.proto",
 }
@@ -55,7 +55,7 @@ func (x DeleteFederatedBundleRequest_Mode) String() string {
 }
 
 func (DeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{10, 0}
+	return fileDescriptor_199f7aef77c18626, []int{17, 0}
 }
 
 // A type that represents the id of an entry.
@@ -411,10 +411,14 @@ func (m *ListAllEntriesResponse) GetPagination() *Pagination {
 // A CA bundle for a different Trust Domain than the one used and managed by the Server.
 type FederatedBundle struct {
 	// Common bundle format
-	Bundle               *common.Bundle `protobuf:"bytes,3,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Bundle *common.Bundle `protobuf:"bytes,3,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// On update, stores the bundle with its own sequence number instead of
+	// one greater than that of the stored bundle. Used to recover when the
+	// sequence number of a federated trust domain has been reset.
+	ResetSequenceNumber  bool     `protobuf:"varint,4,opt,name=reset_sequence_number,json=resetSequenceNumber,proto3" json:"reset_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
@@ -449,6 +453,13 @@ func (m *FederatedBundle) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (m *FederatedBundle) GetResetSequenceNumber() bool {
+	if m != nil {
+		return m.ResetSequenceNumber
+	}
+	return false
+}
+
 // A type that represents a federated bundle id.
 type FederatedBundleID struct {
 	// SPIFFE ID of the federated bundle
@@ -490,6 +501,349 @@ func (m *FederatedBundleID) GetId() string {
 	return ""
 }
 
+// Represents a ListFederatedBundleSyncStatus request
+type ListFederatedBundleSyncStatusRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederatedBundleSyncStatusRequest) Reset()         { *m = ListFederatedBundleSyncStatusRequest{} }
+func (m *ListFederatedBundleSyncStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederatedBundleSyncStatusRequest) ProtoMessage()    {}
+func (*ListFederatedBundleSyncStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{10}
+}
+
+func (m *ListFederatedBundleSyncStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusRequest.Unmarshal(m, b)
+}
+func (m *ListFederatedBundleSyncStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederatedBundleSyncStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederatedBundleSyncStatusRequest.Merge(m, src)
+}
+func (m *ListFederatedBundleSyncStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusRequest.Size(m)
+}
+func (m *ListFederatedBundleSyncStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederatedBundleSyncStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederatedBundleSyncStatusRequest proto.InternalMessageInfo
+
+// Synchronization status of a federated bundle fetched from a bundle endpoint
+type FederatedBundleSyncStatus struct {
+	// SPIFFE ID of the federated trust domain
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Address of the bundle endpoint
+	EndpointAddress string `protobuf:"bytes,2,opt,name=endpoint_address,json=endpointAddress,proto3" json:"endpoint_address,omitempty"`
+	// Time of the last successful synchronization, in seconds since the Unix epoch
+	LastSuccess int64 `protobuf:"varint,3,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	// Error returned by the last failed synchronization, if any
+	LastError string `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	// Time of the last failed synchronization, in seconds since the Unix epoch
+	LastErrorAt int64 `protobuf:"varint,5,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	// Time of the next scheduled synchronization, in seconds since the Unix epoch
+	NextRefresh int64 `protobuf:"varint,6,opt,name=next_refresh,json=nextRefresh,proto3" json:"next_refresh,omitempty"`
+	// Sequence number of the last bundle received from the endpoint
+	SequenceNumber       uint64   `protobuf:"varint,7,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederatedBundleSyncStatus) Reset()         { *m = FederatedBundleSyncStatus{} }
+func (m *FederatedBundleSyncStatus) String() string { return proto.CompactTextString(m) }
+func (*FederatedBundleSyncStatus) ProtoMessage()    {}
+func (*FederatedBundleSyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{11}
+}
+
+func (m *FederatedBundleSyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederatedBundleSyncStatus.Unmarshal(m, b)
+}
+func (m *FederatedBundleSyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederatedBundleSyncStatus.Marshal(b, m, deterministic)
+}
+func (m *FederatedBundleSyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedBundleSyncStatus.Merge(m, src)
+}
+func (m *FederatedBundleSyncStatus) XXX_Size() int {
+	return xxx_messageInfo_FederatedBundleSyncStatus.Size(m)
+}
+func (m *FederatedBundleSyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedBundleSyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedBundleSyncStatus proto.InternalMessageInfo
+
+func (m *FederatedBundleSyncStatus) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *FederatedBundleSyncStatus) GetEndpointAddress() string {
+	if m != nil {
+		return m.EndpointAddress
+	}
+	return ""
+}
+
+func (m *FederatedBundleSyncStatus) GetLastSuccess() int64 {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return 0
+}
+
+func (m *FederatedBundleSyncStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *FederatedBundleSyncStatus) GetLastErrorAt() int64 {
+	if m != nil {
+		return m.LastErrorAt
+	}
+	return 0
+}
+
+func (m *FederatedBundleSyncStatus) GetNextRefresh() int64 {
+	if m != nil {
+		return m.NextRefresh
+	}
+	return 0
+}
+
+func (m *FederatedBundleSyncStatus) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+// Represents a ListFederatedBundleSyncStatus response
+type ListFederatedBundleSyncStatusResponse struct {
+	// Synchronization status of each federated trust domain
+	Statuses             []*FederatedBundleSyncStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ListFederatedBundleSyncStatusResponse) Reset()         { *m = ListFederatedBundleSyncStatusResponse{} }
+func (m *ListFederatedBundleSyncStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederatedBundleSyncStatusResponse) ProtoMessage()    {}
+func (*ListFederatedBundleSyncStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{12}
+}
+
+func (m *ListFederatedBundleSyncStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusResponse.Unmarshal(m, b)
+}
+func (m *ListFederatedBundleSyncStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederatedBundleSyncStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederatedBundleSyncStatusResponse.Merge(m, src)
+}
+func (m *ListFederatedBundleSyncStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederatedBundleSyncStatusResponse.Size(m)
+}
+func (m *ListFederatedBundleSyncStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederatedBundleSyncStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederatedBundleSyncStatusResponse proto.InternalMessageInfo
+
+func (m *ListFederatedBundleSyncStatusResponse) GetStatuses() []*FederatedBundleSyncStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+// A type that represents a federation relationship id.
+type FederationRelationshipID struct {
+	// SPIFFE ID of the federated trust domain
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederationRelationshipID) Reset()         { *m = FederationRelationshipID{} }
+func (m *FederationRelationshipID) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationshipID) ProtoMessage()    {}
+func (*FederationRelationshipID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{13}
+}
+
+func (m *FederationRelationshipID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationshipID.Unmarshal(m, b)
+}
+func (m *FederationRelationshipID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationshipID.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationshipID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationshipID.Merge(m, src)
+}
+func (m *FederationRelationshipID) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationshipID.Size(m)
+}
+func (m *FederationRelationshipID) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationshipID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationRelationshipID proto.InternalMessageInfo
+
+func (m *FederationRelationshipID) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+// Represents a DeleteFederationRelationship request
+type DeleteFederationRelationshipRequest struct {
+	// SPIFFE ID of the federated trust domain
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// If true, the federated bundle is deleted along with the relationship.
+	// Otherwise, it is kept and no longer updated.
+	DeleteBundle bool `protobuf:"varint,2,opt,name=delete_bundle,json=deleteBundle,proto3" json:"delete_bundle,omitempty"`
+	// Controls how registration entries federated with the bundle are
+	// handled when the bundle is deleted.
+	BundleMode           DeleteFederatedBundleRequest_Mode `protobuf:"varint,3,opt,name=bundle_mode,json=bundleMode,proto3,enum=spire.api.registration.DeleteFederatedBundleRequest_Mode" json:"bundle_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipRequest) Reset()         { *m = DeleteFederationRelationshipRequest{} }
+func (m *DeleteFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipRequest) ProtoMessage()    {}
+func (*DeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{14}
+}
+
+func (m *DeleteFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Size(m)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *DeleteFederationRelationshipRequest) GetDeleteBundle() bool {
+	if m != nil {
+		return m.DeleteBundle
+	}
+	return false
+}
+
+func (m *DeleteFederationRelationshipRequest) GetBundleMode() DeleteFederatedBundleRequest_Mode {
+	if m != nil {
+		return m.BundleMode
+	}
+	return DeleteFederatedBundleRequest_RESTRICT
+}
+
+// Represents a ListFederationRelationships request
+type ListFederationRelationshipsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederationRelationshipsRequest) Reset()         { *m = ListFederationRelationshipsRequest{} }
+func (m *ListFederationRelationshipsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsRequest) ProtoMessage()    {}
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{15}
+}
+
+func (m *ListFederationRelationshipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsRequest.Merge(m, src)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Size(m)
+}
+func (m *ListFederationRelationshipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsRequest proto.InternalMessageInfo
+
+// Represents a ListFederationRelationships response
+type ListFederationRelationshipsResponse struct {
+	// Federation relationships managed through the API
+	Relationships        []*common.FederationRelationship `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *ListFederationRelationshipsResponse) Reset()         { *m = ListFederationRelationshipsResponse{} }
+func (m *ListFederationRelationshipsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsResponse) ProtoMessage()    {}
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{16}
+}
+
+func (m *ListFederationRelationshipsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsResponse.Merge(m, src)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Size(m)
+}
+func (m *ListFederationRelationshipsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsResponse proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsResponse) GetRelationships() []*common.FederationRelationship {
+	if m != nil {
+		return m.Relationships
+	}
+	return nil
+}
+
 type DeleteFederatedBundleRequest struct {
 	Id                   string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Mode                 DeleteFederatedBundleRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=spire.api.registration.DeleteFederatedBundleRequest_Mode" json:"mode,omitempty"`
@@ -502,7 +856,7 @@ func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBun
 func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
 func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{10}
+	return fileDescriptor_199f7aef77c18626, []int{17}
 }
 
 func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -542,7 +896,19 @@ type JoinToken struct {
 	// The join token. If not set, one will be generated
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// TTL in seconds
-	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Ttl int32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// (optional) SPIFFE ID template, or path template relative to
+	// /spire/agent, of the agents attesting with the token. The template can
+	// reference {{ .Token }} and {{ .Use }}, the use number starting at one.
+	// Defaults to join_token/{{ .Token }} for single-use tokens and
+	// join_token/{{ .Token }}/{{ .Use }} otherwise.
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// (optional) Node selectors applied to the agents attesting with the
+	// token
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// (optional) Number of agents that can attest with the token. Defaults
+	// to one.
+	MaxUses              int32    `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -552,7 +918,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{11}
+	return fileDescriptor_199f7aef77c18626, []int{18}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -587,6 +953,27 @@ func (m *JoinToken) GetTtl() int32 {
 	return 0
 }
 
+func (m *JoinToken) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *JoinToken) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
 // CA Bundle of the server
 type Bundle struct {
 	// Common bundle format
@@ -600,7 +987,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{12}
+	return fileDescriptor_199f7aef77c18626, []int{19}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -630,16 +1017,31 @@ func (m *Bundle) GetBundle() *common.Bundle {
 
 // Represents a ListAgents request
 type ListAgentsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Only list agents that attested with the given attestation type
+	ByAttestationType string `protobuf:"bytes,1,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// Only list agents that have all of the given selectors
+	BySelectors []*common.Selector `protobuf:"bytes,2,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	// Only list agents whose SVID expires before the given time (seconds
+	// since unix epoch)
+	ByExpiresBefore int64 `protobuf:"varint,3,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	// Only list agents whose SVID expires after the given time (seconds
+	// since unix epoch)
+	ByExpiresAfter int64 `protobuf:"varint,4,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	// Only list agents that have not been seen since the given time (seconds
+	// since unix epoch)
+	ByNotSeenSince int64 `protobuf:"varint,5,opt,name=by_not_seen_since,json=byNotSeenSince,proto3" json:"by_not_seen_since,omitempty"`
+	// Pagination. All of the agents are listed if not set.
+	Pagination           *Pagination `protobuf:"bytes,6,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
 func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsRequest) ProtoMessage()    {}
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{13}
+	return fileDescriptor_199f7aef77c18626, []int{20}
 }
 
 func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
@@ -658,128 +1060,590 @@ func (m *ListAgentsRequest) XXX_DiscardUnknown() {
 	xxx_messageInfo_ListAgentsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
+
+func (m *ListAgentsRequest) GetByAttestationType() string {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return ""
+}
+
+func (m *ListAgentsRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *ListAgentsRequest) GetByExpiresBefore() int64 {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest) GetByExpiresAfter() int64 {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest) GetByNotSeenSince() int64 {
+	if m != nil {
+		return m.ByNotSeenSince
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// Represents a ListAgents response
+type ListAgentsResponse struct {
+	// List of attested agents, including their node selectors
+	Nodes []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Pagination for the next page, if the request was paginated
+	Pagination           *Pagination `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
+func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAgentsResponse) ProtoMessage()    {}
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{21}
+}
+
+func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
+}
+func (m *ListAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentsResponse.Merge(m, src)
+}
+func (m *ListAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAgentsResponse.Size(m)
+}
+func (m *ListAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentsResponse proto.InternalMessageInfo
+
+func (m *ListAgentsResponse) GetNodes() []*common.AttestedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ListAgentsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// Represents a ListDownstreamAgents request
+type ListDownstreamAgentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDownstreamAgentsRequest) Reset()         { *m = ListDownstreamAgentsRequest{} }
+func (m *ListDownstreamAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDownstreamAgentsRequest) ProtoMessage()    {}
+func (*ListDownstreamAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{22}
+}
+
+func (m *ListDownstreamAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDownstreamAgentsRequest.Unmarshal(m, b)
+}
+func (m *ListDownstreamAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDownstreamAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDownstreamAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDownstreamAgentsRequest.Merge(m, src)
+}
+func (m *ListDownstreamAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDownstreamAgentsRequest.Size(m)
+}
+func (m *ListDownstreamAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDownstreamAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDownstreamAgentsRequest proto.InternalMessageInfo
+
+// Agents reported by a downstream SPIRE server
+type DownstreamAgents struct {
+	// SPIFFE ID of the downstream SPIRE server
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Time the agents were last reported, in seconds since the Unix epoch
+	ReportedAt int64 `protobuf:"varint,2,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	// Agents attested by the downstream SPIRE server
+	Agents               []*common.AttestedNode `protobuf:"bytes,3,rep,name=agents,proto3" json:"agents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *DownstreamAgents) Reset()         { *m = DownstreamAgents{} }
+func (m *DownstreamAgents) String() string { return proto.CompactTextString(m) }
+func (*DownstreamAgents) ProtoMessage()    {}
+func (*DownstreamAgents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{23}
+}
+
+func (m *DownstreamAgents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownstreamAgents.Unmarshal(m, b)
+}
+func (m *DownstreamAgents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownstreamAgents.Marshal(b, m, deterministic)
+}
+func (m *DownstreamAgents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownstreamAgents.Merge(m, src)
+}
+func (m *DownstreamAgents) XXX_Size() int {
+	return xxx_messageInfo_DownstreamAgents.Size(m)
+}
+func (m *DownstreamAgents) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownstreamAgents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownstreamAgents proto.InternalMessageInfo
+
+func (m *DownstreamAgents) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *DownstreamAgents) GetReportedAt() int64 {
+	if m != nil {
+		return m.ReportedAt
+	}
+	return 0
+}
+
+func (m *DownstreamAgents) GetAgents() []*common.AttestedNode {
+	if m != nil {
+		return m.Agents
+	}
+	return nil
+}
+
+// Represents a ListDownstreamAgents response
+type ListDownstreamAgentsResponse struct {
+	// List of agents reported by each downstream SPIRE server
+	DownstreamAgents     []*DownstreamAgents `protobuf:"bytes,1,rep,name=downstream_agents,json=downstreamAgents,proto3" json:"downstream_agents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListDownstreamAgentsResponse) Reset()         { *m = ListDownstreamAgentsResponse{} }
+func (m *ListDownstreamAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDownstreamAgentsResponse) ProtoMessage()    {}
+func (*ListDownstreamAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{24}
+}
+
+func (m *ListDownstreamAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDownstreamAgentsResponse.Unmarshal(m, b)
+}
+func (m *ListDownstreamAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDownstreamAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDownstreamAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDownstreamAgentsResponse.Merge(m, src)
+}
+func (m *ListDownstreamAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDownstreamAgentsResponse.Size(m)
+}
+func (m *ListDownstreamAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDownstreamAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDownstreamAgentsResponse proto.InternalMessageInfo
+
+func (m *ListDownstreamAgentsResponse) GetDownstreamAgents() []*DownstreamAgents {
+	if m != nil {
+		return m.DownstreamAgents
+	}
+	return nil
+}
+
+// Represents an evict request
+type EvictAgentRequest struct {
+	// Agent identity of the node to be evicted.
+	// For example: "spiffe://example.org/spire/agent/join_token/feea6adc-3254-4052-9a18-5eeb74bf214f"
+	SpiffeID             string   `protobuf:"bytes,1,opt,name=spiffeID,proto3" json:"spiffeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
+func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*EvictAgentRequest) ProtoMessage()    {}
+func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{25}
+}
+
+func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvictAgentRequest.Unmarshal(m, b)
+}
+func (m *EvictAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvictAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *EvictAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvictAgentRequest.Merge(m, src)
+}
+func (m *EvictAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_EvictAgentRequest.Size(m)
+}
+func (m *EvictAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvictAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvictAgentRequest proto.InternalMessageInfo
+
+func (m *EvictAgentRequest) GetSpiffeID() string {
+	if m != nil {
+		return m.SpiffeID
+	}
+	return ""
+}
+
+// Represents an evict response
+type EvictAgentResponse struct {
+	// Node contains the evicted node
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
+func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*EvictAgentResponse) ProtoMessage()    {}
+func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{26}
+}
+
+func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvictAgentResponse.Unmarshal(m, b)
+}
+func (m *EvictAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvictAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *EvictAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvictAgentResponse.Merge(m, src)
+}
+func (m *EvictAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_EvictAgentResponse.Size(m)
+}
+func (m *EvictAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvictAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvictAgentResponse proto.InternalMessageInfo
+
+func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents a ban request. Exactly one of spiffe_id or selector must be set.
+type BanAgentRequest struct {
+	// Agent identity of the node to be banned.
+	// For example: "spiffe://example.org/spire/agent/aws_iid/123456789012/us-east-1/i-0123456789abcdef0"
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Attestation selector of the nodes to be banned.
+	Selector *common.Selector `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Reason the agents are banned.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// TTL of the ban, in seconds. The ban never expires if unset.
+	Ttl                  int32    `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanAgentRequest) Reset()         { *m = BanAgentRequest{} }
+func (m *BanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*BanAgentRequest) ProtoMessage()    {}
+func (*BanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{27}
+}
+
+func (m *BanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentRequest.Unmarshal(m, b)
+}
+func (m *BanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *BanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentRequest.Merge(m, src)
+}
+func (m *BanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_BanAgentRequest.Size(m)
+}
+func (m *BanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentRequest proto.InternalMessageInfo
+
+func (m *BanAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *BanAgentRequest) GetSelector() *common.Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *BanAgentRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *BanAgentRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+// Represents a ban response
+type BanAgentResponse struct {
+	// Ban contains the created ban
+	Ban *common.AgentBan `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	// Nodes contains the attested nodes evicted by the ban
+	EvictedNodes         []*common.AttestedNode `protobuf:"bytes,2,rep,name=evicted_nodes,json=evictedNodes,proto3" json:"evicted_nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *BanAgentResponse) Reset()         { *m = BanAgentResponse{} }
+func (m *BanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*BanAgentResponse) ProtoMessage()    {}
+func (*BanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{28}
+}
+
+func (m *BanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentResponse.Unmarshal(m, b)
+}
+func (m *BanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *BanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentResponse.Merge(m, src)
+}
+func (m *BanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_BanAgentResponse.Size(m)
+}
+func (m *BanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentResponse proto.InternalMessageInfo
+
+func (m *BanAgentResponse) GetBan() *common.AgentBan {
+	if m != nil {
+		return m.Ban
+	}
+	return nil
+}
+
+func (m *BanAgentResponse) GetEvictedNodes() []*common.AttestedNode {
+	if m != nil {
+		return m.EvictedNodes
+	}
+	return nil
+}
+
+// Represents an unban request. Exactly one of spiffe_id or selector must be
+// set.
+type UnbanAgentRequest struct {
+	SpiffeId             string           `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Selector             *common.Selector `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *UnbanAgentRequest) Reset()         { *m = UnbanAgentRequest{} }
+func (m *UnbanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*UnbanAgentRequest) ProtoMessage()    {}
+func (*UnbanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{29}
+}
+
+func (m *UnbanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanAgentRequest.Unmarshal(m, b)
+}
+func (m *UnbanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *UnbanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanAgentRequest.Merge(m, src)
+}
+func (m *UnbanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_UnbanAgentRequest.Size(m)
+}
+func (m *UnbanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanAgentRequest proto.InternalMessageInfo
+
+func (m *UnbanAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *UnbanAgentRequest) GetSelector() *common.Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
 
-// Represents a ListAgents response
-type ListAgentsResponse struct {
-	// List of all attested agents
-	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+// Represents an unban response
+type UnbanAgentResponse struct {
+	// Ban contains the removed ban
+	Ban                  *common.AgentBan `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
-func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListAgentsResponse) ProtoMessage()    {}
-func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{14}
+func (m *UnbanAgentResponse) Reset()         { *m = UnbanAgentResponse{} }
+func (m *UnbanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*UnbanAgentResponse) ProtoMessage()    {}
+func (*UnbanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{30}
 }
 
-func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
+func (m *UnbanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanAgentResponse.Unmarshal(m, b)
 }
-func (m *ListAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListAgentsResponse.Marshal(b, m, deterministic)
+func (m *UnbanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanAgentResponse.Marshal(b, m, deterministic)
 }
-func (m *ListAgentsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListAgentsResponse.Merge(m, src)
+func (m *UnbanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanAgentResponse.Merge(m, src)
 }
-func (m *ListAgentsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListAgentsResponse.Size(m)
+func (m *UnbanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_UnbanAgentResponse.Size(m)
 }
-func (m *ListAgentsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListAgentsResponse.DiscardUnknown(m)
+func (m *UnbanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanAgentResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListAgentsResponse proto.InternalMessageInfo
+var xxx_messageInfo_UnbanAgentResponse proto.InternalMessageInfo
 
-func (m *ListAgentsResponse) GetNodes() []*common.AttestedNode {
+func (m *UnbanAgentResponse) GetBan() *common.AgentBan {
 	if m != nil {
-		return m.Nodes
+		return m.Ban
 	}
 	return nil
 }
 
-// Represents an evict request
-type EvictAgentRequest struct {
-	// Agent identity of the node to be evicted.
-	// For example: "spiffe://example.org/spire/agent/join_token/feea6adc-3254-4052-9a18-5eeb74bf214f"
-	SpiffeID             string   `protobuf:"bytes,1,opt,name=spiffeID,proto3" json:"spiffeID,omitempty"`
+type ListAgentBansRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
-func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
-func (*EvictAgentRequest) ProtoMessage()    {}
-func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{15}
+func (m *ListAgentBansRequest) Reset()         { *m = ListAgentBansRequest{} }
+func (m *ListAgentBansRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAgentBansRequest) ProtoMessage()    {}
+func (*ListAgentBansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{31}
 }
 
-func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EvictAgentRequest.Unmarshal(m, b)
+func (m *ListAgentBansRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentBansRequest.Unmarshal(m, b)
 }
-func (m *EvictAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EvictAgentRequest.Marshal(b, m, deterministic)
+func (m *ListAgentBansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentBansRequest.Marshal(b, m, deterministic)
 }
-func (m *EvictAgentRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EvictAgentRequest.Merge(m, src)
+func (m *ListAgentBansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentBansRequest.Merge(m, src)
 }
-func (m *EvictAgentRequest) XXX_Size() int {
-	return xxx_messageInfo_EvictAgentRequest.Size(m)
+func (m *ListAgentBansRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAgentBansRequest.Size(m)
 }
-func (m *EvictAgentRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EvictAgentRequest.DiscardUnknown(m)
+func (m *ListAgentBansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentBansRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EvictAgentRequest proto.InternalMessageInfo
-
-func (m *EvictAgentRequest) GetSpiffeID() string {
-	if m != nil {
-		return m.SpiffeID
-	}
-	return ""
-}
+var xxx_messageInfo_ListAgentBansRequest proto.InternalMessageInfo
 
-// Represents an evict response
-type EvictAgentResponse struct {
-	// Node contains the evicted node
-	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type ListAgentBansResponse struct {
+	Bans                 []*common.AgentBan `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
-func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
-func (*EvictAgentResponse) ProtoMessage()    {}
-func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{16}
+func (m *ListAgentBansResponse) Reset()         { *m = ListAgentBansResponse{} }
+func (m *ListAgentBansResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAgentBansResponse) ProtoMessage()    {}
+func (*ListAgentBansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{32}
 }
 
-func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EvictAgentResponse.Unmarshal(m, b)
+func (m *ListAgentBansResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentBansResponse.Unmarshal(m, b)
 }
-func (m *EvictAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EvictAgentResponse.Marshal(b, m, deterministic)
+func (m *ListAgentBansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentBansResponse.Marshal(b, m, deterministic)
 }
-func (m *EvictAgentResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EvictAgentResponse.Merge(m, src)
+func (m *ListAgentBansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentBansResponse.Merge(m, src)
 }
-func (m *EvictAgentResponse) XXX_Size() int {
-	return xxx_messageInfo_EvictAgentResponse.Size(m)
+func (m *ListAgentBansResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAgentBansResponse.Size(m)
 }
-func (m *EvictAgentResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EvictAgentResponse.DiscardUnknown(m)
+func (m *ListAgentBansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentBansResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EvictAgentResponse proto.InternalMessageInfo
+var xxx_messageInfo_ListAgentBansResponse proto.InternalMessageInfo
 
-func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
+func (m *ListAgentBansResponse) GetBans() []*common.AgentBan {
 	if m != nil {
-		return m.Node
+		return m.Bans
 	}
 	return nil
 }
@@ -808,7 +1672,7 @@ func (m *MintX509SVIDRequest) Reset()         { *m = MintX509SVIDRequest{} }
 func (m *MintX509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDRequest) ProtoMessage()    {}
 func (*MintX509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{17}
+	return fileDescriptor_199f7aef77c18626, []int{33}
 }
 
 func (m *MintX509SVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -879,7 +1743,7 @@ func (m *MintX509SVIDResponse) Reset()         { *m = MintX509SVIDResponse{} }
 func (m *MintX509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintX509SVIDResponse) ProtoMessage()    {}
 func (*MintX509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{18}
+	return fileDescriptor_199f7aef77c18626, []int{34}
 }
 
 func (m *MintX509SVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -934,7 +1798,7 @@ func (m *MintJWTSVIDRequest) Reset()         { *m = MintJWTSVIDRequest{} }
 func (m *MintJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDRequest) ProtoMessage()    {}
 func (*MintJWTSVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{19}
+	return fileDescriptor_199f7aef77c18626, []int{35}
 }
 
 func (m *MintJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
@@ -988,7 +1852,7 @@ func (m *MintJWTSVIDResponse) Reset()         { *m = MintJWTSVIDResponse{} }
 func (m *MintJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*MintJWTSVIDResponse) ProtoMessage()    {}
 func (*MintJWTSVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{20}
+	return fileDescriptor_199f7aef77c18626, []int{36}
 }
 
 func (m *MintJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
@@ -1016,6 +1880,104 @@ func (m *MintJWTSVIDResponse) GetToken() string {
 	return ""
 }
 
+type RevokeJWTSVIDsRequest struct {
+	// Key IDs of JWT signing keys whose JWT-SVIDs must no longer be accepted
+	KeyIds []string `protobuf:"bytes,1,rep,name=key_ids,json=keyIds,proto3" json:"key_ids,omitempty"`
+	// SPIFFE IDs whose JWT-SVIDs must no longer be accepted
+	SpiffeIds            []string `protobuf:"bytes,2,rep,name=spiffe_ids,json=spiffeIds,proto3" json:"spiffe_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeJWTSVIDsRequest) Reset()         { *m = RevokeJWTSVIDsRequest{} }
+func (m *RevokeJWTSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeJWTSVIDsRequest) ProtoMessage()    {}
+func (*RevokeJWTSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{37}
+}
+
+func (m *RevokeJWTSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJWTSVIDsRequest.Unmarshal(m, b)
+}
+func (m *RevokeJWTSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJWTSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeJWTSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJWTSVIDsRequest.Merge(m, src)
+}
+func (m *RevokeJWTSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeJWTSVIDsRequest.Size(m)
+}
+func (m *RevokeJWTSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJWTSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJWTSVIDsRequest proto.InternalMessageInfo
+
+func (m *RevokeJWTSVIDsRequest) GetKeyIds() []string {
+	if m != nil {
+		return m.KeyIds
+	}
+	return nil
+}
+
+func (m *RevokeJWTSVIDsRequest) GetSpiffeIds() []string {
+	if m != nil {
+		return m.SpiffeIds
+	}
+	return nil
+}
+
+type UnrevokeJWTSVIDsRequest struct {
+	// Key IDs of JWT signing keys to remove from the revoked key IDs
+	KeyIds []string `protobuf:"bytes,1,rep,name=key_ids,json=keyIds,proto3" json:"key_ids,omitempty"`
+	// SPIFFE IDs to remove from the revoked subjects
+	SpiffeIds            []string `protobuf:"bytes,2,rep,name=spiffe_ids,json=spiffeIds,proto3" json:"spiffe_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnrevokeJWTSVIDsRequest) Reset()         { *m = UnrevokeJWTSVIDsRequest{} }
+func (m *UnrevokeJWTSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*UnrevokeJWTSVIDsRequest) ProtoMessage()    {}
+func (*UnrevokeJWTSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{38}
+}
+
+func (m *UnrevokeJWTSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnrevokeJWTSVIDsRequest.Unmarshal(m, b)
+}
+func (m *UnrevokeJWTSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnrevokeJWTSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *UnrevokeJWTSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnrevokeJWTSVIDsRequest.Merge(m, src)
+}
+func (m *UnrevokeJWTSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_UnrevokeJWTSVIDsRequest.Size(m)
+}
+func (m *UnrevokeJWTSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnrevokeJWTSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnrevokeJWTSVIDsRequest proto.InternalMessageInfo
+
+func (m *UnrevokeJWTSVIDsRequest) GetKeyIds() []string {
+	if m != nil {
+		return m.KeyIds
+	}
+	return nil
+}
+
+func (m *UnrevokeJWTSVIDsRequest) GetSpiffeIds() []string {
+	if m != nil {
+		return m.SpiffeIds
+	}
+	return nil
+}
+
 type NodeSelectors struct {
 	// Node SPIFFE ID
 	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
@@ -1030,7 +1992,7 @@ func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
 func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
 func (*NodeSelectors) ProtoMessage()    {}
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{21}
+	return fileDescriptor_199f7aef77c18626, []int{39}
 }
 
 func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
@@ -1077,7 +2039,7 @@ func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest
 func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsRequest) ProtoMessage()    {}
 func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{22}
+	return fileDescriptor_199f7aef77c18626, []int{40}
 }
 
 func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
@@ -1117,7 +2079,7 @@ func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsRespon
 func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsResponse) ProtoMessage()    {}
 func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{23}
+	return fileDescriptor_199f7aef77c18626, []int{41}
 }
 
 func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
@@ -1145,6 +2107,164 @@ func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
 	return nil
 }
 
+// Represents an ExplainEntries request
+type ExplainEntriesRequest struct {
+	// SPIFFE ID of the agent the workload runs on
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Selectors the workload is attested with
+	Selectors            []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ExplainEntriesRequest) Reset()         { *m = ExplainEntriesRequest{} }
+func (m *ExplainEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainEntriesRequest) ProtoMessage()    {}
+func (*ExplainEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{42}
+}
+
+func (m *ExplainEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainEntriesRequest.Unmarshal(m, b)
+}
+func (m *ExplainEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainEntriesRequest.Merge(m, src)
+}
+func (m *ExplainEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainEntriesRequest.Size(m)
+}
+func (m *ExplainEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainEntriesRequest proto.InternalMessageInfo
+
+func (m *ExplainEntriesRequest) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *ExplainEntriesRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type ExcludedEntry struct {
+	// Registration entry that would not be issued to the workload
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Why the entry would not be issued to the workload
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExcludedEntry) Reset()         { *m = ExcludedEntry{} }
+func (m *ExcludedEntry) String() string { return proto.CompactTextString(m) }
+func (*ExcludedEntry) ProtoMessage()    {}
+func (*ExcludedEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{43}
+}
+
+func (m *ExcludedEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExcludedEntry.Unmarshal(m, b)
+}
+func (m *ExcludedEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExcludedEntry.Marshal(b, m, deterministic)
+}
+func (m *ExcludedEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExcludedEntry.Merge(m, src)
+}
+func (m *ExcludedEntry) XXX_Size() int {
+	return xxx_messageInfo_ExcludedEntry.Size(m)
+}
+func (m *ExcludedEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExcludedEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExcludedEntry proto.InternalMessageInfo
+
+func (m *ExcludedEntry) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *ExcludedEntry) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// Represents an ExplainEntries response
+type ExplainEntriesResponse struct {
+	// Selectors the agent was attested with
+	NodeSelectors []*common.Selector `protobuf:"bytes,1,rep,name=node_selectors,json=nodeSelectors,proto3" json:"node_selectors,omitempty"`
+	// Entries the workload would be issued
+	Authorized []*common.RegistrationEntry `protobuf:"bytes,2,rep,name=authorized,proto3" json:"authorized,omitempty"`
+	// Entries that nearly match the workload but would not be issued
+	Excluded             []*ExcludedEntry `protobuf:"bytes,3,rep,name=excluded,proto3" json:"excluded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ExplainEntriesResponse) Reset()         { *m = ExplainEntriesResponse{} }
+func (m *ExplainEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ExplainEntriesResponse) ProtoMessage()    {}
+func (*ExplainEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{44}
+}
+
+func (m *ExplainEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainEntriesResponse.Unmarshal(m, b)
+}
+func (m *ExplainEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ExplainEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainEntriesResponse.Merge(m, src)
+}
+func (m *ExplainEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ExplainEntriesResponse.Size(m)
+}
+func (m *ExplainEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainEntriesResponse proto.InternalMessageInfo
+
+func (m *ExplainEntriesResponse) GetNodeSelectors() []*common.Selector {
+	if m != nil {
+		return m.NodeSelectors
+	}
+	return nil
+}
+
+func (m *ExplainEntriesResponse) GetAuthorized() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Authorized
+	}
+	return nil
+}
+
+func (m *ExplainEntriesResponse) GetExcluded() []*ExcludedEntry {
+	if m != nil {
+		return m.Excluded
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("spire.api.registration.DeleteFederatedBundleRequest_Mode", DeleteFederatedBundleRequest_Mode_name, DeleteFederatedBundleRequest_Mode_value)
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
@@ -1157,97 +2277,182 @@ func init() {
 	proto.RegisterType((*ListAllEntriesResponse)(nil), "spire.api.registration.ListAllEntriesResponse")
 	proto.RegisterType((*FederatedBundle)(nil), "spire.api.registration.FederatedBundle")
 	proto.RegisterType((*FederatedBundleID)(nil), "spire.api.registration.FederatedBundleID")
+	proto.RegisterType((*ListFederatedBundleSyncStatusRequest)(nil), "spire.api.registration.ListFederatedBundleSyncStatusRequest")
+	proto.RegisterType((*FederatedBundleSyncStatus)(nil), "spire.api.registration.FederatedBundleSyncStatus")
+	proto.RegisterType((*ListFederatedBundleSyncStatusResponse)(nil), "spire.api.registration.ListFederatedBundleSyncStatusResponse")
+	proto.RegisterType((*FederationRelationshipID)(nil), "spire.api.registration.FederationRelationshipID")
+	proto.RegisterType((*DeleteFederationRelationshipRequest)(nil), "spire.api.registration.DeleteFederationRelationshipRequest")
+	proto.RegisterType((*ListFederationRelationshipsRequest)(nil), "spire.api.registration.ListFederationRelationshipsRequest")
+	proto.RegisterType((*ListFederationRelationshipsResponse)(nil), "spire.api.registration.ListFederationRelationshipsResponse")
 	proto.RegisterType((*DeleteFederatedBundleRequest)(nil), "spire.api.registration.DeleteFederatedBundleRequest")
 	proto.RegisterType((*JoinToken)(nil), "spire.api.registration.JoinToken")
 	proto.RegisterType((*Bundle)(nil), "spire.api.registration.Bundle")
 	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.registration.ListAgentsRequest")
 	proto.RegisterType((*ListAgentsResponse)(nil), "spire.api.registration.ListAgentsResponse")
+	proto.RegisterType((*ListDownstreamAgentsRequest)(nil), "spire.api.registration.ListDownstreamAgentsRequest")
+	proto.RegisterType((*DownstreamAgents)(nil), "spire.api.registration.DownstreamAgents")
+	proto.RegisterType((*ListDownstreamAgentsResponse)(nil), "spire.api.registration.ListDownstreamAgentsResponse")
 	proto.RegisterType((*EvictAgentRequest)(nil), "spire.api.registration.EvictAgentRequest")
 	proto.RegisterType((*EvictAgentResponse)(nil), "spire.api.registration.EvictAgentResponse")
+	proto.RegisterType((*BanAgentRequest)(nil), "spire.api.registration.BanAgentRequest")
+	proto.RegisterType((*BanAgentResponse)(nil), "spire.api.registration.BanAgentResponse")
+	proto.RegisterType((*UnbanAgentRequest)(nil), "spire.api.registration.UnbanAgentRequest")
+	proto.RegisterType((*UnbanAgentResponse)(nil), "spire.api.registration.UnbanAgentResponse")
+	proto.RegisterType((*ListAgentBansRequest)(nil), "spire.api.registration.ListAgentBansRequest")
+	proto.RegisterType((*ListAgentBansResponse)(nil), "spire.api.registration.ListAgentBansResponse")
 	proto.RegisterType((*MintX509SVIDRequest)(nil), "spire.api.registration.MintX509SVIDRequest")
 	proto.RegisterType((*MintX509SVIDResponse)(nil), "spire.api.registration.MintX509SVIDResponse")
 	proto.RegisterType((*MintJWTSVIDRequest)(nil), "spire.api.registration.MintJWTSVIDRequest")
 	proto.RegisterType((*MintJWTSVIDResponse)(nil), "spire.api.registration.MintJWTSVIDResponse")
+	proto.RegisterType((*RevokeJWTSVIDsRequest)(nil), "spire.api.registration.RevokeJWTSVIDsRequest")
+	proto.RegisterType((*UnrevokeJWTSVIDsRequest)(nil), "spire.api.registration.UnrevokeJWTSVIDsRequest")
 	proto.RegisterType((*NodeSelectors)(nil), "spire.api.registration.NodeSelectors")
 	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.api.registration.GetNodeSelectorsRequest")
 	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.api.registration.GetNodeSelectorsResponse")
+	proto.RegisterType((*ExplainEntriesRequest)(nil), "spire.api.registration.ExplainEntriesRequest")
+	proto.RegisterType((*ExcludedEntry)(nil), "spire.api.registration.ExcludedEntry")
+	proto.RegisterType((*ExplainEntriesResponse)(nil), "spire.api.registration.ExplainEntriesResponse")
 }
 
 func init() { proto.RegisterFile("registration.proto", fileDescriptor_199f7aef77c18626) }
 
 var fileDescriptor_199f7aef77c18626 = []byte{
-	// 1130 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x53, 0x1b, 0x37,
-	0x10, 0xae, 0xcd, 0x4b, 0xec, 0xb5, 0x4b, 0x40, 0xbc, 0x39, 0x97, 0x36, 0x75, 0xd4, 0xc9, 0x34,
-	0x0d, 0xa9, 0xcd, 0x10, 0xc2, 0x0c, 0x9f, 0x32, 0x60, 0x9b, 0x8e, 0x93, 0x40, 0x99, 0xb3, 0x09,
-	0x1d, 0xf8, 0xe0, 0x39, 0x7c, 0xc2, 0xa8, 0x35, 0x77, 0x97, 0x93, 0xc8, 0x84, 0xfc, 0x80, 0xfe,
-	0x85, 0xfe, 0x8c, 0xfe, 0xc5, 0x8e, 0x4e, 0xba, 0xf3, 0xf9, 0x7c, 0xc2, 0x17, 0xa6, 0xfd, 0xe4,
-	0x93, 0xb4, 0xfb, 0xec, 0xb3, 0xab, 0x95, 0xf4, 0x18, 0x90, 0x4f, 0x06, 0x94, 0x71, 0xdf, 0xe2,
-	0xd4, 0x75, 0x6a, 0x9e, 0xef, 0x72, 0x17, 0xad, 0x31, 0x8f, 0xfa, 0xa4, 0x66, 0x79, 0xb4, 0x16,
-	0x5f, 0x35, 0x1e, 0x05, 0xf3, 0xf5, 0xbe, 0x7b, 0x7d, 0xed, 0x3a, 0xea, 0x47, 0xba, 0xe0, 0x67,
-	0xb0, 0x6c, 0xc6, 0x4c, 0x5b, 0x0e, 0xf7, 0x6f, 0xdb, 0x4d, 0xb4, 0x00, 0x79, 0x6a, 0x57, 0x72,
-	0xd5, 0xdc, 0xf3, 0xa2, 0x99, 0xa7, 0x36, 0x36, 0xa0, 0x70, 0x6c, 0xf9, 0xc4, 0xe1, 0xe9, 0x6b,
-	0x1d, 0x8f, 0x5e, 0x5e, 0x92, 0x94, 0xb5, 0x5b, 0x78, 0xd2, 0xf0, 0x89, 0xc5, 0x89, 0x04, 0xbe,
-	0x3c, 0x72, 0x79, 0xeb, 0x33, 0x65, 0x9c, 0x99, 0x84, 0x79, 0xae, 0xc3, 0x08, 0x7a, 0x0d, 0x73,
-	0x44, 0xac, 0x05, 0x4e, 0xa5, 0xad, 0x1f, 0x6a, 0x32, 0x07, 0x45, 0x72, 0x82, 0x9b, 0x29, 0xad,
-	0x51, 0x15, 0x4a, 0x9e, 0x4f, 0x88, 0xc0, 0xa2, 0xce, 0xa0, 0x92, 0xaf, 0xe6, 0x9e, 0x17, 0xcc,
-	0xf8, 0x14, 0x7e, 0x07, 0xe8, 0xc4, 0xb3, 0xc3, 0xd0, 0x26, 0xf9, 0x78, 0x43, 0x18, 0xbf, 0x67,
-	0x38, 0xfc, 0x06, 0xe0, 0xd8, 0x1a, 0x50, 0x27, 0x58, 0x41, 0x2b, 0x30, 0xc7, 0xdd, 0x3f, 0x89,
-	0xa3, 0x12, 0x95, 0x03, 0xf4, 0x18, 0x8a, 0x9e, 0x35, 0x20, 0x3d, 0x46, 0xbf, 0x90, 0x80, 0xd0,
-	0x9c, 0x59, 0x10, 0x13, 0x1d, 0xfa, 0x85, 0xe0, 0x73, 0x58, 0x7d, 0x4f, 0x19, 0xdf, 0x1b, 0x0e,
-	0x05, 0x2e, 0x25, 0x2c, 0x24, 0xb4, 0x0f, 0xe0, 0x45, 0xc8, 0x8a, 0x15, 0xae, 0xa5, 0x6f, 0x64,
-	0x6d, 0xc4, 0xc1, 0x8c, 0x79, 0xe1, 0xbf, 0x73, 0xb0, 0x96, 0x44, 0x57, 0xe5, 0xdd, 0x85, 0x07,
-	0x44, 0x4e, 0x55, 0x72, 0xd5, 0x99, 0x2c, 0x19, 0x87, 0xf6, 0x09, 0x66, 0xf9, 0x7b, 0x31, 0x7b,
-	0x03, 0x0f, 0x0f, 0x88, 0x4d, 0x7c, 0x8b, 0x13, 0x7b, 0xff, 0xc6, 0xb1, 0x87, 0x04, 0xbd, 0x84,
-	0xf9, 0x8b, 0xe0, 0xab, 0x32, 0x13, 0x40, 0xae, 0x8c, 0x13, 0x92, 0x56, 0xa6, 0xb2, 0xc1, 0x3f,
-	0xc2, 0x52, 0x02, 0x20, 0xa5, 0xcb, 0xfe, 0xc9, 0xc1, 0x77, 0x4d, 0x32, 0x24, 0x9c, 0x24, 0x6c,
-	0xc3, 0x22, 0x27, 0x1c, 0xd0, 0x21, 0xcc, 0x5e, 0xbb, 0xb6, 0xdc, 0xa5, 0x85, 0xad, 0x5d, 0x5d,
-	0x52, 0x77, 0x61, 0xd6, 0x0e, 0x5d, 0x9b, 0x98, 0x01, 0x0c, 0xde, 0x84, 0x59, 0x31, 0x42, 0x65,
-	0x28, 0x98, 0xad, 0x4e, 0xd7, 0x6c, 0x37, 0xba, 0x8b, 0xdf, 0x20, 0x80, 0xf9, 0x66, 0xeb, 0x7d,
-	0xab, 0xdb, 0x5a, 0xcc, 0xa1, 0x05, 0x80, 0x66, 0xbb, 0xd3, 0xf9, 0xad, 0xd1, 0xde, 0xeb, 0xb6,
-	0x16, 0xf3, 0xf8, 0x15, 0x14, 0xdf, 0xba, 0xd4, 0xe9, 0x06, 0x8d, 0x93, 0xde, 0x4e, 0x8b, 0x30,
-	0xc3, 0xf9, 0x50, 0x35, 0x92, 0xf8, 0xc4, 0x3b, 0x30, 0x3f, 0x51, 0xc3, 0x7c, 0x86, 0x1a, 0x2e,
-	0xc3, 0x52, 0xd0, 0x1d, 0x03, 0xe2, 0xf0, 0xb0, 0xef, 0xf0, 0x01, 0xa0, 0xf8, 0xa4, 0x6a, 0x97,
-	0x4d, 0x98, 0x73, 0x5c, 0x3b, 0x6a, 0x16, 0x63, 0x1c, 0x77, 0x8f, 0x73, 0xc2, 0x38, 0xb1, 0x8f,
-	0x44, 0xea, 0xd2, 0x10, 0xd7, 0x61, 0xa9, 0xf5, 0x89, 0xf6, 0x25, 0x50, 0x58, 0x6f, 0x03, 0x0a,
-	0x4c, 0x5d, 0x09, 0x2a, 0xa9, 0x68, 0x8c, 0x9b, 0x80, 0xe2, 0x0e, 0x2a, 0x70, 0x0d, 0x66, 0x05,
-	0x9e, 0x3a, 0x00, 0x77, 0xc5, 0x0d, 0xec, 0x30, 0x83, 0xe5, 0x43, 0xea, 0xf0, 0xdf, 0x5f, 0x6f,
-	0xee, 0x76, 0x3e, 0xb4, 0x9b, 0x61, 0xe0, 0xc7, 0x50, 0x94, 0x81, 0x7a, 0xd1, 0x7e, 0x87, 0x91,
-	0x6d, 0x51, 0xd1, 0x3e, 0xf3, 0x83, 0x92, 0x95, 0x4d, 0xf1, 0x19, 0xd6, 0x78, 0x26, 0xaa, 0xb1,
-	0x00, 0xb0, 0x1d, 0xd6, 0x73, 0xac, 0x6b, 0xc2, 0x2a, 0xb3, 0xd5, 0x19, 0x01, 0x60, 0x3b, 0xec,
-	0x48, 0x8c, 0xf1, 0x31, 0xac, 0x8c, 0x07, 0x55, 0xe4, 0xbf, 0x07, 0x60, 0x9f, 0xa8, 0xdd, 0xeb,
-	0x5f, 0x59, 0xd4, 0x09, 0x4a, 0x57, 0x36, 0x8b, 0x62, 0xa6, 0x21, 0x26, 0xd0, 0x23, 0x28, 0xf8,
-	0xae, 0xcb, 0x7b, 0x7d, 0x8b, 0x55, 0xf2, 0xc1, 0xe2, 0x03, 0x31, 0x6e, 0x58, 0x0c, 0xf7, 0x00,
-	0x09, 0xc4, 0xb7, 0xa7, 0xdd, 0xaf, 0xc9, 0x62, 0xbc, 0x2f, 0x44, 0xb5, 0xad, 0x1b, 0x9b, 0x12,
-	0xa7, 0x2f, 0xce, 0x54, 0x40, 0x39, 0x1c, 0xe3, 0x0d, 0x59, 0xa7, 0x28, 0x80, 0x62, 0x9c, 0xda,
-	0x72, 0xf8, 0x02, 0xbe, 0x15, 0x25, 0xee, 0x90, 0x21, 0xe9, 0x73, 0xd7, 0x67, 0x77, 0x13, 0xd9,
-	0x86, 0x22, 0x0b, 0x2d, 0x83, 0xbc, 0x4a, 0x5b, 0x6b, 0xe3, 0xfb, 0x16, 0x02, 0x99, 0x23, 0x43,
-	0xbc, 0x03, 0xeb, 0xbf, 0x12, 0x3e, 0x16, 0x26, 0x4b, 0xda, 0xb8, 0x07, 0x95, 0x49, 0x3f, 0x95,
-	0x4d, 0x23, 0xce, 0x44, 0x76, 0xd0, 0x33, 0xdd, 0x99, 0x1e, 0x47, 0x18, 0xf9, 0x6d, 0xfd, 0xb5,
-	0x04, 0xe5, 0xf8, 0x6d, 0x88, 0xce, 0xa1, 0x14, 0x7b, 0xbb, 0xd0, 0xb4, 0x8b, 0xd3, 0xd8, 0xd0,
-	0x85, 0x4c, 0x7b, 0x60, 0x3f, 0xc2, 0x5a, 0xfa, 0xc3, 0x38, 0x3d, 0xce, 0x8e, 0x2e, 0xce, 0x94,
-	0x97, 0xf6, 0x1c, 0x4a, 0xf2, 0x42, 0x93, 0xf9, 0x7c, 0x0d, 0x5d, 0x63, 0x1a, 0x29, 0x74, 0x06,
-	0x70, 0x40, 0x78, 0xff, 0xea, 0xff, 0xc0, 0x3e, 0x80, 0x72, 0x84, 0x2d, 0x1e, 0xa6, 0xe5, 0x71,
-	0x87, 0xd6, 0xb5, 0xc7, 0x6f, 0x8d, 0xa7, 0x77, 0xa3, 0x08, 0xbf, 0x33, 0x28, 0xc5, 0x14, 0x01,
-	0x7a, 0xa1, 0x23, 0x39, 0x29, 0x1b, 0xa6, 0x73, 0x3c, 0x81, 0x05, 0x71, 0x9d, 0xee, 0xdf, 0x46,
-	0x32, 0xa9, 0xaa, 0x7f, 0x2a, 0xa5, 0x45, 0x16, 0xca, 0xef, 0x42, 0xd8, 0xb0, 0x65, 0x91, 0xe6,
-	0x88, 0x65, 0x01, 0x3b, 0x84, 0x87, 0xe3, 0x60, 0x0c, 0xad, 0xa7, 0xa3, 0xb1, 0x2c, 0x70, 0x51,
-	0xca, 0x91, 0xfa, 0xd3, 0xa6, 0x1c, 0x5a, 0x64, 0x81, 0xfd, 0x0c, 0xeb, 0xe3, 0x5a, 0xe6, 0x94,
-	0xf2, 0xab, 0x63, 0x6b, 0x40, 0x18, 0xfa, 0x45, 0x87, 0x9f, 0x2a, 0xad, 0x8c, 0x5a, 0x56, 0x73,
-	0x75, 0x40, 0x4e, 0x60, 0x55, 0x1e, 0xa1, 0xa4, 0x64, 0xf9, 0x49, 0x07, 0x94, 0x30, 0x34, 0xd2,
-	0x3a, 0x13, 0xfd, 0x01, 0x2b, 0x41, 0xfb, 0x26, 0x51, 0x7f, 0xce, 0x88, 0xda, 0x6e, 0x1a, 0x59,
-	0x09, 0xa0, 0x0f, 0xb0, 0x22, 0x92, 0x4b, 0x4c, 0x6b, 0x8e, 0x4c, 0x56, 0xd4, 0xcd, 0x9c, 0x28,
-	0x8d, 0x3c, 0x15, 0xff, 0x6d, 0x69, 0x2e, 0x60, 0x35, 0x55, 0x63, 0xa1, 0xed, 0xfb, 0x48, 0xb2,
-	0xf4, 0x18, 0xa7, 0xf0, 0x50, 0xee, 0xea, 0x48, 0x70, 0x3d, 0xd5, 0xa1, 0x47, 0x26, 0xc6, 0x74,
-	0x13, 0xb4, 0x0f, 0xa5, 0x60, 0x5f, 0x15, 0xe5, 0xd4, 0x12, 0x3f, 0xd1, 0xc1, 0x28, 0xa7, 0x3e,
-	0xc0, 0x48, 0x0c, 0xe9, 0x3b, 0x62, 0x42, 0x61, 0x19, 0x2f, 0xb2, 0x98, 0xaa, 0xbe, 0xee, 0x03,
-	0x8c, 0xa4, 0x9e, 0x3e, 0xc8, 0x84, 0x46, 0xd4, 0x07, 0x49, 0x51, 0x8e, 0x14, 0xca, 0x71, 0x6d,
-	0xa4, 0x7f, 0x02, 0x52, 0x64, 0x9b, 0xf1, 0x32, 0x9b, 0xb1, 0x0a, 0x75, 0x09, 0xa5, 0x98, 0xa6,
-	0xd1, 0xdf, 0xe3, 0x93, 0xca, 0xca, 0xd8, 0xc8, 0x64, 0xab, 0xe2, 0xdc, 0xc0, 0x62, 0x52, 0x72,
-	0xa0, 0xba, 0x0e, 0x40, 0x23, 0x6a, 0x8c, 0xcd, 0xec, 0x0e, 0x32, 0xec, 0xfe, 0xce, 0xd9, 0xf6,
-	0x80, 0xf2, 0xab, 0x9b, 0x0b, 0xd1, 0x4a, 0x75, 0x29, 0x80, 0xea, 0xf2, 0x1f, 0x7c, 0xf0, 0x9f,
-	0x5d, 0x7d, 0x5b, 0x1e, 0xad, 0xc7, 0x01, 0x2f, 0xe6, 0x83, 0xd5, 0x57, 0xff, 0x06, 0x00, 0x00,
-	0xff, 0xff, 0x1c, 0xa6, 0x33, 0xe8, 0x1a, 0x10, 0x00, 0x00,
+	// 2147 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xdd, 0x6e, 0xdb, 0xc8,
+	0xf5, 0xff, 0x4b, 0xfe, 0x92, 0x8e, 0x64, 0x59, 0x1e, 0xc7, 0x8e, 0xc2, 0x24, 0xbb, 0x0e, 0x93,
+	0xec, 0x3a, 0x4e, 0x56, 0xce, 0xdf, 0x9b, 0x0d, 0x90, 0x6e, 0xb7, 0x81, 0x65, 0x29, 0x85, 0xb2,
+	0x1b, 0x6f, 0x4a, 0xd9, 0x9b, 0x22, 0x41, 0x4b, 0x50, 0xe2, 0xd8, 0xe6, 0x46, 0x1a, 0x2a, 0x9c,
+	0x51, 0x6a, 0x05, 0xe8, 0xc5, 0x02, 0x05, 0x0a, 0x14, 0xbd, 0x6e, 0x6f, 0x0a, 0xb4, 0x6f, 0xd0,
+	0x87, 0xe8, 0x7d, 0x5f, 0xa0, 0x2f, 0x53, 0xcc, 0x07, 0x29, 0x92, 0x26, 0x25, 0xc6, 0x4d, 0xae,
+	0x2c, 0x9e, 0xf9, 0xcd, 0xf9, 0x9a, 0x73, 0xce, 0xcc, 0x39, 0x30, 0x20, 0x0f, 0x9f, 0x38, 0x94,
+	0x79, 0x16, 0x73, 0x5c, 0x52, 0x1f, 0x7a, 0x2e, 0x73, 0xd1, 0x06, 0x1d, 0x3a, 0x1e, 0xae, 0x5b,
+	0x43, 0xa7, 0x1e, 0x5e, 0xd5, 0xae, 0x08, 0xfa, 0x4e, 0xcf, 0x1d, 0x0c, 0x5c, 0xa2, 0xfe, 0xc8,
+	0x2d, 0xfa, 0x6d, 0x58, 0x33, 0x42, 0xd0, 0x16, 0x61, 0xde, 0xb8, 0xdd, 0x44, 0x15, 0xc8, 0x3b,
+	0x76, 0x2d, 0xb7, 0x99, 0xdb, 0x2a, 0x1a, 0x79, 0xc7, 0xd6, 0x35, 0x28, 0x3c, 0xb7, 0x3c, 0x4c,
+	0x58, 0xf2, 0x5a, 0x67, 0xe8, 0x1c, 0x1f, 0xe3, 0x84, 0xb5, 0x31, 0x7c, 0xb2, 0xef, 0x61, 0x8b,
+	0x61, 0xc9, 0xf8, 0xf8, 0xc0, 0x65, 0xad, 0x33, 0x87, 0x32, 0x6a, 0x60, 0x3a, 0x74, 0x09, 0xc5,
+	0xe8, 0x2b, 0x58, 0xc0, 0x7c, 0x4d, 0x6c, 0x2a, 0xed, 0x7e, 0x5a, 0x97, 0x36, 0x28, 0x25, 0xcf,
+	0xe9, 0x66, 0x48, 0x34, 0xda, 0x84, 0xd2, 0xd0, 0xc3, 0x98, 0xf3, 0x72, 0xc8, 0x49, 0x2d, 0xbf,
+	0x99, 0xdb, 0x2a, 0x18, 0x61, 0x92, 0xfe, 0x2d, 0xa0, 0xa3, 0xa1, 0xed, 0x8b, 0x36, 0xf0, 0x9b,
+	0x11, 0xa6, 0xec, 0x82, 0xe2, 0xf4, 0xc7, 0x00, 0xcf, 0xad, 0x13, 0x87, 0x88, 0x15, 0x74, 0x09,
+	0x16, 0x98, 0xfb, 0x1a, 0x13, 0x65, 0xa8, 0xfc, 0x40, 0x57, 0xa1, 0x38, 0xb4, 0x4e, 0xb0, 0x49,
+	0x9d, 0x77, 0x58, 0x28, 0xb4, 0x60, 0x14, 0x38, 0xa1, 0xe3, 0xbc, 0xc3, 0xfa, 0x2b, 0x58, 0xff,
+	0xce, 0xa1, 0x6c, 0xaf, 0xdf, 0xe7, 0x7c, 0x1d, 0x4c, 0x7d, 0x85, 0x1a, 0x00, 0xc3, 0x80, 0xb3,
+	0xd2, 0x4a, 0xaf, 0x27, 0x1f, 0x64, 0x7d, 0xa2, 0x83, 0x11, 0xda, 0xa5, 0xff, 0x35, 0x07, 0x1b,
+	0x71, 0xee, 0xca, 0xbd, 0x8f, 0x60, 0x09, 0x4b, 0x52, 0x2d, 0xb7, 0x39, 0x97, 0xc5, 0x62, 0x1f,
+	0x1f, 0xd3, 0x2c, 0x7f, 0x21, 0xcd, 0x28, 0xac, 0x3c, 0xc1, 0x36, 0xf6, 0x2c, 0x86, 0xed, 0xc6,
+	0x88, 0xd8, 0x7d, 0x8c, 0xee, 0xc1, 0x62, 0x57, 0xfc, 0xaa, 0xcd, 0x09, 0x96, 0x97, 0xa2, 0x0a,
+	0x49, 0x94, 0xa1, 0x30, 0x68, 0x17, 0xd6, 0x3d, 0x4c, 0x31, 0x33, 0x29, 0xf7, 0x17, 0xe9, 0x61,
+	0x93, 0x8c, 0x06, 0x5d, 0xec, 0xd5, 0xe6, 0xc5, 0x89, 0xaf, 0x89, 0xc5, 0x8e, 0x5a, 0x3b, 0x10,
+	0x4b, 0xfa, 0x4d, 0x58, 0x8d, 0x09, 0x4d, 0x88, 0xcc, 0xcf, 0xe0, 0x16, 0x77, 0x59, 0x0c, 0xd8,
+	0x19, 0x93, 0x5e, 0x87, 0x59, 0x6c, 0xe4, 0x9f, 0x8f, 0xfe, 0x8f, 0x3c, 0x5c, 0x49, 0x05, 0xa1,
+	0xcf, 0x60, 0x85, 0x79, 0x23, 0xca, 0x4c, 0xdb, 0x1d, 0x58, 0x0e, 0x31, 0x03, 0x11, 0xcb, 0x82,
+	0xdc, 0x14, 0xd4, 0xb6, 0x8d, 0xee, 0x40, 0x15, 0x13, 0x7b, 0xe8, 0x3a, 0x84, 0x99, 0x96, 0x6d,
+	0x7b, 0x98, 0x52, 0xe1, 0xd1, 0xa2, 0xb1, 0xe2, 0xd3, 0xf7, 0x24, 0x19, 0xdd, 0x80, 0x72, 0xdf,
+	0xa2, 0xcc, 0xa4, 0xa3, 0x5e, 0x8f, 0xc3, 0xb8, 0x97, 0xe6, 0x8c, 0x12, 0xa7, 0x75, 0x24, 0x09,
+	0x5d, 0x07, 0x10, 0x10, 0xec, 0x79, 0xae, 0xf4, 0x44, 0xd1, 0x28, 0x72, 0x4a, 0x8b, 0x13, 0x90,
+	0x0e, 0xcb, 0x93, 0x65, 0xd3, 0x62, 0xb5, 0x85, 0x09, 0x0b, 0x81, 0xd8, 0x63, 0x5c, 0x0a, 0xc1,
+	0x67, 0xcc, 0xf4, 0xf0, 0xb1, 0x87, 0xe9, 0x69, 0x6d, 0x51, 0x42, 0x38, 0xcd, 0x90, 0x24, 0xf4,
+	0x39, 0xac, 0xc4, 0x9d, 0xbe, 0xb4, 0x99, 0xdb, 0x9a, 0x37, 0x2a, 0x34, 0xea, 0xef, 0xb7, 0x70,
+	0x7b, 0x86, 0x2b, 0x55, 0x30, 0x3e, 0x83, 0x02, 0x15, 0x94, 0x20, 0x1a, 0xff, 0x3f, 0x2d, 0x9e,
+	0xd2, 0x99, 0x05, 0x2c, 0xf4, 0x06, 0xd4, 0x14, 0x8c, 0x87, 0x1d, 0xee, 0x8b, 0xbf, 0xf4, 0xd4,
+	0x19, 0xb6, 0x9b, 0x59, 0x0f, 0x46, 0xff, 0x77, 0x0e, 0x6e, 0x36, 0x71, 0x1f, 0x33, 0x9c, 0xcc,
+	0xca, 0x4f, 0xd3, 0xac, 0x07, 0x7d, 0x13, 0x96, 0x6d, 0xc1, 0xce, 0x54, 0x41, 0x2e, 0x2b, 0x53,
+	0x59, 0x12, 0x55, 0x0a, 0xbc, 0x84, 0x92, 0x5c, 0x35, 0x07, 0xae, 0x2d, 0xf3, 0xa0, 0xb2, 0xfb,
+	0x28, 0xcd, 0x15, 0x11, 0xf5, 0x7c, 0x87, 0x28, 0xbd, 0xea, 0xcf, 0x5c, 0x1b, 0x1b, 0x20, 0xb9,
+	0xf1, 0xdf, 0xfa, 0x2d, 0xd0, 0x43, 0x87, 0x11, 0xb3, 0x26, 0x88, 0xea, 0x37, 0x70, 0x73, 0x2a,
+	0x4a, 0x1d, 0xd8, 0x53, 0x58, 0xf6, 0xc2, 0x0b, 0xea, 0xd4, 0x6e, 0x45, 0x53, 0x36, 0xc5, 0x73,
+	0xd1, 0xad, 0xfa, 0x3f, 0x73, 0x70, 0x6d, 0x9a, 0x29, 0xf1, 0x0c, 0x45, 0xcf, 0x60, 0x5e, 0xb8,
+	0x27, 0xff, 0xbf, 0xba, 0x47, 0xb0, 0xd1, 0xef, 0xc3, 0x3c, 0xff, 0x42, 0x65, 0x28, 0x18, 0xad,
+	0xce, 0xa1, 0xd1, 0xde, 0x3f, 0xac, 0xfe, 0x1f, 0x02, 0x58, 0x6c, 0xb6, 0xbe, 0x6b, 0x1d, 0xb6,
+	0xaa, 0x39, 0x54, 0x01, 0x68, 0xb6, 0x3b, 0x9d, 0xef, 0xf7, 0xdb, 0x7b, 0x87, 0xad, 0x6a, 0x5e,
+	0xff, 0x7b, 0x0e, 0x8a, 0x4f, 0x5d, 0x87, 0x1c, 0x8a, 0xf2, 0x9e, 0x5c, 0xf4, 0xab, 0x30, 0xc7,
+	0x58, 0x5f, 0x95, 0x7b, 0xfe, 0x13, 0x5d, 0x81, 0x82, 0x75, 0x82, 0x09, 0xe3, 0x21, 0x32, 0x27,
+	0xa0, 0x4b, 0xe2, 0xbb, 0x6d, 0xa3, 0x07, 0x50, 0xa4, 0xb8, 0x8f, 0x7b, 0xcc, 0xf5, 0x68, 0x6d,
+	0x5e, 0xb8, 0x72, 0x23, 0xea, 0xca, 0x8e, 0x5a, 0x36, 0x26, 0x40, 0xce, 0x70, 0x60, 0x9d, 0x99,
+	0x22, 0x6b, 0x16, 0x84, 0x9c, 0xa5, 0x81, 0x75, 0x76, 0xc4, 0x33, 0xe0, 0x21, 0x2c, 0x9e, 0xab,
+	0xaa, 0xf9, 0xd9, 0x55, 0x55, 0xff, 0x57, 0x1e, 0x56, 0xc5, 0x85, 0xc1, 0x15, 0x0b, 0xae, 0xa2,
+	0x3a, 0xac, 0x75, 0xc7, 0xa6, 0xc5, 0x18, 0xe6, 0x29, 0xe6, 0xb8, 0xc4, 0x64, 0xe3, 0x21, 0x56,
+	0xf6, 0xae, 0x76, 0xc7, 0x7b, 0x93, 0x95, 0xc3, 0xf1, 0x90, 0xdf, 0x2d, 0xe5, 0xee, 0xd8, 0x9c,
+	0x58, 0x94, 0x9f, 0x6a, 0x51, 0xa9, 0x3b, 0xee, 0x04, 0x36, 0x6d, 0xc3, 0x6a, 0x77, 0x6c, 0xe2,
+	0x33, 0x8e, 0xa4, 0x66, 0x17, 0x1f, 0xbb, 0x1e, 0x56, 0x95, 0x6e, 0xa5, 0x3b, 0x6e, 0x49, 0x7a,
+	0x43, 0x90, 0xd1, 0x16, 0x54, 0x43, 0x58, 0xeb, 0x98, 0xa9, 0xea, 0x3f, 0x67, 0x54, 0x02, 0xe8,
+	0x1e, 0xa7, 0xa2, 0x3b, 0x82, 0x2b, 0x71, 0xf9, 0x6d, 0x81, 0x89, 0x49, 0x1d, 0xd2, 0xc3, 0xaa,
+	0xf8, 0x55, 0xba, 0xe3, 0x03, 0x97, 0x75, 0x30, 0x26, 0x1d, 0x4e, 0x8d, 0x5d, 0x6e, 0x8b, 0x17,
+	0xba, 0xdc, 0xfe, 0x94, 0x03, 0x14, 0xf6, 0xa2, 0x4a, 0x9a, 0xfb, 0xb0, 0x40, 0x5c, 0x3b, 0x28,
+	0x71, 0x5a, 0xd4, 0x1f, 0xd2, 0x89, 0xd8, 0x3e, 0xe0, 0x91, 0x29, 0x81, 0x1f, 0xe4, 0xa6, 0xbd,
+	0x0e, 0x57, 0xb9, 0x2e, 0x4d, 0xf7, 0x77, 0x84, 0x32, 0x0f, 0x5b, 0x83, 0xc8, 0xd9, 0xea, 0x7f,
+	0xc8, 0x41, 0x35, 0xbe, 0xc6, 0x5f, 0x2c, 0x54, 0xbc, 0xdc, 0x26, 0xe5, 0xac, 0x20, 0x09, 0x6d,
+	0x1b, 0x7d, 0x0a, 0x25, 0x0f, 0x0f, 0x5d, 0x8f, 0x61, 0x9b, 0xdf, 0x21, 0x79, 0xe1, 0x46, 0xf0,
+	0x49, 0x7b, 0x0c, 0xed, 0xc2, 0xa2, 0x08, 0x6c, 0x7e, 0x45, 0xcd, 0x32, 0x54, 0x21, 0xf5, 0x11,
+	0x5c, 0x4b, 0xd6, 0x52, 0xf9, 0xee, 0x08, 0x56, 0xed, 0x60, 0xcd, 0x54, 0xec, 0xa5, 0x1f, 0xb7,
+	0x52, 0x0b, 0x40, 0x9c, 0x59, 0xd5, 0x8e, 0x51, 0xf4, 0x1d, 0x58, 0x6d, 0xbd, 0x75, 0x7a, 0xf2,
+	0xa4, 0xfc, 0x70, 0xd7, 0xc0, 0x37, 0xb6, 0x19, 0x33, 0xbe, 0xa9, 0x37, 0x01, 0x85, 0x37, 0x28,
+	0xed, 0xea, 0x30, 0xcf, 0x0f, 0x4c, 0xbd, 0xd2, 0xa6, 0xd9, 0x2b, 0x70, 0xfa, 0x9f, 0x73, 0xb0,
+	0xd2, 0xb0, 0x48, 0x44, 0xea, 0x54, 0x9f, 0xef, 0x42, 0xc1, 0x4f, 0x27, 0x15, 0x06, 0x69, 0xd9,
+	0x14, 0xe0, 0xd0, 0x06, 0x2c, 0x7a, 0xd8, 0xa2, 0x2e, 0x51, 0xd5, 0x46, 0x7d, 0xf9, 0x95, 0x69,
+	0x3e, 0xa8, 0x4c, 0xfa, 0xef, 0xa1, 0x3a, 0xd1, 0x46, 0x99, 0xb4, 0x05, 0x73, 0x5d, 0xcb, 0x7f,
+	0x77, 0xc6, 0x84, 0x09, 0x64, 0xc3, 0x22, 0x06, 0x87, 0xa0, 0xc7, 0xb0, 0x8c, 0xb9, 0x4b, 0xb0,
+	0x6d, 0xca, 0xf0, 0xce, 0xcf, 0x3c, 0xf5, 0xb2, 0xda, 0xc0, 0x3f, 0xa8, 0x6e, 0xc3, 0xea, 0x11,
+	0xe9, 0x7e, 0x64, 0x77, 0xe8, 0xbf, 0x00, 0x14, 0x96, 0xf2, 0xbe, 0x66, 0xea, 0x1b, 0x70, 0x29,
+	0xc8, 0xe9, 0x86, 0x45, 0x82, 0x04, 0xda, 0x87, 0xf5, 0x18, 0x5d, 0xb1, 0xde, 0x86, 0xf9, 0xae,
+	0x45, 0xfc, 0x28, 0x4d, 0xe3, 0x2d, 0x30, 0x3a, 0x85, 0xb5, 0x67, 0x0e, 0x61, 0xbf, 0xfe, 0xea,
+	0xfe, 0xa3, 0xce, 0x0f, 0xed, 0x66, 0x26, 0x27, 0x54, 0x61, 0xae, 0x47, 0xa5, 0xfd, 0x65, 0x83,
+	0xff, 0xf4, 0x4f, 0x76, 0x6e, 0x72, 0xe7, 0x5c, 0x85, 0xa2, 0x4d, 0xa8, 0x49, 0xac, 0x01, 0x96,
+	0x17, 0x4b, 0xd1, 0x28, 0xd8, 0x84, 0x1e, 0xf0, 0x6f, 0xfd, 0x39, 0x5c, 0x8a, 0x0a, 0x55, 0x8a,
+	0x5f, 0x07, 0xa0, 0x6f, 0x1d, 0xdb, 0xec, 0x9d, 0x5a, 0x0e, 0x11, 0xea, 0x97, 0x8d, 0x22, 0xa7,
+	0xec, 0x73, 0x02, 0xbf, 0x76, 0x3c, 0xd7, 0x65, 0x66, 0xcf, 0x92, 0x47, 0x5d, 0x36, 0x96, 0xf8,
+	0xf7, 0xbe, 0x45, 0x75, 0x13, 0x10, 0xe7, 0xf8, 0xf4, 0xc5, 0xe1, 0xfb, 0x58, 0x11, 0xbb, 0x27,
+	0x35, 0x28, 0x58, 0x23, 0xdb, 0xc1, 0xbc, 0x46, 0xcf, 0x49, 0x95, 0xfd, 0x6f, 0xfd, 0x2e, 0xac,
+	0x45, 0x04, 0x28, 0x8d, 0x13, 0xaf, 0x60, 0xfd, 0x7b, 0x58, 0x37, 0xf0, 0x5b, 0xf7, 0x35, 0x56,
+	0xf0, 0xe0, 0x3e, 0xbb, 0x0c, 0x4b, 0xaf, 0xf1, 0xd8, 0x74, 0x6c, 0x79, 0x38, 0x45, 0x63, 0xf1,
+	0x35, 0x1e, 0xb7, 0x6d, 0xf1, 0x7e, 0x0e, 0x34, 0x95, 0xc6, 0x15, 0x8d, 0xa2, 0xaf, 0x2a, 0xd5,
+	0x7f, 0x05, 0x97, 0x8f, 0x88, 0xf7, 0x41, 0x59, 0x76, 0x61, 0x99, 0x27, 0xc1, 0xe4, 0x02, 0x9c,
+	0xea, 0xac, 0xc8, 0x3b, 0x21, 0x9f, 0xf1, 0x9d, 0xa0, 0x3f, 0x84, 0xcb, 0xbf, 0xc4, 0x2c, 0x22,
+	0x26, 0xcb, 0xd1, 0xe8, 0x26, 0xd4, 0xce, 0xef, 0x53, 0x1e, 0xdf, 0x0f, 0x6b, 0x22, 0xb3, 0xe7,
+	0x76, 0x5a, 0x1d, 0x8e, 0x72, 0x08, 0x29, 0x76, 0x0a, 0xeb, 0xad, 0xb3, 0x61, 0xdf, 0x72, 0x48,
+	0xac, 0xf7, 0x0d, 0x3f, 0x95, 0x72, 0x53, 0x9e, 0x4a, 0x99, 0x5d, 0xf0, 0x5b, 0x58, 0x6e, 0x9d,
+	0xf5, 0xfa, 0x23, 0x1b, 0xdb, 0xa2, 0x99, 0xbd, 0xe8, 0x74, 0x61, 0x52, 0x53, 0xf3, 0xe1, 0x9a,
+	0xaa, 0xff, 0x27, 0x07, 0x1b, 0x71, 0x53, 0x94, 0xa7, 0xbe, 0x81, 0x0a, 0x2f, 0x8b, 0x66, 0xd8,
+	0x5d, 0xd3, 0xb4, 0x5e, 0x26, 0x91, 0x78, 0x78, 0x0c, 0x60, 0x8d, 0xd8, 0xa9, 0xeb, 0x39, 0xef,
+	0xb0, 0xad, 0x0c, 0x9e, 0xa9, 0x6d, 0x68, 0x0b, 0xda, 0x83, 0x02, 0x56, 0xa6, 0xab, 0xfb, 0x38,
+	0xf5, 0xa0, 0x22, 0x2e, 0x32, 0x82, 0x6d, 0xbb, 0x7f, 0xbc, 0x06, 0xe5, 0xb0, 0x10, 0xf4, 0x0a,
+	0x4a, 0xa1, 0xe9, 0x0d, 0x9a, 0xa5, 0x8f, 0x76, 0x37, 0x4d, 0x62, 0xd2, 0x88, 0xe9, 0x0d, 0x6c,
+	0x24, 0x8f, 0x86, 0x66, 0xcb, 0x79, 0x98, 0x26, 0x67, 0xc6, 0xac, 0xe9, 0x15, 0x94, 0x64, 0xb7,
+	0x20, 0xed, 0x79, 0x1f, 0x75, 0xb5, 0x59, 0x4a, 0xa1, 0x97, 0x00, 0x4f, 0x30, 0xeb, 0x9d, 0x7e,
+	0x0c, 0xde, 0x4f, 0xa0, 0x1c, 0xf0, 0x76, 0x30, 0x45, 0x6b, 0xd1, 0x0d, 0xad, 0xc1, 0x90, 0x8d,
+	0xb5, 0x1b, 0xd3, 0xb9, 0xf0, 0x7d, 0x2f, 0xa1, 0x14, 0x9a, 0x89, 0xa1, 0xed, 0x34, 0x25, 0xcf,
+	0x0f, 0xce, 0x66, 0xeb, 0x78, 0x04, 0x15, 0x7e, 0x41, 0x36, 0xc6, 0xc1, 0xa0, 0x70, 0x33, 0xfd,
+	0x09, 0x2b, 0x11, 0x59, 0x54, 0xfe, 0xd6, 0x67, 0xdb, 0x09, 0x1e, 0x3c, 0xc9, 0x19, 0x95, 0x85,
+	0xd9, 0x33, 0x58, 0x89, 0x32, 0xa3, 0xe8, 0x72, 0x32, 0x37, 0x9a, 0x85, 0x5d, 0x60, 0x72, 0x30,
+	0xff, 0x4c, 0x35, 0xd9, 0x47, 0x64, 0x61, 0x7b, 0x06, 0x97, 0xa3, 0xd3, 0xbc, 0x17, 0x0e, 0x3b,
+	0x7d, 0x6e, 0x9d, 0x60, 0x8a, 0xbe, 0x48, 0xe3, 0x9f, 0x38, 0x5c, 0xd4, 0xea, 0x59, 0xe1, 0xc1,
+	0xf3, 0x7b, 0x5d, 0xa6, 0x50, 0x7c, 0x68, 0xf7, 0x79, 0xc6, 0x39, 0x8d, 0x96, 0x14, 0x99, 0xe8,
+	0x47, 0xb8, 0x24, 0xc2, 0x37, 0xce, 0xf5, 0x4e, 0x46, 0xae, 0xed, 0xa6, 0x96, 0x55, 0x01, 0xf4,
+	0x83, 0x7c, 0xbf, 0xc5, 0xc8, 0x29, 0x29, 0x93, 0x95, 0xeb, 0xfd, 0x1c, 0x77, 0x8d, 0xcc, 0x8a,
+	0x0f, 0xeb, 0x9a, 0x2e, 0xac, 0x27, 0x0e, 0x30, 0xd0, 0x83, 0x8b, 0xcc, 0x3b, 0x92, 0x65, 0xfc,
+	0x2d, 0x07, 0xd7, 0xa7, 0x0e, 0xe8, 0xd0, 0xcf, 0xa7, 0xc5, 0xc9, 0xac, 0x11, 0xa9, 0xf6, 0xcd,
+	0x05, 0x77, 0xab, 0xa0, 0xfb, 0x11, 0xae, 0x45, 0x82, 0x2e, 0x36, 0x47, 0x42, 0x99, 0xa6, 0x4d,
+	0x5a, 0x26, 0x14, 0xfa, 0x4b, 0x4e, 0xb6, 0xc9, 0xc9, 0xcb, 0x14, 0xfd, 0x2c, 0x83, 0x29, 0x29,
+	0x33, 0x35, 0xed, 0xeb, 0x0b, 0xed, 0x9d, 0x38, 0x21, 0x12, 0x5e, 0x1f, 0xd3, 0x09, 0x3f, 0xc5,
+	0x27, 0x71, 0x71, 0xc0, 0xd7, 0x99, 0x62, 0x2f, 0x79, 0x52, 0x9a, 0x51, 0x87, 0x17, 0xb0, 0x22,
+	0x0f, 0x7d, 0x32, 0x60, 0xbb, 0x91, 0x26, 0x35, 0x80, 0x68, 0xb3, 0x21, 0xa8, 0x01, 0x25, 0x51,
+	0x6b, 0x54, 0x1a, 0x25, 0xa6, 0xfd, 0x27, 0x69, 0x6c, 0xd4, 0xa6, 0x1e, 0xc0, 0xa4, 0xfb, 0x4f,
+	0xaf, 0x52, 0xe7, 0x46, 0x0a, 0xda, 0x76, 0x16, 0xa8, 0x3a, 0xf1, 0x1e, 0xc0, 0x64, 0x78, 0x94,
+	0x2e, 0xe4, 0xdc, 0x98, 0x4e, 0xdb, 0xce, 0x02, 0x55, 0x42, 0x7e, 0x03, 0x05, 0xbf, 0xe5, 0x4f,
+	0x2f, 0x54, 0xb1, 0x11, 0x85, 0xb6, 0x35, 0x1b, 0x38, 0xb1, 0x61, 0xd2, 0x6c, 0xa7, 0xdb, 0x70,
+	0xae, 0xed, 0xd7, 0xb6, 0xb3, 0x40, 0x95, 0x90, 0x3e, 0x2c, 0x47, 0x3a, 0x6f, 0x74, 0x6f, 0xa6,
+	0x03, 0x42, 0x8d, 0xbb, 0xf6, 0x45, 0x46, 0xb4, 0x92, 0xf6, 0x53, 0x4e, 0x5e, 0x20, 0xe7, 0x86,
+	0x65, 0x5f, 0x4e, 0xe3, 0x93, 0x32, 0x76, 0xd3, 0x1e, 0xbc, 0xdf, 0x26, 0xa5, 0x83, 0x03, 0xe5,
+	0x70, 0xc7, 0x9e, 0xfe, 0x98, 0x4c, 0x18, 0x26, 0x68, 0xf7, 0xb2, 0x81, 0x95, 0xa8, 0x63, 0x28,
+	0x85, 0x3a, 0xed, 0xf4, 0x17, 0xe1, 0xf9, 0x7e, 0x5f, 0xbb, 0x9b, 0x09, 0xab, 0xe4, 0x98, 0x50,
+	0x89, 0x36, 0xe9, 0xe9, 0x4f, 0x99, 0xc4, 0x66, 0x3e, 0x43, 0xce, 0x56, 0xe3, 0x4d, 0x3b, 0xda,
+	0x49, 0x8f, 0x32, 0xef, 0x42, 0x42, 0x46, 0x50, 0x8d, 0xb7, 0xca, 0xe9, 0x42, 0x52, 0x9a, 0x71,
+	0xed, 0x7e, 0xf6, 0x0d, 0xca, 0x79, 0x2e, 0x54, 0xa2, 0x5d, 0x67, 0xba, 0xf3, 0x12, 0x1b, 0x6d,
+	0xad, 0x9e, 0x15, 0x2e, 0x05, 0x36, 0x1e, 0xbe, 0x7c, 0x70, 0xe2, 0xb0, 0xd3, 0x51, 0x97, 0xd7,
+	0xcd, 0x1d, 0x39, 0x29, 0xd8, 0x91, 0xff, 0x44, 0x20, 0xfe, 0x6d, 0x40, 0xfd, 0xb6, 0x86, 0xce,
+	0x4e, 0x98, 0x5d, 0x77, 0x51, 0xac, 0x7e, 0xf9, 0xdf, 0x01, 0x00, 0x20, 0x64, 0xe6, 0xde, 0x9d,
+	0x20, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1294,20 +2499,50 @@ type RegistrationClient interface {
 	UpdateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error)
 	// Delete a particular Federated Bundle. Used to destroy inter-domain trust.
 	DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*common.Empty, error)
+	// ListFederatedBundleSyncStatus will list the synchronization status of the bundles fetched from federated bundle endpoints
+	ListFederatedBundleSyncStatus(ctx context.Context, in *ListFederatedBundleSyncStatusRequest, opts ...grpc.CallOption) (*ListFederatedBundleSyncStatusResponse, error)
+	// Creates a federation relationship. The bundle endpoint of the federated trust domain is polled without restarting the server.
+	CreateFederationRelationship(ctx context.Context, in *common.FederationRelationship, opts ...grpc.CallOption) (*common.FederationRelationship, error)
+	// Lists the federation relationships managed through the API
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	// Updates a federation relationship
+	UpdateFederationRelationship(ctx context.Context, in *common.FederationRelationship, opts ...grpc.CallOption) (*common.FederationRelationship, error)
+	// Deletes a federation relationship. The federated bundle is kept unless
+	// requested otherwise.
+	DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*common.FederationRelationship, error)
 	// Create a new join token
 	CreateJoinToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*JoinToken, error)
 	// Retrieves the CA bundle.
 	FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(ctx context.Context, in *EvictAgentRequest, opts ...grpc.CallOption) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// ListAgents will list attested nodes (optionally filtered)
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// BanAgent evicts the matching agents and prevents them from attesting
+	// again until they are unbanned or the ban expires
+	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error)
+	// UnbanAgent removes an agent ban
+	UnbanAgent(ctx context.Context, in *UnbanAgentRequest, opts ...grpc.CallOption) (*UnbanAgentResponse, error)
+	// ListAgentBans lists the agent bans
+	ListAgentBans(ctx context.Context, in *ListAgentBansRequest, opts ...grpc.CallOption) (*ListAgentBansResponse, error)
+	// ListDownstreamAgents will list the agents reported by downstream SPIRE servers
+	ListDownstreamAgents(ctx context.Context, in *ListDownstreamAgentsRequest, opts ...grpc.CallOption) (*ListDownstreamAgentsResponse, error)
 	// MintX509SVID mints an X509-SVID directly with the SPIRE server CA.
 	MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error)
 	// MintJWTSVID mints a JWT-SVID directly with the SPIRE server CA.
 	MintJWTSVID(ctx context.Context, in *MintJWTSVIDRequest, opts ...grpc.CallOption) (*MintJWTSVIDResponse, error)
+	// RevokeJWTSVIDs publishes revoked JWT signing key IDs and subjects in
+	// the server bundle so agents stop accepting the matching JWT-SVIDs.
+	RevokeJWTSVIDs(ctx context.Context, in *RevokeJWTSVIDsRequest, opts ...grpc.CallOption) (*Bundle, error)
+	// UnrevokeJWTSVIDs removes JWT signing key IDs and subjects from the
+	// revocations published in the server bundle.
+	UnrevokeJWTSVIDs(ctx context.Context, in *UnrevokeJWTSVIDsRequest, opts ...grpc.CallOption) (*Bundle, error)
 	// GetNodeSelectors gets node (agent) selectors
 	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
+	// ExplainEntries reports which entries would be issued to a workload
+	// with the given selectors running on the given agent, and why nearly
+	// matching entries would not be.
+	ExplainEntries(ctx context.Context, in *ExplainEntriesRequest, opts ...grpc.CallOption) (*ExplainEntriesResponse, error)
 }
 
 type registrationClient struct {
@@ -1455,30 +2690,75 @@ type Registration_ListFederatedBundlesClient interface {
 	grpc.ClientStream
 }
 
-type registrationListFederatedBundlesClient struct {
-	grpc.ClientStream
+type registrationListFederatedBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *registrationListFederatedBundlesClient) Recv() (*FederatedBundle, error) {
+	m := new(FederatedBundle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registrationClient) UpdateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UpdateFederatedBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/DeleteFederatedBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ListFederatedBundleSyncStatus(ctx context.Context, in *ListFederatedBundleSyncStatusRequest, opts ...grpc.CallOption) (*ListFederatedBundleSyncStatusResponse, error) {
+	out := new(ListFederatedBundleSyncStatusResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListFederatedBundleSyncStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) CreateFederationRelationship(ctx context.Context, in *common.FederationRelationship, opts ...grpc.CallOption) (*common.FederationRelationship, error) {
+	out := new(common.FederationRelationship)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/CreateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *registrationListFederatedBundlesClient) Recv() (*FederatedBundle, error) {
-	m := new(FederatedBundle)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *registrationClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListFederationRelationships", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *registrationClient) UpdateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error) {
-	out := new(common.Empty)
-	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UpdateFederatedBundle", in, out, opts...)
+func (c *registrationClient) UpdateFederationRelationship(ctx context.Context, in *common.FederationRelationship, opts ...grpc.CallOption) (*common.FederationRelationship, error) {
+	out := new(common.FederationRelationship)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UpdateFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registrationClient) DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*common.Empty, error) {
-	out := new(common.Empty)
-	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/DeleteFederatedBundle", in, out, opts...)
+func (c *registrationClient) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*common.FederationRelationship, error) {
+	out := new(common.FederationRelationship)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/DeleteFederationRelationship", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1521,6 +2801,42 @@ func (c *registrationClient) ListAgents(ctx context.Context, in *ListAgentsReque
 	return out, nil
 }
 
+func (c *registrationClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error) {
+	out := new(BanAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BanAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) UnbanAgent(ctx context.Context, in *UnbanAgentRequest, opts ...grpc.CallOption) (*UnbanAgentResponse, error) {
+	out := new(UnbanAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UnbanAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ListAgentBans(ctx context.Context, in *ListAgentBansRequest, opts ...grpc.CallOption) (*ListAgentBansResponse, error) {
+	out := new(ListAgentBansResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListAgentBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ListDownstreamAgents(ctx context.Context, in *ListDownstreamAgentsRequest, opts ...grpc.CallOption) (*ListDownstreamAgentsResponse, error) {
+	out := new(ListDownstreamAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListDownstreamAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error) {
 	out := new(MintX509SVIDResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/MintX509SVID", in, out, opts...)
@@ -1539,6 +2855,24 @@ func (c *registrationClient) MintJWTSVID(ctx context.Context, in *MintJWTSVIDReq
 	return out, nil
 }
 
+func (c *registrationClient) RevokeJWTSVIDs(ctx context.Context, in *RevokeJWTSVIDsRequest, opts ...grpc.CallOption) (*Bundle, error) {
+	out := new(Bundle)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/RevokeJWTSVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) UnrevokeJWTSVIDs(ctx context.Context, in *UnrevokeJWTSVIDsRequest, opts ...grpc.CallOption) (*Bundle, error) {
+	out := new(Bundle)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UnrevokeJWTSVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
 	out := new(GetNodeSelectorsResponse)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/GetNodeSelectors", in, out, opts...)
@@ -1548,6 +2882,15 @@ func (c *registrationClient) GetNodeSelectors(ctx context.Context, in *GetNodeSe
 	return out, nil
 }
 
+func (c *registrationClient) ExplainEntries(ctx context.Context, in *ExplainEntriesRequest, opts ...grpc.CallOption) (*ExplainEntriesResponse, error) {
+	out := new(ExplainEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ExplainEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationServer is the server API for Registration service.
 type RegistrationServer interface {
 	// Creates an entry in the Registration table, used to assign SPIFFE IDs to nodes and workloads.
@@ -1582,20 +2925,50 @@ type RegistrationServer interface {
 	UpdateFederatedBundle(context.Context, *FederatedBundle) (*common.Empty, error)
 	// Delete a particular Federated Bundle. Used to destroy inter-domain trust.
 	DeleteFederatedBundle(context.Context, *DeleteFederatedBundleRequest) (*common.Empty, error)
+	// ListFederatedBundleSyncStatus will list the synchronization status of the bundles fetched from federated bundle endpoints
+	ListFederatedBundleSyncStatus(context.Context, *ListFederatedBundleSyncStatusRequest) (*ListFederatedBundleSyncStatusResponse, error)
+	// Creates a federation relationship. The bundle endpoint of the federated trust domain is polled without restarting the server.
+	CreateFederationRelationship(context.Context, *common.FederationRelationship) (*common.FederationRelationship, error)
+	// Lists the federation relationships managed through the API
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	// Updates a federation relationship
+	UpdateFederationRelationship(context.Context, *common.FederationRelationship) (*common.FederationRelationship, error)
+	// Deletes a federation relationship. The federated bundle is kept unless
+	// requested otherwise.
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*common.FederationRelationship, error)
 	// Create a new join token
 	CreateJoinToken(context.Context, *JoinToken) (*JoinToken, error)
 	// Retrieves the CA bundle.
 	FetchBundle(context.Context, *common.Empty) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(context.Context, *EvictAgentRequest) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// ListAgents will list attested nodes (optionally filtered)
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// BanAgent evicts the matching agents and prevents them from attesting
+	// again until they are unbanned or the ban expires
+	BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error)
+	// UnbanAgent removes an agent ban
+	UnbanAgent(context.Context, *UnbanAgentRequest) (*UnbanAgentResponse, error)
+	// ListAgentBans lists the agent bans
+	ListAgentBans(context.Context, *ListAgentBansRequest) (*ListAgentBansResponse, error)
+	// ListDownstreamAgents will list the agents reported by downstream SPIRE servers
+	ListDownstreamAgents(context.Context, *ListDownstreamAgentsRequest) (*ListDownstreamAgentsResponse, error)
 	// MintX509SVID mints an X509-SVID directly with the SPIRE server CA.
 	MintX509SVID(context.Context, *MintX509SVIDRequest) (*MintX509SVIDResponse, error)
 	// MintJWTSVID mints a JWT-SVID directly with the SPIRE server CA.
 	MintJWTSVID(context.Context, *MintJWTSVIDRequest) (*MintJWTSVIDResponse, error)
+	// RevokeJWTSVIDs publishes revoked JWT signing key IDs and subjects in
+	// the server bundle so agents stop accepting the matching JWT-SVIDs.
+	RevokeJWTSVIDs(context.Context, *RevokeJWTSVIDsRequest) (*Bundle, error)
+	// UnrevokeJWTSVIDs removes JWT signing key IDs and subjects from the
+	// revocations published in the server bundle.
+	UnrevokeJWTSVIDs(context.Context, *UnrevokeJWTSVIDsRequest) (*Bundle, error)
 	// GetNodeSelectors gets node (agent) selectors
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
+	// ExplainEntries reports which entries would be issued to a workload
+	// with the given selectors running on the given agent, and why nearly
+	// matching entries would not be.
+	ExplainEntries(context.Context, *ExplainEntriesRequest) (*ExplainEntriesResponse, error)
 }
 
 // UnimplementedRegistrationServer can be embedded to have forward compatible implementations.
@@ -1650,6 +3023,21 @@ func (*UnimplementedRegistrationServer) UpdateFederatedBundle(ctx context.Contex
 func (*UnimplementedRegistrationServer) DeleteFederatedBundle(ctx context.Context, req *DeleteFederatedBundleRequest) (*common.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederatedBundle not implemented")
 }
+func (*UnimplementedRegistrationServer) ListFederatedBundleSyncStatus(ctx context.Context, req *ListFederatedBundleSyncStatusRequest) (*ListFederatedBundleSyncStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederatedBundleSyncStatus not implemented")
+}
+func (*UnimplementedRegistrationServer) CreateFederationRelationship(ctx context.Context, req *common.FederationRelationship) (*common.FederationRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFederationRelationship not implemented")
+}
+func (*UnimplementedRegistrationServer) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (*UnimplementedRegistrationServer) UpdateFederationRelationship(ctx context.Context, req *common.FederationRelationship) (*common.FederationRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederationRelationship not implemented")
+}
+func (*UnimplementedRegistrationServer) DeleteFederationRelationship(ctx context.Context, req *DeleteFederationRelationshipRequest) (*common.FederationRelationship, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationRelationship not implemented")
+}
 func (*UnimplementedRegistrationServer) CreateJoinToken(ctx context.Context, req *JoinToken) (*JoinToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateJoinToken not implemented")
 }
@@ -1662,15 +3050,36 @@ func (*UnimplementedRegistrationServer) EvictAgent(ctx context.Context, req *Evi
 func (*UnimplementedRegistrationServer) ListAgents(ctx context.Context, req *ListAgentsRequest) (*ListAgentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
 }
+func (*UnimplementedRegistrationServer) BanAgent(ctx context.Context, req *BanAgentRequest) (*BanAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanAgent not implemented")
+}
+func (*UnimplementedRegistrationServer) UnbanAgent(ctx context.Context, req *UnbanAgentRequest) (*UnbanAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanAgent not implemented")
+}
+func (*UnimplementedRegistrationServer) ListAgentBans(ctx context.Context, req *ListAgentBansRequest) (*ListAgentBansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgentBans not implemented")
+}
+func (*UnimplementedRegistrationServer) ListDownstreamAgents(ctx context.Context, req *ListDownstreamAgentsRequest) (*ListDownstreamAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDownstreamAgents not implemented")
+}
 func (*UnimplementedRegistrationServer) MintX509SVID(ctx context.Context, req *MintX509SVIDRequest) (*MintX509SVIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MintX509SVID not implemented")
 }
 func (*UnimplementedRegistrationServer) MintJWTSVID(ctx context.Context, req *MintJWTSVIDRequest) (*MintJWTSVIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MintJWTSVID not implemented")
 }
+func (*UnimplementedRegistrationServer) RevokeJWTSVIDs(ctx context.Context, req *RevokeJWTSVIDsRequest) (*Bundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeJWTSVIDs not implemented")
+}
+func (*UnimplementedRegistrationServer) UnrevokeJWTSVIDs(ctx context.Context, req *UnrevokeJWTSVIDsRequest) (*Bundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnrevokeJWTSVIDs not implemented")
+}
 func (*UnimplementedRegistrationServer) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeSelectors not implemented")
 }
+func (*UnimplementedRegistrationServer) ExplainEntries(ctx context.Context, req *ExplainEntriesRequest) (*ExplainEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainEntries not implemented")
+}
 
 func RegisterRegistrationServer(s *grpc.Server, srv RegistrationServer) {
 	s.RegisterService(&_Registration_serviceDesc, srv)
@@ -1967,6 +3376,96 @@ func _Registration_DeleteFederatedBundle_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ListFederatedBundleSyncStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederatedBundleSyncStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListFederatedBundleSyncStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListFederatedBundleSyncStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListFederatedBundleSyncStatus(ctx, req.(*ListFederatedBundleSyncStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_CreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.FederationRelationship)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).CreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/CreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).CreateFederationRelationship(ctx, req.(*common.FederationRelationship))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListFederationRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_UpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.FederationRelationship)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).UpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/UpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).UpdateFederationRelationship(ctx, req.(*common.FederationRelationship))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_DeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).DeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/DeleteFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).DeleteFederationRelationship(ctx, req.(*DeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinToken)
 	if err := dec(in); err != nil {
@@ -2039,6 +3538,78 @@ func _Registration_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_BanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).BanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/BanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).BanAgent(ctx, req.(*BanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_UnbanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).UnbanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/UnbanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).UnbanAgent(ctx, req.(*UnbanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListAgentBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentBansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListAgentBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListAgentBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListAgentBans(ctx, req.(*ListAgentBansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListDownstreamAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDownstreamAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListDownstreamAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListDownstreamAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListDownstreamAgents(ctx, req.(*ListDownstreamAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_MintX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MintX509SVIDRequest)
 	if err := dec(in); err != nil {
@@ -2075,6 +3646,42 @@ func _Registration_MintJWTSVID_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_RevokeJWTSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJWTSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).RevokeJWTSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/RevokeJWTSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).RevokeJWTSVIDs(ctx, req.(*RevokeJWTSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_UnrevokeJWTSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnrevokeJWTSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).UnrevokeJWTSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/UnrevokeJWTSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).UnrevokeJWTSVIDs(ctx, req.(*UnrevokeJWTSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNodeSelectorsRequest)
 	if err := dec(in); err != nil {
@@ -2093,6 +3700,24 @@ func _Registration_GetNodeSelectors_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ExplainEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ExplainEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ExplainEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ExplainEntries(ctx, req.(*ExplainEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Registration_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.registration.Registration",
 	HandlerType: (*RegistrationServer)(nil),
@@ -2157,6 +3782,26 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteFederatedBundle",
 			Handler:    _Registration_DeleteFederatedBundle_Handler,
 		},
+		{
+			MethodName: "ListFederatedBundleSyncStatus",
+			Handler:    _Registration_ListFederatedBundleSyncStatus_Handler,
+		},
+		{
+			MethodName: "CreateFederationRelationship",
+			Handler:    _Registration_CreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _Registration_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "UpdateFederationRelationship",
+			Handler:    _Registration_UpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteFederationRelationship",
+			Handler:    _Registration_DeleteFederationRelationship_Handler,
+		},
 		{
 			MethodName: "CreateJoinToken",
 			Handler:    _Registration_CreateJoinToken_Handler,
@@ -2173,6 +3818,22 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _Registration_ListAgents_Handler,
 		},
+		{
+			MethodName: "BanAgent",
+			Handler:    _Registration_BanAgent_Handler,
+		},
+		{
+			MethodName: "UnbanAgent",
+			Handler:    _Registration_UnbanAgent_Handler,
+		},
+		{
+			MethodName: "ListAgentBans",
+			Handler:    _Registration_ListAgentBans_Handler,
+		},
+		{
+			MethodName: "ListDownstreamAgents",
+			Handler:    _Registration_ListDownstreamAgents_Handler,
+		},
 		{
 			MethodName: "MintX509SVID",
 			Handler:    _Registration_MintX509SVID_Handler,
@@ -2181,10 +3842,22 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MintJWTSVID",
 			Handler:    _Registration_MintJWTSVID_Handler,
 		},
+		{
+			MethodName: "RevokeJWTSVIDs",
+			Handler:    _Registration_RevokeJWTSVIDs_Handler,
+		},
+		{
+			MethodName: "UnrevokeJWTSVIDs",
+			Handler:    _Registration_UnrevokeJWTSVIDs_Handler,
+		},
 		{
 			MethodName: "GetNodeSelectors",
 			Handler:    _Registration_GetNodeSelectors_Handler,
 		},
+		{
+			MethodName: "ExplainEntries",
+			Handler:    _Registration_ExplainEntries_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -20,7 +20,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Represents an empty message
+// * Represents an empty message
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -52,7 +52,7 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
-//* A type which contains attestation data for specific platform.
+// * A type which contains attestation data for specific platform.
 type AttestationData struct {
 	//* Type of attestation to perform.
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -102,8 +102,8 @@ func (m *AttestationData) GetData() []byte {
 	return nil
 }
 
-//* A type which describes the conditions under which a registration
-//entry is matched.
+// * A type which describes the conditions under which a registration
+// entry is matched.
 type Selector struct {
 	//* A selector type represents the type of attestation used in attesting
 	//the entity (Eg: AWS, K8).
@@ -154,7 +154,7 @@ func (m *Selector) GetValue() string {
 	return ""
 }
 
-//* Represents a type with a list of Selector.
+// * Represents a type with a list of Selector.
 type Selectors struct {
 	//* A list of Selector.
 	Entries              []*Selector `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -208,10 +208,19 @@ type AttestedNode struct {
 	// Node certificate serial number
 	NewCertSerialNumber string `protobuf:"bytes,5,opt,name=new_cert_serial_number,json=newCertSerialNumber,proto3" json:"new_cert_serial_number,omitempty"`
 	// Node certificate not_after (seconds since unix epoch)
-	NewCertNotAfter      int64    `protobuf:"varint,6,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	NewCertNotAfter int64 `protobuf:"varint,6,opt,name=new_cert_not_after,json=newCertNotAfter,proto3" json:"new_cert_not_after,omitempty"`
+	// Version of the agent, as last reported by the agent
+	AgentVersion string `protobuf:"bytes,7,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	// Last time the agent attested or synced with the server (seconds since
+	// unix epoch)
+	LastSeenAt int64 `protobuf:"varint,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// Remote address the agent last attested or synced from
+	RemoteAddress string `protobuf:"bytes,9,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	// Node selectors. Only populated when explicitly requested.
+	Selectors            []*Selector `protobuf:"bytes,10,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *AttestedNode) Reset()         { *m = AttestedNode{} }
@@ -281,8 +290,36 @@ func (m *AttestedNode) GetNewCertNotAfter() int64 {
 	return 0
 }
 
-//* This is a curated record that the Server uses to set up and
-//manage the various registered nodes and workloads that are controlled by it.
+func (m *AttestedNode) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *AttestedNode) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
+func (m *AttestedNode) GetRemoteAddress() string {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return ""
+}
+
+func (m *AttestedNode) GetSelectors() []*Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+// * This is a curated record that the Server uses to set up and
+// manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
 	//* A list of selectors.
 	Selectors []*Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
@@ -308,11 +345,26 @@ type RegistrationEntry struct {
 	//* Expiration of this entry, in seconds from epoch
 	EntryExpiry int64 `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
 	//* DNS entries
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
-	IPAddresses          []string `protobuf:"bytes,10,rep,name=ip_address,json=ipAddresses,proto3" json:"ip_address,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	DnsNames    []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	IPAddresses []string `protobuf:"bytes,10,rep,name=ip_address,json=ipAddresses,proto3" json:"ip_address,omitempty"`
+	//* SPIFFE ID path prefixes the CA issued to a downstream entry is
+	//allowed to sign for. Empty means the whole trust domain. The prefixes
+	//are carried in a non-critical X.509 extension that only SPIRE enforces;
+	//other X.509-SVID validators ignore them.
+	DownstreamPathPrefixes []string `protobuf:"bytes,11,rep,name=downstream_path_prefixes,json=downstreamPathPrefixes,proto3" json:"downstream_path_prefixes,omitempty"`
+	//* DNS domains the CA issued to a downstream entry is allowed to sign
+	//for. Empty means any DNS name.
+	DownstreamDnsDomains []string `protobuf:"bytes,12,rep,name=downstream_dns_domains,json=downstreamDnsDomains,proto3" json:"downstream_dns_domains,omitempty"`
+	//* Static claims added to the JWT-SVIDs issued for the entry, keyed by
+	//claim name. Registered JWT claims (e.g. sub, aud) cannot be set.
+	JwtClaims map[string]string `protobuf:"bytes,13,rep,name=jwt_claims,json=jwtClaims,proto3" json:"jwt_claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	//* Templated claims added to the JWT-SVIDs issued for the entry, keyed by
+	//claim name. The values are Go text/templates rendered against the entry
+	//and attested node selectors when the JWT-SVID is signed.
+	JwtClaimTemplates    map[string]string `protobuf:"bytes,14,rep,name=jwt_claim_templates,json=jwtClaimTemplates,proto3" json:"jwt_claim_templates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *RegistrationEntry) Reset()         { *m = RegistrationEntry{} }
@@ -417,7 +469,35 @@ func (m *RegistrationEntry) GetIPAddresses() []string {
 	return nil
 }
 
-//* A list of registration entries.
+func (m *RegistrationEntry) GetDownstreamPathPrefixes() []string {
+	if m != nil {
+		return m.DownstreamPathPrefixes
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetDownstreamDnsDomains() []string {
+	if m != nil {
+		return m.DownstreamDnsDomains
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetJwtClaims() map[string]string {
+	if m != nil {
+		return m.JwtClaims
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetJwtClaimTemplates() map[string]string {
+	if m != nil {
+		return m.JwtClaimTemplates
+	}
+	return nil
+}
+
+// * A list of registration entries.
 type RegistrationEntries struct {
 	//* A list of RegistrationEntry.
 	Entries              []*RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
@@ -458,7 +538,7 @@ func (m *RegistrationEntries) GetEntries() []*RegistrationEntry {
 	return nil
 }
 
-//* Certificate represents a ASN.1/DER encoded X509 certificate
+// * Certificate represents a ASN.1/DER encoded X509 certificate
 type Certificate struct {
 	DerBytes             []byte   `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -498,14 +578,21 @@ func (m *Certificate) GetDerBytes() []byte {
 	return nil
 }
 
-//* PublicKey represents a PKIX encoded public key
+// * PublicKey represents a PKIX encoded public key
 type PublicKey struct {
 	//* PKIX encoded key data
 	PkixBytes []byte `protobuf:"bytes,1,opt,name=pkix_bytes,json=pkixBytes,proto3" json:"pkix_bytes,omitempty"`
 	//* key identifier
 	Kid string `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
 	//* not after (seconds since unix epoch, 0 means "never expires")
-	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	//* SPIFFE ID path prefixes the key is allowed to sign JWT-SVIDs for.
+	//Empty means the whole trust domain.
+	PathPrefixes []string `protobuf:"bytes,4,rep,name=path_prefixes,json=pathPrefixes,proto3" json:"path_prefixes,omitempty"`
+	//* SPIFFE IDs a JWT key delegated to an agent is allowed to sign
+	//JWT-SVIDs for. Only set on delegated keys, which cannot sign for any
+	//other SPIFFE ID, including those nested under these.
+	SpiffeIds            []string `protobuf:"bytes,5,rep,name=spiffe_ids,json=spiffeIds,proto3" json:"spiffe_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -557,6 +644,20 @@ func (m *PublicKey) GetNotAfter() int64 {
 	return 0
 }
 
+func (m *PublicKey) GetPathPrefixes() []string {
+	if m != nil {
+		return m.PathPrefixes
+	}
+	return nil
+}
+
+func (m *PublicKey) GetSpiffeIds() []string {
+	if m != nil {
+		return m.SpiffeIds
+	}
+	return nil
+}
+
 type Bundle struct {
 	//* the SPIFFE ID of the trust domain the bundle belongs to
 	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
@@ -566,7 +667,15 @@ type Bundle struct {
 	JwtSigningKeys []*PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
 	//* refresh hint is a hint, in seconds, on how often a bundle consumer
 	// should poll for bundle updates
-	RefreshHint          int64    `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	RefreshHint int64 `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	//* sequence number of the bundle. It is incremented every time the
+	// bundle contents change so consumers can detect stale bundles
+	SequenceNumber uint64 `protobuf:"varint,5,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	//* key IDs of JWT signing keys whose JWT-SVIDs must no longer be
+	// accepted
+	RevokedJwtKeyIds []string `protobuf:"bytes,6,rep,name=revoked_jwt_key_ids,json=revokedJwtKeyIds,proto3" json:"revoked_jwt_key_ids,omitempty"`
+	//* SPIFFE IDs whose JWT-SVIDs must no longer be accepted
+	RevokedJwtSubjects   []string `protobuf:"bytes,7,rep,name=revoked_jwt_subjects,json=revokedJwtSubjects,proto3" json:"revoked_jwt_subjects,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -625,6 +734,180 @@ func (m *Bundle) GetRefreshHint() int64 {
 	return 0
 }
 
+func (m *Bundle) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *Bundle) GetRevokedJwtKeyIds() []string {
+	if m != nil {
+		return m.RevokedJwtKeyIds
+	}
+	return nil
+}
+
+func (m *Bundle) GetRevokedJwtSubjects() []string {
+	if m != nil {
+		return m.RevokedJwtSubjects
+	}
+	return nil
+}
+
+// * FederationRelationship describes how bundles are obtained from a federated
+// trust domain
+type FederationRelationship struct {
+	//* the SPIFFE ID of the federated trust domain
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	//* URL of the bundle endpoint of the federated trust domain (i.e.
+	// https://domain.test:8443)
+	BundleEndpointUrl string `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl,proto3" json:"bundle_endpoint_url,omitempty"`
+	//* profile used to authenticate the bundle endpoint ("https_spiffe" or
+	// "https_web")
+	BundleEndpointProfile string `protobuf:"bytes,3,opt,name=bundle_endpoint_profile,json=bundleEndpointProfile,proto3" json:"bundle_endpoint_profile,omitempty"`
+	//* expected SPIFFE ID of the bundle endpoint server when using the
+	// "https_spiffe" profile. Defaults to the SPIRE server ID of the
+	// federated trust domain.
+	EndpointSpiffeId     string   `protobuf:"bytes,4,opt,name=endpoint_spiffe_id,json=endpointSpiffeId,proto3" json:"endpoint_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederationRelationship) Reset()         { *m = FederationRelationship{} }
+func (m *FederationRelationship) String() string { return proto.CompactTextString(m) }
+func (*FederationRelationship) ProtoMessage()    {}
+func (*FederationRelationship) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{10}
+}
+
+func (m *FederationRelationship) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationRelationship.Unmarshal(m, b)
+}
+func (m *FederationRelationship) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationRelationship.Marshal(b, m, deterministic)
+}
+func (m *FederationRelationship) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationRelationship.Merge(m, src)
+}
+func (m *FederationRelationship) XXX_Size() int {
+	return xxx_messageInfo_FederationRelationship.Size(m)
+}
+func (m *FederationRelationship) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationRelationship.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationRelationship proto.InternalMessageInfo
+
+func (m *FederationRelationship) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetBundleEndpointUrl() string {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetBundleEndpointProfile() string {
+	if m != nil {
+		return m.BundleEndpointProfile
+	}
+	return ""
+}
+
+func (m *FederationRelationship) GetEndpointSpiffeId() string {
+	if m != nil {
+		return m.EndpointSpiffeId
+	}
+	return ""
+}
+
+// * AgentBan prevents matching agents from attesting to the server. A ban
+// matches either an agent SPIFFE ID or an attestation selector.
+type AgentBan struct {
+	//* SPIFFE ID of the banned agent
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	//* attestation selector of the banned agents (i.e. a certificate
+	// subject or a node UID)
+	Selector *Selector `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	//* reason the agents were banned
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	//* when the ban was created (seconds since unix epoch)
+	CreatedAt int64 `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	//* when the ban expires (seconds since unix epoch, 0 means "never
+	// expires")
+	ExpiresAt            int64    `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AgentBan) Reset()         { *m = AgentBan{} }
+func (m *AgentBan) String() string { return proto.CompactTextString(m) }
+func (*AgentBan) ProtoMessage()    {}
+func (*AgentBan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{11}
+}
+
+func (m *AgentBan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentBan.Unmarshal(m, b)
+}
+func (m *AgentBan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentBan.Marshal(b, m, deterministic)
+}
+func (m *AgentBan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentBan.Merge(m, src)
+}
+func (m *AgentBan) XXX_Size() int {
+	return xxx_messageInfo_AgentBan.Size(m)
+}
+func (m *AgentBan) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentBan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentBan proto.InternalMessageInfo
+
+func (m *AgentBan) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *AgentBan) GetSelector() *Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *AgentBan) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *AgentBan) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *AgentBan) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "spire.common.Empty")
 	proto.RegisterType((*AttestationData)(nil), "spire.common.AttestationData")
@@ -632,57 +915,88 @@ func init() {
 	proto.RegisterType((*Selectors)(nil), "spire.common.Selectors")
 	proto.RegisterType((*AttestedNode)(nil), "spire.common.AttestedNode")
 	proto.RegisterType((*RegistrationEntry)(nil), "spire.common.RegistrationEntry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.common.RegistrationEntry.JwtClaimTemplatesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.common.RegistrationEntry.JwtClaimsEntry")
 	proto.RegisterType((*RegistrationEntries)(nil), "spire.common.RegistrationEntries")
 	proto.RegisterType((*Certificate)(nil), "spire.common.Certificate")
 	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
 	proto.RegisterType((*Bundle)(nil), "spire.common.Bundle")
+	proto.RegisterType((*FederationRelationship)(nil), "spire.common.FederationRelationship")
+	proto.RegisterType((*AgentBan)(nil), "spire.common.AgentBan")
 }
 
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 687 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdd, 0x6e, 0xf3, 0x44,
-	0x10, 0x55, 0xe2, 0x26, 0xb1, 0x27, 0xe9, 0x0f, 0x5b, 0x28, 0xae, 0x10, 0x10, 0x2c, 0x40, 0x51,
-	0xa9, 0x52, 0xd4, 0xf6, 0xa6, 0x17, 0x5c, 0xf4, 0x4f, 0x22, 0xaa, 0x14, 0x55, 0x2e, 0x12, 0x82,
-	0x1b, 0x6b, 0x93, 0x9d, 0x24, 0xdb, 0xc6, 0x6b, 0x6b, 0x77, 0x42, 0xea, 0x07, 0xe1, 0xad, 0x78,
-	0x28, 0xb4, 0xeb, 0xfc, 0x7f, 0x95, 0xbe, 0xbb, 0xd9, 0xe3, 0x99, 0xb3, 0x73, 0xce, 0xcc, 0x1a,
-	0x5a, 0xc3, 0x2c, 0x4d, 0x33, 0xd5, 0xcd, 0x75, 0x46, 0x19, 0x6b, 0x99, 0x5c, 0x6a, 0xec, 0x96,
-	0x58, 0xd4, 0x80, 0xda, 0x63, 0x9a, 0x53, 0x11, 0xdd, 0xc0, 0xe1, 0x2d, 0x11, 0x1a, 0xe2, 0x24,
-	0x33, 0xf5, 0xc0, 0x89, 0x33, 0x06, 0x7b, 0x54, 0xe4, 0x18, 0x56, 0xda, 0x95, 0x4e, 0x10, 0xbb,
-	0xd8, 0x62, 0x82, 0x13, 0x0f, 0xab, 0xed, 0x4a, 0xa7, 0x15, 0xbb, 0x38, 0xba, 0x06, 0xff, 0x05,
-	0xa7, 0x38, 0xa4, 0x4c, 0x7f, 0x58, 0xf3, 0x25, 0xd4, 0xfe, 0xe1, 0xd3, 0x19, 0xba, 0xa2, 0x20,
-	0x2e, 0x0f, 0xd1, 0x6f, 0x10, 0x2c, 0xab, 0x0c, 0xfb, 0x15, 0x1a, 0xa8, 0x48, 0x4b, 0x34, 0x61,
-	0xa5, 0xed, 0x75, 0x9a, 0x97, 0x27, 0xdd, 0xcd, 0x36, 0xbb, 0xcb, 0xcc, 0x78, 0x99, 0x16, 0xfd,
-	0x5b, 0x85, 0x56, 0xd9, 0x30, 0x8a, 0x7e, 0x26, 0x90, 0x7d, 0x03, 0x81, 0xc9, 0xe5, 0x68, 0x84,
-	0x89, 0x14, 0x8b, 0xeb, 0xfd, 0x12, 0xe8, 0x09, 0x76, 0x09, 0x5f, 0xf1, 0xb5, 0xba, 0xc4, 0xb6,
-	0x9d, 0xb8, 0x3e, 0xcb, 0x96, 0x8e, 0xf9, 0xb6, 0xf4, 0x3f, 0x6c, 0xdb, 0xe7, 0xc0, 0x86, 0xa8,
-	0x29, 0x31, 0xa8, 0x25, 0x9f, 0x26, 0x6a, 0x96, 0x0e, 0x50, 0x87, 0x9e, 0x2b, 0x38, 0xb2, 0x5f,
-	0x5e, 0xdc, 0x87, 0xbe, 0xc3, 0xd9, 0x8f, 0x70, 0xe0, 0xb2, 0x55, 0x46, 0x09, 0x1f, 0x11, 0xea,
-	0x70, 0xaf, 0x5d, 0xe9, 0x78, 0x71, 0xcb, 0xa2, 0xfd, 0x8c, 0x6e, 0x2d, 0xc6, 0xae, 0xe0, 0x44,
-	0xe1, 0x3c, 0xf9, 0x80, 0xb7, 0x56, 0x36, 0xa2, 0x70, 0x7e, 0xbf, 0x4b, 0xfd, 0x0b, 0xb0, 0x55,
-	0xd1, 0x9a, 0xbe, 0xee, 0xe8, 0x0f, 0x17, 0x05, 0xcb, 0x1b, 0xa2, 0xff, 0xaa, 0xf0, 0x45, 0x8c,
-	0x63, 0x69, 0x48, 0x3b, 0x39, 0x8f, 0x8a, 0x74, 0xc1, 0xae, 0x21, 0x30, 0x4b, 0xb3, 0x3f, 0xe3,
-	0xf0, 0x3a, 0xd1, 0x5a, 0x9a, 0x73, 0x8d, 0x8a, 0xac, 0xa5, 0xa5, 0x53, 0x7e, 0x09, 0xf4, 0xc4,
-	0xb6, 0xdf, 0xde, 0x8e, 0xdf, 0x47, 0xe0, 0x11, 0x4d, 0x9d, 0x05, 0xb5, 0xd8, 0x86, 0xec, 0x27,
-	0x38, 0x18, 0xa1, 0x40, 0xcd, 0x09, 0x4d, 0x32, 0x97, 0x34, 0x09, 0x6b, 0x6d, 0xaf, 0x13, 0xc4,
-	0xfb, 0x2b, 0xf4, 0x4f, 0x49, 0x13, 0x76, 0x0a, 0xbe, 0x9d, 0x70, 0x61, 0x49, 0xeb, 0x8e, 0xd4,
-	0x4d, 0xbc, 0xe8, 0x09, 0xbb, 0x46, 0x5c, 0xa4, 0x52, 0x85, 0x8d, 0x76, 0xa5, 0xe3, 0xc7, 0xe5,
-	0x81, 0x7d, 0x07, 0x20, 0xb2, 0xb9, 0x32, 0xa4, 0x91, 0xa7, 0xa1, 0xef, 0x3e, 0x6d, 0x20, 0xac,
-	0x0d, 0x4d, 0x47, 0xf0, 0xf8, 0x9e, 0x4b, 0x5d, 0x84, 0x81, 0x73, 0x6d, 0x13, 0xb2, 0x42, 0x84,
-	0x32, 0x89, 0xe2, 0x29, 0x9a, 0x10, 0x5c, 0x53, 0xbe, 0x50, 0xa6, 0x6f, 0xcf, 0xd1, 0x33, 0x1c,
-	0xef, 0xba, 0x29, 0xd1, 0xb0, 0x9b, 0xdd, 0x7d, 0xfd, 0x7e, 0xdb, 0xcd, 0x4f, 0x26, 0xb0, 0x5e,
-	0xdc, 0x33, 0x68, 0xda, 0x81, 0xc9, 0x91, 0x1c, 0x72, 0x72, 0x6b, 0x2b, 0x50, 0x27, 0x83, 0x82,
-	0x1c, 0x97, 0x7d, 0x55, 0xbe, 0x40, 0x7d, 0x67, 0xcf, 0xd1, 0x5f, 0x10, 0x3c, 0xcf, 0x06, 0x53,
-	0x39, 0x7c, 0xc2, 0x82, 0x7d, 0x0b, 0x90, 0xbf, 0xc9, 0xf7, 0xad, 0xd4, 0xc0, 0x22, 0x2e, 0xd7,
-	0x5a, 0xfe, 0xb6, 0x1a, 0x93, 0x0d, 0x2d, 0xf5, 0x7a, 0x5d, 0x3c, 0x27, 0xdc, 0x57, 0xab, 0x3d,
-	0xa9, 0x40, 0xfd, 0x6e, 0xa6, 0xc4, 0x14, 0xd9, 0xcf, 0x70, 0x48, 0x7a, 0x66, 0x28, 0x11, 0x59,
-	0xca, 0xa5, 0x5a, 0xbf, 0x9f, 0x7d, 0x07, 0x3f, 0x38, 0xb4, 0x27, 0xd8, 0x35, 0xf8, 0x3a, 0xcb,
-	0x28, 0x19, 0x72, 0x13, 0x56, 0x9d, 0xea, 0xd3, 0x6d, 0xd5, 0x1b, 0xba, 0xe2, 0x86, 0x4d, 0xbd,
-	0xe7, 0x86, 0xdd, 0xc2, 0xd1, 0xeb, 0x9c, 0x12, 0x23, 0xc7, 0x4a, 0xaa, 0x71, 0xf2, 0x86, 0x85,
-	0x09, 0x3d, 0x57, 0xfd, 0xf5, 0x76, 0xf5, 0x4a, 0x69, 0x7c, 0xf0, 0x3a, 0xa7, 0x97, 0x32, 0xff,
-	0x09, 0x0b, 0xc3, 0x7e, 0x80, 0x96, 0xc6, 0x91, 0x46, 0x33, 0x49, 0x26, 0x52, 0xd1, 0xe2, 0x65,
-	0x35, 0x17, 0xd8, 0xef, 0x52, 0xd1, 0xdd, 0xf9, 0xdf, 0x67, 0x63, 0x49, 0x93, 0xd9, 0xc0, 0xb2,
-	0x5d, 0x94, 0x7b, 0x78, 0xe1, 0xe8, 0x2f, 0xdc, 0x6f, 0x6f, 0x11, 0x97, 0x57, 0x0d, 0xea, 0x0e,
-	0xbb, 0xfa, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xec, 0x32, 0xf4, 0x74, 0x1a, 0x05, 0x00, 0x00,
+	// 1117 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x72, 0x1b, 0x35,
+	0x14, 0x1e, 0xc7, 0xb1, 0xb3, 0x7b, 0xe2, 0x38, 0xa9, 0xd2, 0xba, 0xdb, 0x32, 0x05, 0x63, 0xfe,
+	0x32, 0xa5, 0xb8, 0x4c, 0x9b, 0xe9, 0xb4, 0x0c, 0x5c, 0x38, 0x4d, 0x18, 0xd2, 0x0c, 0x99, 0xcc,
+	0xba, 0xc0, 0x0c, 0x37, 0x1a, 0xd9, 0x3a, 0x8e, 0x95, 0xac, 0xb5, 0x8b, 0x24, 0xc7, 0xd9, 0x87,
+	0xe1, 0x21, 0xb8, 0xe4, 0x45, 0xfa, 0x2a, 0xdc, 0x32, 0x92, 0xd6, 0xbf, 0x04, 0x1a, 0xae, 0x56,
+	0xfa, 0xce, 0x39, 0x9f, 0xce, 0x9f, 0x8e, 0x16, 0x6a, 0xfd, 0x74, 0x34, 0x4a, 0x65, 0x3b, 0x53,
+	0xa9, 0x49, 0x49, 0x4d, 0x67, 0x42, 0x61, 0xdb, 0x63, 0xad, 0x0d, 0xa8, 0x1c, 0x8d, 0x32, 0x93,
+	0xb7, 0x5e, 0xc1, 0x76, 0xc7, 0x18, 0xd4, 0x86, 0x19, 0x91, 0xca, 0x43, 0x66, 0x18, 0x21, 0xb0,
+	0x6e, 0xf2, 0x0c, 0xa3, 0x52, 0xb3, 0xb4, 0x17, 0xc6, 0x6e, 0x6d, 0x31, 0xce, 0x0c, 0x8b, 0xd6,
+	0x9a, 0xa5, 0xbd, 0x5a, 0xec, 0xd6, 0xad, 0x7d, 0x08, 0xba, 0x98, 0x60, 0xdf, 0xa4, 0xea, 0x46,
+	0x9b, 0xbb, 0x50, 0xb9, 0x62, 0xc9, 0x18, 0x9d, 0x51, 0x18, 0xfb, 0x4d, 0xeb, 0x3b, 0x08, 0xa7,
+	0x56, 0x9a, 0x7c, 0x0d, 0x1b, 0x28, 0x8d, 0x12, 0xa8, 0xa3, 0x52, 0xb3, 0xbc, 0xb7, 0xf9, 0xac,
+	0xd1, 0x5e, 0x74, 0xb3, 0x3d, 0xd5, 0x8c, 0xa7, 0x6a, 0xad, 0x3f, 0xcb, 0x50, 0xf3, 0x0e, 0x23,
+	0x3f, 0x4d, 0x39, 0x92, 0x0f, 0x20, 0xd4, 0x99, 0x18, 0x0c, 0x90, 0x0a, 0x5e, 0x1c, 0x1f, 0x78,
+	0xe0, 0x98, 0x93, 0x67, 0x70, 0x8f, 0xcd, 0xa3, 0xa3, 0xd6, 0x6d, 0xea, 0xfc, 0xf4, 0x2e, 0xed,
+	0xb2, 0xe5, 0xd0, 0xdf, 0x5a, 0xb7, 0x9f, 0x00, 0xe9, 0xa3, 0x32, 0x54, 0xa3, 0x12, 0x2c, 0xa1,
+	0x72, 0x3c, 0xea, 0xa1, 0x8a, 0xca, 0xce, 0x60, 0xc7, 0x4a, 0xba, 0x4e, 0x70, 0xea, 0x70, 0xf2,
+	0x29, 0xd4, 0x9d, 0xb6, 0x4c, 0x0d, 0x65, 0x03, 0x83, 0x2a, 0x5a, 0x6f, 0x96, 0xf6, 0xca, 0x71,
+	0xcd, 0xa2, 0xa7, 0xa9, 0xe9, 0x58, 0x8c, 0x3c, 0x87, 0x86, 0xc4, 0x09, 0xbd, 0x81, 0xb7, 0xe2,
+	0x1d, 0x91, 0x38, 0x79, 0xbd, 0x4a, 0xfd, 0x25, 0x90, 0x99, 0xd1, 0x9c, 0xbe, 0xea, 0xe8, 0xb7,
+	0x0b, 0x83, 0xd9, 0x09, 0x9f, 0xc0, 0x16, 0x3b, 0x47, 0x69, 0xe8, 0x15, 0x2a, 0x2d, 0x52, 0x19,
+	0x6d, 0x38, 0xe2, 0x9a, 0x03, 0x7f, 0xf6, 0x18, 0x69, 0x42, 0x2d, 0x61, 0xda, 0xba, 0x80, 0x92,
+	0x32, 0x13, 0x05, 0x8e, 0x0b, 0x2c, 0xd6, 0x45, 0x94, 0x1d, 0x43, 0x3e, 0x83, 0xba, 0xc2, 0x51,
+	0x6a, 0x90, 0x32, 0xce, 0x15, 0x6a, 0x1d, 0x85, 0x8e, 0x67, 0xcb, 0xa3, 0x1d, 0x0f, 0x92, 0x7d,
+	0x08, 0xf5, 0xb4, 0x88, 0x11, 0xfc, 0x67, 0xe5, 0xe6, 0x8a, 0xad, 0xbf, 0x2a, 0x70, 0x27, 0xc6,
+	0x73, 0xa1, 0x8d, 0x72, 0x29, 0x3f, 0x92, 0x46, 0xe5, 0xcb, 0x5c, 0xa5, 0x5b, 0x72, 0xd9, 0xb2,
+	0x67, 0x4c, 0xd9, 0x80, 0x05, 0x2f, 0xaa, 0x19, 0x78, 0xe0, 0x98, 0x2f, 0xf7, 0x44, 0x79, 0xa5,
+	0x27, 0x76, 0xa0, 0x6c, 0x4c, 0xe2, 0xca, 0x54, 0x89, 0xed, 0xd2, 0x06, 0x3d, 0x40, 0x8e, 0x8a,
+	0x19, 0xd4, 0x74, 0x22, 0xcc, 0x30, 0xaa, 0x34, 0xcb, 0x36, 0xe8, 0x19, 0xfa, 0x8b, 0x30, 0x43,
+	0xf2, 0x00, 0x02, 0xdb, 0x85, 0xb9, 0x25, 0xad, 0x3a, 0x52, 0xd7, 0x95, 0xf9, 0x31, 0xb7, 0xad,
+	0xce, 0xf8, 0x48, 0xf8, 0xac, 0x07, 0xb1, 0xdf, 0x90, 0x0f, 0x01, 0x78, 0x3a, 0x91, 0xda, 0x28,
+	0x64, 0x23, 0x97, 0xec, 0x20, 0x5e, 0x40, 0x48, 0x13, 0x36, 0x1d, 0xc1, 0xd1, 0x75, 0x26, 0x54,
+	0xee, 0x32, 0x5d, 0x8e, 0x17, 0x21, 0x1b, 0x08, 0x97, 0x9a, 0x4a, 0x36, 0x42, 0x9f, 0xe7, 0x30,
+	0x0e, 0xb8, 0xd4, 0xa7, 0x76, 0x4f, 0x5e, 0x42, 0x34, 0x27, 0xa3, 0x19, 0x33, 0x43, 0x9a, 0x29,
+	0x1c, 0x88, 0x6b, 0xd4, 0xd1, 0xa6, 0xd3, 0x6d, 0xcc, 0xe5, 0x67, 0xcc, 0x0c, 0xcf, 0x0a, 0x29,
+	0xd9, 0x87, 0x05, 0x09, 0xb5, 0x27, 0xf0, 0x74, 0xc4, 0x84, 0xd4, 0x51, 0xcd, 0xd9, 0xdd, 0x9d,
+	0x4b, 0x0f, 0xa5, 0x3e, 0xf4, 0x32, 0xf2, 0x23, 0xc0, 0xc5, 0xc4, 0xd0, 0x7e, 0xc2, 0xc4, 0x48,
+	0x47, 0x5b, 0xae, 0x52, 0xed, 0xe5, 0x4a, 0xfd, 0xa3, 0xba, 0xed, 0x37, 0x13, 0xf3, 0xda, 0x19,
+	0xb8, 0x6d, 0x1c, 0x5e, 0x4c, 0xf7, 0x64, 0x00, 0xbb, 0x33, 0x3a, 0x6a, 0x70, 0x94, 0x25, 0x36,
+	0xd3, 0x51, 0xdd, 0xf1, 0xbe, 0xb8, 0x2d, 0xef, 0xdb, 0xa9, 0xa1, 0xe7, 0xbf, 0x73, 0xb1, 0x8a,
+	0x3f, 0xfc, 0x16, 0xea, 0xcb, 0x4e, 0xd8, 0x0e, 0xb8, 0xc4, 0xbc, 0x18, 0x16, 0x76, 0x79, 0xf3,
+	0xa8, 0xfa, 0x66, 0xed, 0x65, 0xe9, 0xe1, 0x21, 0x34, 0x6e, 0x3e, 0xea, 0xff, 0xb0, 0xb4, 0xce,
+	0x60, 0x77, 0x35, 0x04, 0x81, 0x9a, 0xbc, 0x5a, 0x1d, 0x7f, 0x1f, 0xbd, 0x27, 0xec, 0xf9, 0x1c,
+	0x7c, 0x0c, 0x9b, 0xf6, 0xfe, 0x8b, 0x81, 0xe8, 0x33, 0xe3, 0xa6, 0x20, 0x47, 0x45, 0x7b, 0xb9,
+	0x71, 0x5c, 0x76, 0x48, 0x07, 0x1c, 0xd5, 0x81, 0xdd, 0xb7, 0x7e, 0x2f, 0x41, 0x78, 0x36, 0xee,
+	0x25, 0xa2, 0x7f, 0x82, 0x39, 0x79, 0x04, 0x90, 0x5d, 0x8a, 0xeb, 0x25, 0xdd, 0xd0, 0x22, 0x4e,
+	0xd9, 0x85, 0x35, 0xbb, 0x52, 0x76, 0x69, 0xb9, 0xe7, 0xe3, 0xa7, 0xec, 0x9a, 0x34, 0x90, 0x0b,
+	0x73, 0x67, 0xb9, 0xf3, 0xd6, 0x5d, 0x07, 0xd5, 0xb2, 0xc5, 0x7e, 0x7b, 0x04, 0x30, 0xbb, 0x8f,
+	0xba, 0xb8, 0x5c, 0xe1, 0xf4, 0x42, 0xea, 0xd6, 0xbb, 0x35, 0xa8, 0x1e, 0x8c, 0x25, 0x4f, 0x90,
+	0x7c, 0x0e, 0xdb, 0x46, 0x8d, 0xb5, 0x29, 0x1a, 0x72, 0x3e, 0xd3, 0xb7, 0x1c, 0xec, 0x5b, 0xf1,
+	0x98, 0x93, 0x7d, 0x08, 0x54, 0x9a, 0x1a, 0xda, 0x67, 0x3a, 0x5a, 0x73, 0xa9, 0x7b, 0xb0, 0x9c,
+	0xba, 0x85, 0xe4, 0xc4, 0x1b, 0x56, 0xf5, 0x35, 0xd3, 0xa4, 0x03, 0x3b, 0xb6, 0xe5, 0xb4, 0x38,
+	0x97, 0x42, 0x9e, 0xd3, 0x4b, 0xcc, 0x75, 0x54, 0x76, 0xd6, 0xf7, 0x97, 0xad, 0x67, 0xd9, 0x8a,
+	0xeb, 0x17, 0x13, 0xd3, 0xf5, 0xfa, 0x27, 0x98, 0x6b, 0xf2, 0x31, 0xd4, 0x14, 0x0e, 0x14, 0xea,
+	0x21, 0x1d, 0x0a, 0x69, 0x8a, 0x69, 0xbf, 0x59, 0x60, 0x3f, 0x08, 0x69, 0xc8, 0x17, 0xb0, 0xad,
+	0xf1, 0xb7, 0x31, 0xca, 0x3e, 0x2e, 0x4e, 0xf9, 0xf5, 0xb8, 0x3e, 0x85, 0x8b, 0x01, 0xff, 0x15,
+	0xec, 0x2a, 0xbc, 0x4a, 0x2f, 0x91, 0x53, 0xeb, 0xd6, 0x25, 0xe6, 0x2e, 0x3f, 0x55, 0x97, 0x9f,
+	0x9d, 0x42, 0xf4, 0x66, 0x62, 0x4e, 0x30, 0x3f, 0xe6, 0xf6, 0xb1, 0xbc, 0xbb, 0xa8, 0xae, 0xc7,
+	0xbd, 0x0b, 0xec, 0x1b, 0x1d, 0x6d, 0x38, 0x7d, 0x32, 0xd7, 0xef, 0x16, 0x92, 0xd6, 0xbb, 0x12,
+	0x34, 0xbe, 0xf7, 0x33, 0x4c, 0xa4, 0x32, 0xc6, 0xc4, 0x7d, 0xf5, 0x50, 0x64, 0xb7, 0x4e, 0x74,
+	0x1b, 0x76, 0x7b, 0xae, 0x34, 0x14, 0x25, 0xcf, 0x52, 0x21, 0x0d, 0x1d, 0xab, 0xa4, 0x68, 0x8f,
+	0x3b, 0x5e, 0x74, 0x54, 0x48, 0x7e, 0x52, 0x09, 0x79, 0x01, 0xf7, 0x57, 0xf5, 0x33, 0x95, 0x0e,
+	0x44, 0x82, 0xc5, 0x20, 0xbe, 0xb7, 0x6c, 0x73, 0xe6, 0x85, 0xf6, 0xd5, 0x9d, 0x19, 0xcc, 0x67,
+	0xf7, 0xba, 0x7f, 0x75, 0xa7, 0x92, 0x6e, 0xd1, 0x32, 0xad, 0x3f, 0x4a, 0x10, 0x74, 0xec, 0xcb,
+	0x76, 0xc0, 0xe4, 0xfb, 0xfe, 0x00, 0x82, 0xe9, 0xa3, 0xe1, 0x9c, 0xfe, 0xf7, 0xc7, 0x65, 0xa6,
+	0x47, 0x1a, 0x50, 0x55, 0xc8, 0x74, 0x2a, 0x0b, 0x97, 0x8b, 0x9d, 0x6d, 0xe3, 0xbe, 0x42, 0x66,
+	0x90, 0xdb, 0xc7, 0xd3, 0x57, 0x3e, 0x2c, 0x90, 0x8e, 0xb1, 0x62, 0xb4, 0x63, 0x1b, 0xb5, 0x15,
+	0x57, 0xbc, 0xb8, 0x40, 0x3a, 0xe6, 0xe0, 0xc9, 0xaf, 0x8f, 0xcf, 0x85, 0x19, 0x8e, 0x7b, 0xf6,
+	0xe4, 0xa7, 0xde, 0xc1, 0xa7, 0xce, 0x95, 0xa7, 0xee, 0x0f, 0xad, 0x58, 0x7b, 0xb7, 0x7a, 0x55,
+	0x87, 0x3d, 0xff, 0x7b, 0x00, 0x25, 0x0c, 0x48, 0x9e, 0xc5, 0x09, 0x00, 0x00,
 }
@@ -57,7 +57,7 @@ func (x DeleteBundleRequest_Mode) String() string {
 }
 
 func (DeleteBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{12, 0}
+	return fileDescriptor_d08157cfd31fc929, []int{14, 0}
 }
 
 type BySelectors_MatchBehavior int32
@@ -82,7 +82,7 @@ func (x BySelectors_MatchBehavior) String() string {
 }
 
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35, 0}
+	return fileDescriptor_d08157cfd31fc929, []int{66, 0}
 }
 
 type CreateBundleRequest struct {
@@ -242,6 +242,9 @@ func (m *FetchBundleResponse) GetBundle() *common.Bundle {
 }
 
 type ListBundlesRequest struct {
+	// If true, only the bundles stored on behalf of the upstream SPIRE server
+	// in a nested deployment are listed.
+	ByUpstream           bool     `protobuf:"varint,1,opt,name=by_upstream,json=byUpstream,proto3" json:"by_upstream,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -272,6 +275,13 @@ func (m *ListBundlesRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ListBundlesRequest proto.InternalMessageInfo
 
+func (m *ListBundlesRequest) GetByUpstream() bool {
+	if m != nil {
+		return m.ByUpstream
+	}
+	return false
+}
+
 type ListBundlesResponse struct {
 	Bundles              []*common.Bundle `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -311,18 +321,92 @@ func (m *ListBundlesResponse) GetBundles() []*common.Bundle {
 	return nil
 }
 
+type CountBundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountBundlesRequest) Reset()         { *m = CountBundlesRequest{} }
+func (m *CountBundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountBundlesRequest) ProtoMessage()    {}
+func (*CountBundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{6}
+}
+
+func (m *CountBundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountBundlesRequest.Unmarshal(m, b)
+}
+func (m *CountBundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountBundlesRequest.Marshal(b, m, deterministic)
+}
+func (m *CountBundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountBundlesRequest.Merge(m, src)
+}
+func (m *CountBundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountBundlesRequest.Size(m)
+}
+func (m *CountBundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountBundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountBundlesRequest proto.InternalMessageInfo
+
+type CountBundlesResponse struct {
+	Bundles              int32    `protobuf:"varint,1,opt,name=bundles,proto3" json:"bundles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountBundlesResponse) Reset()         { *m = CountBundlesResponse{} }
+func (m *CountBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountBundlesResponse) ProtoMessage()    {}
+func (*CountBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{7}
+}
+
+func (m *CountBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountBundlesResponse.Unmarshal(m, b)
+}
+func (m *CountBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountBundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *CountBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountBundlesResponse.Merge(m, src)
+}
+func (m *CountBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountBundlesResponse.Size(m)
+}
+func (m *CountBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountBundlesResponse proto.InternalMessageInfo
+
+func (m *CountBundlesResponse) GetBundles() int32 {
+	if m != nil {
+		return m.Bundles
+	}
+	return 0
+}
+
 type UpdateBundleRequest struct {
-	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// If true, the bundle is stored with its own sequence number. Otherwise,
+	// the sequence number is incremented past that of the stored bundle when
+	// the contents change.
+	PreserveSequenceNumber bool     `protobuf:"varint,2,opt,name=preserve_sequence_number,json=preserveSequenceNumber,proto3" json:"preserve_sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *UpdateBundleRequest) Reset()         { *m = UpdateBundleRequest{} }
 func (m *UpdateBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateBundleRequest) ProtoMessage()    {}
 func (*UpdateBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{6}
+	return fileDescriptor_d08157cfd31fc929, []int{8}
 }
 
 func (m *UpdateBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -350,6 +434,13 @@ func (m *UpdateBundleRequest) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (m *UpdateBundleRequest) GetPreserveSequenceNumber() bool {
+	if m != nil {
+		return m.PreserveSequenceNumber
+	}
+	return false
+}
+
 type UpdateBundleResponse struct {
 	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -361,7 +452,7 @@ func (m *UpdateBundleResponse) Reset()         { *m = UpdateBundleResponse{} }
 func (m *UpdateBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateBundleResponse) ProtoMessage()    {}
 func (*UpdateBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{7}
+	return fileDescriptor_d08157cfd31fc929, []int{9}
 }
 
 func (m *UpdateBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -390,17 +481,24 @@ func (m *UpdateBundleResponse) GetBundle() *common.Bundle {
 }
 
 type SetBundleRequest struct {
-	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// If true, the bundle is stored with its own sequence number. Otherwise,
+	// the sequence number is incremented past that of the stored bundle when
+	// the contents change.
+	PreserveSequenceNumber bool `protobuf:"varint,2,opt,name=preserve_sequence_number,json=preserveSequenceNumber,proto3" json:"preserve_sequence_number,omitempty"`
+	// If true, the bundle is marked as stored on behalf of the upstream SPIRE
+	// server in a nested deployment. Otherwise, the mark is cleared.
+	Upstream             bool     `protobuf:"varint,3,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SetBundleRequest) Reset()         { *m = SetBundleRequest{} }
 func (m *SetBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*SetBundleRequest) ProtoMessage()    {}
 func (*SetBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{8}
+	return fileDescriptor_d08157cfd31fc929, []int{10}
 }
 
 func (m *SetBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -428,6 +526,20 @@ func (m *SetBundleRequest) GetBundle() *common.Bundle {
 	return nil
 }
 
+func (m *SetBundleRequest) GetPreserveSequenceNumber() bool {
+	if m != nil {
+		return m.PreserveSequenceNumber
+	}
+	return false
+}
+
+func (m *SetBundleRequest) GetUpstream() bool {
+	if m != nil {
+		return m.Upstream
+	}
+	return false
+}
+
 type SetBundleResponse struct {
 	Bundle               *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -439,7 +551,7 @@ func (m *SetBundleResponse) Reset()         { *m = SetBundleResponse{} }
 func (m *SetBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*SetBundleResponse) ProtoMessage()    {}
 func (*SetBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{9}
+	return fileDescriptor_d08157cfd31fc929, []int{11}
 }
 
 func (m *SetBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -478,7 +590,7 @@ func (m *AppendBundleRequest) Reset()         { *m = AppendBundleRequest{} }
 func (m *AppendBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*AppendBundleRequest) ProtoMessage()    {}
 func (*AppendBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{10}
+	return fileDescriptor_d08157cfd31fc929, []int{12}
 }
 
 func (m *AppendBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -517,7 +629,7 @@ func (m *AppendBundleResponse) Reset()         { *m = AppendBundleResponse{} }
 func (m *AppendBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*AppendBundleResponse) ProtoMessage()    {}
 func (*AppendBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{11}
+	return fileDescriptor_d08157cfd31fc929, []int{13}
 }
 
 func (m *AppendBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -557,7 +669,7 @@ func (m *DeleteBundleRequest) Reset()         { *m = DeleteBundleRequest{} }
 func (m *DeleteBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleRequest) ProtoMessage()    {}
 func (*DeleteBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{12}
+	return fileDescriptor_d08157cfd31fc929, []int{14}
 }
 
 func (m *DeleteBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -603,7 +715,7 @@ func (m *DeleteBundleResponse) Reset()         { *m = DeleteBundleResponse{} }
 func (m *DeleteBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleResponse) ProtoMessage()    {}
 func (*DeleteBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{13}
+	return fileDescriptor_d08157cfd31fc929, []int{15}
 }
 
 func (m *DeleteBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -645,7 +757,7 @@ func (m *PruneBundleRequest) Reset()         { *m = PruneBundleRequest{} }
 func (m *PruneBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneBundleRequest) ProtoMessage()    {}
 func (*PruneBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{14}
+	return fileDescriptor_d08157cfd31fc929, []int{16}
 }
 
 func (m *PruneBundleRequest) XXX_Unmarshal(b []byte) error {
@@ -691,7 +803,7 @@ func (m *PruneBundleResponse) Reset()         { *m = PruneBundleResponse{} }
 func (m *PruneBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneBundleResponse) ProtoMessage()    {}
 func (*PruneBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{15}
+	return fileDescriptor_d08157cfd31fc929, []int{17}
 }
 
 func (m *PruneBundleResponse) XXX_Unmarshal(b []byte) error {
@@ -719,224 +831,1186 @@ func (m *PruneBundleResponse) GetBundleChanged() bool {
 	return false
 }
 
-type NodeSelectors struct {
-	// Node SPIFFE ID
-	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	// Node selectors
-	Selectors            []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type RemoveBundleJWTSigningKeysRequest struct {
+	// Trust domain of the bundle
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// JWT signing keys with a key ID that starts with this prefix are removed
+	KeyIdPrefix          string   `protobuf:"bytes,2,opt,name=key_id_prefix,json=keyIdPrefix,proto3" json:"key_id_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
-func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
-func (*NodeSelectors) ProtoMessage()    {}
-func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{16}
+func (m *RemoveBundleJWTSigningKeysRequest) Reset()         { *m = RemoveBundleJWTSigningKeysRequest{} }
+func (m *RemoveBundleJWTSigningKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveBundleJWTSigningKeysRequest) ProtoMessage()    {}
+func (*RemoveBundleJWTSigningKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{18}
 }
 
-func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_NodeSelectors.Unmarshal(m, b)
+func (m *RemoveBundleJWTSigningKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysRequest.Unmarshal(m, b)
 }
-func (m *NodeSelectors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_NodeSelectors.Marshal(b, m, deterministic)
+func (m *RemoveBundleJWTSigningKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysRequest.Marshal(b, m, deterministic)
 }
-func (m *NodeSelectors) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_NodeSelectors.Merge(m, src)
+func (m *RemoveBundleJWTSigningKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveBundleJWTSigningKeysRequest.Merge(m, src)
 }
-func (m *NodeSelectors) XXX_Size() int {
-	return xxx_messageInfo_NodeSelectors.Size(m)
+func (m *RemoveBundleJWTSigningKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysRequest.Size(m)
 }
-func (m *NodeSelectors) XXX_DiscardUnknown() {
-	xxx_messageInfo_NodeSelectors.DiscardUnknown(m)
+func (m *RemoveBundleJWTSigningKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveBundleJWTSigningKeysRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_NodeSelectors proto.InternalMessageInfo
+var xxx_messageInfo_RemoveBundleJWTSigningKeysRequest proto.InternalMessageInfo
 
-func (m *NodeSelectors) GetSpiffeId() string {
+func (m *RemoveBundleJWTSigningKeysRequest) GetTrustDomainId() string {
 	if m != nil {
-		return m.SpiffeId
+		return m.TrustDomainId
 	}
 	return ""
 }
 
-func (m *NodeSelectors) GetSelectors() []*common.Selector {
+func (m *RemoveBundleJWTSigningKeysRequest) GetKeyIdPrefix() string {
 	if m != nil {
-		return m.Selectors
+		return m.KeyIdPrefix
 	}
-	return nil
+	return ""
 }
 
-type SetNodeSelectorsRequest struct {
-	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type RemoveBundleJWTSigningKeysResponse struct {
+	// Number of JWT signing keys removed
+	Removed              int32    `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetNodeSelectorsRequest) Reset()         { *m = SetNodeSelectorsRequest{} }
-func (m *SetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
-func (*SetNodeSelectorsRequest) ProtoMessage()    {}
-func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{17}
+func (m *RemoveBundleJWTSigningKeysResponse) Reset()         { *m = RemoveBundleJWTSigningKeysResponse{} }
+func (m *RemoveBundleJWTSigningKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveBundleJWTSigningKeysResponse) ProtoMessage()    {}
+func (*RemoveBundleJWTSigningKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{19}
 }
 
-func (m *SetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetNodeSelectorsRequest.Unmarshal(m, b)
+func (m *RemoveBundleJWTSigningKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysResponse.Unmarshal(m, b)
 }
-func (m *SetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetNodeSelectorsRequest.Marshal(b, m, deterministic)
+func (m *RemoveBundleJWTSigningKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysResponse.Marshal(b, m, deterministic)
 }
-func (m *SetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetNodeSelectorsRequest.Merge(m, src)
+func (m *RemoveBundleJWTSigningKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveBundleJWTSigningKeysResponse.Merge(m, src)
 }
-func (m *SetNodeSelectorsRequest) XXX_Size() int {
-	return xxx_messageInfo_SetNodeSelectorsRequest.Size(m)
+func (m *RemoveBundleJWTSigningKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveBundleJWTSigningKeysResponse.Size(m)
 }
-func (m *SetNodeSelectorsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetNodeSelectorsRequest.DiscardUnknown(m)
+func (m *RemoveBundleJWTSigningKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveBundleJWTSigningKeysResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetNodeSelectorsRequest proto.InternalMessageInfo
+var xxx_messageInfo_RemoveBundleJWTSigningKeysResponse proto.InternalMessageInfo
 
-func (m *SetNodeSelectorsRequest) GetSelectors() *NodeSelectors {
+func (m *RemoveBundleJWTSigningKeysResponse) GetRemoved() int32 {
 	if m != nil {
-		return m.Selectors
+		return m.Removed
 	}
-	return nil
+	return 0
 }
 
-type SetNodeSelectorsResponse struct {
+type BundleHistoryEntry struct {
+	// Bundle as received from the bundle endpoint
+	Bundle *common.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Time the bundle was received, in seconds since the Unix epoch
+	ReceivedAt           int64    `protobuf:"varint,2,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SetNodeSelectorsResponse) Reset()         { *m = SetNodeSelectorsResponse{} }
-func (m *SetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
-func (*SetNodeSelectorsResponse) ProtoMessage()    {}
-func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{18}
+func (m *BundleHistoryEntry) Reset()         { *m = BundleHistoryEntry{} }
+func (m *BundleHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*BundleHistoryEntry) ProtoMessage()    {}
+func (*BundleHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{20}
 }
 
-func (m *SetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetNodeSelectorsResponse.Unmarshal(m, b)
+func (m *BundleHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BundleHistoryEntry.Unmarshal(m, b)
 }
-func (m *SetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetNodeSelectorsResponse.Marshal(b, m, deterministic)
+func (m *BundleHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BundleHistoryEntry.Marshal(b, m, deterministic)
 }
-func (m *SetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetNodeSelectorsResponse.Merge(m, src)
+func (m *BundleHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BundleHistoryEntry.Merge(m, src)
 }
-func (m *SetNodeSelectorsResponse) XXX_Size() int {
-	return xxx_messageInfo_SetNodeSelectorsResponse.Size(m)
+func (m *BundleHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_BundleHistoryEntry.Size(m)
 }
-func (m *SetNodeSelectorsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetNodeSelectorsResponse.DiscardUnknown(m)
+func (m *BundleHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BundleHistoryEntry.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SetNodeSelectorsResponse proto.InternalMessageInfo
+var xxx_messageInfo_BundleHistoryEntry proto.InternalMessageInfo
 
-type GetNodeSelectorsRequest struct {
-	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
-	TolerateStale        bool     `protobuf:"varint,2,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
+func (m *BundleHistoryEntry) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *BundleHistoryEntry) GetReceivedAt() int64 {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return 0
+}
+
+type AppendBundleHistoryRequest struct {
+	Entry *BundleHistoryEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Maximum number of entries to retain for the trust domain. Older entries
+	// are removed. Zero retains all entries.
+	MaxEntries           int32    `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest{} }
-func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetNodeSelectorsRequest) ProtoMessage()    {}
-func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{19}
+func (m *AppendBundleHistoryRequest) Reset()         { *m = AppendBundleHistoryRequest{} }
+func (m *AppendBundleHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*AppendBundleHistoryRequest) ProtoMessage()    {}
+func (*AppendBundleHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{21}
 }
 
-func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Unmarshal(m, b)
+func (m *AppendBundleHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppendBundleHistoryRequest.Unmarshal(m, b)
 }
-func (m *GetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Marshal(b, m, deterministic)
+func (m *AppendBundleHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppendBundleHistoryRequest.Marshal(b, m, deterministic)
 }
-func (m *GetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeSelectorsRequest.Merge(m, src)
+func (m *AppendBundleHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppendBundleHistoryRequest.Merge(m, src)
 }
-func (m *GetNodeSelectorsRequest) XXX_Size() int {
-	return xxx_messageInfo_GetNodeSelectorsRequest.Size(m)
+func (m *AppendBundleHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_AppendBundleHistoryRequest.Size(m)
 }
-func (m *GetNodeSelectorsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeSelectorsRequest.DiscardUnknown(m)
+func (m *AppendBundleHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppendBundleHistoryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeSelectorsRequest proto.InternalMessageInfo
+var xxx_messageInfo_AppendBundleHistoryRequest proto.InternalMessageInfo
 
-func (m *GetNodeSelectorsRequest) GetSpiffeId() string {
+func (m *AppendBundleHistoryRequest) GetEntry() *BundleHistoryEntry {
 	if m != nil {
-		return m.SpiffeId
+		return m.Entry
 	}
-	return ""
+	return nil
 }
 
-func (m *GetNodeSelectorsRequest) GetTolerateStale() bool {
+func (m *AppendBundleHistoryRequest) GetMaxEntries() int32 {
 	if m != nil {
-		return m.TolerateStale
+		return m.MaxEntries
 	}
-	return false
+	return 0
 }
 
-type GetNodeSelectorsResponse struct {
-	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type AppendBundleHistoryResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsResponse{} }
-func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetNodeSelectorsResponse) ProtoMessage()    {}
-func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{20}
+func (m *AppendBundleHistoryResponse) Reset()         { *m = AppendBundleHistoryResponse{} }
+func (m *AppendBundleHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*AppendBundleHistoryResponse) ProtoMessage()    {}
+func (*AppendBundleHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{22}
 }
 
-func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Unmarshal(m, b)
+func (m *AppendBundleHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppendBundleHistoryResponse.Unmarshal(m, b)
 }
-func (m *GetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Marshal(b, m, deterministic)
+func (m *AppendBundleHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppendBundleHistoryResponse.Marshal(b, m, deterministic)
 }
-func (m *GetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeSelectorsResponse.Merge(m, src)
+func (m *AppendBundleHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppendBundleHistoryResponse.Merge(m, src)
 }
-func (m *GetNodeSelectorsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetNodeSelectorsResponse.Size(m)
+func (m *AppendBundleHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_AppendBundleHistoryResponse.Size(m)
 }
-func (m *GetNodeSelectorsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeSelectorsResponse.DiscardUnknown(m)
+func (m *AppendBundleHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppendBundleHistoryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeSelectorsResponse proto.InternalMessageInfo
+var xxx_messageInfo_AppendBundleHistoryResponse proto.InternalMessageInfo
 
-func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
-	if m != nil {
-		return m.Selectors
-	}
-	return nil
+type ListBundleHistoryRequest struct {
+	// Trust domain of the bundle history to list
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type CreateAttestedNodeRequest struct {
-	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *ListBundleHistoryRequest) Reset()         { *m = ListBundleHistoryRequest{} }
+func (m *ListBundleHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ListBundleHistoryRequest) ProtoMessage()    {}
+func (*ListBundleHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{23}
 }
 
-func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeRequest{} }
-func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateAttestedNodeRequest) ProtoMessage()    {}
-func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{21}
+func (m *ListBundleHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBundleHistoryRequest.Unmarshal(m, b)
+}
+func (m *ListBundleHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBundleHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *ListBundleHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBundleHistoryRequest.Merge(m, src)
+}
+func (m *ListBundleHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ListBundleHistoryRequest.Size(m)
+}
+func (m *ListBundleHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBundleHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBundleHistoryRequest proto.InternalMessageInfo
+
+func (m *ListBundleHistoryRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type ListBundleHistoryResponse struct {
+	// History entries, oldest first
+	Entries              []*BundleHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListBundleHistoryResponse) Reset()         { *m = ListBundleHistoryResponse{} }
+func (m *ListBundleHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ListBundleHistoryResponse) ProtoMessage()    {}
+func (*ListBundleHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{24}
+}
+
+func (m *ListBundleHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListBundleHistoryResponse.Unmarshal(m, b)
+}
+func (m *ListBundleHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListBundleHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *ListBundleHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListBundleHistoryResponse.Merge(m, src)
+}
+func (m *ListBundleHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ListBundleHistoryResponse.Size(m)
+}
+func (m *ListBundleHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListBundleHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListBundleHistoryResponse proto.InternalMessageInfo
+
+func (m *ListBundleHistoryResponse) GetEntries() []*BundleHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type CreateFederationRelationshipRequest struct {
+	Relationship         *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *CreateFederationRelationshipRequest) Reset()         { *m = CreateFederationRelationshipRequest{} }
+func (m *CreateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipRequest) ProtoMessage()    {}
+func (*CreateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{25}
+}
+
+func (m *CreateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *CreateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipRequest.Size(m)
+}
+func (m *CreateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipRequest) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+type CreateFederationRelationshipResponse struct {
+	Relationship         *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *CreateFederationRelationshipResponse) Reset()         { *m = CreateFederationRelationshipResponse{} }
+func (m *CreateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFederationRelationshipResponse) ProtoMessage()    {}
+func (*CreateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{26}
+}
+
+func (m *CreateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *CreateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFederationRelationshipResponse.Size(m)
+}
+func (m *CreateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *CreateFederationRelationshipResponse) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+type FetchFederationRelationshipRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchFederationRelationshipRequest) Reset()         { *m = FetchFederationRelationshipRequest{} }
+func (m *FetchFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipRequest) ProtoMessage()    {}
+func (*FetchFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{27}
+}
+
+func (m *FetchFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipRequest.Merge(m, src)
+}
+func (m *FetchFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipRequest.Size(m)
+}
+func (m *FetchFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type FetchFederationRelationshipResponse struct {
+	Relationship         *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *FetchFederationRelationshipResponse) Reset()         { *m = FetchFederationRelationshipResponse{} }
+func (m *FetchFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchFederationRelationshipResponse) ProtoMessage()    {}
+func (*FetchFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{28}
+}
+
+func (m *FetchFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchFederationRelationshipResponse.Merge(m, src)
+}
+func (m *FetchFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchFederationRelationshipResponse.Size(m)
+}
+func (m *FetchFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *FetchFederationRelationshipResponse) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+type ListFederationRelationshipsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederationRelationshipsRequest) Reset()         { *m = ListFederationRelationshipsRequest{} }
+func (m *ListFederationRelationshipsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsRequest) ProtoMessage()    {}
+func (*ListFederationRelationshipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{29}
+}
+
+func (m *ListFederationRelationshipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsRequest.Merge(m, src)
+}
+func (m *ListFederationRelationshipsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsRequest.Size(m)
+}
+func (m *ListFederationRelationshipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsRequest proto.InternalMessageInfo
+
+type ListFederationRelationshipsResponse struct {
+	Relationships        []*common.FederationRelationship `protobuf:"bytes,1,rep,name=relationships,proto3" json:"relationships,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *ListFederationRelationshipsResponse) Reset()         { *m = ListFederationRelationshipsResponse{} }
+func (m *ListFederationRelationshipsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationRelationshipsResponse) ProtoMessage()    {}
+func (*ListFederationRelationshipsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{30}
+}
+
+func (m *ListFederationRelationshipsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Unmarshal(m, b)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationRelationshipsResponse.Merge(m, src)
+}
+func (m *ListFederationRelationshipsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationRelationshipsResponse.Size(m)
+}
+func (m *ListFederationRelationshipsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationRelationshipsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationRelationshipsResponse proto.InternalMessageInfo
+
+func (m *ListFederationRelationshipsResponse) GetRelationships() []*common.FederationRelationship {
+	if m != nil {
+		return m.Relationships
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipRequest struct {
+	Relationship         *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipRequest) Reset()         { *m = UpdateFederationRelationshipRequest{} }
+func (m *UpdateFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipRequest) ProtoMessage()    {}
+func (*UpdateFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{31}
+}
+
+func (m *UpdateFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipRequest.Size(m)
+}
+func (m *UpdateFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipRequest) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+type UpdateFederationRelationshipResponse struct {
+	Relationship         *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *UpdateFederationRelationshipResponse) Reset()         { *m = UpdateFederationRelationshipResponse{} }
+func (m *UpdateFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateFederationRelationshipResponse) ProtoMessage()    {}
+func (*UpdateFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{32}
+}
+
+func (m *UpdateFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.Merge(m, src)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateFederationRelationshipResponse.Size(m)
+}
+func (m *UpdateFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *UpdateFederationRelationshipResponse) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+type DeleteFederationRelationshipRequest struct {
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// If true, the bundle of the trust domain, if any, is deleted along with
+	// the relationship.
+	DeleteBundle bool `protobuf:"varint,2,opt,name=delete_bundle,json=deleteBundle,proto3" json:"delete_bundle,omitempty"`
+	// Controls how registration entries associated with the bundle are
+	// handled when the bundle is deleted.
+	BundleMode           DeleteBundleRequest_Mode `protobuf:"varint,3,opt,name=bundle_mode,json=bundleMode,proto3,enum=spire.server.datastore.DeleteBundleRequest_Mode" json:"bundle_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipRequest) Reset()         { *m = DeleteFederationRelationshipRequest{} }
+func (m *DeleteFederationRelationshipRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipRequest) ProtoMessage()    {}
+func (*DeleteFederationRelationshipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{33}
+}
+
+func (m *DeleteFederationRelationshipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipRequest.Size(m)
+}
+func (m *DeleteFederationRelationshipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipRequest proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *DeleteFederationRelationshipRequest) GetDeleteBundle() bool {
+	if m != nil {
+		return m.DeleteBundle
+	}
+	return false
+}
+
+func (m *DeleteFederationRelationshipRequest) GetBundleMode() DeleteBundleRequest_Mode {
+	if m != nil {
+		return m.BundleMode
+	}
+	return DeleteBundleRequest_RESTRICT
+}
+
+type DeleteFederationRelationshipResponse struct {
+	Relationship *common.FederationRelationship `protobuf:"bytes,1,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	// The deleted bundle, if any
+	Bundle               *common.Bundle `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeleteFederationRelationshipResponse) Reset()         { *m = DeleteFederationRelationshipResponse{} }
+func (m *DeleteFederationRelationshipResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederationRelationshipResponse) ProtoMessage()    {}
+func (*DeleteFederationRelationshipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{34}
+}
+
+func (m *DeleteFederationRelationshipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Unmarshal(m, b)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.Merge(m, src)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederationRelationshipResponse.Size(m)
+}
+func (m *DeleteFederationRelationshipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederationRelationshipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederationRelationshipResponse proto.InternalMessageInfo
+
+func (m *DeleteFederationRelationshipResponse) GetRelationship() *common.FederationRelationship {
+	if m != nil {
+		return m.Relationship
+	}
+	return nil
+}
+
+func (m *DeleteFederationRelationshipResponse) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+type CreateAgentBanRequest struct {
+	Ban                  *common.AgentBan `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateAgentBanRequest) Reset()         { *m = CreateAgentBanRequest{} }
+func (m *CreateAgentBanRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAgentBanRequest) ProtoMessage()    {}
+func (*CreateAgentBanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{35}
+}
+
+func (m *CreateAgentBanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAgentBanRequest.Unmarshal(m, b)
+}
+func (m *CreateAgentBanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAgentBanRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAgentBanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAgentBanRequest.Merge(m, src)
+}
+func (m *CreateAgentBanRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAgentBanRequest.Size(m)
+}
+func (m *CreateAgentBanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAgentBanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAgentBanRequest proto.InternalMessageInfo
+
+func (m *CreateAgentBanRequest) GetBan() *common.AgentBan {
+	if m != nil {
+		return m.Ban
+	}
+	return nil
+}
+
+type CreateAgentBanResponse struct {
+	Ban                  *common.AgentBan `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateAgentBanResponse) Reset()         { *m = CreateAgentBanResponse{} }
+func (m *CreateAgentBanResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAgentBanResponse) ProtoMessage()    {}
+func (*CreateAgentBanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{36}
+}
+
+func (m *CreateAgentBanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAgentBanResponse.Unmarshal(m, b)
+}
+func (m *CreateAgentBanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAgentBanResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAgentBanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAgentBanResponse.Merge(m, src)
+}
+func (m *CreateAgentBanResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAgentBanResponse.Size(m)
+}
+func (m *CreateAgentBanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAgentBanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAgentBanResponse proto.InternalMessageInfo
+
+func (m *CreateAgentBanResponse) GetBan() *common.AgentBan {
+	if m != nil {
+		return m.Ban
+	}
+	return nil
+}
+
+type ListAgentBansRequest struct {
+	// If set, only bans for the given SPIFFE ID or for any of the given
+	// selectors are returned.
+	BySpiffeId           string             `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	BySelectors          []*common.Selector `protobuf:"bytes,2,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListAgentBansRequest) Reset()         { *m = ListAgentBansRequest{} }
+func (m *ListAgentBansRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAgentBansRequest) ProtoMessage()    {}
+func (*ListAgentBansRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{37}
+}
+
+func (m *ListAgentBansRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentBansRequest.Unmarshal(m, b)
+}
+func (m *ListAgentBansRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentBansRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAgentBansRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentBansRequest.Merge(m, src)
+}
+func (m *ListAgentBansRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAgentBansRequest.Size(m)
+}
+func (m *ListAgentBansRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentBansRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentBansRequest proto.InternalMessageInfo
+
+func (m *ListAgentBansRequest) GetBySpiffeId() string {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return ""
+}
+
+func (m *ListAgentBansRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+type ListAgentBansResponse struct {
+	Bans                 []*common.AgentBan `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListAgentBansResponse) Reset()         { *m = ListAgentBansResponse{} }
+func (m *ListAgentBansResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAgentBansResponse) ProtoMessage()    {}
+func (*ListAgentBansResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{38}
+}
+
+func (m *ListAgentBansResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentBansResponse.Unmarshal(m, b)
+}
+func (m *ListAgentBansResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentBansResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAgentBansResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentBansResponse.Merge(m, src)
+}
+func (m *ListAgentBansResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAgentBansResponse.Size(m)
+}
+func (m *ListAgentBansResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentBansResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentBansResponse proto.InternalMessageInfo
+
+func (m *ListAgentBansResponse) GetBans() []*common.AgentBan {
+	if m != nil {
+		return m.Bans
+	}
+	return nil
+}
+
+type DeleteAgentBanRequest struct {
+	// SPIFFE ID or selector of the ban to delete
+	SpiffeId             string           `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Selector             *common.Selector `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DeleteAgentBanRequest) Reset()         { *m = DeleteAgentBanRequest{} }
+func (m *DeleteAgentBanRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAgentBanRequest) ProtoMessage()    {}
+func (*DeleteAgentBanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{39}
+}
+
+func (m *DeleteAgentBanRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAgentBanRequest.Unmarshal(m, b)
+}
+func (m *DeleteAgentBanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAgentBanRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteAgentBanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAgentBanRequest.Merge(m, src)
+}
+func (m *DeleteAgentBanRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAgentBanRequest.Size(m)
+}
+func (m *DeleteAgentBanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAgentBanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAgentBanRequest proto.InternalMessageInfo
+
+func (m *DeleteAgentBanRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *DeleteAgentBanRequest) GetSelector() *common.Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+type DeleteAgentBanResponse struct {
+	Ban                  *common.AgentBan `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DeleteAgentBanResponse) Reset()         { *m = DeleteAgentBanResponse{} }
+func (m *DeleteAgentBanResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteAgentBanResponse) ProtoMessage()    {}
+func (*DeleteAgentBanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{40}
+}
+
+func (m *DeleteAgentBanResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAgentBanResponse.Unmarshal(m, b)
+}
+func (m *DeleteAgentBanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAgentBanResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteAgentBanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAgentBanResponse.Merge(m, src)
+}
+func (m *DeleteAgentBanResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteAgentBanResponse.Size(m)
+}
+func (m *DeleteAgentBanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAgentBanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAgentBanResponse proto.InternalMessageInfo
+
+func (m *DeleteAgentBanResponse) GetBan() *common.AgentBan {
+	if m != nil {
+		return m.Ban
+	}
+	return nil
+}
+
+type NodeSelectors struct {
+	// Node SPIFFE ID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Node selectors
+	Selectors            []*common.Selector `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
+func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
+func (*NodeSelectors) ProtoMessage()    {}
+func (*NodeSelectors) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{41}
+}
+
+func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeSelectors.Unmarshal(m, b)
+}
+func (m *NodeSelectors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeSelectors.Marshal(b, m, deterministic)
+}
+func (m *NodeSelectors) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeSelectors.Merge(m, src)
+}
+func (m *NodeSelectors) XXX_Size() int {
+	return xxx_messageInfo_NodeSelectors.Size(m)
+}
+func (m *NodeSelectors) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSelectors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSelectors proto.InternalMessageInfo
+
+func (m *NodeSelectors) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *NodeSelectors) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type SetNodeSelectorsRequest struct {
+	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *SetNodeSelectorsRequest) Reset()         { *m = SetNodeSelectorsRequest{} }
+func (m *SetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetNodeSelectorsRequest) ProtoMessage()    {}
+func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{42}
+}
+
+func (m *SetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeSelectorsRequest.Unmarshal(m, b)
+}
+func (m *SetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeSelectorsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeSelectorsRequest.Merge(m, src)
+}
+func (m *SetNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetNodeSelectorsRequest.Size(m)
+}
+func (m *SetNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeSelectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeSelectorsRequest proto.InternalMessageInfo
+
+func (m *SetNodeSelectorsRequest) GetSelectors() *NodeSelectors {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type SetNodeSelectorsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetNodeSelectorsResponse) Reset()         { *m = SetNodeSelectorsResponse{} }
+func (m *SetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetNodeSelectorsResponse) ProtoMessage()    {}
+func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{43}
+}
+
+func (m *SetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *SetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeSelectorsResponse.Merge(m, src)
+}
+func (m *SetNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetNodeSelectorsResponse.Size(m)
+}
+func (m *SetNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeSelectorsResponse proto.InternalMessageInfo
+
+type GetNodeSelectorsRequest struct {
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// When enabled, read-only connection will be used to connect to database read instances. Some staleness of data will be observed.
+	TolerateStale        bool     `protobuf:"varint,2,opt,name=tolerate_stale,json=tolerateStale,proto3" json:"tolerate_stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest{} }
+func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeSelectorsRequest) ProtoMessage()    {}
+func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{44}
+}
+
+func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Unmarshal(m, b)
+}
+func (m *GetNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeSelectorsRequest.Merge(m, src)
+}
+func (m *GetNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNodeSelectorsRequest.Size(m)
+}
+func (m *GetNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeSelectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeSelectorsRequest proto.InternalMessageInfo
+
+func (m *GetNodeSelectorsRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *GetNodeSelectorsRequest) GetTolerateStale() bool {
+	if m != nil {
+		return m.TolerateStale
+	}
+	return false
+}
+
+type GetNodeSelectorsResponse struct {
+	Selectors            *NodeSelectors `protobuf:"bytes,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsResponse{} }
+func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeSelectorsResponse) ProtoMessage()    {}
+func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{45}
+}
+
+func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *GetNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeSelectorsResponse.Merge(m, src)
+}
+func (m *GetNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeSelectorsResponse.Size(m)
+}
+func (m *GetNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeSelectorsResponse proto.InternalMessageInfo
+
+func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+type CreateAttestedNodeRequest struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeRequest{} }
+func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAttestedNodeRequest) ProtoMessage()    {}
+func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{46}
 }
 
 func (m *CreateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -975,7 +2049,7 @@ func (m *CreateAttestedNodeResponse) Reset()         { *m = CreateAttestedNodeRe
 func (m *CreateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeResponse) ProtoMessage()    {}
 func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{22}
+	return fileDescriptor_d08157cfd31fc929, []int{47}
 }
 
 func (m *CreateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1014,7 +2088,7 @@ func (m *FetchAttestedNodeRequest) Reset()         { *m = FetchAttestedNodeReque
 func (m *FetchAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeRequest) ProtoMessage()    {}
 func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{23}
+	return fileDescriptor_d08157cfd31fc929, []int{48}
 }
 
 func (m *FetchAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1053,7 +2127,7 @@ func (m *FetchAttestedNodeResponse) Reset()         { *m = FetchAttestedNodeResp
 func (m *FetchAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeResponse) ProtoMessage()    {}
 func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{24}
+	return fileDescriptor_d08157cfd31fc929, []int{49}
 }
 
 func (m *FetchAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1082,18 +2156,26 @@ func (m *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
 }
 
 type ListAttestedNodesRequest struct {
-	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
-	Pagination           *Pagination          `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	ByExpiresBefore   *wrappers.Int64Value  `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	Pagination        *Pagination           `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	ByAttestationType *wrappers.StringValue `protobuf:"bytes,3,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// Only nodes that have all of the given selectors are listed
+	BySelectors    []*common.Selector   `protobuf:"bytes,4,rep,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	ByExpiresAfter *wrappers.Int64Value `protobuf:"bytes,5,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	// Nodes that were never seen are also listed
+	ByLastSeenBefore *wrappers.Int64Value `protobuf:"bytes,6,opt,name=by_last_seen_before,json=byLastSeenBefore,proto3" json:"by_last_seen_before,omitempty"`
+	// Populates the selectors of the listed nodes
+	FetchSelectors       bool     `protobuf:"varint,7,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListAttestedNodesRequest) Reset()         { *m = ListAttestedNodesRequest{} }
 func (m *ListAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesRequest) ProtoMessage()    {}
 func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{25}
+	return fileDescriptor_d08157cfd31fc929, []int{50}
 }
 
 func (m *ListAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
@@ -1128,6 +2210,41 @@ func (m *ListAttestedNodesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListAttestedNodesRequest) GetByAttestationType() *wrappers.StringValue {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetByLastSeenBefore() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByLastSeenBefore
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetFetchSelectors() bool {
+	if m != nil {
+		return m.FetchSelectors
+	}
+	return false
+}
+
 type ListAttestedNodesResponse struct {
 	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	Pagination           *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1140,7 +2257,7 @@ func (m *ListAttestedNodesResponse) Reset()         { *m = ListAttestedNodesResp
 func (m *ListAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesResponse) ProtoMessage()    {}
 func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{26}
+	return fileDescriptor_d08157cfd31fc929, []int{51}
 }
 
 func (m *ListAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
@@ -1175,6 +2292,84 @@ func (m *ListAttestedNodesResponse) GetPagination() *Pagination {
 	return nil
 }
 
+type CountAttestedNodesRequest struct {
+	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CountAttestedNodesRequest) Reset()         { *m = CountAttestedNodesRequest{} }
+func (m *CountAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountAttestedNodesRequest) ProtoMessage()    {}
+func (*CountAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{52}
+}
+
+func (m *CountAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAttestedNodesRequest.Unmarshal(m, b)
+}
+func (m *CountAttestedNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAttestedNodesRequest.Marshal(b, m, deterministic)
+}
+func (m *CountAttestedNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAttestedNodesRequest.Merge(m, src)
+}
+func (m *CountAttestedNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountAttestedNodesRequest.Size(m)
+}
+func (m *CountAttestedNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAttestedNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAttestedNodesRequest proto.InternalMessageInfo
+
+func (m *CountAttestedNodesRequest) GetByExpiresBefore() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresBefore
+	}
+	return nil
+}
+
+type CountAttestedNodesResponse struct {
+	Nodes                int32    `protobuf:"varint,1,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAttestedNodesResponse) Reset()         { *m = CountAttestedNodesResponse{} }
+func (m *CountAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountAttestedNodesResponse) ProtoMessage()    {}
+func (*CountAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{53}
+}
+
+func (m *CountAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAttestedNodesResponse.Unmarshal(m, b)
+}
+func (m *CountAttestedNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAttestedNodesResponse.Marshal(b, m, deterministic)
+}
+func (m *CountAttestedNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAttestedNodesResponse.Merge(m, src)
+}
+func (m *CountAttestedNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountAttestedNodesResponse.Size(m)
+}
+func (m *CountAttestedNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAttestedNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAttestedNodesResponse proto.InternalMessageInfo
+
+func (m *CountAttestedNodesResponse) GetNodes() int32 {
+	if m != nil {
+		return m.Nodes
+	}
+	return 0
+}
+
 type UpdateAttestedNodeRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	CertSerialNumber     string   `protobuf:"bytes,2,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
@@ -1190,7 +2385,7 @@ func (m *UpdateAttestedNodeRequest) Reset()         { *m = UpdateAttestedNodeReq
 func (m *UpdateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeRequest) ProtoMessage()    {}
 func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{27}
+	return fileDescriptor_d08157cfd31fc929, []int{54}
 }
 
 func (m *UpdateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1257,7 +2452,7 @@ func (m *UpdateAttestedNodeResponse) Reset()         { *m = UpdateAttestedNodeRe
 func (m *UpdateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeResponse) ProtoMessage()    {}
 func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{28}
+	return fileDescriptor_d08157cfd31fc929, []int{55}
 }
 
 func (m *UpdateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1285,6 +2480,108 @@ func (m *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type UpdateAttestedNodeLastSeenRequest struct {
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	LastSeenAt           int64    `protobuf:"varint,2,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	AgentVersion         string   `protobuf:"bytes,3,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	RemoteAddress        string   `protobuf:"bytes,4,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) Reset()         { *m = UpdateAttestedNodeLastSeenRequest{} }
+func (m *UpdateAttestedNodeLastSeenRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateAttestedNodeLastSeenRequest) ProtoMessage()    {}
+func (*UpdateAttestedNodeLastSeenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{56}
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Unmarshal(m, b)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Merge(m, src)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Size(m)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAttestedNodeLastSeenRequest proto.InternalMessageInfo
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetAgentVersion() string {
+	if m != nil {
+		return m.AgentVersion
+	}
+	return ""
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetRemoteAddress() string {
+	if m != nil {
+		return m.RemoteAddress
+	}
+	return ""
+}
+
+type UpdateAttestedNodeLastSeenResponse struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdateAttestedNodeLastSeenResponse) Reset()         { *m = UpdateAttestedNodeLastSeenResponse{} }
+func (m *UpdateAttestedNodeLastSeenResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateAttestedNodeLastSeenResponse) ProtoMessage()    {}
+func (*UpdateAttestedNodeLastSeenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{57}
+}
+
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Unmarshal(m, b)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Merge(m, src)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Size(m)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAttestedNodeLastSeenResponse proto.InternalMessageInfo
+
+func (m *UpdateAttestedNodeLastSeenResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type DeleteAttestedNodeRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1296,7 +2593,7 @@ func (m *DeleteAttestedNodeRequest) Reset()         { *m = DeleteAttestedNodeReq
 func (m *DeleteAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{29}
+	return fileDescriptor_d08157cfd31fc929, []int{58}
 }
 
 func (m *DeleteAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1335,7 +2632,7 @@ func (m *DeleteAttestedNodeResponse) Reset()         { *m = DeleteAttestedNodeRe
 func (m *DeleteAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{30}
+	return fileDescriptor_d08157cfd31fc929, []int{59}
 }
 
 func (m *DeleteAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1363,6 +2660,95 @@ func (m *DeleteAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type PruneAttestedNodesRequest struct {
+	ExpiresBefore int64 `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	// Maximum number of attested nodes to prune. All of the matching
+	// attested nodes are pruned if zero.
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneAttestedNodesRequest) Reset()         { *m = PruneAttestedNodesRequest{} }
+func (m *PruneAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneAttestedNodesRequest) ProtoMessage()    {}
+func (*PruneAttestedNodesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{60}
+}
+
+func (m *PruneAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Unmarshal(m, b)
+}
+func (m *PruneAttestedNodesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneAttestedNodesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAttestedNodesRequest.Merge(m, src)
+}
+func (m *PruneAttestedNodesRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneAttestedNodesRequest.Size(m)
+}
+func (m *PruneAttestedNodesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAttestedNodesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAttestedNodesRequest proto.InternalMessageInfo
+
+func (m *PruneAttestedNodesRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+func (m *PruneAttestedNodesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type PruneAttestedNodesResponse struct {
+	// Nodes that were pruned
+	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PruneAttestedNodesResponse) Reset()         { *m = PruneAttestedNodesResponse{} }
+func (m *PruneAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneAttestedNodesResponse) ProtoMessage()    {}
+func (*PruneAttestedNodesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{61}
+}
+
+func (m *PruneAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Unmarshal(m, b)
+}
+func (m *PruneAttestedNodesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneAttestedNodesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneAttestedNodesResponse.Merge(m, src)
+}
+func (m *PruneAttestedNodesResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneAttestedNodesResponse.Size(m)
+}
+func (m *PruneAttestedNodesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneAttestedNodesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneAttestedNodesResponse proto.InternalMessageInfo
+
+func (m *PruneAttestedNodesResponse) GetNodes() []*common.AttestedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 type CreateRegistrationEntryRequest struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1374,7 +2760,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{31}
+	return fileDescriptor_d08157cfd31fc929, []int{62}
 }
 
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1413,7 +2799,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{32}
+	return fileDescriptor_d08157cfd31fc929, []int{63}
 }
 
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1452,7 +2838,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{33}
+	return fileDescriptor_d08157cfd31fc929, []int{64}
 }
 
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1491,7 +2877,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{34}
+	return fileDescriptor_d08157cfd31fc929, []int{65}
 }
 
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1531,7 +2917,7 @@ func (m *BySelectors) Reset()         { *m = BySelectors{} }
 func (m *BySelectors) String() string { return proto.CompactTextString(m) }
 func (*BySelectors) ProtoMessage()    {}
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35}
+	return fileDescriptor_d08157cfd31fc929, []int{66}
 }
 
 func (m *BySelectors) XXX_Unmarshal(b []byte) error {
@@ -1578,7 +2964,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{36}
+	return fileDescriptor_d08157cfd31fc929, []int{67}
 }
 
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
@@ -1629,7 +3015,7 @@ func (m *ListRegistrationEntriesRequest) Reset()         { *m = ListRegistration
 func (m *ListRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesRequest) ProtoMessage()    {}
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{37}
+	return fileDescriptor_d08157cfd31fc929, []int{68}
 }
 
 func (m *ListRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1693,43 +3079,113 @@ type ListRegistrationEntriesResponse struct {
 	XXX_sizecache        int32                       `json:"-"`
 }
 
-func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistrationEntriesResponse{} }
-func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
-func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
-func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{38}
+func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistrationEntriesResponse{} }
+func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
+func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{69}
+}
+
+func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntriesResponse.Unmarshal(m, b)
+}
+func (m *ListRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRegistrationEntriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRegistrationEntriesResponse.Merge(m, src)
+}
+func (m *ListRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRegistrationEntriesResponse.Size(m)
+}
+func (m *ListRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRegistrationEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRegistrationEntriesResponse proto.InternalMessageInfo
+
+func (m *ListRegistrationEntriesResponse) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type CountRegistrationEntriesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountRegistrationEntriesRequest) Reset()         { *m = CountRegistrationEntriesRequest{} }
+func (m *CountRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountRegistrationEntriesRequest) ProtoMessage()    {}
+func (*CountRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{70}
+}
+
+func (m *CountRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Unmarshal(m, b)
+}
+func (m *CountRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *CountRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountRegistrationEntriesRequest.Merge(m, src)
+}
+func (m *CountRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Size(m)
+}
+func (m *CountRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountRegistrationEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountRegistrationEntriesRequest proto.InternalMessageInfo
+
+type CountRegistrationEntriesResponse struct {
+	Entries              int32    `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountRegistrationEntriesResponse) Reset()         { *m = CountRegistrationEntriesResponse{} }
+func (m *CountRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountRegistrationEntriesResponse) ProtoMessage()    {}
+func (*CountRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{71}
 }
 
-func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListRegistrationEntriesResponse.Unmarshal(m, b)
+func (m *CountRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Unmarshal(m, b)
 }
-func (m *ListRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListRegistrationEntriesResponse.Marshal(b, m, deterministic)
+func (m *CountRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *ListRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListRegistrationEntriesResponse.Merge(m, src)
+func (m *CountRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountRegistrationEntriesResponse.Merge(m, src)
 }
-func (m *ListRegistrationEntriesResponse) XXX_Size() int {
-	return xxx_messageInfo_ListRegistrationEntriesResponse.Size(m)
+func (m *CountRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Size(m)
 }
-func (m *ListRegistrationEntriesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListRegistrationEntriesResponse.DiscardUnknown(m)
+func (m *CountRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountRegistrationEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListRegistrationEntriesResponse proto.InternalMessageInfo
+var xxx_messageInfo_CountRegistrationEntriesResponse proto.InternalMessageInfo
 
-func (m *ListRegistrationEntriesResponse) GetEntries() []*common.RegistrationEntry {
+func (m *CountRegistrationEntriesResponse) GetEntries() int32 {
 	if m != nil {
 		return m.Entries
 	}
-	return nil
-}
-
-func (m *ListRegistrationEntriesResponse) GetPagination() *Pagination {
-	if m != nil {
-		return m.Pagination
-	}
-	return nil
+	return 0
 }
 
 type UpdateRegistrationEntryRequest struct {
@@ -1743,7 +3199,7 @@ func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrati
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{39}
+	return fileDescriptor_d08157cfd31fc929, []int{72}
 }
 
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1782,7 +3238,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{40}
+	return fileDescriptor_d08157cfd31fc929, []int{73}
 }
 
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1821,7 +3277,7 @@ func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrati
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{41}
+	return fileDescriptor_d08157cfd31fc929, []int{74}
 }
 
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1860,7 +3316,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{42}
+	return fileDescriptor_d08157cfd31fc929, []int{75}
 }
 
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1899,7 +3355,7 @@ func (m *PruneRegistrationEntriesRequest) Reset()         { *m = PruneRegistrati
 func (m *PruneRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesRequest) ProtoMessage()    {}
 func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{43}
+	return fileDescriptor_d08157cfd31fc929, []int{76}
 }
 
 func (m *PruneRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1937,7 +3393,7 @@ func (m *PruneRegistrationEntriesResponse) Reset()         { *m = PruneRegistrat
 func (m *PruneRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneRegistrationEntriesResponse) ProtoMessage()    {}
 func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{44}
+	return fileDescriptor_d08157cfd31fc929, []int{77}
 }
 
 func (m *PruneRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -1962,17 +3418,27 @@ type JoinToken struct {
 	// Token value
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// Expiration in seconds since unix epoch
-	Expiry               int64    `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// (optional) SPIFFE ID template, or path template relative to
+	// /spire/agent, of the agents attesting with the token
+	AgentIdTemplate string `protobuf:"bytes,3,opt,name=agent_id_template,json=agentIdTemplate,proto3" json:"agent_id_template,omitempty"`
+	// (optional) Node selectors applied to the agents attesting with the
+	// token
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Number of times the token can be used. Zero means once.
+	MaxUses int32 `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Uses of the token so far, in order
+	Uses                 []*JoinTokenUse `protobuf:"bytes,6,rep,name=uses,proto3" json:"uses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{45}
+	return fileDescriptor_d08157cfd31fc929, []int{78}
 }
 
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
@@ -2007,6 +3473,92 @@ func (m *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+func (m *JoinToken) GetAgentIdTemplate() string {
+	if m != nil {
+		return m.AgentIdTemplate
+	}
+	return ""
+}
+
+func (m *JoinToken) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUses() []*JoinTokenUse {
+	if m != nil {
+		return m.Uses
+	}
+	return nil
+}
+
+type JoinTokenUse struct {
+	// Use number, starting at one
+	Use int32 `protobuf:"varint,1,opt,name=use,proto3" json:"use,omitempty"`
+	// SPIFFE ID of the agent that attested with the token
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// When the token was used, in seconds since unix epoch
+	UsedAt               int64    `protobuf:"varint,3,opt,name=used_at,json=usedAt,proto3" json:"used_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinTokenUse) Reset()         { *m = JoinTokenUse{} }
+func (m *JoinTokenUse) String() string { return proto.CompactTextString(m) }
+func (*JoinTokenUse) ProtoMessage()    {}
+func (*JoinTokenUse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{79}
+}
+
+func (m *JoinTokenUse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinTokenUse.Unmarshal(m, b)
+}
+func (m *JoinTokenUse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinTokenUse.Marshal(b, m, deterministic)
+}
+func (m *JoinTokenUse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinTokenUse.Merge(m, src)
+}
+func (m *JoinTokenUse) XXX_Size() int {
+	return xxx_messageInfo_JoinTokenUse.Size(m)
+}
+func (m *JoinTokenUse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinTokenUse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinTokenUse proto.InternalMessageInfo
+
+func (m *JoinTokenUse) GetUse() int32 {
+	if m != nil {
+		return m.Use
+	}
+	return 0
+}
+
+func (m *JoinTokenUse) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *JoinTokenUse) GetUsedAt() int64 {
+	if m != nil {
+		return m.UsedAt
+	}
+	return 0
+}
+
 type CreateJoinTokenRequest struct {
 	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -2018,7 +3570,7 @@ func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{}
 func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenRequest) ProtoMessage()    {}
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{46}
+	return fileDescriptor_d08157cfd31fc929, []int{80}
 }
 
 func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2057,7 +3609,7 @@ func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse
 func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenResponse) ProtoMessage()    {}
 func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{47}
+	return fileDescriptor_d08157cfd31fc929, []int{81}
 }
 
 func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2096,7 +3648,7 @@ func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
 func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenRequest) ProtoMessage()    {}
 func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{48}
+	return fileDescriptor_d08157cfd31fc929, []int{82}
 }
 
 func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2135,7 +3687,7 @@ func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{}
 func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenResponse) ProtoMessage()    {}
 func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{49}
+	return fileDescriptor_d08157cfd31fc929, []int{83}
 }
 
 func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2174,7 +3726,7 @@ func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{}
 func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenRequest) ProtoMessage()    {}
 func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{50}
+	return fileDescriptor_d08157cfd31fc929, []int{84}
 }
 
 func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
@@ -2213,7 +3765,7 @@ func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse
 func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenResponse) ProtoMessage()    {}
 func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{51}
+	return fileDescriptor_d08157cfd31fc929, []int{85}
 }
 
 func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
@@ -2241,6 +3793,94 @@ func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
+type UseJoinTokenRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The use to record. Its use number must be the one following the
+	// last recorded use.
+	Use                  *JoinTokenUse `protobuf:"bytes,2,opt,name=use,proto3" json:"use,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *UseJoinTokenRequest) Reset()         { *m = UseJoinTokenRequest{} }
+func (m *UseJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenRequest) ProtoMessage()    {}
+func (*UseJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{86}
+}
+
+func (m *UseJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *UseJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *UseJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenRequest.Merge(m, src)
+}
+func (m *UseJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenRequest.Size(m)
+}
+func (m *UseJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenRequest proto.InternalMessageInfo
+
+func (m *UseJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *UseJoinTokenRequest) GetUse() *JoinTokenUse {
+	if m != nil {
+		return m.Use
+	}
+	return nil
+}
+
+type UseJoinTokenResponse struct {
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UseJoinTokenResponse) Reset()         { *m = UseJoinTokenResponse{} }
+func (m *UseJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenResponse) ProtoMessage()    {}
+func (*UseJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{87}
+}
+
+func (m *UseJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *UseJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *UseJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenResponse.Merge(m, src)
+}
+func (m *UseJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenResponse.Size(m)
+}
+func (m *UseJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenResponse proto.InternalMessageInfo
+
+func (m *UseJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
 type PruneJoinTokensRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2252,7 +3892,7 @@ func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{}
 func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensRequest) ProtoMessage()    {}
 func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{52}
+	return fileDescriptor_d08157cfd31fc929, []int{88}
 }
 
 func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
@@ -2290,7 +3930,7 @@ func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse
 func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensResponse) ProtoMessage()    {}
 func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{53}
+	return fileDescriptor_d08157cfd31fc929, []int{89}
 }
 
 func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
@@ -2320,6 +3960,8 @@ func init() {
 	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
 	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
 	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
+	proto.RegisterType((*CountBundlesRequest)(nil), "spire.server.datastore.CountBundlesRequest")
+	proto.RegisterType((*CountBundlesResponse)(nil), "spire.server.datastore.CountBundlesResponse")
 	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
 	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
 	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
@@ -2330,6 +3972,29 @@ func init() {
 	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
 	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
 	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
+	proto.RegisterType((*RemoveBundleJWTSigningKeysRequest)(nil), "spire.server.datastore.RemoveBundleJWTSigningKeysRequest")
+	proto.RegisterType((*RemoveBundleJWTSigningKeysResponse)(nil), "spire.server.datastore.RemoveBundleJWTSigningKeysResponse")
+	proto.RegisterType((*BundleHistoryEntry)(nil), "spire.server.datastore.BundleHistoryEntry")
+	proto.RegisterType((*AppendBundleHistoryRequest)(nil), "spire.server.datastore.AppendBundleHistoryRequest")
+	proto.RegisterType((*AppendBundleHistoryResponse)(nil), "spire.server.datastore.AppendBundleHistoryResponse")
+	proto.RegisterType((*ListBundleHistoryRequest)(nil), "spire.server.datastore.ListBundleHistoryRequest")
+	proto.RegisterType((*ListBundleHistoryResponse)(nil), "spire.server.datastore.ListBundleHistoryResponse")
+	proto.RegisterType((*CreateFederationRelationshipRequest)(nil), "spire.server.datastore.CreateFederationRelationshipRequest")
+	proto.RegisterType((*CreateFederationRelationshipResponse)(nil), "spire.server.datastore.CreateFederationRelationshipResponse")
+	proto.RegisterType((*FetchFederationRelationshipRequest)(nil), "spire.server.datastore.FetchFederationRelationshipRequest")
+	proto.RegisterType((*FetchFederationRelationshipResponse)(nil), "spire.server.datastore.FetchFederationRelationshipResponse")
+	proto.RegisterType((*ListFederationRelationshipsRequest)(nil), "spire.server.datastore.ListFederationRelationshipsRequest")
+	proto.RegisterType((*ListFederationRelationshipsResponse)(nil), "spire.server.datastore.ListFederationRelationshipsResponse")
+	proto.RegisterType((*UpdateFederationRelationshipRequest)(nil), "spire.server.datastore.UpdateFederationRelationshipRequest")
+	proto.RegisterType((*UpdateFederationRelationshipResponse)(nil), "spire.server.datastore.UpdateFederationRelationshipResponse")
+	proto.RegisterType((*DeleteFederationRelationshipRequest)(nil), "spire.server.datastore.DeleteFederationRelationshipRequest")
+	proto.RegisterType((*DeleteFederationRelationshipResponse)(nil), "spire.server.datastore.DeleteFederationRelationshipResponse")
+	proto.RegisterType((*CreateAgentBanRequest)(nil), "spire.server.datastore.CreateAgentBanRequest")
+	proto.RegisterType((*CreateAgentBanResponse)(nil), "spire.server.datastore.CreateAgentBanResponse")
+	proto.RegisterType((*ListAgentBansRequest)(nil), "spire.server.datastore.ListAgentBansRequest")
+	proto.RegisterType((*ListAgentBansResponse)(nil), "spire.server.datastore.ListAgentBansResponse")
+	proto.RegisterType((*DeleteAgentBanRequest)(nil), "spire.server.datastore.DeleteAgentBanRequest")
+	proto.RegisterType((*DeleteAgentBanResponse)(nil), "spire.server.datastore.DeleteAgentBanResponse")
 	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
 	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
 	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
@@ -2341,10 +4006,16 @@ func init() {
 	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
 	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
 	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
+	proto.RegisterType((*CountAttestedNodesRequest)(nil), "spire.server.datastore.CountAttestedNodesRequest")
+	proto.RegisterType((*CountAttestedNodesResponse)(nil), "spire.server.datastore.CountAttestedNodesResponse")
 	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
 	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*UpdateAttestedNodeLastSeenRequest)(nil), "spire.server.datastore.UpdateAttestedNodeLastSeenRequest")
+	proto.RegisterType((*UpdateAttestedNodeLastSeenResponse)(nil), "spire.server.datastore.UpdateAttestedNodeLastSeenResponse")
 	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
 	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
+	proto.RegisterType((*PruneAttestedNodesRequest)(nil), "spire.server.datastore.PruneAttestedNodesRequest")
+	proto.RegisterType((*PruneAttestedNodesResponse)(nil), "spire.server.datastore.PruneAttestedNodesResponse")
 	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
 	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
 	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
@@ -2353,6 +4024,8 @@ func init() {
 	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
 	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
 	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
+	proto.RegisterType((*CountRegistrationEntriesRequest)(nil), "spire.server.datastore.CountRegistrationEntriesRequest")
+	proto.RegisterType((*CountRegistrationEntriesResponse)(nil), "spire.server.datastore.CountRegistrationEntriesResponse")
 	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
 	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
 	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
@@ -2360,12 +4033,15 @@ func init() {
 	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
 	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
 	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
+	proto.RegisterType((*JoinTokenUse)(nil), "spire.server.datastore.JoinTokenUse")
 	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
 	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
 	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
 	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
 	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
 	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*UseJoinTokenRequest)(nil), "spire.server.datastore.UseJoinTokenRequest")
+	proto.RegisterType((*UseJoinTokenResponse)(nil), "spire.server.datastore.UseJoinTokenResponse")
 	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
 	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
 }
@@ -2373,117 +4049,190 @@ func init() {
 func init() { proto.RegisterFile("datastore.proto", fileDescriptor_d08157cfd31fc929) }
 
 var fileDescriptor_d08157cfd31fc929 = []byte{
-	// 1758 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xeb, 0x76, 0xdb, 0x44,
-	0x10, 0x46, 0xb9, 0x35, 0x1e, 0xe7, 0xd6, 0x4d, 0x48, 0x6c, 0x17, 0x92, 0x20, 0x48, 0x4f, 0xaf,
-	0x72, 0xea, 0xa6, 0x69, 0x0b, 0x3d, 0xb4, 0x89, 0xe3, 0xa6, 0x86, 0xb4, 0xe4, 0xc8, 0x29, 0xed,
-	0x69, 0x0f, 0x08, 0x39, 0x5e, 0x3b, 0x2a, 0x8e, 0x64, 0xa4, 0x75, 0xdb, 0xb4, 0x0f, 0xc0, 0x81,
-	0x73, 0xf8, 0xc1, 0x1b, 0xf0, 0x8f, 0x27, 0xe0, 0x3f, 0xef, 0xc0, 0x7b, 0xf0, 0x0c, 0x1c, 0xed,
-	0x4a, 0x96, 0x64, 0xed, 0x2a, 0xbe, 0x84, 0x5f, 0x89, 0x66, 0xe7, 0xf2, 0xcd, 0xec, 0xce, 0xee,
-	0xcc, 0x18, 0x66, 0x6b, 0x3a, 0xd1, 0x1d, 0x62, 0xd9, 0x58, 0x69, 0xd9, 0x16, 0xb1, 0xd0, 0xa2,
-	0xd3, 0x32, 0x6c, 0xac, 0x38, 0xd8, 0x7e, 0x8d, 0x6d, 0xa5, 0xb3, 0x9a, 0x5b, 0x6e, 0x58, 0x56,
-	0xa3, 0x89, 0xf3, 0x94, 0xab, 0xda, 0xae, 0xe7, 0xdf, 0xd8, 0x7a, 0xab, 0x85, 0x6d, 0x87, 0xc9,
-	0xe5, 0x56, 0xa9, 0x5c, 0xfe, 0xd0, 0x3a, 0x3e, 0xb6, 0xcc, 0x7c, 0xab, 0xd9, 0x6e, 0x18, 0xfe,
-	0x1f, 0x8f, 0x23, 0x1b, 0xe1, 0x60, 0x7f, 0xd8, 0x92, 0x5c, 0x84, 0xf9, 0xa2, 0x8d, 0x75, 0x82,
-	0xb7, 0xdb, 0x66, 0xad, 0x89, 0x55, 0xfc, 0x53, 0x1b, 0x3b, 0x04, 0x5d, 0x83, 0x89, 0x2a, 0x25,
-	0x64, 0xa4, 0x55, 0xe9, 0x52, 0xba, 0xb0, 0xa0, 0x30, 0x70, 0x9e, 0xac, 0xc7, 0xec, 0xf1, 0xc8,
-	0x3b, 0xb0, 0x10, 0x55, 0xe2, 0xb4, 0x2c, 0xd3, 0xc1, 0x7d, 0x6a, 0xb9, 0x07, 0xe8, 0x21, 0x26,
-	0x87, 0x47, 0x51, 0x24, 0x17, 0x61, 0x96, 0xd8, 0x6d, 0x87, 0x68, 0x35, 0xeb, 0x58, 0x37, 0x4c,
-	0xcd, 0xa8, 0x51, 0x65, 0x29, 0x75, 0x9a, 0x92, 0x77, 0x28, 0xb5, 0x5c, 0x73, 0x1d, 0x89, 0x48,
-	0x0f, 0x04, 0x61, 0x01, 0xd0, 0x9e, 0xe1, 0x10, 0x46, 0x75, 0x3c, 0x08, 0x72, 0x09, 0xe6, 0x23,
-	0x54, 0x4f, 0xb5, 0x02, 0xe7, 0x98, 0x98, 0x93, 0x91, 0x56, 0x47, 0x85, 0xba, 0x7d, 0x26, 0x17,
-	0xe1, 0xd3, 0x56, 0x6d, 0xf8, 0x50, 0x47, 0x95, 0x0c, 0xe4, 0xe7, 0x03, 0x98, 0xab, 0x60, 0x32,
-	0x0c, 0x8e, 0x2d, 0x38, 0x1f, 0xd2, 0x30, 0x10, 0x88, 0x22, 0xcc, 0x6f, 0xb5, 0x5a, 0xd8, 0xac,
-	0x0d, 0x19, 0x8f, 0xa8, 0x92, 0x81, 0xa0, 0xfc, 0x25, 0xc1, 0xfc, 0x0e, 0x6e, 0xe2, 0xee, 0xbd,
-	0xe9, 0xf1, 0xf0, 0xa1, 0x1d, 0x18, 0x3b, 0xb6, 0x6a, 0x38, 0x33, 0xb2, 0x2a, 0x5d, 0x9a, 0x29,
-	0xac, 0x2b, 0xfc, 0x4c, 0x56, 0x38, 0x26, 0x94, 0xc7, 0x56, 0x0d, 0xab, 0x54, 0x5a, 0x5e, 0x87,
-	0x31, 0xf7, 0x0b, 0x4d, 0xc1, 0xa4, 0x5a, 0xaa, 0x1c, 0xa8, 0xe5, 0xe2, 0xc1, 0xdc, 0x07, 0x08,
-	0x60, 0x62, 0xa7, 0xb4, 0x57, 0x3a, 0x28, 0xcd, 0x49, 0x68, 0x06, 0x60, 0xa7, 0x5c, 0xa9, 0x7c,
-	0x53, 0x2c, 0x6f, 0x1d, 0x94, 0xe6, 0x46, 0x5c, 0xef, 0xa3, 0x3a, 0x07, 0xf2, 0xfe, 0x10, 0xd0,
-	0xbe, 0xdd, 0x36, 0x07, 0xf4, 0x7d, 0x0d, 0x66, 0xf0, 0x5b, 0x57, 0xbb, 0xa3, 0x55, 0x71, 0xdd,
-	0xb2, 0x59, 0x14, 0x46, 0xd5, 0x69, 0x8f, 0xba, 0x4d, 0x89, 0xf2, 0x3d, 0x98, 0x8f, 0x18, 0xf1,
-	0x90, 0xae, 0xc1, 0x0c, 0x43, 0xa1, 0x1d, 0x1e, 0xe9, 0x66, 0x03, 0x33, 0x23, 0x93, 0xea, 0x34,
-	0xa3, 0x16, 0x19, 0x51, 0xae, 0xc2, 0xf4, 0x13, 0xab, 0x86, 0x2b, 0xb8, 0x89, 0x0f, 0x89, 0x65,
-	0x3b, 0xe8, 0x02, 0xa4, 0x9c, 0x96, 0x51, 0xaf, 0xe3, 0x00, 0xd7, 0x24, 0x23, 0x94, 0x6b, 0x68,
-	0x03, 0x52, 0x8e, 0xcf, 0x99, 0x19, 0xa1, 0xb9, 0xb9, 0x18, 0x8d, 0x80, 0xaf, 0x48, 0x0d, 0x18,
-	0xe5, 0xef, 0x61, 0xa9, 0x82, 0x49, 0xc4, 0x8c, 0x1f, 0x8b, 0x62, 0x58, 0x21, 0x0b, 0xe9, 0x9a,
-	0x68, 0x93, 0xa3, 0x0a, 0x42, 0xfa, 0x73, 0x90, 0x89, 0xeb, 0x67, 0x61, 0x90, 0xbf, 0x83, 0xa5,
-	0x5d, 0x81, 0xed, 0x44, 0x4f, 0xd7, 0x60, 0x86, 0x58, 0x4d, 0x6c, 0xeb, 0x04, 0x6b, 0x0e, 0xd1,
-	0x9b, 0x2c, 0xf8, 0x93, 0xea, 0xb4, 0x4f, 0xad, 0xb8, 0x44, 0x59, 0x83, 0xcc, 0xae, 0xc0, 0xf4,
-	0xd9, 0xf8, 0xf6, 0x35, 0x64, 0xd9, 0x0b, 0xb0, 0x45, 0x08, 0x76, 0x08, 0xae, 0xb9, 0x9c, 0xbe,
-	0x07, 0x0a, 0x8c, 0x99, 0x6e, 0x76, 0x30, 0xe5, 0xb9, 0xe8, 0x4e, 0x44, 0x04, 0x28, 0x9f, 0xbc,
-	0x07, 0x39, 0x9e, 0xb2, 0xce, 0xb5, 0xdb, 0x9f, 0xb6, 0xdb, 0x90, 0xa1, 0x0f, 0x03, 0x0f, 0x59,
-	0x52, 0x6c, 0x5d, 0x9f, 0x38, 0x82, 0x03, 0xa2, 0xf8, 0x53, 0x82, 0x8c, 0xfb, 0x88, 0x84, 0x97,
-	0x3a, 0x5b, 0xbc, 0x0b, 0xe7, 0xab, 0x27, 0x5a, 0x57, 0x16, 0x31, 0xcd, 0x17, 0x14, 0xf6, 0xfa,
-	0x2b, 0xfe, 0xeb, 0xaf, 0x94, 0x4d, 0xb2, 0xb9, 0xf1, 0xad, 0xde, 0x6c, 0x63, 0x75, 0xb6, 0x7a,
-	0x52, 0x0a, 0x27, 0x19, 0xda, 0x06, 0x68, 0xe9, 0x0d, 0xc3, 0xd4, 0x89, 0x61, 0x99, 0xf4, 0x28,
-	0xa4, 0x0b, 0xb2, 0x68, 0x33, 0xf7, 0x3b, 0x9c, 0x6a, 0x48, 0x4a, 0xfe, 0x5d, 0x82, 0x2c, 0x07,
-	0xa9, 0xe7, 0xf7, 0x3a, 0x8c, 0xbb, 0xfe, 0xf8, 0x4f, 0x5e, 0x92, 0xe3, 0x8c, 0xf1, 0x4c, 0x30,
-	0xfd, 0x2b, 0x41, 0x96, 0x3d, 0x7b, 0xfd, 0xee, 0x22, 0xba, 0x06, 0xe8, 0x10, 0xdb, 0x44, 0x73,
-	0xb0, 0x6d, 0xe8, 0x4d, 0xcd, 0x6c, 0x1f, 0x57, 0xb1, 0x4d, 0x61, 0xa4, 0xd4, 0x39, 0x77, 0xa5,
-	0x42, 0x17, 0x9e, 0x50, 0x3a, 0xfa, 0x0c, 0x66, 0x28, 0xb7, 0x69, 0x11, 0x4d, 0xaf, 0x13, 0x6c,
-	0x67, 0x46, 0xe9, 0x65, 0x36, 0xe5, 0x52, 0x9f, 0x58, 0x64, 0xcb, 0xa5, 0xa1, 0x9b, 0xb0, 0x68,
-	0xe2, 0x37, 0x1a, 0x47, 0xef, 0x18, 0xd5, 0x3b, 0x6f, 0xe2, 0x37, 0xc5, 0x6e, 0xd5, 0x57, 0x01,
-	0x75, 0x84, 0x02, 0xf5, 0xe3, 0x54, 0xfd, 0xac, 0x27, 0xe0, 0x5b, 0x70, 0x53, 0x80, 0xe7, 0xef,
-	0x80, 0x87, 0xef, 0x0e, 0x64, 0xd9, 0x33, 0xd1, 0x77, 0x0e, 0xec, 0x41, 0x8e, 0x27, 0x39, 0x20,
-	0x8e, 0x67, 0xb0, 0xcc, 0x12, 0x5b, 0xc5, 0x0d, 0xc3, 0x21, 0x36, 0xdd, 0xdc, 0x92, 0x49, 0xec,
-	0x13, 0x1f, 0xcc, 0x2d, 0x18, 0xc7, 0xee, 0xb7, 0xa7, 0x72, 0x25, 0xaa, 0x32, 0x2e, 0xc6, 0xb8,
-	0xe5, 0xe7, 0xb0, 0x22, 0x54, 0xec, 0x61, 0x1d, 0x50, 0xf3, 0xe7, 0xf0, 0x31, 0xbd, 0x04, 0x84,
-	0x88, 0xb3, 0x30, 0x49, 0x39, 0x83, 0xe8, 0x9d, 0xa3, 0xdf, 0xe5, 0x9a, 0xeb, 0xae, 0x48, 0x76,
-	0x38, 0x50, 0x7f, 0x4b, 0x90, 0xde, 0x3e, 0x09, 0x1e, 0xc3, 0x8d, 0xe8, 0x15, 0xde, 0xdb, 0x7b,
-	0x87, 0x76, 0x61, 0xfc, 0x58, 0x27, 0x87, 0x47, 0x5e, 0xd5, 0x72, 0x43, 0x94, 0x93, 0x21, 0x4b,
-	0xca, 0x63, 0x57, 0x60, 0x1b, 0x1f, 0xe9, 0xaf, 0x0d, 0xcb, 0x56, 0x99, 0xbc, 0x5c, 0x80, 0xe9,
-	0x08, 0x1d, 0xcd, 0x42, 0xfa, 0xf1, 0xd6, 0x41, 0xf1, 0x91, 0x56, 0x7a, 0xbe, 0x45, 0x6b, 0x98,
-	0x39, 0x98, 0x62, 0x84, 0xca, 0xd3, 0xed, 0x4a, 0xe9, 0x60, 0x4e, 0x92, 0xef, 0x03, 0x04, 0xb9,
-	0x8e, 0x16, 0x60, 0x9c, 0x58, 0x3f, 0x62, 0xd3, 0x8b, 0x20, 0xfb, 0x70, 0x4f, 0x66, 0x4b, 0x6f,
-	0x60, 0xcd, 0x31, 0xde, 0xb1, 0x77, 0x6d, 0x5c, 0x9d, 0x74, 0x09, 0x15, 0xe3, 0x1d, 0x96, 0xff,
-	0x19, 0x81, 0x65, 0xf7, 0x9a, 0xea, 0x0e, 0x92, 0x11, 0x5c, 0xab, 0x5f, 0xc2, 0x54, 0xf5, 0x44,
-	0x6b, 0xe9, 0x36, 0x36, 0x89, 0xbf, 0x3d, 0xe9, 0xc2, 0x47, 0xb1, 0x1b, 0xb5, 0x42, 0x6c, 0xc3,
-	0x6c, 0xb0, 0x2b, 0x15, 0xaa, 0x27, 0xfb, 0x54, 0xa0, 0x5c, 0x43, 0x0f, 0xa9, 0x7c, 0xb8, 0x92,
-	0x70, 0xe5, 0x3f, 0xed, 0x21, 0x4e, 0x6a, 0xba, 0x1a, 0xda, 0x1e, 0x86, 0x23, 0x48, 0xb2, 0xd1,
-	0xde, 0x70, 0x54, 0xfc, 0x2b, 0x2c, 0x7a, 0x83, 0x8e, 0x0d, 0x72, 0x83, 0x72, 0x0a, 0x85, 0x71,
-	0x5e, 0xa1, 0xf0, 0x87, 0x04, 0x2b, 0xc2, 0xa8, 0x7a, 0x87, 0xf6, 0x2e, 0xd0, 0x13, 0x6e, 0x74,
-	0x1e, 0x81, 0x53, 0x8f, 0xad, 0xcf, 0x7f, 0x26, 0x6f, 0xc1, 0x33, 0x58, 0x66, 0x57, 0xe3, 0xff,
-	0x70, 0x89, 0x08, 0x15, 0x0f, 0x97, 0xaf, 0x5f, 0xc0, 0x32, 0xbb, 0x45, 0x07, 0xb9, 0x45, 0x9e,
-	0xc3, 0x8a, 0x50, 0x78, 0x38, 0x58, 0x8f, 0x60, 0x85, 0x96, 0xe4, 0x09, 0x29, 0x14, 0x2f, 0xee,
-	0x25, 0x5e, 0x71, 0x2f, 0xc3, 0xaa, 0x58, 0x93, 0x57, 0xe2, 0xde, 0x85, 0xd4, 0x57, 0x96, 0x61,
-	0x1e, 0xd0, 0xd4, 0xe6, 0x27, 0xfc, 0x22, 0x4c, 0x50, 0xbd, 0x27, 0x5e, 0x0b, 0xe1, 0x7d, 0xc9,
-	0x2f, 0x60, 0x91, 0x5d, 0xef, 0x1d, 0x05, 0x3e, 0xbe, 0x07, 0x00, 0xaf, 0x2c, 0xc3, 0xd4, 0x02,
-	0x65, 0xe9, 0xc2, 0x27, 0xa2, 0x03, 0x15, 0x48, 0xa7, 0x5e, 0xf9, 0xff, 0xca, 0x2f, 0x61, 0x29,
-	0xa6, 0xdb, 0x0b, 0xeb, 0xf0, 0xca, 0xaf, 0xc3, 0x87, 0xf4, 0x05, 0x88, 0xe1, 0xe6, 0xfa, 0xef,
-	0xfa, 0xd9, 0xcd, 0x7e, 0x66, 0x50, 0x14, 0x58, 0x64, 0xc7, 0xa8, 0x47, 0x2c, 0x2f, 0x61, 0x29,
-	0xc6, 0x7f, 0x66, 0x60, 0xee, 0xc3, 0x22, 0x3d, 0x2f, 0x9d, 0xc5, 0x7e, 0x0f, 0x5c, 0x16, 0x96,
-	0x62, 0x0a, 0x18, 0xba, 0xc2, 0x2f, 0x59, 0x48, 0xed, 0xe8, 0x44, 0xaf, 0xb8, 0xe6, 0x91, 0x01,
-	0x53, 0xe1, 0xd1, 0x14, 0xba, 0x2a, 0xc2, 0xc9, 0x99, 0x82, 0xe5, 0xae, 0xf5, 0xc6, 0xec, 0x85,
-	0xa5, 0x0e, 0xe9, 0xd0, 0x04, 0x0a, 0x5d, 0x11, 0x09, 0xc7, 0x87, 0x5c, 0xb9, 0xab, 0x3d, 0xf1,
-	0x06, 0x76, 0x42, 0xe3, 0x28, 0xb1, 0x9d, 0xf8, 0x24, 0x4b, 0x6c, 0x87, 0x37, 0xdf, 0x32, 0x60,
-	0x2a, 0x3c, 0x6a, 0x12, 0x87, 0x8e, 0x33, 0xd5, 0x12, 0x87, 0x8e, 0x3b, 0xbd, 0xfa, 0x01, 0x52,
-	0x9d, 0x69, 0x12, 0xba, 0x24, 0x12, 0xed, 0x1e, 0x59, 0xe5, 0x2e, 0xf7, 0xc0, 0x19, 0x38, 0x13,
-	0x9e, 0x13, 0x89, 0x9d, 0xe1, 0x8c, 0xa4, 0xc4, 0xce, 0x70, 0x47, 0x4f, 0x06, 0x4c, 0x85, 0x87,
-	0x32, 0x62, 0x53, 0x9c, 0x71, 0x90, 0xd8, 0x14, 0x77, 0xce, 0x53, 0x87, 0x74, 0x68, 0xa8, 0x22,
-	0x3e, 0x0a, 0xf1, 0xf1, 0x8e, 0xf8, 0x28, 0xf0, 0xa6, 0x34, 0xef, 0x01, 0xc5, 0x3b, 0x72, 0x74,
-	0x23, 0x39, 0x3d, 0x38, 0xcd, 0x46, 0xae, 0xd0, 0x8f, 0x88, 0x67, 0xfc, 0x2d, 0x9c, 0x8f, 0xf5,
-	0xe1, 0x68, 0x3d, 0x31, 0x63, 0x78, 0xa6, 0x6f, 0xf4, 0x21, 0x11, 0x58, 0x8e, 0x75, 0xc2, 0x62,
-	0xcb, 0xa2, 0xf6, 0x5e, 0x6c, 0x59, 0xdc, 0x66, 0xbf, 0x07, 0x14, 0xef, 0xff, 0xc4, 0x01, 0x17,
-	0xf6, 0xc6, 0xe2, 0x80, 0x27, 0xb4, 0x97, 0xef, 0x01, 0xc5, 0x9b, 0x3e, 0xb1, 0x71, 0x61, 0x6b,
-	0x29, 0x36, 0x9e, 0xd0, 0x53, 0xb6, 0xe9, 0x68, 0x3a, 0x3a, 0xec, 0xcb, 0x27, 0xe4, 0x39, 0x6f,
-	0x66, 0x96, 0x5b, 0xef, 0x5d, 0x20, 0x30, 0xbb, 0xdb, 0xb3, 0xd9, 0xdd, 0x7e, 0xcd, 0x0a, 0x87,
-	0x6f, 0xbf, 0x4a, 0x7e, 0xf9, 0x11, 0xab, 0xd2, 0xd0, 0x66, 0x72, 0xae, 0x88, 0x6a, 0xc9, 0xdc,
-	0xed, 0xbe, 0xe5, 0x3c, 0x30, 0x3f, 0x4b, 0x5e, 0xfd, 0x11, 0xc7, 0x72, 0x2b, 0x31, 0x79, 0x84,
-	0x50, 0x36, 0xfb, 0x15, 0x0b, 0x85, 0x45, 0xd0, 0x86, 0x88, 0xc3, 0x92, 0xdc, 0x0d, 0x8a, 0xc3,
-	0x72, 0x5a, 0xbf, 0xe3, 0x82, 0x11, 0x34, 0x06, 0x62, 0x30, 0xc9, 0x2d, 0x8a, 0x18, 0xcc, 0x69,
-	0x1d, 0x88, 0x0b, 0x46, 0xd0, 0x0e, 0x88, 0xc1, 0x24, 0x37, 0x1f, 0x62, 0x30, 0xa7, 0xf5, 0x1d,
-	0xbf, 0x49, 0x90, 0x11, 0xd5, 0xfd, 0xe8, 0x76, 0xe2, 0x03, 0x93, 0xb0, 0x51, 0x77, 0xfa, 0x17,
-	0xf4, 0xf0, 0xd8, 0x30, 0xdb, 0x55, 0xcb, 0x23, 0x25, 0x39, 0x19, 0xba, 0x8b, 0xe1, 0x5c, 0xbe,
-	0x67, 0x7e, 0xcf, 0xa6, 0x05, 0x33, 0xd1, 0x9a, 0x1d, 0x5d, 0x4f, 0x3c, 0xf4, 0x31, 0x8b, 0x4a,
-	0xaf, 0xec, 0x81, 0x93, 0x5d, 0x85, 0xb9, 0xd8, 0x49, 0x7e, 0xc5, 0x2f, 0x76, 0x52, 0x54, 0xf1,
-	0xdb, 0x30, 0xdb, 0x55, 0x6e, 0x8b, 0x6d, 0xf2, 0x0b, 0x7b, 0xb1, 0x4d, 0x41, 0x1d, 0x8f, 0x5e,
-	0x40, 0xaa, 0x68, 0x99, 0x75, 0xa3, 0xd1, 0xb6, 0x31, 0x5a, 0x8b, 0xb6, 0xb4, 0xde, 0xaf, 0xdb,
-	0x9d, 0x75, 0xdf, 0xc8, 0xc5, 0xd3, 0xd8, 0x3a, 0x75, 0xd3, 0xf4, 0x2e, 0x26, 0xfb, 0x74, 0xb9,
-	0x6c, 0xd6, 0x2d, 0x74, 0x99, 0x2b, 0x18, 0xe1, 0xf1, 0x6d, 0x5c, 0xe9, 0x85, 0x95, 0xd9, 0xd9,
-	0xde, 0x7c, 0xb1, 0xd1, 0x30, 0xc8, 0x51, 0xbb, 0xea, 0x72, 0xe7, 0xd9, 0x04, 0x28, 0xcf, 0x7e,
-	0x8c, 0xa7, 0x53, 0x1f, 0xef, 0x7f, 0x16, 0x93, 0x7c, 0x27, 0x26, 0xd5, 0x09, 0xba, 0x7a, 0xf3,
-	0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x73, 0x70, 0x6b, 0x24, 0x20, 0x00, 0x00,
+	// 2920 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5b, 0x5b, 0x6f, 0xdb, 0xc8,
+	0x15, 0xae, 0xe2, 0xfb, 0xb1, 0x65, 0x3b, 0x63, 0xc7, 0x17, 0x66, 0x37, 0x76, 0xe8, 0x64, 0x9b,
+	0x4d, 0xbc, 0xb2, 0xa3, 0x5c, 0x9c, 0x8b, 0x91, 0xad, 0x2c, 0x2b, 0x8e, 0xb3, 0x4e, 0x9a, 0x4a,
+	0xf2, 0x6e, 0x90, 0x45, 0xcb, 0x52, 0xe6, 0x58, 0xe6, 0x46, 0x22, 0xb5, 0x24, 0x95, 0x58, 0x5b,
+	0xb4, 0x7d, 0x2c, 0x50, 0xa0, 0x0f, 0x45, 0x81, 0x05, 0xfa, 0x52, 0xf4, 0x17, 0xf4, 0xad, 0xef,
+	0x7d, 0xe9, 0x2f, 0xe8, 0x6f, 0xe8, 0x5b, 0x51, 0xf4, 0x27, 0x14, 0x73, 0xa1, 0x48, 0x8a, 0x1c,
+	0x8a, 0x94, 0x9d, 0xf6, 0xc9, 0xe2, 0xf0, 0x5c, 0xbe, 0x73, 0xe6, 0xcc, 0x39, 0xc3, 0x33, 0x63,
+	0x98, 0xd1, 0x54, 0x47, 0xb5, 0x1d, 0xd3, 0xc2, 0xb9, 0x96, 0x65, 0x3a, 0x26, 0x5a, 0xb0, 0x5b,
+	0xba, 0x85, 0x73, 0x36, 0xb6, 0xde, 0x61, 0x2b, 0xd7, 0x7d, 0x2b, 0x5d, 0xa9, 0x9b, 0x66, 0xbd,
+	0x81, 0x37, 0x28, 0x55, 0xad, 0x7d, 0xbc, 0xf1, 0xde, 0x52, 0x5b, 0x2d, 0x6c, 0xd9, 0x8c, 0x4f,
+	0x5a, 0xa5, 0x7c, 0x1b, 0x47, 0x66, 0xb3, 0x69, 0x1a, 0x1b, 0xad, 0x46, 0xbb, 0xae, 0xbb, 0x7f,
+	0x38, 0xc5, 0x72, 0x80, 0x82, 0xfd, 0x61, 0xaf, 0xe4, 0x22, 0xcc, 0x15, 0x2d, 0xac, 0x3a, 0x78,
+	0xa7, 0x6d, 0x68, 0x0d, 0x5c, 0xc6, 0xdf, 0xb6, 0xb1, 0xed, 0xa0, 0x75, 0x18, 0xad, 0xd1, 0x81,
+	0xa5, 0xcc, 0x6a, 0xe6, 0xc6, 0x64, 0x7e, 0x3e, 0xc7, 0xc0, 0x71, 0x5e, 0x4e, 0xcc, 0x69, 0xe4,
+	0x5d, 0x98, 0x0f, 0x0a, 0xb1, 0x5b, 0xa6, 0x61, 0xe3, 0x94, 0x52, 0xb6, 0x01, 0x3d, 0xc5, 0xce,
+	0xd1, 0x49, 0x10, 0xc9, 0x27, 0x30, 0xe3, 0x58, 0x6d, 0xdb, 0x51, 0x34, 0xb3, 0xa9, 0xea, 0x86,
+	0xa2, 0x6b, 0x54, 0xd8, 0x44, 0x39, 0x4b, 0x87, 0x77, 0xe9, 0xe8, 0xbe, 0x46, 0x0c, 0x09, 0x70,
+	0x0f, 0x04, 0xe1, 0x1e, 0xa0, 0x03, 0xdd, 0x76, 0xd8, 0xa8, 0xed, 0x42, 0x58, 0x81, 0xc9, 0x5a,
+	0x47, 0x69, 0xb7, 0x6c, 0xc7, 0xc2, 0x6a, 0x93, 0x0a, 0x1a, 0x2f, 0x43, 0xad, 0x73, 0xc8, 0x47,
+	0xe4, 0x12, 0xcc, 0x05, 0xd8, 0xb8, 0xee, 0x1c, 0x8c, 0x31, 0xb9, 0xf6, 0x52, 0x66, 0x75, 0x48,
+	0xa8, 0xdc, 0x25, 0x92, 0x2f, 0xc1, 0x5c, 0xd1, 0x6c, 0x1b, 0x3d, 0xea, 0xe5, 0x4d, 0x98, 0x0f,
+	0x0e, 0x73, 0xf1, 0x4b, 0x7e, 0xf1, 0x99, 0x1b, 0x23, 0x9e, 0xa0, 0x5f, 0xc2, 0xdc, 0x61, 0x4b,
+	0x3b, 0xdb, 0xa4, 0xa2, 0x07, 0xb0, 0xd4, 0xb2, 0x30, 0x8d, 0x46, 0xc5, 0x26, 0x12, 0x8c, 0x23,
+	0xac, 0x18, 0xed, 0x66, 0x0d, 0x5b, 0x4b, 0x17, 0xa8, 0x0b, 0x16, 0xdc, 0xf7, 0x15, 0xfe, 0xfa,
+	0x25, 0x7d, 0x4b, 0xc2, 0x21, 0xa8, 0x7e, 0xa0, 0xb9, 0xf8, 0x3e, 0x03, 0xb3, 0x15, 0xec, 0xfc,
+	0x5f, 0x4c, 0x40, 0x12, 0x8c, 0x77, 0xe7, 0x7b, 0x88, 0x52, 0x76, 0x9f, 0xe5, 0x02, 0x5c, 0xf4,
+	0xe1, 0x1a, 0xc8, 0xb6, 0x22, 0xcc, 0x15, 0x5a, 0x2d, 0x6c, 0x68, 0x67, 0x5c, 0x75, 0x41, 0x21,
+	0x03, 0x41, 0xf9, 0x6b, 0x06, 0xe6, 0x76, 0x71, 0x03, 0x3b, 0x78, 0xa0, 0x75, 0x87, 0x76, 0x61,
+	0xb8, 0x69, 0x6a, 0x98, 0xfa, 0x73, 0x3a, 0xbf, 0x99, 0x8b, 0x4e, 0x62, 0xb9, 0x08, 0x15, 0xb9,
+	0x17, 0xa6, 0x86, 0xcb, 0x94, 0x5b, 0xde, 0x84, 0x61, 0xf2, 0x84, 0xa6, 0x60, 0xbc, 0x5c, 0xaa,
+	0x54, 0xcb, 0xfb, 0xc5, 0xea, 0xec, 0x0f, 0x10, 0xc0, 0xe8, 0x6e, 0xe9, 0xa0, 0x54, 0x2d, 0xcd,
+	0x66, 0xd0, 0x34, 0xc0, 0xee, 0x7e, 0xa5, 0xf2, 0xe3, 0xe2, 0x7e, 0xa1, 0x5a, 0x9a, 0xbd, 0x40,
+	0xac, 0x0f, 0xca, 0x1c, 0xc8, 0xfa, 0x23, 0x40, 0xaf, 0xac, 0xb6, 0x31, 0xa0, 0xed, 0xd7, 0x61,
+	0x1a, 0x9f, 0x12, 0xe9, 0xb6, 0x52, 0xc3, 0xc7, 0xa6, 0xc5, 0xbc, 0x30, 0x54, 0xce, 0xf2, 0xd1,
+	0x1d, 0x3a, 0x28, 0x6f, 0xc3, 0x5c, 0x40, 0x09, 0x47, 0x7a, 0x1d, 0xa6, 0x19, 0x0a, 0xe5, 0xe8,
+	0x44, 0x35, 0xea, 0x58, 0xe3, 0x99, 0x25, 0xcb, 0x46, 0x8b, 0x6c, 0x50, 0x36, 0xe1, 0x6a, 0x19,
+	0x37, 0xcd, 0x77, 0x9c, 0xfd, 0xf9, 0x57, 0xd5, 0x8a, 0x5e, 0x37, 0x74, 0xa3, 0xfe, 0x05, 0xee,
+	0xd8, 0x69, 0x11, 0xcb, 0x90, 0x7d, 0x8b, 0x3b, 0x8a, 0xae, 0x29, 0x2d, 0x0b, 0x1f, 0xeb, 0xa7,
+	0x14, 0xf0, 0x44, 0x79, 0xf2, 0x2d, 0xee, 0xec, 0x6b, 0xaf, 0xe8, 0x90, 0xfc, 0x04, 0xe4, 0x38,
+	0x85, 0x5e, 0xf6, 0xb1, 0x28, 0x95, 0xe6, 0x66, 0x1f, 0xfe, 0x48, 0x7c, 0xca, 0x38, 0x9f, 0xe9,
+	0x64, 0xee, 0x3b, 0x25, 0xc3, 0xb1, 0x3a, 0x29, 0x57, 0xee, 0x0a, 0x4c, 0x5a, 0xf8, 0x08, 0xeb,
+	0xef, 0xb0, 0xa6, 0xa8, 0x0e, 0x77, 0x2b, 0xb8, 0x43, 0x05, 0x47, 0xfe, 0x35, 0x48, 0xfe, 0xe0,
+	0xe7, 0xaa, 0x5c, 0x77, 0xfc, 0x08, 0x46, 0x30, 0xd1, 0xca, 0x75, 0xdd, 0x14, 0x45, 0x65, 0x18,
+	0x67, 0x99, 0x31, 0x12, 0x00, 0x4d, 0xf5, 0x54, 0x21, 0x0f, 0x3a, 0xb6, 0x29, 0x80, 0x91, 0x32,
+	0x34, 0xd5, 0xd3, 0x12, 0x1b, 0x91, 0x3f, 0x86, 0xcb, 0x91, 0x00, 0x98, 0x7b, 0xe4, 0x1d, 0x58,
+	0xf2, 0x4a, 0x42, 0x0f, 0xba, 0xa4, 0x25, 0x4d, 0x85, 0xe5, 0x08, 0x19, 0xdc, 0xff, 0xbb, 0x30,
+	0xe6, 0x82, 0x63, 0xc5, 0x25, 0x8d, 0x91, 0x2e, 0xab, 0x6c, 0xc2, 0x1a, 0xab, 0xdc, 0x4f, 0xb1,
+	0x86, 0x2d, 0xd5, 0xd1, 0x4d, 0xa3, 0x8c, 0x1b, 0xf4, 0xaf, 0x7d, 0xa2, 0xb7, 0x5c, 0xc4, 0xcf,
+	0x60, 0xca, 0xf2, 0x0d, 0x73, 0xb7, 0x5e, 0x0b, 0x4e, 0xa1, 0x40, 0x44, 0x80, 0x53, 0x6e, 0xc1,
+	0xb5, 0x78, 0x85, 0xdc, 0xbc, 0xf3, 0xd3, 0x78, 0x00, 0x32, 0xdd, 0x18, 0xc4, 0x5b, 0x98, 0x74,
+	0x4e, 0x4c, 0x58, 0x8b, 0x95, 0x76, 0xee, 0xf0, 0xaf, 0x81, 0x4c, 0x82, 0x20, 0x9a, 0xb6, 0xbb,
+	0x47, 0xf8, 0x16, 0xd6, 0x62, 0xa9, 0x38, 0xac, 0xe7, 0x90, 0xf5, 0x0b, 0x77, 0x43, 0x27, 0x19,
+	0xae, 0x20, 0x2b, 0xf1, 0x04, 0xab, 0xf2, 0xff, 0xc3, 0xd0, 0x89, 0x57, 0x78, 0xee, 0xbe, 0xff,
+	0x7b, 0x06, 0xd6, 0x58, 0x91, 0x39, 0x97, 0xe0, 0x41, 0x6b, 0x90, 0xd5, 0xa8, 0x38, 0x85, 0xa7,
+	0x42, 0xb6, 0x09, 0x99, 0xd2, 0x7c, 0x85, 0x0c, 0xfd, 0x04, 0x26, 0x79, 0x59, 0xa0, 0x75, 0x75,
+	0x68, 0xc0, 0xba, 0x0a, 0x4c, 0x08, 0xf9, 0x2d, 0xff, 0x29, 0x03, 0xd7, 0xe2, 0xed, 0x38, 0x6f,
+	0xd7, 0xf9, 0xd2, 0xfd, 0x85, 0x04, 0x65, 0xb8, 0x00, 0x97, 0x58, 0x56, 0x28, 0xd4, 0xb1, 0xe1,
+	0xec, 0xa8, 0x86, 0xeb, 0xd9, 0x1b, 0x30, 0x54, 0x53, 0x0d, 0x8e, 0x63, 0x21, 0x28, 0xa3, 0x4b,
+	0x4b, 0x48, 0xe4, 0x1d, 0x58, 0xe8, 0x15, 0xc1, 0x8d, 0x4a, 0x2e, 0xc3, 0x86, 0x79, 0xb2, 0x8a,
+	0xdc, 0xc1, 0x6e, 0x75, 0x5d, 0x85, 0xa9, 0x5a, 0x47, 0xb1, 0x5b, 0xfa, 0xf1, 0x31, 0xf6, 0x26,
+	0x17, 0x6a, 0x9d, 0x0a, 0x1d, 0xda, 0xd7, 0xd0, 0x43, 0x46, 0x81, 0x1b, 0xf8, 0xc8, 0x31, 0x2d,
+	0x52, 0x2f, 0x86, 0xc2, 0xca, 0x2a, 0xfc, 0x75, 0x79, 0xb2, 0xd6, 0x71, 0x7f, 0xdb, 0x72, 0x11,
+	0x2e, 0xf5, 0x28, 0xe5, 0xb8, 0x6f, 0xc2, 0x70, 0x4d, 0x35, 0xdc, 0x35, 0x2a, 0x02, 0x4e, 0x69,
+	0xe4, 0x13, 0xb8, 0xc4, 0x26, 0xb8, 0xd7, 0x81, 0x97, 0x61, 0xa2, 0x17, 0xf7, 0xb8, 0xed, 0xa2,
+	0xce, 0xc3, 0xb8, 0x0b, 0x99, 0x4f, 0x93, 0x08, 0x71, 0x97, 0x8e, 0xf8, 0xb9, 0x57, 0x53, 0x6a,
+	0x3f, 0xd7, 0x20, 0xfb, 0xd2, 0xd4, 0x70, 0xd7, 0x07, 0xf1, 0x28, 0xef, 0xc2, 0x44, 0x52, 0xc7,
+	0x7a, 0x84, 0xf2, 0xcf, 0x60, 0xb1, 0x82, 0x9d, 0x80, 0x1a, 0xd7, 0x27, 0x45, 0xbf, 0x40, 0x06,
+	0xf7, 0xba, 0x68, 0x7d, 0x05, 0x05, 0xf8, 0xe4, 0x4b, 0xb0, 0x14, 0x96, 0xcf, 0x8b, 0xff, 0x4f,
+	0x61, 0x71, 0x4f, 0xa0, 0x3b, 0xd6, 0xd2, 0xeb, 0x30, 0xed, 0x98, 0x0d, 0xb2, 0xb8, 0xb0, 0x62,
+	0x3b, 0x6a, 0x37, 0x41, 0x64, 0xdd, 0xd1, 0x0a, 0x19, 0x94, 0x15, 0x58, 0xda, 0x13, 0xa8, 0x3e,
+	0x1f, 0xdb, 0xbe, 0x80, 0x65, 0xbe, 0x96, 0x1c, 0x07, 0xdb, 0x0e, 0xd6, 0x08, 0xa5, 0x6b, 0x41,
+	0x0e, 0x86, 0x0d, 0x92, 0x98, 0x98, 0x70, 0xa9, 0x67, 0x9e, 0xfd, 0x0c, 0x94, 0x4e, 0x3e, 0x00,
+	0x29, 0x4a, 0x58, 0xf7, 0x1b, 0x39, 0x9d, 0xb4, 0x2d, 0x58, 0xa2, 0xf5, 0x37, 0x0a, 0x59, 0x9c,
+	0x6f, 0x89, 0x4d, 0x11, 0x8c, 0x03, 0xa2, 0xf8, 0xd7, 0x10, 0xdb, 0xde, 0xf9, 0x5f, 0x75, 0xa7,
+	0x78, 0x0f, 0x2e, 0xd6, 0x3a, 0x4a, 0xcf, 0x87, 0x01, 0x93, 0x7c, 0x39, 0xc7, 0x7a, 0x39, 0x39,
+	0xb7, 0x97, 0x93, 0xdb, 0x37, 0x9c, 0xfb, 0x77, 0xbf, 0x54, 0x1b, 0x6d, 0x5c, 0x9e, 0xa9, 0x75,
+	0x4a, 0xfe, 0xef, 0x06, 0xb4, 0x03, 0xd0, 0x52, 0xeb, 0xba, 0x41, 0xb3, 0x2a, 0x5f, 0xa0, 0xb2,
+	0x68, 0x32, 0x5f, 0x75, 0x29, 0xcb, 0x3e, 0x2e, 0x74, 0x00, 0x73, 0xb5, 0x8e, 0xa2, 0x52, 0x9c,
+	0x74, 0x44, 0x71, 0x3a, 0x2d, 0x56, 0x55, 0x26, 0xf3, 0x1f, 0x85, 0xe0, 0x54, 0x1c, 0x4b, 0x37,
+	0xea, 0x0c, 0xcf, 0xc5, 0x5a, 0xa7, 0xe0, 0xf1, 0x55, 0x3b, 0x2d, 0x1c, 0x4a, 0x73, 0xc3, 0x89,
+	0xd3, 0x1c, 0x2a, 0xc1, 0xac, 0xcf, 0x2b, 0xea, 0xb1, 0x83, 0xad, 0xa5, 0x91, 0xfe, 0x4e, 0x99,
+	0xee, 0x3a, 0xa5, 0x40, 0x58, 0xd0, 0x73, 0x6a, 0x4f, 0x43, 0xb5, 0x1d, 0xc5, 0xc6, 0xd8, 0x70,
+	0xdd, 0x3b, 0xda, 0x5f, 0xd2, 0x6c, 0xad, 0x73, 0xa0, 0xda, 0x4e, 0x05, 0x63, 0x83, 0xfb, 0xf7,
+	0x87, 0x30, 0x73, 0x4c, 0x42, 0xc2, 0x67, 0xd0, 0x18, 0x5d, 0x6f, 0xd3, 0x74, 0xd8, 0x4b, 0xd1,
+	0xbf, 0xcf, 0xb0, 0x9d, 0x78, 0xcf, 0x74, 0xf3, 0xe0, 0xd9, 0x84, 0x11, 0x12, 0x14, 0x6e, 0xa2,
+	0x8e, 0x8b, 0x1e, 0x46, 0x78, 0x1e, 0x13, 0x2b, 0x6b, 0xb0, 0x4c, 0xbb, 0x42, 0x1f, 0x34, 0x04,
+	0xe5, 0x3c, 0x48, 0x51, 0x5a, 0xb8, 0xe5, 0xf3, 0x9e, 0xe5, 0xe4, 0xf3, 0x88, 0x3d, 0xc8, 0xff,
+	0xce, 0xc0, 0x32, 0xdb, 0xa8, 0xa5, 0x5d, 0xa4, 0x68, 0x1d, 0xd0, 0x11, 0xb6, 0xc8, 0xd4, 0x5a,
+	0xba, 0xda, 0xf0, 0xb7, 0x6a, 0x26, 0xca, 0xb3, 0xe4, 0x4d, 0x85, 0xbe, 0xe0, 0x4d, 0x9a, 0x6b,
+	0x30, 0x4d, 0xa9, 0x0d, 0xd3, 0xe1, 0x01, 0x35, 0x44, 0xbf, 0x13, 0xa7, 0xc8, 0xe8, 0x4b, 0xd3,
+	0x61, 0x11, 0x73, 0x07, 0x16, 0x0c, 0xfc, 0x5e, 0x89, 0x90, 0x3b, 0x4c, 0xe5, 0xce, 0x19, 0xf8,
+	0x7d, 0xb1, 0x57, 0xf4, 0x2d, 0x40, 0x5d, 0x26, 0x4f, 0xfc, 0x08, 0x15, 0x3f, 0xc3, 0x19, 0x5c,
+	0x0d, 0x24, 0xc3, 0x45, 0xd9, 0x3b, 0x60, 0x6e, 0xf9, 0x4b, 0x06, 0xae, 0x86, 0xc5, 0xb9, 0xa1,
+	0x9b, 0xc8, 0x8d, 0xab, 0x30, 0xe5, 0xad, 0x10, 0xef, 0xf3, 0xb9, 0xc1, 0x65, 0x14, 0x1c, 0xb2,
+	0x13, 0x55, 0x49, 0x49, 0x56, 0xde, 0x61, 0xcb, 0x26, 0x41, 0x38, 0x44, 0x45, 0x4c, 0xd1, 0xc1,
+	0x2f, 0xd9, 0x18, 0x29, 0x47, 0x16, 0x6e, 0x9a, 0x0e, 0x56, 0x54, 0x4d, 0xb3, 0xb0, 0x6d, 0x73,
+	0x8f, 0x65, 0xd9, 0x68, 0x81, 0x0d, 0xca, 0x55, 0x90, 0xe3, 0xf0, 0x0e, 0xe8, 0x86, 0x07, 0xb0,
+	0xcc, 0xf7, 0x19, 0x69, 0x33, 0xfd, 0x01, 0x48, 0x51, 0x9c, 0x03, 0xe2, 0x78, 0x0d, 0xcb, 0xb4,
+	0x79, 0x13, 0xb9, 0xce, 0xc2, 0x0d, 0xa0, 0x4c, 0x44, 0x03, 0x88, 0xac, 0x93, 0x86, 0xde, 0xd4,
+	0x1d, 0xde, 0x46, 0x60, 0x0f, 0xf2, 0x4b, 0x90, 0xa2, 0x24, 0x0f, 0x9a, 0x55, 0xe4, 0xaf, 0xe0,
+	0x0a, 0x2b, 0xb4, 0x65, 0x5c, 0xd7, 0x6d, 0x87, 0x6d, 0xd1, 0xd9, 0xf7, 0x3e, 0x87, 0x7b, 0x2f,
+	0xd8, 0x16, 0x59, 0x09, 0xca, 0x0c, 0xb3, 0x31, 0x6a, 0xf9, 0x35, 0xac, 0x08, 0x05, 0x73, 0xb4,
+	0x03, 0x4a, 0x7e, 0x04, 0x1f, 0xd3, 0xa2, 0x2c, 0x44, 0xbc, 0x0c, 0xe3, 0x94, 0xd2, 0x9b, 0x67,
+	0xda, 0xba, 0xe8, 0xec, 0x6b, 0xc4, 0x5c, 0x11, 0xef, 0xd9, 0x40, 0xfd, 0x2d, 0x03, 0x93, 0x3b,
+	0xbe, 0xca, 0x75, 0x37, 0xb8, 0xa5, 0x4a, 0xb6, 0xff, 0x44, 0x7b, 0x30, 0xd2, 0x54, 0x9d, 0xa3,
+	0x13, 0xde, 0x18, 0xbd, 0x2d, 0xec, 0xce, 0x78, 0x9a, 0x72, 0x2f, 0x08, 0xc3, 0x0e, 0x3e, 0x51,
+	0xdf, 0xe9, 0xa6, 0x55, 0x66, 0xfc, 0x72, 0x1e, 0xb2, 0x81, 0x71, 0x34, 0x03, 0x93, 0x2f, 0x0a,
+	0xd5, 0xe2, 0x33, 0xa5, 0xf4, 0xba, 0x40, 0xdb, 0xa4, 0xb3, 0x30, 0xc5, 0x06, 0x2a, 0x87, 0x3b,
+	0x95, 0x52, 0x75, 0x36, 0x23, 0x7f, 0x0e, 0xe0, 0x95, 0x0d, 0x12, 0x7e, 0x8e, 0xf9, 0x16, 0x1b,
+	0xdc, 0x83, 0xec, 0x81, 0xac, 0xa1, 0x96, 0x5a, 0xc7, 0x8a, 0xad, 0x7f, 0x87, 0x79, 0x60, 0x8e,
+	0x93, 0x81, 0x8a, 0xfe, 0x1d, 0x96, 0xff, 0x71, 0x01, 0xae, 0x90, 0x8a, 0xd7, 0xeb, 0x24, 0xdd,
+	0x8b, 0xfd, 0x27, 0x74, 0x2f, 0xd0, 0x52, 0x2d, 0x92, 0x46, 0xf8, 0xf4, 0xf4, 0xdb, 0x52, 0x40,
+	0xad, 0xf3, 0x8a, 0x32, 0xec, 0x6b, 0xe8, 0x69, 0xe8, 0x93, 0x89, 0xf0, 0xaf, 0x25, 0xf0, 0x53,
+	0x70, 0x63, 0xf1, 0xa4, 0xe7, 0xe3, 0x6c, 0x28, 0x19, 0x8e, 0xee, 0xa7, 0x5b, 0xb0, 0x18, 0x0f,
+	0x0f, 0xb4, 0xcb, 0x0a, 0x6f, 0xdc, 0x47, 0xa2, 0x36, 0xee, 0x7f, 0xce, 0xc0, 0x8a, 0xd0, 0xab,
+	0x3c, 0x68, 0x1f, 0xf6, 0xf6, 0xf5, 0xfa, 0x86, 0xad, 0x4b, 0x7f, 0x2e, 0xdb, 0x8a, 0xab, 0xb0,
+	0x42, 0x0b, 0xbe, 0x78, 0xe2, 0xe5, 0x6d, 0x58, 0x15, 0x93, 0x78, 0xdd, 0x61, 0xcf, 0x0a, 0xda,
+	0x1d, 0xe6, 0x8f, 0x64, 0xd9, 0xb2, 0x6a, 0xf1, 0x01, 0xb2, 0x94, 0x50, 0xf0, 0xd9, 0x12, 0xc2,
+	0x63, 0xb8, 0xc2, 0x0a, 0xca, 0x20, 0x69, 0xea, 0x35, 0xac, 0x08, 0x99, 0xcf, 0x06, 0xeb, 0x19,
+	0xac, 0xd0, 0xfa, 0x11, 0xb3, 0x46, 0x93, 0xd5, 0x27, 0x59, 0x86, 0x55, 0xb1, 0x24, 0xfe, 0x4d,
+	0xfb, 0x9f, 0x0c, 0x4c, 0x3c, 0x37, 0x75, 0xa3, 0x4a, 0x93, 0x47, 0x74, 0x4a, 0x59, 0x80, 0x51,
+	0x2a, 0xb8, 0xc3, 0x77, 0x1c, 0xfc, 0x09, 0xdd, 0x84, 0x8b, 0x6c, 0xb7, 0xa1, 0x6b, 0x8a, 0x83,
+	0x9b, 0xad, 0x86, 0xea, 0x60, 0xbe, 0xe3, 0x98, 0xa1, 0x2f, 0xf6, 0xb5, 0x2a, 0x1f, 0x0e, 0x66,
+	0xdb, 0xe1, 0xa4, 0xd9, 0x76, 0x19, 0xc6, 0x49, 0xbb, 0xbe, 0x6d, 0x63, 0x9b, 0x2e, 0xbd, 0x91,
+	0xf2, 0x58, 0x53, 0x3d, 0x3d, 0xb4, 0xb1, 0x8d, 0x1e, 0xc0, 0x30, 0x1d, 0x1e, 0x0d, 0xb4, 0x3a,
+	0x43, 0xeb, 0xa1, 0x6b, 0xdb, 0xa1, 0x8d, 0xcb, 0x94, 0x43, 0xae, 0xc2, 0x94, 0x7f, 0x14, 0xcd,
+	0xc2, 0x50, 0xdb, 0xc6, 0x3c, 0xa0, 0xc9, 0x4f, 0xa2, 0xd6, 0x35, 0x8c, 0xef, 0x52, 0xc7, 0xb8,
+	0x3d, 0x68, 0x11, 0xc6, 0xda, 0x36, 0x3b, 0xbd, 0x60, 0xbb, 0xd2, 0x51, 0xf2, 0x58, 0x70, 0xe4,
+	0x37, 0x6e, 0xa3, 0xaa, 0x2b, 0xdb, 0x3b, 0xb5, 0x80, 0x6f, 0x4c, 0xdd, 0x50, 0x3c, 0xcf, 0x4e,
+	0xe6, 0xaf, 0xf6, 0xc5, 0x5b, 0x9e, 0xf8, 0xc6, 0xfd, 0x29, 0x7f, 0x0d, 0x8b, 0x21, 0xd9, 0x3c,
+	0xc8, 0xce, 0x2e, 0xfc, 0x33, 0xb8, 0x44, 0x0b, 0x6e, 0x08, 0x77, 0x64, 0x30, 0x10, 0x3b, 0x7b,
+	0xc9, 0xcf, 0x0d, 0x4a, 0xce, 0x6d, 0x42, 0x25, 0xc4, 0xf2, 0x35, 0x2c, 0x86, 0xe8, 0xcf, 0x0d,
+	0xcc, 0x11, 0xcc, 0x1d, 0xda, 0x09, 0x91, 0xa0, 0xfb, 0x2c, 0x86, 0x2e, 0x04, 0x1a, 0xab, 0xf1,
+	0xc1, 0x48, 0x18, 0xe4, 0xd7, 0x30, 0x7f, 0x68, 0x7f, 0x10, 0xf8, 0x9f, 0xc3, 0x02, 0x5d, 0xfc,
+	0xdd, 0x97, 0x69, 0xb3, 0xc7, 0x32, 0x2c, 0x86, 0x04, 0x30, 0x74, 0xf9, 0x7f, 0xde, 0x84, 0x89,
+	0x5d, 0xd5, 0x51, 0x2b, 0x44, 0x3d, 0xd2, 0x61, 0xca, 0x7f, 0x4d, 0x04, 0xdd, 0x12, 0xe1, 0x8c,
+	0xb8, 0x91, 0x22, 0xad, 0x27, 0x23, 0xe6, 0x6e, 0x39, 0x86, 0x49, 0xdf, 0x6d, 0x10, 0x24, 0x3c,
+	0x1b, 0x0b, 0x5f, 0x38, 0x91, 0x6e, 0x25, 0xa2, 0xf5, 0xf4, 0xf8, 0x6e, 0x7e, 0x88, 0xf5, 0x84,
+	0x6f, 0x95, 0x48, 0xb7, 0x12, 0xd1, 0x72, 0x3d, 0xc4, 0x75, 0xbe, 0x3b, 0x20, 0x31, 0xae, 0x0b,
+	0x5f, 0x20, 0x91, 0xd6, 0x93, 0x11, 0x7b, 0xaa, 0xfc, 0xb7, 0x37, 0xc4, 0xaa, 0x22, 0xae, 0x98,
+	0x48, 0xeb, 0xc9, 0x88, 0xb9, 0xaa, 0x9f, 0xc3, 0x44, 0xf7, 0x26, 0x05, 0xba, 0x21, 0x62, 0xed,
+	0xbd, 0x04, 0x22, 0x7d, 0x9a, 0x80, 0xd2, 0x33, 0xc6, 0x7f, 0x4a, 0x2b, 0x36, 0x26, 0xe2, 0x3a,
+	0x86, 0xb4, 0x9e, 0x8c, 0xd8, 0x53, 0xe5, 0x3f, 0x8c, 0x11, 0xab, 0x8a, 0x38, 0xb2, 0x91, 0xd6,
+	0x93, 0x11, 0x7b, 0x51, 0xe7, 0xbb, 0x50, 0x20, 0x8e, 0xba, 0xf0, 0xd5, 0x06, 0xe9, 0x56, 0x22,
+	0x5a, 0xae, 0xe7, 0x0f, 0x19, 0x90, 0xc4, 0x57, 0x01, 0xd0, 0x43, 0x91, 0xac, 0xbe, 0xf7, 0x15,
+	0xa4, 0x47, 0x83, 0xb0, 0x72, 0x54, 0xbf, 0x0a, 0x5e, 0x9e, 0xe1, 0x07, 0xdb, 0x28, 0x9f, 0x64,
+	0xb6, 0x82, 0x27, 0xf1, 0xd2, 0x9d, 0x54, 0x3c, 0x5c, 0xff, 0x29, 0x5c, 0x0c, 0x1d, 0xcb, 0xa3,
+	0xcd, 0xfe, 0xab, 0xb9, 0x47, 0xf7, 0xed, 0x14, 0x1c, 0x5c, 0xf3, 0x1f, 0x33, 0xf0, 0x51, 0xdc,
+	0xe9, 0x39, 0x7a, 0x1c, 0x9f, 0x24, 0x63, 0x4f, 0x31, 0xa5, 0xed, 0xc1, 0x98, 0x39, 0xb6, 0xef,
+	0x33, 0x70, 0x39, 0xe6, 0x64, 0x1c, 0x3d, 0x8a, 0x4d, 0xab, 0xf1, 0xc8, 0x1e, 0x0f, 0xc4, 0xeb,
+	0x03, 0x16, 0x73, 0x36, 0x2e, 0x06, 0xd6, 0xff, 0xd8, 0x5d, 0x7a, 0x3c, 0x10, 0xaf, 0x6f, 0x36,
+	0xe3, 0x0e, 0xb4, 0xc5, 0xb3, 0x99, 0xe0, 0xdc, 0x5d, 0xda, 0x1e, 0x8c, 0xd9, 0x87, 0x2d, 0xee,
+	0xc4, 0x58, 0x8c, 0x2d, 0xc1, 0x79, 0xb9, 0xb4, 0x3d, 0x18, 0x33, 0xc7, 0xf6, 0x0b, 0x40, 0xe1,
+	0x03, 0x25, 0x74, 0x3b, 0x3e, 0x7a, 0x23, 0xba, 0x88, 0x52, 0x3e, 0x0d, 0x8b, 0xb7, 0xf8, 0x43,
+	0xc7, 0x48, 0xe2, 0xc5, 0x2f, 0x3a, 0xaa, 0x92, 0x6e, 0xa7, 0xe0, 0x08, 0xa6, 0x1d, 0xff, 0x3b,
+	0x3b, 0x3e, 0xed, 0x44, 0xb5, 0x2c, 0xa5, 0xdb, 0x29, 0x38, 0x7c, 0x0e, 0x0f, 0x1d, 0x02, 0xc4,
+	0x38, 0x5c, 0x74, 0x2c, 0x21, 0xe5, 0xd3, 0xb0, 0x78, 0xca, 0xc3, 0xdd, 0x65, 0xb1, 0x72, 0xe1,
+	0xc1, 0x83, 0x94, 0x4f, 0xc3, 0xe2, 0x2b, 0x80, 0xe2, 0xde, 0xb6, 0xb8, 0x00, 0xf6, 0xed, 0xdf,
+	0x4b, 0x8f, 0x06, 0x61, 0xf5, 0x5c, 0x12, 0x6e, 0x70, 0x8b, 0x5d, 0x22, 0x6c, 0xa3, 0x4b, 0xf9,
+	0x34, 0x2c, 0x9e, 0xf2, 0x70, 0xd7, 0x5a, 0xac, 0x5c, 0xd8, 0x3b, 0x97, 0xf2, 0x69, 0x58, 0xb8,
+	0x72, 0x13, 0xa6, 0x83, 0x97, 0x3c, 0xd0, 0x67, 0x7d, 0xd6, 0x70, 0xf0, 0x3a, 0x84, 0x94, 0x4b,
+	0x4a, 0xce, 0x15, 0x36, 0x20, 0x1b, 0xb8, 0x9c, 0x81, 0xd6, 0x63, 0x97, 0x4f, 0xcf, 0xc5, 0x11,
+	0xe9, 0xb3, 0x84, 0xd4, 0x9e, 0x79, 0xc1, 0xbb, 0x15, 0x62, 0xf3, 0x22, 0x6f, 0x7b, 0x48, 0xb9,
+	0xa4, 0xe4, 0x5c, 0x61, 0x9b, 0x5e, 0xb1, 0x0e, 0xde, 0xc5, 0xd8, 0x88, 0xd9, 0x5d, 0x47, 0x5d,
+	0x69, 0x90, 0x36, 0x93, 0x33, 0x78, 0x6a, 0xf7, 0x12, 0xab, 0xdd, 0x4b, 0xab, 0x56, 0x78, 0x37,
+	0xe2, 0xb7, 0x19, 0xb7, 0x3d, 0x12, 0xea, 0xa9, 0xa1, 0xfb, 0xf1, 0x81, 0x21, 0xea, 0xfc, 0x49,
+	0x5b, 0xa9, 0xf9, 0x38, 0x98, 0xdf, 0x64, 0x78, 0x7f, 0x24, 0x8c, 0xe5, 0x5e, 0x6c, 0x71, 0x10,
+	0x42, 0xb9, 0x9f, 0x96, 0xcd, 0xe7, 0x16, 0x41, 0x57, 0x5a, 0xec, 0x96, 0xf8, 0xc3, 0x01, 0x69,
+	0x2b, 0x35, 0x1f, 0x07, 0xf3, 0xbb, 0x0c, 0x2c, 0x89, 0xba, 0xcb, 0x68, 0x2b, 0xb6, 0x7e, 0xc4,
+	0xc0, 0x79, 0x90, 0x9e, 0xd1, 0xe7, 0x1c, 0x41, 0x5b, 0x59, 0xec, 0x9c, 0xf8, 0x06, 0xb7, 0xb4,
+	0x95, 0x9a, 0xcf, 0x07, 0x46, 0xd0, 0x4c, 0x16, 0x83, 0x89, 0x6f, 0x5d, 0x4b, 0x5b, 0xa9, 0xf9,
+	0x7c, 0x33, 0x25, 0xea, 0x1a, 0x8b, 0x67, 0xaa, 0x4f, 0xc7, 0x5a, 0x7a, 0x90, 0x9e, 0x91, 0xe3,
+	0xb1, 0x60, 0xa6, 0xa7, 0xf7, 0x89, 0xfa, 0x64, 0xfb, 0xde, 0x96, 0x9d, 0xb4, 0x91, 0x98, 0xde,
+	0x4b, 0xd8, 0xc1, 0x1e, 0xa7, 0x38, 0x61, 0x47, 0xb6, 0x4e, 0xa5, 0x5c, 0x52, 0x72, 0xcf, 0xc8,
+	0x9e, 0x46, 0x26, 0xea, 0x93, 0xf3, 0x93, 0x1b, 0x29, 0xea, 0x90, 0x92, 0x86, 0x90, 0xaf, 0xf5,
+	0x18, 0xd3, 0x10, 0x0a, 0x77, 0x41, 0xa5, 0xf5, 0x64, 0xc4, 0x9e, 0x79, 0x3d, 0xad, 0x44, 0xb1,
+	0x79, 0xd1, 0x4d, 0x4b, 0x69, 0x23, 0x31, 0x3d, 0xd7, 0xf9, 0x06, 0x26, 0x8a, 0xa6, 0x71, 0xac,
+	0xd7, 0xdb, 0x16, 0x46, 0xd7, 0x83, 0x47, 0x0d, 0xfc, 0xbf, 0xe8, 0xba, 0xef, 0x5d, 0x25, 0x9f,
+	0xf4, 0x23, 0xeb, 0x36, 0x6a, 0xb2, 0x7b, 0xd8, 0x79, 0x45, 0x5f, 0xef, 0x1b, 0xc7, 0x26, 0xfa,
+	0x34, 0x92, 0x31, 0x40, 0xe3, 0xea, 0xb8, 0x99, 0x84, 0x94, 0xe9, 0xd9, 0xb9, 0xff, 0xe6, 0x6e,
+	0x5d, 0x77, 0x4e, 0xda, 0x35, 0x42, 0xbd, 0xc1, 0xce, 0x42, 0x37, 0xd8, 0x3f, 0xfd, 0xd1, 0xf3,
+	0x4f, 0xfe, 0x9b, 0xf9, 0x64, 0xa3, 0xeb, 0x93, 0xda, 0x28, 0x7d, 0x7b, 0xe7, 0xbf, 0x03, 0x00,
+	0x32, 0xb2, 0x09, 0xe1, 0x8c, 0x38, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2504,6 +4253,8 @@ type DataStoreClient interface {
 	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
 	// Lists bundles (optionally filtered)
 	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	// Counts bundles
+	CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error)
 	// Updates a specific bundle
 	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
 	// Sets bundle contents (creates if it does not exist)
@@ -2514,16 +4265,46 @@ type DataStoreClient interface {
 	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
 	// Prunes all expired certificates and JWT signing keys from a bundle
 	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
+	// Removes the JWT signing keys with a given key ID prefix from a bundle
+	RemoveBundleJWTSigningKeys(ctx context.Context, in *RemoveBundleJWTSigningKeysRequest, opts ...grpc.CallOption) (*RemoveBundleJWTSigningKeysResponse, error)
+	// Records a bundle received from a federated bundle endpoint
+	AppendBundleHistory(ctx context.Context, in *AppendBundleHistoryRequest, opts ...grpc.CallOption) (*AppendBundleHistoryResponse, error)
+	// Lists the bundles received from a federated bundle endpoint
+	ListBundleHistory(ctx context.Context, in *ListBundleHistoryRequest, opts ...grpc.CallOption) (*ListBundleHistoryResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error)
 	// Creates an attested node
 	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
 	// Fetches a specific attested node
 	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
 	// Lists attested nodes (optionally filtered)
 	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
+	// Counts attested nodes (optionally filtered)
+	CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Records when a specific attested node was last seen, along with the
+	// agent version and the address it was seen from
+	UpdateAttestedNodeLastSeen(ctx context.Context, in *UpdateAttestedNodeLastSeenRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeLastSeenResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	// Deletes attested nodes (and their node selectors) whose SVID expired
+	// before the given time
+	PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest, opts ...grpc.CallOption) (*PruneAttestedNodesResponse, error)
+	// Creates an agent ban
+	CreateAgentBan(ctx context.Context, in *CreateAgentBanRequest, opts ...grpc.CallOption) (*CreateAgentBanResponse, error)
+	// Lists agent bans (optionally filtered)
+	ListAgentBans(ctx context.Context, in *ListAgentBansRequest, opts ...grpc.CallOption) (*ListAgentBansResponse, error)
+	// Deletes a specific agent ban
+	DeleteAgentBan(ctx context.Context, in *DeleteAgentBanRequest, opts ...grpc.CallOption) (*DeleteAgentBanResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
@@ -2534,6 +4315,8 @@ type DataStoreClient interface {
 	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
 	// Lists registration entries (optionally filtered)
 	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
+	// Counts registration entries
+	CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error)
 	// Updates a specific registration entry
 	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
 	// Deletes a specific registration entry
@@ -2546,6 +4329,8 @@ type DataStoreClient interface {
 	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Records a use of a join token. Fails if the token has no uses left.
+	UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
 	// Applies the plugin configuration
@@ -2589,6 +4374,15 @@ func (c *dataStoreClient) ListBundles(ctx context.Context, in *ListBundlesReques
 	return out, nil
 }
 
+func (c *dataStoreClient) CountBundles(ctx context.Context, in *CountBundlesRequest, opts ...grpc.CallOption) (*CountBundlesResponse, error) {
+	out := new(CountBundlesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CountBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error) {
 	out := new(UpdateBundleResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateBundle", in, out, opts...)
@@ -2634,6 +4428,78 @@ func (c *dataStoreClient) PruneBundle(ctx context.Context, in *PruneBundleReques
 	return out, nil
 }
 
+func (c *dataStoreClient) RemoveBundleJWTSigningKeys(ctx context.Context, in *RemoveBundleJWTSigningKeysRequest, opts ...grpc.CallOption) (*RemoveBundleJWTSigningKeysResponse, error) {
+	out := new(RemoveBundleJWTSigningKeysResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/RemoveBundleJWTSigningKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) AppendBundleHistory(ctx context.Context, in *AppendBundleHistoryRequest, opts ...grpc.CallOption) (*AppendBundleHistoryResponse, error) {
+	out := new(AppendBundleHistoryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/AppendBundleHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListBundleHistory(ctx context.Context, in *ListBundleHistoryRequest, opts ...grpc.CallOption) (*ListBundleHistoryResponse, error) {
+	out := new(ListBundleHistoryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListBundleHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateFederationRelationship(ctx context.Context, in *CreateFederationRelationshipRequest, opts ...grpc.CallOption) (*CreateFederationRelationshipResponse, error) {
+	out := new(CreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchFederationRelationship(ctx context.Context, in *FetchFederationRelationshipRequest, opts ...grpc.CallOption) (*FetchFederationRelationshipResponse, error) {
+	out := new(FetchFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListFederationRelationships(ctx context.Context, in *ListFederationRelationshipsRequest, opts ...grpc.CallOption) (*ListFederationRelationshipsResponse, error) {
+	out := new(ListFederationRelationshipsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListFederationRelationships", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateFederationRelationship(ctx context.Context, in *UpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*UpdateFederationRelationshipResponse, error) {
+	out := new(UpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteFederationRelationship(ctx context.Context, in *DeleteFederationRelationshipRequest, opts ...grpc.CallOption) (*DeleteFederationRelationshipResponse, error) {
+	out := new(DeleteFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error) {
 	out := new(CreateAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateAttestedNode", in, out, opts...)
@@ -2661,6 +4527,15 @@ func (c *dataStoreClient) ListAttestedNodes(ctx context.Context, in *ListAtteste
 	return out, nil
 }
 
+func (c *dataStoreClient) CountAttestedNodes(ctx context.Context, in *CountAttestedNodesRequest, opts ...grpc.CallOption) (*CountAttestedNodesResponse, error) {
+	out := new(CountAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CountAttestedNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
 	out := new(UpdateAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNode", in, out, opts...)
@@ -2670,6 +4545,15 @@ func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAtte
 	return out, nil
 }
 
+func (c *dataStoreClient) UpdateAttestedNodeLastSeen(ctx context.Context, in *UpdateAttestedNodeLastSeenRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeLastSeenResponse, error) {
+	out := new(UpdateAttestedNodeLastSeenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNodeLastSeen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
 	out := new(DeleteAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
@@ -2679,6 +4563,42 @@ func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAtte
 	return out, nil
 }
 
+func (c *dataStoreClient) PruneAttestedNodes(ctx context.Context, in *PruneAttestedNodesRequest, opts ...grpc.CallOption) (*PruneAttestedNodesResponse, error) {
+	out := new(PruneAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneAttestedNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateAgentBan(ctx context.Context, in *CreateAgentBanRequest, opts ...grpc.CallOption) (*CreateAgentBanResponse, error) {
+	out := new(CreateAgentBanResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateAgentBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListAgentBans(ctx context.Context, in *ListAgentBansRequest, opts ...grpc.CallOption) (*ListAgentBansResponse, error) {
+	out := new(ListAgentBansResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListAgentBans", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteAgentBan(ctx context.Context, in *DeleteAgentBanRequest, opts ...grpc.CallOption) (*DeleteAgentBanResponse, error) {
+	out := new(DeleteAgentBanResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAgentBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
 	out := new(SetNodeSelectorsResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
@@ -2724,6 +4644,15 @@ func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListR
 	return out, nil
 }
 
+func (c *dataStoreClient) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error) {
+	out := new(CountRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CountRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
 	out := new(UpdateRegistrationEntryResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, opts...)
@@ -2778,6 +4707,15 @@ func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTok
 	return out, nil
 }
 
+func (c *dataStoreClient) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error) {
+	out := new(UseJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UseJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
 	out := new(PruneJoinTokensResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
@@ -2813,6 +4751,8 @@ type DataStoreServer interface {
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
 	// Lists bundles (optionally filtered)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	// Counts bundles
+	CountBundles(context.Context, *CountBundlesRequest) (*CountBundlesResponse, error)
 	// Updates a specific bundle
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
 	// Sets bundle contents (creates if it does not exist)
@@ -2823,16 +4763,46 @@ type DataStoreServer interface {
 	DeleteBundle(context.Context, *DeleteBundleRequest) (*DeleteBundleResponse, error)
 	// Prunes all expired certificates and JWT signing keys from a bundle
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	// Removes the JWT signing keys with a given key ID prefix from a bundle
+	RemoveBundleJWTSigningKeys(context.Context, *RemoveBundleJWTSigningKeysRequest) (*RemoveBundleJWTSigningKeysResponse, error)
+	// Records a bundle received from a federated bundle endpoint
+	AppendBundleHistory(context.Context, *AppendBundleHistoryRequest) (*AppendBundleHistoryResponse, error)
+	// Lists the bundles received from a federated bundle endpoint
+	ListBundleHistory(context.Context, *ListBundleHistoryRequest) (*ListBundleHistoryResponse, error)
+	// Creates a federation relationship
+	CreateFederationRelationship(context.Context, *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error)
+	// Fetches a specific federation relationship
+	FetchFederationRelationship(context.Context, *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error)
+	// Lists federation relationships
+	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
+	// Updates a specific federation relationship
+	UpdateFederationRelationship(context.Context, *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error)
+	// Deletes a specific federation relationship
+	DeleteFederationRelationship(context.Context, *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error)
 	// Creates an attested node
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	// Fetches a specific attested node
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	// Lists attested nodes (optionally filtered)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
+	// Counts attested nodes (optionally filtered)
+	CountAttestedNodes(context.Context, *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	// Records when a specific attested node was last seen, along with the
+	// agent version and the address it was seen from
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
+	// Deletes attested nodes (and their node selectors) whose SVID expired
+	// before the given time
+	PruneAttestedNodes(context.Context, *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error)
+	// Creates an agent ban
+	CreateAgentBan(context.Context, *CreateAgentBanRequest) (*CreateAgentBanResponse, error)
+	// Lists agent bans (optionally filtered)
+	ListAgentBans(context.Context, *ListAgentBansRequest) (*ListAgentBansResponse, error)
+	// Deletes a specific agent ban
+	DeleteAgentBan(context.Context, *DeleteAgentBanRequest) (*DeleteAgentBanResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
@@ -2843,6 +4813,8 @@ type DataStoreServer interface {
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	// Lists registration entries (optionally filtered)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	// Counts registration entries
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	// Updates a specific registration entry
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 	// Deletes a specific registration entry
@@ -2855,6 +4827,8 @@ type DataStoreServer interface {
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Records a use of a join token. Fails if the token has no uses left.
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	// Applies the plugin configuration
@@ -2876,6 +4850,9 @@ func (*UnimplementedDataStoreServer) FetchBundle(ctx context.Context, req *Fetch
 func (*UnimplementedDataStoreServer) ListBundles(ctx context.Context, req *ListBundlesRequest) (*ListBundlesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBundles not implemented")
 }
+func (*UnimplementedDataStoreServer) CountBundles(ctx context.Context, req *CountBundlesRequest) (*CountBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountBundles not implemented")
+}
 func (*UnimplementedDataStoreServer) UpdateBundle(ctx context.Context, req *UpdateBundleRequest) (*UpdateBundleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBundle not implemented")
 }
@@ -2891,6 +4868,30 @@ func (*UnimplementedDataStoreServer) DeleteBundle(ctx context.Context, req *Dele
 func (*UnimplementedDataStoreServer) PruneBundle(ctx context.Context, req *PruneBundleRequest) (*PruneBundleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneBundle not implemented")
 }
+func (*UnimplementedDataStoreServer) RemoveBundleJWTSigningKeys(ctx context.Context, req *RemoveBundleJWTSigningKeysRequest) (*RemoveBundleJWTSigningKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBundleJWTSigningKeys not implemented")
+}
+func (*UnimplementedDataStoreServer) AppendBundleHistory(ctx context.Context, req *AppendBundleHistoryRequest) (*AppendBundleHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendBundleHistory not implemented")
+}
+func (*UnimplementedDataStoreServer) ListBundleHistory(ctx context.Context, req *ListBundleHistoryRequest) (*ListBundleHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBundleHistory not implemented")
+}
+func (*UnimplementedDataStoreServer) CreateFederationRelationship(ctx context.Context, req *CreateFederationRelationshipRequest) (*CreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) FetchFederationRelationship(ctx context.Context, req *FetchFederationRelationshipRequest) (*FetchFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) ListFederationRelationships(ctx context.Context, req *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationships not implemented")
+}
+func (*UnimplementedDataStoreServer) UpdateFederationRelationship(ctx context.Context, req *UpdateFederationRelationshipRequest) (*UpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFederationRelationship not implemented")
+}
+func (*UnimplementedDataStoreServer) DeleteFederationRelationship(ctx context.Context, req *DeleteFederationRelationshipRequest) (*DeleteFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFederationRelationship not implemented")
+}
 func (*UnimplementedDataStoreServer) CreateAttestedNode(ctx context.Context, req *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateAttestedNode not implemented")
 }
@@ -2900,12 +4901,30 @@ func (*UnimplementedDataStoreServer) FetchAttestedNode(ctx context.Context, req
 func (*UnimplementedDataStoreServer) ListAttestedNodes(ctx context.Context, req *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAttestedNodes not implemented")
 }
+func (*UnimplementedDataStoreServer) CountAttestedNodes(ctx context.Context, req *CountAttestedNodesRequest) (*CountAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAttestedNodes not implemented")
+}
 func (*UnimplementedDataStoreServer) UpdateAttestedNode(ctx context.Context, req *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttestedNode not implemented")
 }
+func (*UnimplementedDataStoreServer) UpdateAttestedNodeLastSeen(ctx context.Context, req *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAttestedNodeLastSeen not implemented")
+}
 func (*UnimplementedDataStoreServer) DeleteAttestedNode(ctx context.Context, req *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttestedNode not implemented")
 }
+func (*UnimplementedDataStoreServer) PruneAttestedNodes(ctx context.Context, req *PruneAttestedNodesRequest) (*PruneAttestedNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneAttestedNodes not implemented")
+}
+func (*UnimplementedDataStoreServer) CreateAgentBan(ctx context.Context, req *CreateAgentBanRequest) (*CreateAgentBanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAgentBan not implemented")
+}
+func (*UnimplementedDataStoreServer) ListAgentBans(ctx context.Context, req *ListAgentBansRequest) (*ListAgentBansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgentBans not implemented")
+}
+func (*UnimplementedDataStoreServer) DeleteAgentBan(ctx context.Context, req *DeleteAgentBanRequest) (*DeleteAgentBanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAgentBan not implemented")
+}
 func (*UnimplementedDataStoreServer) SetNodeSelectors(ctx context.Context, req *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetNodeSelectors not implemented")
 }
@@ -2921,6 +4940,9 @@ func (*UnimplementedDataStoreServer) FetchRegistrationEntry(ctx context.Context,
 func (*UnimplementedDataStoreServer) ListRegistrationEntries(ctx context.Context, req *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRegistrationEntries not implemented")
 }
+func (*UnimplementedDataStoreServer) CountRegistrationEntries(ctx context.Context, req *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountRegistrationEntries not implemented")
+}
 func (*UnimplementedDataStoreServer) UpdateRegistrationEntry(ctx context.Context, req *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateRegistrationEntry not implemented")
 }
@@ -2939,6 +4961,9 @@ func (*UnimplementedDataStoreServer) FetchJoinToken(ctx context.Context, req *Fe
 func (*UnimplementedDataStoreServer) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJoinToken not implemented")
 }
+func (*UnimplementedDataStoreServer) UseJoinToken(ctx context.Context, req *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseJoinToken not implemented")
+}
 func (*UnimplementedDataStoreServer) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PruneJoinTokens not implemented")
 }
@@ -3007,6 +5032,24 @@ func _DataStore_ListBundles_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CountBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CountBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountBundles(ctx, req.(*CountBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateBundleRequest)
 	if err := dec(in); err != nil {
@@ -3097,6 +5140,150 @@ func _DataStore_PruneBundle_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_RemoveBundleJWTSigningKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBundleJWTSigningKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RemoveBundleJWTSigningKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/RemoveBundleJWTSigningKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RemoveBundleJWTSigningKeys(ctx, req.(*RemoveBundleJWTSigningKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_AppendBundleHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendBundleHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).AppendBundleHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/AppendBundleHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).AppendBundleHistory(ctx, req.(*AppendBundleHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListBundleHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundleHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListBundleHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListBundleHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListBundleHistory(ctx, req.(*ListBundleHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateFederationRelationship(ctx, req.(*CreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchFederationRelationship(ctx, req.(*FetchFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListFederationRelationships_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListFederationRelationships",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListFederationRelationships(ctx, req.(*ListFederationRelationshipsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateFederationRelationship(ctx, req.(*UpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteFederationRelationship(ctx, req.(*DeleteFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_CreateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -3151,6 +5338,24 @@ func _DataStore_ListAttestedNodes_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CountAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CountAttestedNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountAttestedNodes(ctx, req.(*CountAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -3169,6 +5374,24 @@ func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UpdateAttestedNodeLastSeen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAttestedNodeLastSeenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateAttestedNodeLastSeen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateAttestedNodeLastSeen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateAttestedNodeLastSeen(ctx, req.(*UpdateAttestedNodeLastSeenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -3187,6 +5410,78 @@ func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_PruneAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneAttestedNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneAttestedNodes(ctx, req.(*PruneAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateAgentBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAgentBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateAgentBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateAgentBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateAgentBan(ctx, req.(*CreateAgentBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListAgentBans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentBansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListAgentBans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListAgentBans",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListAgentBans(ctx, req.(*ListAgentBansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteAgentBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAgentBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteAgentBan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteAgentBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteAgentBan(ctx, req.(*DeleteAgentBanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetNodeSelectorsRequest)
 	if err := dec(in); err != nil {
@@ -3277,6 +5572,24 @@ func _DataStore_ListRegistrationEntries_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CountRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CountRegistrationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, req.(*CountRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_UpdateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateRegistrationEntryRequest)
 	if err := dec(in); err != nil {
@@ -3385,6 +5698,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UseJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UseJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UseJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UseJoinToken(ctx, req.(*UseJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -3455,6 +5786,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListBundles",
 			Handler:    _DataStore_ListBundles_Handler,
 		},
+		{
+			MethodName: "CountBundles",
+			Handler:    _DataStore_CountBundles_Handler,
+		},
 		{
 			MethodName: "UpdateBundle",
 			Handler:    _DataStore_UpdateBundle_Handler,
@@ -3475,6 +5810,38 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PruneBundle",
 			Handler:    _DataStore_PruneBundle_Handler,
 		},
+		{
+			MethodName: "RemoveBundleJWTSigningKeys",
+			Handler:    _DataStore_RemoveBundleJWTSigningKeys_Handler,
+		},
+		{
+			MethodName: "AppendBundleHistory",
+			Handler:    _DataStore_AppendBundleHistory_Handler,
+		},
+		{
+			MethodName: "ListBundleHistory",
+			Handler:    _DataStore_ListBundleHistory_Handler,
+		},
+		{
+			MethodName: "CreateFederationRelationship",
+			Handler:    _DataStore_CreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "FetchFederationRelationship",
+			Handler:    _DataStore_FetchFederationRelationship_Handler,
+		},
+		{
+			MethodName: "ListFederationRelationships",
+			Handler:    _DataStore_ListFederationRelationships_Handler,
+		},
+		{
+			MethodName: "UpdateFederationRelationship",
+			Handler:    _DataStore_UpdateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "DeleteFederationRelationship",
+			Handler:    _DataStore_DeleteFederationRelationship_Handler,
+		},
 		{
 			MethodName: "CreateAttestedNode",
 			Handler:    _DataStore_CreateAttestedNode_Handler,
@@ -3487,14 +5854,38 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAttestedNodes",
 			Handler:    _DataStore_ListAttestedNodes_Handler,
 		},
+		{
+			MethodName: "CountAttestedNodes",
+			Handler:    _DataStore_CountAttestedNodes_Handler,
+		},
 		{
 			MethodName: "UpdateAttestedNode",
 			Handler:    _DataStore_UpdateAttestedNode_Handler,
 		},
+		{
+			MethodName: "UpdateAttestedNodeLastSeen",
+			Handler:    _DataStore_UpdateAttestedNodeLastSeen_Handler,
+		},
 		{
 			MethodName: "DeleteAttestedNode",
 			Handler:    _DataStore_DeleteAttestedNode_Handler,
 		},
+		{
+			MethodName: "PruneAttestedNodes",
+			Handler:    _DataStore_PruneAttestedNodes_Handler,
+		},
+		{
+			MethodName: "CreateAgentBan",
+			Handler:    _DataStore_CreateAgentBan_Handler,
+		},
+		{
+			MethodName: "ListAgentBans",
+			Handler:    _DataStore_ListAgentBans_Handler,
+		},
+		{
+			MethodName: "DeleteAgentBan",
+			Handler:    _DataStore_DeleteAgentBan_Handler,
+		},
 		{
 			MethodName: "SetNodeSelectors",
 			Handler:    _DataStore_SetNodeSelectors_Handler,
@@ -3515,6 +5906,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListRegistrationEntries",
 			Handler:    _DataStore_ListRegistrationEntries_Handler,
 		},
+		{
+			MethodName: "CountRegistrationEntries",
+			Handler:    _DataStore_CountRegistrationEntries_Handler,
+		},
 		{
 			MethodName: "UpdateRegistrationEntry",
 			Handler:    _DataStore_UpdateRegistrationEntry_Handler,
@@ -3539,6 +5934,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "UseJoinToken",
+			Handler:    _DataStore_UseJoinToken_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
@@ -33,7 +33,11 @@ type AttestRequest struct {
 	//* Is true if the Base SPIFFE ID is present in the Attested Node table.
 	DEPRECATEDAttestedBefore bool `protobuf:"varint,2,opt,name=DEPRECATED_attested_before,json=DEPRECATEDAttestedBefore,proto3" json:"DEPRECATED_attested_before,omitempty"`
 	//* Challenge response
-	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	Response []byte `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	//* SPIFFE ID of the agent when it is re-attesting over an authenticated
+	//channel. Attestors that refuse to attest an agent twice should allow this
+	//agent ID to attest again.
+	ReattestAgentId      string   `protobuf:"bytes,4,opt,name=reattest_agent_id,json=reattestAgentId,proto3" json:"reattest_agent_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -85,6 +89,13 @@ func (m *AttestRequest) GetResponse() []byte {
 	return nil
 }
 
+func (m *AttestRequest) GetReattestAgentId() string {
+	if m != nil {
+		return m.ReattestAgentId
+	}
+	return ""
+}
+
 //* Represents a response when attesting a node.
 type AttestResponse struct {
 	//* True/False
@@ -161,33 +172,34 @@ func init() {
 func init() { proto.RegisterFile("nodeattestor.proto", fileDescriptor_9e3b2582c38c076c) }
 
 var fileDescriptor_9e3b2582c38c076c = []byte{
-	// 414 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0xe5, 0x0d, 0x8d, 0xc4, 0xdb, 0xd8, 0xe4, 0x03, 0xca, 0x22, 0x90, 0xa2, 0x49, 0x40,
-	0xc6, 0x21, 0x41, 0x05, 0x09, 0x21, 0x71, 0xc9, 0xd6, 0x0a, 0x76, 0x41, 0x93, 0x41, 0x1c, 0x76,
-	0x89, 0xdc, 0xe6, 0x25, 0xb5, 0x94, 0xda, 0xc1, 0x76, 0xfa, 0xa1, 0xb8, 0xf6, 0x0b, 0xa2, 0xda,
-	0x49, 0xd3, 0x48, 0xa0, 0x72, 0xb2, 0xfd, 0xfe, 0x3f, 0xbf, 0xf7, 0xf4, 0x7f, 0x0f, 0x13, 0x21,
-	0x0b, 0x60, 0xc6, 0x80, 0x36, 0x52, 0x25, 0x8d, 0x92, 0x46, 0x92, 0x40, 0x37, 0x5c, 0x41, 0xc2,
-	0x2a, 0x10, 0x26, 0xd9, 0xd7, 0xc3, 0xc8, 0x2a, 0xe9, 0x42, 0xae, 0x56, 0x52, 0xa4, 0x4d, 0xdd,
-	0x56, 0xbc, 0x3f, 0xdc, 0xdf, 0xf0, 0x6a, 0x44, 0xb8, 0xc3, 0x49, 0xd7, 0x1b, 0x84, 0xcf, 0x33,
-	0x9b, 0x89, 0xc2, 0xaf, 0x16, 0xb4, 0x21, 0x5f, 0xf1, 0xa5, 0x4b, 0xcd, 0x0c, 0x97, 0x22, 0x2f,
-	0x98, 0x61, 0x01, 0x8a, 0x50, 0x7c, 0x3a, 0x79, 0x99, 0xb8, 0x1e, 0xba, 0x04, 0xd9, 0x40, 0x4d,
-	0x99, 0x61, 0xf4, 0x82, 0x8d, 0x03, 0xe4, 0x33, 0x0e, 0xa7, 0xb3, 0x07, 0x3a, 0xbb, 0xcb, 0x7e,
-	0xcc, 0xa6, 0xb9, 0x53, 0xa1, 0xc8, 0xe7, 0x50, 0x4a, 0x05, 0xc1, 0x51, 0x84, 0x62, 0x8f, 0x06,
-	0x03, 0x91, 0x75, 0xc0, 0xad, 0xd5, 0x49, 0x88, 0x3d, 0x05, 0xba, 0x91, 0x42, 0x43, 0x70, 0x1c,
-	0xa1, 0xf8, 0x8c, 0xee, 0xde, 0xd7, 0xbf, 0x11, 0x7e, 0xd6, 0x77, 0xed, 0x42, 0xe4, 0x06, 0x5f,
-	0xee, 0x15, 0x5b, 0xb3, 0x9a, 0x17, 0xb6, 0x6d, 0x8f, 0x5e, 0x0c, 0xf1, 0x9f, 0xdb, 0x30, 0xb9,
-	0xc2, 0x9e, 0xb5, 0x31, 0xe7, 0x85, 0xed, 0xc2, 0xa7, 0x4f, 0xed, 0xfb, 0xbe, 0x20, 0x2f, 0xb0,
-	0xbf, 0x58, 0xb2, 0xba, 0x06, 0x51, 0xf5, 0x55, 0x87, 0x00, 0xf9, 0x80, 0x7d, 0x0d, 0x35, 0x2c,
-	0x8c, 0x54, 0x3a, 0x78, 0x12, 0x1d, 0xc7, 0xa7, 0x93, 0xe7, 0x63, 0x4f, 0xbe, 0x77, 0x32, 0x1d,
-	0xc0, 0xc9, 0xe6, 0x08, 0x9f, 0x7d, 0x93, 0x05, 0x64, 0xdd, 0xc0, 0x48, 0x8e, 0x4f, 0xdc, 0x9d,
-	0xbc, 0x49, 0xfe, 0x35, 0xd5, 0x64, 0x34, 0x94, 0x30, 0x3e, 0x0c, 0x3a, 0x1f, 0x62, 0xf4, 0x0e,
-	0x91, 0x47, 0xec, 0xdf, 0x49, 0x51, 0xf2, 0xaa, 0x55, 0x40, 0x5e, 0x8d, 0x3b, 0xec, 0x16, 0x63,
-	0xa7, 0xf7, 0x15, 0x5e, 0x1f, 0xc2, 0x3a, 0x9f, 0x4b, 0x7c, 0xfe, 0x05, 0xcc, 0x83, 0x95, 0xef,
-	0x45, 0x29, 0xc9, 0xcd, 0x5f, 0x3f, 0x8e, 0x98, 0xbe, 0xc6, 0xdb, 0xff, 0x41, 0x5d, 0x9d, 0xdb,
-	0x4f, 0x8f, 0x1f, 0x2b, 0x6e, 0x96, 0xed, 0x7c, 0x4b, 0xa7, 0xba, 0xe1, 0x65, 0x09, 0xa9, 0xdb,
-	0x63, 0xbb, 0xb9, 0xdd, 0x5d, 0x83, 0x5a, 0x83, 0x4a, 0xf7, 0x1d, 0x99, 0x9f, 0x58, 0xe0, 0xfd,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xfd, 0xfb, 0x31, 0xb6, 0x47, 0x03, 0x00, 0x00,
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x6f, 0xd3, 0x30,
+	0x18, 0xc6, 0xe5, 0x6e, 0x1a, 0xad, 0xb7, 0xd1, 0xe1, 0x03, 0xca, 0x22, 0x90, 0xa2, 0x49, 0x40,
+	0xb6, 0x43, 0x82, 0x0a, 0x12, 0x42, 0xe2, 0x92, 0xad, 0x15, 0xec, 0x82, 0x26, 0x83, 0x38, 0xec,
+	0x12, 0xb9, 0xcd, 0x9b, 0xcc, 0x52, 0x66, 0x07, 0xdb, 0xd9, 0x87, 0xe2, 0xca, 0x07, 0xe2, 0xab,
+	0xa0, 0xda, 0x4e, 0xd3, 0x48, 0xa0, 0xee, 0x14, 0xe7, 0x7d, 0x7e, 0xef, 0x1f, 0x3f, 0x7e, 0x31,
+	0x11, 0xb2, 0x00, 0x66, 0x0c, 0x68, 0x23, 0x55, 0xd2, 0x28, 0x69, 0x24, 0x09, 0x74, 0xc3, 0x15,
+	0x24, 0xac, 0x02, 0x61, 0x92, 0x6d, 0x3d, 0x8c, 0xac, 0x92, 0xae, 0xe4, 0xfd, 0xbd, 0x14, 0x69,
+	0x53, 0xb7, 0x15, 0xef, 0x3e, 0x2e, 0x37, 0x3c, 0x1d, 0x10, 0xee, 0xe3, 0xa4, 0xb3, 0x3f, 0x08,
+	0x1f, 0x67, 0xb6, 0x12, 0x85, 0x9f, 0x2d, 0x68, 0x43, 0xbe, 0xe0, 0x13, 0x57, 0x9a, 0x19, 0x2e,
+	0x45, 0x5e, 0x30, 0xc3, 0x02, 0x14, 0xa1, 0xf8, 0x70, 0xf6, 0x32, 0x71, 0x33, 0xf8, 0x02, 0x59,
+	0x4f, 0xcd, 0x99, 0x61, 0x74, 0xca, 0x86, 0x01, 0xf2, 0x09, 0x87, 0xf3, 0xc5, 0x0d, 0x5d, 0x5c,
+	0x65, 0xdf, 0x17, 0xf3, 0xdc, 0xa9, 0x50, 0xe4, 0x4b, 0x28, 0xa5, 0x82, 0x60, 0x14, 0xa1, 0x78,
+	0x4c, 0x83, 0x9e, 0xc8, 0x3c, 0x70, 0x69, 0x75, 0x12, 0xe2, 0xb1, 0x02, 0xdd, 0x48, 0xa1, 0x21,
+	0xd8, 0x8b, 0x50, 0x7c, 0x44, 0x37, 0xff, 0xe4, 0x02, 0x3f, 0x53, 0xde, 0x80, 0xdc, 0x3a, 0x92,
+	0xf3, 0x22, 0xd8, 0x8f, 0x50, 0x3c, 0xa1, 0xd3, 0x4e, 0xc8, 0xd6, 0xf1, 0xeb, 0xe2, 0xec, 0x17,
+	0xc2, 0x4f, 0xbb, 0x1b, 0xfa, 0xf4, 0x73, 0x7c, 0xb2, 0x35, 0xd8, 0x03, 0xab, 0x79, 0x61, 0xaf,
+	0x38, 0xa6, 0xd3, 0x3e, 0xfe, 0x63, 0x1d, 0x26, 0xa7, 0x78, 0xbc, 0x69, 0x30, 0xb2, 0x0d, 0x9e,
+	0x30, 0x57, 0x98, 0xbc, 0xc0, 0x93, 0xd5, 0x1d, 0xab, 0x6b, 0x10, 0x55, 0x37, 0x61, 0x1f, 0x20,
+	0xef, 0xf1, 0x44, 0x43, 0x0d, 0x2b, 0x23, 0x95, 0x0e, 0xf6, 0xa3, 0xbd, 0xf8, 0x70, 0xf6, 0x7c,
+	0xe8, 0xdf, 0x37, 0x2f, 0xd3, 0x1e, 0x9c, 0xfd, 0x1e, 0xe1, 0xa3, 0xaf, 0xb2, 0x80, 0xcc, 0x3f,
+	0x2e, 0xc9, 0xf1, 0x81, 0x3b, 0x93, 0x37, 0xc9, 0xff, 0x36, 0x20, 0x19, 0x3c, 0x60, 0x18, 0xef,
+	0x06, 0x9d, 0x0f, 0x31, 0x7a, 0x8b, 0xc8, 0x2d, 0x9e, 0x5c, 0x49, 0x51, 0xf2, 0xaa, 0x55, 0x40,
+	0x5e, 0x0d, 0x27, 0xf4, 0x4b, 0xb4, 0xd1, 0xbb, 0x0e, 0xaf, 0x77, 0x61, 0xde, 0xe7, 0x12, 0x1f,
+	0x7f, 0x06, 0x73, 0x63, 0xe5, 0x6b, 0x51, 0x4a, 0x72, 0xfe, 0xcf, 0xc4, 0x01, 0xd3, 0xf5, 0xb8,
+	0x78, 0x0c, 0xea, 0xfa, 0x5c, 0x7e, 0xbc, 0xfd, 0x50, 0x71, 0x73, 0xd7, 0x2e, 0xd7, 0x74, 0xaa,
+	0x1b, 0x5e, 0x96, 0x90, 0xba, 0x9d, 0xb7, 0x5b, 0xee, 0xcf, 0x1a, 0xd4, 0x03, 0xa8, 0x74, 0xdb,
+	0x91, 0xe5, 0x81, 0x05, 0xde, 0xfd, 0x1d, 0x00, 0x7c, 0xa1, 0x0f, 0x3f, 0x73, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
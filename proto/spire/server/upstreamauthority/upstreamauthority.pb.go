@@ -208,45 +208,221 @@ func (m *PublishJWTKeyResponse) GetUpstreamJwtKeys() []*common.PublicKey {
 	return nil
 }
 
+type ReportAgentsRequest struct {
+	// The agents attested by this SPIRE server
+	Agents []*common.AttestedNode `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Large reports are split across requests. True if the agents are
+	// appended to the report started by the previous request.
+	Continued bool `protobuf:"varint,2,opt,name=continued,proto3" json:"continued,omitempty"`
+	// True if the report continues in the next request. The upstream authority
+	// replaces the reported agents once the last request is received.
+	More                 bool     `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportAgentsRequest) Reset()         { *m = ReportAgentsRequest{} }
+func (m *ReportAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentsRequest) ProtoMessage()    {}
+func (*ReportAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_319df9ed2f4933fc, []int{4}
+}
+
+func (m *ReportAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentsRequest.Unmarshal(m, b)
+}
+func (m *ReportAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentsRequest.Merge(m, src)
+}
+func (m *ReportAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentsRequest.Size(m)
+}
+func (m *ReportAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportAgentsRequest proto.InternalMessageInfo
+
+func (m *ReportAgentsRequest) GetAgents() []*common.AttestedNode {
+	if m != nil {
+		return m.Agents
+	}
+	return nil
+}
+
+func (m *ReportAgentsRequest) GetContinued() bool {
+	if m != nil {
+		return m.Continued
+	}
+	return false
+}
+
+func (m *ReportAgentsRequest) GetMore() bool {
+	if m != nil {
+		return m.More
+	}
+	return false
+}
+
+type ReportAgentsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportAgentsResponse) Reset()         { *m = ReportAgentsResponse{} }
+func (m *ReportAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentsResponse) ProtoMessage()    {}
+func (*ReportAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_319df9ed2f4933fc, []int{5}
+}
+
+func (m *ReportAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentsResponse.Unmarshal(m, b)
+}
+func (m *ReportAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentsResponse.Merge(m, src)
+}
+func (m *ReportAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentsResponse.Size(m)
+}
+func (m *ReportAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportAgentsResponse proto.InternalMessageInfo
+
+type SubscribeToFederatedBundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeToFederatedBundlesRequest) Reset()         { *m = SubscribeToFederatedBundlesRequest{} }
+func (m *SubscribeToFederatedBundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeToFederatedBundlesRequest) ProtoMessage()    {}
+func (*SubscribeToFederatedBundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_319df9ed2f4933fc, []int{6}
+}
+
+func (m *SubscribeToFederatedBundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeToFederatedBundlesRequest.Unmarshal(m, b)
+}
+func (m *SubscribeToFederatedBundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeToFederatedBundlesRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeToFederatedBundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeToFederatedBundlesRequest.Merge(m, src)
+}
+func (m *SubscribeToFederatedBundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeToFederatedBundlesRequest.Size(m)
+}
+func (m *SubscribeToFederatedBundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeToFederatedBundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeToFederatedBundlesRequest proto.InternalMessageInfo
+
+type SubscribeToFederatedBundlesResponse struct {
+	// The bundles of the trust domains federated with the upstream authority
+	FederatedBundles     []*common.Bundle `protobuf:"bytes,1,rep,name=federated_bundles,json=federatedBundles,proto3" json:"federated_bundles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SubscribeToFederatedBundlesResponse) Reset()         { *m = SubscribeToFederatedBundlesResponse{} }
+func (m *SubscribeToFederatedBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeToFederatedBundlesResponse) ProtoMessage()    {}
+func (*SubscribeToFederatedBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_319df9ed2f4933fc, []int{7}
+}
+
+func (m *SubscribeToFederatedBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeToFederatedBundlesResponse.Unmarshal(m, b)
+}
+func (m *SubscribeToFederatedBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeToFederatedBundlesResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeToFederatedBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeToFederatedBundlesResponse.Merge(m, src)
+}
+func (m *SubscribeToFederatedBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeToFederatedBundlesResponse.Size(m)
+}
+func (m *SubscribeToFederatedBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeToFederatedBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeToFederatedBundlesResponse proto.InternalMessageInfo
+
+func (m *SubscribeToFederatedBundlesResponse) GetFederatedBundles() []*common.Bundle {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MintX509CARequest)(nil), "spire.server.upstreamauthority.MintX509CARequest")
 	proto.RegisterType((*MintX509CAResponse)(nil), "spire.server.upstreamauthority.MintX509CAResponse")
 	proto.RegisterType((*PublishJWTKeyRequest)(nil), "spire.server.upstreamauthority.PublishJWTKeyRequest")
 	proto.RegisterType((*PublishJWTKeyResponse)(nil), "spire.server.upstreamauthority.PublishJWTKeyResponse")
+	proto.RegisterType((*ReportAgentsRequest)(nil), "spire.server.upstreamauthority.ReportAgentsRequest")
+	proto.RegisterType((*ReportAgentsResponse)(nil), "spire.server.upstreamauthority.ReportAgentsResponse")
+	proto.RegisterType((*SubscribeToFederatedBundlesRequest)(nil), "spire.server.upstreamauthority.SubscribeToFederatedBundlesRequest")
+	proto.RegisterType((*SubscribeToFederatedBundlesResponse)(nil), "spire.server.upstreamauthority.SubscribeToFederatedBundlesResponse")
 }
 
 func init() { proto.RegisterFile("upstreamauthority.proto", fileDescriptor_319df9ed2f4933fc) }
 
 var fileDescriptor_319df9ed2f4933fc = []byte{
-	// 442 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x5f, 0x6b, 0xd3, 0x50,
-	0x14, 0x27, 0x2b, 0x4e, 0x3c, 0x6b, 0xd1, 0x5e, 0x95, 0xd5, 0x3e, 0x48, 0x89, 0x28, 0xd5, 0x87,
-	0xb4, 0x56, 0xfb, 0x30, 0x10, 0x61, 0xe6, 0x41, 0xed, 0x10, 0x46, 0x98, 0x28, 0x43, 0x08, 0x69,
-	0x3c, 0x69, 0xee, 0x4c, 0x73, 0xe3, 0xfd, 0xe3, 0xcc, 0x8b, 0x1f, 0xd1, 0xcf, 0x24, 0xb9, 0xb9,
-	0x37, 0x1a, 0x3b, 0xb6, 0xf5, 0x29, 0x97, 0xf3, 0xfb, 0x73, 0xce, 0xfd, 0x9d, 0x5c, 0xd8, 0x57,
-	0x85, 0x90, 0x1c, 0xa3, 0x75, 0xa4, 0x64, 0xca, 0x38, 0x95, 0xa5, 0x57, 0x70, 0x26, 0x19, 0x79,
-	0x28, 0x0a, 0xca, 0xd1, 0x13, 0xc8, 0x7f, 0x20, 0xf7, 0x36, 0x58, 0xc3, 0x07, 0x1a, 0x9f, 0xc4,
-	0x6c, 0xbd, 0x66, 0xb9, 0xf9, 0xd4, 0xd2, 0xe1, 0xa8, 0x05, 0x15, 0x99, 0x5a, 0x51, 0xfb, 0xa9,
-	0x19, 0xee, 0x02, 0xfa, 0x1f, 0x68, 0x2e, 0x3f, 0xcf, 0xa7, 0x07, 0xfe, 0x61, 0x80, 0xdf, 0x15,
-	0x0a, 0x49, 0xee, 0x40, 0x27, 0x16, 0x7c, 0xe0, 0x8c, 0x9c, 0x71, 0x37, 0xa8, 0x8e, 0xe4, 0x11,
-	0xf4, 0x0a, 0x8e, 0x09, 0x72, 0x8e, 0x5f, 0x43, 0x29, 0xb3, 0xc1, 0xce, 0xc8, 0x19, 0xdf, 0x08,
-	0xba, 0x4d, 0xf1, 0x44, 0x66, 0x6e, 0x0a, 0xe4, 0x5f, 0x2f, 0x51, 0xb0, 0x5c, 0x20, 0x71, 0xa1,
-	0xf7, 0x73, 0x3e, 0x3d, 0x08, 0xe3, 0x28, 0x8c, 0xd3, 0x88, 0xe6, 0x03, 0x67, 0xd4, 0x19, 0x77,
-	0x83, 0xbd, 0xaa, 0xe8, 0x47, 0x7e, 0x55, 0x22, 0x1e, 0xdc, 0xb5, 0xf7, 0x0a, 0x35, 0x99, 0x33,
-	0x26, 0xc5, 0x60, 0x47, 0x33, 0xfb, 0x16, 0xaa, 0x8c, 0x83, 0x0a, 0x70, 0xdf, 0xc1, 0xbd, 0x63,
-	0xb5, 0xcc, 0xa8, 0x48, 0x17, 0x9f, 0x4e, 0x8e, 0xb0, 0xb4, 0x83, 0x4f, 0xe1, 0xe6, 0xd9, 0xb9,
-	0x0c, 0xbf, 0x61, 0xa9, 0x87, 0xdf, 0x9b, 0xed, 0x7b, 0x75, 0x78, 0x26, 0x15, 0x2d, 0x8a, 0x2b,
-	0xc1, 0xee, 0xd9, 0xb9, 0x3c, 0xc2, 0xd2, 0xfd, 0x02, 0xf7, 0xff, 0x73, 0x32, 0x63, 0xfb, 0xd0,
-	0xf4, 0x0d, 0x8d, 0xa7, 0xd0, 0xa3, 0x5f, 0x62, 0x7a, 0xdb, 0x2a, 0x16, 0xda, 0x5c, 0xcc, 0x7e,
-	0x77, 0xa0, 0xff, 0xd1, 0xd4, 0x0e, 0xed, 0xc2, 0x88, 0x02, 0xf8, 0x9b, 0x13, 0x79, 0xee, 0x5d,
-	0xbe, 0x5f, 0x6f, 0x63, 0x3f, 0xc3, 0xd9, 0x36, 0x92, 0xfa, 0x3e, 0x53, 0x87, 0xfc, 0x82, 0x5e,
-	0xeb, 0xaa, 0xe4, 0xe5, 0x55, 0x36, 0x17, 0x65, 0x3c, 0x9c, 0x6f, 0xa9, 0x6a, 0xfa, 0x9f, 0xc2,
-	0x2d, 0x9f, 0xe5, 0x09, 0x5d, 0x29, 0x8e, 0xe4, 0x71, 0x3b, 0x43, 0xf3, 0x4f, 0x36, 0xb8, 0x6d,
-	0xf6, 0xe4, 0x2a, 0x9a, 0xd9, 0x56, 0x02, 0xbd, 0xb7, 0x28, 0x8f, 0x35, 0xfc, 0x3e, 0x4f, 0x18,
-	0x79, 0x7a, 0xa1, 0xb0, 0xc5, 0xb1, 0x3d, 0x9e, 0x5d, 0x87, 0x5a, 0xf7, 0x79, 0xf3, 0xfa, 0xf4,
-	0xd5, 0x8a, 0xca, 0x54, 0x2d, 0x2b, 0xf6, 0x44, 0x14, 0x34, 0x49, 0x70, 0x52, 0x3f, 0x32, 0xfd,
-	0x9e, 0xcc, 0xb9, 0xce, 0x66, 0xb2, 0x91, 0xcd, 0x72, 0x57, 0xb3, 0x5e, 0xfc, 0x09, 0x00, 0x00,
-	0xff, 0xff, 0x79, 0x87, 0x6c, 0x38, 0xed, 0x03, 0x00, 0x00,
+	// 601 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x95, 0x5b, 0x5a, 0xe8, 0x34, 0x11, 0xcd, 0xb6, 0xd0, 0x60, 0x10, 0x8a, 0x5c, 0x40, 0x85,
+	0x07, 0x27, 0xa4, 0xed, 0x43, 0x25, 0x84, 0x94, 0x5a, 0xe2, 0xd2, 0x0a, 0x54, 0x99, 0x22, 0x50,
+	0x85, 0x64, 0xf9, 0x32, 0x8e, 0x5d, 0x12, 0xaf, 0xd9, 0x5d, 0x53, 0x22, 0x24, 0xbe, 0x87, 0x0f,
+	0xe4, 0x03, 0x90, 0xd7, 0xeb, 0xb4, 0x6e, 0xa3, 0x84, 0x88, 0x27, 0xaf, 0x66, 0xce, 0x39, 0x33,
+	0x73, 0x76, 0xbc, 0xb0, 0x99, 0xa5, 0x5c, 0x30, 0x74, 0x87, 0x6e, 0x26, 0x22, 0xca, 0x62, 0x31,
+	0x32, 0x53, 0x46, 0x05, 0x25, 0x0f, 0x79, 0x1a, 0x33, 0x34, 0x39, 0xb2, 0xef, 0xc8, 0xcc, 0x6b,
+	0x28, 0xfd, 0x9e, 0xcc, 0xb7, 0x7d, 0x3a, 0x1c, 0xd2, 0x44, 0x7d, 0x0a, 0xaa, 0xde, 0xaa, 0xa4,
+	0xd2, 0x41, 0xd6, 0x8f, 0xcb, 0x4f, 0x81, 0x30, 0x0e, 0xa1, 0xf1, 0x2e, 0x4e, 0xc4, 0xe7, 0xbd,
+	0xce, 0xbe, 0xd5, 0xb3, 0xf1, 0x5b, 0x86, 0x5c, 0x90, 0x35, 0x58, 0xf4, 0x39, 0x6b, 0x6a, 0x2d,
+	0x6d, 0xbb, 0x66, 0xe7, 0x47, 0xb2, 0x05, 0xf5, 0x94, 0x61, 0x88, 0x8c, 0x61, 0xe0, 0x08, 0x31,
+	0x68, 0x2e, 0xb4, 0xb4, 0xed, 0x25, 0xbb, 0x36, 0x0e, 0x9e, 0x88, 0x81, 0x11, 0x01, 0xb9, 0xac,
+	0xc5, 0x53, 0x9a, 0x70, 0x24, 0x06, 0xd4, 0x7f, 0xec, 0x75, 0xf6, 0x1d, 0xdf, 0x75, 0xfc, 0xc8,
+	0x8d, 0x93, 0xa6, 0xd6, 0x5a, 0xdc, 0xae, 0xd9, 0xab, 0x79, 0xd0, 0x72, 0xad, 0x3c, 0x44, 0x4c,
+	0x58, 0x2f, 0xe7, 0x72, 0x24, 0x98, 0x51, 0x2a, 0x78, 0x73, 0x41, 0x22, 0x1b, 0x65, 0x2a, 0x17,
+	0xb6, 0xf3, 0x84, 0xf1, 0x06, 0x36, 0x8e, 0x33, 0x6f, 0x10, 0xf3, 0xe8, 0xf0, 0xd3, 0xc9, 0x11,
+	0x8e, 0xca, 0xc6, 0x3b, 0x70, 0xf3, 0xec, 0x5c, 0x38, 0x5f, 0x71, 0x24, 0x9b, 0x5f, 0xed, 0x6e,
+	0x9a, 0x85, 0x79, 0xca, 0x15, 0x49, 0xf2, 0x73, 0xc2, 0xf2, 0xd9, 0xb9, 0x38, 0xc2, 0x91, 0xf1,
+	0x05, 0xee, 0x5c, 0x51, 0x52, 0x6d, 0x5b, 0x30, 0xae, 0xeb, 0x28, 0x4d, 0x2e, 0x5b, 0x9f, 0x22,
+	0x7a, 0xbb, 0x64, 0x1c, 0x4a, 0x71, 0x6e, 0xfc, 0x84, 0x75, 0x1b, 0x53, 0xca, 0x44, 0xaf, 0x8f,
+	0x89, 0xe0, 0x65, 0x9b, 0x5d, 0x58, 0x76, 0x65, 0x40, 0x09, 0xea, 0x55, 0xc1, 0x9e, 0x10, 0xc8,
+	0x05, 0x06, 0xef, 0x69, 0x80, 0xb6, 0x42, 0x92, 0x07, 0xb0, 0xe2, 0xd3, 0x44, 0xc4, 0x49, 0x86,
+	0x81, 0x74, 0xff, 0x96, 0x7d, 0x11, 0x20, 0x04, 0x6e, 0x0c, 0x29, 0xc3, 0xe6, 0xa2, 0x4c, 0xc8,
+	0xb3, 0x71, 0x17, 0x36, 0xaa, 0xc5, 0x8b, 0xc9, 0x8c, 0x47, 0x60, 0x7c, 0xc8, 0x3c, 0xee, 0xb3,
+	0xd8, 0xc3, 0x13, 0xfa, 0x0a, 0x03, 0x64, 0xae, 0xc0, 0xe0, 0x20, 0x4b, 0x82, 0x01, 0x96, 0x3d,
+	0x1a, 0x11, 0x6c, 0x4d, 0x45, 0x29, 0x9b, 0x7a, 0xd0, 0x08, 0xcb, 0x9c, 0xe3, 0x15, 0x49, 0x35,
+	0xd5, 0x46, 0x75, 0xaa, 0x82, 0x69, 0xaf, 0x85, 0x57, 0xa4, 0xba, 0x7f, 0x96, 0xa0, 0xf1, 0x51,
+	0x19, 0xd7, 0x2b, 0xb7, 0x9a, 0x64, 0x00, 0x17, 0xcb, 0x44, 0x9e, 0x9b, 0xd3, 0x7f, 0x02, 0xf3,
+	0xda, 0x12, 0xeb, 0xdd, 0x79, 0x28, 0xc5, 0x34, 0x1d, 0x8d, 0xfc, 0x82, 0x7a, 0x65, 0x1f, 0xc8,
+	0xee, 0x2c, 0x99, 0x49, 0x8b, 0xa8, 0xef, 0xcd, 0xc9, 0x1a, 0xd7, 0x1f, 0x41, 0xed, 0xf2, 0xa5,
+	0x91, 0x9d, 0x59, 0x42, 0x13, 0xf6, 0x4b, 0xdf, 0x9d, 0x8f, 0xa4, 0xae, 0xf2, 0xb7, 0x06, 0xf7,
+	0xa7, 0x5c, 0x39, 0x39, 0x98, 0xa5, 0x3a, 0x7b, 0xab, 0x74, 0xeb, 0xbf, 0x34, 0xc6, 0x2e, 0x9d,
+	0xc2, 0x8a, 0x45, 0x93, 0x30, 0xee, 0x67, 0x0c, 0xc9, 0xe3, 0xea, 0x9e, 0xa9, 0xe7, 0x6d, 0x9c,
+	0x2f, 0x4b, 0x3f, 0x99, 0x05, 0x53, 0x36, 0x84, 0x50, 0x7f, 0x8d, 0xe2, 0x58, 0xa6, 0xdf, 0x26,
+	0x21, 0x25, 0x4f, 0x27, 0x12, 0x2b, 0x98, 0xb2, 0xc6, 0xb3, 0x7f, 0x81, 0x16, 0x75, 0x0e, 0x5e,
+	0x9e, 0xbe, 0xe8, 0xc7, 0x22, 0xca, 0xbc, 0x1c, 0xdd, 0xe6, 0x69, 0x1c, 0x86, 0xd8, 0x2e, 0xde,
+	0x6b, 0xf9, 0x34, 0xab, 0x73, 0xe1, 0x54, 0xfb, 0x9a, 0x53, 0xde, 0xb2, 0x44, 0xed, 0xfc, 0x1d,
+	0x00, 0xdd, 0xed, 0xe8, 0xb2, 0x38, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -283,6 +459,22 @@ type UpstreamAuthorityClient interface {
 	// encountered while tracking changes to the upstream JWT keys as SPIRE
 	// core will not reopen a closed stream until the next JWT key rotation.
 	PublishJWTKey(ctx context.Context, in *PublishJWTKeyRequest, opts ...grpc.CallOption) (UpstreamAuthority_PublishJWTKeyClient, error)
+	// Reports the agents attested by this SPIRE server to the upstream
+	// authority, which may use them to maintain an inventory of the agents
+	// in the trust domain.
+	//
+	// This RPC is optional and will return NotImplemented if unsupported.
+	ReportAgents(ctx context.Context, in *ReportAgentsRequest, opts ...grpc.CallOption) (*ReportAgentsResponse, error)
+	// Subscribes to the bundles of the trust domains federated with the
+	// upstream authority. The first response contains the current federated
+	// bundles and subsequent responses on the stream contain updates.
+	//
+	// This RPC is optional and will return NotImplemented if unsupported.
+	//
+	// Implementation note:
+	// The stream should be kept open open in the face of transient errors
+	// encountered while tracking changes to the federated bundles.
+	SubscribeToFederatedBundles(ctx context.Context, in *SubscribeToFederatedBundlesRequest, opts ...grpc.CallOption) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error)
 	// Standard SPIRE plugin RPCs
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
@@ -360,6 +552,47 @@ func (x *upstreamAuthorityPublishJWTKeyClient) Recv() (*PublishJWTKeyResponse, e
 	return m, nil
 }
 
+func (c *upstreamAuthorityClient) ReportAgents(ctx context.Context, in *ReportAgentsRequest, opts ...grpc.CallOption) (*ReportAgentsResponse, error) {
+	out := new(ReportAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.upstreamauthority.UpstreamAuthority/ReportAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *upstreamAuthorityClient) SubscribeToFederatedBundles(ctx context.Context, in *SubscribeToFederatedBundlesRequest, opts ...grpc.CallOption) (UpstreamAuthority_SubscribeToFederatedBundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UpstreamAuthority_serviceDesc.Streams[2], "/spire.server.upstreamauthority.UpstreamAuthority/SubscribeToFederatedBundles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upstreamAuthoritySubscribeToFederatedBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UpstreamAuthority_SubscribeToFederatedBundlesClient interface {
+	Recv() (*SubscribeToFederatedBundlesResponse, error)
+	grpc.ClientStream
+}
+
+type upstreamAuthoritySubscribeToFederatedBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *upstreamAuthoritySubscribeToFederatedBundlesClient) Recv() (*SubscribeToFederatedBundlesResponse, error) {
+	m := new(SubscribeToFederatedBundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *upstreamAuthorityClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.upstreamauthority.UpstreamAuthority/Configure", in, out, opts...)
@@ -402,6 +635,22 @@ type UpstreamAuthorityServer interface {
 	// encountered while tracking changes to the upstream JWT keys as SPIRE
 	// core will not reopen a closed stream until the next JWT key rotation.
 	PublishJWTKey(*PublishJWTKeyRequest, UpstreamAuthority_PublishJWTKeyServer) error
+	// Reports the agents attested by this SPIRE server to the upstream
+	// authority, which may use them to maintain an inventory of the agents
+	// in the trust domain.
+	//
+	// This RPC is optional and will return NotImplemented if unsupported.
+	ReportAgents(context.Context, *ReportAgentsRequest) (*ReportAgentsResponse, error)
+	// Subscribes to the bundles of the trust domains federated with the
+	// upstream authority. The first response contains the current federated
+	// bundles and subsequent responses on the stream contain updates.
+	//
+	// This RPC is optional and will return NotImplemented if unsupported.
+	//
+	// Implementation note:
+	// The stream should be kept open open in the face of transient errors
+	// encountered while tracking changes to the federated bundles.
+	SubscribeToFederatedBundles(*SubscribeToFederatedBundlesRequest, UpstreamAuthority_SubscribeToFederatedBundlesServer) error
 	// Standard SPIRE plugin RPCs
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
@@ -417,6 +666,12 @@ func (*UnimplementedUpstreamAuthorityServer) MintX509CA(req *MintX509CARequest,
 func (*UnimplementedUpstreamAuthorityServer) PublishJWTKey(req *PublishJWTKeyRequest, srv UpstreamAuthority_PublishJWTKeyServer) error {
 	return status.Errorf(codes.Unimplemented, "method PublishJWTKey not implemented")
 }
+func (*UnimplementedUpstreamAuthorityServer) ReportAgents(ctx context.Context, req *ReportAgentsRequest) (*ReportAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportAgents not implemented")
+}
+func (*UnimplementedUpstreamAuthorityServer) SubscribeToFederatedBundles(req *SubscribeToFederatedBundlesRequest, srv UpstreamAuthority_SubscribeToFederatedBundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToFederatedBundles not implemented")
+}
 func (*UnimplementedUpstreamAuthorityServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
@@ -470,6 +725,45 @@ func (x *upstreamAuthorityPublishJWTKeyServer) Send(m *PublishJWTKeyResponse) er
 	return x.ServerStream.SendMsg(m)
 }
 
+func _UpstreamAuthority_ReportAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpstreamAuthorityServer).ReportAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.upstreamauthority.UpstreamAuthority/ReportAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpstreamAuthorityServer).ReportAgents(ctx, req.(*ReportAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UpstreamAuthority_SubscribeToFederatedBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeToFederatedBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpstreamAuthorityServer).SubscribeToFederatedBundles(m, &upstreamAuthoritySubscribeToFederatedBundlesServer{stream})
+}
+
+type UpstreamAuthority_SubscribeToFederatedBundlesServer interface {
+	Send(*SubscribeToFederatedBundlesResponse) error
+	grpc.ServerStream
+}
+
+type upstreamAuthoritySubscribeToFederatedBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *upstreamAuthoritySubscribeToFederatedBundlesServer) Send(m *SubscribeToFederatedBundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _UpstreamAuthority_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -510,6 +804,10 @@ var _UpstreamAuthority_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.server.upstreamauthority.UpstreamAuthority",
 	HandlerType: (*UpstreamAuthorityServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportAgents",
+			Handler:    _UpstreamAuthority_ReportAgents_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _UpstreamAuthority_Configure_Handler,
@@ -530,6 +828,11 @@ var _UpstreamAuthority_serviceDesc = grpc.ServiceDesc{
 			Handler:       _UpstreamAuthority_PublishJWTKey_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "SubscribeToFederatedBundles",
+			Handler:       _UpstreamAuthority_SubscribeToFederatedBundles_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "upstreamauthority.proto",
 }
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/zeebo/errs"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// clusterSPIFFEIDResource identifies the cluster scoped ClusterSPIFFEID
+	// custom resource watched in "crd" mode.
+	clusterSPIFFEIDResource = schema.GroupVersionResource{
+		Group:    "spiffeid.spiffe.io",
+		Version:  "v1alpha1",
+		Resource: "clusterspiffeids",
+	}
+)
+
+// ClusterSPIFFEID describes how registration entries are derived for the pods
+// selected by it.
+type ClusterSPIFFEID struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterSPIFFEIDSpec `json:"spec"`
+}
+
+type ClusterSPIFFEIDSpec struct {
+	// SPIFFEIDTemplate is a text/template rendered for each selected pod to
+	// produce the SPIFFE ID of the workload (see templateData).
+	SPIFFEIDTemplate string `json:"spiffeIDTemplate"`
+
+	// NamespaceSelector selects the namespaces of the pods. If unset, pods in
+	// all namespaces are selected.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector selects the pods. If unset, all pods are selected.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// TTL is the TTL of the X509-SVID. If unset, the server default is used.
+	TTL metav1.Duration `json:"ttl,omitempty"`
+
+	// DNSNameTemplates are text/templates rendered for each selected pod to
+	// produce the DNS names of the X509-SVID.
+	DNSNameTemplates []string `json:"dnsNameTemplates,omitempty"`
+
+	// FederatesWith is the list of trust domains (e.g. domain.test) the
+	// workloads federate with.
+	FederatesWith []string `json:"federatesWith,omitempty"`
+}
+
+// templateData is the data available to the SPIFFE ID and DNS name templates.
+type templateData struct {
+	TrustDomain string
+	ClusterName string
+	PodMeta     *metav1.ObjectMeta
+	PodSpec     *corev1.PodSpec
+}
+
+// parsedClusterSPIFFEID is a validated ClusterSPIFFEID ready to be rendered
+// against pods.
+type parsedClusterSPIFFEID struct {
+	name              string
+	spiffeIDTemplate  *template.Template
+	namespaceSelector labels.Selector
+	podSelector       labels.Selector
+	ttl               int32
+	dnsNameTemplates  []*template.Template
+	federatesWith     []string
+}
+
+// parseClusterSPIFFEIDObject converts and validates a ClusterSPIFFEID object
+// obtained from the dynamic client.
+func parseClusterSPIFFEIDObject(obj runtime.Object) (*parsedClusterSPIFFEID, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errs.New("unexpected ClusterSPIFFEID object type %T", obj)
+	}
+
+	clusterSPIFFEID := new(ClusterSPIFFEID)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), clusterSPIFFEID); err != nil {
+		return nil, errs.New("unable to convert ClusterSPIFFEID %q: %v", u.GetName(), err)
+	}
+
+	parsed, err := parseClusterSPIFFEID(clusterSPIFFEID)
+	if err != nil {
+		return nil, errs.New("invalid ClusterSPIFFEID %q: %v", u.GetName(), err)
+	}
+	return parsed, nil
+}
+
+func parseClusterSPIFFEID(clusterSPIFFEID *ClusterSPIFFEID) (*parsedClusterSPIFFEID, error) {
+	spec := clusterSPIFFEID.Spec
+
+	if spec.SPIFFEIDTemplate == "" {
+		return nil, errs.New("spiffeIDTemplate is required")
+	}
+	spiffeIDTemplate, err := parseTemplate(spec.SPIFFEIDTemplate)
+	if err != nil {
+		return nil, errs.New("invalid spiffeIDTemplate: %v", err)
+	}
+
+	namespaceSelector, err := parseLabelSelector(spec.NamespaceSelector)
+	if err != nil {
+		return nil, errs.New("invalid namespaceSelector: %v", err)
+	}
+
+	podSelector, err := parseLabelSelector(spec.PodSelector)
+	if err != nil {
+		return nil, errs.New("invalid podSelector: %v", err)
+	}
+
+	if spec.TTL.Duration < 0 {
+		return nil, errs.New("ttl cannot be negative")
+	}
+
+	var dnsNameTemplates []*template.Template
+	for _, dnsNameTemplate := range spec.DNSNameTemplates {
+		tmpl, err := parseTemplate(dnsNameTemplate)
+		if err != nil {
+			return nil, errs.New("invalid dnsNameTemplate %q: %v", dnsNameTemplate, err)
+		}
+		dnsNameTemplates = append(dnsNameTemplates, tmpl)
+	}
+
+	var federatesWith []string
+	for _, trustDomain := range spec.FederatesWith {
+		trustDomainID, err := idutil.NormalizeSpiffeID(idutil.TrustDomainID(trustDomain), idutil.AllowAnyTrustDomain())
+		if err != nil {
+			return nil, errs.New("invalid federatesWith trust domain %q: %v", trustDomain, err)
+		}
+		federatesWith = append(federatesWith, trustDomainID)
+	}
+
+	return &parsedClusterSPIFFEID{
+		name:              clusterSPIFFEID.Name,
+		spiffeIDTemplate:  spiffeIDTemplate,
+		namespaceSelector: namespaceSelector,
+		podSelector:       podSelector,
+		ttl:               int32(spec.TTL.Seconds()),
+		dnsNameTemplates:  dnsNameTemplates,
+		federatesWith:     federatesWith,
+	}, nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("").Option("missingkey=error").Parse(text)
+}
+
+func parseLabelSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+func renderTemplate(tmpl *template.Template, data *templateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"io/ioutil"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/zeebo/errs"
@@ -13,6 +14,11 @@ const (
 	defaultCertPath   = "cert.pem"
 	defaultKeyPath    = "key.pem"
 	defaultCaCertPath = "cacert.pem"
+
+	defaultResyncInterval = 5 * time.Minute
+
+	modeWebhook = "webhook"
+	modeCRD     = "crd"
 )
 
 type Config struct {
@@ -29,6 +35,23 @@ type Config struct {
 	Cluster                        string `hcl:"cluster"`
 	PodLabel                       string `hcl:"pod_label"`
 	PodAnnotation                  string `hcl:"pod_annotation"`
+
+	// Mode is the workload registration mode, either "webhook" (the default)
+	// or "crd".
+	Mode string `hcl:"mode"`
+
+	// KubeConfig is the path to the kubeconfig used to watch the cluster in
+	// "crd" mode. If unset, the in-cluster configuration is used.
+	KubeConfig string `hcl:"kubeconfig"`
+
+	// ResyncInterval controls how frequently the "crd" mode controller
+	// reconciles registration entries in the absence of cluster events. This
+	// value is calculated by LoadConfig()/ParseConfig() from RawResyncInterval.
+	ResyncInterval time.Duration `hcl:"-"`
+
+	// RawResyncInterval holds the string version of the ResyncInterval.
+	// Consumers should use ResyncInterval instead.
+	RawResyncInterval string `hcl:"resync_interval"`
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -73,5 +96,28 @@ func ParseConfig(hclConfig string) (*Config, error) {
 		return nil, errs.New("workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation")
 	}
 
+	switch c.Mode {
+	case "":
+		c.Mode = modeWebhook
+	case modeWebhook:
+	case modeCRD:
+		if c.PodLabel != "" || c.PodAnnotation != "" {
+			return nil, errs.New("pod_label and pod_annotation cannot be used in %q mode", modeCRD)
+		}
+		c.ResyncInterval = defaultResyncInterval
+		if c.RawResyncInterval != "" {
+			resyncInterval, err := time.ParseDuration(c.RawResyncInterval)
+			if err != nil {
+				return nil, errs.New("invalid resync_interval: %v", err)
+			}
+			if resyncInterval <= 0 {
+				return nil, errs.New("resync_interval must be positive")
+			}
+			c.ResyncInterval = resyncInterval
+		}
+	default:
+		return nil, errs.New("unknown mode %q; expected %q or %q", c.Mode, modeWebhook, modeCRD)
+	}
+
 	return c, nil
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +46,7 @@ func TestLoadConfig(t *testing.T) {
 		ServerSocketPath: "SOCKETPATH",
 		TrustDomain:      "TRUSTDOMAIN",
 		Cluster:          "CLUSTER",
+		Mode:             modeWebhook,
 	}, config)
 }
 
@@ -68,6 +70,7 @@ func TestParseConfig(t *testing.T) {
 				ServerSocketPath:               "SOCKETPATH",
 				TrustDomain:                    "TRUSTDOMAIN",
 				Cluster:                        "CLUSTER",
+				Mode:                           modeWebhook,
 			},
 		},
 		{
@@ -97,6 +100,47 @@ func TestParseConfig(t *testing.T) {
 				TrustDomain:                    "TRUSTDOMAINOVERRIDE",
 				Cluster:                        "CLUSTEROVERRIDE",
 				PodLabel:                       "PODLABEL",
+				Mode:                           modeWebhook,
+			},
+		},
+		{
+			name: "crd mode",
+			in: testMinimalConfig + `
+				mode = "crd"
+				kubeconfig = "KUBECONFIG"
+				resync_interval = "30s"
+			`,
+			out: &Config{
+				LogLevel:          defaultLogLevel,
+				Addr:              ":8443",
+				CertPath:          defaultCertPath,
+				KeyPath:           defaultKeyPath,
+				CaCertPath:        defaultCaCertPath,
+				ServerSocketPath:  "SOCKETPATH",
+				TrustDomain:       "TRUSTDOMAIN",
+				Cluster:           "CLUSTER",
+				Mode:              modeCRD,
+				KubeConfig:        "KUBECONFIG",
+				ResyncInterval:    30 * time.Second,
+				RawResyncInterval: "30s",
+			},
+		},
+		{
+			name: "crd mode defaults",
+			in: testMinimalConfig + `
+				mode = "crd"
+			`,
+			out: &Config{
+				LogLevel:         defaultLogLevel,
+				Addr:             ":8443",
+				CertPath:         defaultCertPath,
+				KeyPath:          defaultKeyPath,
+				CaCertPath:       defaultCaCertPath,
+				ServerSocketPath: "SOCKETPATH",
+				TrustDomain:      "TRUSTDOMAIN",
+				Cluster:          "CLUSTER",
+				Mode:             modeCRD,
+				ResyncInterval:   defaultResyncInterval,
 			},
 		},
 		{
@@ -136,6 +180,29 @@ func TestParseConfig(t *testing.T) {
 			`,
 			err: "workload registration mode specification is incorrect, can't specify both pod_label and pod_annotation",
 		},
+		{
+			name: "unknown mode",
+			in: testMinimalConfig + `
+				mode = "UNKNOWN"
+			`,
+			err: `unknown mode "UNKNOWN"; expected "webhook" or "crd"`,
+		},
+		{
+			name: "pod label in crd mode",
+			in: testMinimalConfig + `
+				mode = "crd"
+				pod_label = "PODLABEL"
+			`,
+			err: `pod_label and pod_annotation cannot be used in "crd" mode`,
+		},
+		{
+			name: "invalid resync interval",
+			in: testMinimalConfig + `
+				mode = "crd"
+				resync_interval = "FOO"
+			`,
+			err: "invalid resync_interval",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -40,14 +40,7 @@ func NewController(config ControllerConfig) *Controller {
 }
 
 func (c *Controller) Initialize(ctx context.Context) error {
-	// ensure there is a node registration entry for PSAT nodes in the cluster.
-	return c.createEntry(ctx, &common.RegistrationEntry{
-		ParentId: idutil.ServerID(c.c.TrustDomain),
-		SpiffeId: c.nodeID(),
-		Selectors: []*common.Selector{
-			{Type: "k8s_psat", Value: fmt.Sprintf("cluster:%s", c.c.Cluster)},
-		},
-	})
+	return initializeNodeEntry(ctx, c.c.Log, c.c.R, c.c.TrustDomain, c.c.Cluster)
 }
 
 func (c *Controller) ReviewAdmission(ctx context.Context, req *admv1beta1.AdmissionRequest) (*admv1beta1.AdmissionResponse, error) {
@@ -198,26 +191,49 @@ func (c *Controller) deletePodEntry(ctx context.Context, namespace, name string)
 }
 
 func (c *Controller) nodeID() string {
-	return c.makeID("k8s-workload-registrar/%s/node", c.c.Cluster)
+	return nodeID(c.c.TrustDomain, c.c.Cluster)
 }
 
 func (c *Controller) makeID(pathFmt string, pathArgs ...interface{}) string {
+	return makeID(c.c.TrustDomain, pathFmt, pathArgs...)
+}
+
+func (c *Controller) createEntry(ctx context.Context, entry *common.RegistrationEntry) error {
+	return createEntry(ctx, c.c.Log, c.c.R, entry)
+}
+
+// initializeNodeEntry ensures there is a node registration entry for PSAT
+// nodes in the cluster.
+func initializeNodeEntry(ctx context.Context, log logrus.FieldLogger, r registration.RegistrationClient, trustDomain, cluster string) error {
+	return createEntry(ctx, log, r, &common.RegistrationEntry{
+		ParentId: idutil.ServerID(trustDomain),
+		SpiffeId: nodeID(trustDomain, cluster),
+		Selectors: []*common.Selector{
+			{Type: "k8s_psat", Value: fmt.Sprintf("cluster:%s", cluster)},
+		},
+	})
+}
+
+func nodeID(trustDomain, cluster string) string {
+	return makeID(trustDomain, "k8s-workload-registrar/%s/node", cluster)
+}
+
+func makeID(trustDomain, pathFmt string, pathArgs ...interface{}) string {
 	id := url.URL{
 		Scheme: "spiffe",
-		Host:   c.c.TrustDomain,
+		Host:   trustDomain,
 		Path:   path.Clean(fmt.Sprintf(pathFmt, pathArgs...)),
 	}
 	return id.String()
 }
 
-func (c *Controller) createEntry(ctx context.Context, entry *common.RegistrationEntry) error {
-	// ensure there is a node registration entry for PSAT nodes in the cluster.
-	log := c.c.Log.WithFields(logrus.Fields{
+func createEntry(ctx context.Context, log logrus.FieldLogger, r registration.RegistrationClient, entry *common.RegistrationEntry) error {
+	log = log.WithFields(logrus.Fields{
 		"parent_id": entry.ParentId,
 		"spiffe_id": entry.SpiffeId,
 		"selectors": selectorsField(entry.Selectors),
 	})
-	_, err := c.c.R.CreateEntry(ctx, entry)
+	_, err := r.CreateEntry(ctx, entry)
 	switch status.Code(err) {
 	case codes.OK, codes.AlreadyExists:
 		log.Info("Created pod entry")
@@ -242,6 +258,13 @@ func podNameSelector(podName string) *common.Selector {
 	}
 }
 
+func podUIDSelector(podUID string) *common.Selector {
+	return &common.Selector{
+		Type:  "k8s",
+		Value: fmt.Sprintf("pod-uid:%s", podUID),
+	}
+}
+
 func selectorsField(selectors []*common.Selector) string {
 	var buf bytes.Buffer
 	for i, selector := range selectors {
@@ -430,6 +430,36 @@ func (c *fakeRegistrationClient) ListBySelectors(ctx context.Context, selectors
 	}, nil
 }
 
+func (c *fakeRegistrationClient) UpdateEntry(ctx context.Context, req *registration.UpdateEntryRequest, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[req.Entry.EntryId]; !ok {
+		return nil, fmt.Errorf("entry %q not found", req.Entry.EntryId)
+	}
+	entry := cloneRegistrationEntry(req.Entry)
+	c.entries[entry.EntryId] = entry
+
+	return cloneRegistrationEntry(entry), nil
+}
+
+func (c *fakeRegistrationClient) ListByParentID(ctx context.Context, parentID *registration.ParentID, opts ...grpc.CallOption) (*common.RegistrationEntries, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []*common.RegistrationEntry
+	for _, entry := range c.entries {
+		if entry.ParentId == parentID.Id {
+			entries = append(entries, cloneRegistrationEntry(entry))
+		}
+	}
+	util.SortRegistrationEntries(entries)
+
+	return &common.RegistrationEntries{
+		Entries: entries,
+	}, nil
+}
+
 func requireEntriesEqual(t *testing.T, expected, actual []*common.RegistrationEntry) {
 	actual = cloneRegistrationEntries(actual)
 	util.SortRegistrationEntries(actual)
@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/zeebo/errs"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	reconcileRetryInterval = 5 * time.Second
+)
+
+type CRDControllerConfig struct {
+	Log                   logrus.FieldLogger
+	R                     registration.RegistrationClient
+	TrustDomain           string
+	Cluster               string
+	PodLister             corelisters.PodLister
+	NamespaceLister       corelisters.NamespaceLister
+	ClusterSPIFFEIDLister cache.GenericLister
+	ResyncInterval        time.Duration
+}
+
+// CRDController continuously reconciles the registration entries of the pods
+// in the cluster against the ClusterSPIFFEID resources. It owns every entry
+// parented by the cluster node entry; entries that are not derived from a
+// ClusterSPIFFEID are garbage collected.
+type CRDController struct {
+	c         CRDControllerConfig
+	triggerCh chan struct{}
+}
+
+func NewCRDController(config CRDControllerConfig) *CRDController {
+	if config.ResyncInterval <= 0 {
+		config.ResyncInterval = defaultResyncInterval
+	}
+	return &CRDController{
+		c:         config,
+		triggerCh: make(chan struct{}, 1),
+	}
+}
+
+func (c *CRDController) Initialize(ctx context.Context) error {
+	return initializeNodeEntry(ctx, c.c.Log, c.c.R, c.c.TrustDomain, c.c.Cluster)
+}
+
+// Trigger schedules a reconciliation. It never blocks and is meant to be
+// called from informer event handlers.
+func (c *CRDController) Trigger() {
+	select {
+	case c.triggerCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run reconciles the registration entries whenever triggered, and at least
+// once per resync interval, until the context is canceled.
+func (c *CRDController) Run(ctx context.Context) error {
+	for {
+		interval := c.c.ResyncInterval
+		if err := c.Reconcile(ctx); err != nil {
+			c.c.Log.WithError(err).Error("Failed to reconcile registration entries")
+			interval = reconcileRetryInterval
+		}
+
+		select {
+		case <-c.triggerCh:
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Reconcile creates, updates and deletes registration entries so that the
+// entries parented by the cluster node entry match the pods selected by the
+// ClusterSPIFFEID resources.
+func (c *CRDController) Reconcile(ctx context.Context) error {
+	desired, err := c.desiredEntries()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.c.R.ListByParentID(ctx, &registration.ParentID{
+		Id: c.nodeID(),
+	})
+	if err != nil {
+		return errs.New("unable to list entries: %v", err)
+	}
+
+	var errGroup errs.Group
+	for _, entry := range resp.Entries {
+		key := entryKey(entry)
+		desiredEntry, ok := desired[key]
+		switch {
+		case !ok:
+			errGroup.Add(c.deleteEntry(ctx, entry))
+		case entryAttributesEqual(desiredEntry, entry):
+			delete(desired, key)
+		default:
+			errGroup.Add(c.updateEntry(ctx, entry.EntryId, desiredEntry))
+			delete(desired, key)
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		errGroup.Add(createEntry(ctx, c.c.Log, c.c.R, desired[key]))
+	}
+
+	return errGroup.Err()
+}
+
+// desiredEntries returns the registration entries for the selected pods,
+// keyed by entryKey.
+func (c *CRDController) desiredEntries() (map[string]*common.RegistrationEntry, error) {
+	clusterSPIFFEIDs, err := c.listClusterSPIFFEIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.c.PodLister.List(labels.Everything())
+	if err != nil {
+		return nil, errs.New("unable to list pods: %v", err)
+	}
+
+	namespaces := make(map[string]*corev1.Namespace)
+	desired := make(map[string]*common.RegistrationEntry)
+	for _, pod := range pods {
+		switch pod.Namespace {
+		case metav1.NamespacePublic, metav1.NamespaceSystem:
+			continue
+		}
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded, corev1.PodFailed:
+			continue
+		}
+
+		namespace, ok := namespaces[pod.Namespace]
+		if !ok {
+			namespace, err = c.c.NamespaceLister.Get(pod.Namespace)
+			switch {
+			case k8serrors.IsNotFound(err):
+				continue
+			case err != nil:
+				return nil, errs.New("unable to get namespace %q: %v", pod.Namespace, err)
+			}
+			namespaces[pod.Namespace] = namespace
+		}
+
+		for _, clusterSPIFFEID := range clusterSPIFFEIDs {
+			if !clusterSPIFFEID.namespaceSelector.Matches(labels.Set(namespace.Labels)) ||
+				!clusterSPIFFEID.podSelector.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+
+			log := c.c.Log.WithFields(logrus.Fields{
+				"cluster_spiffe_id": clusterSPIFFEID.name,
+				"ns":                pod.Namespace,
+				"pod":               pod.Name,
+			})
+
+			entry, err := c.renderEntry(clusterSPIFFEID, pod)
+			if err != nil {
+				log.WithError(err).Warn("Unable to render pod entry")
+				continue
+			}
+
+			key := entryKey(entry)
+			if _, ok := desired[key]; ok {
+				log.WithField("spiffe_id", entry.SpiffeId).Debug("Pod entry already declared by another ClusterSPIFFEID")
+				continue
+			}
+			desired[key] = entry
+		}
+	}
+	return desired, nil
+}
+
+// listClusterSPIFFEIDs returns the valid ClusterSPIFFEID resources, sorted by
+// name so that conflicts are resolved deterministically. Invalid resources are
+// logged and ignored so that they do not hold up the rest of the cluster.
+func (c *CRDController) listClusterSPIFFEIDs() ([]*parsedClusterSPIFFEID, error) {
+	objs, err := c.c.ClusterSPIFFEIDLister.List(labels.Everything())
+	if err != nil {
+		return nil, errs.New("unable to list ClusterSPIFFEIDs: %v", err)
+	}
+
+	var clusterSPIFFEIDs []*parsedClusterSPIFFEID
+	for _, obj := range objs {
+		clusterSPIFFEID, err := parseClusterSPIFFEIDObject(obj)
+		if err != nil {
+			c.c.Log.WithError(err).Warn("Ignoring invalid ClusterSPIFFEID")
+			continue
+		}
+		clusterSPIFFEIDs = append(clusterSPIFFEIDs, clusterSPIFFEID)
+	}
+
+	sort.Slice(clusterSPIFFEIDs, func(i, j int) bool {
+		return clusterSPIFFEIDs[i].name < clusterSPIFFEIDs[j].name
+	})
+	return clusterSPIFFEIDs, nil
+}
+
+func (c *CRDController) renderEntry(clusterSPIFFEID *parsedClusterSPIFFEID, pod *corev1.Pod) (*common.RegistrationEntry, error) {
+	data := &templateData{
+		TrustDomain: c.c.TrustDomain,
+		ClusterName: c.c.Cluster,
+		PodMeta:     &pod.ObjectMeta,
+		PodSpec:     &pod.Spec,
+	}
+
+	spiffeID, err := renderTemplate(clusterSPIFFEID.spiffeIDTemplate, data)
+	if err != nil {
+		return nil, errs.New("unable to render SPIFFE ID: %v", err)
+	}
+	spiffeID, err = idutil.NormalizeSpiffeID(spiffeID, idutil.AllowTrustDomainWorkload(c.c.TrustDomain))
+	if err != nil {
+		return nil, errs.New("invalid SPIFFE ID: %v", err)
+	}
+
+	var dnsNames []string
+	for _, dnsNameTemplate := range clusterSPIFFEID.dnsNameTemplates {
+		dnsName, err := renderTemplate(dnsNameTemplate, data)
+		if err != nil {
+			return nil, errs.New("unable to render DNS name: %v", err)
+		}
+		if dnsName != "" {
+			dnsNames = append(dnsNames, dnsName)
+		}
+	}
+
+	return &common.RegistrationEntry{
+		ParentId: c.nodeID(),
+		SpiffeId: spiffeID,
+		Selectors: []*common.Selector{
+			namespaceSelector(pod.Namespace),
+			podUIDSelector(string(pod.UID)),
+		},
+		Ttl:           clusterSPIFFEID.ttl,
+		DnsNames:      dnsNames,
+		FederatesWith: clusterSPIFFEID.federatesWith,
+	}, nil
+}
+
+func (c *CRDController) updateEntry(ctx context.Context, entryID string, entry *common.RegistrationEntry) error {
+	entry = proto.Clone(entry).(*common.RegistrationEntry)
+	entry.EntryId = entryID
+
+	log := c.c.Log.WithFields(logrus.Fields{
+		"entry_id":  entryID,
+		"spiffe_id": entry.SpiffeId,
+		"selectors": selectorsField(entry.Selectors),
+	})
+	if _, err := c.c.R.UpdateEntry(ctx, &registration.UpdateEntryRequest{
+		Entry: entry,
+	}); err != nil {
+		log.WithError(err).Error("Failed to update pod entry")
+		return errs.New("unable to update entry %q: %v", entryID, err)
+	}
+	log.Info("Updated pod entry")
+	return nil
+}
+
+func (c *CRDController) deleteEntry(ctx context.Context, entry *common.RegistrationEntry) error {
+	log := c.c.Log.WithFields(logrus.Fields{
+		"entry_id":  entry.EntryId,
+		"spiffe_id": entry.SpiffeId,
+		"selectors": selectorsField(entry.Selectors),
+	})
+	if _, err := c.c.R.DeleteEntry(ctx, &registration.RegistrationEntryID{
+		Id: entry.EntryId,
+	}); err != nil {
+		log.WithError(err).Error("Failed to delete stale pod entry")
+		return errs.New("unable to delete entry %q: %v", entry.EntryId, err)
+	}
+	log.Info("Deleted stale pod entry")
+	return nil
+}
+
+func (c *CRDController) nodeID() string {
+	return nodeID(c.c.TrustDomain, c.c.Cluster)
+}
+
+// entryKey identifies an entry by its SPIFFE ID and selectors, which are the
+// attributes that cannot change without replacing the entry.
+func entryKey(entry *common.RegistrationEntry) string {
+	selectors := proto.Clone(&common.Selectors{Entries: entry.Selectors}).(*common.Selectors).Entries
+	util.SortSelectors(selectors)
+	return entry.SpiffeId + " " + selectorsField(selectors)
+}
+
+// entryAttributesEqual returns true if the mutable attributes of the entries
+// are equal.
+func entryAttributesEqual(a, b *common.RegistrationEntry) bool {
+	return a.Ttl == b.Ttl &&
+		stringsEqual(a.DnsNames, b.DnsNames) &&
+		stringsEqual(sortedStrings(a.FederatesWith), sortedStrings(b.FederatesWith))
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedStrings(in []string) []string {
+	out := append([]string(nil), in...)
+	sort.Strings(out)
+	return out
+}
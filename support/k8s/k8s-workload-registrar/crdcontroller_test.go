@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	testNodeID = "spiffe://domain.test/k8s-workload-registrar/CLUSTER/node"
+)
+
+func TestCRDControllerInitialization(t *testing.T) {
+	controller, r, _ := newTestCRDController()
+
+	require.NoError(t, controller.Initialize(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		{
+			EntryId:  "00000001",
+			ParentId: "spiffe://domain.test/spire/server",
+			SpiffeId: testNodeID,
+			Selectors: []*common.Selector{
+				{Type: "k8s_psat", Value: "cluster:CLUSTER"},
+			},
+		},
+	}, r.GetEntries())
+}
+
+func TestCRDControllerReconcile(t *testing.T) {
+	controller, r, cluster := newTestCRDController()
+
+	cluster.addNamespace("prod", map[string]string{"env": "prod"})
+	cluster.addNamespace("dev", map[string]string{"env": "dev"})
+	cluster.addPod("prod", "blog-1", "UID-1", "blog", map[string]string{"app": "blog"})
+	cluster.addPod("prod", "blog-2", "UID-2", "blog", map[string]string{"app": "blog"})
+	cluster.addPod("prod", "db-1", "UID-3", "db", map[string]string{"app": "db"})
+	cluster.addPod("dev", "blog-3", "UID-4", "blog", map[string]string{"app": "blog"})
+	cluster.addPod("kube-system", "blog-4", "UID-5", "blog", map[string]string{"app": "blog"})
+	completed := cluster.addPod("prod", "blog-5", "UID-6", "blog", map[string]string{"app": "blog"})
+	completed.Status.Phase = corev1.PodSucceeded
+	cluster.addClusterSPIFFEID("blog", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}/sa/{{ .PodSpec.ServiceAccountName }}",
+		"namespaceSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"env": "prod"},
+		},
+		"podSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"app": "blog"},
+		},
+		"ttl":              "1h",
+		"dnsNameTemplates": []interface{}{"{{ .PodMeta.Name }}.{{ .PodMeta.Namespace }}.svc"},
+		"federatesWith":    []interface{}{"federated.test"},
+	})
+
+	// This entry is for the blog-1 pod but has a stale TTL and should be
+	// updated in place.
+	_, err := r.CreateEntry(context.Background(), &common.RegistrationEntry{
+		ParentId: testNodeID,
+		SpiffeId: "spiffe://domain.test/ns/prod/sa/blog",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "pod-uid:UID-1"},
+			{Type: "k8s", Value: "ns:prod"},
+		},
+		Ttl:           60,
+		DnsNames:      []string{"blog-1.prod.svc"},
+		FederatesWith: []string{"spiffe://federated.test"},
+	})
+	require.NoError(t, err)
+
+	// This entry is for a pod that no longer exists and should be deleted.
+	_, err = r.CreateEntry(context.Background(), &common.RegistrationEntry{
+		ParentId: testNodeID,
+		SpiffeId: "spiffe://domain.test/ns/prod/sa/blog",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:prod"},
+			{Type: "k8s", Value: "pod-uid:UID-GONE"},
+		},
+	})
+	require.NoError(t, err)
+
+	// This entry is not parented by the cluster node entry and is left alone.
+	_, err = r.CreateEntry(context.Background(), &common.RegistrationEntry{
+		ParentId: "spiffe://domain.test/other",
+		SpiffeId: "spiffe://domain.test/unmanaged",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:0"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, controller.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		{
+			EntryId:  "00000001",
+			ParentId: testNodeID,
+			SpiffeId: "spiffe://domain.test/ns/prod/sa/blog",
+			Selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:prod"},
+				{Type: "k8s", Value: "pod-uid:UID-1"},
+			},
+			Ttl:           3600,
+			DnsNames:      []string{"blog-1.prod.svc"},
+			FederatesWith: []string{"spiffe://federated.test"},
+		},
+		{
+			EntryId:  "00000003",
+			ParentId: "spiffe://domain.test/other",
+			SpiffeId: "spiffe://domain.test/unmanaged",
+			Selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:0"},
+			},
+		},
+		{
+			EntryId:  "00000004",
+			ParentId: testNodeID,
+			SpiffeId: "spiffe://domain.test/ns/prod/sa/blog",
+			Selectors: []*common.Selector{
+				{Type: "k8s", Value: "ns:prod"},
+				{Type: "k8s", Value: "pod-uid:UID-2"},
+			},
+			Ttl:           3600,
+			DnsNames:      []string{"blog-2.prod.svc"},
+			FederatesWith: []string{"spiffe://federated.test"},
+		},
+	}, r.GetEntries())
+
+	// Reconciling again is a no-op.
+	require.NoError(t, controller.Reconcile(context.Background()))
+	require.Len(t, r.GetEntries(), 3)
+
+	// Removing a pod garbage collects its entry.
+	cluster.deletePod("prod", "blog-2")
+	require.NoError(t, controller.Reconcile(context.Background()))
+	entries := r.GetEntries()
+	require.Len(t, entries, 2)
+	require.Equal(t, "00000001", entries[0].EntryId)
+
+	// Removing the ClusterSPIFFEID garbage collects all of its entries.
+	cluster.deleteClusterSPIFFEID("blog")
+	require.NoError(t, controller.Reconcile(context.Background()))
+	requireEntriesEqual(t, []*common.RegistrationEntry{
+		{
+			EntryId:  "00000003",
+			ParentId: "spiffe://domain.test/other",
+			SpiffeId: "spiffe://domain.test/unmanaged",
+			Selectors: []*common.Selector{
+				{Type: "unix", Value: "uid:0"},
+			},
+		},
+	}, r.GetEntries())
+}
+
+func TestCRDControllerDeduplicatesEntries(t *testing.T) {
+	controller, r, cluster := newTestCRDController()
+
+	cluster.addNamespace("prod", nil)
+	cluster.addPod("prod", "blog-1", "UID-1", "blog", nil)
+	cluster.addClusterSPIFFEID("b", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/blog",
+		"ttl":              "2h",
+	})
+	cluster.addClusterSPIFFEID("a", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/blog",
+		"ttl":              "1h",
+	})
+
+	// The ClusterSPIFFEID with the lowest name wins.
+	require.NoError(t, controller.Reconcile(context.Background()))
+	entries := r.GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, int32(3600), entries[0].Ttl)
+}
+
+func TestCRDControllerIgnoresInvalidClusterSPIFFEIDs(t *testing.T) {
+	controller, r, cluster := newTestCRDController()
+
+	cluster.addNamespace("prod", nil)
+	cluster.addPod("prod", "blog-1", "UID-1", "blog", nil)
+	cluster.addClusterSPIFFEID("missing-template", map[string]interface{}{})
+	cluster.addClusterSPIFFEID("bad-template", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/{{ .Foo",
+	})
+	cluster.addClusterSPIFFEID("missing-key", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/{{ .PodMeta.Labels.missing }}",
+	})
+	cluster.addClusterSPIFFEID("other-trust-domain", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://other.test/blog",
+	})
+	cluster.addClusterSPIFFEID("bad-selector", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/blog",
+		"podSelector": map[string]interface{}{
+			"matchExpressions": []interface{}{
+				map[string]interface{}{"key": "app", "operator": "BAD"},
+			},
+		},
+	})
+	cluster.addClusterSPIFFEID("valid", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/{{ .ClusterName }}/{{ .PodMeta.Name }}",
+	})
+
+	require.NoError(t, controller.Reconcile(context.Background()))
+	entries := r.GetEntries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "spiffe://domain.test/CLUSTER/blog-1", entries[0].SpiffeId)
+}
+
+func TestCRDControllerRun(t *testing.T) {
+	controller, r, cluster := newTestCRDController()
+
+	cluster.addNamespace("prod", nil)
+	cluster.addClusterSPIFFEID("blog", map[string]interface{}{
+		"spiffeIDTemplate": "spiffe://domain.test/{{ .PodMeta.Name }}",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- controller.Run(ctx)
+	}()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	cluster.addPod("prod", "blog-1", "UID-1", "blog", nil)
+	controller.Trigger()
+	require.Eventually(t, func() bool {
+		return len(r.GetEntries()) == 1
+	}, time.Minute, 10*time.Millisecond)
+
+	cluster.deletePod("prod", "blog-1")
+	controller.Trigger()
+	require.Eventually(t, func() bool {
+		return len(r.GetEntries()) == 0
+	}, time.Minute, 10*time.Millisecond)
+}
+
+type testCluster struct {
+	t                *testing.T
+	pods             cache.Indexer
+	namespaces       cache.Indexer
+	clusterSPIFFEIDs cache.Indexer
+}
+
+func newTestCRDController() (*CRDController, *fakeRegistrationClient, *testCluster) {
+	log, _ := test.NewNullLogger()
+	r := newFakeRegistrationClient()
+	cluster := &testCluster{
+		pods:             newTestIndexer(),
+		namespaces:       newTestIndexer(),
+		clusterSPIFFEIDs: newTestIndexer(),
+	}
+	return NewCRDController(CRDControllerConfig{
+		Log:                   log,
+		R:                     r,
+		TrustDomain:           "domain.test",
+		Cluster:               "CLUSTER",
+		PodLister:             corelisters.NewPodLister(cluster.pods),
+		NamespaceLister:       corelisters.NewNamespaceLister(cluster.namespaces),
+		ClusterSPIFFEIDLister: cache.NewGenericLister(cluster.clusterSPIFFEIDs, clusterSPIFFEIDResource.GroupResource()),
+	}), r, cluster
+}
+
+func newTestIndexer() cache.Indexer {
+	return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+	})
+}
+
+func (c *testCluster) addNamespace(name string, labels map[string]string) {
+	mustAdd(c.namespaces, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	})
+}
+
+func (c *testCluster) addPod(namespace, name, uid, serviceAccount string, labels map[string]string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			UID:       types.UID(uid),
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccount,
+		},
+	}
+	mustAdd(c.pods, pod)
+	return pod
+}
+
+func (c *testCluster) deletePod(namespace, name string) {
+	mustDelete(c.pods, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	})
+}
+
+func (c *testCluster) addClusterSPIFFEID(name string, spec map[string]interface{}) {
+	mustAdd(c.clusterSPIFFEIDs, newClusterSPIFFEIDObject(name, spec))
+}
+
+func (c *testCluster) deleteClusterSPIFFEID(name string) {
+	mustDelete(c.clusterSPIFFEIDs, newClusterSPIFFEIDObject(name, nil))
+}
+
+func newClusterSPIFFEIDObject(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "spiffeid.spiffe.io/v1alpha1",
+			"kind":       "ClusterSPIFFEID",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+func mustAdd(indexer cache.Indexer, obj interface{}) {
+	if err := indexer.Add(obj); err != nil {
+		panic(err)
+	}
+}
+
+func mustDelete(indexer cache.Indexer, obj interface{}) {
+	if err := indexer.Delete(obj); err != nil {
+		panic(err)
+	}
+}
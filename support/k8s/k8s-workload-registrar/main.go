@@ -6,10 +6,19 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 var (
@@ -43,9 +52,20 @@ func run(ctx context.Context, configPath string) error {
 	}
 	defer serverConn.Close()
 
+	r := registration.NewRegistrationClient(serverConn)
+
+	switch config.Mode {
+	case modeCRD:
+		return runCRDMode(ctx, config, log, r)
+	default:
+		return runWebhookMode(ctx, config, log, r)
+	}
+}
+
+func runWebhookMode(ctx context.Context, config *Config, log logrus.FieldLogger, r registration.RegistrationClient) error {
 	controller := NewController(ControllerConfig{
 		Log:           log,
-		R:             registration.NewRegistrationClient(serverConn),
+		R:             r,
 		TrustDomain:   config.TrustDomain,
 		Cluster:       config.Cluster,
 		PodLabel:      config.PodLabel,
@@ -71,3 +91,87 @@ func run(ctx context.Context, configPath string) error {
 	}
 	return server.Run(ctx)
 }
+
+func runCRDMode(ctx context.Context, config *Config, log logrus.FieldLogger, r registration.RegistrationClient) error {
+	restConfig, err := loadRestConfig(config.KubeConfig)
+	if err != nil {
+		return err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return errs.New("unable to create kubernetes client: %v", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return errs.New("unable to create dynamic kubernetes client: %v", err)
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(clientset, config.ResyncInterval)
+	podInformer := informerFactory.Core().V1().Pods()
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, config.ResyncInterval)
+	clusterSPIFFEIDInformer := dynamicInformerFactory.ForResource(clusterSPIFFEIDResource)
+
+	controller := NewCRDController(CRDControllerConfig{
+		Log:                   log,
+		R:                     r,
+		TrustDomain:           config.TrustDomain,
+		Cluster:               config.Cluster,
+		PodLister:             podInformer.Lister(),
+		NamespaceLister:       namespaceInformer.Lister(),
+		ClusterSPIFFEIDLister: clusterSPIFFEIDInformer.Lister(),
+		ResyncInterval:        config.ResyncInterval,
+	})
+
+	// Every change in the cluster triggers a full reconciliation. Triggers
+	// are coalesced by the controller so bursts of events are cheap.
+	eventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { controller.Trigger() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldErr := meta.Accessor(oldObj)
+			newMeta, newErr := meta.Accessor(newObj)
+			if oldErr == nil && newErr == nil && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				// periodic resync; the controller resyncs on its own.
+				return
+			}
+			controller.Trigger()
+		},
+		DeleteFunc: func(interface{}) { controller.Trigger() },
+	}
+	podInformer.Informer().AddEventHandler(eventHandler)
+	namespaceInformer.Informer().AddEventHandler(eventHandler)
+	clusterSPIFFEIDInformer.Informer().AddEventHandler(eventHandler)
+
+	log.Info("Initializing registrar")
+	if err := controller.Initialize(ctx); err != nil {
+		return err
+	}
+
+	informerFactory.Start(ctx.Done())
+	dynamicInformerFactory.Start(ctx.Done())
+	log.Info("Waiting for informer caches to sync")
+	if !cache.WaitForCacheSync(ctx.Done(),
+		podInformer.Informer().HasSynced,
+		namespaceInformer.Informer().HasSynced,
+		clusterSPIFFEIDInformer.Informer().HasSynced) {
+		return errs.New("failed waiting for informer caches to sync")
+	}
+
+	log.Info("Reconciling registration entries")
+	return controller.Run(ctx)
+}
+
+func loadRestConfig(kubeConfig string) (*rest.Config, error) {
+	if kubeConfig == "" {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, errs.New("unable to load in-cluster configuration: %v", err)
+		}
+		return restConfig, nil
+	}
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeConfig)
+	if err != nil {
+		return nil, errs.New("unable to load kubeconfig %q: %v", kubeConfig, err)
+	}
+	return restConfig, nil
+}
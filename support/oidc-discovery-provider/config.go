@@ -12,6 +12,7 @@ const (
 	defaultLogLevel     = "info"
 	defaultPollInterval = time.Second * 10
 	defaultCacheDir     = "./.acme-cache"
+	defaultServingAddr  = ":443"
 )
 
 type Config struct {
@@ -48,6 +49,59 @@ type Config struct {
 	// Workload API is the configuration for using the SPIFFE Workload API
 	// as the source for the public keys. Only one source can be configured.
 	WorkloadAPI *WorkloadAPIConfig `hcl:"workload_api"`
+
+	// JWKSOptions controls the JWKS served for Domain.
+	JWKSOptions `hcl:",squash"`
+
+	// Issuers are the issuer domains served by the provider, keyed by domain,
+	// each with its own source for the public keys. They cannot be configured
+	// alongside Domain and the top-level sources.
+	Issuers map[string]*IssuerConfig `hcl:"issuer"`
+
+	// ServingCertFile is the configuration for serving HTTPS using a
+	// certificate and key on disk (e.g. mounted from a secret) instead of
+	// obtaining them via ACME.
+	ServingCertFile *ServingCertFileConfig `hcl:"serving_cert_file"`
+}
+
+type IssuerConfig struct {
+	// RegistrationAPI is the configuration for using the SPIRE Registration
+	// API as the source for the public keys of the issuer.
+	RegistrationAPI *RegistrationAPIConfig `hcl:"registration_api"`
+
+	// WorkloadAPI is the configuration for using the SPIFFE Workload API
+	// as the source for the public keys of the issuer.
+	WorkloadAPI *WorkloadAPIConfig `hcl:"workload_api"`
+
+	// JWKSOptions controls the JWKS served for the issuer.
+	JWKSOptions `hcl:",squash"`
+}
+
+type JWKSOptions struct {
+	// SetKeyUse, if true, sets the "use" field of the keys in the JWKS to
+	// "sig".
+	SetKeyUse bool `hcl:"set_key_use"`
+
+	// SetKeyAlg, if true, sets the "alg" field of the keys in the JWKS to the
+	// signing algorithm implied by the key type (e.g. ES256 for P-256 keys).
+	SetKeyAlg bool `hcl:"set_key_alg"`
+
+	// RefreshHintCacheControl, if true, allows clients to cache the JWKS for
+	// the refresh hint of the bundle. Otherwise caching is disabled.
+	RefreshHintCacheControl bool `hcl:"refresh_hint_cache_control"`
+}
+
+type ServingCertFileConfig struct {
+	// CertFilePath is the path to the PEM encoded certificate chain. The file
+	// is reloaded when it changes.
+	CertFilePath string `hcl:"cert_file_path"`
+
+	// KeyFilePath is the path to the PEM encoded private key. The file is
+	// reloaded when it changes.
+	KeyFilePath string `hcl:"key_file_path"`
+
+	// Addr is the address to serve HTTPS on. Defaults to ":443".
+	Addr string `hcl:"addr"`
 }
 
 type ACMEConfig struct {
@@ -75,6 +129,11 @@ type RegistrationAPIConfig struct {
 	// SocketPath is the path to the Registration API Unix Domain socket.
 	SocketPath string `hcl:"socket_path"`
 
+	// TrustDomain, if set, is the trust domain whose bundle is served. It
+	// may be the trust domain of the server or a federated trust domain. If
+	// unset, the bundle of the trust domain of the server is served.
+	TrustDomain string `hcl:"trust_domain"`
+
 	// PollInterval controls how frequently the service polls the Registration
 	// API for the bundle containing the JWT public keys. This value is calculated
 	// by LoadConfig()/ParseConfig() from RawPollInterval.
@@ -121,8 +180,17 @@ func ParseConfig(hclConfig string) (_ *Config, err error) {
 		c.LogLevel = defaultLogLevel
 	}
 
-	if c.Domain == "" {
-		return nil, errs.New("domain must be configured")
+	if len(c.Issuers) == 0 {
+		if c.Domain == "" {
+			return nil, errs.New("domain must be configured")
+		}
+	} else {
+		if c.Domain != "" || c.RegistrationAPI != nil || c.WorkloadAPI != nil {
+			return nil, errs.New("issuer sections cannot be configured alongside domain, registration_api or workload_api")
+		}
+		if c.JWKSOptions != (JWKSOptions{}) {
+			return nil, errs.New("JWKS options must be configured within the issuer sections")
+		}
 	}
 
 	if c.ACME != nil {
@@ -133,9 +201,25 @@ func ParseConfig(hclConfig string) (_ *Config, err error) {
 	}
 
 	switch {
+	case c.ServingCertFile != nil:
+		switch {
+		case c.ACME != nil:
+			return nil, errs.New("serving_cert_file and the acme section are mutually exclusive")
+		case c.InsecureAddr != "":
+			return nil, errs.New("serving_cert_file and insecure_addr are mutually exclusive")
+		case c.ListenSocketPath != "":
+			return nil, errs.New("serving_cert_file and listen_socket_path are mutually exclusive")
+		case c.ServingCertFile.CertFilePath == "":
+			return nil, errs.New("cert_file_path must be configured in the serving_cert_file configuration section")
+		case c.ServingCertFile.KeyFilePath == "":
+			return nil, errs.New("key_file_path must be configured in the serving_cert_file configuration section")
+		}
+		if c.ServingCertFile.Addr == "" {
+			c.ServingCertFile.Addr = defaultServingAddr
+		}
 	case c.ACME == nil:
 		if c.InsecureAddr == "" && c.ListenSocketPath == "" {
-			return nil, errs.New("either acme or listen_socket_path must be configured")
+			return nil, errs.New("either acme, serving_cert_file or listen_socket_path must be configured")
 		}
 		if c.InsecureAddr != "" && c.ListenSocketPath != "" {
 			return nil, errs.New("insecure_addr and listen_socket_path are mutually exclusive")
@@ -150,41 +234,76 @@ func ParseConfig(hclConfig string) (_ *Config, err error) {
 		return nil, errs.New("email must be configured in the acme configuration section")
 	}
 
+	if len(c.Issuers) == 0 {
+		if err := validateSource(c.RegistrationAPI, c.WorkloadAPI); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	for domain, issuer := range c.Issuers {
+		if issuer == nil {
+			issuer = new(IssuerConfig)
+			c.Issuers[domain] = issuer
+		}
+		if err := validateSource(issuer.RegistrationAPI, issuer.WorkloadAPI); err != nil {
+			return nil, errs.New("issuer %q: %v", domain, err)
+		}
+	}
+
+	return c, nil
+}
+
+// IssuerConfigs returns the configuration of every issuer served by the
+// provider, keyed by domain.
+func (c *Config) IssuerConfigs() map[string]*IssuerConfig {
+	if len(c.Issuers) > 0 {
+		return c.Issuers
+	}
+	return map[string]*IssuerConfig{
+		c.Domain: {
+			RegistrationAPI: c.RegistrationAPI,
+			WorkloadAPI:     c.WorkloadAPI,
+			JWKSOptions:     c.JWKSOptions,
+		},
+	}
+}
+
+func validateSource(registrationAPI *RegistrationAPIConfig, workloadAPI *WorkloadAPIConfig) (err error) {
 	switch {
-	case c.RegistrationAPI == nil && c.WorkloadAPI == nil:
-		return nil, errs.New("one of registration_api or workload_api section must be configured")
-	case c.RegistrationAPI != nil && c.WorkloadAPI != nil:
-		return nil, errs.New("registration_api and workload_api configuration sections are mutually exclusive")
-	case c.RegistrationAPI != nil:
-		if c.RegistrationAPI.SocketPath == "" {
-			return nil, errs.New("socket_path must be configured in the registration_api configuration section")
-		}
-		if c.RegistrationAPI.RawPollInterval != "" {
-			c.RegistrationAPI.PollInterval, err = time.ParseDuration(c.RegistrationAPI.RawPollInterval)
+	case registrationAPI == nil && workloadAPI == nil:
+		return errs.New("one of registration_api or workload_api section must be configured")
+	case registrationAPI != nil && workloadAPI != nil:
+		return errs.New("registration_api and workload_api configuration sections are mutually exclusive")
+	case registrationAPI != nil:
+		if registrationAPI.SocketPath == "" {
+			return errs.New("socket_path must be configured in the registration_api configuration section")
+		}
+		if registrationAPI.RawPollInterval != "" {
+			registrationAPI.PollInterval, err = time.ParseDuration(registrationAPI.RawPollInterval)
 			if err != nil {
-				return nil, errs.New("invalid poll_interval in the registration_api configuration section: %v", err)
+				return errs.New("invalid poll_interval in the registration_api configuration section: %v", err)
 			}
 		}
-		if c.RegistrationAPI.PollInterval <= 0 {
-			c.RegistrationAPI.PollInterval = defaultPollInterval
+		if registrationAPI.PollInterval <= 0 {
+			registrationAPI.PollInterval = defaultPollInterval
 		}
-	case c.WorkloadAPI != nil:
-		if c.WorkloadAPI.SocketPath == "" {
-			return nil, errs.New("socket_path must be configured in the workload_api configuration section")
+	case workloadAPI != nil:
+		if workloadAPI.SocketPath == "" {
+			return errs.New("socket_path must be configured in the workload_api configuration section")
 		}
-		if c.WorkloadAPI.TrustDomain == "" {
-			return nil, errs.New("trust_domain must be configured in the workload_api configuration section")
+		if workloadAPI.TrustDomain == "" {
+			return errs.New("trust_domain must be configured in the workload_api configuration section")
 		}
-		if c.WorkloadAPI.RawPollInterval != "" {
-			c.WorkloadAPI.PollInterval, err = time.ParseDuration(c.WorkloadAPI.RawPollInterval)
+		if workloadAPI.RawPollInterval != "" {
+			workloadAPI.PollInterval, err = time.ParseDuration(workloadAPI.RawPollInterval)
 			if err != nil {
-				return nil, errs.New("invalid poll_interval in the workload_api configuration section: %v", err)
+				return errs.New("invalid poll_interval in the workload_api configuration section: %v", err)
 			}
 		}
-		if c.WorkloadAPI.PollInterval <= 0 {
-			c.WorkloadAPI.PollInterval = defaultPollInterval
+		if workloadAPI.PollInterval <= 0 {
+			workloadAPI.PollInterval = defaultPollInterval
 		}
 	}
-
-	return c, nil
+	return nil
 }
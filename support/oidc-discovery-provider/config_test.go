@@ -90,7 +90,7 @@ func TestParseConfig(t *testing.T) {
 					socket_path = "/other/socket/path"
 				}
 			`,
-			err: "either acme or listen_socket_path must be configured",
+			err: "either acme, serving_cert_file or listen_socket_path must be configured",
 		},
 		{
 			name: "ACME ToS not accepted",
@@ -427,6 +427,175 @@ func TestParseConfig(t *testing.T) {
 			`,
 			err: "trust_domain must be configured in the workload_api configuration section",
 		},
+		{
+			name: "JWKS options",
+			in: `
+				domain = "domain.test"
+				listen_socket_path = "/some/socket/path"
+				set_key_use = true
+				set_key_alg = true
+				refresh_hint_cache_control = true
+				registration_api {
+					socket_path = "/some/socket/path"
+					trust_domain = "otherdomain.test"
+				}
+			`,
+			out: &Config{
+				LogLevel:         defaultLogLevel,
+				Domain:           "domain.test",
+				ListenSocketPath: "/some/socket/path",
+				JWKSOptions: JWKSOptions{
+					SetKeyUse:               true,
+					SetKeyAlg:               true,
+					RefreshHintCacheControl: true,
+				},
+				RegistrationAPI: &RegistrationAPIConfig{
+					SocketPath:   "/some/socket/path",
+					TrustDomain:  "otherdomain.test",
+					PollInterval: defaultPollInterval,
+				},
+			},
+		},
+		{
+			name: "multiple issuers",
+			in: `
+				acme {
+					email = "admin@domain.test"
+					tos_accepted = true
+				}
+				issuer "domain1.test" {
+					set_key_use = true
+					registration_api {
+						socket_path = "/some/socket/path"
+					}
+				}
+				issuer "domain2.test" {
+					workload_api {
+						socket_path = "/other/socket/path"
+						trust_domain = "domain2.test"
+					}
+				}
+			`,
+			out: &Config{
+				LogLevel: defaultLogLevel,
+				ACME: &ACMEConfig{
+					CacheDir:    defaultCacheDir,
+					Email:       "admin@domain.test",
+					ToSAccepted: true,
+				},
+				Issuers: map[string]*IssuerConfig{
+					"domain1.test": {
+						RegistrationAPI: &RegistrationAPIConfig{
+							SocketPath:   "/some/socket/path",
+							PollInterval: defaultPollInterval,
+						},
+						JWKSOptions: JWKSOptions{
+							SetKeyUse: true,
+						},
+					},
+					"domain2.test": {
+						WorkloadAPI: &WorkloadAPIConfig{
+							SocketPath:   "/other/socket/path",
+							TrustDomain:  "domain2.test",
+							PollInterval: defaultPollInterval,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "issuers alongside domain",
+			in: `
+				domain = "domain.test"
+				listen_socket_path = "/some/socket/path"
+				issuer "domain1.test" {
+					registration_api {
+						socket_path = "/some/socket/path"
+					}
+				}
+			`,
+			err: "issuer sections cannot be configured alongside domain, registration_api or workload_api",
+		},
+		{
+			name: "issuers with top-level JWKS options",
+			in: `
+				listen_socket_path = "/some/socket/path"
+				set_key_alg = true
+				issuer "domain1.test" {
+					registration_api {
+						socket_path = "/some/socket/path"
+					}
+				}
+			`,
+			err: "JWKS options must be configured within the issuer sections",
+		},
+		{
+			name: "issuer without source",
+			in: `
+				listen_socket_path = "/some/socket/path"
+				issuer "domain1.test" {
+				}
+			`,
+			err: `issuer "domain1.test": one of registration_api or workload_api section must be configured`,
+		},
+		{
+			name: "serving cert file",
+			in: `
+				domain = "domain.test"
+				serving_cert_file {
+					cert_file_path = "/some/cert.pem"
+					key_file_path = "/some/key.pem"
+				}
+				registration_api {
+					socket_path = "/some/socket/path"
+				}
+			`,
+			out: &Config{
+				LogLevel: defaultLogLevel,
+				Domain:   "domain.test",
+				ServingCertFile: &ServingCertFileConfig{
+					CertFilePath: "/some/cert.pem",
+					KeyFilePath:  "/some/key.pem",
+					Addr:         defaultServingAddr,
+				},
+				RegistrationAPI: &RegistrationAPIConfig{
+					SocketPath:   "/some/socket/path",
+					PollInterval: defaultPollInterval,
+				},
+			},
+		},
+		{
+			name: "serving cert file with ACME",
+			in: `
+				domain = "domain.test"
+				acme {
+					email = "admin@domain.test"
+					tos_accepted = true
+				}
+				serving_cert_file {
+					cert_file_path = "/some/cert.pem"
+					key_file_path = "/some/key.pem"
+				}
+				registration_api {
+					socket_path = "/some/socket/path"
+				}
+			`,
+			err: "serving_cert_file and the acme section are mutually exclusive",
+		},
+		{
+			name: "serving cert file missing key file path",
+			in: `
+				domain = "domain.test"
+				serving_cert_file {
+					cert_file_path = "/some/cert.pem"
+					addr = ":8443"
+				}
+				registration_api {
+					socket_path = "/some/socket/path"
+				}
+			`,
+			err: "key_file_path must be configured in the serving_cert_file configuration section",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -2,22 +2,41 @@ package main
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
+
+	"gopkg.in/square/go-jose.v2"
 )
 
+// Issuer is an issuer domain served by the handler.
+type Issuer struct {
+	Domain      string
+	Source      JWKSSource
+	JWKSOptions JWKSOptions
+}
+
 type Handler struct {
-	domain string
-	source JWKSSource
+	issuers map[string]Issuer
 
 	http.Handler
 }
 
-func NewHandler(domain string, source JWKSSource) *Handler {
+// NewHandler returns a handler serving the discovery document and JWKS of the
+// given issuers. If more than one issuer is served, the issuer is selected
+// using the Host of the request.
+func NewHandler(issuers ...Issuer) *Handler {
 	h := &Handler{
-		domain: domain,
-		source: source,
+		issuers: make(map[string]Issuer),
+	}
+	for _, issuer := range issuers {
+		h.issuers[strings.ToLower(issuer.Domain)] = issuer
 	}
 
 	mux := http.NewServeMux()
@@ -34,14 +53,20 @@ func (h *Handler) serveWellKnown(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	issuer, ok := h.lookupIssuer(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
 	issuerURL := url.URL{
 		Scheme: "https",
-		Host:   h.domain,
+		Host:   issuer.Domain,
 	}
 
 	jwksURI := url.URL{
 		Scheme: "https",
-		Host:   h.domain,
+		Host:   issuer.Domain,
 		Path:   "/keys",
 	}
 
@@ -81,23 +106,93 @@ func (h *Handler) serveKeys(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jwks, modTime, ok := h.source.FetchKeySet()
+	issuer, ok := h.lookupIssuer(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	jwks, modTime, ok := issuer.Source.FetchKeySet()
 	if !ok {
 		http.Error(w, "document not available", http.StatusInternalServerError)
 		return
 	}
 
-	jwksBytes, err := json.MarshalIndent(jwks, "", "  ")
+	jwksBytes, err := json.MarshalIndent(applyJWKSOptions(jwks, issuer.JWKSOptions), "", "  ")
 	if err != nil {
 		http.Error(w, "failed to marshal JWKS", http.StatusInternalServerError)
 		return
 	}
 
-	// Disable caching
-	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-	w.Header().Set("Pragma", "no-cache")
-	w.Header().Set("Expires", "0")
+	if refreshHint := issuer.Source.RefreshHint(); issuer.JWKSOptions.RefreshHintCacheControl && refreshHint > 0 {
+		// Allow clients to cache the keys until they are expected to refresh
+		// the bundle anyway.
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int64(refreshHint.Seconds())))
+	} else {
+		// Disable caching
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Set("Pragma", "no-cache")
+		w.Header().Set("Expires", "0")
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	http.ServeContent(w, r, "keys", modTime, bytes.NewReader(jwksBytes))
 }
+
+// lookupIssuer returns the issuer for the request. When a single issuer is
+// served it is returned regardless of the Host of the request, for
+// compatibility with deployments behind proxies that rewrite it.
+func (h *Handler) lookupIssuer(r *http.Request) (Issuer, bool) {
+	if len(h.issuers) == 1 {
+		for _, issuer := range h.issuers {
+			return issuer, true
+		}
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	issuer, ok := h.issuers[strings.ToLower(host)]
+	return issuer, ok
+}
+
+// applyJWKSOptions returns the key set with the "use" and "alg" fields set as
+// requested by the options. The key set returned by the source is not
+// modified.
+func applyJWKSOptions(jwks *jose.JSONWebKeySet, options JWKSOptions) *jose.JSONWebKeySet {
+	if !options.SetKeyUse && !options.SetKeyAlg {
+		return jwks
+	}
+
+	out := &jose.JSONWebKeySet{
+		Keys: make([]jose.JSONWebKey, 0, len(jwks.Keys)),
+	}
+	for _, key := range jwks.Keys {
+		if options.SetKeyUse {
+			key.Use = "sig"
+		}
+		if options.SetKeyAlg {
+			key.Algorithm = keyAlgorithm(key.Key)
+		}
+		out.Keys = append(out.Keys, key)
+	}
+	return out
+}
+
+// keyAlgorithm returns the JWS algorithm used by SPIRE to sign JWT-SVIDs with
+// the given public key, or an empty string if the key type is not supported.
+func keyAlgorithm(key interface{}) string {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return string(jose.RS256)
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return string(jose.ES256)
+		case elliptic.P384():
+			return string(jose.ES384)
+		}
+	}
+	return ""
+}
@@ -112,7 +112,7 @@ func TestHandler(t *testing.T) {
 			require.NoError(t, err)
 			w := httptest.NewRecorder()
 
-			h := NewHandler("domain.test", source)
+			h := NewHandler(Issuer{Domain: "domain.test", Source: source})
 			h.ServeHTTP(w, r)
 
 			t.Logf("HEADERS: %q", w.Header())
@@ -122,10 +122,116 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandlerJWKSOptions(t *testing.T) {
+	source := new(FakeKeySetSource)
+	source.SetKeySet(&jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{
+				Key:   ec256Pubkey,
+				KeyID: "KEYID",
+			},
+		},
+	}, time.Time{})
+	source.SetRefreshHint(5 * time.Minute)
+
+	t.Run("defaults", func(t *testing.T) {
+		w := serveKeys(t, NewHandler(Issuer{Domain: "domain.test", Source: source}), "domain.test")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"use"`)
+		assert.NotContains(t, w.Body.String(), `"alg"`)
+		assert.Equal(t, "no-cache, no-store, must-revalidate", w.Header().Get("Cache-Control"))
+	})
+
+	t.Run("all options set", func(t *testing.T) {
+		w := serveKeys(t, NewHandler(Issuer{
+			Domain: "domain.test",
+			Source: source,
+			JWKSOptions: JWKSOptions{
+				SetKeyUse:               true,
+				SetKeyAlg:               true,
+				RefreshHintCacheControl: true,
+			},
+		}), "domain.test")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{
+  "keys": [
+    {
+      "use": "sig",
+      "kty": "EC",
+      "kid": "KEYID",
+      "crv": "P-256",
+      "alg": "ES256",
+      "x": "iSt7S4ih6QLodw9wf-zdPV8bmAlDJBCRRy24_UAZY70",
+      "y": "Gb4gkQCeHj7HCbZzdctcAx9dxoDgC9sudsSG7ZLIWJs"
+    }
+  ]
+}`, w.Body.String())
+		assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+		assert.Empty(t, w.Header().Get("Pragma"))
+
+		// The key set of the source is left untouched.
+		jwks, _, _ := source.FetchKeySet()
+		assert.Empty(t, jwks.Keys[0].Use)
+		assert.Empty(t, jwks.Keys[0].Algorithm)
+	})
+}
+
+func TestHandlerMultipleIssuers(t *testing.T) {
+	source1 := new(FakeKeySetSource)
+	source1.SetKeySet(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: ec256Pubkey, KeyID: "KEYID1"}}}, time.Time{})
+	source2 := new(FakeKeySetSource)
+	source2.SetKeySet(&jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: ec256Pubkey, KeyID: "KEYID2"}}}, time.Time{})
+
+	h := NewHandler(
+		Issuer{Domain: "domain1.test", Source: source1},
+		Issuer{Domain: "domain2.test", Source: source2},
+	)
+
+	w := serveKeys(t, h, "domain1.test")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "KEYID1")
+
+	w = serveKeys(t, h, "DOMAIN2.test:443")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "KEYID2")
+
+	w = serveKeys(t, h, "domain3.test")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	r, err := http.NewRequest("GET", "http://domain2.test/.well-known/openid-configuration", nil)
+	require.NoError(t, err)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"issuer": "https://domain2.test"`)
+}
+
+func serveKeys(t *testing.T, h http.Handler, host string) *httptest.ResponseRecorder {
+	r, err := http.NewRequest("GET", "http://localhost/keys", nil)
+	require.NoError(t, err)
+	r.Host = host
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
 type FakeKeySetSource struct {
-	mu      sync.Mutex
-	jwks    *jose.JSONWebKeySet
-	modTime time.Time
+	mu          sync.Mutex
+	jwks        *jose.JSONWebKeySet
+	modTime     time.Time
+	refreshHint time.Duration
+}
+
+func (s *FakeKeySetSource) SetRefreshHint(refreshHint time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshHint = refreshHint
+}
+
+func (s *FakeKeySetSource) RefreshHint() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refreshHint
 }
 
 func (s *FakeKeySetSource) SetKeySet(jwks *jose.JSONWebKeySet, modTime time.Time) {
@@ -10,6 +10,10 @@ type JWKSSource interface {
 	// FetchJWKS returns the key set and modified time.
 	FetchKeySet() (*jose.JSONWebKeySet, time.Time, bool)
 
+	// RefreshHint returns the refresh hint of the bundle the key set was
+	// built from, or zero if the bundle does not have one.
+	RefreshHint() time.Duration
+
 	// Close closes the source.
 	Close() error
 }
@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/log"
@@ -38,31 +39,23 @@ func run(configPath string) error {
 	}
 	defer log.Close()
 
-	var source JWKSSource
-	switch {
-	case config.RegistrationAPI != nil:
-		source, err = NewRegistrationAPISource(RegistrationAPISourceConfig{
-			Log:          log,
-			SocketPath:   config.RegistrationAPI.SocketPath,
-			PollInterval: config.RegistrationAPI.PollInterval,
-		})
-	case config.WorkloadAPI != nil:
-		source, err = NewWorkloadAPISource(WorkloadAPISourceConfig{
-			Log:          log,
-			SocketPath:   config.WorkloadAPI.SocketPath,
-			PollInterval: config.WorkloadAPI.PollInterval,
-			TrustDomain:  config.WorkloadAPI.TrustDomain,
+	var issuers []Issuer
+	for _, domain := range sortedDomains(config) {
+		issuerConfig := config.IssuerConfigs()[domain]
+		source, err := newSource(log, issuerConfig)
+		if err != nil {
+			return errs.New("issuer %q: %v", domain, err)
+		}
+		defer source.Close()
+
+		issuers = append(issuers, Issuer{
+			Domain:      domain,
+			Source:      source,
+			JWKSOptions: issuerConfig.JWKSOptions,
 		})
-	default:
-		// This is defensive; LoadConfig should prevent this from happening.
-		err = errs.New("no source has been configured")
 	}
-	if err != nil {
-		return err
-	}
-	defer source.Close()
 
-	var handler http.Handler = NewHandler(config.Domain, source)
+	var handler http.Handler = NewHandler(issuers...)
 	if config.LogRequests {
 		log.Info("Logging all requests")
 		handler = logHandler(log, handler)
@@ -83,6 +76,12 @@ func run(configPath string) error {
 			return err
 		}
 		log.WithField("socket", config.ListenSocketPath).Info("Serving HTTP (unix)")
+	case config.ServingCertFile != nil:
+		listener, err = servingCertFileListener(log, config.ServingCertFile)
+		if err != nil {
+			return err
+		}
+		log.WithField("address", config.ServingCertFile.Addr).Info("Serving HTTPS using certificate from disk")
 	default:
 		listener = acmeListener(log, config)
 		log.Info("Serving HTTPS via ACME")
@@ -91,6 +90,37 @@ func run(configPath string) error {
 	return http.Serve(listener, handler)
 }
 
+func newSource(log logrus.FieldLogger, config *IssuerConfig) (JWKSSource, error) {
+	switch {
+	case config.RegistrationAPI != nil:
+		return NewRegistrationAPISource(RegistrationAPISourceConfig{
+			Log:          log,
+			SocketPath:   config.RegistrationAPI.SocketPath,
+			TrustDomain:  config.RegistrationAPI.TrustDomain,
+			PollInterval: config.RegistrationAPI.PollInterval,
+		})
+	case config.WorkloadAPI != nil:
+		return NewWorkloadAPISource(WorkloadAPISourceConfig{
+			Log:          log,
+			SocketPath:   config.WorkloadAPI.SocketPath,
+			PollInterval: config.WorkloadAPI.PollInterval,
+			TrustDomain:  config.WorkloadAPI.TrustDomain,
+		})
+	default:
+		// This is defensive; LoadConfig should prevent this from happening.
+		return nil, errs.New("no source has been configured")
+	}
+}
+
+func sortedDomains(config *Config) []string {
+	var domains []string
+	for domain := range config.IssuerConfigs() {
+		domains = append(domains, domain)
+	}
+	sort.Strings(domains)
+	return domains
+}
+
 func acmeListener(logger *log.Logger, config *Config) net.Listener {
 	var cache autocert.Cache
 	if config.ACME.CacheDir != "" {
@@ -104,7 +134,7 @@ func acmeListener(logger *log.Logger, config *Config) net.Listener {
 			DirectoryURL: config.ACME.DirectoryURL,
 		},
 		Email:      config.ACME.Email,
-		HostPolicy: autocert.HostWhitelist(config.Domain),
+		HostPolicy: autocert.HostWhitelist(sortedDomains(config)...),
 		Prompt: func(tosURL string) bool {
 			logger.WithField("url", tosURL).Info("ACME Terms Of Service accepted")
 			return true
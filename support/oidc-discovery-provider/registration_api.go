@@ -9,6 +9,8 @@ import (
 	"github.com/andres-erbsen/clock"
 	"github.com/gogo/protobuf/proto"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/zeebo/errs"
@@ -23,14 +25,16 @@ const (
 type RegistrationAPISourceConfig struct {
 	Log          logrus.FieldLogger
 	SocketPath   string
+	TrustDomain  string
 	PollInterval time.Duration
 	Clock        clock.Clock
 }
 
 type RegistrationAPISource struct {
-	log    logrus.FieldLogger
-	clock  clock.Clock
-	cancel context.CancelFunc
+	log           logrus.FieldLogger
+	clock         clock.Clock
+	trustDomainID string
+	cancel        context.CancelFunc
 
 	mu      sync.RWMutex
 	wg      sync.WaitGroup
@@ -58,6 +62,9 @@ func NewRegistrationAPISource(config RegistrationAPISourceConfig) (*Registration
 		clock:  config.Clock,
 		cancel: cancel,
 	}
+	if config.TrustDomain != "" {
+		s.trustDomainID = idutil.TrustDomainID(config.TrustDomain)
+	}
 
 	go s.pollEvery(ctx, conn, config.PollInterval)
 	return s, nil
@@ -78,6 +85,15 @@ func (s *RegistrationAPISource) FetchKeySet() (*jose.JSONWebKeySet, time.Time, b
 	return s.jwks, s.modTime, true
 }
 
+func (s *RegistrationAPISource) RefreshHint() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.bundle == nil {
+		return 0
+	}
+	return time.Duration(s.bundle.RefreshHint) * time.Second
+}
+
 func (s *RegistrationAPISource) pollEvery(ctx context.Context, conn *grpc.ClientConn, interval time.Duration) {
 	s.wg.Add(1)
 	defer s.wg.Done()
@@ -108,7 +124,21 @@ func (s *RegistrationAPISource) pollOnce(ctx context.Context, client registratio
 		return
 	}
 
-	s.parseBundle(resp.Bundle)
+	bundle := resp.Bundle
+	if s.trustDomainID != "" && bundle != nil && bundle.TrustDomainId != s.trustDomainID {
+		// The trust domain is not the one of the server, so it must be a
+		// federated trust domain.
+		resp, err := client.FetchFederatedBundle(ctx, &registration.FederatedBundleID{
+			Id: s.trustDomainID,
+		})
+		if err != nil {
+			s.log.WithError(err).WithField("trust_domain_id", s.trustDomainID).Warn("Failed to fetch federated bundle")
+			return
+		}
+		bundle = resp.Bundle
+	}
+
+	s.parseBundle(bundle)
 }
 
 func (s *RegistrationAPISource) parseBundle(bundle *common.Bundle) {
@@ -127,6 +157,12 @@ func (s *RegistrationAPISource) parseBundle(bundle *common.Bundle) {
 
 	jwks := new(jose.JSONWebKeySet)
 	for _, key := range bundle.JwtSigningKeys {
+		// Keys delegated to agents are scoped to SPIFFE ID path prefixes that
+		// OIDC relying parties cannot enforce, so they are not published.
+		if len(key.PathPrefixes) > 0 || jwtsvid.IsDelegatedKeyID(key.Kid) {
+			continue
+		}
+
 		publicKey, err := x509.ParsePKIXPublicKey(key.PkixBytes)
 		if err != nil {
 			s.log.WithError(err).WithField("kid", key.Kid).Warn("Malformed public key in bundle")
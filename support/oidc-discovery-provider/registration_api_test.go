@@ -75,13 +75,19 @@ func TestRegistrationAPISource(t *testing.T) {
 	require.Equal(t, modTime1, modTime2)
 
 	// Change the bundle, step forward past the poll interval, wait for polling,
-	// and assert that the changes have been picked up.
+	// and assert that the changes have been picked up. Keys delegated to
+	// agents are left out of the key set.
 	api.SetBundle(&common.Bundle{
 		JwtSigningKeys: []*common.PublicKey{
 			{
 				Kid:       "KID2",
 				PkixBytes: ec256PubkeyPKIX,
 			},
+			{
+				Kid:          "agent.0123456789abcdef.KID",
+				PkixBytes:    ec256PubkeyPKIX,
+				PathPrefixes: []string{"/workload"},
+			},
 		},
 	})
 	clock.Add(pollInterval)
@@ -96,14 +102,69 @@ func TestRegistrationAPISource(t *testing.T) {
 	require.Equal(t, ec256Pubkey, keySet3.Keys[0].Key)
 }
 
+func TestRegistrationAPISourceFederatedTrustDomain(t *testing.T) {
+	const pollInterval = time.Second
+
+	api := &fakeRegistrationAPIServer{}
+	api.SetBundle(&common.Bundle{
+		TrustDomainId: "spiffe://domain.test",
+		JwtSigningKeys: []*common.PublicKey{
+			{
+				Kid:       "LOCAL",
+				PkixBytes: ec256PubkeyPKIX,
+			},
+		},
+	})
+	api.SetFederatedBundle(&common.Bundle{
+		TrustDomainId: "spiffe://otherdomain.test",
+		RefreshHint:   300,
+		JwtSigningKeys: []*common.PublicKey{
+			{
+				Kid:       "FEDERATED",
+				PkixBytes: ec256PubkeyPKIX,
+			},
+		},
+	})
+
+	socketPath, closeServer := spiretest.StartRegistrationAPIOnTempSocket(t, api)
+	defer closeServer()
+
+	log, _ := test.NewNullLogger()
+	clock := clock.NewMock(t)
+
+	source, err := NewRegistrationAPISource(RegistrationAPISourceConfig{
+		Log:          log,
+		SocketPath:   socketPath,
+		TrustDomain:  "otherdomain.test",
+		PollInterval: pollInterval,
+		Clock:        clock,
+	})
+	require.NoError(t, err)
+	defer source.Close()
+
+	clock.WaitForAfter(time.Minute, "failed to wait for the poll timer")
+	keySet, _, ok := source.FetchKeySet()
+	require.True(t, ok)
+	require.Len(t, keySet.Keys, 1)
+	require.Equal(t, "FEDERATED", keySet.Keys[0].KeyID)
+	require.Equal(t, 5*time.Minute, source.RefreshHint())
+}
+
 type fakeRegistrationAPIServer struct {
 	registration.RegistrationServer
 
 	mu               sync.Mutex
 	bundle           *common.Bundle
+	federatedBundle  *common.Bundle
 	fetchBundleCount int
 }
 
+func (s *fakeRegistrationAPIServer) SetFederatedBundle(bundle *common.Bundle) {
+	s.mu.Lock()
+	s.federatedBundle = bundle
+	s.mu.Unlock()
+}
+
 func (s *fakeRegistrationAPIServer) SetBundle(bundle *common.Bundle) {
 	s.mu.Lock()
 	s.bundle = bundle
@@ -128,3 +189,14 @@ func (s *fakeRegistrationAPIServer) FetchBundle(context.Context, *common.Empty)
 		Bundle: s.bundle,
 	}, nil
 }
+
+func (s *fakeRegistrationAPIServer) FetchFederatedBundle(ctx context.Context, req *registration.FederatedBundleID) (*registration.FederatedBundle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.federatedBundle == nil || s.federatedBundle.TrustDomainId != req.Id {
+		return nil, status.Error(codes.NotFound, "no federated bundle")
+	}
+	return &registration.FederatedBundle{
+		Bundle: s.federatedBundle,
+	}, nil
+}
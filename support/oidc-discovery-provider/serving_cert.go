@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/zeebo/errs"
+)
+
+func servingCertFileListener(log logrus.FieldLogger, config *ServingCertFileConfig) (net.Listener, error) {
+	loader := &certFileLoader{
+		log:          log,
+		certFilePath: config.CertFilePath,
+		keyFilePath:  config.KeyFilePath,
+	}
+	// Fail fast if the certificate cannot be loaded at startup.
+	if _, err := loader.GetCertificate(nil); err != nil {
+		return nil, err
+	}
+
+	return tls.Listen("tcp", config.Addr, &tls.Config{
+		GetCertificate: loader.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	})
+}
+
+// certFileLoader loads the serving certificate and key from disk, reloading
+// them whenever the files are modified (e.g. when a mounted secret is
+// rotated).
+type certFileLoader struct {
+	log          logrus.FieldLogger
+	certFilePath string
+	keyFilePath  string
+
+	mu          sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+func (l *certFileLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	certModTime, err := modTime(l.certFilePath)
+	if err != nil {
+		return l.fallback(err)
+	}
+	keyModTime, err := modTime(l.keyFilePath)
+	if err != nil {
+		return l.fallback(err)
+	}
+
+	if l.cert != nil && certModTime.Equal(l.certModTime) && keyModTime.Equal(l.keyModTime) {
+		return l.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(l.certFilePath, l.keyFilePath)
+	if err != nil {
+		// The files may be observed mid-update; keep serving the previous
+		// certificate and try again on the next handshake.
+		return l.fallback(errs.New("unable to load serving certificate: %v", err))
+	}
+
+	if l.cert != nil {
+		l.log.Info("Reloaded serving certificate")
+	}
+	l.cert = &cert
+	l.certModTime = certModTime
+	l.keyModTime = keyModTime
+	return l.cert, nil
+}
+
+func (l *certFileLoader) fallback(err error) (*tls.Certificate, error) {
+	if l.cert == nil {
+		return nil, err
+	}
+	l.log.WithError(err).Warn("Serving previously loaded certificate")
+	return l.cert, nil
+}
+
+func modTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, errs.Wrap(err)
+	}
+	return info.ModTime(), nil
+}
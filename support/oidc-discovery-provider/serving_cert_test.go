@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertFileLoader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFilePath := filepath.Join(dir, "cert.pem")
+	keyFilePath := filepath.Join(dir, "key.pem")
+
+	log, _ := test.NewNullLogger()
+	loader := &certFileLoader{
+		log:          log,
+		certFilePath: certFilePath,
+		keyFilePath:  keyFilePath,
+	}
+
+	// Nothing to serve until the files exist.
+	_, err = loader.GetCertificate(nil)
+	require.Error(t, err)
+
+	keyPEM, err := pemutil.EncodePKCS8PrivateKey(spiretest.DefaultKey)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(keyFilePath, keyPEM, 0600))
+
+	writeCert := func(serial int64, modTime time.Time) {
+		cert, _ := spiretest.SelfSignCertificate(t, &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			NotAfter:     time.Now().Add(time.Hour),
+		})
+		require.NoError(t, ioutil.WriteFile(certFilePath, pemutil.EncodeCertificate(cert), 0600))
+		require.NoError(t, os.Chtimes(certFilePath, modTime, modTime))
+	}
+
+	now := time.Now()
+	writeCert(1, now)
+	cert, err := loader.GetCertificate(nil)
+	require.NoError(t, err)
+	requireSerial(t, 1, cert.Certificate[0])
+
+	// The certificate is reloaded once the file changes.
+	writeCert(2, now.Add(time.Second))
+	cert, err = loader.GetCertificate(nil)
+	require.NoError(t, err)
+	requireSerial(t, 2, cert.Certificate[0])
+
+	// A broken file keeps the previous certificate in service.
+	require.NoError(t, ioutil.WriteFile(certFilePath, []byte("garbage"), 0600))
+	cert, err = loader.GetCertificate(nil)
+	require.NoError(t, err)
+	requireSerial(t, 2, cert.Certificate[0])
+}
+
+func requireSerial(t *testing.T, serial int64, certDER []byte) {
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(serial), cert.SerialNumber)
+}
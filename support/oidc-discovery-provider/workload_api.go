@@ -13,6 +13,7 @@ import (
 	workload_pb "github.com/spiffe/go-spiffe/proto/spiffe/workload"
 	"github.com/spiffe/go-spiffe/workload"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/zeebo/errs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -37,11 +38,12 @@ type WorkloadAPISource struct {
 	trustDomainID string
 	cancel        context.CancelFunc
 
-	mu      sync.RWMutex
-	wg      sync.WaitGroup
-	bundle  []byte
-	jwks    *jose.JSONWebKeySet
-	modTime time.Time
+	mu          sync.RWMutex
+	wg          sync.WaitGroup
+	bundle      []byte
+	jwks        *jose.JSONWebKeySet
+	refreshHint time.Duration
+	modTime     time.Time
 }
 
 func NewWorkloadAPISource(config WorkloadAPISourceConfig) (*WorkloadAPISource, error) {
@@ -88,6 +90,12 @@ func (s *WorkloadAPISource) FetchKeySet() (*jose.JSONWebKeySet, time.Time, bool)
 	return s.jwks, s.modTime, true
 }
 
+func (s *WorkloadAPISource) RefreshHint() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshHint
+}
+
 func (s *WorkloadAPISource) pollEvery(ctx context.Context, conn *grpc.ClientConn, interval time.Duration) {
 	s.wg.Add(1)
 	defer s.wg.Done()
@@ -153,15 +161,32 @@ func (s *WorkloadAPISource) parseBundle(bundle []byte) {
 		return
 	}
 
-	// Clean the JWKS
-	for i, key := range jwks.Keys {
+	// The refresh hint, in seconds, is carried in the SPIFFE bundle document
+	// alongside the keys.
+	var bundleDoc struct {
+		RefreshHint int64 `json:"spiffe_refresh_hint"`
+	}
+	if err := json.Unmarshal(bundle, &bundleDoc); err != nil {
+		s.log.WithError(err).Error("Failed to parse trust domain bundle received from the Workload API")
+		return
+	}
+
+	// Clean the JWKS, leaving out keys delegated to agents since OIDC relying
+	// parties cannot enforce the path prefixes they are scoped to.
+	keys := jwks.Keys[:0]
+	for _, key := range jwks.Keys {
+		if jwtsvid.IsDelegatedKeyID(key.KeyID) {
+			continue
+		}
 		key.Use = ""
-		jwks.Keys[i] = key
+		keys = append(keys, key)
 	}
+	jwks.Keys = keys
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.bundle = bundle
 	s.jwks = jwks
+	s.refreshHint = time.Duration(bundleDoc.RefreshHint) * time.Second
 	s.modTime = s.clock.Now()
 }
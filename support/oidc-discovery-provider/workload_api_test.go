@@ -88,7 +88,8 @@ func TestWorkloadAPISource(t *testing.T) {
 	require.Equal(t, modTime1, modTime2)
 
 	// Change the bundle, step forward past the poll interval, wait for polling,
-	// and assert that the changes have been picked up.
+	// and assert that the changes have been picked up. Keys delegated to
+	// agents are left out of the key set.
 	api.SetJWTBundles(map[string][]byte{
 		"spiffe://domain.test": makeJWKS(t, &jose.JSONWebKeySet{
 			Keys: []jose.JSONWebKey{
@@ -96,6 +97,10 @@ func TestWorkloadAPISource(t *testing.T) {
 					KeyID: "KID2",
 					Key:   ec256Pubkey,
 				},
+				{
+					KeyID: "agent.0123456789abcdef.KID",
+					Key:   ec256Pubkey,
+				},
 			},
 		}),
 	})
@@ -109,6 +114,16 @@ func TestWorkloadAPISource(t *testing.T) {
 	require.Len(t, keySet3.Keys, 1)
 	require.Equal(t, "KID2", keySet3.Keys[0].KeyID)
 	require.Equal(t, ec256Pubkey, keySet3.Keys[0].Key)
+	require.Zero(t, source.RefreshHint())
+
+	// Set a refresh hint on the bundle and assert it is picked up.
+	api.SetJWTBundles(map[string][]byte{
+		"spiffe://domain.test": []byte(`{"keys": [], "spiffe_refresh_hint": 60}`),
+	})
+	clock.Add(pollInterval)
+	clock.WaitForAfter(time.Minute, "failed to wait for the poll timer")
+	require.Equal(t, 6, api.GetFetchJWTBundlesCount())
+	require.Equal(t, time.Minute, source.RefreshHint())
 }
 
 type fakeWorkloadAPIServer struct {
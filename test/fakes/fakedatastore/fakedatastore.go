@@ -25,24 +25,32 @@ import (
 var (
 	// TODO: The following errors should be grpc/status errors with the AlreadyExists code. #992
 
-	ErrBundleAlreadyExists       = errors.New("bundle already exists")
-	ErrAttestedNodeAlreadyExists = errors.New("attested node entry already exists")
-	ErrTokenAlreadyExists        = errors.New("token already exists")
-
-	ErrNoSuchBundle            = status.Error(codes.NotFound, "no such bundle")
-	ErrNoSuchAttestedNode      = status.Error(codes.NotFound, "no such attested node entry")
-	ErrNoSuchRegistrationEntry = status.Error(codes.NotFound, "no such registration entry")
-	ErrNoSuchToken             = status.Error(codes.NotFound, "no such token")
+	ErrBundleAlreadyExists                 = errors.New("bundle already exists")
+	ErrAttestedNodeAlreadyExists           = errors.New("attested node entry already exists")
+	ErrTokenAlreadyExists                  = errors.New("token already exists")
+	ErrFederationRelationshipAlreadyExists = errors.New("federation relationship already exists")
+	ErrAgentBanAlreadyExists               = errors.New("agent ban already exists")
+
+	ErrNoSuchBundle                 = status.Error(codes.NotFound, "no such bundle")
+	ErrNoSuchAttestedNode           = status.Error(codes.NotFound, "no such attested node entry")
+	ErrNoSuchRegistrationEntry      = status.Error(codes.NotFound, "no such registration entry")
+	ErrNoSuchToken                  = status.Error(codes.NotFound, "no such token")
+	ErrNoSuchFederationRelationship = status.Error(codes.NotFound, "no such federation relationship")
+	ErrNoSuchAgentBan               = status.Error(codes.NotFound, "no such agent ban")
 )
 
 type DataStore struct {
 	mu sync.Mutex
 
-	bundles             map[string]*common.Bundle
-	attestedNodes       map[string]*common.AttestedNode
-	nodeSelectors       map[string][]*common.Selector
-	registrationEntries map[string]*common.RegistrationEntry
-	tokens              map[string]*datastore.JoinToken
+	bundles                 map[string]*common.Bundle
+	upstreamBundles         map[string]bool
+	bundleHistory           map[string][]*datastore.BundleHistoryEntry
+	federationRelationships map[string]*common.FederationRelationship
+	attestedNodes           map[string]*common.AttestedNode
+	nodeSelectors           map[string][]*common.Selector
+	registrationEntries     map[string]*common.RegistrationEntry
+	tokens                  map[string]*datastore.JoinToken
+	agentBans               []*common.AgentBan
 
 	// relates bundles with entries that federate with them
 	bundleEntries map[string]map[string]bool
@@ -52,12 +60,15 @@ var _ datastore.DataStore = (*DataStore)(nil)
 
 func New() *DataStore {
 	return &DataStore{
-		bundles:             make(map[string]*common.Bundle),
-		attestedNodes:       make(map[string]*common.AttestedNode),
-		nodeSelectors:       make(map[string][]*common.Selector),
-		registrationEntries: make(map[string]*common.RegistrationEntry),
-		tokens:              make(map[string]*datastore.JoinToken),
-		bundleEntries:       make(map[string]map[string]bool),
+		bundles:                 make(map[string]*common.Bundle),
+		upstreamBundles:         make(map[string]bool),
+		bundleHistory:           make(map[string][]*datastore.BundleHistoryEntry),
+		federationRelationships: make(map[string]*common.FederationRelationship),
+		attestedNodes:           make(map[string]*common.AttestedNode),
+		nodeSelectors:           make(map[string][]*common.Selector),
+		registrationEntries:     make(map[string]*common.RegistrationEntry),
+		tokens:                  make(map[string]*datastore.JoinToken),
+		bundleEntries:           make(map[string]map[string]bool),
 	}
 }
 
@@ -87,9 +98,13 @@ func (s *DataStore) UpdateBundle(ctx context.Context, req *datastore.UpdateBundl
 
 	bundle := req.Bundle
 
-	if _, ok := s.bundles[bundle.TrustDomainId]; !ok {
+	existingBundle, ok := s.bundles[bundle.TrustDomainId]
+	if !ok {
 		return nil, ErrNoSuchBundle
 	}
+	if !req.PreserveSequenceNumber {
+		bundle = bundleutil.SequenceBundleUpdate(existingBundle, bundle)
+	}
 
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
 
@@ -104,8 +119,16 @@ func (s *DataStore) SetBundle(ctx context.Context, req *datastore.SetBundleReque
 	defer s.mu.Unlock()
 
 	bundle := req.Bundle
+	if existingBundle, ok := s.bundles[bundle.TrustDomainId]; ok && !req.PreserveSequenceNumber {
+		bundle = bundleutil.SequenceBundleUpdate(existingBundle, bundle)
+	}
 
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	if req.Upstream {
+		s.upstreamBundles[bundle.TrustDomainId] = true
+	} else {
+		delete(s.upstreamBundles, bundle.TrustDomainId)
+	}
 
 	return &datastore.SetBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -136,6 +159,10 @@ func (s *DataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundl
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.deleteBundle(req)
+}
+
+func (s *DataStore) deleteBundle(req *datastore.DeleteBundleRequest) (*datastore.DeleteBundleResponse, error) {
 	bundle, ok := s.bundles[req.TrustDomainId]
 	if !ok {
 		return nil, ErrNoSuchBundle
@@ -158,6 +185,8 @@ func (s *DataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundl
 		}
 	}
 	delete(s.bundles, req.TrustDomainId)
+	delete(s.upstreamBundles, req.TrustDomainId)
+	delete(s.bundleHistory, req.TrustDomainId)
 
 	return &datastore.DeleteBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -187,6 +216,9 @@ func (s *DataStore) ListBundles(ctx context.Context, req *datastore.ListBundlesR
 	// get an ordered list of keys so tests can rely on ordering for stability
 	keys := make([]string, 0, len(s.bundles))
 	for key := range s.bundles {
+		if req.ByUpstream && !s.upstreamBundles[key] {
+			continue
+		}
 		keys = append(keys, key)
 	}
 	sort.Strings(keys)
@@ -199,6 +231,16 @@ func (s *DataStore) ListBundles(ctx context.Context, req *datastore.ListBundlesR
 	return resp, nil
 }
 
+// CountBundles returns the number of bundles
+func (s *DataStore) CountBundles(ctx context.Context, req *datastore.CountBundlesRequest) (*datastore.CountBundlesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &datastore.CountBundlesResponse{
+		Bundles: int32(len(s.bundles)),
+	}, nil
+}
+
 // PruneBundle removes expired certs from a bundle
 func (s *DataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
 	s.mu.Lock()
@@ -223,6 +265,229 @@ func (s *DataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleR
 	return &datastore.PruneBundleResponse{BundleChanged: changed}, nil
 }
 
+// RemoveBundleJWTSigningKeys removes the JWT signing keys with a given key ID
+// prefix from a bundle
+func (s *DataStore) RemoveBundleJWTSigningKeys(ctx context.Context, req *datastore.RemoveBundleJWTSigningKeysRequest) (*datastore.RemoveBundleJWTSigningKeysResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.KeyIdPrefix == "" {
+		return nil, errors.New("key ID prefix is required")
+	}
+
+	oldBundle, ok := s.bundles[req.TrustDomainId]
+	if !ok {
+		return &datastore.RemoveBundleJWTSigningKeysResponse{}, nil
+	}
+
+	newBundle, removed := bundleutil.RemoveJWTSigningKeys(oldBundle, req.KeyIdPrefix)
+	if removed > 0 {
+		s.bundles[req.TrustDomainId] = newBundle
+	}
+
+	return &datastore.RemoveBundleJWTSigningKeysResponse{Removed: int32(removed)}, nil
+}
+
+// AppendBundleHistory records a bundle received from a federated bundle endpoint
+func (s *DataStore) AppendBundleHistory(ctx context.Context, req *datastore.AppendBundleHistoryRequest) (*datastore.AppendBundleHistoryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Entry == nil || req.Entry.Bundle == nil {
+		return nil, errors.New("missing bundle history entry in request")
+	}
+
+	trustDomainID := req.Entry.Bundle.TrustDomainId
+	history := append(s.bundleHistory[trustDomainID], proto.Clone(req.Entry).(*datastore.BundleHistoryEntry))
+	if req.MaxEntries > 0 && len(history) > int(req.MaxEntries) {
+		history = history[len(history)-int(req.MaxEntries):]
+	}
+	s.bundleHistory[trustDomainID] = history
+
+	return &datastore.AppendBundleHistoryResponse{}, nil
+}
+
+// ListBundleHistory lists the bundles received from a federated bundle endpoint, oldest first
+func (s *DataStore) ListBundleHistory(ctx context.Context, req *datastore.ListBundleHistoryRequest) (*datastore.ListBundleHistoryResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListBundleHistoryResponse)
+	for _, entry := range s.bundleHistory[req.TrustDomainId] {
+		resp.Entries = append(resp.Entries, proto.Clone(entry).(*datastore.BundleHistoryEntry))
+	}
+	return resp, nil
+}
+
+// CreateFederationRelationship stores the given federation relationship
+func (s *DataStore) CreateFederationRelationship(ctx context.Context, req *datastore.CreateFederationRelationshipRequest) (*datastore.CreateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship := req.Relationship
+
+	if _, ok := s.federationRelationships[relationship.TrustDomainId]; ok {
+		return nil, ErrFederationRelationshipAlreadyExists
+	}
+
+	s.federationRelationships[relationship.TrustDomainId] = cloneFederationRelationship(relationship)
+
+	return &datastore.CreateFederationRelationshipResponse{
+		Relationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+// FetchFederationRelationship fetches the federation relationship with the given trust domain
+func (s *DataStore) FetchFederationRelationship(ctx context.Context, req *datastore.FetchFederationRelationshipRequest) (*datastore.FetchFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship, ok := s.federationRelationships[req.TrustDomainId]
+	if !ok {
+		return &datastore.FetchFederationRelationshipResponse{}, nil
+	}
+
+	return &datastore.FetchFederationRelationshipResponse{
+		Relationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+// ListFederationRelationships lists all federation relationships
+func (s *DataStore) ListFederationRelationships(ctx context.Context, req *datastore.ListFederationRelationshipsRequest) (*datastore.ListFederationRelationshipsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// get an ordered list of keys so tests can rely on ordering for stability
+	keys := make([]string, 0, len(s.federationRelationships))
+	for key := range s.federationRelationships {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resp := new(datastore.ListFederationRelationshipsResponse)
+	for _, key := range keys {
+		resp.Relationships = append(resp.Relationships, cloneFederationRelationship(s.federationRelationships[key]))
+	}
+	return resp, nil
+}
+
+// UpdateFederationRelationship updates an existing federation relationship
+func (s *DataStore) UpdateFederationRelationship(ctx context.Context, req *datastore.UpdateFederationRelationshipRequest) (*datastore.UpdateFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship := req.Relationship
+
+	if _, ok := s.federationRelationships[relationship.TrustDomainId]; !ok {
+		return nil, ErrNoSuchFederationRelationship
+	}
+
+	s.federationRelationships[relationship.TrustDomainId] = cloneFederationRelationship(relationship)
+
+	return &datastore.UpdateFederationRelationshipResponse{
+		Relationship: cloneFederationRelationship(relationship),
+	}, nil
+}
+
+// DeleteFederationRelationship deletes the federation relationship with the given trust domain
+func (s *DataStore) DeleteFederationRelationship(ctx context.Context, req *datastore.DeleteFederationRelationshipRequest) (*datastore.DeleteFederationRelationshipResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	relationship, ok := s.federationRelationships[req.TrustDomainId]
+	if !ok {
+		return nil, ErrNoSuchFederationRelationship
+	}
+
+	resp := &datastore.DeleteFederationRelationshipResponse{
+		Relationship: cloneFederationRelationship(relationship),
+	}
+	if _, ok := s.bundles[req.TrustDomainId]; ok && req.DeleteBundle {
+		bundleResp, err := s.deleteBundle(&datastore.DeleteBundleRequest{
+			TrustDomainId: req.TrustDomainId,
+			Mode:          req.BundleMode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.Bundle = bundleResp.Bundle
+	}
+	delete(s.federationRelationships, req.TrustDomainId)
+
+	return resp, nil
+}
+
+// CreateAgentBan stores the given agent ban
+func (s *DataStore) CreateAgentBan(ctx context.Context, req *datastore.CreateAgentBanRequest) (*datastore.CreateAgentBanResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Ban == nil {
+		return nil, errors.New("missing agent ban in request")
+	}
+	key, err := agentBanKey(req.Ban.SpiffeId, req.Ban.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if s.findAgentBan(key) >= 0 {
+		return nil, ErrAgentBanAlreadyExists
+	}
+
+	ban := cloneAgentBan(req.Ban)
+	ban.CreatedAt = time.Now().Unix()
+	s.agentBans = append(s.agentBans, ban)
+
+	return &datastore.CreateAgentBanResponse{
+		Ban: cloneAgentBan(ban),
+	}, nil
+}
+
+// ListAgentBans lists agent bans, optionally filtered by SPIFFE ID or selectors
+func (s *DataStore) ListAgentBans(ctx context.Context, req *datastore.ListAgentBansRequest) (*datastore.ListAgentBansResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filtered := req.BySpiffeId != "" || len(req.BySelectors) > 0
+	resp := new(datastore.ListAgentBansResponse)
+	for _, ban := range s.agentBans {
+		if filtered && !agentBanMatches(ban, req.BySpiffeId, req.BySelectors) {
+			continue
+		}
+		resp.Bans = append(resp.Bans, cloneAgentBan(ban))
+	}
+	return resp, nil
+}
+
+// DeleteAgentBan deletes the agent ban for the given SPIFFE ID or selector
+func (s *DataStore) DeleteAgentBan(ctx context.Context, req *datastore.DeleteAgentBanRequest) (*datastore.DeleteAgentBanResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := agentBanKey(req.SpiffeId, req.Selector)
+	if err != nil {
+		return nil, err
+	}
+	i := s.findAgentBan(key)
+	if i < 0 {
+		return nil, ErrNoSuchAgentBan
+	}
+	ban := s.agentBans[i]
+	s.agentBans = append(s.agentBans[:i], s.agentBans[i+1:]...)
+
+	return &datastore.DeleteAgentBanResponse{
+		Ban: cloneAgentBan(ban),
+	}, nil
+}
+
+func (s *DataStore) findAgentBan(key string) int {
+	for i, ban := range s.agentBans {
+		if banKey, _ := agentBanKey(ban.SpiffeId, ban.Selector); banKey == key {
+			return i
+		}
+	}
+	return -1
+}
+
 func (s *DataStore) CreateAttestedNode(ctx context.Context, req *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -264,15 +529,76 @@ func (s *DataStore) ListAttestedNodes(ctx context.Context, req *datastore.ListAt
 	}
 	sort.Strings(keys)
 
+	// the pagination token is the SPIFFE ID of the last node listed
+	p := req.Pagination
+	if p != nil && p.PageSize > 0 && p.Token != "" {
+		keys = keys[sort.SearchStrings(keys, p.Token):]
+		if len(keys) > 0 && keys[0] == p.Token {
+			keys = keys[1:]
+		}
+	}
+
 	resp := new(datastore.ListAttestedNodesResponse)
 	for _, key := range keys {
+		if p != nil && p.PageSize > 0 && len(resp.Nodes) == int(p.PageSize) {
+			break
+		}
 		attestedNodeEntry := s.attestedNodes[key]
+		if !s.attestedNodeMatches(attestedNodeEntry, req) {
+			continue
+		}
+		node := cloneAttestedNode(attestedNodeEntry)
+		if req.FetchSelectors {
+			node.Selectors = cloneSelectors(s.nodeSelectors[key])
+		}
+		resp.Nodes = append(resp.Nodes, node)
+	}
+
+	if p != nil && p.PageSize > 0 {
+		resp.Pagination = &datastore.Pagination{
+			PageSize: p.PageSize,
+		}
+		if len(resp.Nodes) > 0 {
+			resp.Pagination.Token = resp.Nodes[len(resp.Nodes)-1].SpiffeId
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *DataStore) attestedNodeMatches(node *common.AttestedNode, req *datastore.ListAttestedNodesRequest) bool {
+	if req.ByExpiresBefore != nil && node.CertNotAfter >= req.ByExpiresBefore.Value {
+		return false
+	}
+	if req.ByExpiresAfter != nil && node.CertNotAfter <= req.ByExpiresAfter.Value {
+		return false
+	}
+	if req.ByAttestationType != nil && node.AttestationDataType != req.ByAttestationType.Value {
+		return false
+	}
+	if req.ByLastSeenBefore != nil && node.LastSeenAt != 0 && node.LastSeenAt >= req.ByLastSeenBefore.Value {
+		return false
+	}
+	for _, selector := range req.BySelectors {
+		if !containsSelector(s.nodeSelectors[node.SpiffeId], selector) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *DataStore) CountAttestedNodes(ctx context.Context, req *datastore.CountAttestedNodesRequest) (*datastore.CountAttestedNodesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.CountAttestedNodesResponse)
+	for _, attestedNodeEntry := range s.attestedNodes {
 		if req.ByExpiresBefore != nil {
 			if attestedNodeEntry.CertNotAfter >= req.ByExpiresBefore.Value {
 				continue
 			}
 		}
-		resp.Nodes = append(resp.Nodes, cloneAttestedNode(attestedNodeEntry))
+		resp.Nodes++
 	}
 
 	return resp, nil
@@ -296,6 +622,27 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context, req *datastore.Updat
 	}, nil
 }
 
+func (s *DataStore) UpdateAttestedNodeLastSeen(ctx context.Context, req *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.attestedNodes[req.SpiffeId]
+	if !ok {
+		return nil, ErrNoSuchAttestedNode
+	}
+	node.LastSeenAt = req.LastSeenAt
+	if req.AgentVersion != "" {
+		node.AgentVersion = req.AgentVersion
+	}
+	if req.RemoteAddress != "" {
+		node.RemoteAddress = req.RemoteAddress
+	}
+
+	return &datastore.UpdateAttestedNodeLastSeenResponse{
+		Node: cloneAttestedNode(node),
+	}, nil
+}
+
 func (s *DataStore) DeleteAttestedNode(ctx context.Context, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -311,6 +658,31 @@ func (s *DataStore) DeleteAttestedNode(ctx context.Context, req *datastore.Delet
 	}, nil
 }
 
+func (s *DataStore) PruneAttestedNodes(ctx context.Context, req *datastore.PruneAttestedNodesRequest) (*datastore.PruneAttestedNodesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id, node := range s.attestedNodes {
+		if node.CertNotAfter < req.ExpiresBefore {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if req.Limit > 0 && len(ids) > int(req.Limit) {
+		ids = ids[:req.Limit]
+	}
+
+	resp := new(datastore.PruneAttestedNodesResponse)
+	for _, id := range ids {
+		resp.Nodes = append(resp.Nodes, cloneAttestedNode(s.attestedNodes[id]))
+		delete(s.attestedNodes, id)
+		delete(s.nodeSelectors, id)
+	}
+
+	return resp, nil
+}
+
 func (s *DataStore) SetNodeSelectors(ctx context.Context, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -467,6 +839,15 @@ func (s *DataStore) ListRegistrationEntries(ctx context.Context, req *datastore.
 	}, nil
 }
 
+func (s *DataStore) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &datastore.CountRegistrationEntriesResponse{
+		Entries: int32(len(s.registrationEntries)),
+	}, nil
+}
+
 func indexOf(element string, entries []*common.RegistrationEntry) int {
 	for k, e := range entries {
 		if element == e.EntryId {
@@ -575,6 +956,33 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	}, nil
 }
 
+func (s *DataStore) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	joinToken, ok := s.tokens[req.Token]
+	if !ok {
+		return nil, ErrNoSuchToken
+	}
+
+	maxUses := joinToken.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+	uses := int32(len(joinToken.Uses))
+	switch {
+	case uses >= maxUses:
+		return nil, errors.New("join token has no uses left")
+	case req.Use.Use != uses+1:
+		return nil, fmt.Errorf("join token use %d is not the next use (%d)", req.Use.Use, uses+1)
+	}
+	joinToken.Uses = append(joinToken.Uses, proto.Clone(req.Use).(*datastore.JoinTokenUse))
+
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: cloneJoinToken(joinToken),
+	}, nil
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -621,6 +1029,42 @@ func cloneBundle(bundle *common.Bundle) *common.Bundle {
 	return proto.Clone(bundle).(*common.Bundle)
 }
 
+func cloneFederationRelationship(relationship *common.FederationRelationship) *common.FederationRelationship {
+	return proto.Clone(relationship).(*common.FederationRelationship)
+}
+
+func cloneAgentBan(ban *common.AgentBan) *common.AgentBan {
+	return proto.Clone(ban).(*common.AgentBan)
+}
+
+func agentBanKey(spiffeID string, selector *common.Selector) (string, error) {
+	switch {
+	case spiffeID == "" && selector == nil:
+		return "", errors.New("agent ban must have a SPIFFE ID or a selector")
+	case spiffeID != "" && selector != nil:
+		return "", errors.New("agent ban cannot have both a SPIFFE ID and a selector")
+	case selector != nil:
+		return "selector:" + selector.Type + ":" + selector.Value, nil
+	default:
+		return "spiffe_id:" + spiffeID, nil
+	}
+}
+
+func agentBanMatches(ban *common.AgentBan, spiffeID string, selectors []*common.Selector) bool {
+	if spiffeID != "" && ban.SpiffeId == spiffeID {
+		return true
+	}
+	if ban.Selector == nil {
+		return false
+	}
+	for _, selector := range selectors {
+		if ban.Selector.Type == selector.Type && ban.Selector.Value == selector.Value {
+			return true
+		}
+	}
+	return false
+}
+
 func cloneAttestedNode(attestedNodeEntry *common.AttestedNode) *common.AttestedNode {
 	return proto.Clone(attestedNodeEntry).(*common.AttestedNode)
 }
@@ -661,6 +1105,15 @@ func matchesSelectors(a, b []*common.Selector) bool {
 	return true
 }
 
+func containsSelector(selectors []*common.Selector, selector *common.Selector) bool {
+	for _, s := range selectors {
+		if s.Type == selector.Type && s.Value == selector.Value {
+			return true
+		}
+	}
+	return false
+}
+
 func removeString(list []string, s string) []string {
 	out := make([]string, 0, len(list))
 	for _, entry := range list {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"net/url"
 	"testing"
 	"time"
@@ -31,6 +32,10 @@ type Options struct {
 	Clock       clock.Clock
 	X509SVIDTTL time.Duration
 	JWTSVIDTTL  time.Duration
+
+	// PathPrefixesOID, if set, allows the CA to constrain downstream CAs to
+	// path prefixes.
+	PathPrefixesOID asn1.ObjectIdentifier
 }
 
 type CA struct {
@@ -71,6 +76,8 @@ func New(t *testing.T, trustDomain string, options *Options) *CA {
 		X509SVIDTTL: options.X509SVIDTTL,
 		JWTSVIDTTL:  options.JWTSVIDTTL,
 		Clock:       options.Clock,
+
+		PathPrefixesOID: options.PathPrefixesOID,
 	})
 	serverCA.SetX509CA(x509CA)
 	serverCA.SetJWTKey(&ca.JWTKey{
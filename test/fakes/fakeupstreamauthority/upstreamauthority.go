@@ -30,6 +30,8 @@ type Config struct {
 	TrustDomain                 string
 	UseIntermediate             bool
 	DisallowPublishJWTKey       bool
+	DisallowReportAgents        bool
+	DisallowFederatedBundles    bool
 	MutateMintX509CAResponse    func(*upstreamauthority.MintX509CAResponse)
 	MutatePublishJWTKeyResponse func(*upstreamauthority.PublishJWTKeyResponse)
 }
@@ -48,17 +50,27 @@ type UpstreamAuthority struct {
 	jwtKeysMtx sync.RWMutex
 	jwtKeys    []*common.PublicKey
 
-	streamsMtx           sync.Mutex
-	mintX509CAStreams    map[chan struct{}]struct{}
-	publishJWTKeyStreams map[chan struct{}]struct{}
+	agentsMtx     sync.RWMutex
+	agents        []*common.AttestedNode
+	pendingAgents []*common.AttestedNode
+	agentReports  []*upstreamauthority.ReportAgentsRequest
+
+	federatedBundlesMtx sync.RWMutex
+	federatedBundles    []*common.Bundle
+
+	streamsMtx              sync.Mutex
+	mintX509CAStreams       map[chan struct{}]struct{}
+	publishJWTKeyStreams    map[chan struct{}]struct{}
+	federatedBundlesStreams map[chan struct{}]struct{}
 }
 
 func New(t *testing.T, config Config) *UpstreamAuthority {
 	ua := &UpstreamAuthority{
-		t:                    t,
-		config:               config,
-		mintX509CAStreams:    make(map[chan struct{}]struct{}),
-		publishJWTKeyStreams: make(map[chan struct{}]struct{}),
+		t:                       t,
+		config:                  config,
+		mintX509CAStreams:       make(map[chan struct{}]struct{}),
+		publishJWTKeyStreams:    make(map[chan struct{}]struct{}),
+		federatedBundlesStreams: make(map[chan struct{}]struct{}),
 	}
 	ua.RotateX509CA()
 	return ua
@@ -122,6 +134,48 @@ func (ua *UpstreamAuthority) PublishJWTKey(req *upstreamauthority.PublishJWTKeyR
 	}
 }
 
+func (ua *UpstreamAuthority) ReportAgents(ctx context.Context, req *upstreamauthority.ReportAgentsRequest) (*upstreamauthority.ReportAgentsResponse, error) {
+	if ua.config.DisallowReportAgents {
+		return nil, status.Error(codes.Unimplemented, "disallowed")
+	}
+
+	ua.agentsMtx.Lock()
+	defer ua.agentsMtx.Unlock()
+	ua.agentReports = append(ua.agentReports, req)
+	if !req.Continued {
+		ua.pendingAgents = nil
+	}
+	ua.pendingAgents = append(ua.pendingAgents, req.Agents...)
+	if !req.More {
+		ua.agents = ua.pendingAgents
+		ua.pendingAgents = nil
+	}
+	return &upstreamauthority.ReportAgentsResponse{}, nil
+}
+
+func (ua *UpstreamAuthority) SubscribeToFederatedBundles(req *upstreamauthority.SubscribeToFederatedBundlesRequest, stream upstreamauthority.UpstreamAuthority_SubscribeToFederatedBundlesServer) error {
+	if ua.config.DisallowFederatedBundles {
+		return status.Error(codes.Unimplemented, "disallowed")
+	}
+
+	streamCh := ua.newFederatedBundlesStream()
+	defer ua.removeFederatedBundlesStream(streamCh)
+
+	ctx := stream.Context()
+	for {
+		if err := stream.Send(&upstreamauthority.SubscribeToFederatedBundlesResponse{
+			FederatedBundles: ua.FederatedBundles(),
+		}); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-streamCh:
+		}
+	}
+}
+
 func (ua *UpstreamAuthority) RotateX509CA() {
 	ua.x509CAMtx.Lock()
 	defer ua.x509CAMtx.Unlock()
@@ -177,6 +231,34 @@ func (ua *UpstreamAuthority) AppendJWTKey(jwtKey *common.PublicKey) {
 	ua.TriggerJWTKeysChanged()
 }
 
+// AgentReports returns the ReportAgents requests received by the upstream
+// authority.
+func (ua *UpstreamAuthority) AgentReports() []*upstreamauthority.ReportAgentsRequest {
+	ua.agentsMtx.RLock()
+	defer ua.agentsMtx.RUnlock()
+	return ua.agentReports
+}
+
+// ReportedAgents returns the agents last reported to the upstream authority.
+func (ua *UpstreamAuthority) ReportedAgents() []*common.AttestedNode {
+	ua.agentsMtx.RLock()
+	defer ua.agentsMtx.RUnlock()
+	return ua.agents
+}
+
+func (ua *UpstreamAuthority) FederatedBundles() []*common.Bundle {
+	ua.federatedBundlesMtx.RLock()
+	defer ua.federatedBundlesMtx.RUnlock()
+	return ua.federatedBundles
+}
+
+func (ua *UpstreamAuthority) SetFederatedBundles(federatedBundles []*common.Bundle) {
+	ua.federatedBundlesMtx.Lock()
+	defer ua.federatedBundlesMtx.Unlock()
+	ua.federatedBundles = federatedBundles
+	ua.TriggerFederatedBundlesChanged()
+}
+
 func (ua *UpstreamAuthority) TriggerX509RootsChanged() {
 	ua.streamsMtx.Lock()
 	defer ua.streamsMtx.Unlock()
@@ -199,6 +281,17 @@ func (ua *UpstreamAuthority) TriggerJWTKeysChanged() {
 	}
 }
 
+func (ua *UpstreamAuthority) TriggerFederatedBundlesChanged() {
+	ua.streamsMtx.Lock()
+	defer ua.streamsMtx.Unlock()
+	for streamCh := range ua.federatedBundlesStreams {
+		select {
+		case streamCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
 func (ua *UpstreamAuthority) newMintX509CAStream() chan struct{} {
 	streamCh := make(chan struct{}, 1)
 	ua.streamsMtx.Lock()
@@ -249,6 +342,20 @@ func (ua *UpstreamAuthority) removePublishJWTKeyStream(streamCh chan struct{}) {
 	ua.streamsMtx.Unlock()
 }
 
+func (ua *UpstreamAuthority) newFederatedBundlesStream() chan struct{} {
+	streamCh := make(chan struct{}, 1)
+	ua.streamsMtx.Lock()
+	ua.federatedBundlesStreams[streamCh] = struct{}{}
+	ua.streamsMtx.Unlock()
+	return streamCh
+}
+
+func (ua *UpstreamAuthority) removeFederatedBundlesStream(streamCh chan struct{}) {
+	ua.streamsMtx.Lock()
+	delete(ua.federatedBundlesStreams, streamCh)
+	ua.streamsMtx.Unlock()
+}
+
 func (ua *UpstreamAuthority) sendPublishJWTKeyStream(stream upstreamauthority.UpstreamAuthority_PublishJWTKeyServer, resp *upstreamauthority.PublishJWTKeyResponse) error {
 	if ua.config.MutatePublishJWTKeyResponse != nil {
 		ua.config.MutatePublishJWTKeyResponse(resp)
@@ -9,6 +9,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	client "github.com/spiffe/spire/pkg/agent/client"
 	node "github.com/spiffe/spire/proto/spire/api/node"
+	common "github.com/spiffe/spire/proto/spire/common"
 	reflect "reflect"
 )
 
@@ -35,6 +36,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// FetchDelegatedJWTKey mocks base method
+func (m *MockClient) FetchDelegatedJWTKey(arg0 context.Context, arg1 []byte) (*common.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDelegatedJWTKey", arg0, arg1)
+	ret0, _ := ret[0].(*common.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDelegatedJWTKey indicates an expected call of FetchDelegatedJWTKey
+func (mr *MockClientMockRecorder) FetchDelegatedJWTKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDelegatedJWTKey", reflect.TypeOf((*MockClient)(nil).FetchDelegatedJWTKey), arg0, arg1)
+}
+
 // FetchJWTSVID mocks base method
 func (m *MockClient) FetchJWTSVID(arg0 context.Context, arg1 *node.JSR) (*client.JWTSVID, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +81,21 @@ func (mr *MockClientMockRecorder) FetchUpdates(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUpdates", reflect.TypeOf((*MockClient)(nil).FetchUpdates), arg0, arg1, arg2)
 }
 
+// Reattest mocks base method
+func (m *MockClient) Reattest(arg0 context.Context, arg1 client.ReattestFunc) ([]*common.Selector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reattest", arg0, arg1)
+	ret0, _ := ret[0].([]*common.Selector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reattest indicates an expected call of Reattest
+func (mr *MockClientMockRecorder) Reattest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reattest", reflect.TypeOf((*MockClient)(nil).Reattest), arg0, arg1)
+}
+
 // Release mocks base method
 func (m *MockClient) Release() {
 	m.ctrl.T.Helper()
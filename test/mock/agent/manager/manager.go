@@ -9,8 +9,10 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	go_observer "github.com/imkira/go-observer"
 	client "github.com/spiffe/spire/pkg/agent/client"
+	manager "github.com/spiffe/spire/pkg/agent/manager"
 	cache "github.com/spiffe/spire/pkg/agent/manager/cache"
 	svid "github.com/spiffe/spire/pkg/agent/svid"
+	bundleutil "github.com/spiffe/spire/pkg/common/bundleutil"
 	common "github.com/spiffe/spire/proto/spire/common"
 	reflect "reflect"
 	sync "sync"
@@ -39,6 +41,20 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// AllIdentities mocks base method
+func (m *MockManager) AllIdentities() []cache.Identity {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllIdentities")
+	ret0, _ := ret[0].([]cache.Identity)
+	return ret0
+}
+
+// AllIdentities indicates an expected call of AllIdentities
+func (mr *MockManagerMockRecorder) AllIdentities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllIdentities", reflect.TypeOf((*MockManager)(nil).AllIdentities))
+}
+
 // FetchJWTSVID mocks base method
 func (m *MockManager) FetchJWTSVID(arg0 context.Context, arg1 string, arg2 []string) (*client.JWTSVID, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +84,20 @@ func (mr *MockManagerMockRecorder) FetchWorkloadUpdate(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWorkloadUpdate", reflect.TypeOf((*MockManager)(nil).FetchWorkloadUpdate), arg0)
 }
 
+// GetBundle mocks base method
+func (m *MockManager) GetBundle() *bundleutil.Bundle {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundle")
+	ret0, _ := ret[0].(*bundleutil.Bundle)
+	return ret0
+}
+
+// GetBundle indicates an expected call of GetBundle
+func (mr *MockManagerMockRecorder) GetBundle() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundle", reflect.TypeOf((*MockManager)(nil).GetBundle))
+}
+
 // GetCurrentCredentials mocks base method
 func (m *MockManager) GetCurrentCredentials() svid.State {
 	m.ctrl.T.Helper()
@@ -124,6 +154,20 @@ func (mr *MockManagerMockRecorder) MatchingIdentities(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchingIdentities", reflect.TypeOf((*MockManager)(nil).MatchingIdentities), arg0)
 }
 
+// RotateSVID mocks base method
+func (m *MockManager) RotateSVID(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSVID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSVID indicates an expected call of RotateSVID
+func (mr *MockManagerMockRecorder) RotateSVID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSVID", reflect.TypeOf((*MockManager)(nil).RotateSVID), arg0)
+}
+
 // Run mocks base method
 func (m *MockManager) Run(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -191,3 +235,31 @@ func (mr *MockManagerMockRecorder) SubscribeToSVIDChanges() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeToSVIDChanges", reflect.TypeOf((*MockManager)(nil).SubscribeToSVIDChanges))
 }
+
+// SyncStatus mocks base method
+func (m *MockManager) SyncStatus() manager.SyncStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus")
+	ret0, _ := ret[0].(manager.SyncStatus)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus
+func (mr *MockManagerMockRecorder) SyncStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockManager)(nil).SyncStatus))
+}
+
+// Synchronize mocks base method
+func (m *MockManager) Synchronize(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Synchronize", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Synchronize indicates an expected call of Synchronize
+func (mr *MockManagerMockRecorder) Synchronize(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Synchronize", reflect.TypeOf((*MockManager)(nil).Synchronize), arg0)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spiffe/spire/proto/spire/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer)
+// Source: github.com/spiffe/spire/proto/spire/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer,Node_ReattestClient)
 
 // Package mock_node is a generated GoMock package.
 package mock_node
@@ -76,6 +76,46 @@ func (mr *MockNodeClientMockRecorder) FetchBundle(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockNodeClient)(nil).FetchBundle), varargs...)
 }
 
+// FetchDelegatedJWTKey mocks base method
+func (m *MockNodeClient) FetchDelegatedJWTKey(arg0 context.Context, arg1 *node.FetchDelegatedJWTKeyRequest, arg2 ...grpc.CallOption) (*node.FetchDelegatedJWTKeyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchDelegatedJWTKey", varargs...)
+	ret0, _ := ret[0].(*node.FetchDelegatedJWTKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDelegatedJWTKey indicates an expected call of FetchDelegatedJWTKey
+func (mr *MockNodeClientMockRecorder) FetchDelegatedJWTKey(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDelegatedJWTKey", reflect.TypeOf((*MockNodeClient)(nil).FetchDelegatedJWTKey), varargs...)
+}
+
+// FetchFederatedBundles mocks base method
+func (m *MockNodeClient) FetchFederatedBundles(arg0 context.Context, arg1 *node.FetchFederatedBundlesRequest, arg2 ...grpc.CallOption) (*node.FetchFederatedBundlesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchFederatedBundles", varargs...)
+	ret0, _ := ret[0].(*node.FetchFederatedBundlesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchFederatedBundles indicates an expected call of FetchFederatedBundles
+func (mr *MockNodeClientMockRecorder) FetchFederatedBundles(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundles", reflect.TypeOf((*MockNodeClient)(nil).FetchFederatedBundles), varargs...)
+}
+
 // FetchJWTSVID mocks base method
 func (m *MockNodeClient) FetchJWTSVID(arg0 context.Context, arg1 *node.FetchJWTSVIDRequest, arg2 ...grpc.CallOption) (*node.FetchJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +196,46 @@ func (mr *MockNodeClientMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeClient)(nil).PushJWTKeyUpstream), varargs...)
 }
 
+// Reattest mocks base method
+func (m *MockNodeClient) Reattest(arg0 context.Context, arg1 ...grpc.CallOption) (node.Node_ReattestClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Reattest", varargs...)
+	ret0, _ := ret[0].(node.Node_ReattestClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reattest indicates an expected call of Reattest
+func (mr *MockNodeClientMockRecorder) Reattest(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reattest", reflect.TypeOf((*MockNodeClient)(nil).Reattest), varargs...)
+}
+
+// ReportAgentsUpstream mocks base method
+func (m *MockNodeClient) ReportAgentsUpstream(arg0 context.Context, arg1 *node.ReportAgentsUpstreamRequest, arg2 ...grpc.CallOption) (*node.ReportAgentsUpstreamResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReportAgentsUpstream", varargs...)
+	ret0, _ := ret[0].(*node.ReportAgentsUpstreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportAgentsUpstream indicates an expected call of ReportAgentsUpstream
+func (mr *MockNodeClientMockRecorder) ReportAgentsUpstream(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportAgentsUpstream", reflect.TypeOf((*MockNodeClient)(nil).ReportAgentsUpstream), varargs...)
+}
+
 // MockNode_AttestClient is a mock of Node_AttestClient interface
 type MockNode_AttestClient struct {
 	ctrl     *gomock.Controller
@@ -616,6 +696,36 @@ func (mr *MockNodeServerMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockNodeServer)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchDelegatedJWTKey mocks base method
+func (m *MockNodeServer) FetchDelegatedJWTKey(arg0 context.Context, arg1 *node.FetchDelegatedJWTKeyRequest) (*node.FetchDelegatedJWTKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDelegatedJWTKey", arg0, arg1)
+	ret0, _ := ret[0].(*node.FetchDelegatedJWTKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDelegatedJWTKey indicates an expected call of FetchDelegatedJWTKey
+func (mr *MockNodeServerMockRecorder) FetchDelegatedJWTKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDelegatedJWTKey", reflect.TypeOf((*MockNodeServer)(nil).FetchDelegatedJWTKey), arg0, arg1)
+}
+
+// FetchFederatedBundles mocks base method
+func (m *MockNodeServer) FetchFederatedBundles(arg0 context.Context, arg1 *node.FetchFederatedBundlesRequest) (*node.FetchFederatedBundlesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchFederatedBundles", arg0, arg1)
+	ret0, _ := ret[0].(*node.FetchFederatedBundlesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchFederatedBundles indicates an expected call of FetchFederatedBundles
+func (mr *MockNodeServerMockRecorder) FetchFederatedBundles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundles", reflect.TypeOf((*MockNodeServer)(nil).FetchFederatedBundles), arg0, arg1)
+}
+
 // FetchJWTSVID mocks base method
 func (m *MockNodeServer) FetchJWTSVID(arg0 context.Context, arg1 *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -675,6 +785,35 @@ func (mr *MockNodeServerMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeServer)(nil).PushJWTKeyUpstream), arg0, arg1)
 }
 
+// Reattest mocks base method
+func (m *MockNodeServer) Reattest(arg0 node.Node_ReattestServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reattest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reattest indicates an expected call of Reattest
+func (mr *MockNodeServerMockRecorder) Reattest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reattest", reflect.TypeOf((*MockNodeServer)(nil).Reattest), arg0)
+}
+
+// ReportAgentsUpstream mocks base method
+func (m *MockNodeServer) ReportAgentsUpstream(arg0 context.Context, arg1 *node.ReportAgentsUpstreamRequest) (*node.ReportAgentsUpstreamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportAgentsUpstream", arg0, arg1)
+	ret0, _ := ret[0].(*node.ReportAgentsUpstreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportAgentsUpstream indicates an expected call of ReportAgentsUpstream
+func (mr *MockNodeServerMockRecorder) ReportAgentsUpstream(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportAgentsUpstream", reflect.TypeOf((*MockNodeServer)(nil).ReportAgentsUpstream), arg0, arg1)
+}
+
 // MockNode_FetchX509SVIDServer is a mock of Node_FetchX509SVIDServer interface
 type MockNode_FetchX509SVIDServer struct {
 	ctrl     *gomock.Controller
@@ -808,3 +947,140 @@ func (mr *MockNode_FetchX509SVIDServerMockRecorder) SetTrailer(arg0 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockNode_FetchX509SVIDServer)(nil).SetTrailer), arg0)
 }
+
+// MockNode_ReattestClient is a mock of Node_ReattestClient interface
+type MockNode_ReattestClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNode_ReattestClientMockRecorder
+}
+
+// MockNode_ReattestClientMockRecorder is the mock recorder for MockNode_ReattestClient
+type MockNode_ReattestClientMockRecorder struct {
+	mock *MockNode_ReattestClient
+}
+
+// NewMockNode_ReattestClient creates a new mock instance
+func NewMockNode_ReattestClient(ctrl *gomock.Controller) *MockNode_ReattestClient {
+	mock := &MockNode_ReattestClient{ctrl: ctrl}
+	mock.recorder = &MockNode_ReattestClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNode_ReattestClient) EXPECT() *MockNode_ReattestClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method
+func (m *MockNode_ReattestClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockNode_ReattestClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockNode_ReattestClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockNode_ReattestClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockNode_ReattestClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockNode_ReattestClient)(nil).Context))
+}
+
+// Header mocks base method
+func (m *MockNode_ReattestClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockNode_ReattestClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockNode_ReattestClient)(nil).Header))
+}
+
+// Recv mocks base method
+func (m *MockNode_ReattestClient) Recv() (*node.ReattestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*node.ReattestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockNode_ReattestClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockNode_ReattestClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method
+func (m *MockNode_ReattestClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockNode_ReattestClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockNode_ReattestClient)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockNode_ReattestClient) Send(arg0 *node.ReattestRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockNode_ReattestClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNode_ReattestClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockNode_ReattestClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockNode_ReattestClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockNode_ReattestClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method
+func (m *MockNode_ReattestClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockNode_ReattestClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockNode_ReattestClient)(nil).Trailer))
+}
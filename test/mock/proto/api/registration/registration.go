@@ -36,6 +36,26 @@ func (m *MockRegistrationClient) EXPECT() *MockRegistrationClientMockRecorder {
 	return m.recorder
 }
 
+// BanAgent mocks base method
+func (m *MockRegistrationClient) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest, arg2 ...grpc.CallOption) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanAgent", varargs...)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationClientMockRecorder) BanAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationClient)(nil).BanAgent), varargs...)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationClient) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry, arg2 ...grpc.CallOption) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +116,26 @@ func (mr *MockRegistrationClientMockRecorder) CreateFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).CreateFederatedBundle), varargs...)
 }
 
+// CreateFederationRelationship mocks base method
+func (m *MockRegistrationClient) CreateFederationRelationship(arg0 context.Context, arg1 *common.FederationRelationship, arg2 ...grpc.CallOption) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateFederationRelationship", varargs...)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFederationRelationship indicates an expected call of CreateFederationRelationship
+func (mr *MockRegistrationClientMockRecorder) CreateFederationRelationship(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFederationRelationship", reflect.TypeOf((*MockRegistrationClient)(nil).CreateFederationRelationship), varargs...)
+}
+
 // CreateJoinToken mocks base method
 func (m *MockRegistrationClient) CreateJoinToken(arg0 context.Context, arg1 *registration.JoinToken, arg2 ...grpc.CallOption) (*registration.JoinToken, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +196,26 @@ func (mr *MockRegistrationClientMockRecorder) DeleteFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).DeleteFederatedBundle), varargs...)
 }
 
+// DeleteFederationRelationship mocks base method
+func (m *MockRegistrationClient) DeleteFederationRelationship(arg0 context.Context, arg1 *registration.DeleteFederationRelationshipRequest, arg2 ...grpc.CallOption) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteFederationRelationship", varargs...)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFederationRelationship indicates an expected call of DeleteFederationRelationship
+func (mr *MockRegistrationClientMockRecorder) DeleteFederationRelationship(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederationRelationship", reflect.TypeOf((*MockRegistrationClient)(nil).DeleteFederationRelationship), varargs...)
+}
+
 // EvictAgent mocks base method
 func (m *MockRegistrationClient) EvictAgent(arg0 context.Context, arg1 *registration.EvictAgentRequest, arg2 ...grpc.CallOption) (*registration.EvictAgentResponse, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +236,26 @@ func (mr *MockRegistrationClientMockRecorder) EvictAgent(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictAgent", reflect.TypeOf((*MockRegistrationClient)(nil).EvictAgent), varargs...)
 }
 
+// ExplainEntries mocks base method
+func (m *MockRegistrationClient) ExplainEntries(arg0 context.Context, arg1 *registration.ExplainEntriesRequest, arg2 ...grpc.CallOption) (*registration.ExplainEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExplainEntries", varargs...)
+	ret0, _ := ret[0].(*registration.ExplainEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainEntries indicates an expected call of ExplainEntries
+func (mr *MockRegistrationClientMockRecorder) ExplainEntries(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainEntries", reflect.TypeOf((*MockRegistrationClient)(nil).ExplainEntries), varargs...)
+}
+
 // FetchBundle mocks base method
 func (m *MockRegistrationClient) FetchBundle(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.Bundle, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +356,26 @@ func (mr *MockRegistrationClientMockRecorder) GetNodeSelectors(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeSelectors", reflect.TypeOf((*MockRegistrationClient)(nil).GetNodeSelectors), varargs...)
 }
 
+// ListAgentBans mocks base method
+func (m *MockRegistrationClient) ListAgentBans(arg0 context.Context, arg1 *registration.ListAgentBansRequest, arg2 ...grpc.CallOption) (*registration.ListAgentBansResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListAgentBans", varargs...)
+	ret0, _ := ret[0].(*registration.ListAgentBansResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAgentBans indicates an expected call of ListAgentBans
+func (mr *MockRegistrationClientMockRecorder) ListAgentBans(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentBans", reflect.TypeOf((*MockRegistrationClient)(nil).ListAgentBans), varargs...)
+}
+
 // ListAgents mocks base method
 func (m *MockRegistrationClient) ListAgents(arg0 context.Context, arg1 *registration.ListAgentsRequest, arg2 ...grpc.CallOption) (*registration.ListAgentsResponse, error) {
 	m.ctrl.T.Helper()
@@ -396,6 +496,46 @@ func (mr *MockRegistrationClientMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationClient)(nil).ListBySpiffeID), varargs...)
 }
 
+// ListDownstreamAgents mocks base method
+func (m *MockRegistrationClient) ListDownstreamAgents(arg0 context.Context, arg1 *registration.ListDownstreamAgentsRequest, arg2 ...grpc.CallOption) (*registration.ListDownstreamAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListDownstreamAgents", varargs...)
+	ret0, _ := ret[0].(*registration.ListDownstreamAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDownstreamAgents indicates an expected call of ListDownstreamAgents
+func (mr *MockRegistrationClientMockRecorder) ListDownstreamAgents(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDownstreamAgents", reflect.TypeOf((*MockRegistrationClient)(nil).ListDownstreamAgents), varargs...)
+}
+
+// ListFederatedBundleSyncStatus mocks base method
+func (m *MockRegistrationClient) ListFederatedBundleSyncStatus(arg0 context.Context, arg1 *registration.ListFederatedBundleSyncStatusRequest, arg2 ...grpc.CallOption) (*registration.ListFederatedBundleSyncStatusResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFederatedBundleSyncStatus", varargs...)
+	ret0, _ := ret[0].(*registration.ListFederatedBundleSyncStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFederatedBundleSyncStatus indicates an expected call of ListFederatedBundleSyncStatus
+func (mr *MockRegistrationClientMockRecorder) ListFederatedBundleSyncStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundleSyncStatus", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundleSyncStatus), varargs...)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationClient) ListFederatedBundles(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (registration.Registration_ListFederatedBundlesClient, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +556,26 @@ func (mr *MockRegistrationClientMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundles), varargs...)
 }
 
+// ListFederationRelationships mocks base method
+func (m *MockRegistrationClient) ListFederationRelationships(arg0 context.Context, arg1 *registration.ListFederationRelationshipsRequest, arg2 ...grpc.CallOption) (*registration.ListFederationRelationshipsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListFederationRelationships", varargs...)
+	ret0, _ := ret[0].(*registration.ListFederationRelationshipsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFederationRelationships indicates an expected call of ListFederationRelationships
+func (mr *MockRegistrationClientMockRecorder) ListFederationRelationships(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederationRelationships", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederationRelationships), varargs...)
+}
+
 // MintJWTSVID mocks base method
 func (m *MockRegistrationClient) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest, arg2 ...grpc.CallOption) (*registration.MintJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -456,6 +616,66 @@ func (mr *MockRegistrationClientMockRecorder) MintX509SVID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationClient)(nil).MintX509SVID), varargs...)
 }
 
+// RevokeJWTSVIDs mocks base method
+func (m *MockRegistrationClient) RevokeJWTSVIDs(arg0 context.Context, arg1 *registration.RevokeJWTSVIDsRequest, arg2 ...grpc.CallOption) (*registration.Bundle, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeJWTSVIDs", varargs...)
+	ret0, _ := ret[0].(*registration.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeJWTSVIDs indicates an expected call of RevokeJWTSVIDs
+func (mr *MockRegistrationClientMockRecorder) RevokeJWTSVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeJWTSVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).RevokeJWTSVIDs), varargs...)
+}
+
+// UnbanAgent mocks base method
+func (m *MockRegistrationClient) UnbanAgent(arg0 context.Context, arg1 *registration.UnbanAgentRequest, arg2 ...grpc.CallOption) (*registration.UnbanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanAgent", varargs...)
+	ret0, _ := ret[0].(*registration.UnbanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanAgent indicates an expected call of UnbanAgent
+func (mr *MockRegistrationClientMockRecorder) UnbanAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanAgent", reflect.TypeOf((*MockRegistrationClient)(nil).UnbanAgent), varargs...)
+}
+
+// UnrevokeJWTSVIDs mocks base method
+func (m *MockRegistrationClient) UnrevokeJWTSVIDs(arg0 context.Context, arg1 *registration.UnrevokeJWTSVIDsRequest, arg2 ...grpc.CallOption) (*registration.Bundle, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnrevokeJWTSVIDs", varargs...)
+	ret0, _ := ret[0].(*registration.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrevokeJWTSVIDs indicates an expected call of UnrevokeJWTSVIDs
+func (mr *MockRegistrationClientMockRecorder) UnrevokeJWTSVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrevokeJWTSVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).UnrevokeJWTSVIDs), varargs...)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationClient) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
@@ -496,6 +716,26 @@ func (mr *MockRegistrationClientMockRecorder) UpdateFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).UpdateFederatedBundle), varargs...)
 }
 
+// UpdateFederationRelationship mocks base method
+func (m *MockRegistrationClient) UpdateFederationRelationship(arg0 context.Context, arg1 *common.FederationRelationship, arg2 ...grpc.CallOption) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFederationRelationship", varargs...)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFederationRelationship indicates an expected call of UpdateFederationRelationship
+func (mr *MockRegistrationClientMockRecorder) UpdateFederationRelationship(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederationRelationship", reflect.TypeOf((*MockRegistrationClient)(nil).UpdateFederationRelationship), varargs...)
+}
+
 // MockRegistrationServer is a mock of RegistrationServer interface
 type MockRegistrationServer struct {
 	ctrl     *gomock.Controller
@@ -519,6 +759,21 @@ func (m *MockRegistrationServer) EXPECT() *MockRegistrationServerMockRecorder {
 	return m.recorder
 }
 
+// BanAgent mocks base method
+func (m *MockRegistrationServer) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationServerMockRecorder) BanAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationServer)(nil).BanAgent), arg0, arg1)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationServer) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -564,6 +819,21 @@ func (mr *MockRegistrationServerMockRecorder) CreateFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).CreateFederatedBundle), arg0, arg1)
 }
 
+// CreateFederationRelationship mocks base method
+func (m *MockRegistrationServer) CreateFederationRelationship(arg0 context.Context, arg1 *common.FederationRelationship) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFederationRelationship", arg0, arg1)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFederationRelationship indicates an expected call of CreateFederationRelationship
+func (mr *MockRegistrationServerMockRecorder) CreateFederationRelationship(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFederationRelationship", reflect.TypeOf((*MockRegistrationServer)(nil).CreateFederationRelationship), arg0, arg1)
+}
+
 // CreateJoinToken mocks base method
 func (m *MockRegistrationServer) CreateJoinToken(arg0 context.Context, arg1 *registration.JoinToken) (*registration.JoinToken, error) {
 	m.ctrl.T.Helper()
@@ -609,6 +879,21 @@ func (mr *MockRegistrationServerMockRecorder) DeleteFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).DeleteFederatedBundle), arg0, arg1)
 }
 
+// DeleteFederationRelationship mocks base method
+func (m *MockRegistrationServer) DeleteFederationRelationship(arg0 context.Context, arg1 *registration.DeleteFederationRelationshipRequest) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFederationRelationship", arg0, arg1)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFederationRelationship indicates an expected call of DeleteFederationRelationship
+func (mr *MockRegistrationServerMockRecorder) DeleteFederationRelationship(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederationRelationship", reflect.TypeOf((*MockRegistrationServer)(nil).DeleteFederationRelationship), arg0, arg1)
+}
+
 // EvictAgent mocks base method
 func (m *MockRegistrationServer) EvictAgent(arg0 context.Context, arg1 *registration.EvictAgentRequest) (*registration.EvictAgentResponse, error) {
 	m.ctrl.T.Helper()
@@ -624,6 +909,21 @@ func (mr *MockRegistrationServerMockRecorder) EvictAgent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictAgent", reflect.TypeOf((*MockRegistrationServer)(nil).EvictAgent), arg0, arg1)
 }
 
+// ExplainEntries mocks base method
+func (m *MockRegistrationServer) ExplainEntries(arg0 context.Context, arg1 *registration.ExplainEntriesRequest) (*registration.ExplainEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainEntries", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ExplainEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainEntries indicates an expected call of ExplainEntries
+func (mr *MockRegistrationServerMockRecorder) ExplainEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainEntries", reflect.TypeOf((*MockRegistrationServer)(nil).ExplainEntries), arg0, arg1)
+}
+
 // FetchBundle mocks base method
 func (m *MockRegistrationServer) FetchBundle(arg0 context.Context, arg1 *common.Empty) (*registration.Bundle, error) {
 	m.ctrl.T.Helper()
@@ -699,6 +999,21 @@ func (mr *MockRegistrationServerMockRecorder) GetNodeSelectors(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeSelectors", reflect.TypeOf((*MockRegistrationServer)(nil).GetNodeSelectors), arg0, arg1)
 }
 
+// ListAgentBans mocks base method
+func (m *MockRegistrationServer) ListAgentBans(arg0 context.Context, arg1 *registration.ListAgentBansRequest) (*registration.ListAgentBansResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAgentBans", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListAgentBansResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAgentBans indicates an expected call of ListAgentBans
+func (mr *MockRegistrationServerMockRecorder) ListAgentBans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentBans", reflect.TypeOf((*MockRegistrationServer)(nil).ListAgentBans), arg0, arg1)
+}
+
 // ListAgents mocks base method
 func (m *MockRegistrationServer) ListAgents(arg0 context.Context, arg1 *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
 	m.ctrl.T.Helper()
@@ -789,6 +1104,36 @@ func (mr *MockRegistrationServerMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationServer)(nil).ListBySpiffeID), arg0, arg1)
 }
 
+// ListDownstreamAgents mocks base method
+func (m *MockRegistrationServer) ListDownstreamAgents(arg0 context.Context, arg1 *registration.ListDownstreamAgentsRequest) (*registration.ListDownstreamAgentsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDownstreamAgents", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListDownstreamAgentsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDownstreamAgents indicates an expected call of ListDownstreamAgents
+func (mr *MockRegistrationServerMockRecorder) ListDownstreamAgents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDownstreamAgents", reflect.TypeOf((*MockRegistrationServer)(nil).ListDownstreamAgents), arg0, arg1)
+}
+
+// ListFederatedBundleSyncStatus mocks base method
+func (m *MockRegistrationServer) ListFederatedBundleSyncStatus(arg0 context.Context, arg1 *registration.ListFederatedBundleSyncStatusRequest) (*registration.ListFederatedBundleSyncStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFederatedBundleSyncStatus", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListFederatedBundleSyncStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFederatedBundleSyncStatus indicates an expected call of ListFederatedBundleSyncStatus
+func (mr *MockRegistrationServerMockRecorder) ListFederatedBundleSyncStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundleSyncStatus", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundleSyncStatus), arg0, arg1)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationServer) ListFederatedBundles(arg0 *common.Empty, arg1 registration.Registration_ListFederatedBundlesServer) error {
 	m.ctrl.T.Helper()
@@ -803,6 +1148,21 @@ func (mr *MockRegistrationServerMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundles), arg0, arg1)
 }
 
+// ListFederationRelationships mocks base method
+func (m *MockRegistrationServer) ListFederationRelationships(arg0 context.Context, arg1 *registration.ListFederationRelationshipsRequest) (*registration.ListFederationRelationshipsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFederationRelationships", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListFederationRelationshipsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFederationRelationships indicates an expected call of ListFederationRelationships
+func (mr *MockRegistrationServerMockRecorder) ListFederationRelationships(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederationRelationships", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederationRelationships), arg0, arg1)
+}
+
 // MintJWTSVID mocks base method
 func (m *MockRegistrationServer) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest) (*registration.MintJWTSVIDResponse, error) {
 	m.ctrl.T.Helper()
@@ -833,6 +1193,51 @@ func (mr *MockRegistrationServerMockRecorder) MintX509SVID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationServer)(nil).MintX509SVID), arg0, arg1)
 }
 
+// RevokeJWTSVIDs mocks base method
+func (m *MockRegistrationServer) RevokeJWTSVIDs(arg0 context.Context, arg1 *registration.RevokeJWTSVIDsRequest) (*registration.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeJWTSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*registration.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeJWTSVIDs indicates an expected call of RevokeJWTSVIDs
+func (mr *MockRegistrationServerMockRecorder) RevokeJWTSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeJWTSVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).RevokeJWTSVIDs), arg0, arg1)
+}
+
+// UnbanAgent mocks base method
+func (m *MockRegistrationServer) UnbanAgent(arg0 context.Context, arg1 *registration.UnbanAgentRequest) (*registration.UnbanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.UnbanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanAgent indicates an expected call of UnbanAgent
+func (mr *MockRegistrationServerMockRecorder) UnbanAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanAgent", reflect.TypeOf((*MockRegistrationServer)(nil).UnbanAgent), arg0, arg1)
+}
+
+// UnrevokeJWTSVIDs mocks base method
+func (m *MockRegistrationServer) UnrevokeJWTSVIDs(arg0 context.Context, arg1 *registration.UnrevokeJWTSVIDsRequest) (*registration.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnrevokeJWTSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*registration.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnrevokeJWTSVIDs indicates an expected call of UnrevokeJWTSVIDs
+func (mr *MockRegistrationServerMockRecorder) UnrevokeJWTSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnrevokeJWTSVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).UnrevokeJWTSVIDs), arg0, arg1)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationServer) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
@@ -862,3 +1267,18 @@ func (mr *MockRegistrationServerMockRecorder) UpdateFederatedBundle(arg0, arg1 i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).UpdateFederatedBundle), arg0, arg1)
 }
+
+// UpdateFederationRelationship mocks base method
+func (m *MockRegistrationServer) UpdateFederationRelationship(arg0 context.Context, arg1 *common.FederationRelationship) (*common.FederationRelationship, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFederationRelationship", arg0, arg1)
+	ret0, _ := ret[0].(*common.FederationRelationship)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFederationRelationship indicates an expected call of UpdateFederationRelationship
+func (mr *MockRegistrationServerMockRecorder) UpdateFederationRelationship(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederationRelationship", reflect.TypeOf((*MockRegistrationServer)(nil).UpdateFederationRelationship), arg0, arg1)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +23,7 @@ func (fn pluginOptionFunc) setOption(opts *pluginConfig) {
 type pluginConfig struct {
 	logger       logrus.FieldLogger
 	hostServices []catalog.HostServiceServer
+	configure    *spi.ConfigureRequest
 }
 
 func Logger(logger logrus.FieldLogger) PluginOption {
@@ -36,6 +38,17 @@ func HostService(hostService catalog.HostServiceServer) PluginOption {
 	})
 }
 
+// Configuration configures the plugin after it is loaded with the given trust
+// domain and plugin data, failing the test if the plugin rejects them.
+func Configuration(trustDomain, data string) PluginOption {
+	return pluginOptionFunc(func(config *pluginConfig) {
+		config.configure = &spi.ConfigureRequest{
+			GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: trustDomain},
+			Configuration: data,
+		}
+	})
+}
+
 func LoadPlugin(tb testing.TB, plugin catalog.Plugin, x interface{}, opts ...PluginOption) (done func()) {
 	config := &pluginConfig{}
 	for _, opt := range opts {
@@ -53,5 +66,12 @@ func LoadPlugin(tb testing.TB, plugin catalog.Plugin, x interface{}, opts ...Plu
 		p.Close()
 		require.NoError(tb, err, "unable to satisfy plugin client")
 	}
+
+	if config.configure != nil {
+		if err := p.Configure(context.Background(), config.configure); err != nil {
+			p.Close()
+			require.NoError(tb, err, "unable to configure plugin")
+		}
+	}
 	return p.Close
 }
@@ -23,6 +23,7 @@ var codeTmpl = `
 {{ $hostServiceClientImpl := mkname $c.Prefix "HostServiceClient" }}
 {{ $adaptHostServiceClientFunc := mkexpname "Adapt" $c.Prefix "HostServiceClient" }}
 {{ $hostServiceClientAdapterImpl := mkname $c.Prefix "HostServiceClientAdapter" }}
+{{ $requireHostServiceFunc := mkexpname "Require" $c.Prefix "HostService" }}
 
 // Provides interfaces and adapters for the {{ $c.Name }} service
 //
@@ -210,6 +211,18 @@ func (c *{{ $hostServiceClientImpl }}) InitHostServiceClient(conn *grpc.ClientCo
 	*c.client = {{ $adaptHostServiceClientFunc }}({{ $c.PkgQual }}New{{ $c.Name }}Client(conn))
 }
 
+// {{ $requireHostServiceFunc }} obtains a client for the {{ $c.Name }} host service from the broker. It fails if the host does not provide the host service.
+func {{ $requireHostServiceFunc }}(broker catalog.HostServiceBroker, client *{{ $c.Name }}) error {
+	has, err := broker.GetHostService({{ $hostServiceClientFunc }}(client))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("required %s host service not available", {{ $typeConst }})
+	}
+	return nil
+}
+
 func {{ $adaptHostServiceClientFunc }}(client {{ $c.ClientType }}) {{ $c.Name }} {
 	return {{ $hostServiceClientAdapterImpl }}{client: client}
 }